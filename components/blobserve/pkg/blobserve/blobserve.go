@@ -125,8 +125,18 @@ func (reg *Server) Serve() error {
 		h = http.TimeoutHandler(h, time.Duration(reg.Config.Timeout), "timeout")
 	}
 
+	// internal endpoints stream entire blobs or return immediately, hence are not subject to the timeout
+	internal := reg.middleware(reg.internalRouter())
+	root := http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if strings.HasPrefix(req.URL.Path, internalPathPrefix+"/") {
+			internal.ServeHTTP(resp, req)
+			return
+		}
+		h.ServeHTTP(resp, req)
+	})
+
 	log.WithField("addr", fmt.Sprintf(":%d", reg.Config.Port)).Info("blob HTTP server listening")
-	return http.ListenAndServe(fmt.Sprintf(":%d", reg.Config.Port), h)
+	return http.ListenAndServe(fmt.Sprintf(":%d", reg.Config.Port), root)
 }
 
 // MustServe calls serve and logs any error as Fatal
@@ -5,6 +5,7 @@
 package blobserve
 
 import (
+	"archive/tar"
 	"bytes"
 	"compress/gzip"
 	"context"
@@ -30,6 +31,8 @@ type FileModifier func(in io.Reader, out io.Writer) error
 type blobspace interface {
 	Get(name string) (fs http.FileSystem, state blobstate)
 	AddFromTarGzip(ctx context.Context, name string, in io.Reader, modifications []blobModifier) (err error)
+	// ExportTarGzip writes a ready blob as gzip compressed tar stream
+	ExportTarGzip(name string, out io.Writer) (err error)
 }
 
 type diskBlobspace struct {
@@ -195,6 +198,12 @@ func (b *diskBlobspace) AddFromTar(ctx context.Context, name string, in io.Reade
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err != nil {
+			// don't leave an unready blob behind, otherwise nobody attempts to download it again until it's garbage collected
+			_ = os.RemoveAll(fn)
+		}
+	}()
 
 	var cw countingWriter
 	cin := io.TeeReader(in, &cw)
@@ -238,6 +247,66 @@ func (b *diskBlobspace) AddFromTarGzip(ctx context.Context, name string, in io.R
 	return b.AddFromTar(ctx, name, gin, modifications)
 }
 
+// ExportTarGzip writes a ready blob as gzip compressed tar stream, e.g. to pass it on to a peer.
+// The stream contains the blob with all modifications applied.
+func (b *diskBlobspace) ExportTarGzip(name string, out io.Writer) (err error) {
+	fn := filepath.Join(b.Location, name)
+	if _, err := os.Stat(fmt.Sprintf("%s.ready", fn)); os.IsNotExist(err) {
+		return errdefs.ErrNotFound
+	}
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	err = filepath.Walk(fn, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(fn, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err = os.Readlink(path)
+			if err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		err = tw.WriteHeader(hdr)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	err = tw.Close()
+	if err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
 // ModifyFile modifies a file in the blobspace.
 // Beware: this function is not synchronised.
 // Beware: this function is not safe for user-provided input (does not file path sanitisation).
@@ -0,0 +1,175 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package blobserve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"golang.org/x/xerrors"
+
+	blobserve_config "github.com/gitpod-io/gitpod/blobserve/pkg/config"
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+const (
+	// internalPathPrefix is the path prefix of the endpoints blobserve replicas and other components use to talk to blobserve.
+	// It cannot clash with image refs because those must start with an alphanumeric character.
+	internalPathPrefix = "/_blobserve"
+
+	// forwardedHeader marks pre-warm requests which a replica passed on to its peers
+	forwardedHeader = "X-Blobserve-Forwarded"
+
+	defaultPeerTimeout = 2 * time.Minute
+)
+
+// peers discovers the other replicas of blobserve using the DNS records of a headless service
+type peers struct {
+	Service string
+	Port    int
+	Client  *http.Client
+
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+}
+
+func newPeers(cfg *blobserve_config.Peers, port int) *peers {
+	if cfg == nil || cfg.Service == "" {
+		return nil
+	}
+
+	timeout := time.Duration(cfg.Timeout)
+	if timeout <= 0 {
+		timeout = defaultPeerTimeout
+	}
+	return &peers{
+		Service:    cfg.Service,
+		Port:       port,
+		Client:     &http.Client{Timeout: timeout},
+		lookupHost: net.DefaultResolver.LookupHost,
+	}
+}
+
+// Addresses returns the host:port of all replicas in random order. This may include this replica.
+func (p *peers) Addresses(ctx context.Context) ([]string, error) {
+	hosts, err := p.lookupHost(ctx, p.Service)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot resolve peers: %w", err)
+	}
+
+	res := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		res = append(res, net.JoinHostPort(h, strconv.Itoa(p.Port)))
+	}
+	rand.Shuffle(len(res), func(i, j int) { res[i], res[j] = res[j], res[i] })
+	return res, nil
+}
+
+// FetchBlob adds an unpacked blob of a peer to the blobspace. It returns false if no peer has the blob.
+func (p *peers) FetchBlob(ctx context.Context, digest string, bs blobspace) (ok bool, err error) {
+	addrs, err := p.Addresses(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, addr := range addrs {
+		ok, err = p.fetchBlobFrom(ctx, addr, digest, bs)
+		if err != nil {
+			log.WithError(err).WithField("peer", addr).WithField("digest", digest).Warn("cannot fetch blob from peer")
+			continue
+		}
+		if ok {
+			log.WithField("peer", addr).WithField("digest", digest).Info("fetched blob from peer")
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *peers) fetchBlobFrom(ctx context.Context, addr, digest string, bs blobspace) (ok bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s%s/blobs/%s", addr, internalPathPrefix, digest), nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, xerrors.Errorf("unexpected status %s", resp.Status)
+	}
+
+	// the blob has been modified by the peer already
+	err = bs.AddFromTarGzip(ctx, digest, resp.Body, nil)
+	if errors.Is(err, errdefs.ErrAlreadyExists) {
+		// someone else added the blob in the meantime
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PrewarmRequest asks blobserve to download and unpack the blobs of images ahead of time
+type PrewarmRequest struct {
+	Refs []string `json:"refs"`
+}
+
+// PrewarmResponse lists the refs blobserve started to download and the ones it rejected
+type PrewarmResponse struct {
+	Accepted []string          `json:"accepted"`
+	Rejected map[string]string `json:"rejected,omitempty"`
+}
+
+// Forward passes a pre-warm request on to all peers
+func (p *peers) Forward(ctx context.Context, req PrewarmRequest) {
+	addrs, err := p.Addresses(ctx)
+	if err != nil {
+		log.WithError(err).Warn("cannot forward pre-warm request to peers")
+		return
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		log.WithError(err).Error("cannot marshal pre-warm request")
+		return
+	}
+	for _, addr := range addrs {
+		r, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://%s%s/prewarm", addr, internalPathPrefix), bytes.NewReader(body))
+		if err != nil {
+			log.WithError(err).WithField("peer", addr).Warn("cannot forward pre-warm request to peer")
+			continue
+		}
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set(forwardedHeader, "true")
+
+		resp, err := p.Client.Do(r)
+		if err != nil {
+			log.WithError(err).WithField("peer", addr).Warn("cannot forward pre-warm request to peer")
+			continue
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusAccepted {
+			log.WithField("peer", addr).WithField("status", resp.Status).Warn("peer did not accept pre-warm request")
+		}
+	}
+}
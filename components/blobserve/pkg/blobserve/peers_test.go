@@ -0,0 +1,197 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package blobserve
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	blobserve_config "github.com/gitpod-io/gitpod/blobserve/pkg/config"
+)
+
+const testDigest = "2b6e1a0f1c0c9d0b7e6c3f8a4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f70"
+
+func tarGzip(t *testing.T, files map[string]string) *bytes.Buffer {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tw.Write([]byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestPeersFetchBlob(t *testing.T) {
+	files := map[string]string{
+		"index.html":     "hello world",
+		"static/main.js": "console.log('foo')",
+	}
+
+	src, err := newBlobSpace(t.TempDir(), 1<<30, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = src.AddFromTarGzip(context.Background(), testDigest, tarGzip(t, files), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reg := &Server{refstore: &refstore{blobspace: src}}
+	srv := httptest.NewServer(reg.internalRouter())
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, port, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newPeers(&blobserve_config.Peers{Service: "blobserve-peers"}, 0)
+	p.Port, _ = strconv.Atoi(port)
+	p.lookupHost = func(ctx context.Context, h string) ([]string, error) {
+		if h != "blobserve-peers" {
+			t.Errorf("unexpected host lookup: %s", h)
+		}
+		return []string{host}, nil
+	}
+
+	dst, err := newBlobSpace(t.TempDir(), 1<<30, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err := p.FetchBlob(context.Background(), strings.Repeat("0", 64), dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Error("fetched blob which the peer does not have")
+	}
+
+	ok, err = p.FetchBlob(context.Background(), testDigest, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("did not fetch blob from peer")
+	}
+	if _, state := dst.Get(testDigest); state != blobReady {
+		t.Errorf("fetched blob is not ready: %v", state)
+	}
+	for name, content := range files {
+		ctnt, err := os.ReadFile(filepath.Join(dst.Location, testDigest, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(content, string(ctnt)); diff != "" {
+			t.Errorf("unexpected content of %s (-want +got):\n%s", name, diff)
+		}
+	}
+}
+
+func TestPrewarm(t *testing.T) {
+	done := make(chan error)
+	close(done)
+
+	reg := &Server{
+		Config: blobserve_config.BlobServe{
+			Repos: map[string]blobserve_config.Repo{
+				"gitpod.io/ide": {},
+			},
+		},
+		refstore: &refstore{
+			refcache: map[string]*refstate{
+				"gitpod.io/ide:latest": {Digest: testDigest, ch: done},
+			},
+			blobspace: &inMemoryBlobspace{Content: map[string]blobstate{testDigest: blobReady}},
+		},
+	}
+
+	tests := []struct {
+		Name        string
+		Body        string
+		Header      http.Header
+		Status      int
+		Expectation *PrewarmResponse
+	}{
+		{
+			Name:   "accepts allowed refs",
+			Body:   `{"refs":["gitpod.io/ide:latest","gitpod.io/other:latest","gitpod.io/ide"]}`,
+			Status: http.StatusAccepted,
+			Expectation: &PrewarmResponse{
+				Accepted: []string{"gitpod.io/ide:latest"},
+				Rejected: map[string]string{
+					"gitpod.io/other:latest": "forbidden repo: gitpod.io/other",
+					"gitpod.io/ide":          "tag or digest is missing",
+				},
+			},
+		},
+		{
+			Name:   "invalid request",
+			Body:   `refs`,
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name:   "proxied request",
+			Body:   `{"refs":["gitpod.io/ide:latest"]}`,
+			Header: http.Header{"X-Forwarded-For": []string{"10.0.0.1"}},
+			Status: http.StatusForbidden,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, internalPathPrefix+"/prewarm", strings.NewReader(test.Body))
+			for k, v := range test.Header {
+				req.Header[k] = v
+			}
+			rec := httptest.NewRecorder()
+			reg.internalRouter().ServeHTTP(rec, req)
+
+			if rec.Code != test.Status {
+				t.Fatalf("unexpected status: want %d, got %d", test.Status, rec.Code)
+			}
+			if test.Expectation == nil {
+				return
+			}
+			var resp PrewarmResponse
+			err := json.Unmarshal(rec.Body.Bytes(), &resp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(*test.Expectation, resp); diff != "" {
+				t.Errorf("unexpected response (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package blobserve
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/docker/distribution/reference"
+	"github.com/gorilla/mux"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+const (
+	// prewarmTimeout is how long a replica tries to download and unpack a pre-warmed blob
+	prewarmTimeout = 15 * time.Minute
+	// maxPrewarmRequestSize is the maximum size of the body of a pre-warm request
+	maxPrewarmRequestSize = 1 << 20
+)
+
+// internalRouter serves the endpoints other components and replicas use to talk to blobserve
+func (reg *Server) internalRouter() http.Handler {
+	r := mux.NewRouter()
+	r.Use(rejectProxied)
+	r.Path(internalPathPrefix + "/prewarm").Methods(http.MethodPost).HandlerFunc(reg.prewarm)
+	r.Path(internalPathPrefix + "/blobs/{digest:[a-f0-9]{64}}").Methods(http.MethodGet).HandlerFunc(reg.exportBlob)
+	r.NewRoute().HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		http.Error(resp, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	})
+	return r
+}
+
+// rejectProxied rejects requests which were proxied, e.g. from ws-proxy, because internal endpoints must not be public
+func rejectProxied(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Forwarded-For") != "" {
+			http.Error(resp, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(resp, req)
+	})
+}
+
+// prewarm starts to download and unpack the blobs of images ahead of time, and passes the request on to all peers.
+// It does not wait for the downloads to finish.
+func (reg *Server) prewarm(w http.ResponseWriter, req *http.Request) {
+	var prewarmReq PrewarmRequest
+	err := json.NewDecoder(io.LimitReader(req.Body, maxPrewarmRequestSize)).Decode(&prewarmReq)
+	if err != nil {
+		http.Error(w, "cannot parse pre-warm request", http.StatusBadRequest)
+		return
+	}
+
+	resp := PrewarmResponse{Accepted: []string{}}
+	for _, image := range prewarmReq.Refs {
+		ref, err := reg.allowedRef(image)
+		if err != nil {
+			if resp.Rejected == nil {
+				resp.Rejected = make(map[string]string)
+			}
+			resp.Rejected[image] = err.Error()
+			continue
+		}
+		resp.Accepted = append(resp.Accepted, ref)
+	}
+
+	for _, ref := range resp.Accepted {
+		go func(ref string) {
+			// The pre-warm must be independent of this request which returns right away.
+			ctx, cancel := context.WithTimeout(context.Background(), prewarmTimeout)
+			defer cancel()
+
+			err := reg.Prepare(ctx, ref)
+			if err != nil {
+				log.WithError(err).WithField("ref", ref).Warn("cannot pre-warm blob")
+				return
+			}
+			log.WithField("ref", ref).Info("pre-warmed blob")
+		}(ref)
+	}
+
+	if reg.refstore.peers != nil && len(resp.Accepted) > 0 && req.Header.Get(forwardedHeader) == "" {
+		go func(refs []string) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			reg.refstore.peers.Forward(ctx, PrewarmRequest{Refs: refs})
+		}(resp.Accepted)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// allowedRef returns the normalized ref of an image if blobserve may serve it
+func (reg *Server) allowedRef(image string) (string, error) {
+	pref, err := reference.ParseNamed(image)
+	if err != nil {
+		return "", xerrors.Errorf("cannot parse image: %w", err)
+	}
+	_, hasTag := pref.(reference.Tagged)
+	_, hasDigest := pref.(reference.Digested)
+	if !hasTag && !hasDigest {
+		return "", xerrors.Errorf("tag or digest is missing")
+	}
+	if _, ok := reg.Config.Repos[pref.Name()]; !ok && !reg.Config.AllowAnyRepo {
+		return "", xerrors.Errorf("forbidden repo: %s", pref.Name())
+	}
+	return pref.String(), nil
+}
+
+// exportBlob streams a ready blob to a peer
+func (reg *Server) exportBlob(w http.ResponseWriter, req *http.Request) {
+	digest := mux.Vars(req)["digest"]
+	if _, state := reg.refstore.blobspace.Get(digest); state != blobReady {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	err := reg.refstore.blobspace.ExportTarGzip(digest, w)
+	if err == errdefs.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		// we have most likely written part of the response already, hence cannot send an error status
+		log.WithError(err).WithField("digest", digest).Error("cannot export blob")
+	}
+}
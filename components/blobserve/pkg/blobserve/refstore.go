@@ -49,6 +49,9 @@ type refstore struct {
 	requests  chan downloadRequest
 	blobspace blobspace
 	config    map[string]blobConfig
+	// peers are the other replicas we fetch unpacked blobs from before downloading them from the registry.
+	// Nil if there are no peers.
+	peers *peers
 
 	close chan struct{}
 	once  *sync.Once
@@ -77,6 +80,7 @@ func newRefStore(cfg blobserve_config.BlobServe, resolver ResolverProvider) (*re
 		Resolver:  resolver,
 		blobspace: bs,
 		config:    config,
+		peers:     newPeers(cfg.Peers, cfg.Port),
 		refcache:  make(map[string]*refstate),
 		requests:  make(chan downloadRequest),
 		once:      &sync.Once{},
@@ -260,6 +264,16 @@ func (store *refstore) handleRequest(ctx context.Context, ref string, force bool
 		_, state := store.blobspace.Get(digest)
 		switch state {
 		case blobUnknown:
+			if store.peers != nil {
+				ok, err := store.peers.FetchBlob(ctx, digest, store.blobspace)
+				if err != nil {
+					log.WithError(err).WithField("ref", ref).Warn("cannot fetch blob from peers - downloading it from the registry")
+				}
+				if ok {
+					continue
+				}
+			}
+
 			in, err := fetcher.Fetch(ctx, *layer)
 			if err != nil {
 				return err
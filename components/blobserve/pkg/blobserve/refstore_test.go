@@ -452,6 +452,10 @@ func (s *inMemoryBlobspace) AddFromTarGzip(ctx context.Context, name string, in
 	return s.Adder(ctx, name, in)
 }
 
+func (s *inMemoryBlobspace) ExportTarGzip(name string, out io.Writer) (err error) {
+	return xerrors.Errorf("not implemented")
+}
+
 type provider func() ([]byte, error)
 
 type fakeFetcher struct {
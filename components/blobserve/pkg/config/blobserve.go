@@ -15,6 +15,16 @@ type BlobServe struct {
 	// ref config or not.
 	AllowAnyRepo bool      `json:"allowAnyRepo"`
 	BlobSpace    BlobSpace `json:"blobSpace"`
+	// Peers enables replicas to fetch unpacked blobs from each other and to pass pre-warm requests on to each other
+	Peers *Peers `json:"peers,omitempty"`
+}
+
+type Peers struct {
+	// Service is the DNS name of a headless service which resolves to the addresses of all replicas.
+	// Replicas are expected to listen on the same port as this one.
+	Service string `json:"service"`
+	// Timeout is how long a replica tries to fetch a blob from a peer. Defaults to 2m.
+	Timeout util.Duration `json:"timeout,omitempty"`
 }
 
 type StringReplacement struct {
@@ -54,7 +54,7 @@ func New(name string, opts ...Option) (*Server, error) {
 			Prefix:   "gitpod",
 			Registry: server.MetricsRegistry(),
 		}),
-	}), server.httpMux)}
+	}), tracingHandler(server.httpMux))}
 
 	err = server.initializeMetrics()
 	if err != nil {
@@ -82,7 +82,7 @@ func New(name string, opts ...Option) (*Server, error) {
 //   - Started when baseserver is configured WithGRPCPort (port is non-negative)
 //   - Use Server.GRPC() to get access to the underlying grpc.Server and register services
 //   - (optional) HTTP server
-//   - Currently does not come with any standard HTTP middlewares
+//   - Comes with metrics and tracing HTTP middlewares
 //   - Started when baseserver is configured WithHTTPPort (port is non-negative)
 //   - Use Server.HTTPMux() to get access to the root handler and register your endpoints
 type Server struct {
@@ -245,9 +245,11 @@ func (s *Server) close(ctx context.Context) error {
 	}
 	s.Logger().Info("Debug server terminated.")
 
-	err = s.tracingCloser.Close()
-	if err != nil {
-		return fmt.Errorf("failed to close tracing: %w", err)
+	if s.tracingCloser != nil {
+		err = s.tracingCloser.Close()
+		if err != nil {
+			return fmt.Errorf("failed to close tracing: %w", err)
+		}
 	}
 
 	return nil
@@ -0,0 +1,43 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package baseserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracingHandler(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+	t.Cleanup(func() {
+		opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+	})
+
+	parent := tracer.StartSpan("caller")
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	require.NoError(t, tracer.Inject(parent.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(req.Header)))
+
+	var spanInHandler opentracing.Span
+	handler := tracingHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		spanInHandler = opentracing.SpanFromContext(r.Context())
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := tracer.FinishedSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	require.Equal(t, spanInHandler, span)
+	require.Equal(t, "HTTP GET /foo", span.OperationName)
+	require.Equal(t, parent.Context().(mocktracer.MockSpanContext).TraceID, span.SpanContext.TraceID)
+	require.Equal(t, uint16(http.StatusServiceUnavailable), span.Tag("http.status_code"))
+	require.Equal(t, true, span.Tag("error"))
+}
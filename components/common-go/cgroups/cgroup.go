@@ -46,6 +46,26 @@ type MemoryStats struct {
 	InactiveFileTotal uint64
 }
 
+// MemoryEvents counts how often the memory boundaries of a cgroup were hit, see memory.events
+type MemoryEvents struct {
+	// High is the number of times processes were throttled and put under reclaim because memory.high was exceeded
+	High uint64
+	// Max is the number of times the usage was about to exceed memory.max
+	Max uint64
+	// OOM is the number of times the usage reached memory.max and allocation failed
+	OOM uint64
+	// OOMKill is the number of processes belonging to this cgroup which were killed by any kind of OOM killer
+	OOMKill uint64
+}
+
+// IOStat is the IO a cgroup has done, summed over all devices
+type IOStat struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadIOs    uint64
+	WriteIOs   uint64
+}
+
 func ReadSingleValue(path string) (uint64, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
@@ -5,7 +5,11 @@
 package cgroups_v2
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/gitpod-io/gitpod/common-go/cgroups"
 )
@@ -31,3 +35,45 @@ func (io *IO) PSI() (cgroups.PSI, error) {
 	path := filepath.Join(io.path, "io.pressure")
 	return cgroups.ReadPSIValue(path)
 }
+
+// Stat reads io.stat and sums up the IO of the cgroup across all devices
+func (io *IO) Stat() (*cgroups.IOStat, error) {
+	path := filepath.Join(io.path, "io.stat")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var res cgroups.IOStat
+	for _, line := range strings.Split(string(content), "\n") {
+		// every line has the form "<major>:<minor> rbytes=1 wbytes=2 rios=3 wios=4 dbytes=0 dios=0"
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse %s in %s: %w", field, path, err)
+			}
+
+			switch key {
+			case "rbytes":
+				res.ReadBytes += v
+			case "wbytes":
+				res.WriteBytes += v
+			case "rios":
+				res.ReadIOs += v
+			case "wios":
+				res.WriteIOs += v
+			}
+		}
+	}
+
+	return &res, nil
+}
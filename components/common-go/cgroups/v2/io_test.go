@@ -0,0 +1,41 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cgroups_v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/common-go/cgroups"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIOStat(t *testing.T) {
+	mountPoint := t.TempDir()
+	cgroupPath := filepath.Join(mountPoint, "cgroup")
+	if err := os.MkdirAll(cgroupPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "259:0 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0\n253:0 rbytes=100 wbytes=200 rios=3 wios=4 dbytes=0 dios=0\n"
+	if err := os.WriteFile(filepath.Join(cgroupPath, "io.stat"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	io := NewIOControllerWithMount(mountPoint, "cgroup")
+	stat, err := io.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, &cgroups.IOStat{ReadBytes: 4196, WriteBytes: 8392, ReadIOs: 4, WriteIOs: 6}, stat)
+}
+
+func TestIOStatNotExist(t *testing.T) {
+	io := NewIOControllerWithMount("/this/does/not", "exist")
+	_, err := io.Stat()
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
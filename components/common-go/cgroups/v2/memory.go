@@ -64,6 +64,23 @@ func (m *Memory) Stat() (*cgroups.MemoryStats, error) {
 	}, nil
 }
 
+// Events returns the number of times memory boundaries were hit by
+// the cgroup and its descendants.
+func (m *Memory) Events() (*cgroups.MemoryEvents, error) {
+	path := filepath.Join(m.path, "memory.events")
+	eventMap, err := cgroups.ReadFlatKeyedFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cgroups.MemoryEvents{
+		High:    eventMap["high"],
+		Max:     eventMap["max"],
+		OOM:     eventMap["oom"],
+		OOMKill: eventMap["oom_kill"],
+	}, nil
+}
+
 func (m *Memory) PSI() (cgroups.PSI, error) {
 	path := filepath.Join(m.path, "memory.pressure")
 	return cgroups.ReadPSIValue(path)
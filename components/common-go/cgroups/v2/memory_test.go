@@ -0,0 +1,41 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cgroups_v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/common-go/cgroups"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvents(t *testing.T) {
+	mountPoint := t.TempDir()
+	cgroupPath := filepath.Join(mountPoint, "cgroup")
+	if err := os.MkdirAll(cgroupPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "low 0\nhigh 12\nmax 3\noom 2\noom_kill 1\noom_group_kill 0\n"
+	if err := os.WriteFile(filepath.Join(cgroupPath, "memory.events"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	memory := NewMemoryControllerWithMount(mountPoint, "cgroup")
+	events, err := memory.Events()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, &cgroups.MemoryEvents{High: 12, Max: 3, OOM: 2, OOMKill: 1}, events)
+}
+
+func TestEventsNotExist(t *testing.T) {
+	memory := NewMemoryControllerWithMount("/this/does/not", "exist")
+	_, err := memory.Events()
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -15,10 +15,13 @@ import (
 
 const (
 	userIDAttribute         = "user_id"
+	userEmailAttribute      = "user_email"
 	projectIDAttribute      = "project_id"
 	teamIDAttribute         = "team_id"
 	teamNameAttribute       = "team_name"
 	vscodeClientIDAttribute = "vscode_client_id"
+	gitpodHostAttribute     = "gitpod_host"
+	componentAttribute      = "component"
 )
 
 func newConfigCatClient(config configcat.Config) *configCatClient {
@@ -80,6 +83,14 @@ func attributesToUser(attributes Attributes) *configcat.UserData {
 		custom[vscodeClientIDAttribute] = attributes.VSCodeClientID
 	}
 
+	if attributes.GitpodHost != "" {
+		custom[gitpodHostAttribute] = attributes.GitpodHost
+	}
+
+	if attributes.Component != "" {
+		custom[componentAttribute] = attributes.Component
+	}
+
 	return &configcat.UserData{
 		Identifier: attributes.UserID,
 		Email:      attributes.UserEmail,
@@ -0,0 +1,287 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package experiments
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/watch"
+)
+
+// FileConfig is the content of a feature flag file. JSON is valid YAML, hence files can be written in either format.
+//
+// Example:
+//
+//	flags:
+//	  personalAccessTokensEnabled:
+//	    value: false
+//	    rules:
+//	      - match:
+//	          team_id: ["0b9f8c1e-1a4f-4a1c-9d3e-2f3b4c5d6e7f"]
+//	        value: true
+//	      - match:
+//	          user_email: ["*@example.com"]
+//	        percentage: 20
+//	        value: true
+type FileConfig struct {
+	Flags map[string]FileFlag `json:"flags" yaml:"flags"`
+}
+
+// FileFlag configures a single feature flag
+type FileFlag struct {
+	// Value is returned when no rule matches
+	Value interface{} `json:"value" yaml:"value"`
+	// Rules are evaluated in order, the first matching rule determines the value
+	Rules []FileRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// FileRule targets a subset of requests
+type FileRule struct {
+	// Match maps attribute names to glob patterns (see path.Match). A rule matches if for every
+	// attribute at least one of its patterns matches. Supported attribute names are
+	// user_id, user_email, project_id, team_id, team_name, vscode_client_id, gitpod_host and component.
+	Match map[string][]string `json:"match,omitempty" yaml:"match,omitempty"`
+	// Percentage limits the rule to a stable share of users (or teams or projects if no user is known), between 0 and 100.
+	// If unset, the rule applies to all requests matched by Match.
+	Percentage *float64 `json:"percentage,omitempty" yaml:"percentage,omitempty"`
+	// Value is returned when this rule matches
+	Value interface{} `json:"value" yaml:"value"`
+}
+
+func (a Attributes) get(name string) (string, bool) {
+	switch name {
+	case userIDAttribute:
+		return a.UserID, true
+	case userEmailAttribute:
+		return a.UserEmail, true
+	case projectIDAttribute:
+		return a.ProjectID, true
+	case teamIDAttribute:
+		return a.TeamID, true
+	case teamNameAttribute:
+		return a.TeamName, true
+	case vscodeClientIDAttribute:
+		return a.VSCodeClientID, true
+	case gitpodHostAttribute:
+		return a.GitpodHost, true
+	case componentAttribute:
+		return a.Component, true
+	default:
+		return "", false
+	}
+}
+
+// rolloutKey identifies the subject of a percentage rollout
+func (a Attributes) rolloutKey() string {
+	for _, k := range []string{a.UserID, a.TeamID, a.ProjectID} {
+		if k != "" {
+			return k
+		}
+	}
+	return ""
+}
+
+// ParseFileConfig parses and validates a feature flag file
+func ParseFileConfig(content []byte) (*FileConfig, error) {
+	var cfg FileConfig
+	err := yaml.Unmarshal(content, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse feature flags: %w", err)
+	}
+
+	var attrs Attributes
+	for name, flag := range cfg.Flags {
+		for i, rule := range flag.Rules {
+			for attr, patterns := range rule.Match {
+				if _, ok := attrs.get(attr); !ok {
+					return nil, fmt.Errorf("flag %s, rule %d: unknown attribute %s", name, i, attr)
+				}
+				for _, p := range patterns {
+					if _, err := path.Match(p, ""); err != nil {
+						return nil, fmt.Errorf("flag %s, rule %d: invalid pattern %q: %w", name, i, p, err)
+					}
+				}
+			}
+			if p := rule.Percentage; p != nil && (*p < 0 || *p > 100) {
+				return nil, fmt.Errorf("flag %s, rule %d: percentage must be between 0 and 100", name, i)
+			}
+		}
+	}
+
+	return &cfg, nil
+}
+
+func (r FileRule) matches(flagName string, attributes Attributes) bool {
+	for attr, patterns := range r.Match {
+		val, _ := attributes.get(attr)
+		var found bool
+		for _, p := range patterns {
+			if ok, _ := path.Match(p, val); ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if r.Percentage == nil {
+		return true
+	}
+	key := attributes.rolloutKey()
+	if key == "" {
+		return false
+	}
+	return rolloutBucket(flagName, key) < *r.Percentage
+}
+
+// rolloutBucket deterministically maps a subject to a value in [0, 100), independently for each flag
+func rolloutBucket(flagName, key string) float64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(flagName))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(key))
+	return float64(h.Sum32()%10000) / 100
+}
+
+// NewFileClient produces a client which evaluates the feature flags defined in a local YAML or JSON file, e.g. a mounted ConfigMap.
+// The file is reloaded whenever it changes.
+func NewFileClient(ctx context.Context, fn string) (Client, error) {
+	c := &fileClient{}
+	err := c.load(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = watch.File(ctx, fn, func() {
+		err := c.load(fn)
+		if err != nil {
+			log.WithError(err).WithField("path", fn).Error("cannot reload feature flags - keeping previous flags")
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+var _ Client = (*fileClient)(nil)
+
+type fileClient struct {
+	mu  sync.RWMutex
+	cfg *FileConfig
+}
+
+func (c *fileClient) load(fn string) error {
+	content, err := os.ReadFile(fn)
+	if err != nil {
+		return fmt.Errorf("cannot read feature flags from %s: %w", fn, err)
+	}
+	cfg, err := ParseFileConfig(content)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cfg = cfg
+	return nil
+}
+
+func (c *fileClient) getValue(experimentName string, attributes Attributes) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	flag, ok := c.cfg.Flags[experimentName]
+	if !ok {
+		return nil, false
+	}
+	for _, rule := range flag.Rules {
+		if rule.matches(experimentName, attributes) {
+			return rule.Value, true
+		}
+	}
+	return flag.Value, flag.Value != nil
+}
+
+func (c *fileClient) GetBoolValue(ctx context.Context, experimentName string, defaultValue bool, attributes Attributes) bool {
+	value := defaultValue
+	if v, ok := c.getValue(experimentName, attributes); ok {
+		switch v := v.(type) {
+		case bool:
+			value = v
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				value = b
+			}
+		}
+	}
+	log.AddFields(ctx, logField(experimentName, value))
+	return value
+}
+
+func (c *fileClient) GetIntValue(ctx context.Context, experimentName string, defaultValue int, attributes Attributes) int {
+	value := defaultValue
+	if v, ok := c.getValue(experimentName, attributes); ok {
+		switch v := v.(type) {
+		case int:
+			value = v
+		case float64:
+			if v == math.Trunc(v) {
+				value = int(v)
+			}
+		case string:
+			if i, err := strconv.Atoi(v); err == nil {
+				value = i
+			}
+		}
+	}
+	log.AddFields(ctx, logField(experimentName, value))
+	return value
+}
+
+func (c *fileClient) GetFloatValue(ctx context.Context, experimentName string, defaultValue float64, attributes Attributes) float64 {
+	value := defaultValue
+	if v, ok := c.getValue(experimentName, attributes); ok {
+		switch v := v.(type) {
+		case int:
+			value = float64(v)
+		case float64:
+			value = v
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				value = f
+			}
+		}
+	}
+	log.AddFields(ctx, logField(experimentName, value))
+	return value
+}
+
+func (c *fileClient) GetStringValue(ctx context.Context, experimentName string, defaultValue string, attributes Attributes) string {
+	value := defaultValue
+	if v, ok := c.getValue(experimentName, attributes); ok {
+		switch v := v.(type) {
+		case string:
+			value = v
+		case bool, int, float64:
+			value = fmt.Sprint(v)
+		}
+	}
+	log.AddFields(ctx, logField(experimentName, value))
+	return value
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package experiments
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testFlags = `
+flags:
+  boolFlag:
+    value: false
+    rules:
+      - match:
+          team_id: ["team-a", "team-b"]
+          component: ["server"]
+        value: true
+      - match:
+          user_email: ["*@example.com"]
+        value: true
+  intFlag:
+    value: 3
+    rules:
+      - match:
+          gitpod_host: ["gitpod.example.com"]
+        value: 7
+  floatFlag:
+    value: 1.5
+  stringFlag:
+    value: foo
+  rollout:
+    value: false
+    rules:
+      - percentage: 50
+        value: true
+`
+
+func newTestFileClient(t *testing.T, content string) (Client, string) {
+	t.Helper()
+
+	fn := filepath.Join(t.TempDir(), "flags.yaml")
+	require.NoError(t, os.WriteFile(fn, []byte(content), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	client, err := NewFileClient(ctx, fn)
+	require.NoError(t, err)
+	return client, fn
+}
+
+func TestFileClient(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestFileClient(t, testFlags)
+
+	require.False(t, client.GetBoolValue(ctx, "boolFlag", true, Attributes{}))
+	require.False(t, client.GetBoolValue(ctx, "boolFlag", true, Attributes{TeamID: "team-a"}))
+	require.True(t, client.GetBoolValue(ctx, "boolFlag", false, Attributes{TeamID: "team-b", Component: "server"}))
+	require.True(t, client.GetBoolValue(ctx, "boolFlag", false, Attributes{UserEmail: "jane@example.com"}))
+	require.True(t, client.GetBoolValue(ctx, "unknown", true, Attributes{}))
+
+	require.Equal(t, 3, client.GetIntValue(ctx, "intFlag", 0, Attributes{}))
+	require.Equal(t, 7, client.GetIntValue(ctx, "intFlag", 0, Attributes{GitpodHost: "gitpod.example.com"}))
+	require.Equal(t, 1.5, client.GetFloatValue(ctx, "floatFlag", 0, Attributes{}))
+	require.Equal(t, 3.0, client.GetFloatValue(ctx, "intFlag", 0, Attributes{}))
+	require.Equal(t, "foo", client.GetStringValue(ctx, "stringFlag", "", Attributes{}))
+
+	// type mismatches return the default value
+	require.Equal(t, 42, client.GetIntValue(ctx, "stringFlag", 42, Attributes{}))
+	require.Equal(t, 42, client.GetIntValue(ctx, "floatFlag", 42, Attributes{}))
+}
+
+func TestFileClient_PercentageRollout(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestFileClient(t, testFlags)
+
+	require.False(t, client.GetBoolValue(ctx, "rollout", false, Attributes{}), "rollouts require a subject")
+
+	var enabled int
+	for i := 0; i < 1000; i++ {
+		attrs := Attributes{UserID: fmt.Sprintf("user-%d", i)}
+		val := client.GetBoolValue(ctx, "rollout", false, attrs)
+		require.Equal(t, val, client.GetBoolValue(ctx, "rollout", false, attrs), "rollouts must be stable")
+		if val {
+			enabled++
+		}
+	}
+	require.InDelta(t, 500, enabled, 75)
+}
+
+func TestFileClient_Reload(t *testing.T) {
+	ctx := context.Background()
+	client, fn := newTestFileClient(t, testFlags)
+	require.Equal(t, "foo", client.GetStringValue(ctx, "stringFlag", "", Attributes{}))
+
+	// replace the file like a ConfigMap update does
+	tmp := fn + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte("flags:\n  stringFlag:\n    value: bar\n"), 0644))
+	require.NoError(t, os.Rename(tmp, fn))
+
+	require.Eventually(t, func() bool {
+		return client.GetStringValue(ctx, "stringFlag", "", Attributes{}) == "bar"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestParseFileConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Content string
+	}{
+		{Name: "unknown attribute", Content: "flags:\n  f:\n    rules:\n      - match:\n          foo: [bar]\n"},
+		{Name: "invalid pattern", Content: "flags:\n  f:\n    rules:\n      - match:\n          user_id: [\"[\"]\n"},
+		{Name: "invalid percentage", Content: "flags:\n  f:\n    rules:\n      - percentage: 101\n"},
+		{Name: "invalid yaml", Content: "flags: ["},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := ParseFileConfig([]byte(test.Content))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewClient_WithFileEnvSet(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "flags.json")
+	require.NoError(t, os.WriteFile(fn, []byte(`{"flags": {"f": {"value": true}}}`), 0644))
+	t.Setenv("CONFIGCAT_SDK_KEY", "foo-bar")
+	t.Setenv("GITPOD_EXPERIMENTS_FILE", fn)
+
+	client := NewClient()
+	require.IsType(t, &fileClient{}, client)
+	require.True(t, client.GetBoolValue(context.Background(), "f", false, Attributes{}))
+}
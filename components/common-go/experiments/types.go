@@ -31,6 +31,11 @@ type Attributes struct {
 
 	// this is vscode header `x-market-client-id`
 	VSCodeClientID string
+
+	// GitpodHost is the domain of the installation, e.g. gitpod.example.com
+	GitpodHost string
+	// Component is the name of the component evaluating the flag, e.g. public-api-server
+	Component string
 }
 
 type ClientOpt func(o *options)
@@ -43,26 +48,45 @@ func WithGitpodProxy(gitpodHost string) ClientOpt {
 	}
 }
 
+// WithFile makes the client evaluate flags from a local YAML or JSON file instead of ConfigCat, see FileConfig.
+func WithFile(fn string) ClientOpt {
+	return func(o *options) {
+		o.file = fn
+	}
+}
+
 type options struct {
 	pollInterval time.Duration
 	baseURL      string
 	sdkKey       string
+	file         string
 }
 
 // NewClient constructs a new experiments.Client. This is NOT A SINGLETON.
 // You should normally only call this once in the lifecycle of an application, clients are independent of each other will refresh flags on their own.
-// If the environment contains CONFIGCAT_SDK_KEY value, it will be used to construct a ConfigCat client.
+// If the environment contains GITPOD_EXPERIMENTS_FILE, flags are evaluated from that file (see FileConfig) - this works offline.
+// Else if the environment contains CONFIGCAT_SDK_KEY value, it will be used to construct a ConfigCat client.
 // Otherwise, it returns a client which always returns the default value. This client is used for Self-Hosted installations.
 func NewClient(opts ...ClientOpt) Client {
 	opt := &options{
 		sdkKey:       os.Getenv("CONFIGCAT_SDK_KEY"),
 		baseURL:      os.Getenv("CONFIGCAT_BASE_URL"),
 		pollInterval: 3 * time.Minute,
+		file:         os.Getenv("GITPOD_EXPERIMENTS_FILE"),
 	}
 	for _, o := range opts {
 		o(opt)
 	}
 
+	if opt.file != "" {
+		client, err := NewFileClient(context.Background(), opt.file)
+		if err == nil {
+			return client
+		}
+		log.WithError(err).WithField("path", opt.file).Error("cannot load feature flags from file - using default values")
+		return NewAlwaysReturningDefaultValueClient()
+	}
+
 	if opt.sdkKey == "" {
 		return NewAlwaysReturningDefaultValueClient()
 	}
@@ -17,6 +17,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
+	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/common-go/util"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/prometheus/client_golang/prometheus"
@@ -45,8 +46,28 @@ func (r RateLimit) Limiter() *rate.Limiter {
 	return rate.NewLimiter(rate.Every(time.Duration(r.RefillInterval)), int(r.BucketSize))
 }
 
+// RatelimitingOption configures a RatelimitingInterceptor
+type RatelimitingOption func(*ratelimitingOptions)
+
+type ratelimitingOptions struct {
+	store RatelimitStore
+}
+
+// WithRatelimitStore keeps the token buckets in a store shared between replicas, so that limits
+// are enforced across all of them. If the store fails, the interceptor falls back to per-process limits.
+func WithRatelimitStore(store RatelimitStore) RatelimitingOption {
+	return func(o *ratelimitingOptions) {
+		o.store = store
+	}
+}
+
 // NewRatelimitingInterceptor creates a new rate limiting interceptor
-func NewRatelimitingInterceptor(f map[string]RateLimit) RatelimitingInterceptor {
+func NewRatelimitingInterceptor(f map[string]RateLimit, opts ...RatelimitingOption) RatelimitingInterceptor {
+	var options ratelimitingOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
 	callCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "grpc",
 		Subsystem: "server",
@@ -57,6 +78,12 @@ func NewRatelimitingInterceptor(f map[string]RateLimit) RatelimitingInterceptor
 		Subsystem: "server",
 		Name:      "rate_limiter_cache_hit_total",
 	}, []string{"grpc_method"})
+	storeErrorCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "grpc",
+		Subsystem: "server",
+		Name:      "rate_limiter_store_errors_total",
+		Help:      "Number of calls for which the rate limit store failed and the per-process limit was used instead",
+	}, []string{"grpc_method"})
 
 	funcs := make(map[string]*ratelimitedFunction, len(f))
 	for name, fnc := range f {
@@ -70,18 +97,21 @@ func NewRatelimitingInterceptor(f map[string]RateLimit) RatelimitingInterceptor
 		}
 
 		funcs[name] = &ratelimitedFunction{
+			Name:                name,
 			RateLimit:           fnc,
 			GlobalLimit:         fnc.Limiter(),
 			Key:                 key,
 			KeyedLimit:          keyedLimit,
+			Store:               options.store,
 			RateLimitedTotal:    callCounter.WithLabelValues(name, "true"),
 			NotRateLimitedTotal: callCounter.WithLabelValues(name, "false"),
 			CacheMissTotal:      cacheHitCounter.WithLabelValues(name),
+			StoreErrorsTotal:    storeErrorCounter.WithLabelValues(name),
 		}
 	}
 	return RatelimitingInterceptor{
 		functions:  funcs,
-		collectors: []prometheus.Collector{callCounter, cacheHitCounter},
+		collectors: []prometheus.Collector{callCounter, cacheHitCounter, storeErrorCounter},
 	}
 }
 
@@ -177,15 +207,18 @@ type counter interface {
 }
 
 type ratelimitedFunction struct {
+	Name      string
 	RateLimit RateLimit
 
 	GlobalLimit *rate.Limiter
 	Key         keyFunc
 	KeyedLimit  *lru.Cache
+	Store       RatelimitStore
 
 	RateLimitedTotal    counter
 	NotRateLimitedTotal counter
 	CacheMissTotal      counter
+	StoreErrorsTotal    counter
 }
 
 // UnaryInterceptor creates a unary interceptor that implements the rate limiting
@@ -196,46 +229,150 @@ func (r RatelimitingInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor
 			return handler(ctx, req)
 		}
 
-		var limit *rate.Limiter
+		err = f.limit(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor creates a stream interceptor that implements the rate limiting.
+// Every stream consumes a single token when it is opened. If the rate limit is keyed, the
+// key is taken from the first message the client sends, e.g. the request of a server-streaming call.
+func (r RatelimitingInterceptor) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		f, ok := r.functions[info.FullMethod]
+		if !ok {
+			return handler(srv, ss)
+		}
+
 		if f.Key == nil {
-			limit = f.GlobalLimit
-		} else {
-			key, err := f.Key(req)
+			err := f.limit(ss.Context(), nil)
 			if err != nil {
-				return nil, err
+				return err
 			}
+			return handler(srv, ss)
+		}
 
-			found, _ := f.KeyedLimit.ContainsOrAdd(key, f.RateLimit.Limiter())
-			if !found && f.CacheMissTotal != nil {
-				f.CacheMissTotal.Inc()
-			}
-			v, _ := f.KeyedLimit.Get(key)
-			limit = v.(*rate.Limiter)
+		return handler(srv, &ratelimitedServerStream{ServerStream: ss, f: f})
+	}
+}
+
+type ratelimitedServerStream struct {
+	grpc.ServerStream
+
+	f       *ratelimitedFunction
+	limited bool
+}
+
+func (s *ratelimitedServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil || s.limited {
+		return err
+	}
+
+	s.limited = true
+	return s.f.limit(s.Context(), m)
+}
+
+// limit consumes a token for the call, returning a gRPC error if the call is rate limited
+func (f *ratelimitedFunction) limit(ctx context.Context, req interface{}) (err error) {
+	var key string
+	if f.Key != nil {
+		key, err = f.Key(req)
+		if err != nil {
+			return err
 		}
+	}
 
-		var blocked bool
-		defer func() {
-			if blocked && f.RateLimitedTotal != nil {
-				f.RateLimitedTotal.Inc()
-			} else if !blocked && f.NotRateLimitedTotal != nil {
-				f.NotRateLimitedTotal.Inc()
-			}
-		}()
-		if f.RateLimit.Block {
-			err := limit.Wait(ctx)
-			if err == context.Canceled {
-				blocked = true
-				return nil, err
-			}
-			if err != nil {
-				blocked = true
-				return nil, status.Error(codes.ResourceExhausted, err.Error())
-			}
-		} else if !limit.Allow() {
+	var blocked bool
+	defer func() {
+		if blocked && f.RateLimitedTotal != nil {
+			f.RateLimitedTotal.Inc()
+		} else if !blocked && f.NotRateLimitedTotal != nil {
+			f.NotRateLimitedTotal.Inc()
+		}
+	}()
+
+	if f.Store != nil && f.RateLimit.RefillInterval > 0 {
+		err = f.take(ctx, f.Name+":"+key)
+		if err == nil {
+			return nil
+		}
+		if _, isStatus := status.FromError(err); isStatus || err == context.Canceled {
 			blocked = true
-			return nil, status.Error(codes.ResourceExhausted, "too many requests")
+			return err
 		}
 
-		return handler(ctx, req)
+		if f.StoreErrorsTotal != nil {
+			f.StoreErrorsTotal.Inc()
+		}
+		log.WithError(err).WithField("method", f.Name).Debug("rate limit store failed - falling back to per-process limit")
+	}
+
+	limit := f.localLimit(key)
+	if f.RateLimit.Block {
+		err := limit.Wait(ctx)
+		if err == context.Canceled {
+			blocked = true
+			return err
+		}
+		if err != nil {
+			blocked = true
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+	} else if !limit.Allow() {
+		blocked = true
+		return status.Error(codes.ResourceExhausted, "too many requests")
+	}
+
+	return nil
+}
+
+// localLimit returns the per-process limiter for the key, creating it if it is not cached yet
+func (f *ratelimitedFunction) localLimit(key string) *rate.Limiter {
+	if f.Key == nil {
+		return f.GlobalLimit
+	}
+
+	found, _ := f.KeyedLimit.ContainsOrAdd(key, f.RateLimit.Limiter())
+	if !found && f.CacheMissTotal != nil {
+		f.CacheMissTotal.Inc()
+	}
+	v, _ := f.KeyedLimit.Get(key)
+	return v.(*rate.Limiter)
+}
+
+// take consumes a token from the store. Errors of the store itself are returned as-is,
+// whereas exceeded rate limits are returned as gRPC status.
+func (f *ratelimitedFunction) take(ctx context.Context, key string) error {
+	for {
+		ok, retryAfter, err := f.Store.Take(ctx, key, f.RateLimit)
+		if err != nil {
+			if ctx.Err() == context.Canceled {
+				return context.Canceled
+			}
+			return err
+		}
+		if ok {
+			return nil
+		}
+		if !f.RateLimit.Block {
+			return status.Error(codes.ResourceExhausted, "too many requests")
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < retryAfter {
+			return status.Error(codes.ResourceExhausted, "rate limit would exceed context deadline")
+		}
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.Canceled {
+				return context.Canceled
+			}
+			return status.Error(codes.ResourceExhausted, ctx.Err().Error())
+		case <-time.After(retryAfter):
+		}
 	}
 }
@@ -0,0 +1,98 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RatelimitStore keeps token buckets which are shared between replicas
+type RatelimitStore interface {
+	// Take removes a token from the bucket identified by key. If the bucket is empty, Take returns false
+	// and the duration after which the next token becomes available.
+	Take(ctx context.Context, key string, limit RateLimit) (ok bool, retryAfter time.Duration, err error)
+}
+
+// redisTokenBucket implements a token bucket stored in a Redis hash. Time is taken from the Redis server
+// so that replicas with skewed clocks share the same view of the bucket.
+//
+// KEYS[1]: bucket key
+// ARGV[1]: bucket size
+// ARGV[2]: refill interval in microseconds
+//
+// Returns {allowed (0|1), retry after in microseconds}
+var redisTokenBucket = redis.NewScript(`
+redis.replicate_commands()
+
+local capacity = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+if now > ts then
+	tokens = math.min(capacity, tokens + (now - ts) / interval)
+	ts = now
+end
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) * interval)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", string.format("%.0f", ts))
+redis.call("PEXPIRE", KEYS[1], math.ceil(capacity * interval / 1000) + 1000)
+return {allowed, retry}
+`)
+
+// NewRedisRatelimitStore produces a store which keeps the token buckets in Redis
+func NewRedisRatelimitStore(client redis.UniversalClient) *RedisRatelimitStore {
+	return &RedisRatelimitStore{
+		Client: client,
+		Prefix: "grpc-ratelimit:",
+	}
+}
+
+// RedisRatelimitStore keeps token buckets in Redis
+type RedisRatelimitStore struct {
+	Client redis.UniversalClient
+	// Prefix is prepended to all bucket keys
+	Prefix string
+}
+
+var _ RatelimitStore = &RedisRatelimitStore{}
+
+// Take removes a token from the bucket identified by key
+func (s *RedisRatelimitStore) Take(ctx context.Context, key string, limit RateLimit) (ok bool, retryAfter time.Duration, err error) {
+	interval := time.Duration(limit.RefillInterval).Microseconds()
+	if interval <= 0 {
+		return false, 0, fmt.Errorf("refill interval must be at least one microsecond")
+	}
+
+	res, err := redisTokenBucket.Run(ctx, s.Client, []string{s.Prefix + key}, limit.BucketSize, interval).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("cannot take token from bucket %s: %w", key, err)
+	}
+	if len(res) != 2 {
+		return false, 0, fmt.Errorf("unexpected token bucket result: %v", res)
+	}
+
+	return res[0] == 1, time.Duration(res[1]) * time.Microsecond, nil
+}
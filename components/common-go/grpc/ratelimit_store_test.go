@@ -0,0 +1,167 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/apipb"
+
+	"github.com/gitpod-io/gitpod/common-go/util"
+)
+
+func newTestRedisStore(t *testing.T) (*RedisRatelimitStore, *miniredis.Miniredis) {
+	t.Helper()
+
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisRatelimitStore(client), s
+}
+
+func TestRedisRatelimitStore(t *testing.T) {
+	store, _ := newTestRedisStore(t)
+	ctx := context.Background()
+	limit := RateLimit{BucketSize: 2, RefillInterval: util.Duration(time.Hour)}
+
+	for i := 0; i < 2; i++ {
+		ok, _, err := store.Take(ctx, "foo", limit)
+		require.NoError(t, err)
+		require.True(t, ok, "token %d", i)
+	}
+
+	ok, retryAfter, err := store.Take(ctx, "foo", limit)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Greater(t, retryAfter, 59*time.Minute)
+	require.LessOrEqual(t, retryAfter, time.Hour)
+
+	ok, _, err = store.Take(ctx, "bar", limit)
+	require.NoError(t, err)
+	require.True(t, ok, "buckets must be independent")
+}
+
+func TestRedisRatelimitStore_Refill(t *testing.T) {
+	store, _ := newTestRedisStore(t)
+	ctx := context.Background()
+	limit := RateLimit{BucketSize: 1, RefillInterval: util.Duration(50 * time.Millisecond)}
+
+	ok, _, err := store.Take(ctx, "foo", limit)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, retryAfter, err := store.Take(ctx, "foo", limit)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	time.Sleep(retryAfter)
+	ok, _, err = store.Take(ctx, "foo", limit)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestRatelimitingInterceptor_SharedStore(t *testing.T) {
+	store, _ := newTestRedisStore(t)
+	limits := map[string]RateLimit{
+		"/test/Foo": {BucketSize: 2, RefillInterval: util.Duration(time.Hour)},
+	}
+	// two replicas share the same bucket
+	replicas := []RatelimitingInterceptor{
+		NewRatelimitingInterceptor(limits, WithRatelimitStore(store)),
+		NewRatelimitingInterceptor(limits, WithRatelimitStore(store)),
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Foo"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	for i, r := range replicas {
+		_, err := r.UnaryInterceptor()(context.Background(), &apipb.Api{}, info, handler)
+		require.NoError(t, err, "replica %d", i)
+	}
+	for i, r := range replicas {
+		_, err := r.UnaryInterceptor()(context.Background(), &apipb.Api{}, info, handler)
+		require.Equal(t, codes.ResourceExhausted, status.Code(err), "replica %d", i)
+	}
+}
+
+func TestRatelimitingInterceptor_StoreFailure(t *testing.T) {
+	store, s := newTestRedisStore(t)
+	s.Close()
+
+	r := NewRatelimitingInterceptor(map[string]RateLimit{
+		"/test/Foo": {BucketSize: 1, RefillInterval: util.Duration(time.Hour)},
+	}, WithRatelimitStore(store))
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Foo"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	_, err := r.UnaryInterceptor()(context.Background(), &apipb.Api{}, info, handler)
+	require.NoError(t, err)
+	_, err = r.UnaryInterceptor()(context.Background(), &apipb.Api{}, info, handler)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err), "per-process limit must apply when the store fails")
+}
+
+func TestRatelimitingInterceptor_StoreKeepsNoLocalLimits(t *testing.T) {
+	store, _ := newTestRedisStore(t)
+	r := NewRatelimitingInterceptor(map[string]RateLimit{
+		"/test/Foo": {BucketSize: 1, RefillInterval: util.Duration(time.Hour), Key: "name", KeyCacheSize: 10},
+	}, WithRatelimitStore(store))
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Foo"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	_, err := r.UnaryInterceptor()(context.Background(), &apipb.Api{Name: "a"}, info, handler)
+	require.NoError(t, err)
+	_, err = r.UnaryInterceptor()(context.Background(), &apipb.Api{Name: "a"}, info, handler)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	require.Zero(t, r.functions["/test/Foo"].KeyedLimit.Len(), "per-process limits must only be created when the store fails")
+	ok, _, err := store.Take(context.Background(), "/test/Foo:|a", RateLimit{BucketSize: 1, RefillInterval: util.Duration(time.Hour)})
+	require.NoError(t, err)
+	require.False(t, ok, "store keys must separate the method from the key")
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	msg *apipb.Api
+}
+
+func (s *testServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *testServerStream) RecvMsg(m interface{}) error {
+	m.(*apipb.Api).Name = s.msg.Name
+	return nil
+}
+
+func TestRatelimitingInterceptor_Stream(t *testing.T) {
+	r := NewRatelimitingInterceptor(map[string]RateLimit{
+		"/test/Global": {BucketSize: 1, RefillInterval: util.Duration(time.Hour)},
+		"/test/Keyed":  {BucketSize: 1, RefillInterval: util.Duration(time.Hour), Key: "name", KeyCacheSize: 10},
+	})
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		var req apipb.Api
+		return stream.RecvMsg(&req)
+	}
+	call := func(method, name string) error {
+		return r.StreamInterceptor()(nil, &testServerStream{msg: &apipb.Api{Name: name}}, &grpc.StreamServerInfo{FullMethod: method}, handler)
+	}
+
+	require.NoError(t, call("/test/Global", "a"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(call("/test/Global", "b")))
+
+	require.NoError(t, call("/test/Keyed", "a"))
+	require.NoError(t, call("/test/Keyed", "b"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(call("/test/Keyed", "a")))
+
+	require.NoError(t, call("/test/Unlimited", "a"))
+	require.NoError(t, call("/test/Unlimited", "a"))
+}
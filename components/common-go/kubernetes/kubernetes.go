@@ -39,6 +39,9 @@ const (
 	// TypeLabel marks the workspace type
 	TypeLabel = "workspaceType"
 
+	// WorkspaceClassLabel is the label which contains the workspace class of a workspace pod
+	WorkspaceClassLabel = "gitpod.io/workspaceClass"
+
 	// ServiceTypeLabel help differentiate between port service and IDE service
 	ServiceTypeLabel = "serviceType"
 
@@ -75,6 +78,21 @@ const (
 
 	// workspacePressureStallInfo indicates if pressure stall information should be retrieved for the workspace
 	WorkspacePressureStallInfoAnnotation = "gitpod.io/psi"
+
+	// PrebuildProjectAnnotation contains the ID of the project a prebuild workspace builds
+	PrebuildProjectAnnotation = "gitpod.io/prebuildProject"
+
+	// PrebuildBranchAnnotation contains the branch a prebuild workspace builds
+	PrebuildBranchAnnotation = "gitpod.io/prebuildBranch"
+
+	// PrebuildCommitAnnotation contains the commit SHA a prebuild workspace builds
+	PrebuildCommitAnnotation = "gitpod.io/prebuildCommit"
+
+	// PrebuildKeepOutdatedAnnotation keeps a prebuild workspace running when a prebuild of a newer commit of its branch starts
+	PrebuildKeepOutdatedAnnotation = "gitpod.io/prebuildKeepOutdated"
+
+	// PrebuildQueuedAnnotation holds back the pod of a prebuild workspace until its project may run another prebuild
+	PrebuildQueuedAnnotation = "gitpod.io/prebuildQueued"
 )
 
 // GetOWIFromObject finds the owner, workspace and instance information on a Kubernetes object using labels
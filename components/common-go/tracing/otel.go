@@ -0,0 +1,156 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelbridge "go.opentelemetry.io/otel/bridge/opentracing"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+const (
+	otlpProtocolGRPC = "grpc"
+	otlpProtocolHTTP = "http/protobuf"
+)
+
+// otlpEnabled returns true if the OTLP exporter was selected using OTEL_TRACES_EXPORTER.
+// We require an explicit opt-in because installations may point OTEL_EXPORTER_OTLP_ENDPOINT at a Jaeger collector.
+func otlpEnabled() bool {
+	if disabled, _ := strconv.ParseBool(os.Getenv("OTEL_SDK_DISABLED")); disabled {
+		return false
+	}
+	return os.Getenv("OTEL_TRACES_EXPORTER") == "otlp"
+}
+
+// otlpProtocol returns the OTLP protocol configured using the standard OpenTelemetry environment variables
+func otlpProtocol() string {
+	if p := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"); p != "" {
+		return p
+	}
+	if p := os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"); p != "" {
+		return p
+	}
+	return otlpProtocolHTTP
+}
+
+// jaegerSampler translates the Jaeger sampler types which installations configure in OTEL_TRACES_SAMPLER
+// into their OpenTelemetry equivalent. Returns nil if the sampler is to be configured by the SDK itself.
+func jaegerSampler() sdktrace.Sampler {
+	param, err := strconv.ParseFloat(os.Getenv("OTEL_TRACES_SAMPLER_ARG"), 64)
+	if err != nil {
+		param = 1
+	}
+
+	switch os.Getenv("OTEL_TRACES_SAMPLER") {
+	case "const":
+		if param == 0 {
+			return sdktrace.ParentBased(sdktrace.NeverSample())
+		}
+		return sdktrace.ParentBased(sdktrace.AlwaysSample())
+	case "probabilistic":
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(param))
+	default:
+		return nil
+	}
+}
+
+// initOTel sets up an OpenTelemetry tracer provider which exports spans using OTLP.
+// The provider is registered as global OpenTelemetry tracer provider, and bridged to the
+// global OpenTracing tracer so that all existing span helpers continue to work.
+// Trace context is propagated using W3C traceparent and baggage headers.
+func initOTel(serviceName string, options tracingOptions) (io.Closer, error) {
+	ctx := context.Background()
+
+	var client otlptrace.Client
+	switch p := otlpProtocol(); p {
+	case otlpProtocolGRPC:
+		client = otlptracegrpc.NewClient()
+	case otlpProtocolHTTP:
+		client = otlptracehttp.NewClient()
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q", p)
+	}
+
+	exporter, err := otlptrace.New(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String(serviceName),
+			semconv.ServiceVersionKey.String(os.Getenv("GITPOD_BUILD_VERSION")),
+			semconv.ServiceNamespaceKey.String("gitpod"),
+			attribute.String("service.build.commit", os.Getenv("GITPOD_BUILD_GIT_COMMIT")),
+		),
+		// OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME take precedence
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create OpenTelemetry resource: %w", err)
+	}
+
+	providerOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	}
+	if sampler := jaegerSampler(); sampler != nil {
+		providerOpts = append(providerOpts, sdktrace.WithSampler(sampler))
+	}
+	if options.prometheusReporter != nil {
+		promrep := options.prometheusReporter
+		err = promrep.RegisterMetrics()
+		if err != nil {
+			log.WithError(err).Debug("cannot register PrometheusReporter metrics - not using this reporter")
+		} else {
+			providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(promrep))
+		}
+	}
+	provider := sdktrace.NewTracerProvider(providerOpts...)
+
+	propagator := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	otel.SetTextMapPropagator(propagator)
+
+	bridge, wrapper := otelbridge.NewTracerPair(provider.Tracer(serviceName))
+	bridge.SetTextMapPropagator(propagator)
+	bridge.SetWarningHandler(func(msg string) {
+		log.WithField("service", serviceName).Debug(msg)
+	})
+	otel.SetTracerProvider(wrapper)
+	opentracing.SetGlobalTracer(bridge)
+
+	return &otelCloser{provider: provider}, nil
+}
+
+type otelCloser struct {
+	provider *sdktrace.TracerProvider
+}
+
+// Close flushes all pending spans and shuts the tracer provider down
+func (c *otelCloser) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return c.provider.Shutdown(ctx)
+}
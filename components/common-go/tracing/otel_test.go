@@ -0,0 +1,140 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package tracing
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	otelbridge "go.opentelemetry.io/otel/bridge/opentracing"
+)
+
+func TestInitOTel(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "otlp")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+	t.Cleanup(func() {
+		opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+	})
+
+	closer := Init("tracing-test")
+	if closer == nil {
+		t.Fatal("expected a closer")
+	}
+	defer closer.Close()
+
+	if _, ok := opentracing.GlobalTracer().(*otelbridge.BridgeTracer); !ok {
+		t.Fatalf("expected the global tracer to be bridged to OpenTelemetry, got %T", opentracing.GlobalTracer())
+	}
+
+	span := opentracing.StartSpan("test")
+	defer span.Finish()
+
+	header := make(http.Header)
+	err := opentracing.GlobalTracer().Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	traceparent := header.Get("traceparent")
+	if !strings.HasPrefix(traceparent, "00-") {
+		t.Errorf("expected W3C traceparent header, got %q", traceparent)
+	}
+
+	traceID := GetTraceID(span)
+	if traceID == "" {
+		t.Fatal("expected a trace ID")
+	}
+	spanCtx := FromTraceID(traceID)
+	if spanCtx == nil {
+		t.Fatal("cannot restore span context from trace ID")
+	}
+
+	child := opentracing.StartSpan("child", opentracing.ChildOf(spanCtx))
+	defer child.Finish()
+	childHeader := make(http.Header)
+	err = opentracing.GlobalTracer().Inject(child.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(childHeader))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act, exp := strings.Split(childHeader.Get("traceparent"), "-")[1], strings.Split(traceparent, "-")[1]; act != exp {
+		t.Errorf("child span is not part of the same trace: got %s, expected %s", act, exp)
+	}
+}
+
+func TestOTLPProtocol(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Env         map[string]string
+		Expectation string
+	}{
+		{Name: "default", Expectation: otlpProtocolHTTP},
+		{Name: "generic", Env: map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc"}, Expectation: otlpProtocolGRPC},
+		{Name: "traces override", Env: map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc", "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/protobuf"}, Expectation: otlpProtocolHTTP},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "")
+			t.Setenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "")
+			for k, v := range test.Env {
+				t.Setenv(k, v)
+			}
+
+			if act := otlpProtocol(); act != test.Expectation {
+				t.Errorf("unexpected protocol: got %s, expected %s", act, test.Expectation)
+			}
+		})
+	}
+}
+
+func TestOTLPEnabled(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Env         map[string]string
+		Expectation bool
+	}{
+		{Name: "endpoint only", Env: map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://jaeger:14268/api/traces"}, Expectation: false},
+		{Name: "otlp exporter", Env: map[string]string{"OTEL_TRACES_EXPORTER": "otlp"}, Expectation: true},
+		{Name: "sdk disabled", Env: map[string]string{"OTEL_TRACES_EXPORTER": "otlp", "OTEL_SDK_DISABLED": "true"}, Expectation: false},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			for _, k := range []string{"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_TRACES_EXPORTER", "OTEL_SDK_DISABLED"} {
+				t.Setenv(k, test.Env[k])
+			}
+
+			if act := otlpEnabled(); act != test.Expectation {
+				t.Errorf("unexpected result: got %v, expected %v", act, test.Expectation)
+			}
+		})
+	}
+}
+
+func TestJaegerSampler(t *testing.T) {
+	tests := []struct {
+		Sampler     string
+		Arg         string
+		Expectation string
+	}{
+		{Sampler: "const", Arg: "1", Expectation: "ParentBased{root:AlwaysOnSampler"},
+		{Sampler: "const", Arg: "0", Expectation: "ParentBased{root:AlwaysOffSampler"},
+		{Sampler: "probabilistic", Arg: "0.5", Expectation: "ParentBased{root:TraceIDRatioBased{0.5}"},
+		{Sampler: "parentbased_always_on", Expectation: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.Sampler+test.Arg, func(t *testing.T) {
+			t.Setenv("OTEL_TRACES_SAMPLER", test.Sampler)
+			t.Setenv("OTEL_TRACES_SAMPLER_ARG", test.Arg)
+
+			var act string
+			if s := jaegerSampler(); s != nil {
+				act = s.Description()
+			}
+			if !strings.HasPrefix(act, test.Expectation) || (test.Expectation == "" && act != "") {
+				t.Errorf("unexpected sampler: got %q, expected %q", act, test.Expectation)
+			}
+		})
+	}
+}
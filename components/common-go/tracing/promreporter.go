@@ -5,13 +5,15 @@
 package tracing
 
 import (
+	"context"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	jaeger "github.com/uber/jaeger-client-go"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
-// PromReporter reports Jaeger span durations to Prometheus
+// PromReporter reports Jaeger or OpenTelemetry span durations to Prometheus
 type PromReporter struct {
 	Operations map[string]SpanMetricMapping
 
@@ -46,15 +48,35 @@ func (r *PromReporter) RegisterMetrics() error {
 
 // Report reports a span as Prometheus metric
 func (r *PromReporter) Report(span *jaeger.Span) {
-	metric, ok := r.metrics[span.OperationName()]
+	r.observe(span.OperationName(), span.Duration())
+}
+
+func (r *PromReporter) observe(operation string, duration time.Duration) {
+	metric, ok := r.metrics[operation]
 	if !ok {
 		return
 	}
 
-	metric.Observe(float64(span.Duration() / time.Millisecond))
+	metric.Observe(float64(duration / time.Millisecond))
 }
 
 // Close implements Close() method of Reporter by closing each underlying reporter.
 func (r *PromReporter) Close() {
 
 }
+
+var _ sdktrace.SpanProcessor = &PromReporter{}
+
+// OnStart implements the OpenTelemetry SpanProcessor interface
+func (r *PromReporter) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {}
+
+// OnEnd reports an OpenTelemetry span as Prometheus metric
+func (r *PromReporter) OnEnd(s sdktrace.ReadOnlySpan) {
+	r.observe(s.Name(), s.EndTime().Sub(s.StartTime()))
+}
+
+// Shutdown implements the OpenTelemetry SpanProcessor interface
+func (r *PromReporter) Shutdown(ctx context.Context) error { return nil }
+
+// ForceFlush implements the OpenTelemetry SpanProcessor interface
+func (r *PromReporter) ForceFlush(ctx context.Context) error { return nil }
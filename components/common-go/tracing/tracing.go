@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -37,8 +38,26 @@ func WithPrometheusReporter(p *PromReporter) Option {
 	}
 }
 
-// Init initializes tracing for this application
+// Init initializes tracing for this application.
+//
+// If OTEL_TRACES_EXPORTER is set to otlp, spans are exported using OpenTelemetry to the endpoint configured
+// using the standard OTEL_EXPORTER_OTLP_* environment variables, and trace context is propagated using W3C traceparent headers.
+// Otherwise we fall back to the Jaeger client configured through the JAEGER_* environment variables.
+// In either case the global OpenTracing tracer is set, so that all helpers in this package keep working.
 func Init(serviceName string, opts ...Option) io.Closer {
+	var options tracingOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if otlpEnabled() {
+		closer, err := initOTel(serviceName, options)
+		if err == nil {
+			return closer
+		}
+		log.WithError(err).Warn("cannot initialize OpenTelemetry tracing - falling back to Jaeger")
+	}
+
 	cfg, err := jaegercfg.FromEnv()
 	if err != nil {
 		log.WithError(err).Debug("cannot initialize Jaeger tracer from env")
@@ -60,11 +79,6 @@ func Init(serviceName string, opts ...Option) io.Closer {
 		return nil
 	}
 
-	var options tracingOptions
-	for _, opt := range opts {
-		opt(&options)
-	}
-
 	if options.prometheusReporter != nil {
 		promrep := options.prometheusReporter
 		err = promrep.RegisterMetrics()
@@ -111,10 +125,21 @@ func ApplyOWI(span opentracing.Span, owi logrus.Fields) {
 }
 
 // GetTraceID extracts the uber-trace-id from the context which encodes
-// {trace-id}:{span-id}:{parent-span-id}:{flags}
+// {trace-id}:{span-id}:{parent-span-id}:{flags}.
+// Tracers which do not support the binary format (e.g. OpenTelemetry) have their
+// text map representation encoded instead, e.g. the W3C traceparent header.
 func GetTraceID(span opentracing.Span) string {
 	var buf bytes.Buffer
 	err := opentracing.GlobalTracer().Inject(span.Context(), opentracing.Binary, &buf)
+	if err == opentracing.ErrUnsupportedFormat {
+		carrier := make(opentracing.TextMapCarrier)
+		err = opentracing.GlobalTracer().Inject(span.Context(), opentracing.TextMap, carrier)
+		if err != nil {
+			return ""
+		}
+		buf.Reset()
+		err = json.NewEncoder(&buf).Encode(carrier)
+	}
 	if err != nil {
 		return ""
 	}
@@ -136,7 +161,17 @@ func FromTraceID(traceID string) opentracing.SpanContext {
 		return nil
 	}
 
-	spanCtx, err := opentracing.GlobalTracer().Extract(opentracing.Binary, bytes.NewReader(decoded))
+	var spanCtx opentracing.SpanContext
+	if bytes.HasPrefix(decoded, []byte("{")) {
+		carrier := make(opentracing.TextMapCarrier)
+		err = json.Unmarshal(decoded, &carrier)
+		if err != nil {
+			return nil
+		}
+		spanCtx, err = opentracing.GlobalTracer().Extract(opentracing.TextMap, carrier)
+	} else {
+		spanCtx, err = opentracing.GlobalTracer().Extract(opentracing.Binary, bytes.NewReader(decoded))
+	}
 	if err != nil {
 		// we don't want to log here as this function will be called very often and if wsman is used without
 		// tracing, this would get rather spammy
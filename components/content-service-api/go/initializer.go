@@ -21,6 +21,7 @@ func GetCheckoutLocationsFromInitializer(init *WorkspaceInitializer) []string {
 			res = append(res, spec.Git.CheckoutLocation)
 		case *WorkspaceInitializer_Backup:
 			res = append(res, spec.Backup.CheckoutLocation)
+			res = append(res, spec.Backup.AdditionalCheckoutLocations...)
 
 		case *WorkspaceInitializer_Prebuild:
 			// walkInitializer will visit the Git initializer
@@ -102,6 +102,18 @@ func TestGetCheckoutLocationsFromInitializer(t *testing.T) {
 			},
 			Expectation: "/foo,/bar",
 		},
+		{
+			Name: "backup initializer with additional repositories",
+			Initializer: &api.WorkspaceInitializer{
+				Spec: &api.WorkspaceInitializer_Backup{
+					Backup: &api.FromBackupInitializer{
+						CheckoutLocation:            "/foo",
+						AdditionalCheckoutLocations: []string{"/bar", "/baz"},
+					},
+				},
+			},
+			Expectation: "/foo,/bar,/baz",
+		},
 		{
 			Name: "nil initializer",
 		},
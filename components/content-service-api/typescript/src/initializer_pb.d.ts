@@ -305,6 +305,10 @@ export class FromBackupInitializer extends jspb.Message {
     setCheckoutLocation(value: string): FromBackupInitializer;
     getFromVolumeSnapshot(): boolean;
     setFromVolumeSnapshot(value: boolean): FromBackupInitializer;
+    clearAdditionalCheckoutLocationsList(): void;
+    getAdditionalCheckoutLocationsList(): Array<string>;
+    setAdditionalCheckoutLocationsList(value: Array<string>): FromBackupInitializer;
+    addAdditionalCheckoutLocations(value: string, index?: number): string;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): FromBackupInitializer.AsObject;
@@ -320,6 +324,7 @@ export namespace FromBackupInitializer {
     export type AsObject = {
         checkoutLocation: string,
         fromVolumeSnapshot: boolean,
+        additionalCheckoutLocationsList: Array<string>,
     }
 }
 
@@ -235,7 +235,7 @@ if (goog.DEBUG && !COMPILED) {
  * @constructor
  */
 proto.contentservice.FromBackupInitializer = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.contentservice.FromBackupInitializer.repeatedFields_, null);
 };
 goog.inherits(proto.contentservice.FromBackupInitializer, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
@@ -2321,6 +2321,13 @@ proto.contentservice.PrebuildInitializer.prototype.clearGitList = function() {
 
 
 
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.contentservice.FromBackupInitializer.repeatedFields_ = [3];
+
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -2353,7 +2360,8 @@ proto.contentservice.FromBackupInitializer.prototype.toObject = function(opt_inc
 proto.contentservice.FromBackupInitializer.toObject = function(includeInstance, msg) {
   var f, obj = {
     checkoutLocation: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    fromVolumeSnapshot: jspb.Message.getBooleanFieldWithDefault(msg, 2, false)
+    fromVolumeSnapshot: jspb.Message.getBooleanFieldWithDefault(msg, 2, false),
+    additionalCheckoutLocationsList: (f = jspb.Message.getRepeatedField(msg, 3)) == null ? undefined : f
   };
 
   if (includeInstance) {
@@ -2398,6 +2406,10 @@ proto.contentservice.FromBackupInitializer.deserializeBinaryFromReader = functio
       var value = /** @type {boolean} */ (reader.readBool());
       msg.setFromVolumeSnapshot(value);
       break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addAdditionalCheckoutLocations(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -2441,6 +2453,13 @@ proto.contentservice.FromBackupInitializer.serializeBinaryToWriter = function(me
       f
     );
   }
+  f = message.getAdditionalCheckoutLocationsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      3,
+      f
+    );
+  }
 };
 
 
@@ -2480,6 +2499,43 @@ proto.contentservice.FromBackupInitializer.prototype.setFromVolumeSnapshot = fun
 };
 
 
+/**
+ * repeated string additional_checkout_locations = 3;
+ * @return {!Array<string>}
+ */
+proto.contentservice.FromBackupInitializer.prototype.getAdditionalCheckoutLocationsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 3));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.contentservice.FromBackupInitializer} returns this
+ */
+proto.contentservice.FromBackupInitializer.prototype.setAdditionalCheckoutLocationsList = function(value) {
+  return jspb.Message.setField(this, 3, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.contentservice.FromBackupInitializer} returns this
+ */
+proto.contentservice.FromBackupInitializer.prototype.addAdditionalCheckoutLocations = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 3, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.contentservice.FromBackupInitializer} returns this
+ */
+proto.contentservice.FromBackupInitializer.prototype.clearAdditionalCheckoutLocationsList = function() {
+  return this.setAdditionalCheckoutLocationsList([]);
+};
+
+
 
 /**
  * List of repeated fields within this message type.
@@ -143,3 +143,46 @@ export namespace WorkspaceSnapshotExistsResponse {
         exists: boolean,
     }
 }
+
+export class DeleteSnapshotRequest extends jspb.Message {
+    getOwnerId(): string;
+    setOwnerId(value: string): DeleteSnapshotRequest;
+    getWorkspaceId(): string;
+    setWorkspaceId(value: string): DeleteSnapshotRequest;
+    getFilename(): string;
+    setFilename(value: string): DeleteSnapshotRequest;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): DeleteSnapshotRequest.AsObject;
+    static toObject(includeInstance: boolean, msg: DeleteSnapshotRequest): DeleteSnapshotRequest.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: DeleteSnapshotRequest, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): DeleteSnapshotRequest;
+    static deserializeBinaryFromReader(message: DeleteSnapshotRequest, reader: jspb.BinaryReader): DeleteSnapshotRequest;
+}
+
+export namespace DeleteSnapshotRequest {
+    export type AsObject = {
+        ownerId: string,
+        workspaceId: string,
+        filename: string,
+    }
+}
+
+export class DeleteSnapshotResponse extends jspb.Message {
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): DeleteSnapshotResponse.AsObject;
+    static toObject(includeInstance: boolean, msg: DeleteSnapshotResponse): DeleteSnapshotResponse.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: DeleteSnapshotResponse, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): DeleteSnapshotResponse;
+    static deserializeBinaryFromReader(message: DeleteSnapshotResponse, reader: jspb.BinaryReader): DeleteSnapshotResponse;
+}
+
+export namespace DeleteSnapshotResponse {
+    export type AsObject = {
+    }
+}
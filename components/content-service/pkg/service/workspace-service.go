@@ -129,3 +129,29 @@ func (cs *WorkspaceService) WorkspaceSnapshotExists(ctx context.Context, req *ap
 		Exists: exists,
 	}, nil
 }
+
+// DeleteSnapshot deletes a single snapshot of a workspace
+func (cs *WorkspaceService) DeleteSnapshot(ctx context.Context, req *api.DeleteSnapshotRequest) (resp *api.DeleteSnapshotResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DeleteSnapshot")
+	span.SetTag("user", req.OwnerId)
+	span.SetTag("workspaceId", req.WorkspaceId)
+	span.SetTag("filename", req.Filename)
+	defer tracing.FinishSpan(span, &err)
+
+	if req.Filename == "" || strings.Contains(req.Filename, "/") {
+		return nil, status.Error(codes.InvalidArgument, "filename must be the name of a single snapshot")
+	}
+
+	blobName := cs.s.BackupObject(req.OwnerId, req.WorkspaceId, req.Filename)
+	err = cs.s.DeleteObject(ctx, cs.s.Bucket(req.OwnerId), &storage.DeleteObjectQuery{Name: blobName})
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			log.WithError(err).Debug("deleting workspace snapshot: NotFound, ", blobName)
+			return &api.DeleteSnapshotResponse{}, nil
+		}
+		log.WithError(err).Error("error deleting workspace snapshot: ", blobName)
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
+	return &api.DeleteSnapshotResponse{}, nil
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// dotfilesReinstallCmd represents the dotfiles reinstall command
+var dotfilesReinstallCmd = &cobra.Command{
+	Use:   "reinstall",
+	Short: "Clones the dotfiles repositories from scratch and runs their installation",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateDotfiles(cmd.Context(), true)
+	},
+}
+
+func init() {
+	dotfilesReinstallCmd.Flags().BoolVarP(&noColor, "no-color", "", false, "Disable output colorization")
+	dotfilesCmd.AddCommand(dotfilesReinstallCmd)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var dotfilesStatusOpts struct {
+	Log bool
+}
+
+// dotfilesStatusCmd represents the dotfiles status command
+var dotfilesStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Shows the status of the dotfiles repositories",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+		defer cancel()
+
+		client, err := supervisor.New(ctx)
+		if err != nil {
+			return xerrors.Errorf("cannot get dotfiles status: %w", err)
+		}
+		defer client.Close()
+
+		resp, err := client.Status.DotfilesStatus(ctx, &api.DotfilesStatusRequest{IncludeLog: dotfilesStatusOpts.Log})
+		if err != nil {
+			return xerrors.Errorf("cannot get dotfiles status: %w", err)
+		}
+
+		if len(resp.Sources) == 0 {
+			fmt.Println("No dotfiles repository configured")
+			return nil
+		}
+
+		if dotfilesStatusOpts.Log {
+			fmt.Println(resp.Log)
+		}
+		printDotfilesStatus(resp.Sources)
+		return nil
+	},
+}
+
+func init() {
+	dotfilesStatusCmd.Flags().BoolVar(&dotfilesStatusOpts.Log, "log", false, "Print the output of the last installation")
+	dotfilesStatusCmd.Flags().BoolVarP(&noColor, "no-color", "", false, "Disable output colorization")
+	dotfilesCmd.AddCommand(dotfilesStatusCmd)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/utils"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dotfilesUpdateCmd represents the dotfiles update command
+var dotfilesUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Pulls the latest changes of the dotfiles repositories and installs them if they changed",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateDotfiles(cmd.Context(), false)
+	},
+}
+
+func updateDotfiles(ctx context.Context, reinstall bool) error {
+	// cloning and installing each repository can take a couple of minutes
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	client, err := supervisor.New(ctx)
+	if err != nil {
+		return xerrors.Errorf("cannot update dotfiles: %w", err)
+	}
+	defer client.Close()
+
+	resp, err := client.Control.UpdateDotfiles(ctx, &api.UpdateDotfilesRequest{Reinstall: reinstall})
+	if status.Code(err) == codes.FailedPrecondition {
+		msg := "No dotfiles repository is configured.\nYou can configure your dotfiles repository in your Gitpod preferences.\n"
+		return GpError{Err: err, Message: msg, OutCome: utils.Outcome_UserErr}
+	}
+	if err != nil {
+		return xerrors.Errorf("cannot update dotfiles: %w", err)
+	}
+
+	fmt.Println(resp.Log)
+	printDotfilesStatus(resp.Sources)
+	for _, source := range resp.Sources {
+		if source.State == api.DotfilesSourceStatus_failed {
+			return GpError{Err: xerrors.Errorf("installing dotfiles from %s failed", source.Repository), OutCome: utils.Outcome_UserErr, Silence: true}
+		}
+	}
+	return nil
+}
+
+func init() {
+	dotfilesUpdateCmd.Flags().BoolVarP(&noColor, "no-color", "", false, "Disable output colorization")
+	dotfilesCmd.AddCommand(dotfilesUpdateCmd)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/utils"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// dotfilesCmd represents the dotfiles command
+var dotfilesCmd = &cobra.Command{
+	Use:   "dotfiles",
+	Short: "Interact with the dotfiles repositories installed in the workspace",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			_ = cmd.Help()
+		}
+		return nil
+	},
+}
+
+func printDotfilesStatus(sources []*api.DotfilesSourceStatus) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Repository", "Ref", "State", "Commit", "Install Script", "Message"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+
+	mapStateToColor := map[api.DotfilesSourceStatus_State]int{
+		api.DotfilesSourceStatus_pending:    tablewriter.FgHiBlackColor,
+		api.DotfilesSourceStatus_installing: tablewriter.FgYellowColor,
+		api.DotfilesSourceStatus_installed:  tablewriter.FgHiGreenColor,
+		api.DotfilesSourceStatus_failed:     tablewriter.FgRedColor,
+	}
+
+	for _, source := range sources {
+		commit := source.Commit
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+
+		colors := []tablewriter.Colors{}
+		if !noColor && utils.ColorsEnabled() {
+			colors = []tablewriter.Colors{{}, {}, {mapStateToColor[source.State]}, {}, {}, {}}
+		}
+
+		table.Rich([]string{source.Repository, source.Ref, source.State.String(), commit, source.InstallScript, source.Message}, colors)
+	}
+
+	table.Render()
+}
+
+func init() {
+	rootCmd.AddCommand(dotfilesCmd)
+}
@@ -5,10 +5,15 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/gitpod"
@@ -42,6 +47,7 @@ var infoCmd = &cobra.Command{
 			WorkspaceUrl:        wsInfo.WorkspaceUrl,
 			WorkspaceContextUrl: wsInfo.WorkspaceContextUrl,
 			ClusterHost:         wsInfo.WorkspaceClusterHost,
+			Repositories:        repositoriesInfo(ctx, os.Getenv("GITPOD_REPO_ROOTS")),
 		}
 
 		if infoCmdOpts.Json {
@@ -61,6 +67,55 @@ type infoData struct {
 	WorkspaceUrl        string                                    `json:"workspace_url"`
 	WorkspaceContextUrl string                                    `json:"workspace_context_url"`
 	ClusterHost         string                                    `json:"cluster_host"`
+	Repositories        []repositoryInfo                          `json:"repositories,omitempty"`
+}
+
+type repositoryInfo struct {
+	Location     string `json:"location"`
+	Branch       string `json:"branch"`
+	LatestCommit string `json:"latest_commit"`
+	ChangedFiles int    `json:"changed_files"`
+}
+
+// repositoriesInfo describes the Git repositories of this workspace, i.e. the main repository
+// followed by the additional repositories of a multi-repository workspace.
+func repositoriesInfo(ctx context.Context, repoRoots string) []repositoryInfo {
+	var res []repositoryInfo
+	for _, repoRoot := range strings.Split(repoRoots, ",") {
+		if repoRoot == "" {
+			continue
+		}
+		out, err := exec.CommandContext(ctx, "git", "-C", repoRoot, "status", "--porcelain=v2", "--branch").Output()
+		if err != nil {
+			// the repository might not have been cloned (yet)
+			continue
+		}
+		res = append(res, parseRepositoryStatus(repoRoot, out))
+	}
+	return res
+}
+
+// parseRepositoryStatus parses the output of "git status --porcelain=v2 --branch".
+func parseRepositoryStatus(location string, status []byte) repositoryInfo {
+	res := repositoryInfo{Location: location}
+	scanner := bufio.NewScanner(bytes.NewReader(status))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			res.Branch = strings.TrimPrefix(line, "# branch.head ")
+		case strings.HasPrefix(line, "# branch.oid "):
+			res.LatestCommit = strings.TrimPrefix(line, "# branch.oid ")
+			// repositories without commits report "(initial)"
+			if len(res.LatestCommit) > 8 && !strings.HasPrefix(res.LatestCommit, "(") {
+				res.LatestCommit = res.LatestCommit[:8]
+			}
+		case strings.HasPrefix(line, "#"), line == "":
+		default:
+			res.ChangedFiles++
+		}
+	}
+	return res
 }
 
 func outputInfo(info *infoData) {
@@ -75,6 +130,19 @@ func outputInfo(info *infoData) {
 	table.Append([]string{"Workspace Context URL", info.WorkspaceContextUrl})
 	table.Append([]string{"Cluster host", info.ClusterHost})
 	table.Render()
+
+	if len(info.Repositories) == 0 {
+		return
+	}
+	fmt.Println()
+	repos := tablewriter.NewWriter(os.Stdout)
+	repos.SetHeader([]string{"Repository", "Branch", "Commit", "Changed files"})
+	repos.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	repos.SetCenterSeparator("|")
+	for _, repo := range info.Repositories {
+		repos.Append([]string{repo.Location, repo.Branch, repo.LatestCommit, strconv.Itoa(repo.ChangedFiles)})
+	}
+	repos.Render()
 }
 
 func init() {
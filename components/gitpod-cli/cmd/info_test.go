@@ -0,0 +1,54 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRepositoryStatus(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Status   string
+		Expected repositoryInfo
+	}{
+		{
+			Name: "clean",
+			Status: `# branch.oid 5b2a1c8e9f0d3e4a6b7c8d9e0f1a2b3c4d5e6f70
+# branch.head main
+# branch.upstream origin/main
+# branch.ab +0 -0
+`,
+			Expected: repositoryInfo{Location: "/workspace/backend", Branch: "main", LatestCommit: "5b2a1c8e"},
+		},
+		{
+			Name: "changes",
+			Status: `# branch.oid 5b2a1c8e9f0d3e4a6b7c8d9e0f1a2b3c4d5e6f70
+# branch.head feature
+1 .M N... 100644 100644 100644 3f2a 3f2a service.go
+? notes.txt
+`,
+			Expected: repositoryInfo{Location: "/workspace/backend", Branch: "feature", LatestCommit: "5b2a1c8e", ChangedFiles: 2},
+		},
+		{
+			Name: "no commits yet",
+			Status: `# branch.oid (initial)
+# branch.head main
+`,
+			Expected: repositoryInfo{Location: "/workspace/backend", Branch: "main", LatestCommit: "(initial)"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			actual := parseRepositoryStatus("/workspace/backend", []byte(test.Status))
+			if diff := cmp.Diff(test.Expected, actual); diff != "" {
+				t.Errorf("unexpected repository info (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
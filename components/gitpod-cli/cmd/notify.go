@@ -0,0 +1,115 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/utils"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var notifyOpts struct {
+	Level  string
+	OnExit bool
+}
+
+// notifyCmd represents the notify command
+var notifyCmd = &cobra.Command{
+	Use:   "notify <message> [--on-exit -- <command> [args...]]",
+	Short: "Sends a notification to the user",
+	Long: `Sends a notification to the user. The notification is shown in the IDE, and forwarded
+to the configured notification sinks if no IDE is attached.
+
+With --on-exit the given command is run first, and the notification is sent once it exits.
+The notification reports whether the command failed, and gp exits with the exit code of the command.`,
+	Example: `  gp notify "Deployment is ready"
+  gp notify "Tests finished" --on-exit -- go test ./...`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		level, ok := api.NotifyRequest_Level_value[strings.ToUpper(notifyOpts.Level)]
+		if !ok {
+			return GpError{Err: xerrors.Errorf("unknown level %s, must be one of info, warning or error", notifyOpts.Level), OutCome: utils.Outcome_UserErr}
+		}
+		req := &api.NotifyRequest{
+			Level:   api.NotifyRequest_Level(level),
+			Message: args[0],
+		}
+
+		if !notifyOpts.OnExit {
+			if len(args) > 1 {
+				return GpError{Err: xerrors.Errorf("unexpected arguments, use --on-exit to run a command"), OutCome: utils.Outcome_UserErr}
+			}
+			return sendNotification(req)
+		}
+
+		if len(args) < 2 {
+			return GpError{Err: xerrors.Errorf("--on-exit requires a command"), OutCome: utils.Outcome_UserErr}
+		}
+		// SIGINT and SIGTERM are handled by the root command, such that we keep running
+		// until the command exits and can notify the user about it
+		child := exec.Command(args[1], args[2:]...)
+		child.Stdin = os.Stdin
+		child.Stdout = os.Stdout
+		child.Stderr = os.Stderr
+		err := child.Run()
+
+		exitCode := 0
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		} else if err != nil {
+			exitCode = 127
+			fmt.Fprintf(os.Stderr, "cannot run %s: %v\n", args[1], err)
+		}
+
+		if exitCode == 0 {
+			req.Message = fmt.Sprintf("%s (%s succeeded)", req.Message, args[1])
+		} else {
+			req.Level = api.NotifyRequest_ERROR
+			req.Message = fmt.Sprintf("%s (%s failed with exit code %d)", req.Message, args[1], exitCode)
+		}
+		if err := sendNotification(req); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		if exitCode != 0 {
+			return GpError{OutCome: utils.Outcome_UserErr, Silence: true, ExitCode: &exitCode}
+		}
+		return nil
+	},
+}
+
+func sendNotification(req *api.NotifyRequest) error {
+	// the command context might have been cancelled by a signal already
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := supervisor.New(ctx)
+	if err != nil {
+		return xerrors.Errorf("cannot send notification: %w", err)
+	}
+	defer client.Close()
+
+	_, err = client.Notification.Notify(ctx, req)
+	if err != nil {
+		return xerrors.Errorf("cannot send notification: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	notifyCmd.Flags().StringVarP(&notifyOpts.Level, "level", "l", "info", "level of the notification: info, warning or error")
+	notifyCmd.Flags().BoolVar(&notifyOpts.OnExit, "on-exit", false, "run the given command and send the notification once it exits")
+	rootCmd.AddCommand(notifyCmd)
+}
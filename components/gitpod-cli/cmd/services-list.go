@@ -0,0 +1,87 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/utils"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// listServicesCmd represents the services list command
+var listServicesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the workspace services and their health",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+		defer cancel()
+
+		client, err := supervisor.New(ctx)
+		if err != nil {
+			return xerrors.Errorf("cannot get service list: %w", err)
+		}
+		defer client.Close()
+
+		services, err := client.GetServicesList(ctx)
+		if err != nil {
+			return xerrors.Errorf("cannot get service list: %w", err)
+		}
+
+		if len(services) == 0 {
+			fmt.Println("No services configured")
+			return nil
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "State", "Health", "Restarts", "PID", "Message"})
+		table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+		table.SetCenterSeparator("|")
+
+		mapStateToColor := map[api.ServiceStatus_State]int{
+			api.ServiceStatus_starting: tablewriter.FgYellowColor,
+			api.ServiceStatus_running:  tablewriter.FgHiGreenColor,
+			api.ServiceStatus_backoff:  tablewriter.FgRedColor,
+			api.ServiceStatus_stopped:  tablewriter.FgHiBlackColor,
+		}
+
+		for _, service := range services {
+			health, healthColor := "ready", tablewriter.FgHiGreenColor
+			if !service.Healthy {
+				health, healthColor = "unhealthy", tablewriter.FgRedColor
+			} else if !service.Ready {
+				health, healthColor = "not ready", tablewriter.FgYellowColor
+			}
+
+			pid := ""
+			if service.Pid != 0 {
+				pid = fmt.Sprint(service.Pid)
+			}
+
+			colors := []tablewriter.Colors{}
+			if !noColor && utils.ColorsEnabled() {
+				colors = []tablewriter.Colors{{}, {mapStateToColor[service.State]}, {healthColor}, {}, {}, {}}
+			}
+
+			table.Rich([]string{service.Name, service.State.String(), health, fmt.Sprint(service.Restarts), pid, service.Message}, colors)
+		}
+
+		table.Render()
+		return nil
+	},
+}
+
+func init() {
+	listServicesCmd.Flags().BoolVarP(&noColor, "no-color", "", false, "Disable output colorization")
+	servicesCmd.AddCommand(listServicesCmd)
+}
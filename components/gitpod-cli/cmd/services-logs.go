@@ -0,0 +1,83 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/utils"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var servicesLogsCmdOpts struct {
+	Follow bool
+}
+
+// servicesLogsCmd represents the services logs command
+var servicesLogsCmd = &cobra.Command{
+	Use:   "logs <name>",
+	Short: "Print the output of a workspace service",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+		defer cancel()
+
+		client, err := supervisor.New(ctx)
+		if err != nil {
+			return xerrors.Errorf("cannot get service logs: %w", err)
+		}
+		defer client.Close()
+
+		service, err := client.GetService(ctx, args[0])
+		if err != nil {
+			return xerrors.Errorf("cannot get service logs: %w", err)
+		}
+		if service == nil {
+			msg := fmt.Sprintf("The service %s was not found.\nUse 'gp services list' to list the configured services.\n", args[0])
+			return GpError{Message: msg, OutCome: utils.Outcome_UserErr}
+		}
+
+		file, err := os.Open(service.LogFile)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("cannot open service logs: %w", err)
+		}
+		defer file.Close()
+
+		_, err = io.Copy(os.Stdout, file)
+		if err != nil {
+			return xerrors.Errorf("cannot read service logs: %w", err)
+		}
+		if !servicesLogsCmdOpts.Follow {
+			return nil
+		}
+
+		for {
+			select {
+			case <-cmd.Context().Done():
+				return nil
+			case <-time.After(500 * time.Millisecond):
+			}
+			_, err = io.Copy(os.Stdout, file)
+			if err != nil {
+				return xerrors.Errorf("cannot read service logs: %w", err)
+			}
+		}
+	},
+}
+
+func init() {
+	servicesCmd.AddCommand(servicesLogsCmd)
+
+	servicesLogsCmd.Flags().BoolVarP(&servicesLogsCmdOpts.Follow, "follow", "f", false, "follow the log output")
+}
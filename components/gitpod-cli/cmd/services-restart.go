@@ -0,0 +1,52 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/utils"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// restartServiceCmd represents the services restart command
+var restartServiceCmd = &cobra.Command{
+	Use:   "restart <name>",
+	Short: "Restart a workspace service",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+		defer cancel()
+
+		client, err := supervisor.New(ctx)
+		if err != nil {
+			return xerrors.Errorf("cannot restart service: %w", err)
+		}
+		defer client.Close()
+
+		_, err = client.Control.RestartService(ctx, &api.RestartServiceRequest{Name: args[0]})
+		if status.Code(err) == codes.NotFound {
+			msg := fmt.Sprintf("The service %s was not found.\nUse 'gp services list' to list the configured services.\n", args[0])
+			return GpError{Err: err, Message: msg, OutCome: utils.Outcome_UserErr}
+		}
+		if err != nil {
+			return xerrors.Errorf("cannot restart service: %w", err)
+		}
+
+		fmt.Printf("Restarting service %s\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	servicesCmd.AddCommand(restartServiceCmd)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/gitpod"
 	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
 	"github.com/sourcegraph/jsonrpc2"
 	"github.com/spf13/cobra"
 )
@@ -34,40 +35,49 @@ var snapshotCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		client, err := gitpod.ConnectToServer(ctx, wsInfo, []string{
-			"function:takeSnapshot",
-			"function:waitForSnapshot",
-			"resource:workspace::" + wsInfo.WorkspaceId + "::get/update",
-		})
+		snapshotId, err := takeSnapshot(ctx, wsInfo)
 		if err != nil {
 			return err
 		}
-		defer client.Close()
-		snapshotId, err := client.TakeSnapshot(ctx, &protocol.TakeSnapshotOptions{
-			WorkspaceID: wsInfo.WorkspaceId,
-			DontWait:    true,
-		})
-		if err != nil {
-			return err
-		}
-		for ctx.Err() == nil {
-			err = client.WaitForSnapshot(ctx, snapshotId)
-			if err != nil {
-				var responseErr *jsonrpc2.Error
-				if errors.As(err, &responseErr) && (responseErr.Code == ErrorCodeSnapshotNotFound || responseErr.Code == ErrorCodeSnapshotError) {
-					return err
-				}
-				time.Sleep(time.Second * 3)
-			} else {
-				break
-			}
-		}
 		url := fmt.Sprintf("%s/#snapshot/%s", wsInfo.GitpodHost, snapshotId)
 		fmt.Println(url)
 		return nil
 	},
 }
 
+// takeSnapshot takes a snapshot of the current workspace, and waits until it is available.
+func takeSnapshot(ctx context.Context, wsInfo *supervisor.WorkspaceInfoResponse) (string, error) {
+	client, err := gitpod.ConnectToServer(ctx, wsInfo, []string{
+		"function:takeSnapshot",
+		"function:waitForSnapshot",
+		"resource:workspace::" + wsInfo.WorkspaceId + "::get/update",
+	})
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+	snapshotId, err := client.TakeSnapshot(ctx, &protocol.TakeSnapshotOptions{
+		WorkspaceID: wsInfo.WorkspaceId,
+		DontWait:    true,
+	})
+	if err != nil {
+		return "", err
+	}
+	for ctx.Err() == nil {
+		err = client.WaitForSnapshot(ctx, snapshotId)
+		if err != nil {
+			var responseErr *jsonrpc2.Error
+			if errors.As(err, &responseErr) && (responseErr.Code == ErrorCodeSnapshotNotFound || responseErr.Code == ErrorCodeSnapshotError) {
+				return "", err
+			}
+			time.Sleep(time.Second * 3)
+		} else {
+			break
+		}
+	}
+	return snapshotId, nil
+}
+
 func init() {
 	rootCmd.AddCommand(snapshotCmd)
 }
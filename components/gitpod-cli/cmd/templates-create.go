@@ -0,0 +1,116 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	connect "github.com/bufbuild/connect-go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/gitpod"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var createTemplateOpts struct {
+	SnapshotID     string
+	Project        bool
+	Description    string
+	WorkspaceClass string
+	IDE            string
+	EnvVars        []string
+}
+
+var createTemplateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Creates a new version of a workspace template from a snapshot of this workspace",
+	Long: `Creates a new version of a workspace template from a snapshot of this workspace.
+
+Unless --snapshot is specified, a new snapshot of this workspace is taken first.`,
+	Example: `gp templates create node --env NODE_ENV=development --ide code`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+
+		envVars, err := parseTemplateEnvVars(createTemplateOpts.EnvVars)
+		if err != nil {
+			return GpError{Err: err, OutCome: utils.Outcome_UserErr, ErrorCode: utils.UserErrorCode_InvalidArguments}
+		}
+
+		wsInfo, err := gitpod.GetWSInfo(ctx)
+		if err != nil {
+			return err
+		}
+		ws, err := currentWorkspace(ctx, wsInfo)
+		if err != nil {
+			return err
+		}
+
+		// Only snapshots taken for the template are deleted together with it, existing ones may have been shared already.
+		snapshotID := createTemplateOpts.SnapshotID
+		ownsSnapshot := snapshotID == ""
+		if ownsSnapshot {
+			fmt.Println("Taking a snapshot of this workspace...")
+			snapshotID, err = takeSnapshot(ctx, wsInfo)
+			if err != nil {
+				return err
+			}
+		}
+
+		c, err := newPublicAPIClient(ctx, wsInfo, nil)
+		if err != nil {
+			return err
+		}
+
+		req := &v1.CreateWorkspaceTemplateRequest{
+			OrganizationId: ws.OrganizationID,
+			Name:           args[0],
+			Description:    createTemplateOpts.Description,
+			SnapshotId:     snapshotID,
+			OwnsSnapshot:   ownsSnapshot,
+			WorkspaceClass: createTemplateOpts.WorkspaceClass,
+			Ide:            createTemplateOpts.IDE,
+			EnvVars:        envVars,
+		}
+		if createTemplateOpts.Project {
+			req.ProjectId = ws.ProjectID
+		}
+		resp, err := c.WorkspaceTemplates.CreateWorkspaceTemplate(ctx, connect.NewRequest(req))
+		if err != nil {
+			return err
+		}
+
+		tmpl := resp.Msg.GetTemplate()
+		fmt.Printf("Created version %d of workspace template %s (%s).\n", tmpl.GetVersion(), tmpl.GetName(), tmpl.GetId())
+		return nil
+	},
+}
+
+// parseTemplateEnvVars parses environment variables in the form NAME=value.
+func parseTemplateEnvVars(envVars []string) ([]*v1.WorkspaceTemplateEnvVar, error) {
+	var result []*v1.WorkspaceTemplateEnvVar
+	for _, envVar := range envVars {
+		name, value, ok := strings.Cut(envVar, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid environment variable %q, expected NAME=value", envVar)
+		}
+		result = append(result, &v1.WorkspaceTemplateEnvVar{Name: name, Value: value})
+	}
+	return result, nil
+}
+
+func init() {
+	templatesCmd.AddCommand(createTemplateCmd)
+
+	createTemplateCmd.Flags().StringVar(&createTemplateOpts.SnapshotID, "snapshot", "", "ID of an existing snapshot to create the template from")
+	createTemplateCmd.Flags().BoolVar(&createTemplateOpts.Project, "project", false, "scope the template to the project of this workspace")
+	createTemplateCmd.Flags().StringVar(&createTemplateOpts.Description, "description", "", "description of the template")
+	createTemplateCmd.Flags().StringVar(&createTemplateOpts.WorkspaceClass, "class", "", "workspace class of workspaces started from the template")
+	createTemplateCmd.Flags().StringVar(&createTemplateOpts.IDE, "ide", "", "IDE of workspaces started from the template, e.g. code")
+	createTemplateCmd.Flags().StringArrayVar(&createTemplateOpts.EnvVars, "env", nil, "environment variable of workspaces started from the template, in the form NAME=value")
+}
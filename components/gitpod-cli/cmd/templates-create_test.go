@@ -0,0 +1,52 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"testing"
+
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestParseTemplateEnvVars(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		EnvVars     []string
+		Expectation []*v1.WorkspaceTemplateEnvVar
+		Error       bool
+	}{
+		{Desc: "empty"},
+		{
+			Desc:    "values may contain equal signs",
+			EnvVars: []string{"FOO=bar", "QUERY=a=b"},
+			Expectation: []*v1.WorkspaceTemplateEnvVar{
+				{Name: "FOO", Value: "bar"},
+				{Name: "QUERY", Value: "a=b"},
+			},
+		},
+		{Desc: "missing value", EnvVars: []string{"FOO"}, Error: true},
+		{Desc: "missing name", EnvVars: []string{"=bar"}, Error: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act, err := parseTemplateEnvVars(test.EnvVars)
+			if test.Error {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, act, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected env vars (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
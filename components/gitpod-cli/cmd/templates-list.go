@@ -0,0 +1,93 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	connect "github.com/bufbuild/connect-go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/gitpod"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var listTemplatesOpts struct {
+	Project     bool
+	Name        string
+	AllVersions bool
+}
+
+var listTemplatesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the workspace templates of the current organization",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		defer cancel()
+
+		wsInfo, err := gitpod.GetWSInfo(ctx)
+		if err != nil {
+			return err
+		}
+		ws, err := currentWorkspace(ctx, wsInfo)
+		if err != nil {
+			return err
+		}
+		c, err := newPublicAPIClient(ctx, wsInfo, nil)
+		if err != nil {
+			return err
+		}
+
+		req := &v1.ListWorkspaceTemplatesRequest{
+			OrganizationId: ws.OrganizationID,
+			Name:           listTemplatesOpts.Name,
+			AllVersions:    listTemplatesOpts.AllVersions,
+			Pagination:     &v1.Pagination{PageSize: 100},
+		}
+		if listTemplatesOpts.Project {
+			req.ProjectId = ws.ProjectID
+		}
+		resp, err := c.WorkspaceTemplates.ListWorkspaceTemplates(ctx, connect.NewRequest(req))
+		if err != nil {
+			return err
+		}
+
+		if len(resp.Msg.GetTemplates()) == 0 {
+			fmt.Println("No workspace templates found.")
+			return nil
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "Version", "Project", "Class", "IDE", "Created", "ID"})
+		table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+		table.SetCenterSeparator("|")
+
+		for _, tmpl := range resp.Msg.GetTemplates() {
+			table.Append([]string{
+				tmpl.GetName(),
+				strconv.Itoa(int(tmpl.GetVersion())),
+				tmpl.GetProjectId(),
+				tmpl.GetWorkspaceClass(),
+				tmpl.GetIde(),
+				tmpl.GetCreatedAt().AsTime().Format(time.RFC3339),
+				tmpl.GetId(),
+			})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+func init() {
+	templatesCmd.AddCommand(listTemplatesCmd)
+
+	listTemplatesCmd.Flags().BoolVar(&listTemplatesOpts.Project, "project", false, "only list templates of the project of this workspace")
+	listTemplatesCmd.Flags().StringVar(&listTemplatesOpts.Name, "name", "", "only list the template with the given name")
+	listTemplatesCmd.Flags().BoolVar(&listTemplatesOpts.AllVersions, "all-versions", false, "list all versions, instead of only the most recent version of every template")
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	connect "github.com/bufbuild/connect-go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/gitpod"
+	"github.com/spf13/cobra"
+)
+
+var startTemplateOpts struct {
+	ID      bool
+	Project bool
+}
+
+var startTemplateCmd = &cobra.Command{
+	Use:   "start <name>",
+	Short: "Starts a new workspace from the most recent version of a workspace template",
+	Example: `gp templates start node
+gp templates start --id 8f2c1b8e-7d3a-4b8e-9c1f-2a6b5d4e3f21`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+		defer cancel()
+
+		wsInfo, err := gitpod.GetWSInfo(ctx)
+		if err != nil {
+			return err
+		}
+
+		req := &v1.StartWorkspaceFromTemplateRequest{}
+		if startTemplateOpts.ID {
+			req.Template = &v1.StartWorkspaceFromTemplateRequest_TemplateId{TemplateId: args[0]}
+		} else {
+			ws, err := currentWorkspace(ctx, wsInfo)
+			if err != nil {
+				return err
+			}
+			name := &v1.WorkspaceTemplateName{
+				OrganizationId: ws.OrganizationID,
+				Name:           args[0],
+			}
+			if startTemplateOpts.Project {
+				name.ProjectId = ws.ProjectID
+			}
+			req.Template = &v1.StartWorkspaceFromTemplateRequest_Name{Name: name}
+		}
+
+		c, err := newPublicAPIClient(ctx, wsInfo, []string{"function:createWorkspace"})
+		if err != nil {
+			return err
+		}
+		resp, err := c.WorkspaceTemplates.StartWorkspaceFromTemplate(ctx, connect.NewRequest(req))
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(resp.Msg.GetWorkspaceUrl())
+		return nil
+	},
+}
+
+func init() {
+	templatesCmd.AddCommand(startTemplateCmd)
+
+	startTemplateCmd.Flags().BoolVar(&startTemplateOpts.ID, "id", false, "treat the argument as the ID of a specific template version")
+	startTemplateCmd.Flags().BoolVar(&startTemplateOpts.Project, "project", false, "start the template of the project of this workspace")
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/gitpod-io/gitpod/common-go/util"
+	"github.com/gitpod-io/gitpod/components/public-api/go/client"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/gitpod"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Create, list and start workspace templates",
+	Long: `Workspace templates are named, versioned starting points for workspaces, created from snapshots.
+
+Every time a template is created under an existing name, a new version is created.
+Templates belong to the organization of the current workspace, and optionally to a project.`,
+}
+
+// newPublicAPIClient returns a public API client, authenticated with a token which grants the given server functions.
+func newPublicAPIClient(ctx context.Context, wsInfo *supervisor.WorkspaceInfoResponse, scope []string) (*client.Gitpod, error) {
+	supervisorConn, err := grpc.Dial(util.GetSupervisorAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, xerrors.Errorf("failed connecting to supervisor: %w", err)
+	}
+	defer supervisorConn.Close()
+	clientToken, err := supervisor.NewTokenServiceClient(supervisorConn).GetToken(ctx, &supervisor.GetTokenRequest{
+		Host:  wsInfo.GitpodApi.Host,
+		Kind:  "gitpod",
+		Scope: append([]string{"function:getLoggedInUser"}, scope...),
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed getting token from supervisor: %w", err)
+	}
+
+	return client.New(client.WithCredentials(clientToken.Token), client.WithURL("https://api."+wsInfo.GitpodApi.Host))
+}
+
+// currentWorkspace returns the current workspace, which carries the organization and project templates are scoped to by default.
+func currentWorkspace(ctx context.Context, wsInfo *supervisor.WorkspaceInfoResponse) (*protocol.Workspace, error) {
+	conn, err := gitpod.ConnectToServer(ctx, wsInfo, []string{
+		"function:getWorkspace",
+		"resource:workspace::" + wsInfo.WorkspaceId + "::get",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	info, err := conn.GetWorkspace(ctx, wsInfo.WorkspaceId)
+	if err != nil {
+		return nil, err
+	}
+	if info.Workspace == nil {
+		return nil, xerrors.Errorf("workspace %s not found", wsInfo.WorkspaceId)
+	}
+	return info.Workspace, nil
+}
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
@@ -33,7 +34,7 @@ type topData struct {
 
 var topCmd = &cobra.Command{
 	Use:   "top",
-	Short: "Display usage of workspace resources (CPU and memory)",
+	Short: "Display usage of workspace resources (CPU, memory, disk, IO and network)",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
 		defer cancel()
@@ -108,7 +109,95 @@ func outputTable(workspaceResources *api.ResourcesStatusResponse, workspaceClass
 	table.Rich([]string{"CPU (millicores)", cpu}, cpuColors)
 	table.Rich([]string{"Memory (bytes)", memory}, memoryColors)
 
+	if disk := workspaceResources.Disk; disk != nil && disk.Limit > 0 {
+		diskFraction := int64((float64(disk.Used) / float64(disk.Limit)) * 100)
+		var diskColors []tablewriter.Colors
+		if !noColor && utils.ColorsEnabled() {
+			diskColors = []tablewriter.Colors{nil, {getColor(disk.Severity)}}
+		}
+		table.Rich([]string{"Disk (bytes)", fmt.Sprintf("%dMi/%dMi (%d%%)", disk.Used/(1024*1024), disk.Limit/(1024*1024), diskFraction)}, diskColors)
+	}
+
+	if io := workspaceResources.Io; io != nil {
+		table.Append([]string{"IO limits", formatIOLimits(io)})
+		table.Append([]string{"IO usage", fmt.Sprintf("read %dMi, written %dMi, throttled %d times", io.ReadBytes/(1024*1024), io.WriteBytes/(1024*1024), io.ThrottledPeriods)})
+	}
+
+	if network := workspaceResources.Network; network != nil {
+		table.Append([]string{"Network", formatNetwork(network)})
+	}
+
 	table.Render()
+
+	outputMemoryEvents(workspaceResources.MemoryEvents)
+}
+
+func formatIOLimits(io *api.IOStatus) string {
+	format := func(op string, bandwidth, iops int64) string {
+		if bandwidth <= 0 && iops <= 0 {
+			return op + " unlimited"
+		}
+		var limits []string
+		if bandwidth > 0 {
+			limits = append(limits, fmt.Sprintf("%dMi/s", bandwidth/(1024*1024)))
+		}
+		if iops > 0 {
+			limits = append(limits, fmt.Sprintf("%d IOPS", iops))
+		}
+		return op + " " + strings.Join(limits, " ")
+	}
+
+	res := format("read", io.ReadBandwidthLimit, io.ReadIopsLimit) + ", " + format("write", io.WriteBandwidthLimit, io.WriteIopsLimit)
+	if io.Bursting {
+		res += " (burst)"
+	}
+	if io.NodeSaturated {
+		res += " (reduced, node disks are saturated)"
+	}
+	return res
+}
+
+func formatNetwork(network *api.NetworkStatus) string {
+	res := fmt.Sprintf("received %dMi, sent %dMi", network.RxBytes/(1024*1024), network.TxBytes/(1024*1024))
+	if network.EgressBandwidthLimit > 0 {
+		res += fmt.Sprintf(", egress limited to %dMi/s", network.EgressBandwidthLimit/(1024*1024))
+	}
+	if network.EgressPolicy != "" {
+		res += fmt.Sprintf(", egress policy %s denied %d packets", network.EgressPolicy, network.EgressDeniedPackets)
+	}
+	return res
+}
+
+func outputMemoryEvents(events []*api.MemoryEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("Recent memory events:")
+	for _, evt := range events {
+		var processes []string
+		for _, p := range evt.Processes {
+			processes = append(processes, fmt.Sprintf("%s (%dMi)", p.Name, p.Rss/(1024*1024)))
+		}
+
+		var desc string
+		if evt.Type == api.MemoryEventType_oom_kill {
+			desc = "out of memory, killed: "
+			if len(processes) == 0 {
+				desc += "unknown process"
+			}
+		} else {
+			desc = fmt.Sprintf("memory pressure at %dMi", evt.Used/(1024*1024))
+			if evt.Limit > 0 {
+				desc += fmt.Sprintf("/%dMi", evt.Limit/(1024*1024))
+			}
+			desc += ", top consumers: "
+		}
+		desc += strings.Join(processes, ", ")
+
+		fmt.Printf("  %s  %s\n", time.Unix(evt.Time, 0).Format("15:04:05"), desc)
+	}
 }
 
 func getColor(severity api.ResourceStatusSeverity) int {
@@ -0,0 +1,37 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"golang.org/x/xerrors"
+)
+
+func (client *SupervisorClient) GetServicesList(ctx context.Context) ([]*api.ServiceStatus, error) {
+	respClient, err := client.Status.ServicesStatus(ctx, &api.ServicesStatusRequest{Observe: false})
+	if err != nil {
+		return nil, xerrors.Errorf("failed get services status client: %w", err)
+	}
+	resp, err := respClient.Recv()
+	if err != nil {
+		return nil, xerrors.Errorf("failed receive data: %w", err)
+	}
+	return resp.GetServices(), nil
+}
+
+func (client *SupervisorClient) GetService(ctx context.Context, name string) (*api.ServiceStatus, error) {
+	services, err := client.GetServicesList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services {
+		if service.Name == name {
+			return service, nil
+		}
+	}
+	return nil, nil
+}
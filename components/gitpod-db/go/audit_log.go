@@ -0,0 +1,130 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// AuditLog records an administrative action performed by an actor, usually a user.
+type AuditLog struct {
+	ID        uuid.UUID `gorm:"primary_key;column:id;type:char;size:36;" json:"id"`
+	Timestamp time.Time `gorm:"column:timestamp;type:timestamp;" json:"timestamp"`
+
+	// OrganizationID is empty for actions which do not affect an organization.
+	OrganizationID string `gorm:"column:organizationId;type:varchar;size:255;" json:"organizationId"`
+
+	// ActorID is empty for actions performed by the system.
+	ActorID string `gorm:"column:actorId;type:varchar;size:255;" json:"actorId"`
+	Action  string `gorm:"column:action;type:varchar;size:128;" json:"action"`
+
+	// Args is a JSON object describing the arguments of the action. It must not contain secrets.
+	Args datatypes.JSON `gorm:"column:args;type:text;size:65535" json:"args"`
+}
+
+// TableName sets the insert table name for this struct type
+func (a *AuditLog) TableName() string {
+	return "d_b_audit_log"
+}
+
+// SetArgs stores args as JSON object on the audit log.
+func (a *AuditLog) SetArgs(args map[string]any) error {
+	b, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit log args: %w", err)
+	}
+	a.Args = b
+	return nil
+}
+
+func CreateAuditLog(ctx context.Context, conn *gorm.DB, entry AuditLog) (AuditLog, error) {
+	if entry.ID == uuid.Nil {
+		return AuditLog{}, errors.New("id must be set")
+	}
+	if entry.Action == "" {
+		return AuditLog{}, errors.New("action must be set")
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now().UTC()
+	}
+	if len(entry.Args) == 0 {
+		entry.Args = datatypes.JSON("{}")
+	}
+
+	tx := conn.WithContext(ctx).Create(&entry)
+	if tx.Error != nil {
+		return AuditLog{}, fmt.Errorf("failed to create audit log: %w", tx.Error)
+	}
+
+	return entry, nil
+}
+
+type ListAuditLogsFilter struct {
+	// ActorID only lists actions performed by the actor, if set.
+	ActorID string
+
+	// Action only lists actions of the given kind, if set.
+	Action string
+
+	// From and To restrict the time range, From is inclusive and To exclusive. Zero values are unbounded.
+	From time.Time
+	To   time.Time
+}
+
+// ListAuditLogsForOrganization lists audit logs of the organization, most recent first.
+func ListAuditLogsForOrganization(ctx context.Context, conn *gorm.DB, organizationID uuid.UUID, filter ListAuditLogsFilter, pagination Pagination) (*PaginatedResult[AuditLog], error) {
+	if organizationID == uuid.Nil {
+		return nil, errors.New("organization ID is a required argument to list audit logs")
+	}
+
+	query := func() *gorm.DB {
+		tx := conn.
+			WithContext(ctx).
+			Table((&AuditLog{}).TableName()).
+			Where("organizationId = ?", organizationID.String())
+		if filter.ActorID != "" {
+			tx = tx.Where("actorId = ?", filter.ActorID)
+		}
+		if filter.Action != "" {
+			tx = tx.Where("action = ?", filter.Action)
+		}
+		if !filter.From.IsZero() {
+			tx = tx.Where("timestamp >= ?", filter.From.UTC())
+		}
+		if !filter.To.IsZero() {
+			tx = tx.Where("timestamp < ?", filter.To.UTC())
+		}
+		return tx
+	}
+
+	var results []AuditLog
+	tx := query().
+		Order("timestamp DESC").
+		Order("id").
+		Scopes(Paginate(pagination)).
+		Find(&results)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list audit logs for organization %s: %w", organizationID.String(), tx.Error)
+	}
+
+	var count int64
+	tx = query().Count(&count)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to count audit logs for organization %s: %w", organizationID.String(), tx.Error)
+	}
+
+	return &PaginatedResult[AuditLog]{
+		Results: results,
+		Total:   count,
+	}, nil
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAuditLog(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+
+	_, err := db.CreateAuditLog(ctx, conn, db.AuditLog{Action: "blockUser"})
+	require.Error(t, err, "id must be set")
+
+	_, err = db.CreateAuditLog(ctx, conn, db.AuditLog{ID: uuid.New()})
+	require.Error(t, err, "action must be set")
+
+	entry := db.AuditLog{ID: uuid.New(), Action: "blockUser"}
+	require.NoError(t, entry.SetArgs(map[string]any{"userId": "foo"}))
+	created, err := db.CreateAuditLog(ctx, conn, entry)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Delete(&created).Error) })
+
+	require.False(t, created.Timestamp.IsZero())
+	require.JSONEq(t, `{"userId":"foo"}`, string(created.Args))
+}
+
+func TestListAuditLogsForOrganization(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+
+	orgID := uuid.New()
+	alice, bob := uuid.NewString(), uuid.NewString()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	entries := []db.AuditLog{
+		{ID: uuid.New(), Timestamp: now.Add(-3 * time.Hour), OrganizationID: orgID.String(), ActorID: alice, Action: "createClientConfig"},
+		{ID: uuid.New(), Timestamp: now.Add(-2 * time.Hour), OrganizationID: orgID.String(), ActorID: bob, Action: "updateTeamMember"},
+		{ID: uuid.New(), Timestamp: now.Add(-1 * time.Hour), OrganizationID: orgID.String(), ActorID: alice, Action: "updateTeamMember"},
+		{ID: uuid.New(), Timestamp: now, OrganizationID: uuid.NewString(), ActorID: alice, Action: "updateTeamMember"},
+	}
+	for _, entry := range entries {
+		created, err := db.CreateAuditLog(ctx, conn, entry)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, conn.Delete(&created).Error) })
+	}
+
+	ids := func(logs []db.AuditLog) []uuid.UUID {
+		var result []uuid.UUID
+		for _, l := range logs {
+			result = append(result, l.ID)
+		}
+		return result
+	}
+
+	all, err := db.ListAuditLogsForOrganization(ctx, conn, orgID, db.ListAuditLogsFilter{}, db.Pagination{PageSize: 25})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, all.Total)
+	require.Equal(t, []uuid.UUID{entries[2].ID, entries[1].ID, entries[0].ID}, ids(all.Results), "most recent first")
+
+	paged, err := db.ListAuditLogsForOrganization(ctx, conn, orgID, db.ListAuditLogsFilter{}, db.Pagination{Page: 2, PageSize: 2})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, paged.Total)
+	require.Equal(t, []uuid.UUID{entries[0].ID}, ids(paged.Results))
+
+	byActor, err := db.ListAuditLogsForOrganization(ctx, conn, orgID, db.ListAuditLogsFilter{ActorID: alice}, db.Pagination{PageSize: 25})
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{entries[2].ID, entries[0].ID}, ids(byActor.Results))
+
+	byAction, err := db.ListAuditLogsForOrganization(ctx, conn, orgID, db.ListAuditLogsFilter{Action: "updateTeamMember"}, db.Pagination{PageSize: 25})
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{entries[2].ID, entries[1].ID}, ids(byAction.Results))
+
+	byTime, err := db.ListAuditLogsForOrganization(ctx, conn, orgID, db.ListAuditLogsFilter{From: now.Add(-2 * time.Hour), To: now.Add(-1 * time.Hour)}, db.Pagination{PageSize: 25})
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{entries[1].ID}, ids(byTime.Results))
+
+	_, err = db.ListAuditLogsForOrganization(ctx, conn, uuid.Nil, db.ListAuditLogsFilter{}, db.Pagination{PageSize: 25})
+	require.Error(t, err)
+}
+
+func TestAuditLogWriter(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+
+	received := make(chan db.AuditLog, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var entry db.AuditLog
+		if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- entry
+	}))
+	t.Cleanup(srv.Close)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sinks, err := db.NewAuditLogSinks(&db.AuditLogSinksConfig{
+		Webhook: &db.WebhookAuditLogSinkConfig{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer secret"}},
+		File:    &db.FileAuditLogSinkConfig{Path: path},
+	})
+	require.NoError(t, err)
+
+	writer := db.NewAuditLogWriter(conn, sinks...)
+	for i := 0; i < 2; i++ {
+		entry := db.AuditLog{OrganizationID: uuid.NewString(), ActorID: uuid.NewString(), Action: "deleteClientConfig"}
+		require.NoError(t, entry.SetArgs(map[string]any{"id": i}))
+
+		created, err := writer.Write(ctx, entry)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, conn.Delete(&created).Error) })
+		require.NotEqual(t, uuid.Nil, created.ID)
+
+		forwarded := <-received
+		require.Equal(t, created.ID, forwarded.ID)
+		require.Equal(t, created.Action, forwarded.Action)
+		require.JSONEq(t, string(created.Args), string(forwarded.Args))
+	}
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+	var entry db.AuditLog
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	require.JSONEq(t, `{"id":1}`, string(entry.Args))
+}
+
+func TestAuditLogWriter_IgnoresFailingSinks(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+
+	sinks, err := db.NewAuditLogSinks(&db.AuditLogSinksConfig{
+		File: &db.FileAuditLogSinkConfig{Path: filepath.Join(t.TempDir(), "missing", "audit.log")},
+	})
+	require.NoError(t, err)
+
+	created, err := db.NewAuditLogWriter(conn, sinks...).Write(context.Background(), db.AuditLog{Action: "blockUser"})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Delete(&created).Error) })
+}
+
+func TestNewAuditLogSinks(t *testing.T) {
+	sinks, err := db.NewAuditLogSinks(nil)
+	require.NoError(t, err)
+	require.Empty(t, sinks)
+
+	_, err = db.NewAuditLogSinks(&db.AuditLogSinksConfig{Webhook: &db.WebhookAuditLogSinkConfig{URL: "file:///etc/passwd"}})
+	require.Error(t, err)
+
+	_, err = db.NewAuditLogSinks(&db.AuditLogSinksConfig{File: &db.FileAuditLogSinkConfig{}})
+	require.Error(t, err)
+}
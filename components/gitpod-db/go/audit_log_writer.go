@@ -0,0 +1,174 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditLogSinksConfig configures external sinks, which receive every audit log after it has been stored,
+// e.g. for ingestion into a SIEM.
+type AuditLogSinksConfig struct {
+	Webhook *WebhookAuditLogSinkConfig `json:"webhook,omitempty"`
+	File    *FileAuditLogSinkConfig    `json:"file,omitempty"`
+}
+
+type WebhookAuditLogSinkConfig struct {
+	// URL receives every audit log as JSON in a POST request.
+	URL string `json:"url"`
+
+	// Headers are added to every request, e.g. to authenticate with the receiver.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type FileAuditLogSinkConfig struct {
+	// Path of the file to which audit logs are appended as JSON lines.
+	Path string `json:"path"`
+}
+
+// AuditLogSink receives audit logs after they have been stored.
+type AuditLogSink interface {
+	Name() string
+	Send(ctx context.Context, entry AuditLog) error
+}
+
+// NewAuditLogSinks creates the sinks configured in cfg, cfg may be nil.
+func NewAuditLogSinks(cfg *AuditLogSinksConfig) ([]AuditLogSink, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	var sinks []AuditLogSink
+	if cfg.Webhook != nil {
+		u, err := url.Parse(cfg.Webhook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("audit log webhook URL must be a http(s) URL, got %q", cfg.Webhook.URL)
+		}
+		sinks = append(sinks, &webhookAuditLogSink{
+			cfg:    cfg.Webhook,
+			client: &http.Client{Timeout: 5 * time.Second},
+		})
+	}
+	if cfg.File != nil {
+		if cfg.File.Path == "" {
+			return nil, errors.New("audit log file path must be set")
+		}
+		sinks = append(sinks, &fileAuditLogSink{path: cfg.File.Path})
+	}
+	return sinks, nil
+}
+
+// AuditLogWriter stores audit logs in the database and forwards them to sinks.
+type AuditLogWriter struct {
+	conn  *gorm.DB
+	sinks []AuditLogSink
+}
+
+func NewAuditLogWriter(conn *gorm.DB, sinks ...AuditLogSink) *AuditLogWriter {
+	return &AuditLogWriter{
+		conn:  conn,
+		sinks: sinks,
+	}
+}
+
+// Write stores the audit log and forwards it to all sinks.
+// Failing to forward the audit log to a sink is logged, but does not fail the write.
+func (w *AuditLogWriter) Write(ctx context.Context, entry AuditLog) (AuditLog, error) {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+
+	created, err := CreateAuditLog(ctx, w.conn, entry)
+	if err != nil {
+		return AuditLog{}, err
+	}
+
+	for _, sink := range w.sinks {
+		err := sink.Send(ctx, created)
+		if err != nil {
+			log.WithError(err).WithField("sink", sink.Name()).WithField("auditLogId", created.ID.String()).Error("Failed to forward audit log to sink.")
+		}
+	}
+
+	return created, nil
+}
+
+type webhookAuditLogSink struct {
+	cfg    *WebhookAuditLogSinkConfig
+	client *http.Client
+}
+
+func (s *webhookAuditLogSink) Name() string { return "webhook" }
+
+func (s *webhookAuditLogSink) Send(ctx context.Context, entry AuditLog) error {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit log: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send audit log to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type fileAuditLogSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+func (s *fileAuditLogSink) Name() string { return "file" }
+
+func (s *fileAuditLogSink) Send(ctx context.Context, entry AuditLog) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit log: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The file is reopened for every entry, such that it can be rotated by an external tool
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log file: %w", err)
+	}
+
+	_, err = f.Write(line)
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write audit log file: %w", err)
+	}
+	return f.Close()
+}
@@ -27,6 +27,7 @@ func NewOIDCClientConfig(t *testing.T, record db.OIDCClientConfig) db.OIDCClient
 		ID:           uuid.New(),
 		Issuer:       "issuer",
 		Data:         encrypted,
+		Active:       record.Active,
 		LastModified: now,
 	}
 
@@ -67,13 +67,14 @@ func NewWorkspace(t *testing.T, workspace db.Workspace) db.Workspace {
 	}
 
 	return db.Workspace{
-		ID:         id,
-		OwnerID:    ownerID,
-		Type:       workspaceType,
-		ProjectID:  projectID,
-		ContextURL: contextURL,
-		Context:    context,
-		Config:     config,
+		ID:                id,
+		OwnerID:           ownerID,
+		Type:              workspaceType,
+		ProjectID:         projectID,
+		ContextURL:        contextURL,
+		Context:           context,
+		Config:            config,
+		ImageNameResolved: workspace.ImageNameResolved,
 	}
 }
 
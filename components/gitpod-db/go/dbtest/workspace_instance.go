@@ -92,7 +92,7 @@ func NewWorkspaceInstance(t *testing.T, instance db.WorkspaceInstance) db.Worksp
 		ImageBuildInfo:     sql.NullString{},
 		IdeURL:             "",
 		WorkspaceBaseImage: "",
-		WorkspaceImage:     "",
+		WorkspaceImage:     instance.WorkspaceImage,
 		CreationTime:       creationTime,
 		StartedTime:        startedTime,
 		DeployedTime:       deployedTime,
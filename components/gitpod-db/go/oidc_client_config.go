@@ -50,6 +50,31 @@ type OIDCSpec struct {
 
 	// Scope specifies optional requested permissions.
 	Scopes []string `json:"scopes"`
+
+	// ClaimMappings grant team roles based on the claims of the ID token.
+	ClaimMappings []OIDCClaimMapping `json:"claimMappings,omitempty"`
+
+	// EmailDomains select this config for users with an email address in one of the domains,
+	// when an organization has several active configs.
+	EmailDomains []string `json:"emailDomains,omitempty"`
+
+	// PreviousClientSecret is the client secret before the last rotation.
+	// It remains valid until PreviousClientSecretExpiresAt.
+	PreviousClientSecret          string     `json:"previousClientSecret,omitempty"`
+	PreviousClientSecretExpiresAt *time.Time `json:"previousClientSecretExpiresAt,omitempty"`
+}
+
+// OIDCClaimMapping grants Role in the organization to users whose ID token contains Value in Claim.
+// The claim may be a single string, or a list of strings (e.g. groups).
+type OIDCClaimMapping struct {
+	// Claim is the name of the claim, e.g. groups.
+	Claim string `json:"claim"`
+
+	// Value is the value the claim needs to have, or contain, e.g. eng-admins.
+	Value string `json:"value"`
+
+	// Role is the role which is granted.
+	Role TeamMembershipRole `json:"role"`
 }
 
 func CreateOIDCClientConfig(ctx context.Context, conn *gorm.DB, cfg OIDCClientConfig) (OIDCClientConfig, error) {
@@ -192,6 +217,54 @@ func GetOIDCClientConfigByOrgSlug(ctx context.Context, conn *gorm.DB, slug strin
 	return config, nil
 }
 
+func ListOIDCClientConfigsByOrgSlug(ctx context.Context, conn *gorm.DB, slug string) ([]OIDCClientConfig, error) {
+	if slug == "" {
+		return nil, fmt.Errorf("slug is a required argument")
+	}
+
+	var results []OIDCClientConfig
+	tx := conn.
+		WithContext(ctx).
+		Table((&OIDCClientConfig{}).TableName()).
+		Joins("JOIN d_b_team team ON team.id = d_b_oidc_client_config.organizationId").
+		Where("team.slug = ?", slug).
+		Where("d_b_oidc_client_config.deleted = ?", 0).
+		Order("d_b_oidc_client_config.id").
+		Find(&results)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list oidc client configs by org slug (slug: %s): %w", slug, tx.Error)
+	}
+
+	return results, nil
+}
+
+// UpdateOIDCClientConfig updates the issuer, data and active state of an existing config.
+func UpdateOIDCClientConfig(ctx context.Context, conn *gorm.DB, cfg OIDCClientConfig) (OIDCClientConfig, error) {
+	if cfg.Issuer == "" {
+		return OIDCClientConfig{}, errors.New("issuer must be set")
+	}
+
+	_, err := GetOIDCClientConfigForOrganization(ctx, conn, cfg.ID, cfg.OrganizationID)
+	if err != nil {
+		return OIDCClientConfig{}, err
+	}
+
+	tx := conn.
+		WithContext(ctx).
+		Table((&OIDCClientConfig{}).TableName()).
+		Where("id = ?", cfg.ID.String()).
+		Updates(map[string]interface{}{
+			"issuer": cfg.Issuer,
+			"data":   []byte(cfg.Data),
+			"active": cfg.Active,
+		})
+	if tx.Error != nil {
+		return OIDCClientConfig{}, fmt.Errorf("failed to update oidc client config (id: %s): %w", cfg.ID.String(), tx.Error)
+	}
+
+	return GetOIDCClientConfig(ctx, conn, cfg.ID)
+}
+
 func ActivateClientConfig(ctx context.Context, conn *gorm.DB, id uuid.UUID) error {
 	_, err := GetOIDCClientConfig(ctx, conn, id)
 	if err != nil {
@@ -146,3 +146,97 @@ func TestActivateClientConfig(t *testing.T) {
 	})
 
 }
+
+func TestUpdateOIDCClientConfig(t *testing.T) {
+
+	t.Run("not found when config does not exist", func(t *testing.T) {
+		conn := dbtest.ConnectForTests(t)
+
+		_, err := db.UpdateOIDCClientConfig(context.Background(), conn, db.OIDCClientConfig{
+			ID:             uuid.New(),
+			OrganizationID: uuid.New(),
+			Issuer:         "https://accounts.google.com",
+		})
+		require.ErrorIs(t, err, db.ErrorNotFound)
+	})
+
+	t.Run("not found when config belongs to another organization", func(t *testing.T) {
+		conn := dbtest.ConnectForTests(t)
+
+		created := dbtest.CreateOIDCClientConfigs(t, conn, db.OIDCClientConfig{
+			OrganizationID: uuid.New(),
+		})[0]
+
+		_, err := db.UpdateOIDCClientConfig(context.Background(), conn, db.OIDCClientConfig{
+			ID:             created.ID,
+			OrganizationID: uuid.New(),
+			Issuer:         "https://accounts.google.com",
+		})
+		require.ErrorIs(t, err, db.ErrorNotFound)
+	})
+
+	t.Run("updates issuer, data and active state", func(t *testing.T) {
+		conn := dbtest.ConnectForTests(t)
+		cipher, _ := dbtest.GetTestCipher(t)
+
+		created := dbtest.CreateOIDCClientConfigs(t, conn, db.OIDCClientConfig{
+			OrganizationID: uuid.New(),
+		})[0]
+
+		data, err := db.EncryptJSON(cipher, db.OIDCSpec{
+			ClientID:     "client-id",
+			ClientSecret: "new-secret",
+			EmailDomains: []string{"gitpod.io"},
+		})
+		require.NoError(t, err)
+
+		updated, err := db.UpdateOIDCClientConfig(context.Background(), conn, db.OIDCClientConfig{
+			ID:             created.ID,
+			OrganizationID: created.OrganizationID,
+			Issuer:         "https://accounts.google.com",
+			Data:           data,
+			Active:         true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "https://accounts.google.com", updated.Issuer)
+		require.True(t, updated.Active)
+
+		spec, err := updated.Data.Decrypt(cipher)
+		require.NoError(t, err)
+		require.Equal(t, "new-secret", spec.ClientSecret)
+		require.Equal(t, []string{"gitpod.io"}, spec.EmailDomains)
+	})
+}
+
+func TestListOIDCClientConfigsByOrgSlug(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+	ctx := context.Background()
+
+	team, err := db.CreateTeam(ctx, conn, db.Team{
+		ID:   uuid.New(),
+		Name: "Org",
+		Slug: uuid.NewString(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Where("id = ?", team.ID).Delete(&db.Team{}).Error)
+	})
+
+	configs := dbtest.CreateOIDCClientConfigs(t, conn,
+		db.OIDCClientConfig{OrganizationID: team.ID},
+		db.OIDCClientConfig{OrganizationID: team.ID, Active: true},
+		db.OIDCClientConfig{OrganizationID: uuid.New()},
+	)
+
+	listed, err := db.ListOIDCClientConfigsByOrgSlug(ctx, conn, team.Slug)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	for _, c := range listed {
+		require.Equal(t, team.ID, c.OrganizationID)
+		require.NotEqual(t, configs[2].ID, c.ID)
+	}
+
+	listed, err = db.ListOIDCClientConfigsByOrgSlug(ctx, conn, uuid.NewString())
+	require.NoError(t, err)
+	require.Empty(t, listed)
+}
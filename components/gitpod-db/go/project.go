@@ -5,11 +5,15 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/datatypes"
+	"gorm.io/gorm"
 )
 
 type Project struct {
@@ -37,3 +41,24 @@ type Project struct {
 func (d *Project) TableName() string {
 	return "d_b_project"
 }
+
+func GetProject(ctx context.Context, conn *gorm.DB, id uuid.UUID) (Project, error) {
+	if id == uuid.Nil {
+		return Project{}, errors.New("project ID is a required argument")
+	}
+
+	var project Project
+	tx := conn.WithContext(ctx).
+		Where("id = ?", id.String()).
+		Where("markedDeleted = ?", 0).
+		Where("deleted = ?", 0).
+		First(&project)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return Project{}, fmt.Errorf("project with ID %s does not exist: %w", id.String(), ErrorNotFound)
+		}
+		return Project{}, fmt.Errorf("failed to retrieve project: %w", tx.Error)
+	}
+
+	return project, nil
+}
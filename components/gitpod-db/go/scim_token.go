@@ -0,0 +1,99 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SCIMToken authenticates SCIM provisioning requests for an organization.
+type SCIMToken struct {
+	ID             uuid.UUID `gorm:"primary_key;column:id;type:char;size:36;" json:"id"`
+	OrganizationID uuid.UUID `gorm:"column:organizationId;type:char;size:36;" json:"organizationId"`
+	Hash           string    `gorm:"column:hash;type:varchar;size:255;" json:"hash"`
+	CreatedAt      time.Time `gorm:"column:createdAt;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"createdAt"`
+	LastModified   time.Time `gorm:"column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
+
+	// deleted is reserved for use by periodic deleter.
+	_ bool `gorm:"column:deleted;type:tinyint;default:0;" json:"deleted"`
+}
+
+// TableName sets the insert table name for this struct type
+func (t *SCIMToken) TableName() string {
+	return "d_b_scim_token"
+}
+
+// CreateSCIMToken stores a new token for the organization and revokes all other tokens of the organization.
+func CreateSCIMToken(ctx context.Context, conn *gorm.DB, token SCIMToken) (SCIMToken, error) {
+	if token.ID == uuid.Nil {
+		return SCIMToken{}, errors.New("id must be set")
+	}
+	if token.OrganizationID == uuid.Nil {
+		return SCIMToken{}, errors.New("organization ID must be set")
+	}
+	if token.Hash == "" {
+		return SCIMToken{}, errors.New("hash must be set")
+	}
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now().UTC()
+	}
+
+	err := conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := DeleteSCIMTokensForOrganization(ctx, tx, token.OrganizationID)
+		if err != nil {
+			return err
+		}
+		return tx.Create(&token).Error
+	})
+	if err != nil {
+		return SCIMToken{}, fmt.Errorf("failed to create scim token: %w", err)
+	}
+
+	return token, nil
+}
+
+func GetSCIMTokenByHash(ctx context.Context, conn *gorm.DB, hash string) (SCIMToken, error) {
+	if hash == "" {
+		return SCIMToken{}, errors.New("hash is a required argument")
+	}
+
+	var token SCIMToken
+	tx := conn.WithContext(ctx).
+		Where("hash = ?", hash).
+		Where("deleted = ?", 0).
+		First(&token)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return SCIMToken{}, fmt.Errorf("scim token does not exist: %w", ErrorNotFound)
+		}
+		return SCIMToken{}, fmt.Errorf("failed to retrieve scim token: %w", tx.Error)
+	}
+
+	return token, nil
+}
+
+// DeleteSCIMTokensForOrganization revokes all tokens of the organization, and returns the number of revoked tokens.
+func DeleteSCIMTokensForOrganization(ctx context.Context, conn *gorm.DB, organizationID uuid.UUID) (int64, error) {
+	if organizationID == uuid.Nil {
+		return 0, errors.New("organization ID is a required argument")
+	}
+
+	tx := conn.WithContext(ctx).
+		Table((&SCIMToken{}).TableName()).
+		Where("organizationId = ?", organizationID).
+		Where("deleted = ?", 0).
+		Update("deleted", 1)
+	if tx.Error != nil {
+		return 0, fmt.Errorf("failed to delete scim tokens for organization %s: %w", organizationID.String(), tx.Error)
+	}
+
+	return tx.RowsAffected, nil
+}
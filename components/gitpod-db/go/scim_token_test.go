@@ -0,0 +1,56 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSCIMToken(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+	orgID := uuid.New()
+
+	first, err := db.CreateSCIMToken(ctx, conn, db.SCIMToken{ID: uuid.New(), OrganizationID: orgID, Hash: uuid.NewString()})
+	require.NoError(t, err)
+
+	read, err := db.GetSCIMTokenByHash(ctx, conn, first.Hash)
+	require.NoError(t, err)
+	require.Equal(t, orgID, read.OrganizationID)
+
+	second, err := db.CreateSCIMToken(ctx, conn, db.SCIMToken{ID: uuid.New(), OrganizationID: orgID, Hash: uuid.NewString()})
+	require.NoError(t, err)
+
+	_, err = db.GetSCIMTokenByHash(ctx, conn, first.Hash)
+	require.ErrorIs(t, err, db.ErrorNotFound, "previous token must be revoked")
+
+	_, err = db.GetSCIMTokenByHash(ctx, conn, second.Hash)
+	require.NoError(t, err)
+}
+
+func TestDeleteSCIMTokensForOrganization(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+
+	token, err := db.CreateSCIMToken(ctx, conn, db.SCIMToken{ID: uuid.New(), OrganizationID: uuid.New(), Hash: uuid.NewString()})
+	require.NoError(t, err)
+
+	deleted, err := db.DeleteSCIMTokensForOrganization(ctx, conn, token.OrganizationID)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, deleted)
+
+	_, err = db.GetSCIMTokenByHash(ctx, conn, token.Hash)
+	require.ErrorIs(t, err, db.ErrorNotFound)
+
+	deleted, err = db.DeleteSCIMTokensForOrganization(ctx, conn, token.OrganizationID)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, deleted)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SnapshotState string
+
+const (
+	SnapshotState_Pending   SnapshotState = "pending"
+	SnapshotState_Available SnapshotState = "available"
+	SnapshotState_Error     SnapshotState = "error"
+)
+
+// Snapshot is a snapshot of the content of a workspace, taken with `gp snapshot`.
+type Snapshot struct {
+	ID                  uuid.UUID     `gorm:"primary_key;column:id;type:char;size:36;" json:"id"`
+	CreationTime        time.Time     `gorm:"column:creationTime;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"creationTime"`
+	AvailableTime       VarcharTime   `gorm:"column:availableTime;type:varchar;size:255;" json:"availableTime"`
+	OriginalWorkspaceID string        `gorm:"column:originalWorkspaceId;type:char;size:36;" json:"originalWorkspaceId"`
+	BucketID            string        `gorm:"column:bucketId;type:varchar;size:255;" json:"bucketId"`
+	State               SnapshotState `gorm:"column:state;type:varchar;size:255;default:available;" json:"state"`
+	Message             string        `gorm:"column:message;type:varchar;size:255;" json:"message"`
+}
+
+// TableName sets the insert table name for this struct type
+func (s *Snapshot) TableName() string {
+	return "d_b_snapshot"
+}
+
+// Filename returns the name of the object which stores the snapshot, within the backups of its workspace.
+// The bucket ID of a snapshot has the form <full path>@<bucket>.
+func (s *Snapshot) Filename() (string, error) {
+	fullPath, _, ok := strings.Cut(s.BucketID, "@")
+	if !ok || fullPath == "" {
+		return "", fmt.Errorf("cannot parse snapshot URL %s", s.BucketID)
+	}
+	segments := strings.Split(fullPath, "/")
+	return segments[len(segments)-1], nil
+}
+
+func GetSnapshot(ctx context.Context, conn *gorm.DB, id uuid.UUID) (Snapshot, error) {
+	if id == uuid.Nil {
+		return Snapshot{}, errors.New("snapshot ID is a required argument")
+	}
+
+	var snapshot Snapshot
+	tx := conn.WithContext(ctx).
+		Where("id = ?", id.String()).
+		First(&snapshot)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return Snapshot{}, fmt.Errorf("snapshot with ID %s does not exist: %w", id.String(), ErrorNotFound)
+		}
+		return Snapshot{}, fmt.Errorf("failed to retrieve snapshot: %w", tx.Error)
+	}
+
+	return snapshot, nil
+}
+
+// DeleteSnapshot deletes the snapshot record, e.g. once its content has been removed from storage.
+func DeleteSnapshot(ctx context.Context, conn *gorm.DB, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("snapshot ID is a required argument")
+	}
+
+	tx := conn.WithContext(ctx).
+		Where("id = ?", id.String()).
+		Delete(&Snapshot{})
+	if tx.Error != nil {
+		return fmt.Errorf("failed to delete snapshot %s: %w", id.String(), tx.Error)
+	}
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("snapshot with ID %s does not exist: %w", id.String(), ErrorNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"testing"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_Filename(t *testing.T) {
+	for _, s := range []struct {
+		BucketID string
+		Expected string
+		Error    bool
+	}{
+		{BucketID: "workspaces/e8d1d1a2-1b9b-4b6e-9e2a-1f6c9d7b0a6f/snapshot-1683547302117.tar@gitpod-user-1234", Expected: "snapshot-1683547302117.tar"},
+		{BucketID: "snapshot.tar@gitpod-user-1234", Expected: "snapshot.tar"},
+		{BucketID: "gitpod-user-1234", Error: true},
+		{BucketID: "@gitpod-user-1234", Error: true},
+	} {
+		t.Run(s.BucketID, func(t *testing.T) {
+			snapshot := db.Snapshot{BucketID: s.BucketID}
+			filename, err := snapshot.Filename()
+			if s.Error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, s.Expected, filename)
+		})
+	}
+}
@@ -75,3 +75,23 @@ func GetTeamBySlug(ctx context.Context, conn *gorm.DB, slug string) (Team, error
 
 	return team, nil
 }
+
+func GetTeam(ctx context.Context, conn *gorm.DB, id uuid.UUID) (Team, error) {
+	if id == uuid.Nil {
+		return Team{}, errors.New("id is a required argument")
+	}
+
+	var team Team
+	tx := conn.WithContext(ctx).
+		Where("id = ?", id).
+		Where("deleted = ?", 0).
+		First(&team)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return Team{}, fmt.Errorf("team with ID %s does not exist: %w", id.String(), ErrorNotFound)
+		}
+		return Team{}, fmt.Errorf("failed to retrieve team: %w", tx.Error)
+	}
+
+	return team, nil
+}
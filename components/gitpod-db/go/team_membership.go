@@ -6,6 +6,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -55,3 +56,133 @@ func ListTeamMembershipsForUserIDs(ctx context.Context, conn *gorm.DB, userIDs [
 
 	return memberships, nil
 }
+
+func GetTeamMembership(ctx context.Context, conn *gorm.DB, userID, teamID uuid.UUID) (TeamMembership, error) {
+	if userID == uuid.Nil {
+		return TeamMembership{}, errors.New("user ID is a required argument")
+	}
+	if teamID == uuid.Nil {
+		return TeamMembership{}, errors.New("team ID is a required argument")
+	}
+
+	var membership TeamMembership
+	tx := conn.WithContext(ctx).
+		Where("userId = ?", userID).
+		Where("teamId = ?", teamID).
+		Where("deleted = ?", 0).
+		First(&membership)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return TeamMembership{}, fmt.Errorf("team membership for user %s in team %s does not exist: %w", userID.String(), teamID.String(), ErrorNotFound)
+		}
+		return TeamMembership{}, fmt.Errorf("failed to retrieve team membership: %w", tx.Error)
+	}
+
+	return membership, nil
+}
+
+func CreateTeamMembership(ctx context.Context, conn *gorm.DB, membership TeamMembership) (TeamMembership, error) {
+	if membership.ID == uuid.Nil {
+		return TeamMembership{}, errors.New("id must be set")
+	}
+	if membership.TeamID == uuid.Nil {
+		return TeamMembership{}, errors.New("team ID must be set")
+	}
+	if membership.UserID == uuid.Nil {
+		return TeamMembership{}, errors.New("user ID must be set")
+	}
+	if membership.Role == "" {
+		return TeamMembership{}, errors.New("role must be set")
+	}
+	if !membership.CreationTime.IsSet() {
+		membership.CreationTime = NewVarCharTime(time.Now())
+	}
+
+	tx := conn.WithContext(ctx).Create(&membership)
+	if tx.Error != nil {
+		return TeamMembership{}, fmt.Errorf("failed to create team membership: %w", tx.Error)
+	}
+
+	return membership, nil
+}
+
+func UpdateTeamMembershipRole(ctx context.Context, conn *gorm.DB, id uuid.UUID, role TeamMembershipRole) error {
+	if id == uuid.Nil {
+		return errors.New("id is a required argument")
+	}
+	if role == "" {
+		return errors.New("role is a required argument")
+	}
+
+	tx := conn.WithContext(ctx).
+		Table((&TeamMembership{}).TableName()).
+		Where("id = ?", id).
+		Where("deleted = ?", 0).
+		Update("role", role)
+	if tx.Error != nil {
+		return fmt.Errorf("failed to update role of team membership %s: %w", id.String(), tx.Error)
+	}
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("team membership %s does not exist: %w", id.String(), ErrorNotFound)
+	}
+
+	return nil
+}
+
+// DeleteTeamMembership marks the membership as deleted.
+func DeleteTeamMembership(ctx context.Context, conn *gorm.DB, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("id is a required argument")
+	}
+
+	tx := conn.WithContext(ctx).
+		Table((&TeamMembership{}).TableName()).
+		Where("id = ?", id).
+		Where("deleted = ?", 0).
+		Update("deleted", 1)
+	if tx.Error != nil {
+		return fmt.Errorf("failed to delete team membership %s: %w", id.String(), tx.Error)
+	}
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("team membership %s does not exist: %w", id.String(), ErrorNotFound)
+	}
+
+	return nil
+}
+
+func CountTeamMembershipsWithRole(ctx context.Context, conn *gorm.DB, teamID uuid.UUID, role TeamMembershipRole) (int64, error) {
+	if teamID == uuid.Nil {
+		return 0, errors.New("team ID is a required argument")
+	}
+
+	var count int64
+	tx := conn.WithContext(ctx).
+		Model(&TeamMembership{}).
+		Where("teamId = ?", teamID).
+		Where("role = ?", role).
+		Where("deleted = ?", 0).
+		Count(&count)
+	if tx.Error != nil {
+		return 0, fmt.Errorf("failed to count team memberships with role %s: %w", role, tx.Error)
+	}
+
+	return count, nil
+}
+
+func ListTeamMembershipsForTeam(ctx context.Context, conn *gorm.DB, teamID uuid.UUID) ([]TeamMembership, error) {
+	if teamID == uuid.Nil {
+		return nil, errors.New("team ID is a required argument")
+	}
+
+	var memberships []TeamMembership
+	tx := conn.WithContext(ctx).
+		Where("teamId = ?", teamID).
+		Where("deleted = ?", 0).
+		Order("creationTime").
+		Find(&memberships)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list team memberships for team %s: %w", teamID.String(), tx.Error)
+	}
+
+	return memberships, nil
+}
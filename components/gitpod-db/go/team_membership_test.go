@@ -99,3 +99,54 @@ func TestListTeamMembershipsForUserIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestTeamMembership_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+	teamID, userID := uuid.New(), uuid.New()
+
+	_, err := db.GetTeamMembership(ctx, conn, userID, teamID)
+	require.ErrorIs(t, err, db.ErrorNotFound)
+
+	created, err := db.CreateTeamMembership(ctx, conn, db.TeamMembership{
+		ID:     uuid.New(),
+		TeamID: teamID,
+		UserID: userID,
+		Role:   db.TeamMembershipRole_Member,
+	})
+	require.NoError(t, err)
+	require.True(t, created.CreationTime.IsSet())
+
+	owners, err := db.CountTeamMembershipsWithRole(ctx, conn, teamID, db.TeamMembershipRole_Owner)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, owners)
+
+	err = db.UpdateTeamMembershipRole(ctx, conn, created.ID, db.TeamMembershipRole_Owner)
+	require.NoError(t, err)
+
+	read, err := db.GetTeamMembership(ctx, conn, userID, teamID)
+	require.NoError(t, err)
+	require.Equal(t, db.TeamMembershipRole_Owner, read.Role)
+
+	listed, err := db.ListTeamMembershipsForTeam(ctx, conn, teamID)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, created.ID, listed[0].ID)
+
+	owners, err = db.CountTeamMembershipsWithRole(ctx, conn, teamID, db.TeamMembershipRole_Owner)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, owners)
+
+	err = db.DeleteTeamMembership(ctx, conn, created.ID)
+	require.NoError(t, err)
+
+	_, err = db.GetTeamMembership(ctx, conn, userID, teamID)
+	require.ErrorIs(t, err, db.ErrorNotFound)
+
+	listed, err = db.ListTeamMembershipsForTeam(ctx, conn, teamID)
+	require.NoError(t, err)
+	require.Empty(t, listed)
+
+	err = db.DeleteTeamMembership(ctx, conn, created.ID)
+	require.ErrorIs(t, err, db.ErrorNotFound)
+}
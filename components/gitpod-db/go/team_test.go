@@ -56,3 +56,21 @@ func Test_GetTeamBySlug(t *testing.T) {
 	require.Equal(t, team.Name, read.Name)
 	require.Equal(t, team.Slug, read.Slug)
 }
+
+func Test_GetTeam(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+
+	team, err := db.CreateTeam(context.Background(), conn, db.Team{
+		ID:   uuid.New(),
+		Name: "Team1",
+		Slug: uuid.New().String(),
+	})
+	require.NoError(t, err)
+
+	read, err := db.GetTeam(context.Background(), conn, team.ID)
+	require.NoError(t, err)
+	require.Equal(t, team.Name, read.Name)
+
+	_, err = db.GetTeam(context.Background(), conn, uuid.New())
+	require.ErrorIs(t, err, db.ErrorNotFound)
+}
@@ -206,6 +206,116 @@ func GetUsageSummary(ctx context.Context, conn *gorm.DB, params GetUsageSummaryP
 	return result, nil
 }
 
+// UsageFilter selects the usage of workspace instances of an attribution ID within a time range.
+// UserID, ProjectID and WorkspaceClass further restrict the usage, if set.
+type UsageFilter struct {
+	AttributionID  AttributionID
+	From, To       time.Time
+	UserID         uuid.UUID
+	ProjectID      string
+	WorkspaceClass string
+}
+
+const (
+	usageWorkspaceIDExpr    = "JSON_UNQUOTE(JSON_EXTRACT(u.metadata, '$.workspaceId'))"
+	usageUserIDExpr         = "JSON_UNQUOTE(JSON_EXTRACT(u.metadata, '$.userId'))"
+	usageWorkspaceClassExpr = "JSON_UNQUOTE(JSON_EXTRACT(u.metadata, '$.workspaceClass'))"
+)
+
+func (f UsageFilter) query(ctx context.Context, conn *gorm.DB, joinWorkspaces bool) *gorm.DB {
+	tx := conn.WithContext(ctx).
+		Table(fmt.Sprintf("%s AS u", (&Usage{}).TableName())).
+		Where("u.attributionId = ?", f.AttributionID).
+		Where("u.effectiveTime >= ? AND u.effectiveTime < ?", TimeToISO8601(f.From), TimeToISO8601(f.To)).
+		Where("u.kind = ?", WorkspaceInstanceUsageKind)
+	if f.UserID != uuid.Nil {
+		tx = tx.Where(usageUserIDExpr+" = ?", f.UserID.String())
+	}
+	if f.WorkspaceClass != "" {
+		tx = tx.Where(usageWorkspaceClassExpr+" = ?", f.WorkspaceClass)
+	}
+	if joinWorkspaces || f.ProjectID != "" {
+		// usage metadata does not contain the project of a workspace
+		tx = tx.Joins(fmt.Sprintf("LEFT JOIN %s AS w ON w.id = %s", (&Workspace{}).TableName(), usageWorkspaceIDExpr))
+	}
+	if f.ProjectID != "" {
+		tx = tx.Where("w.projectId = ?", f.ProjectID)
+	}
+	return tx
+}
+
+// ListUsageWithFilter lists the usage matching the filter, most recent first.
+func ListUsageWithFilter(ctx context.Context, conn *gorm.DB, filter UsageFilter, pagination Pagination) ([]Usage, error) {
+	var results []Usage
+	err := filter.query(ctx, conn, false).
+		Select("u.*").
+		Order("u.effectiveTime DESC").
+		Order("u.id").
+		Scopes(Paginate(pagination)).
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usage: %w", err)
+	}
+	return results, nil
+}
+
+// SummarizeUsageWithFilter counts and sums up the credits of the usage matching the filter.
+func SummarizeUsageWithFilter(ctx context.Context, conn *gorm.DB, filter UsageFilter) (GetUsageSummaryResponse, error) {
+	var result GetUsageSummaryResponse
+	err := filter.query(ctx, conn, false).
+		Select("COALESCE(SUM(u.creditCents), 0) AS CreditCentsUsed, COUNT(*) AS NumberOfRecords").
+		Find(&result).Error
+	if err != nil {
+		return result, fmt.Errorf("failed to summarize usage: %w", err)
+	}
+	return result, nil
+}
+
+type UsageDimension string
+
+const (
+	UsageDimensionUser           UsageDimension = "user"
+	UsageDimensionProject        UsageDimension = "project"
+	UsageDimensionWorkspaceClass UsageDimension = "workspaceClass"
+	UsageDimensionDay            UsageDimension = "day"
+)
+
+type UsageAggregate struct {
+	// Key is the value of the dimension, e.g. the ID of a user or the day in the form 2006-01-02.
+	Key         string      `gorm:"column:aggregateKey"`
+	CreditCents CreditCents `gorm:"column:creditCents"`
+	Count       int64       `gorm:"column:usageCount"`
+}
+
+// AggregateUsageWithFilter sums up the credits of the usage matching the filter per value of the dimension.
+func AggregateUsageWithFilter(ctx context.Context, conn *gorm.DB, filter UsageFilter, dimension UsageDimension) ([]UsageAggregate, error) {
+	var key string
+	switch dimension {
+	case UsageDimensionUser:
+		key = usageUserIDExpr
+	case UsageDimensionProject:
+		key = "w.projectId"
+	case UsageDimensionWorkspaceClass:
+		key = usageWorkspaceClassExpr
+	case UsageDimensionDay:
+		// effective times are stored in UTC as ISO 8601, hence the day is their prefix
+		key = "LEFT(u.effectiveTime, 10)"
+	default:
+		return nil, fmt.Errorf("unknown usage dimension %q", dimension)
+	}
+	key = fmt.Sprintf("COALESCE(%s, '')", key)
+
+	var results []UsageAggregate
+	err := filter.query(ctx, conn, dimension == UsageDimensionProject).
+		Select(fmt.Sprintf("%s AS aggregateKey, SUM(u.creditCents) AS creditCents, COUNT(*) AS usageCount", key)).
+		Group(key).
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate usage by %s: %w", dimension, err)
+	}
+	return results, nil
+}
+
 type Balance struct {
 	AttributionID AttributionID `gorm:"column:attributionId;type:varchar;size:255;" json:"attributionId"`
 	CreditCents   CreditCents   `gorm:"column:creditCents;type:bigint;" json:"creditCents"`
@@ -6,6 +6,7 @@ package db_test
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 	"time"
@@ -136,6 +137,98 @@ func TestGetUsageSummary(t *testing.T) {
 	}
 }
 
+func TestUsageWithFilter(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+	ctx := context.Background()
+
+	start := time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+	attributionID := db.NewTeamAttributionID(uuid.New().String())
+	userID, otherUserID := uuid.New(), uuid.New()
+	projectID := uuid.New().String()
+	workspaces := dbtest.CreateWorkspaces(t, conn,
+		dbtest.NewWorkspace(t, db.Workspace{ProjectID: sql.NullString{String: projectID, Valid: true}}),
+		dbtest.NewWorkspace(t, db.Workspace{}),
+	)
+
+	newUsage := func(effectiveTime time.Time, cents db.CreditCents, data db.WorkspaceInstanceUsageData) db.Usage {
+		usage := dbtest.NewUsage(t, db.Usage{
+			AttributionID: attributionID,
+			EffectiveTime: db.NewVarCharTime(effectiveTime),
+			CreditCents:   cents,
+		})
+		require.NoError(t, usage.SetMetadataWithWorkspaceInstance(data))
+		return usage
+	}
+	first := newUsage(start.Add(time.Hour), 100, db.WorkspaceInstanceUsageData{WorkspaceId: workspaces[0].ID, WorkspaceClass: "g1-standard", UserID: userID})
+	second := newUsage(start.Add(25*time.Hour), 200, db.WorkspaceInstanceUsageData{WorkspaceId: workspaces[1].ID, WorkspaceClass: "g1-large", UserID: userID})
+	third := newUsage(start.Add(26*time.Hour), 10, db.WorkspaceInstanceUsageData{WorkspaceId: workspaces[0].ID, WorkspaceClass: "g1-large", UserID: otherUserID})
+	outside := newUsage(start.Add(-time.Hour), 1000, db.WorkspaceInstanceUsageData{WorkspaceId: workspaces[0].ID, UserID: userID})
+	dbtest.CreateUsageRecords(t, conn, first, second, third, outside)
+
+	filter := db.UsageFilter{AttributionID: attributionID, From: start, To: start.Add(48 * time.Hour)}
+
+	t.Run("lists most recent first", func(t *testing.T) {
+		usage, err := db.ListUsageWithFilter(ctx, conn, filter, db.Pagination{Page: 2, PageSize: 2})
+		require.NoError(t, err)
+		require.Len(t, usage, 1)
+		require.Equal(t, first.ID, usage[0].ID)
+
+		summary, err := db.SummarizeUsageWithFilter(ctx, conn, filter)
+		require.NoError(t, err)
+		require.Equal(t, db.GetUsageSummaryResponse{CreditCentsUsed: 310, NumberOfRecords: 3}, summary)
+	})
+
+	t.Run("filters by user, project and workspace class", func(t *testing.T) {
+		for _, test := range []struct {
+			Filter   db.UsageFilter
+			Expected []uuid.UUID
+		}{
+			{Filter: db.UsageFilter{UserID: userID}, Expected: []uuid.UUID{second.ID, first.ID}},
+			{Filter: db.UsageFilter{ProjectID: projectID}, Expected: []uuid.UUID{third.ID, first.ID}},
+			{Filter: db.UsageFilter{WorkspaceClass: "g1-large", UserID: otherUserID}, Expected: []uuid.UUID{third.ID}},
+		} {
+			f := test.Filter
+			f.AttributionID, f.From, f.To = filter.AttributionID, filter.From, filter.To
+
+			usage, err := db.ListUsageWithFilter(ctx, conn, f, db.Pagination{PageSize: 10})
+			require.NoError(t, err)
+			var ids []uuid.UUID
+			for _, u := range usage {
+				ids = append(ids, u.ID)
+			}
+			require.Equal(t, test.Expected, ids)
+		}
+	})
+
+	t.Run("aggregates by dimension", func(t *testing.T) {
+		for dimension, expected := range map[db.UsageDimension][]db.UsageAggregate{
+			db.UsageDimensionUser: {
+				{Key: userID.String(), CreditCents: 300, Count: 2},
+				{Key: otherUserID.String(), CreditCents: 10, Count: 1},
+			},
+			db.UsageDimensionProject: {
+				{Key: projectID, CreditCents: 110, Count: 2},
+				{Key: "", CreditCents: 200, Count: 1},
+			},
+			db.UsageDimensionWorkspaceClass: {
+				{Key: "g1-standard", CreditCents: 100, Count: 1},
+				{Key: "g1-large", CreditCents: 210, Count: 2},
+			},
+			db.UsageDimensionDay: {
+				{Key: "2022-07-01", CreditCents: 100, Count: 1},
+				{Key: "2022-07-02", CreditCents: 210, Count: 2},
+			},
+		} {
+			aggregates, err := db.AggregateUsageWithFilter(ctx, conn, filter, dimension)
+			require.NoError(t, err)
+			require.ElementsMatch(t, expected, aggregates, dimension)
+		}
+
+		_, err := db.AggregateUsageWithFilter(ctx, conn, filter, "unknown")
+		require.Error(t, err)
+	})
+}
+
 func TestInsertUsageRecords(t *testing.T) {
 	conn := dbtest.ConnectForTests(t)
 
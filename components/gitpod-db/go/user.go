@@ -0,0 +1,193 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type User struct {
+	ID uuid.UUID `gorm:"primary_key;column:id;type:char;size:36;" json:"id"`
+
+	// OrganizationID is set for users which are owned by an organization, e.g. because they were created through SSO.
+	OrganizationID string `gorm:"column:organizationId;type:char;size:36;" json:"organizationId"`
+
+	AvatarURL string `gorm:"column:avatarUrl;type:varchar;size:255;" json:"avatarUrl"`
+	Name      string `gorm:"column:name;type:varchar;size:255;" json:"name"`
+	FullName  string `gorm:"column:fullName;type:varchar;size:255;" json:"fullName"`
+
+	Blocked       bool `gorm:"column:blocked;type:tinyint;default:0;" json:"blocked"`
+	MarkedDeleted bool `gorm:"column:markedDeleted;type:tinyint;default:0;" json:"markedDeleted"`
+
+	CreationDate VarcharTime `gorm:"column:creationDate;type:varchar;size:255;" json:"creationDate"`
+	LastModified time.Time   `gorm:"column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
+}
+
+// TableName sets the insert table name for this struct type
+func (u *User) TableName() string {
+	return "d_b_user"
+}
+
+type Identity struct {
+	AuthProviderID string    `gorm:"primary_key;column:authProviderId;type:varchar;size:255;" json:"authProviderId"`
+	AuthID         string    `gorm:"primary_key;column:authId;type:varchar;size:255;" json:"authId"`
+	AuthName       string    `gorm:"column:authName;type:varchar;size:255;" json:"authName"`
+	UserID         uuid.UUID `gorm:"column:userId;type:char;size:36;" json:"userId"`
+	PrimaryEmail   string    `gorm:"column:primaryEmail;type:varchar;size:255;" json:"primaryEmail"`
+
+	// Tokens is deprecated, but the column does not have a default value.
+	Tokens string `gorm:"column:tokens;type:text;" json:"-"`
+
+	LastModified time.Time `gorm:"column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
+
+	// deleted is reserved for use by periodic deleter
+	_ bool `gorm:"column:deleted;type:tinyint;default:0;" json:"deleted"`
+}
+
+// TableName sets the insert table name for this struct type
+func (i *Identity) TableName() string {
+	return "d_b_identity"
+}
+
+// CreateUser stores a new user together with its identity. It does not open a transaction of its own,
+// callers pass a transaction to store both atomically.
+func CreateUser(ctx context.Context, conn *gorm.DB, user User, identity Identity) (User, error) {
+	if user.ID == uuid.Nil {
+		return User{}, errors.New("id must be set")
+	}
+	if identity.AuthProviderID == "" || identity.AuthID == "" {
+		return User{}, errors.New("identity must have an auth provider and auth ID")
+	}
+	if !user.CreationDate.IsSet() {
+		user.CreationDate = NewVarCharTime(time.Now())
+	}
+	identity.UserID = user.ID
+	if identity.Tokens == "" {
+		identity.Tokens = "[]"
+	}
+
+	tx := conn.WithContext(ctx)
+	if err := tx.Create(&user).Error; err != nil {
+		return User{}, fmt.Errorf("failed to create user: %w", err)
+	}
+	if err := tx.Create(&identity).Error; err != nil {
+		return User{}, fmt.Errorf("failed to create identity of user %s: %w", user.ID.String(), err)
+	}
+
+	return user, nil
+}
+
+func GetUser(ctx context.Context, conn *gorm.DB, id uuid.UUID) (User, error) {
+	if id == uuid.Nil {
+		return User{}, errors.New("id is a required argument")
+	}
+
+	var user User
+	tx := conn.WithContext(ctx).
+		Where("id = ?", id).
+		Where("markedDeleted = ?", 0).
+		First(&user)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return User{}, fmt.Errorf("user with ID %s does not exist: %w", id.String(), ErrorNotFound)
+		}
+		return User{}, fmt.Errorf("failed to retrieve user: %w", tx.Error)
+	}
+
+	return user, nil
+}
+
+func ListUsersForOrganization(ctx context.Context, conn *gorm.DB, organizationID uuid.UUID) ([]User, error) {
+	if organizationID == uuid.Nil {
+		return nil, errors.New("organization ID is a required argument")
+	}
+
+	var users []User
+	tx := conn.WithContext(ctx).
+		Where("organizationId = ?", organizationID.String()).
+		Where("markedDeleted = ?", 0).
+		Order("creationDate").
+		Find(&users)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list users for organization %s: %w", organizationID.String(), tx.Error)
+	}
+
+	return users, nil
+}
+
+// UpdateUser updates the profile and the blocked state of a user.
+func UpdateUser(ctx context.Context, conn *gorm.DB, user User) (User, error) {
+	if user.ID == uuid.Nil {
+		return User{}, errors.New("id is a required argument")
+	}
+
+	tx := conn.WithContext(ctx).
+		Model(&user).
+		Select("avatarUrl", "name", "fullName", "blocked").
+		Updates(&user)
+	if tx.Error != nil {
+		return User{}, fmt.Errorf("failed to update user %s: %w", user.ID.String(), tx.Error)
+	}
+
+	return GetUser(ctx, conn, user.ID)
+}
+
+func GetIdentity(ctx context.Context, conn *gorm.DB, authProviderID, authID string) (Identity, error) {
+	var identity Identity
+	tx := conn.WithContext(ctx).
+		Where("authProviderId = ?", authProviderID).
+		Where("authId = ?", authID).
+		Where("deleted = ?", 0).
+		First(&identity)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return Identity{}, fmt.Errorf("identity %s of auth provider %s does not exist: %w", authID, authProviderID, ErrorNotFound)
+		}
+		return Identity{}, fmt.Errorf("failed to retrieve identity: %w", tx.Error)
+	}
+
+	return identity, nil
+}
+
+func ListIdentitiesForUserIDs(ctx context.Context, conn *gorm.DB, userIDs []uuid.UUID) ([]Identity, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	var identities []Identity
+	tx := conn.WithContext(ctx).
+		Where("userId IN ?", userIDs).
+		Where("deleted = ?", 0).
+		Find(&identities)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list identities for user IDs: %w", tx.Error)
+	}
+
+	return identities, nil
+}
+
+// UpdateIdentity updates the auth name and primary email of an identity.
+func UpdateIdentity(ctx context.Context, conn *gorm.DB, identity Identity) error {
+	tx := conn.WithContext(ctx).
+		Model(&Identity{}).
+		Where("authProviderId = ?", identity.AuthProviderID).
+		Where("authId = ?", identity.AuthID).
+		Where("deleted = ?", 0).
+		Updates(map[string]interface{}{
+			"authName":     identity.AuthName,
+			"primaryEmail": identity.PrimaryEmail,
+		})
+	if tx.Error != nil {
+		return fmt.Errorf("failed to update identity %s of auth provider %s: %w", identity.AuthID, identity.AuthProviderID, tx.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUser(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+	orgID := uuid.New()
+
+	created, err := db.CreateUser(ctx, conn, db.User{
+		ID:             uuid.New(),
+		OrganizationID: orgID.String(),
+		Name:           "homer",
+		FullName:       "Homer Simpson",
+	}, db.Identity{
+		AuthProviderID: "https://accounts.google.com",
+		AuthID:         uuid.NewString(),
+		AuthName:       "homer@example.com",
+		PrimaryEmail:   "homer@example.com",
+	})
+	require.NoError(t, err)
+	require.True(t, created.CreationDate.IsSet())
+
+	read, err := db.GetUser(ctx, conn, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Homer Simpson", read.FullName)
+	require.Equal(t, orgID.String(), read.OrganizationID)
+
+	users, err := db.ListUsersForOrganization(ctx, conn, orgID)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+
+	identities, err := db.ListIdentitiesForUserIDs(ctx, conn, []uuid.UUID{created.ID})
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+	require.Equal(t, "homer@example.com", identities[0].AuthName)
+
+	identity, err := db.GetIdentity(ctx, conn, identities[0].AuthProviderID, identities[0].AuthID)
+	require.NoError(t, err)
+	require.Equal(t, created.ID, identity.UserID)
+
+	_, err = db.GetIdentity(ctx, conn, identities[0].AuthProviderID, uuid.NewString())
+	require.ErrorIs(t, err, db.ErrorNotFound)
+}
+
+func TestUpdateUser(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+
+	identity := db.Identity{
+		AuthProviderID: "https://accounts.google.com",
+		AuthID:         uuid.NewString(),
+		AuthName:       "homer",
+	}
+	created, err := db.CreateUser(ctx, conn, db.User{ID: uuid.New(), Name: "homer", Blocked: true}, identity)
+	require.NoError(t, err)
+
+	created.Name = "marge"
+	created.Blocked = false
+	updated, err := db.UpdateUser(ctx, conn, created)
+	require.NoError(t, err)
+	require.Equal(t, "marge", updated.Name)
+	require.False(t, updated.Blocked, "zero values must be written")
+
+	identity.AuthName = "marge"
+	identity.PrimaryEmail = "marge@example.com"
+	require.NoError(t, db.UpdateIdentity(ctx, conn, identity))
+
+	read, err := db.GetIdentity(ctx, conn, identity.AuthProviderID, identity.AuthID)
+	require.NoError(t, err)
+	require.Equal(t, "marge", read.AuthName)
+	require.Equal(t, "marge@example.com", read.PrimaryEmail)
+}
@@ -271,3 +271,21 @@ func ListWorkspaceInstanceIDsWithPhaseStoppedButNoStoppingTime(ctx context.Conte
 	}
 	return ids, nil
 }
+
+// FindWorkspaceImagesInUse finds the distinct workspace images of all instances which were created at or after since,
+// or which have not stopped yet.
+func FindWorkspaceImagesInUse(ctx context.Context, conn *gorm.DB, since time.Time) ([]string, error) {
+	var images []string
+
+	tx := conn.WithContext(ctx).
+		Table(fmt.Sprintf("%s as wsi", (&WorkspaceInstance{}).TableName())).
+		Distinct("wsi.workspaceImage").
+		Where("wsi.workspaceImage != ?", "").
+		Where(conn.Where("wsi.creationTime >= ?", TimeToISO8601(since)).Or("wsi.stoppedTime = ?", "")).
+		Pluck("wsi.workspaceImage", &images)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to find workspace images in use: %w", tx.Error)
+	}
+
+	return images, nil
+}
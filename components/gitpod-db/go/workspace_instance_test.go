@@ -226,3 +226,42 @@ func TestListWorkspaceInstanceIDsWithPhaseStoppedButNoStoppingTime(t *testing.T)
 	require.NoError(t, err)
 	require.Equal(t, []uuid.UUID{instances[1].ID}, detectedIDs)
 }
+
+func TestFindWorkspaceImagesInUse(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+
+	now := time.Now().UTC()
+	image := func() string {
+		return fmt.Sprintf("eu.gcr.io/gitpod/workspace-images:%s", uuid.New().String())
+	}
+
+	recent, running, expired := image(), image(), image()
+	dbtest.CreateWorkspaceInstances(t, conn,
+		// created within the retention window
+		dbtest.NewWorkspaceInstance(t, db.WorkspaceInstance{
+			CreationTime:   db.NewVarCharTime(now.Add(-1 * time.Hour)),
+			StartedTime:    db.NewVarCharTime(now.Add(-1 * time.Hour)),
+			StoppedTime:    db.NewVarCharTime(now.Add(-30 * time.Minute)),
+			WorkspaceImage: recent,
+		}),
+		// created before the retention window, but still running
+		dbtest.NewWorkspaceInstance(t, db.WorkspaceInstance{
+			CreationTime:   db.NewVarCharTime(now.Add(-72 * time.Hour)),
+			StartedTime:    db.NewVarCharTime(now.Add(-72 * time.Hour)),
+			WorkspaceImage: running,
+		}),
+		// created and stopped before the retention window
+		dbtest.NewWorkspaceInstance(t, db.WorkspaceInstance{
+			CreationTime:   db.NewVarCharTime(now.Add(-72 * time.Hour)),
+			StartedTime:    db.NewVarCharTime(now.Add(-72 * time.Hour)),
+			StoppedTime:    db.NewVarCharTime(now.Add(-71 * time.Hour)),
+			WorkspaceImage: expired,
+		}),
+	)
+
+	images, err := db.FindWorkspaceImagesInUse(context.Background(), conn, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.Contains(t, images, recent)
+	require.Contains(t, images, running)
+	require.NotContains(t, images, expired)
+}
@@ -0,0 +1,335 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	driver_mysql "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// WorkspaceTemplate is a version of a named, reusable starting point for workspaces, created from a snapshot.
+// Every time a template is saved under an existing name, a new version is created.
+type WorkspaceTemplate struct {
+	ID             uuid.UUID `gorm:"primary_key;column:id;type:char;size:36;" json:"id"`
+	OrganizationID uuid.UUID `gorm:"column:organizationId;type:char;size:36;" json:"organizationId"`
+
+	// ProjectID is empty for templates which are shared across the whole organization.
+	ProjectID string `gorm:"column:projectId;type:varchar;size:255;" json:"projectId"`
+
+	Name        string `gorm:"column:name;type:varchar;size:255;" json:"name"`
+	Version     int    `gorm:"column:version;type:int;" json:"version"`
+	Description string `gorm:"column:description;type:varchar;size:255;" json:"description"`
+
+	SnapshotID uuid.UUID `gorm:"column:snapshotId;type:char;size:36;" json:"snapshotId"`
+	CreatorID  uuid.UUID `gorm:"column:creatorId;type:char;size:36;" json:"creatorId"`
+
+	// OwnsSnapshot is set when the snapshot was taken for the template. Only such snapshots are deleted
+	// together with the template, other snapshots may have been shared already.
+	OwnsSnapshot bool `gorm:"column:ownsSnapshot;type:tinyint;default:0;" json:"ownsSnapshot"`
+
+	Image          string `gorm:"column:image;type:varchar;size:255;" json:"image"`
+	WorkspaceClass string `gorm:"column:workspaceClass;type:varchar;size:255;" json:"workspaceClass"`
+	IDE            string `gorm:"column:ide;type:varchar;size:255;" json:"ide"`
+
+	// EnvVars is a JSON object of the environment variables workspaces started from the template receive.
+	EnvVars datatypes.JSON `gorm:"column:envVars;type:text;size:65535" json:"envVars"`
+
+	CreatedAt    time.Time `gorm:"column:createdAt;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"createdAt"`
+	LastModified time.Time `gorm:"column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
+
+	// deleted is reserved for use by periodic deleter.
+	_ bool `gorm:"column:deleted;type:tinyint;default:0;" json:"deleted"`
+}
+
+// TableName sets the insert table name for this struct type
+func (t *WorkspaceTemplate) TableName() string {
+	return "d_b_workspace_template"
+}
+
+// SetEnvVars stores the environment variables as JSON object on the template.
+func (t *WorkspaceTemplate) SetEnvVars(envVars map[string]string) error {
+	if envVars == nil {
+		envVars = map[string]string{}
+	}
+	b, err := json.Marshal(envVars)
+	if err != nil {
+		return fmt.Errorf("failed to marshal workspace template env vars: %w", err)
+	}
+	t.EnvVars = b
+	return nil
+}
+
+// GetEnvVars returns the environment variables of the template.
+func (t *WorkspaceTemplate) GetEnvVars() (map[string]string, error) {
+	res := map[string]string{}
+	if len(t.EnvVars) == 0 {
+		return res, nil
+	}
+	err := json.Unmarshal(t.EnvVars, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workspace template env vars: %w", err)
+	}
+	return res, nil
+}
+
+const createWorkspaceTemplateAttempts = 5
+
+// CreateWorkspaceTemplate stores the template as the next version of the template with the same name.
+// The version of the template passed in is ignored.
+func CreateWorkspaceTemplate(ctx context.Context, conn *gorm.DB, tmpl WorkspaceTemplate) (WorkspaceTemplate, error) {
+	if tmpl.ID == uuid.Nil {
+		return WorkspaceTemplate{}, errors.New("id must be set")
+	}
+	if tmpl.OrganizationID == uuid.Nil {
+		return WorkspaceTemplate{}, errors.New("organization ID must be set")
+	}
+	if tmpl.Name == "" {
+		return WorkspaceTemplate{}, errors.New("name must be set")
+	}
+	if tmpl.SnapshotID == uuid.Nil {
+		return WorkspaceTemplate{}, errors.New("snapshot ID must be set")
+	}
+	if tmpl.CreatedAt.IsZero() {
+		tmpl.CreatedAt = time.Now().UTC()
+	}
+	if len(tmpl.EnvVars) == 0 {
+		tmpl.EnvVars = datatypes.JSON("{}")
+	}
+
+	// Concurrent creates of the same template compute the same version, of which only one can be inserted
+	// because of the unique index. The others retry with the next version.
+	var err error
+	for attempt := 0; attempt < createWorkspaceTemplateAttempts; attempt++ {
+		err = conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			// Deleted versions are included, such that version numbers are never reused.
+			var latest *int
+			err := tx.
+				Table((&WorkspaceTemplate{}).TableName()).
+				Select("MAX(version)").
+				Where("organizationId = ?", tmpl.OrganizationID.String()).
+				Where("projectId = ?", tmpl.ProjectID).
+				Where("name = ?", tmpl.Name).
+				Scan(&latest).Error
+			if err != nil {
+				return err
+			}
+
+			tmpl.Version = 1
+			if latest != nil {
+				tmpl.Version = *latest + 1
+			}
+			return tx.Create(&tmpl).Error
+		})
+		if !isDuplicateEntry(err) {
+			break
+		}
+	}
+	if err != nil {
+		return WorkspaceTemplate{}, fmt.Errorf("failed to create workspace template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+func GetWorkspaceTemplate(ctx context.Context, conn *gorm.DB, id uuid.UUID) (WorkspaceTemplate, error) {
+	if id == uuid.Nil {
+		return WorkspaceTemplate{}, errors.New("workspace template ID is a required argument")
+	}
+
+	var tmpl WorkspaceTemplate
+	tx := conn.WithContext(ctx).
+		Where("id = ?", id.String()).
+		Where("deleted = ?", 0).
+		First(&tmpl)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return WorkspaceTemplate{}, fmt.Errorf("workspace template with ID %s does not exist: %w", id.String(), ErrorNotFound)
+		}
+		return WorkspaceTemplate{}, fmt.Errorf("failed to retrieve workspace template: %w", tx.Error)
+	}
+
+	return tmpl, nil
+}
+
+// GetLatestWorkspaceTemplate returns the most recent version of a template.
+func GetLatestWorkspaceTemplate(ctx context.Context, conn *gorm.DB, organizationID uuid.UUID, projectID, name string) (WorkspaceTemplate, error) {
+	if organizationID == uuid.Nil {
+		return WorkspaceTemplate{}, errors.New("organization ID is a required argument")
+	}
+	if name == "" {
+		return WorkspaceTemplate{}, errors.New("name is a required argument")
+	}
+
+	var tmpl WorkspaceTemplate
+	tx := conn.WithContext(ctx).
+		Where("organizationId = ?", organizationID.String()).
+		Where("projectId = ?", projectID).
+		Where("name = ?", name).
+		Where("deleted = ?", 0).
+		Order("version DESC").
+		First(&tmpl)
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return WorkspaceTemplate{}, fmt.Errorf("workspace template %s does not exist: %w", name, ErrorNotFound)
+		}
+		return WorkspaceTemplate{}, fmt.Errorf("failed to retrieve workspace template: %w", tx.Error)
+	}
+
+	return tmpl, nil
+}
+
+type ListWorkspaceTemplatesFilter struct {
+	// ProjectID only lists templates of the project, if set.
+	ProjectID string
+
+	// Name only lists versions of the template with the given name, if set.
+	Name string
+
+	// AllVersions lists all versions of every template, instead of only the most recent one.
+	AllVersions bool
+}
+
+// ListWorkspaceTemplatesForOrganization lists the templates of the organization by name, most recent version first.
+func ListWorkspaceTemplatesForOrganization(ctx context.Context, conn *gorm.DB, organizationID uuid.UUID, filter ListWorkspaceTemplatesFilter, pagination Pagination) (*PaginatedResult[WorkspaceTemplate], error) {
+	if organizationID == uuid.Nil {
+		return nil, errors.New("organization ID is a required argument to list workspace templates")
+	}
+
+	table := (&WorkspaceTemplate{}).TableName()
+	query := func() *gorm.DB {
+		tx := conn.
+			WithContext(ctx).
+			Table(table+" AS t").
+			Where("t.organizationId = ?", organizationID.String()).
+			Where("t.deleted = ?", 0)
+		if filter.ProjectID != "" {
+			tx = tx.Where("t.projectId = ?", filter.ProjectID)
+		}
+		if filter.Name != "" {
+			tx = tx.Where("t.name = ?", filter.Name)
+		}
+		if !filter.AllVersions {
+			tx = tx.Where("NOT EXISTS (SELECT 1 FROM " + table + " AS n WHERE n.organizationId = t.organizationId AND n.projectId = t.projectId AND n.name = t.name AND n.version > t.version AND n.deleted = 0)")
+		}
+		return tx
+	}
+
+	var results []WorkspaceTemplate
+	tx := query().
+		Select("t.*").
+		Order("t.name").
+		Order("t.projectId").
+		Order("t.version DESC").
+		Scopes(Paginate(pagination)).
+		Find(&results)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list workspace templates for organization %s: %w", organizationID.String(), tx.Error)
+	}
+
+	var count int64
+	tx = query().Count(&count)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to count workspace templates for organization %s: %w", organizationID.String(), tx.Error)
+	}
+
+	return &PaginatedResult[WorkspaceTemplate]{
+		Results: results,
+		Total:   count,
+	}, nil
+}
+
+// ListOutdatedWorkspaceTemplateVersions returns all versions of a template except for the most recent keep versions.
+func ListOutdatedWorkspaceTemplateVersions(ctx context.Context, conn *gorm.DB, organizationID uuid.UUID, projectID, name string, keep int) ([]WorkspaceTemplate, error) {
+	if organizationID == uuid.Nil {
+		return nil, errors.New("organization ID is a required argument")
+	}
+	if keep < 1 {
+		return nil, errors.New("at least one version must be kept")
+	}
+
+	var results []WorkspaceTemplate
+	tx := conn.
+		WithContext(ctx).
+		Where("organizationId = ?", organizationID.String()).
+		Where("projectId = ?", projectID).
+		Where("name = ?", name).
+		Where("deleted = ?", 0).
+		Order("version DESC").
+		Offset(keep).
+		// MySQL does not support an offset without a limit
+		Limit(maxListBatchSize).
+		Find(&results)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list outdated versions of workspace template %s: %w", name, tx.Error)
+	}
+
+	return results, nil
+}
+
+// CountWorkspaceTemplatesForSnapshot returns the number of template versions which were created from the snapshot.
+func CountWorkspaceTemplatesForSnapshot(ctx context.Context, conn *gorm.DB, snapshotID uuid.UUID) (int64, error) {
+	var count int64
+	tx := conn.
+		WithContext(ctx).
+		Table((&WorkspaceTemplate{}).TableName()).
+		Where("snapshotId = ?", snapshotID.String()).
+		Where("deleted = ?", 0).
+		Count(&count)
+	if tx.Error != nil {
+		return 0, fmt.Errorf("failed to count workspace templates for snapshot %s: %w", snapshotID.String(), tx.Error)
+	}
+
+	return count, nil
+}
+
+// WorkspaceTemplateOwnsSnapshot reports whether any version of a template, including deleted ones, was created with a snapshot taken for it.
+func WorkspaceTemplateOwnsSnapshot(ctx context.Context, conn *gorm.DB, snapshotID uuid.UUID) (bool, error) {
+	var count int64
+	tx := conn.
+		WithContext(ctx).
+		Table((&WorkspaceTemplate{}).TableName()).
+		Where("snapshotId = ?", snapshotID.String()).
+		Where("ownsSnapshot = ?", 1).
+		Count(&count)
+	if tx.Error != nil {
+		return false, fmt.Errorf("failed to look up workspace templates owning snapshot %s: %w", snapshotID.String(), tx.Error)
+	}
+
+	return count > 0, nil
+}
+
+func DeleteWorkspaceTemplate(ctx context.Context, conn *gorm.DB, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("workspace template ID is a required argument")
+	}
+
+	tx := conn.
+		WithContext(ctx).
+		Table((&WorkspaceTemplate{}).TableName()).
+		Where("id = ?", id.String()).
+		Where("deleted = ?", 0).
+		Update("deleted", 1)
+	if tx.Error != nil {
+		return fmt.Errorf("failed to delete workspace template %s: %w", id.String(), tx.Error)
+	}
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("workspace template with ID %s does not exist: %w", id.String(), ErrorNotFound)
+	}
+
+	return nil
+}
+
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *driver_mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCreateWorkspaceTemplate_IncrementsVersion(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+	orgID := uuid.New()
+
+	_, err := db.CreateWorkspaceTemplate(ctx, conn, db.WorkspaceTemplate{ID: uuid.New(), OrganizationID: orgID, SnapshotID: uuid.New()})
+	require.Error(t, err, "name must be set")
+
+	first := createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: orgID, Name: "node"})
+	require.Equal(t, 1, first.Version)
+
+	second := createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: orgID, Name: "node"})
+	require.Equal(t, 2, second.Version)
+
+	other := createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: orgID, ProjectID: "project", Name: "node"})
+	require.Equal(t, 1, other.Version, "versions are counted per project")
+
+	// deleted versions are never reused
+	require.NoError(t, db.DeleteWorkspaceTemplate(ctx, conn, second.ID))
+	third := createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: orgID, Name: "node"})
+	require.Equal(t, 3, third.Version)
+
+	latest, err := db.GetLatestWorkspaceTemplate(ctx, conn, orgID, "", "node")
+	require.NoError(t, err)
+	require.Equal(t, third.ID, latest.ID)
+}
+
+func TestCreateWorkspaceTemplate_Concurrent(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+	orgID := uuid.New()
+
+	var wg sync.WaitGroup
+	created := make([]db.WorkspaceTemplate, 4)
+	errs := make([]error, len(created))
+	for i := range created {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			created[i], errs[i] = db.CreateWorkspaceTemplate(context.Background(), conn, db.WorkspaceTemplate{ID: uuid.New(), OrganizationID: orgID, Name: "node", SnapshotID: uuid.New()})
+		}(i)
+	}
+	wg.Wait()
+
+	var versions []int
+	for i, tmpl := range created {
+		require.NoError(t, errs[i])
+		tmpl := tmpl
+		t.Cleanup(func() {
+			require.NoError(t, conn.Delete(&tmpl).Error)
+		})
+		versions = append(versions, tmpl.Version)
+	}
+	require.ElementsMatch(t, []int{1, 2, 3, 4}, versions)
+}
+
+func TestWorkspaceTemplate_EnvVars(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+
+	tmpl := db.WorkspaceTemplate{OrganizationID: uuid.New(), Name: "go"}
+	require.NoError(t, tmpl.SetEnvVars(map[string]string{"FOO": "bar baz"}))
+	created := createWorkspaceTemplate(t, conn, tmpl)
+
+	retrieved, err := db.GetWorkspaceTemplate(ctx, conn, created.ID)
+	require.NoError(t, err)
+
+	envVars, err := retrieved.GetEnvVars()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"FOO": "bar baz"}, envVars)
+}
+
+func TestListWorkspaceTemplatesForOrganization(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+	orgID := uuid.New()
+
+	nodeV1 := createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: orgID, Name: "node"})
+	nodeV2 := createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: orgID, Name: "node"})
+	goV1 := createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: orgID, ProjectID: "project", Name: "go"})
+	createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: uuid.New(), Name: "node"})
+
+	ids := func(templates []db.WorkspaceTemplate) []uuid.UUID {
+		var result []uuid.UUID
+		for _, tmpl := range templates {
+			result = append(result, tmpl.ID)
+		}
+		return result
+	}
+
+	latest, err := db.ListWorkspaceTemplatesForOrganization(ctx, conn, orgID, db.ListWorkspaceTemplatesFilter{}, db.Pagination{PageSize: 25})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, latest.Total)
+	require.Equal(t, []uuid.UUID{goV1.ID, nodeV2.ID}, ids(latest.Results))
+
+	all, err := db.ListWorkspaceTemplatesForOrganization(ctx, conn, orgID, db.ListWorkspaceTemplatesFilter{Name: "node", AllVersions: true}, db.Pagination{PageSize: 25})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, all.Total)
+	require.Equal(t, []uuid.UUID{nodeV2.ID, nodeV1.ID}, ids(all.Results))
+
+	byProject, err := db.ListWorkspaceTemplatesForOrganization(ctx, conn, orgID, db.ListWorkspaceTemplatesFilter{ProjectID: "project"}, db.Pagination{PageSize: 25})
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{goV1.ID}, ids(byProject.Results))
+
+	// once the latest version is deleted, the previous version is listed again
+	require.NoError(t, db.DeleteWorkspaceTemplate(ctx, conn, nodeV2.ID))
+	latest, err = db.ListWorkspaceTemplatesForOrganization(ctx, conn, orgID, db.ListWorkspaceTemplatesFilter{Name: "node"}, db.Pagination{PageSize: 25})
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{nodeV1.ID}, ids(latest.Results))
+}
+
+func TestListOutdatedWorkspaceTemplateVersions(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+	orgID := uuid.New()
+
+	var versions []db.WorkspaceTemplate
+	for i := 0; i < 4; i++ {
+		versions = append(versions, createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: orgID, Name: "node"}))
+	}
+
+	outdated, err := db.ListOutdatedWorkspaceTemplateVersions(ctx, conn, orgID, "", "node", 2)
+	require.NoError(t, err)
+	require.Len(t, outdated, 2)
+	require.Equal(t, versions[1].ID, outdated[0].ID)
+	require.Equal(t, versions[0].ID, outdated[1].ID)
+
+	_, err = db.ListOutdatedWorkspaceTemplateVersions(ctx, conn, orgID, "", "node", 0)
+	require.Error(t, err)
+}
+
+func TestCountWorkspaceTemplatesForSnapshot(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+	snapshotID := uuid.New()
+
+	first := createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: uuid.New(), Name: "node", SnapshotID: snapshotID})
+	createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: uuid.New(), Name: "node", SnapshotID: snapshotID})
+
+	count, err := db.CountWorkspaceTemplatesForSnapshot(ctx, conn, snapshotID)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+
+	require.NoError(t, db.DeleteWorkspaceTemplate(ctx, conn, first.ID))
+	count, err = db.CountWorkspaceTemplatesForSnapshot(ctx, conn, snapshotID)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count)
+}
+
+func TestWorkspaceTemplateOwnsSnapshot(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+	snapshotID := uuid.New()
+
+	createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: uuid.New(), Name: "node", SnapshotID: snapshotID})
+	owns, err := db.WorkspaceTemplateOwnsSnapshot(ctx, conn, snapshotID)
+	require.NoError(t, err)
+	require.False(t, owns)
+
+	owner := createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: uuid.New(), Name: "node", SnapshotID: snapshotID, OwnsSnapshot: true})
+	require.NoError(t, db.DeleteWorkspaceTemplate(ctx, conn, owner.ID))
+	owns, err = db.WorkspaceTemplateOwnsSnapshot(ctx, conn, snapshotID)
+	require.NoError(t, err)
+	require.True(t, owns, "deleted versions still own their snapshot")
+}
+
+func TestDeleteWorkspaceTemplate(t *testing.T) {
+	ctx := context.Background()
+	conn := dbtest.ConnectForTests(t)
+
+	err := db.DeleteWorkspaceTemplate(ctx, conn, uuid.New())
+	require.ErrorIs(t, err, db.ErrorNotFound)
+
+	created := createWorkspaceTemplate(t, conn, db.WorkspaceTemplate{OrganizationID: uuid.New(), Name: "node"})
+	require.NoError(t, db.DeleteWorkspaceTemplate(ctx, conn, created.ID))
+
+	_, err = db.GetWorkspaceTemplate(ctx, conn, created.ID)
+	require.ErrorIs(t, err, db.ErrorNotFound)
+
+	err = db.DeleteWorkspaceTemplate(ctx, conn, created.ID)
+	require.ErrorIs(t, err, db.ErrorNotFound)
+}
+
+func createWorkspaceTemplate(t *testing.T, conn *gorm.DB, tmpl db.WorkspaceTemplate) db.WorkspaceTemplate {
+	t.Helper()
+
+	tmpl.ID = uuid.New()
+	if tmpl.SnapshotID == uuid.Nil {
+		tmpl.SnapshotID = uuid.New()
+	}
+	created, err := db.CreateWorkspaceTemplate(context.Background(), conn, tmpl)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, conn.Delete(&created).Error)
+	})
+
+	return created
+}
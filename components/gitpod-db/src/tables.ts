@@ -275,6 +275,18 @@ export class GitpodTableDescriptionProvider implements TableDescriptionProvider
             timeColumn: "_lastModified",
             deletionColumn: "deleted",
         },
+        {
+            name: "d_b_scim_token",
+            primaryKeys: ["id"],
+            timeColumn: "_lastModified",
+            deletionColumn: "deleted",
+        },
+        {
+            name: "d_b_workspace_template",
+            primaryKeys: ["id"],
+            timeColumn: "_lastModified",
+            deletionColumn: "deleted",
+        },
         {
             name: "d_b_linked_in_profile",
             primaryKeys: ["id"],
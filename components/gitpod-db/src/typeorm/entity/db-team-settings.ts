@@ -18,6 +18,12 @@ export class DBOrgSettings implements OrganizationSettings {
     })
     workspaceSharingDisabled?: boolean;
 
+    @Column({
+        type: "simple-json",
+        nullable: true,
+    })
+    dotfileRepos?: string[];
+
     @Column()
     deleted: boolean;
 }
@@ -0,0 +1,27 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+import { MigrationInterface, QueryRunner } from "typeorm";
+import { tableExists } from "./helper/helper";
+
+export class CreateSCIMTokenTable1682672138451 implements MigrationInterface {
+    public async up(queryRunner: QueryRunner): Promise<void> {
+        if (!(await tableExists(queryRunner, "d_b_scim_token"))) {
+            await queryRunner.query(
+                "CREATE TABLE IF NOT EXISTS `d_b_scim_token` (`id` char(36) NOT NULL, `organizationId` char(36) NOT NULL, `hash` varchar(255) NOT NULL, `createdAt` timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6), `_lastModified` timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6), `deleted` tinyint(4) NOT NULL DEFAULT '0', PRIMARY KEY (id))",
+            );
+            await queryRunner.query("CREATE INDEX `ind_organizationId` ON `d_b_scim_token` (organizationId)");
+            await queryRunner.query("CREATE INDEX `ind_hash` ON `d_b_scim_token` (hash)");
+            await queryRunner.query("CREATE INDEX `ind_lastModified` ON `d_b_scim_token` (_lastModified)");
+        }
+    }
+
+    public async down(queryRunner: QueryRunner): Promise<void> {
+        if (await tableExists(queryRunner, "d_b_scim_token")) {
+            await queryRunner.query("DROP TABLE `d_b_scim_token`");
+        }
+    }
+}
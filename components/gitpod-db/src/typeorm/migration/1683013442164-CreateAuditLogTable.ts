@@ -0,0 +1,28 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+import { MigrationInterface, QueryRunner } from "typeorm";
+import { tableExists } from "./helper/helper";
+
+export class CreateAuditLogTable1683013442164 implements MigrationInterface {
+    public async up(queryRunner: QueryRunner): Promise<void> {
+        if (!(await tableExists(queryRunner, "d_b_audit_log"))) {
+            await queryRunner.query(
+                "CREATE TABLE IF NOT EXISTS `d_b_audit_log` (`id` char(36) NOT NULL, `timestamp` timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6), `organizationId` varchar(255) NOT NULL DEFAULT '', `actorId` varchar(255) NOT NULL DEFAULT '', `action` varchar(128) NOT NULL, `args` text NOT NULL, PRIMARY KEY (id))",
+            );
+            await queryRunner.query(
+                "CREATE INDEX `ind_organizationId_timestamp` ON `d_b_audit_log` (organizationId, timestamp)",
+            );
+            await queryRunner.query("CREATE INDEX `ind_actorId` ON `d_b_audit_log` (actorId)");
+        }
+    }
+
+    public async down(queryRunner: QueryRunner): Promise<void> {
+        if (await tableExists(queryRunner, "d_b_audit_log")) {
+            await queryRunner.query("DROP TABLE `d_b_audit_log`");
+        }
+    }
+}
@@ -0,0 +1,29 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+import { MigrationInterface, QueryRunner } from "typeorm";
+import { tableExists } from "./helper/helper";
+
+export class CreateWorkspaceTemplateTable1683547302117 implements MigrationInterface {
+    public async up(queryRunner: QueryRunner): Promise<void> {
+        if (!(await tableExists(queryRunner, "d_b_workspace_template"))) {
+            await queryRunner.query(
+                "CREATE TABLE IF NOT EXISTS `d_b_workspace_template` (`id` char(36) NOT NULL, `organizationId` char(36) NOT NULL, `projectId` varchar(255) NOT NULL DEFAULT '', `name` varchar(255) NOT NULL, `version` int NOT NULL, `description` varchar(255) NOT NULL DEFAULT '', `snapshotId` char(36) NOT NULL, `creatorId` char(36) NOT NULL DEFAULT '', `image` varchar(255) NOT NULL DEFAULT '', `workspaceClass` varchar(255) NOT NULL DEFAULT '', `ide` varchar(255) NOT NULL DEFAULT '', `envVars` text NOT NULL, `createdAt` timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6), `_lastModified` timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6), `deleted` tinyint(4) NOT NULL DEFAULT '0', PRIMARY KEY (id))",
+            );
+            await queryRunner.query(
+                "CREATE UNIQUE INDEX `ind_organizationId_projectId_name_version` ON `d_b_workspace_template` (organizationId, projectId, name, version)",
+            );
+            await queryRunner.query("CREATE INDEX `ind_snapshotId` ON `d_b_workspace_template` (snapshotId)");
+            await queryRunner.query("CREATE INDEX `ind_lastModified` ON `d_b_workspace_template` (_lastModified)");
+        }
+    }
+
+    public async down(queryRunner: QueryRunner): Promise<void> {
+        if (await tableExists(queryRunner, "d_b_workspace_template")) {
+            await queryRunner.query("DROP TABLE `d_b_workspace_template`");
+        }
+    }
+}
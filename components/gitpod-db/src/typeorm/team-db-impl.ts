@@ -360,7 +360,10 @@ export class TeamDBImpl implements TeamDB {
 
     public async findOrgSettings(orgId: string): Promise<OrganizationSettings | undefined> {
         const repo = await this.getOrgSettingsRepo();
-        return repo.findOne({ where: { orgId, deleted: false }, select: ["orgId", "workspaceSharingDisabled"] });
+        return repo.findOne({
+            where: { orgId, deleted: false },
+            select: ["orgId", "workspaceSharingDisabled", "dotfileRepos"],
+        });
     }
 
     public async setOrgSettings(orgId: string, settings: Partial<OrganizationSettings>): Promise<void> {
@@ -372,7 +375,12 @@ export class TeamDBImpl implements TeamDB {
                 orgId,
             });
         } else {
-            team.workspaceSharingDisabled = settings.workspaceSharingDisabled;
+            if (settings.workspaceSharingDisabled !== undefined) {
+                team.workspaceSharingDisabled = settings.workspaceSharingDisabled;
+            }
+            if (settings.dotfileRepos !== undefined) {
+                team.dotfileRepos = settings.dotfileRepos;
+            }
             repo.save(team);
         }
     }
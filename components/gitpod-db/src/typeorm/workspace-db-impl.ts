@@ -63,6 +63,17 @@ interface OrderBy {
     order: "ASC" | "DESC";
 }
 
+/**
+ * Workspace templates point at snapshots of their original workspace, which are deleted together with the workspace's content.
+ * Workspaces with snapshots which are still used by a template are thus exempt from garbage collection.
+ */
+const NOT_REFERENCED_BY_WORKSPACE_TEMPLATE = `NOT EXISTS (
+    SELECT 1 FROM d_b_snapshot AS snapshot
+        INNER JOIN d_b_workspace_template AS tmpl ON tmpl.snapshotId = snapshot.id
+        WHERE snapshot.originalWorkspaceId = ws.id
+            AND tmpl.deleted = 0
+)`;
+
 @injectable()
 export abstract class AbstractTypeORMWorkspaceDBImpl implements WorkspaceDB {
     protected abstract getManager(): Promise<EntityManager>;
@@ -584,6 +595,7 @@ export abstract class AbstractTypeORMWorkspaceDBImpl implements WorkspaceDB {
                         AND ws.softDeleted IS NULL
                         AND ws.pinned = 0
                         AND ws.creationTime < NOW() - INTERVAL ? DAY
+                        AND ${NOT_REFERENCED_BY_WORKSPACE_TEMPLATE}
                     GROUP BY ws.id, ws.ownerId
                     HAVING MAX(GREATEST(wsi.creationTime, wsi.startedTime, wsi.stoppedTime)) < NOW() - INTERVAL ? DAY OR MAX(wsi.creationTime) IS NULL
                     LIMIT ?;
@@ -630,6 +642,7 @@ export abstract class AbstractTypeORMWorkspaceDBImpl implements WorkspaceDB {
                                 OR  ws.softDeletedTime = ''
                             )
                         AND ws.ownerId <> ?
+                        AND ${NOT_REFERENCED_BY_WORKSPACE_TEMPLATE}
                     LIMIT ?;
             `,
             [minSoftDeletedTimeInDays, BUILTIN_WORKSPACE_PROBE_USER_ID, limit],
@@ -17,6 +17,7 @@ import { TypeORM } from "./typeorm/typeorm";
 import { DBWorkspace } from "./typeorm/entity/db-workspace";
 import { DBPrebuiltWorkspace } from "./typeorm/entity/db-prebuilt-workspace";
 import { DBWorkspaceInstance } from "./typeorm/entity/db-workspace-instance";
+import { DBSnapshot } from "./typeorm/entity/db-snapshot";
 import { secondsBefore } from "@gitpod/gitpod-protocol/lib/util/timeutil";
 
 @suite
@@ -190,6 +191,8 @@ class WorkspaceDBSpec {
         await mnr.getRepository(DBWorkspace).delete({});
         await mnr.getRepository(DBWorkspaceInstance).delete({});
         await mnr.getRepository(DBPrebuiltWorkspace).delete({});
+        await mnr.getRepository(DBSnapshot).delete({});
+        await mnr.query("DELETE FROM d_b_workspace_template");
     }
 
     @test(timeout(10000))
@@ -331,6 +334,53 @@ class WorkspaceDBSpec {
         expect(dbResult.length).to.eq(0);
     }
 
+    @test(timeout(10000))
+    public async testFindWorkspacesForGarbageCollection_referencedByTemplate() {
+        await Promise.all([this.db.store(this.ws), this.db.storeInstance(this.wsi1), this.db.storeInstance(this.wsi2)]);
+        const templateId = await this.createWorkspaceTemplateForSnapshotOf(this.ws.id);
+
+        let dbResult = await this.db.findWorkspacesForGarbageCollection(14, 10);
+        expect(dbResult.length).to.eq(0);
+
+        const mnr = await this.typeorm.getConnection();
+        await mnr.query("UPDATE d_b_workspace_template SET deleted = 1 WHERE id = ?", [templateId]);
+        dbResult = await this.db.findWorkspacesForGarbageCollection(14, 10);
+        expect(dbResult.length).to.eq(1);
+        expect(dbResult[0].id).to.eq(this.ws.id);
+    }
+
+    @test(timeout(10000))
+    public async testFindWorkspacesForContentDeletion_referencedByTemplate() {
+        await this.db.store({ ...this.ws, softDeleted: "user", softDeletedTime: this.timeBefore });
+        const templateId = await this.createWorkspaceTemplateForSnapshotOf(this.ws.id);
+
+        let dbResult = await this.db.findWorkspacesForContentDeletion(14, 10);
+        expect(dbResult.length).to.eq(0);
+
+        const mnr = await this.typeorm.getConnection();
+        await mnr.query("UPDATE d_b_workspace_template SET deleted = 1 WHERE id = ?", [templateId]);
+        dbResult = await this.db.findWorkspacesForContentDeletion(14, 10);
+        expect(dbResult.length).to.eq(1);
+        expect(dbResult[0].id).to.eq(this.ws.id);
+    }
+
+    protected async createWorkspaceTemplateForSnapshotOf(workspaceId: string): Promise<string> {
+        const snapshot = await this.db.storeSnapshot({
+            id: uuidv4(),
+            creationTime: this.timeBefore,
+            originalWorkspaceId: workspaceId,
+            bucketId: "snapshot.tar",
+            state: "available",
+        });
+        const templateId = uuidv4();
+        const mnr = await this.typeorm.getConnection();
+        await mnr.query(
+            "INSERT INTO d_b_workspace_template (id, organizationId, name, version, snapshotId, envVars) VALUES (?, ?, ?, ?, ?, ?)",
+            [templateId, uuidv4(), "node", 1, snapshot.id, "{}"],
+        );
+        return templateId;
+    }
+
     @test(timeout(10000))
     public async testFindAllWorkspaces_contextUrl() {
         await Promise.all([this.db.store(this.ws)]);
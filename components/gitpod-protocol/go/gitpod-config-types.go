@@ -12,10 +12,23 @@ type AdditionalRepositoriesItems struct {
 	// Path to where the repository should be checked out relative to `/workspace`. Defaults to the simple repository name.
 	CheckoutLocation string `yaml:"checkoutLocation,omitempty" json:"checkoutLocation,omitempty"`
 
+	// The branch to check out. Defaults to the branch of the main repository if it exists in this repository, otherwise to the default branch.
+	Ref string `yaml:"ref,omitempty" json:"ref,omitempty"`
+
 	// The url of the git repository to clone. Supports any context URLs.
 	Url string `yaml:"url" json:"url"`
 }
 
+// Backoff The delay between restarts, which doubles with every consecutive failure.
+type Backoff struct {
+
+	// The delay before the first restart, e.g. '500ms'. Default is '1s'.
+	Initial string `yaml:"initial,omitempty" json:"initial,omitempty"`
+
+	// The maximum delay between restarts, e.g. '30s'. Default is '1m'.
+	Max string `yaml:"max,omitempty" json:"max,omitempty"`
+}
+
 // CoreDump Configure the default action of certain signals is to cause a process to terminate and produce a core dump file, a file containing an image of the process's memory at the time of termination. Disabled by default.
 type CoreDump struct {
 	Enabled bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
@@ -71,6 +84,9 @@ type GitpodConfig struct {
 	// List of exposed ports.
 	Ports []*PortsItems `yaml:"ports,omitempty" json:"ports,omitempty"`
 
+	// List of background services to run on start. Services are managed by Gitpod: they are restarted according to their restart policy and their health is checked with probes.
+	Services []*ServicesItems `yaml:"services,omitempty" json:"services,omitempty"`
+
 	// List of tasks to run on start. Each task will open a terminal in the IDE.
 	Tasks []*TasksItems `yaml:"tasks,omitempty" json:"tasks,omitempty"`
 
@@ -189,6 +205,53 @@ type Prebuilds_object struct {
 	PullRequestsFromForks bool `yaml:"pullRequestsFromForks,omitempty" json:"pullRequestsFromForks,omitempty"`
 }
 
+// ServiceProbe
+type ServiceProbe struct {
+
+	// Shell command which exits with 0 if the check succeeds.
+	Exec string `yaml:"exec,omitempty" json:"exec,omitempty"`
+
+	// Number of consecutive failed checks after which the probe is considered failed. Default is 3.
+	FailureThreshold int `yaml:"failureThreshold,omitempty" json:"failureThreshold,omitempty"`
+
+	// URL which responds with a 2xx or 3xx status code if the check succeeds, e.g. 'http://localhost:8080/health'.
+	Http string `yaml:"http,omitempty" json:"http,omitempty"`
+
+	// How often to perform the check, e.g. '5s'. Default is '10s'.
+	Interval string `yaml:"interval,omitempty" json:"interval,omitempty"`
+
+	// Port on localhost which accepts TCP connections if the check succeeds.
+	Tcp int `yaml:"tcp,omitempty" json:"tcp,omitempty"`
+
+	// Timeout of a single check, e.g. '1s'. Default is '5s'.
+	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// ServicesItems
+type ServicesItems struct {
+
+	// The delay between restarts, which doubles with every consecutive failure.
+	Backoff *Backoff `yaml:"backoff,omitempty" json:"backoff,omitempty"`
+
+	// The shell command to run the service. The command is expected to keep running in the foreground.
+	Command string `yaml:"command" json:"command"`
+
+	// Environment variables to set.
+	Env map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+
+	// Probe to check whether the service is alive. The service is restarted according to its restart policy if the probe fails.
+	LivenessProbe *ServiceProbe `yaml:"livenessProbe,omitempty" json:"livenessProbe,omitempty"`
+
+	// Unique name of the service, e.g. `postgres`.
+	Name string `yaml:"name" json:"name"`
+
+	// Probe to check whether the service is ready to accept requests.
+	ReadinessProbe *ServiceProbe `yaml:"readinessProbe,omitempty" json:"readinessProbe,omitempty"`
+
+	// When to restart the service after it stopped or failed its liveness probe. Default is 'on-failure'.
+	RestartPolicy string `yaml:"restartPolicy,omitempty" json:"restartPolicy,omitempty"`
+}
+
 // TasksItems
 type TasksItems struct {
 
@@ -98,9 +98,13 @@ type APIInterface interface {
 	// Projects
 	CreateProject(ctx context.Context, options *CreateProjectOptions) (*Project, error)
 	DeleteProject(ctx context.Context, projectID string) error
+	UpdateProjectPartial(ctx context.Context, partialProject *PartialProject) error
 	GetUserProjects(ctx context.Context) ([]*Project, error)
 	GetTeamProjects(ctx context.Context, teamID string) ([]*Project, error)
 
+	// Usage
+	GetUsageBalance(ctx context.Context, attributionID string) (float64, error)
+
 	InstanceUpdates(ctx context.Context, instanceID string) (<-chan *WorkspaceInstance, error)
 
 	// GetIDToken doesn't actually do anything, it just authorises
@@ -245,10 +249,14 @@ const (
 	FunctionDeleteTeam FunctionName = "deleteTeam"
 
 	// Projects
-	FunctionCreateProject   FunctionName = "createProject"
-	FunctionDeleteProject   FunctionName = "deleteProject"
-	FunctionGetUserProjects FunctionName = "getUserProjects"
-	FunctionGetTeamProjects FunctionName = "getTeamProjects"
+	FunctionCreateProject        FunctionName = "createProject"
+	FunctionDeleteProject        FunctionName = "deleteProject"
+	FunctionUpdateProjectPartial FunctionName = "updateProjectPartial"
+	FunctionGetUserProjects      FunctionName = "getUserProjects"
+	FunctionGetTeamProjects      FunctionName = "getTeamProjects"
+
+	// Usage
+	FunctionGetUsageBalance FunctionName = "getUsageBalance"
 
 	// FunctionOnInstanceUpdate is the name of the onInstanceUpdate callback function
 	FunctionOnInstanceUpdate = "onInstanceUpdate"
@@ -1545,6 +1553,16 @@ func (gp *APIoverJSONRPC) DeleteProject(ctx context.Context, projectID string) (
 	return
 }
 
+func (gp *APIoverJSONRPC) UpdateProjectPartial(ctx context.Context, partialProject *PartialProject) (err error) {
+	if gp == nil {
+		err = errNotConnected
+		return
+	}
+	_params := []interface{}{partialProject}
+	err = gp.C.Call(ctx, string(FunctionUpdateProjectPartial), _params, nil)
+	return
+}
+
 func (gp *APIoverJSONRPC) GetUserProjects(ctx context.Context) (res []*Project, err error) {
 	if gp == nil {
 		err = errNotConnected
@@ -1565,6 +1583,16 @@ func (gp *APIoverJSONRPC) GetTeamProjects(ctx context.Context, teamID string) (r
 	return
 }
 
+func (gp *APIoverJSONRPC) GetUsageBalance(ctx context.Context, attributionID string) (res float64, err error) {
+	if gp == nil {
+		err = errNotConnected
+		return
+	}
+	_params := []interface{}{attributionID}
+	err = gp.C.Call(ctx, string(FunctionGetUsageBalance), _params, &res)
+	return
+}
+
 func (gp *APIoverJSONRPC) GetIDToken(ctx context.Context) (err error) {
 	if gp == nil {
 		err = errNotConnected
@@ -1760,10 +1788,12 @@ type Workspace struct {
 	// The source where to get the workspace base image from. This source is resolved
 	// during workspace creation. Once a base image has been built the information in here
 	// is superseded by baseImageNameResolved.
-	ImageSource interface{} `json:"imageSource,omitempty"`
-	OwnerID     string      `json:"ownerId,omitempty"`
-	Pinned      bool        `json:"pinned,omitempty"`
-	Shareable   bool        `json:"shareable,omitempty"`
+	ImageSource    interface{} `json:"imageSource,omitempty"`
+	OrganizationID string      `json:"organizationId,omitempty"`
+	OwnerID        string      `json:"ownerId,omitempty"`
+	Pinned         bool        `json:"pinned,omitempty"`
+	ProjectID      string      `json:"projectId,omitempty"`
+	Shareable      bool        `json:"shareable,omitempty"`
 
 	// Mark as deleted (user-facing). The actual deletion of the workspace content is executed
 	// with a (configurable) delay
@@ -2323,6 +2353,12 @@ type ProjectSettings struct {
 	WorkspaceClasses             *WorkspaceClassesSettings `json:"workspaceClasses,omitempty"`
 }
 
+// PartialProject updates the fields of the project with the given ID. Only settings can be updated.
+type PartialProject struct {
+	ID       string           `json:"id"`
+	Settings *ProjectSettings `json:"settings,omitempty"`
+}
+
 type WorkspaceClassesSettings struct {
 	Regular  string `json:"regular,omitempty"`
 	Prebuild string `json:"prebuild,omitempty"`
@@ -626,6 +626,21 @@ func (mr *MockAPIInterfaceMockRecorder) GetToken(ctx, query interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*MockAPIInterface)(nil).GetToken), ctx, query)
 }
 
+// GetUsageBalance mocks base method.
+func (m *MockAPIInterface) GetUsageBalance(ctx context.Context, attributionID string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsageBalance", ctx, attributionID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsageBalance indicates an expected call of GetUsageBalance.
+func (mr *MockAPIInterfaceMockRecorder) GetUsageBalance(ctx, attributionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsageBalance", reflect.TypeOf((*MockAPIInterface)(nil).GetUsageBalance), ctx, attributionID)
+}
+
 // GetUserProjects mocks base method.
 func (m *MockAPIInterface) GetUserProjects(ctx context.Context) ([]*Project, error) {
 	m.ctrl.T.Helper()
@@ -1067,6 +1082,20 @@ func (mr *MockAPIInterfaceMockRecorder) UpdateOwnAuthProvider(ctx, params interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOwnAuthProvider", reflect.TypeOf((*MockAPIInterface)(nil).UpdateOwnAuthProvider), ctx, params)
 }
 
+// UpdateProjectPartial mocks base method.
+func (m *MockAPIInterface) UpdateProjectPartial(ctx context.Context, partialProject *PartialProject) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProjectPartial", ctx, partialProject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProjectPartial indicates an expected call of UpdateProjectPartial.
+func (mr *MockAPIInterfaceMockRecorder) UpdateProjectPartial(ctx, partialProject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProjectPartial", reflect.TypeOf((*MockAPIInterface)(nil).UpdateProjectPartial), ctx, partialProject)
+}
+
 // UpdateUserStorageResource mocks base method.
 func (m *MockAPIInterface) UpdateUserStorageResource(ctx context.Context, options *UpdateUserStorageResourceOptions) error {
 	m.ctrl.T.Helper()
@@ -262,6 +262,8 @@ export interface AdditionalUserData extends Partial<WorkspaceTimeoutSetting> {
     knownGitHubOrgs?: string[];
     // Git clone URL pointing to the user's dotfile repo
     dotfileRepo?: string;
+    // where supervisor forwards notifications of the user's workspaces to in addition to the IDE
+    notificationSinks?: NotificationSinksSettings;
     // preferred workspace classes
     workspaceClasses?: WorkspaceClasses;
     // additional user profile data
@@ -285,6 +287,24 @@ export namespace AdditionalUserData {
         return user;
     }
 }
+// NotificationSinksSettings mirrors the notification sinks configuration of supervisor
+export interface NotificationSinksSettings {
+    // either "unattended" (default) or "always"
+    forward?: "unattended" | "always";
+    // the minimum level of forwarded notifications, i.e. "error", "warning" or "info" (default)
+    minLevel?: "error" | "warning" | "info";
+    webhook?: {
+        url: string;
+        headers?: { [key: string]: string };
+    };
+    smtp?: {
+        // address of the SMTP relay, e.g. localhost:25
+        address: string;
+        from: string;
+        to: string[];
+    };
+}
+
 // The format in which we store User Profiles in
 export interface ProfileDetails {
     // when was the last time the user updated their profile information or has been nudged to do so.
@@ -872,6 +892,7 @@ export interface JetBrainsPrebuilds {
 export interface RepositoryCloneInformation {
     url: string;
     checkoutLocation?: string;
+    ref?: string;
 }
 
 export interface CoreDumpConfig {
@@ -880,12 +901,35 @@ export interface CoreDumpConfig {
     hardLimit?: number;
 }
 
+export interface ServiceConfig {
+    name: string;
+    command: string;
+    env?: { [env: string]: string };
+    restartPolicy?: "always" | "on-failure" | "never";
+    backoff?: ServiceBackoffConfig;
+    livenessProbe?: ServiceProbeConfig;
+    readinessProbe?: ServiceProbeConfig;
+}
+export interface ServiceBackoffConfig {
+    initial?: string;
+    max?: string;
+}
+export interface ServiceProbeConfig {
+    tcp?: number;
+    http?: string;
+    exec?: string;
+    interval?: string;
+    timeout?: string;
+    failureThreshold?: number;
+}
+
 export interface WorkspaceConfig {
     mainConfiguration?: string;
     additionalRepositories?: RepositoryCloneInformation[];
     image?: ImageConfig;
     ports?: PortConfig[];
     tasks?: TaskConfig[];
+    services?: ServiceConfig[];
     checkoutLocation?: string;
     workspaceLocation?: string;
     gitConfig?: { [config: string]: string };
@@ -144,6 +144,9 @@ export interface Organization {
 
 export interface OrganizationSettings {
     workspaceSharingDisabled?: boolean;
+    // dotfileRepos are installed into every workspace of the organization before the user's own dotfiles.
+    // Repositories can be pinned to a branch or tag by appending #<ref> to their URL.
+    dotfileRepos?: string[];
 }
 
 export type TeamMemberRole = OrgMemberRole;
@@ -16,6 +16,8 @@ import (
 type ServiceConfiguration struct {
 	Server        *baseserver.Configuration `json:"server,omitempty"`
 	IDEConfigPath string                    `json:"ideConfigPath"`
+	// BlobserveURL is the URL of blobserve which is asked to pre-warm the images of a new IDE config. Empty disables pre-warming.
+	BlobserveURL string `json:"blobserveURL,omitempty"`
 }
 
 func Read(fn string) (*ServiceConfiguration, error) {
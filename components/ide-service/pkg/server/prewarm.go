@@ -0,0 +1,102 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/ide-service-api/config"
+)
+
+const prewarmTimeout = 30 * time.Second
+
+type prewarmRequest struct {
+	Refs []string `json:"refs"`
+}
+
+type prewarmResponse struct {
+	Accepted []string          `json:"accepted"`
+	Rejected map[string]string `json:"rejected,omitempty"`
+}
+
+// prewarmRefs returns all image refs of an IDE config, so that blobserve can serve them before the first workspace uses them
+func prewarmRefs(cfg *config.IDEConfig) []string {
+	refs := make(map[string]struct{})
+	add := func(r ...string) {
+		for _, ref := range r {
+			if ref != "" {
+				refs[ref] = struct{}{}
+			}
+		}
+	}
+
+	add(cfg.SupervisorImage)
+	for _, opt := range cfg.IdeOptions.Options {
+		add(opt.Image, opt.LatestImage, opt.PluginImage, opt.PluginLatestImage)
+		add(opt.ImageLayers...)
+		add(opt.LatestImageLayers...)
+	}
+
+	res := make([]string, 0, len(refs))
+	for ref := range refs {
+		res = append(res, ref)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// prewarmBlobs asks blobserve to download and unpack the images of a new IDE config ahead of time.
+// Pre-warming is best effort, i.e. blobserve still downloads images on first use if this fails.
+func (s *IDEServiceServer) prewarmBlobs(cfg *config.IDEConfig) {
+	refs := prewarmRefs(cfg)
+	if len(refs) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), prewarmTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(prewarmRequest{Refs: refs})
+	if err != nil {
+		log.WithError(err).Error("cannot marshal blobserve pre-warm request")
+		return
+	}
+	url := strings.TrimSuffix(s.config.BlobserveURL, "/") + "/_blobserve/prewarm"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		log.WithError(err).Error("cannot create blobserve pre-warm request")
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.WithError(err).Warn("cannot pre-warm blobserve")
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		log.WithError(fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(msg)))).Warn("cannot pre-warm blobserve")
+		return
+	}
+
+	var res prewarmResponse
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		log.WithError(err).Warn("cannot parse blobserve pre-warm response")
+		return
+	}
+	log.WithField("accepted", res.Accepted).WithField("rejected", res.Rejected).Info("pre-warming blobserve")
+}
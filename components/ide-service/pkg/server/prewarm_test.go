@@ -0,0 +1,64 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/ide-service-api/config"
+)
+
+func TestPrewarmBlobs(t *testing.T) {
+	ideConfig := &config.IDEConfig{
+		SupervisorImage: "gitpod.io/supervisor:commit-1",
+		IdeOptions: config.IDEOptions{
+			Options: map[string]config.IDEOption{
+				"code": {
+					Image:             "gitpod.io/ide/code:commit-1",
+					LatestImage:       "gitpod.io/ide/code:nightly",
+					ImageLayers:       []string{"gitpod.io/ide/code-web-extension:commit-1"},
+					LatestImageLayers: []string{"gitpod.io/ide/code-web-extension:commit-1"},
+				},
+				"intellij": {
+					Image:       "gitpod.io/ide/intellij:commit-1",
+					PluginImage: "gitpod.io/ide/jb-backend-plugin:commit-1",
+				},
+			},
+		},
+	}
+
+	var received prewarmRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/_blobserve/prewarm" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		err := json.NewDecoder(r.Body).Decode(&received)
+		if err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(prewarmResponse{Accepted: received.Refs})
+	}))
+	defer srv.Close()
+
+	s := &IDEServiceServer{config: &config.ServiceConfiguration{BlobserveURL: srv.URL + "/"}}
+	s.prewarmBlobs(ideConfig)
+
+	expectation := []string{
+		"gitpod.io/ide/code-web-extension:commit-1",
+		"gitpod.io/ide/code:commit-1",
+		"gitpod.io/ide/code:nightly",
+		"gitpod.io/ide/intellij:commit-1",
+		"gitpod.io/ide/jb-backend-plugin:commit-1",
+		"gitpod.io/supervisor:commit-1",
+	}
+	if !reflect.DeepEqual(expectation, received.Refs) {
+		t.Errorf("unexpected pre-warm refs: want %v, got %v", expectation, received.Refs)
+	}
+}
@@ -161,6 +161,10 @@ func (s *IDEServiceServer) readIDEConfig(ctx context.Context, isInit bool) {
 			return
 		}
 
+		if s.config.BlobserveURL != "" && string(parsedConfig) != s.parsedIDEConfigContent {
+			go s.prewarmBlobs(ideConfig)
+		}
+
 		s.parsedIDEConfigContent = string(parsedConfig)
 		s.ideConfig = ideConfig
 
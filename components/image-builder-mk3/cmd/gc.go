@@ -0,0 +1,96 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/spf13/cobra"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/image-builder/pkg/auth"
+	"github.com/gitpod-io/gitpod/image-builder/pkg/gc"
+)
+
+var gcOpts struct {
+	Retention time.Duration
+	DryRun    bool
+}
+
+// gcCmd represents the gc command
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Deletes workspace images which are no longer referenced by any workspace instance",
+	Long: `Deletes tags from the workspace image repository which are not referenced by any
+workspace instance created within the retention window, nor by any running instance.
+Connects to the database using DB_HOST, DB_PORT, DB_USERNAME, DB_PASSWORD and DB_CA_CERT.
+Prints a JSON report of the retained and deleted tags to stdout.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := getConfig()
+		repository := cfg.Orchestrator.WorkspaceImageRepository
+
+		var authentication *auth.Authentication
+		if cfg.Orchestrator.PullSecretFile != "" {
+			dockerAuth, err := auth.NewDockerConfigFileAuth(cfg.Orchestrator.PullSecretFile)
+			if err != nil {
+				log.WithError(err).Fatal("cannot load pull secret")
+			}
+			ref, err := reference.ParseNormalizedNamed(repository)
+			if err != nil {
+				log.WithError(err).WithField("repository", repository).Fatal("invalid workspace image repository")
+			}
+			authentication, err = dockerAuth.Authenticate(reference.Domain(ref))
+			if err != nil {
+				log.WithError(err).Fatal("cannot authenticate for workspace image repository")
+			}
+		}
+
+		registry, err := gc.NewRegistryClient(repository, authentication)
+		if err != nil {
+			log.WithError(err).Fatal("cannot create registry client")
+		}
+
+		conn, err := db.Connect(db.ConnectionParamsFromEnv())
+		if err != nil {
+			log.WithError(err).Fatal("cannot connect to database")
+		}
+
+		collector := &gc.Collector{
+			Repository: repository,
+			Registry:   registry,
+			InUse: func(ctx context.Context, since time.Time) ([]string, error) {
+				return db.FindWorkspaceImagesInUse(ctx, conn, since)
+			},
+			Retention: gcOpts.Retention,
+			DryRun:    gcOpts.DryRun,
+		}
+		report, err := collector.Collect(context.Background())
+		if err != nil {
+			log.WithError(err).Fatal("workspace image garbage collection failed")
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+		if err != nil {
+			log.WithError(err).Fatal("cannot print report")
+		}
+		if len(report.Failed) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gcCmd)
+
+	gcCmd.Flags().DurationVar(&gcOpts.Retention, "retention", 14*24*time.Hour, "keep images of workspace instances created within this time window")
+	gcCmd.Flags().BoolVar(&gcOpts.DryRun, "dry-run", true, "only report which images would be deleted")
+}
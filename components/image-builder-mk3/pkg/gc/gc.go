@@ -0,0 +1,159 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package gc
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// workspaceImageTag matches the tags produced by the orchestrator's getWorkspaceImageRef.
+// Tags that do not match were not pushed by image-builder and are never collected.
+var workspaceImageTag = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// ImagesInUseFunc returns the workspace image refs referenced by workspace instances
+// which were created at or after since, or which are still running.
+type ImagesInUseFunc func(ctx context.Context, since time.Time) ([]string, error)
+
+// Collector deletes workspace image tags which are no longer referenced by any workspace instance
+type Collector struct {
+	// Repository is the workspace image repository, i.e. the WorkspaceImageRepository of the orchestrator
+	Repository string
+	Registry   Registry
+	InUse      ImagesInUseFunc
+
+	// Retention is the time window within which a workspace instance must have been created
+	// for its image to be retained.
+	Retention time.Duration
+
+	// DryRun produces the report without deleting anything
+	DryRun bool
+}
+
+// Report describes the outcome of a collection run
+type Report struct {
+	Repository string    `json:"repository"`
+	Since      time.Time `json:"since"`
+	DryRun     bool      `json:"dryRun"`
+
+	// Tags is the number of tags found in the repository
+	Tags int `json:"tags"`
+	// Retained lists the tags which are still referenced
+	Retained []string `json:"retained"`
+	// Deleted lists the tags which were (or in dry-run mode: would have been) deleted
+	Deleted []string `json:"deleted"`
+	// Failed lists the tags that could not be deleted with the reason why
+	Failed map[string]string `json:"failed,omitempty"`
+}
+
+// Collect finds and deletes unreferenced workspace image tags
+func (c *Collector) Collect(ctx context.Context) (*Report, error) {
+	repo, err := reference.ParseNormalizedNamed(c.Repository)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot parse repository %s: %w", c.Repository, err)
+	}
+
+	since := time.Now().Add(-c.Retention)
+	report := &Report{
+		Repository: repo.Name(),
+		Since:      since,
+		DryRun:     c.DryRun,
+		Retained:   []string{},
+		Deleted:    []string{},
+		Failed:     make(map[string]string),
+	}
+
+	images, err := c.InUse(ctx, since)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list workspace images in use: %w", err)
+	}
+	inUse := make(map[string]struct{}, len(images))
+	for _, img := range images {
+		ref, err := reference.ParseNormalizedNamed(img)
+		if err != nil {
+			log.WithError(err).WithField("ref", img).Debug("ignoring invalid workspace image ref")
+			continue
+		}
+		tagged, ok := ref.(reference.Tagged)
+		if !ok || ref.Name() != repo.Name() {
+			continue
+		}
+		inUse[tagged.Tag()] = struct{}{}
+	}
+
+	tags, err := c.Registry.ListTags(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list tags of %s: %w", repo.Name(), err)
+	}
+	report.Tags = len(tags)
+
+	// Deleting a manifest deletes every tag pointing to it. We must not delete a manifest
+	// that is still referenced through another tag, hence we resolve all tags first.
+	var (
+		candidates = make(map[digest.Digest][]string)
+		retained   = make(map[digest.Digest]struct{})
+	)
+	for _, tag := range tags {
+		_, used := inUse[tag]
+		if !used && !workspaceImageTag.MatchString(tag) {
+			continue
+		}
+
+		dgst, err := c.Registry.ResolveTag(ctx, tag)
+		if xerrors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			if used {
+				return nil, xerrors.Errorf("cannot resolve retained tag %s: %w", tag, err)
+			}
+			report.Failed[tag] = err.Error()
+			continue
+		}
+
+		if used {
+			retained[dgst] = struct{}{}
+			report.Retained = append(report.Retained, tag)
+			continue
+		}
+		candidates[dgst] = append(candidates[dgst], tag)
+	}
+
+	for dgst, tags := range candidates {
+		if _, ok := retained[dgst]; ok {
+			report.Retained = append(report.Retained, tags...)
+			continue
+		}
+
+		if !c.DryRun {
+			err := c.Registry.DeleteManifest(ctx, dgst)
+			if xerrors.Is(err, ErrUnsupported) {
+				return nil, xerrors.Errorf("registry does not support deleting manifests - is deletion enabled?")
+			}
+			if err != nil && !xerrors.Is(err, ErrNotFound) {
+				for _, tag := range tags {
+					report.Failed[tag] = err.Error()
+				}
+				continue
+			}
+		}
+
+		log.WithField("digest", dgst).WithField("tags", tags).WithField("dryRun", c.DryRun).Info("collected unused workspace image")
+		report.Deleted = append(report.Deleted, tags...)
+	}
+
+	sort.Strings(report.Retained)
+	sort.Strings(report.Deleted)
+
+	return report, nil
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package gc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/opencontainers/go-digest"
+)
+
+type fakeRegistry struct {
+	Tags    map[string]digest.Digest
+	Deleted []digest.Digest
+}
+
+func (r *fakeRegistry) ListTags(ctx context.Context) ([]string, error) {
+	var res []string
+	for t := range r.Tags {
+		res = append(res, t)
+	}
+	return res, nil
+}
+
+func (r *fakeRegistry) ResolveTag(ctx context.Context, tag string) (digest.Digest, error) {
+	dgst, ok := r.Tags[tag]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return dgst, nil
+}
+
+func (r *fakeRegistry) DeleteManifest(ctx context.Context, dgst digest.Digest) error {
+	r.Deleted = append(r.Deleted, dgst)
+	return nil
+}
+
+func hexTag(i int) string {
+	return fmt.Sprintf("%064x", i)
+}
+
+func TestCollect(t *testing.T) {
+	const repo = "registry.example.com/workspace-images"
+
+	tests := []struct {
+		Name        string
+		Tags        map[string]digest.Digest
+		InUse       []string
+		DryRun      bool
+		Expectation *Report
+		Deleted     []digest.Digest
+	}{
+		{
+			Name: "deletes unreferenced tags",
+			Tags: map[string]digest.Digest{
+				hexTag(1): digest.FromString("1"),
+				hexTag(2): digest.FromString("2"),
+			},
+			InUse: []string{repo + ":" + hexTag(1)},
+			Expectation: &Report{
+				Tags:     2,
+				Retained: []string{hexTag(1)},
+				Deleted:  []string{hexTag(2)},
+			},
+			Deleted: []digest.Digest{digest.FromString("2")},
+		},
+		{
+			Name: "dry run deletes nothing",
+			Tags: map[string]digest.Digest{
+				hexTag(1): digest.FromString("1"),
+				hexTag(2): digest.FromString("2"),
+			},
+			InUse:  []string{repo + ":" + hexTag(1)},
+			DryRun: true,
+			Expectation: &Report{
+				DryRun:   true,
+				Tags:     2,
+				Retained: []string{hexTag(1)},
+				Deleted:  []string{hexTag(2)},
+			},
+		},
+		{
+			Name: "keeps manifests referenced through another tag",
+			Tags: map[string]digest.Digest{
+				hexTag(1): digest.FromString("1"),
+				hexTag(2): digest.FromString("1"),
+			},
+			InUse: []string{repo + ":" + hexTag(1)},
+			Expectation: &Report{
+				Tags:     2,
+				Retained: []string{hexTag(1), hexTag(2)},
+				Deleted:  []string{},
+			},
+		},
+		{
+			Name: "ignores tags not produced by image-builder",
+			Tags: map[string]digest.Digest{
+				"latest": digest.FromString("1"),
+			},
+			Expectation: &Report{
+				Tags:     1,
+				Retained: []string{},
+				Deleted:  []string{},
+			},
+		},
+		{
+			Name: "ignores images of other repositories",
+			Tags: map[string]digest.Digest{
+				hexTag(1): digest.FromString("1"),
+			},
+			InUse: []string{"registry.example.com/other:" + hexTag(1), "not a valid ref"},
+			Expectation: &Report{
+				Tags:     1,
+				Retained: []string{},
+				Deleted:  []string{hexTag(1)},
+			},
+			Deleted: []digest.Digest{digest.FromString("1")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			reg := &fakeRegistry{Tags: test.Tags}
+			c := &Collector{
+				Repository: repo,
+				Registry:   reg,
+				InUse: func(ctx context.Context, since time.Time) ([]string, error) {
+					return test.InUse, nil
+				},
+				Retention: 24 * time.Hour,
+				DryRun:    test.DryRun,
+			}
+
+			act, err := c.Collect(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			test.Expectation.Repository = repo
+			test.Expectation.Since = act.Since
+			test.Expectation.Failed = map[string]string{}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected report (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.Deleted, reg.Deleted); diff != "" {
+				t.Errorf("unexpected deletions (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRegistryClient(t *testing.T) {
+	dgst := digest.FromString("manifest")
+
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/workspace-images/tags/list":
+			if r.URL.Query().Get("last") == "" {
+				w.Header().Set("Link", `</v2/workspace-images/tags/list?n=1&last=a>; rel="next"`)
+				fmt.Fprint(w, `{"name":"workspace-images","tags":["a"]}`)
+				return
+			}
+			fmt.Fprint(w, `{"name":"workspace-images","tags":["b"]}`)
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/workspace-images/manifests/a":
+			if !strings.Contains(r.Header.Get("Accept"), "application/vnd.oci.image.manifest.v1+json") {
+				http.Error(w, "", http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", dgst.String())
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v2/workspace-images/manifests/"):
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/v2/workspace-images/manifests/"))
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &RegistryClient{BaseURL: srv.URL, Name: "workspace-images", Client: srv.Client()}
+	ctx := context.Background()
+
+	tags, err := client.ListTags(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, tags); diff != "" {
+		t.Errorf("unexpected tags (-want +got):\n%s", diff)
+	}
+
+	act, err := client.ResolveTag(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act != dgst {
+		t.Errorf("unexpected digest: %s", act)
+	}
+
+	_, err = client.ResolveTag(ctx, "c")
+	if err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	err = client.DeleteManifest(ctx, dgst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{dgst.String()}, deleted); diff != "" {
+		t.Errorf("unexpected deletions (-want +got):\n%s", diff)
+	}
+}
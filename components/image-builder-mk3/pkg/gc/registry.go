@@ -0,0 +1,207 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package gc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	dockerremote "github.com/containerd/containerd/remotes/docker"
+	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/image-builder/pkg/auth"
+)
+
+var (
+	// ErrNotFound is returned when a tag or manifest does not exist in the repository
+	ErrNotFound = xerrors.Errorf("not found")
+
+	// ErrUnsupported is returned when the registry does not support deleting manifests
+	ErrUnsupported = xerrors.Errorf("unsupported")
+)
+
+// Registry provides access to the tags of a single image repository
+type Registry interface {
+	// ListTags returns all tags of the repository
+	ListTags(ctx context.Context) ([]string, error)
+
+	// ResolveTag returns the manifest digest a tag points to
+	ResolveTag(ctx context.Context, tag string) (digest.Digest, error)
+
+	// DeleteManifest deletes a manifest and all tags pointing to it
+	DeleteManifest(ctx context.Context, dgst digest.Digest) error
+}
+
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	ociv1.MediaTypeImageManifest,
+	ociv1.MediaTypeImageIndex,
+}
+
+// NewRegistryClient produces a client for the repository using the Docker registry HTTP API V2
+func NewRegistryClient(repository string, authentication *auth.Authentication) (*RegistryClient, error) {
+	ref, err := reference.ParseNormalizedNamed(repository)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot parse repository %s: %w", repository, err)
+	}
+
+	host := reference.Domain(ref)
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+
+	return &RegistryClient{
+		BaseURL: fmt.Sprintf("https://%s", host),
+		Name:    reference.Path(ref),
+		Client:  http.DefaultClient,
+		authorizer: dockerremote.NewDockerAuthorizer(dockerremote.WithAuthCreds(func(host string) (username, password string, err error) {
+			if authentication == nil {
+				return
+			}
+			return authentication.Username, authentication.Password, nil
+		})),
+	}, nil
+}
+
+// RegistryClient talks to a single repository using the Docker registry HTTP API V2
+type RegistryClient struct {
+	BaseURL string
+	Name    string
+	Client  *http.Client
+
+	authorizer dockerremote.Authorizer
+}
+
+// ListTags returns all tags of the repository, following the registry's pagination
+func (c *RegistryClient) ListTags(ctx context.Context) (tags []string, err error) {
+	next := fmt.Sprintf("%s/v2/%s/tags/list", c.BaseURL, c.Name)
+	for next != "" {
+		resp, err := c.do(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, xerrors.Errorf("cannot decode tag list: %w", err)
+		}
+		tags = append(tags, page.Tags...)
+
+		next, err = c.nextPage(next, resp.Header.Get("Link"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tags, nil
+}
+
+// nextPage parses an RFC 5988 Link header as returned by paginated tag list requests
+func (c *RegistryClient) nextPage(current, link string) (string, error) {
+	if link == "" {
+		return "", nil
+	}
+
+	start, end := strings.Index(link, "<"), strings.Index(link, ">")
+	if start < 0 || end < start {
+		return "", xerrors.Errorf("invalid link header: %s", link)
+	}
+
+	base, err := url.Parse(current)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(link[start+1 : end])
+	if err != nil {
+		return "", xerrors.Errorf("invalid link header: %w", err)
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+// ResolveTag returns the manifest digest a tag points to
+func (c *RegistryClient) ResolveTag(ctx context.Context, tag string) (digest.Digest, error) {
+	resp, err := c.do(ctx, http.MethodHead, fmt.Sprintf("%s/v2/%s/manifests/%s", c.BaseURL, c.Name, tag), map[string]string{
+		"Accept": strings.Join(manifestMediaTypes, ", "),
+	})
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	dgst, err := digest.Parse(resp.Header.Get("Docker-Content-Digest"))
+	if err != nil {
+		return "", xerrors.Errorf("registry returned invalid digest for %s: %w", tag, err)
+	}
+	return dgst, nil
+}
+
+// DeleteManifest deletes a manifest and all tags pointing to it
+func (c *RegistryClient) DeleteManifest(ctx context.Context, dgst digest.Digest) error {
+	resp, err := c.do(ctx, http.MethodDelete, fmt.Sprintf("%s/v2/%s/manifests/%s", c.BaseURL, c.Name, dgst), nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (c *RegistryClient) do(ctx context.Context, method, url string, header map[string]string) (*http.Response, error) {
+	// The first attempt may be answered with an authentication challenge. The authorizer learns
+	// from that response, so that the second attempt carries the appropriate credentials.
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		if c.authorizer != nil {
+			err = c.authorizer.Authorize(ctx, req)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot authorize request: %w", err)
+			}
+		}
+
+		resp, err := c.Client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized && attempt == 0 && c.authorizer != nil:
+			err = c.authorizer.AddResponses(ctx, []*http.Response{resp})
+			resp.Body.Close()
+			if err != nil {
+				return nil, xerrors.Errorf("cannot authorize request: %w", err)
+			}
+			continue
+		case resp.StatusCode == http.StatusNotFound:
+			resp.Body.Close()
+			return nil, ErrNotFound
+		case resp.StatusCode == http.StatusMethodNotAllowed:
+			resp.Body.Close()
+			return nil, ErrUnsupported
+		case resp.StatusCode >= 300:
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			resp.Body.Close()
+			return nil, xerrors.Errorf("%s %s: %s: %s", method, url, resp.Status, strings.TrimSpace(string(body)))
+		}
+
+		return resp, nil
+	}
+}
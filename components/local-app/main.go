@@ -77,6 +77,14 @@ func main() {
 				},
 				Value: true,
 			},
+			&cli.BoolFlag{
+				Name:  "notifications",
+				Usage: "Show notifications of workspaces as OS notifications if no IDE is attached",
+				EnvVars: []string{
+					"GITPOD_LCA_NOTIFICATIONS",
+				},
+				Value: true,
+			},
 			&cli.StringFlag{
 				Name: "auth-redirect-url",
 				EnvVars: []string{
@@ -121,6 +129,7 @@ func main() {
 						apiPort:           c.Int("api-port"),
 						allowCORSFromPort: c.Bool("allow-cors-from-port"),
 						autoTunnel:        c.Bool("auto-tunnel"),
+						notifications:     c.Bool("notifications"),
 						authRedirectURL:   c.String("auth-redirect-url"),
 						verbose:           c.Bool("verbose"),
 						authTimeout:       c.Duration("auth-timeout"),
@@ -155,6 +164,7 @@ type runOptions struct {
 	apiPort           int
 	allowCORSFromPort bool
 	autoTunnel        bool
+	notifications     bool
 	authRedirectURL   string
 	verbose           bool
 	authTimeout       time.Duration
@@ -208,6 +218,7 @@ func run(opts runOptions) error {
 
 	b = bastion.New(client, opts.localAppTimeout, cb)
 	b.EnableAutoTunnel = opts.autoTunnel
+	b.EnableNotifications = opts.notifications
 	grpcServer := grpc.NewServer()
 	appapi.RegisterLocalAppServer(grpcServer, bastion.NewLocalAppService(b, s))
 	allowOrigin := func(origin string) bool {
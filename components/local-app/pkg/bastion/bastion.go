@@ -93,6 +93,8 @@ type Workspace struct {
 
 	tunnelClient          chan chan *TunnelClient
 	tunnelClientConnected bool
+
+	forwardingNotifications bool
 }
 
 func (ws *Workspace) Status() []*app.TunnelStatus {
@@ -205,6 +207,8 @@ type Bastion struct {
 	subscriptions   map[*StatusSubscription]struct{}
 
 	EnableAutoTunnel bool
+	// EnableNotifications shows notifications of workspaces as OS notifications if no IDE is attached
+	EnableNotifications bool
 }
 
 func (b *Bastion) Run() error {
@@ -372,6 +376,11 @@ func (b *Bastion) handleUpdate(ur *WorkspaceUpdateRequest) {
 			go b.tunnelPorts(ws)
 		}
 
+		if ws.supervisorClient != nil && b.EnableNotifications && !ws.forwardingNotifications {
+			ws.forwardingNotifications = true
+			go b.forwardNotifications(ws)
+		}
+
 		if ws.localSSHListener == nil && ws.supervisorClient != nil {
 			func() {
 				var err error
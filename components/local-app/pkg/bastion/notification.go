@@ -0,0 +1,87 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package bastion
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	supervisor "github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// forwardNotifications subscribes to the notifications of a workspace and shows them as OS notifications.
+// Supervisor only sends notifications to us if they are forwarded, e.g. because no IDE is attached.
+func (b *Bastion) forwardNotifications(ws *Workspace) {
+	for {
+		err := b.doForwardNotifications(ws)
+		if ws.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logrus.WithError(err).WithField("workspace", ws.WorkspaceID).Warn("notification forwarding failed, retrying...")
+		}
+		select {
+		case <-ws.ctx.Done():
+			return
+		case <-time.After(1 * time.Second):
+		}
+	}
+}
+
+func (b *Bastion) doForwardNotifications(ws *Workspace) error {
+	notificationService := supervisor.NewNotificationServiceClient(ws.supervisorClient)
+	sub, err := notificationService.Subscribe(ws.ctx, &supervisor.SubscribeRequest{Sink: true})
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := sub.Recv()
+		if err != nil {
+			return err
+		}
+		if resp.Request == nil {
+			continue
+		}
+		title := "Gitpod: " + ws.WorkspaceID
+		if resp.Request.Level == supervisor.NotifyRequest_ERROR {
+			title = "Gitpod: " + ws.WorkspaceID + " (error)"
+		}
+		go func(message string) {
+			err := showOSNotification(ws.ctx, title, message)
+			if err != nil {
+				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).Warn("cannot show notification")
+			}
+		}(resp.Request.Message)
+	}
+}
+
+// showOSNotification shows a desktop notification using the tools available on the platform.
+// Title and message are passed through the environment to avoid any quoting issues.
+func showOSNotification(ctx context.Context, title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "osascript", "-e", `display notification (system attribute "GITPOD_NOTIFICATION_MESSAGE") with title (system attribute "GITPOD_NOTIFICATION_TITLE")`)
+	case "linux":
+		cmd = exec.CommandContext(ctx, "notify-send", "--app-name=Gitpod", "--", title, message)
+	case "windows":
+		cmd = exec.CommandContext(ctx, "powershell", "-NoProfile", "-Command", `[void][System.Reflection.Assembly]::LoadWithPartialName('System.Windows.Forms'); `+
+			`$n = New-Object System.Windows.Forms.NotifyIcon; $n.Icon = [System.Drawing.SystemIcons]::Information; $n.Visible = $true; `+
+			`$n.ShowBalloonTip(10000, $env:GITPOD_NOTIFICATION_TITLE, $env:GITPOD_NOTIFICATION_MESSAGE, 'None'); Start-Sleep -Seconds 10; $n.Dispose()`)
+	default:
+		return xerrors.Errorf("notifications are not supported on %s", runtime.GOOS)
+	}
+	cmd.Env = append(os.Environ(), "GITPOD_NOTIFICATION_TITLE="+title, "GITPOD_NOTIFICATION_MESSAGE="+message)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return xerrors.Errorf("%w: %s", err, string(out))
+	}
+	return nil
+}
@@ -19,11 +19,14 @@ import (
 func main() {
 	log.Init("openvsx-proxy", "", true, false)
 
-	if len(os.Args) != 2 {
-		log.Panicf("Usage: %s </path/to/config.json>", os.Args[0])
+	var mirrorOnly bool
+	if len(os.Args) == 3 && os.Args[1] == "mirror" {
+		mirrorOnly = true
+	} else if len(os.Args) != 2 {
+		log.Panicf("Usage: %[1]s </path/to/config.json>\n       %[1]s mirror </path/to/config.json>", os.Args[0])
 	}
 
-	cfg, err := pkg.ReadConfig(os.Args[1])
+	cfg, err := pkg.ReadConfig(os.Args[len(os.Args)-1])
 	if err != nil {
 		log.WithError(err).Panic("error reading config 😢")
 	}
@@ -32,6 +35,11 @@ func main() {
 		log.Log.Logger.SetLevel(logrus.DebugLevel)
 	}
 
+	if mirrorOnly {
+		syncMirror(cfg)
+		return
+	}
+
 	log.WithField("config", string(cfg.ToJson())).Info("starting OpenVSX proxy 🚀 ...")
 
 	done := make(chan os.Signal, 1)
@@ -68,3 +76,25 @@ func main() {
 		WithField("shutdown_duration", time.Since(startShutdown).String()).
 		Info("OpenVSX proxy has been stopped 👋")
 }
+
+// syncMirror syncs the extension mirror once, e.g. to populate the mirror storage of an air-gapped installation
+func syncMirror(cfg *pkg.Config) {
+	if !cfg.Mirror.Enabled {
+		log.Panic("extension mirror is not enabled in config 😢")
+	}
+
+	metrics := &pkg.Prometheus{}
+	metrics.Start(&pkg.Config{})
+
+	mirror, err := pkg.NewMirror(cfg, metrics)
+	if err != nil {
+		log.WithError(err).Panic("failed to set up extension mirror 😢")
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	if err := mirror.Sync(ctx); err != nil {
+		log.WithError(err).Fatal("failed to sync extension mirror 😢")
+	}
+	log.WithField("storage_path", cfg.Mirror.StoragePath).Info("extension mirror is in sync 👋")
+}
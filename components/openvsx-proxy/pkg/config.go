@@ -25,6 +25,24 @@ type Config struct {
 	RedisAddr            string        `json:"redis_addr"`
 	PrometheusAddr       string        `json:"prometheusAddr"`
 	AllowCacheDomain     []string      `json:"allow_cache_domain"`
+	Mirror               MirrorConfig  `json:"mirror"`
+	// BlockedExtensions are publishers (e.g. "ms-python") or extensions (e.g. "ms-python.python")
+	// which are neither served nor mirrored
+	BlockedExtensions []string `json:"blocked_extensions,omitempty"`
+}
+
+// MirrorConfig configures which extensions are kept in local storage
+type MirrorConfig struct {
+	Enabled bool `json:"enabled"`
+	// Extensions are the extensions to mirror, either as publisher.name for their latest version,
+	// or as publisher.name@version for a specific version
+	Extensions []string `json:"extensions"`
+	// StoragePath is the directory mirrored extensions are stored in
+	StoragePath string `json:"storage_path"`
+	// RefreshInterval is how often the mirrored extensions are synced with upstream, zero syncs them at startup only
+	RefreshInterval util.Duration `json:"refresh_interval"`
+	// Offline serves mirrored extensions only and never calls upstream, e.g. in air-gapped installations
+	Offline bool `json:"offline"`
 }
 
 // Validate validates the configuration to catch issues during startup and not at runtime
@@ -38,6 +56,26 @@ func (c *Config) Validate() error {
 		validation.Field(&c.CacheDurationBackup, validation.Required),
 		validation.Field(&c.URLUpstream, validation.Required, is.URL),
 		validation.Field(&c.URLLocal, validation.Required, is.URL),
+		validation.Field(&c.Mirror),
+		validation.Field(&c.BlockedExtensions, validation.Each(validation.Required)),
+	)
+}
+
+// Validate validates the mirror configuration
+func (c MirrorConfig) Validate() error {
+	if !c.Enabled {
+		if c.Offline {
+			return xerrors.Errorf("offline mode requires the mirror to be enabled")
+		}
+		return nil
+	}
+
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.StoragePath, validation.Required),
+		validation.Field(&c.Extensions, validation.Each(validation.By(func(value interface{}) error {
+			_, _, _, err := parseMirrorExtension(value.(string))
+			return err
+		}))),
 	)
 }
 
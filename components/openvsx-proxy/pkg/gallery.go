@@ -0,0 +1,629 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package pkg
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type extensionRequestKind int
+
+const (
+	extensionRequestOther extensionRequestKind = iota
+	// extensionRequestAsset is /vscode/asset/{publisher}/{name}/{version}/{assetType}
+	extensionRequestAsset
+	// extensionRequestPackage is /vscode/gallery/publishers/{publisher}/vsextensions/{name}/{version}/vspackage
+	extensionRequestPackage
+	// extensionRequestMetadata is /api/{publisher}/{name}[/{version}]
+	extensionRequestMetadata
+	// extensionRequestFile is /api/{publisher}/{name}/{version}/file/{fileName}
+	extensionRequestFile
+)
+
+// extensionRequest is a request which refers to a single extension
+type extensionRequest struct {
+	Kind      extensionRequestKind
+	Publisher string
+	Name      string
+	Version   string
+	// Asset is the asset type of asset requests and the file name of file requests
+	Asset string
+}
+
+// assetTypeFiles maps the asset types of the VS Code gallery to the keys of the files of the Open VSX API
+var assetTypeFiles = map[string]string{
+	"Microsoft.VisualStudio.Services.VSIXPackage":       mirrorFileDownload,
+	"Microsoft.VisualStudio.Services.Icons.Default":     "icon",
+	"Microsoft.VisualStudio.Code.Manifest":              "manifest",
+	"Microsoft.VisualStudio.Services.Content.Details":   "readme",
+	"Microsoft.VisualStudio.Services.Content.Changelog": "changelog",
+	"Microsoft.VisualStudio.Services.Content.License":   "license",
+	"Microsoft.VisualStudio.Services.VsixSignature":     "signature",
+}
+
+// parseExtensionRequest returns the extension a request refers to, false if it refers to none or many extensions
+func parseExtensionRequest(r *http.Request) (extensionRequest, bool) {
+	segs := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	is := func(prefix ...string) bool {
+		if len(segs) < len(prefix) {
+			return false
+		}
+		for i, p := range prefix {
+			if p != "" && segs[i] != p {
+				return false
+			}
+		}
+		return true
+	}
+
+	var res extensionRequest
+	switch {
+	case is("vscode", "asset", "", "", "", ""):
+		res = extensionRequest{Kind: extensionRequestAsset, Publisher: segs[2], Name: segs[3], Version: segs[4], Asset: strings.Join(segs[5:], "/")}
+	case is("vscode", "gallery", "publishers", "", "vsextensions", "", "", "vspackage") && len(segs) == 8:
+		res = extensionRequest{Kind: extensionRequestPackage, Publisher: segs[3], Name: segs[5], Version: segs[6]}
+	case is("vscode", "unpkg", "", "", ""):
+		res = extensionRequest{Publisher: segs[2], Name: segs[3], Version: segs[4]}
+	case is("vscode", "gallery", "itemName", "") && len(segs) == 4:
+		res.Publisher, res.Name, _ = strings.Cut(segs[3], ".")
+	case is("vscode", "item") && len(segs) == 2:
+		res.Publisher, res.Name, _ = strings.Cut(r.URL.Query().Get("itemName"), ".")
+	case is("api", "", "") && segs[1] != "-":
+		res = extensionRequest{Publisher: segs[1], Name: segs[2]}
+		switch {
+		case len(segs) <= 4:
+			res.Kind = extensionRequestMetadata
+			if len(segs) == 4 {
+				res.Version = segs[3]
+			}
+		case is("api", "", "", "", "file", ""):
+			res.Kind = extensionRequestFile
+			res.Version = segs[3]
+			res.Asset = strings.Join(segs[5:], "/")
+		}
+	}
+	if res.Publisher == "" || res.Name == "" {
+		return extensionRequest{}, false
+	}
+	return res, true
+}
+
+// isBlocked returns true if the extension or its publisher is on the blocklist
+func isBlocked(blocklist []string, publisher, name string) bool {
+	id := extensionID(publisher, name)
+	for _, b := range blocklist {
+		b = strings.ToLower(b)
+		if b == id || b == strings.ToLower(publisher) {
+			return true
+		}
+	}
+	return false
+}
+
+// serveLocal answers requests for blocked and mirrored extensions, and all requests in offline mode,
+// without calling upstream. It returns false if the request should be proxied.
+func (o *OpenVSXProxy) serveLocal(rw http.ResponseWriter, r *http.Request, logFields logrus.Fields) bool {
+	ext, ok := parseExtensionRequest(r)
+	if ok && isBlocked(o.Config.BlockedExtensions, ext.Publisher, ext.Name) {
+		log.WithFields(logFields).WithField("extension", extensionID(ext.Publisher, ext.Name)).Info("extension is blocked")
+		o.metrics.BlockedRequestsCounter.Inc()
+		o.writeLocal(rw, r, http.StatusForbidden, "text/plain", []byte("extension is blocked"))
+		return true
+	}
+
+	if o.mirror == nil {
+		return false
+	}
+	if ok && o.serveMirrored(rw, r, ext) {
+		log.WithFields(logFields).WithField("extension", extensionID(ext.Publisher, ext.Name)).Debug("served mirrored extension")
+		o.metrics.MirrorServeCounter.Inc()
+		return true
+	}
+	if !o.Config.Mirror.Offline {
+		return false
+	}
+
+	switch {
+	case r.Method == http.MethodOptions:
+		rw.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		rw.Header().Set("Access-Control-Allow-Headers", r.Header.Get("Access-Control-Request-Headers"))
+		o.writeLocal(rw, r, http.StatusNoContent, "", nil)
+	case r.Method == http.MethodPost && strings.TrimSuffix(r.URL.Path, "/") == "/vscode/gallery/extensionquery":
+		o.serveExtensionQuery(rw, r, logFields)
+	default:
+		log.WithFields(logFields).Debug("not mirrored - offline mode")
+		o.writeLocal(rw, r, http.StatusNotFound, "text/plain", []byte("not available in offline mode"))
+	}
+	return true
+}
+
+func (o *OpenVSXProxy) writeLocal(rw http.ResponseWriter, r *http.Request, status int, contentType string, body []byte) {
+	if contentType != "" {
+		rw.Header().Set("Content-Type", contentType)
+	}
+	rw.Header().Set("Access-Control-Allow-Origin", "*")
+	rw.WriteHeader(status)
+	rw.Write(body)
+	o.metrics.IncStatusCounter(r, strconv.Itoa(status))
+}
+
+// serveMirrored serves a mirrored file or the metadata of a mirrored extension, false if they are not mirrored
+func (o *OpenVSXProxy) serveMirrored(rw http.ResponseWriter, r *http.Request, ext extensionRequest) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	v, ok := o.mirror.Lookup(ext.Publisher, ext.Name, ext.Version)
+	if !ok {
+		return false
+	}
+
+	var key string
+	switch ext.Kind {
+	case extensionRequestAsset:
+		key = assetTypeFiles[ext.Asset]
+	case extensionRequestPackage:
+		key = mirrorFileDownload
+	case extensionRequestFile:
+		for k, fn := range v.Files {
+			if fn == ext.Asset {
+				key = k
+			}
+		}
+	case extensionRequestMetadata:
+		body, err := o.mirroredMetadata(v)
+		if err != nil {
+			log.WithError(err).WithField("extension", v.Metadata.ID()).Error("cannot render metadata of mirrored extension")
+			return false
+		}
+		o.writeLocal(rw, r, http.StatusOK, "application/json", body)
+		return true
+	}
+
+	fn, ok := v.FilePath(key)
+	if !ok {
+		return false
+	}
+	f, err := os.Open(fn)
+	if err != nil {
+		log.WithError(err).WithField("file", fn).Error("cannot open mirrored file")
+		return false
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		log.WithError(err).WithField("file", fn).Error("cannot open mirrored file")
+		return false
+	}
+
+	if key == mirrorFileDownload {
+		rw.Header().Set("Content-Type", "application/octet-stream")
+	}
+	rw.Header().Set("Access-Control-Allow-Origin", "*")
+	http.ServeContent(rw, r, path.Base(fn), stat.ModTime(), f)
+	o.metrics.IncStatusCounter(r, strconv.Itoa(http.StatusOK))
+	return true
+}
+
+// mirroredMetadata returns the upstream metadata of a mirrored version with its files pointing to the proxy
+func (o *OpenVSXProxy) mirroredMetadata(v *mirroredVersion) ([]byte, error) {
+	var metadata map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(v.Raw))
+	dec.UseNumber()
+	err := dec.Decode(&metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(v.Files))
+	for key, fn := range v.Files {
+		files[key] = o.localURL("api", v.Metadata.Namespace, v.Metadata.Name, v.Metadata.Version, "file", fn)
+	}
+	metadata["files"] = files
+	return json.Marshal(metadata)
+}
+
+func (o *OpenVSXProxy) localURL(segs ...string) string {
+	for i, s := range segs {
+		segs[i] = url.PathEscape(s)
+	}
+	return strings.TrimSuffix(o.Config.URLLocal, "/") + "/" + strings.Join(segs, "/")
+}
+
+// Filter types of the VS Code gallery extension query
+const (
+	galleryFilterTag         = 1
+	galleryFilterExtensionID = 4
+	galleryFilterCategory    = 5
+	galleryFilterName        = 7
+	galleryFilterSearchText  = 10
+
+	galleryDefaultPageSize = 50
+)
+
+type galleryQuery struct {
+	Filters []struct {
+		Criteria []struct {
+			FilterType int    `json:"filterType"`
+			Value      string `json:"value"`
+		} `json:"criteria"`
+		PageNumber int `json:"pageNumber"`
+		PageSize   int `json:"pageSize"`
+	} `json:"filters"`
+}
+
+type galleryResult struct {
+	Extensions     []galleryExtension      `json:"extensions"`
+	PagingToken    *string                 `json:"pagingToken"`
+	ResultMetadata []galleryResultMetadata `json:"resultMetadata"`
+}
+
+type galleryResultMetadata struct {
+	MetadataType  string `json:"metadataType"`
+	MetadataItems []struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	} `json:"metadataItems"`
+}
+
+type galleryExtension struct {
+	ExtensionID      string           `json:"extensionId"`
+	ExtensionName    string           `json:"extensionName"`
+	DisplayName      string           `json:"displayName"`
+	ShortDescription string           `json:"shortDescription"`
+	Publisher        galleryPublisher `json:"publisher"`
+	Versions         []galleryVersion `json:"versions"`
+	Categories       []string         `json:"categories"`
+	Tags             []string         `json:"tags"`
+	Flags            string           `json:"flags"`
+}
+
+type galleryPublisher struct {
+	PublisherID   string `json:"publisherId"`
+	PublisherName string `json:"publisherName"`
+	DisplayName   string `json:"displayName"`
+}
+
+type galleryVersion struct {
+	Version          string            `json:"version"`
+	LastUpdated      string            `json:"lastUpdated"`
+	AssetURI         string            `json:"assetUri"`
+	FallbackAssetURI string            `json:"fallbackAssetUri"`
+	Files            []galleryFile     `json:"files"`
+	Properties       []galleryProperty `json:"properties"`
+	TargetPlatform   string            `json:"targetPlatform,omitempty"`
+}
+
+type galleryFile struct {
+	AssetType string `json:"assetType"`
+	Source    string `json:"source"`
+}
+
+type galleryProperty struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// serveExtensionQuery answers a query of the VS Code gallery with the mirrored extensions
+func (o *OpenVSXProxy) serveExtensionQuery(rw http.ResponseWriter, r *http.Request, logFields logrus.Fields) {
+	var query galleryQuery
+	err := json.NewDecoder(r.Body).Decode(&query)
+	if err != nil {
+		log.WithFields(logFields).WithError(err).Debug("cannot parse extension query")
+		o.writeLocal(rw, r, http.StatusBadRequest, "text/plain", []byte("invalid extension query"))
+		return
+	}
+
+	var extensions []*mirroredVersion
+	for _, v := range o.mirror.Latest() {
+		if !isBlocked(o.Config.BlockedExtensions, v.Metadata.Namespace, v.Metadata.Name) {
+			extensions = append(extensions, v)
+		}
+	}
+
+	results := make([]galleryResult, 0, len(query.Filters))
+	for _, filter := range query.Filters {
+		criteria := make(map[int][]string)
+		for _, c := range filter.Criteria {
+			switch c.FilterType {
+			case galleryFilterTag, galleryFilterExtensionID, galleryFilterCategory, galleryFilterName, galleryFilterSearchText:
+				criteria[c.FilterType] = append(criteria[c.FilterType], strings.ToLower(c.Value))
+			}
+		}
+
+		var matches []galleryExtension
+		for _, v := range extensions {
+			if matchesGalleryCriteria(v, criteria) {
+				matches = append(matches, o.galleryExtension(v))
+			}
+		}
+
+		pageSize := filter.PageSize
+		if pageSize <= 0 {
+			pageSize = galleryDefaultPageSize
+		}
+		pageNumber := filter.PageNumber
+		if pageNumber <= 0 {
+			pageNumber = 1
+		}
+		page := []galleryExtension{}
+		if start := (pageNumber - 1) * pageSize; start < len(matches) {
+			end := start + pageSize
+			if end > len(matches) {
+				end = len(matches)
+			}
+			page = matches[start:end]
+		}
+
+		count := galleryResultMetadata{MetadataType: "ResultCount"}
+		count.MetadataItems = append(count.MetadataItems, struct {
+			Name  string `json:"name"`
+			Count int    `json:"count"`
+		}{Name: "TotalCount", Count: len(matches)})
+		results = append(results, galleryResult{
+			Extensions:     page,
+			ResultMetadata: []galleryResultMetadata{count},
+		})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"results": results})
+	if err != nil {
+		log.WithFields(logFields).WithError(err).Error("cannot render extension query result")
+		o.writeLocal(rw, r, http.StatusInternalServerError, "text/plain", nil)
+		return
+	}
+	o.writeLocal(rw, r, http.StatusOK, "application/json", body)
+}
+
+// matchesGalleryCriteria returns true if the extension matches any value of every filter type of the criteria
+func matchesGalleryCriteria(v *mirroredVersion, criteria map[int][]string) bool {
+	id := v.Metadata.ID()
+	for filterType, values := range criteria {
+		var match bool
+		for _, value := range values {
+			switch filterType {
+			case galleryFilterName:
+				match = value == id
+			case galleryFilterExtensionID:
+				match = value == galleryExtensionID(v)
+			case galleryFilterTag:
+				match = containsFold(v.Metadata.Tags, value)
+			case galleryFilterCategory:
+				match = containsFold(v.Metadata.Categories, value)
+			case galleryFilterSearchText:
+				match = strings.Contains(id, value) ||
+					strings.Contains(strings.ToLower(v.Metadata.DisplayName), value) ||
+					strings.Contains(strings.ToLower(v.Metadata.Description), value)
+			}
+			if match {
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// galleryExtensionID returns a stable ID of a mirrored extension, since Open VSX does not expose its own IDs
+func galleryExtensionID(v *mirroredVersion) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("open-vsx:extension:"+v.Metadata.ID())).String()
+}
+
+func (o *OpenVSXProxy) galleryExtension(v *mirroredVersion) galleryExtension {
+	m := v.Metadata
+	assetURI := o.localURL("vscode", "asset", m.Namespace, m.Name, m.Version)
+
+	var files []galleryFile
+	for assetType, key := range assetTypeFiles {
+		if _, ok := v.Files[key]; ok {
+			files = append(files, galleryFile{AssetType: assetType, Source: assetURI + "/" + assetType})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].AssetType < files[j].AssetType })
+
+	properties := []galleryProperty{{Key: "Microsoft.VisualStudio.Code.Engine", Value: m.Engines["vscode"]}}
+	if m.PreRelease {
+		properties = append(properties, galleryProperty{Key: "Microsoft.VisualStudio.Code.PreRelease", Value: "true"})
+	}
+
+	publisherName := m.NamespaceDisplayName
+	if publisherName == "" {
+		publisherName = m.Namespace
+	}
+	displayName := m.DisplayName
+	if displayName == "" {
+		displayName = m.Name
+	}
+
+	return galleryExtension{
+		ExtensionID:      galleryExtensionID(v),
+		ExtensionName:    m.Name,
+		DisplayName:      displayName,
+		ShortDescription: m.Description,
+		Publisher: galleryPublisher{
+			PublisherID:   uuid.NewSHA1(uuid.NameSpaceURL, []byte("open-vsx:publisher:"+strings.ToLower(m.Namespace))).String(),
+			PublisherName: m.Namespace,
+			DisplayName:   publisherName,
+		},
+		Versions: []galleryVersion{{
+			Version:          m.Version,
+			LastUpdated:      m.Timestamp,
+			AssetURI:         assetURI,
+			FallbackAssetURI: assetURI,
+			Files:            files,
+			Properties:       properties,
+			TargetPlatform:   m.TargetPlatform,
+		}},
+		Categories: m.Categories,
+		Tags:       m.Tags,
+		Flags:      "validated",
+	}
+}
+
+// filterBlocked removes blocked extensions from the results of extension queries and searches.
+// It returns the body unchanged if there is nothing to remove.
+func (o *OpenVSXProxy) filterBlocked(r *http.Request, header http.Header, body []byte) []byte {
+	filter := o.blockedFilter(r)
+	if filter == nil {
+		return body
+	}
+
+	gzipped := strings.EqualFold(header.Get("Content-Encoding"), "gzip")
+	plain := body
+	if gzipped {
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return body
+		}
+		plain, err = io.ReadAll(zr)
+		if err != nil {
+			return body
+		}
+	}
+
+	var res map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(plain))
+	dec.UseNumber()
+	if err := dec.Decode(&res); err != nil {
+		return body
+	}
+	if !filter(res) {
+		return body
+	}
+	filtered, err := json.Marshal(res)
+	if err != nil {
+		return body
+	}
+
+	if gzipped {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(filtered); err != nil {
+			return body
+		}
+		if err := zw.Close(); err != nil {
+			return body
+		}
+		filtered = buf.Bytes()
+	}
+	if header.Get("Content-Length") != "" {
+		header.Set("Content-Length", strconv.Itoa(len(filtered)))
+	}
+	return filtered
+}
+
+// blockedFilter returns the function which removes blocked extensions from the response to a request,
+// nil if the response needs no filtering
+func (o *OpenVSXProxy) blockedFilter(r *http.Request) func(map[string]interface{}) bool {
+	if len(o.Config.BlockedExtensions) == 0 {
+		return nil
+	}
+	switch strings.TrimSuffix(r.URL.Path, "/") {
+	case "/vscode/gallery/extensionquery":
+		return o.filterBlockedGallery
+	case "/api/-/query", "/api/-/search":
+		return o.filterBlockedAPI
+	}
+	return nil
+}
+
+func (o *OpenVSXProxy) filterBlockedGallery(res map[string]interface{}) bool {
+	results, _ := res["results"].([]interface{})
+	var changed bool
+	for _, result := range results {
+		result, ok := result.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		extensions, _ := result["extensions"].([]interface{})
+		kept := make([]interface{}, 0, len(extensions))
+		for _, e := range extensions {
+			ext, _ := e.(map[string]interface{})
+			publisher, _ := ext["publisher"].(map[string]interface{})
+			publisherName, _ := publisher["publisherName"].(string)
+			name, _ := ext["extensionName"].(string)
+			if isBlocked(o.Config.BlockedExtensions, publisherName, name) {
+				continue
+			}
+			kept = append(kept, e)
+		}
+		removed := len(extensions) - len(kept)
+		if removed == 0 {
+			continue
+		}
+		changed = true
+		result["extensions"] = kept
+
+		metadata, _ := result["resultMetadata"].([]interface{})
+		for _, m := range metadata {
+			m, _ := m.(map[string]interface{})
+			if m["metadataType"] != "ResultCount" {
+				continue
+			}
+			items, _ := m["metadataItems"].([]interface{})
+			for _, item := range items {
+				item, _ := item.(map[string]interface{})
+				if item["name"] != "TotalCount" {
+					continue
+				}
+				if count, ok := item["count"].(json.Number); ok {
+					if n, err := count.Int64(); err == nil && n >= int64(removed) {
+						item["count"] = n - int64(removed)
+					}
+				}
+			}
+		}
+	}
+	return changed
+}
+
+func (o *OpenVSXProxy) filterBlockedAPI(res map[string]interface{}) bool {
+	extensions, _ := res["extensions"].([]interface{})
+	kept := make([]interface{}, 0, len(extensions))
+	for _, e := range extensions {
+		ext, _ := e.(map[string]interface{})
+		namespace, _ := ext["namespace"].(string)
+		name, _ := ext["name"].(string)
+		if isBlocked(o.Config.BlockedExtensions, namespace, name) {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	removed := len(extensions) - len(kept)
+	if removed == 0 {
+		return false
+	}
+	res["extensions"] = kept
+	if size, ok := res["totalSize"].(json.Number); ok {
+		if n, err := size.Int64(); err == nil && n >= int64(removed) {
+			res["totalSize"] = n - int64(removed)
+		}
+	}
+	return true
+}
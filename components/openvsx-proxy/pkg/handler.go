@@ -46,6 +46,13 @@ func (o *OpenVSXProxy) Handler(p *httputil.ReverseProxy) func(http.ResponseWrite
 		log.WithFields(logFields).Debug("handling request")
 		r = r.WithContext(context.WithValue(r.Context(), REQUEST_ID_CTX, reqid))
 
+		if o.serveLocal(rw, r, logFields) {
+			duration := time.Since(start)
+			o.metrics.DurationOverallHistogram.Observe(duration.Seconds())
+			log.WithFields(logFields).WithFields(o.DurationLogFields(duration)).Info("request finished locally")
+			return
+		}
+
 		upstream := o.GetUpstreamUrl(r)
 		r = r.WithContext(context.WithValue(r.Context(), UPSTREAM_CTX, upstream))
 
@@ -98,9 +105,10 @@ func (o *OpenVSXProxy) Handler(p *httputil.ReverseProxy) func(http.ResponseWrite
 						if v := rw.Header().Get("Access-Control-Allow-Origin"); v != "" && v != "*" {
 							rw.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
 						}
+						body := o.filterBlocked(r, rw.Header(), cached.Body)
 						rw.Header().Set("X-Cache", "HIT")
 						rw.WriteHeader(cached.StatusCode)
-						rw.Write(cached.Body)
+						rw.Write(body)
 						o.finishLog(logFields, start, hitCacheRegular, hitCacheBackup)
 						o.metrics.DurationRequestProcessingHistogram.Observe(time.Since(start).Seconds())
 						return
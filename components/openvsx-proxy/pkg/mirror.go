@@ -0,0 +1,584 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package pkg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"golang.org/x/xerrors"
+)
+
+const (
+	mirrorMetadataFile = "metadata.json"
+	mirrorFilesDir     = "files"
+	mirrorLatestFile   = "latest"
+
+	// mirrorFileDownload is the key of the VSIX file in the files of the extension metadata
+	mirrorFileDownload = "download"
+	// mirrorFileSHA256 is the key of the file with the checksum of the VSIX file
+	mirrorFileSHA256 = "sha256"
+)
+
+// extensionMetadata is the part of the metadata of an extension version in the Open VSX API the proxy relies on
+type extensionMetadata struct {
+	Namespace            string            `json:"namespace"`
+	NamespaceDisplayName string            `json:"namespaceDisplayName"`
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	DisplayName          string            `json:"displayName"`
+	Description          string            `json:"description"`
+	Timestamp            string            `json:"timestamp"`
+	TargetPlatform       string            `json:"targetPlatform"`
+	PreRelease           bool              `json:"preRelease"`
+	Engines              map[string]string `json:"engines"`
+	Categories           []string          `json:"categories"`
+	Tags                 []string          `json:"tags"`
+	Files                map[string]string `json:"files"`
+	Error                string            `json:"error"`
+}
+
+// ID returns the extension ID, i.e. publisher.name
+func (m *extensionMetadata) ID() string {
+	return extensionID(m.Namespace, m.Name)
+}
+
+func extensionID(publisher, name string) string {
+	return strings.ToLower(publisher + "." + name)
+}
+
+// mirroredVersion is a version of an extension in local storage
+type mirroredVersion struct {
+	Dir      string
+	Metadata extensionMetadata
+	// Raw is the metadata as returned by upstream
+	Raw json.RawMessage
+	// Files maps the keys of the metadata files to their file names
+	Files map[string]string
+}
+
+// FilePath returns the path of a file of the version, false if it is not mirrored
+func (v *mirroredVersion) FilePath(key string) (string, bool) {
+	fn, ok := v.Files[key]
+	if !ok {
+		return "", false
+	}
+	return filepath.Join(v.Dir, mirrorFilesDir, key, fn), true
+}
+
+// mirroredExtension is an extension with all of its versions in local storage
+type mirroredExtension struct {
+	Latest   string
+	Versions map[string]*mirroredVersion
+}
+
+// Mirror keeps an allowlist of extensions, including their VSIX files, in local storage
+type Mirror struct {
+	Config   *Config
+	Upstream *url.URL
+	Client   *http.Client
+	metrics  *Prometheus
+
+	mu         sync.RWMutex
+	extensions map[string]*mirroredExtension
+}
+
+// NewMirror produces a new mirror and loads the extensions which are in local storage already
+func NewMirror(cfg *Config, metrics *Prometheus) (*Mirror, error) {
+	upstream, err := url.Parse(cfg.URLUpstream)
+	if err != nil {
+		return nil, xerrors.Errorf("error parsing upstream URL: %v", err)
+	}
+	err = os.MkdirAll(cfg.Mirror.StoragePath, 0755)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create mirror storage: %w", err)
+	}
+
+	m := &Mirror{
+		Config:     cfg,
+		Upstream:   upstream,
+		Client:     &http.Client{Timeout: 10 * time.Minute},
+		metrics:    metrics,
+		extensions: make(map[string]*mirroredExtension),
+	}
+	err = m.load()
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// load reads the index of the mirrored extensions from local storage
+func (m *Mirror) load() error {
+	publishers, err := os.ReadDir(m.Config.Mirror.StoragePath)
+	if err != nil {
+		return xerrors.Errorf("cannot read mirror storage: %w", err)
+	}
+
+	for _, publisher := range publishers {
+		if !publisher.IsDir() || strings.HasPrefix(publisher.Name(), ".") {
+			continue
+		}
+		names, err := os.ReadDir(filepath.Join(m.Config.Mirror.StoragePath, publisher.Name()))
+		if err != nil {
+			return xerrors.Errorf("cannot read mirror storage: %w", err)
+		}
+		for _, name := range names {
+			if !name.IsDir() {
+				continue
+			}
+			ext, err := loadMirroredExtension(filepath.Join(m.Config.Mirror.StoragePath, publisher.Name(), name.Name()))
+			if err != nil {
+				log.WithError(err).WithField("extension", extensionID(publisher.Name(), name.Name())).Warn("cannot load mirrored extension - ignoring it")
+				continue
+			}
+			if len(ext.Versions) == 0 {
+				continue
+			}
+			m.extensions[extensionID(publisher.Name(), name.Name())] = ext
+		}
+	}
+
+	m.updateMetrics()
+	log.WithField("extensions", len(m.extensions)).Info("loaded mirrored extensions")
+	return nil
+}
+
+func loadMirroredExtension(dir string) (*mirroredExtension, error) {
+	res := &mirroredExtension{Versions: make(map[string]*mirroredVersion)}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		v, err := loadMirroredVersion(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		res.Versions[v.Metadata.Version] = v
+	}
+
+	latest, err := os.ReadFile(filepath.Join(dir, mirrorLatestFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	res.Latest = strings.TrimSpace(string(latest))
+	if _, ok := res.Versions[res.Latest]; !ok {
+		res.Latest = ""
+	}
+	return res, nil
+}
+
+func loadMirroredVersion(dir string) (*mirroredVersion, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, mirrorMetadataFile))
+	if err != nil {
+		return nil, err
+	}
+	res := &mirroredVersion{
+		Dir:   dir,
+		Raw:   raw,
+		Files: make(map[string]string),
+	}
+	err = json.Unmarshal(raw, &res.Metadata)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot parse metadata in %s: %w", dir, err)
+	}
+
+	keys, err := os.ReadDir(filepath.Join(dir, mirrorFilesDir))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, key := range keys {
+		files, err := os.ReadDir(filepath.Join(dir, mirrorFilesDir, key.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 1 {
+			res.Files[key.Name()] = files[0].Name()
+		}
+	}
+	if _, ok := res.Files[mirrorFileDownload]; !ok {
+		return nil, xerrors.Errorf("version in %s has no VSIX file", dir)
+	}
+	return res, nil
+}
+
+// Lookup returns a mirrored version of an extension. An empty version or "latest" return the latest version.
+func (m *Mirror) Lookup(publisher, name, version string) (*mirroredVersion, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ext, ok := m.extensions[extensionID(publisher, name)]
+	if !ok {
+		return nil, false
+	}
+	if version == "" || version == "latest" {
+		version = ext.Latest
+	}
+	v, ok := ext.Versions[version]
+	return v, ok
+}
+
+// Latest returns the latest mirrored version of all extensions, ordered by their ID
+func (m *Mirror) Latest() []*mirroredVersion {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make([]string, 0, len(m.extensions))
+	for id := range m.extensions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	res := make([]*mirroredVersion, 0, len(ids))
+	for _, id := range ids {
+		ext := m.extensions[id]
+		if v, ok := ext.Versions[ext.Latest]; ok {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// Start syncs the mirror with upstream now and then in the refresh interval until the context is canceled
+func (m *Mirror) Start(ctx context.Context) {
+	for {
+		err := m.Sync(ctx)
+		if err != nil {
+			log.WithError(err).Error("cannot sync all mirrored extensions")
+		}
+
+		if m.Config.Mirror.RefreshInterval <= 0 {
+			return
+		}
+		select {
+		case <-time.After(time.Duration(m.Config.Mirror.RefreshInterval)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Sync fetches all extensions of the allowlist from upstream and removes the ones which are no longer on it
+func (m *Mirror) Sync(ctx context.Context) error {
+	start := time.Now()
+
+	// the versions which are pinned or latest must be kept, all others are removed after syncing
+	keep := make(map[string]map[string]struct{})
+	var failed []string
+	for _, spec := range m.Config.Mirror.Extensions {
+		publisher, name, version, err := parseMirrorExtension(spec)
+		if err != nil {
+			return err
+		}
+		id := extensionID(publisher, name)
+		if isBlocked(m.Config.BlockedExtensions, publisher, name) {
+			log.WithField("extension", id).Warn("extension is blocked - not mirroring it")
+			continue
+		}
+		if keep[id] == nil {
+			keep[id] = make(map[string]struct{})
+		}
+
+		synced, err := m.syncExtension(ctx, publisher, name, version)
+		if err != nil {
+			log.WithError(err).WithField("extension", spec).Warn("cannot mirror extension")
+			m.metrics.MirrorSyncErrorsCounter.Inc()
+			failed = append(failed, spec)
+
+			// keep what we have, it is better than nothing
+			if v, ok := m.Lookup(publisher, name, version); ok {
+				keep[id][v.Metadata.Version] = struct{}{}
+			}
+			continue
+		}
+		keep[id][synced] = struct{}{}
+	}
+
+	m.prune(keep)
+	m.updateMetrics()
+
+	log.WithField("duration", time.Since(start).String()).WithField("failed", len(failed)).Info("synced mirrored extensions")
+	if len(failed) > 0 {
+		return xerrors.Errorf("cannot mirror %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// syncExtension mirrors a version of an extension, or its latest version if version is empty, and returns the mirrored version
+func (m *Mirror) syncExtension(ctx context.Context, publisher, name, version string) (string, error) {
+	metadataURL := m.apiURL(publisher, name, version)
+	raw, err := m.fetch(ctx, metadataURL)
+	if err != nil {
+		return "", err
+	}
+	var metadata extensionMetadata
+	err = json.Unmarshal(raw, &metadata)
+	if err != nil {
+		return "", xerrors.Errorf("cannot parse metadata of %s: %w", metadataURL, err)
+	}
+	if metadata.Error != "" {
+		return "", xerrors.Errorf("upstream error: %s", metadata.Error)
+	}
+	if metadata.Version == "" || metadata.Files[mirrorFileDownload] == "" {
+		return "", xerrors.Errorf("metadata of %s has no version or VSIX file", metadataURL)
+	}
+	if metadata.ID() != extensionID(publisher, name) {
+		return "", xerrors.Errorf("upstream returned %s instead of %s", metadata.ID(), extensionID(publisher, name))
+	}
+	if !isPathElement(metadata.Version) {
+		return "", xerrors.Errorf("metadata of %s has invalid version %q", metadataURL, metadata.Version)
+	}
+
+	extDir := filepath.Join(m.Config.Mirror.StoragePath, strings.ToLower(publisher), strings.ToLower(name))
+	if _, ok := m.Lookup(publisher, name, metadata.Version); !ok {
+		v, err := m.download(ctx, extDir, raw, &metadata)
+		if err != nil {
+			return "", err
+		}
+
+		m.mu.Lock()
+		ext, ok := m.extensions[metadata.ID()]
+		if !ok {
+			ext = &mirroredExtension{Versions: make(map[string]*mirroredVersion)}
+			m.extensions[metadata.ID()] = ext
+		}
+		ext.Versions[metadata.Version] = v
+		m.mu.Unlock()
+		log.WithField("extension", metadata.ID()).WithField("version", metadata.Version).Info("mirrored extension")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ext := m.extensions[metadata.ID()]
+	if version == "" || ext.Latest == "" {
+		ext.Latest = metadata.Version
+		err = os.WriteFile(filepath.Join(extDir, mirrorLatestFile), []byte(metadata.Version), 0644)
+		if err != nil {
+			return "", xerrors.Errorf("cannot store latest version: %w", err)
+		}
+	}
+	return metadata.Version, nil
+}
+
+// download stores the metadata and all files of an extension version in local storage
+func (m *Mirror) download(ctx context.Context, extDir string, raw []byte, metadata *extensionMetadata) (*mirroredVersion, error) {
+	err := os.MkdirAll(extDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.MkdirTemp(extDir, ".sync-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	err = os.WriteFile(filepath.Join(tmp, mirrorMetadataFile), raw, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(metadata.Files))
+	for key, fileURL := range metadata.Files {
+		if !isPathElement(key) {
+			continue
+		}
+		u, err := url.Parse(fileURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		fn, err := url.PathUnescape(path.Base(u.Path))
+		if err != nil || !isPathElement(fn) {
+			continue
+		}
+
+		dst := filepath.Join(tmp, mirrorFilesDir, key, fn)
+		err = m.downloadFile(ctx, fileURL, dst)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot download %s of %s: %w", key, metadata.ID(), err)
+		}
+		files[key] = fn
+	}
+	if _, ok := files[mirrorFileDownload]; !ok {
+		return nil, xerrors.Errorf("cannot download VSIX of %s", metadata.ID())
+	}
+
+	if fn, ok := files[mirrorFileSHA256]; ok {
+		err = verifyChecksum(filepath.Join(tmp, mirrorFilesDir, mirrorFileDownload, files[mirrorFileDownload]), filepath.Join(tmp, mirrorFilesDir, mirrorFileSHA256, fn))
+		if err != nil {
+			return nil, xerrors.Errorf("VSIX of %s: %w", metadata.ID(), err)
+		}
+	}
+
+	dir := filepath.Join(extDir, metadata.Version)
+	err = os.RemoveAll(dir)
+	if err != nil {
+		return nil, err
+	}
+	err = os.Rename(tmp, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mirroredVersion{
+		Dir:      dir,
+		Metadata: *metadata,
+		Raw:      raw,
+		Files:    files,
+	}, nil
+}
+
+func (m *Mirror) downloadFile(ctx context.Context, src, dst string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return xerrors.Errorf("unexpected status %s", resp.Status)
+	}
+
+	err = os.MkdirAll(filepath.Dir(dst), 0755)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, resp.Body)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func (m *Mirror) fetch(ctx context.Context, src string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, xerrors.Errorf("cannot fetch %s: unexpected status %s", src, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (m *Mirror) apiURL(publisher, name, version string) string {
+	p := "/api/" + publisher + "/" + name
+	if version != "" {
+		p += "/" + version
+	}
+	u := *m.Upstream
+	u.Path, u.RawPath = joinURLPath(m.Upstream, &url.URL{Path: p})
+	return u.String()
+}
+
+// prune removes all extensions and versions from local storage which are not kept
+func (m *Mirror) prune(keep map[string]map[string]struct{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, ext := range m.extensions {
+		versions := keep[id]
+		for version, v := range ext.Versions {
+			if _, ok := versions[version]; ok {
+				continue
+			}
+			err := os.RemoveAll(v.Dir)
+			if err != nil {
+				log.WithError(err).WithField("extension", id).WithField("version", version).Warn("cannot remove mirrored extension version")
+				continue
+			}
+			delete(ext.Versions, version)
+			log.WithField("extension", id).WithField("version", version).Info("removed mirrored extension version")
+		}
+		if len(ext.Versions) == 0 {
+			delete(m.extensions, id)
+		}
+	}
+}
+
+func (m *Mirror) updateMetrics() {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.metrics.MirroredExtensionsGauge.Set(float64(len(m.extensions)))
+}
+
+// verifyChecksum compares the SHA256 checksum of a file to the hex encoded checksum in another file
+func verifyChecksum(fn, checksumFn string) error {
+	checksum, err := os.ReadFile(checksumFn)
+	if err != nil {
+		return err
+	}
+	expected := strings.ToLower(strings.TrimSpace(string(checksum)))
+	if fields := strings.Fields(expected); len(fields) > 0 {
+		expected = fields[0]
+	}
+
+	f, err := os.Open(fn)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return err
+	}
+
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+		return xerrors.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// parseMirrorExtension parses publisher.name or publisher.name@version
+func parseMirrorExtension(spec string) (publisher, name, version string, err error) {
+	id, version, _ := strings.Cut(spec, "@")
+	publisher, name, ok := strings.Cut(id, ".")
+	if !ok || !isPathElement(publisher) || !isPathElement(name) || (version != "" && !isPathElement(version)) {
+		return "", "", "", fmt.Errorf("invalid extension %q, expected publisher.name or publisher.name@version", spec)
+	}
+	if version == "latest" {
+		version = ""
+	}
+	return publisher, name, version, nil
+}
+
+// isPathElement returns true if s can be used as a single element of a path in local storage,
+// i.e. it contains no separators and cannot refer to a parent directory.
+func isPathElement(s string) bool {
+	return s != "" && s != "." && !strings.Contains(s, "..") && !strings.ContainsAny(s, `/\`)
+}
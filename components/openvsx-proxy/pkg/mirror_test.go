@@ -0,0 +1,411 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testVSIX = "PK fake vsix content"
+
+// newTestUpstream serves the Open VSX API for gitpod.gitpod-theme in version 1.0.0 and redhat.java in version 2.0.0
+func newTestUpstream(t *testing.T) *httptest.Server {
+	var srv *httptest.Server
+	checksum := sha256.Sum256([]byte(testVSIX))
+	metadata := func(namespace, name, version string) map[string]interface{} {
+		base := fmt.Sprintf("%s/api/%s/%s/%s/file/", srv.URL, namespace, name, version)
+		return map[string]interface{}{
+			"namespace":   namespace,
+			"name":        name,
+			"version":     version,
+			"displayName": strings.ToUpper(name),
+			"description": "the " + name + " extension",
+			"categories":  []string{"Themes"},
+			"engines":     map[string]string{"vscode": "^1.60.0"},
+			"files": map[string]string{
+				"download": base + namespace + "." + name + "-" + version + ".vsix",
+				"sha256":   base + namespace + "." + name + "-" + version + ".sha256",
+				"readme":   base + "README.md",
+			},
+			"allVersions": map[string]string{version: base},
+		}
+	}
+
+	srv = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, ".vsix"):
+			rw.Write([]byte(testVSIX))
+		case strings.HasSuffix(r.URL.Path, ".sha256"):
+			rw.Write([]byte(hex.EncodeToString(checksum[:]) + "  extension.vsix\n"))
+		case strings.HasSuffix(r.URL.Path, "README.md"):
+			rw.Write([]byte("# Readme"))
+		case r.URL.Path == "/api/gitpod/gitpod-theme" || r.URL.Path == "/api/gitpod/gitpod-theme/1.0.0":
+			json.NewEncoder(rw).Encode(metadata("gitpod", "gitpod-theme", "1.0.0"))
+		case r.URL.Path == "/api/redhat/java/2.0.0":
+			json.NewEncoder(rw).Encode(metadata("redhat", "java", "2.0.0"))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			rw.Write([]byte(`{"error":"not found"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestMirror(t *testing.T, upstream string, extensions ...string) *Mirror {
+	metrics := &Prometheus{}
+	metrics.Start(&Config{})
+	m, err := NewMirror(&Config{
+		URLUpstream: upstream,
+		Mirror: MirrorConfig{
+			Enabled:     true,
+			Extensions:  extensions,
+			StoragePath: t.TempDir(),
+		},
+	}, metrics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestParseMirrorExtension(t *testing.T) {
+	tests := []struct {
+		Spec      string
+		Publisher string
+		Name      string
+		Version   string
+		Error     bool
+	}{
+		{Spec: "redhat.java", Publisher: "redhat", Name: "java"},
+		{Spec: "redhat.java@1.2.3", Publisher: "redhat", Name: "java", Version: "1.2.3"},
+		{Spec: "ms-python.vscode-pylance", Publisher: "ms-python", Name: "vscode-pylance"},
+		{Spec: "redhat", Error: true},
+		{Spec: ".java", Error: true},
+		{Spec: "redhat.java@latest", Publisher: "redhat", Name: "java"},
+		{Spec: "redhat.java@../../etc", Error: true},
+		{Spec: "redhat.java@..", Error: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Spec, func(t *testing.T) {
+			publisher, name, version, err := parseMirrorExtension(test.Spec)
+			if (err != nil) != test.Error {
+				t.Fatalf("expected error %v, got %v", test.Error, err)
+			}
+			if publisher != test.Publisher || name != test.Name || version != test.Version {
+				t.Errorf("expected %s %s %s, got %s %s %s", test.Publisher, test.Name, test.Version, publisher, name, version)
+			}
+		})
+	}
+}
+
+func TestMirrorSync(t *testing.T) {
+	upstream := newTestUpstream(t)
+	m := newTestMirror(t, upstream.URL, "gitpod.gitpod-theme", "redhat.java@2.0.0", "unknown.extension")
+
+	err := m.Sync(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "unknown.extension") {
+		t.Errorf("expected an error for unknown.extension, got %v", err)
+	}
+
+	for _, id := range []string{"gitpod.gitpod-theme", "redhat.java"} {
+		v, ok := m.Lookup(strings.Split(id, ".")[0], strings.Split(id, ".")[1], "")
+		if !ok {
+			t.Fatalf("%s is not mirrored", id)
+		}
+		fn, ok := v.FilePath(mirrorFileDownload)
+		if !ok {
+			t.Fatalf("VSIX of %s is not mirrored", id)
+		}
+		if _, ok := v.FilePath("readme"); !ok {
+			t.Errorf("readme of %s is not mirrored", id)
+		}
+		m.mu.RLock()
+		mirrored := m.extensions[id].Versions[v.Metadata.Version]
+		m.mu.RUnlock()
+		if mirrored == nil || mirrored.Dir != strings.TrimSuffix(fn, "/files/download/"+mirrored.Files[mirrorFileDownload]) {
+			t.Errorf("unexpected storage of %s: %s", id, fn)
+		}
+	}
+
+	// a new mirror on the same storage loads the extensions from disk
+	reloaded, err := NewMirror(m.Config, m.metrics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var act []string
+	for _, v := range reloaded.Latest() {
+		act = append(act, v.Metadata.ID()+"@"+v.Metadata.Version)
+	}
+	if expected := []string{"gitpod.gitpod-theme@1.0.0", "redhat.java@2.0.0"}; !reflect.DeepEqual(expected, act) {
+		t.Errorf("expected %v, got %v", expected, act)
+	}
+
+	// extensions which are removed from the allowlist are removed from storage
+	m.Config.Mirror.Extensions = []string{"gitpod.gitpod-theme"}
+	err = m.Sync(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.Lookup("redhat", "java", ""); ok {
+		t.Error("redhat.java is still mirrored")
+	}
+}
+
+func TestMirrorSyncChecksumMismatch(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/gitpod/gitpod-theme" {
+			json.NewEncoder(rw).Encode(map[string]interface{}{
+				"namespace": "gitpod",
+				"name":      "gitpod-theme",
+				"version":   "1.0.0",
+				"files": map[string]string{
+					"download": "http://" + r.Host + "/theme.vsix",
+					"sha256":   "http://" + r.Host + "/theme.sha256",
+				},
+			})
+			return
+		}
+		rw.Write([]byte("0000"))
+	}))
+	defer upstream.Close()
+
+	m := newTestMirror(t, upstream.URL, "gitpod.gitpod-theme")
+	err := m.Sync(context.Background())
+	if err == nil {
+		t.Fatal("expected a checksum error")
+	}
+	if _, ok := m.Lookup("gitpod", "gitpod-theme", ""); ok {
+		t.Error("extension with checksum mismatch is mirrored")
+	}
+}
+
+func TestMirrorSyncInvalidMetadata(t *testing.T) {
+	var version string
+	upstream := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/gitpod/gitpod-theme" {
+			json.NewEncoder(rw).Encode(map[string]interface{}{
+				"namespace": "gitpod",
+				"name":      "gitpod-theme",
+				"version":   version,
+				"files": map[string]string{
+					"download":    "http://" + r.Host + "/theme.vsix",
+					"../../evil":  "http://" + r.Host + "/evil.txt",
+					"..":          "http://" + r.Host + "/evil.txt",
+					"readme/../x": "http://" + r.Host + "/evil.txt",
+				},
+			})
+			return
+		}
+		rw.Write([]byte(testVSIX))
+	}))
+	defer upstream.Close()
+
+	m := newTestMirror(t, upstream.URL, "gitpod.gitpod-theme")
+
+	version = "../../escape"
+	err := m.Sync(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "gitpod.gitpod-theme") {
+		t.Fatalf("expected an error for the invalid version, got %v", err)
+	}
+
+	version = "1.0.0"
+	err = m.Sync(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, ok := m.Lookup("gitpod", "gitpod-theme", "")
+	if !ok {
+		t.Fatal("extension is not mirrored")
+	}
+	if expected := map[string]string{mirrorFileDownload: "theme.vsix"}; !reflect.DeepEqual(expected, v.Files) {
+		t.Errorf("files with invalid keys must be skipped, expected %v, got %v", expected, v.Files)
+	}
+}
+
+func TestMirrorSyncLatest(t *testing.T) {
+	latest := "1.0.0"
+	upstream := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/gitpod/gitpod-theme" {
+			json.NewEncoder(rw).Encode(map[string]interface{}{
+				"namespace": "gitpod",
+				"name":      "gitpod-theme",
+				"version":   latest,
+				"files":     map[string]string{"download": "http://" + r.Host + "/theme-" + latest + ".vsix"},
+			})
+			return
+		}
+		rw.Write([]byte(testVSIX))
+	}))
+	defer upstream.Close()
+
+	m := newTestMirror(t, upstream.URL, "gitpod.gitpod-theme@latest")
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		latest = version
+		err := m.Sync(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, ok := m.Lookup("gitpod", "gitpod-theme", "")
+		if !ok || v.Metadata.Version != version {
+			t.Errorf("expected %s to be the latest mirrored version, got %v", version, v)
+		}
+	}
+}
+
+func createMirrorFrontend(t *testing.T, upstream string, offline bool, blocked ...string) *httptest.Server {
+	m := newTestMirror(t, upstream, "gitpod.gitpod-theme", "redhat.java@2.0.0")
+	if err := m.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := m.Config
+	cfg.Mirror.Offline = offline
+	cfg.BlockedExtensions = blocked
+	openVSXProxy := &OpenVSXProxy{Config: cfg}
+	openVSXProxy.Setup()
+
+	proxy := httputil.NewSingleHostReverseProxy(openVSXProxy.defaultUpstreamURL)
+	proxy.ModifyResponse = openVSXProxy.ModifyResponse
+	frontend := httptest.NewServer(http.HandlerFunc(openVSXProxy.Handler(proxy)))
+	cfg.URLLocal = frontend.URL
+	t.Cleanup(frontend.Close)
+	return frontend
+}
+
+func TestServeMirrored(t *testing.T) {
+	upstream := newTestUpstream(t)
+	frontend := createMirrorFrontend(t, upstream.URL, true, "redhat")
+	// nothing must go upstream in offline mode
+	upstream.Close()
+
+	tests := []struct {
+		Name   string
+		Method string
+		Path   string
+		Body   string
+		Status int
+		Expect string
+	}{
+		{Name: "asset", Method: "GET", Path: "/vscode/asset/gitpod/gitpod-theme/1.0.0/Microsoft.VisualStudio.Services.VSIXPackage", Status: 200, Expect: testVSIX},
+		{Name: "vspackage", Method: "GET", Path: "/vscode/gallery/publishers/gitpod/vsextensions/gitpod-theme/1.0.0/vspackage", Status: 200, Expect: testVSIX},
+		{Name: "readme", Method: "GET", Path: "/vscode/asset/gitpod/gitpod-theme/1.0.0/Microsoft.VisualStudio.Services.Content.Details", Status: 200, Expect: "# Readme"},
+		{Name: "api file", Method: "GET", Path: "/api/gitpod/gitpod-theme/1.0.0/file/gitpod.gitpod-theme-1.0.0.vsix", Status: 200, Expect: testVSIX},
+		{Name: "api metadata", Method: "GET", Path: "/api/gitpod/gitpod-theme", Status: 200, Expect: `"download":"FRONTEND/api/gitpod/gitpod-theme/1.0.0/file/gitpod.gitpod-theme-1.0.0.vsix"`},
+		{Name: "missing version", Method: "GET", Path: "/vscode/asset/gitpod/gitpod-theme/0.9.0/Microsoft.VisualStudio.Services.VSIXPackage", Status: 404},
+		{Name: "blocked publisher", Method: "GET", Path: "/vscode/asset/redhat/java/2.0.0/Microsoft.VisualStudio.Services.VSIXPackage", Status: 403},
+		{Name: "blocked item", Method: "GET", Path: "/vscode/item?itemName=RedHat.java", Status: 403},
+		{
+			Name:   "query by name",
+			Method: "POST",
+			Path:   "/vscode/gallery/extensionquery",
+			Body:   `{"filters":[{"criteria":[{"filterType":8,"value":"Microsoft.VisualStudio.Code"},{"filterType":7,"value":"gitpod.gitpod-theme"}],"pageNumber":1,"pageSize":10}],"flags":950}`,
+			Status: 200,
+			Expect: `"extensionName":"gitpod-theme"`,
+		},
+		{
+			Name:   "query blocked",
+			Method: "POST",
+			Path:   "/vscode/gallery/extensionquery",
+			Body:   `{"filters":[{"criteria":[{"filterType":10,"value":"java"}]}]}`,
+			Status: 200,
+			Expect: `"extensions":[]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			req, _ := http.NewRequest(test.Method, frontend.URL+test.Path, bytes.NewBufferString(test.Body))
+			resp, err := frontend.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+
+			if resp.StatusCode != test.Status {
+				t.Fatalf("expected status %d, got %d: %s", test.Status, resp.StatusCode, body)
+			}
+			if expect := strings.ReplaceAll(test.Expect, "FRONTEND", frontend.URL); !strings.Contains(string(body), expect) {
+				t.Errorf("expected body to contain %s, got %s", expect, body)
+			}
+		})
+	}
+}
+
+func TestExtensionQuery(t *testing.T) {
+	frontend := createMirrorFrontend(t, newTestUpstream(t).URL, true)
+
+	query := `{"filters":[{"criteria":[{"filterType":10,"value":""}],"pageNumber":2,"pageSize":1}]}`
+	resp, err := frontend.Client().Post(frontend.URL+"/vscode/gallery/extensionquery", "application/json", strings.NewReader(query))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Results []galleryResult `json:"results"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Results) != 1 || len(res.Results[0].Extensions) != 1 {
+		t.Fatalf("expected a single result with a single extension, got %+v", res)
+	}
+	ext := res.Results[0].Extensions[0]
+	if ext.Publisher.PublisherName != "redhat" || ext.ExtensionName != "java" {
+		t.Errorf("expected redhat.java on the second page, got %s.%s", ext.Publisher.PublisherName, ext.ExtensionName)
+	}
+	if count := res.Results[0].ResultMetadata[0].MetadataItems[0].Count; count != 2 {
+		t.Errorf("expected a total count of 2, got %d", count)
+	}
+	expectedAssetURI := frontend.URL + "/vscode/asset/redhat/java/2.0.0"
+	if act := ext.Versions[0].AssetURI; act != expectedAssetURI {
+		t.Errorf("expected asset URI %s, got %s", expectedAssetURI, act)
+	}
+}
+
+func TestFilterBlocked(t *testing.T) {
+	o := &OpenVSXProxy{Config: &Config{BlockedExtensions: []string{"ms-python", "redhat.java"}}}
+
+	gallery := `{"results":[{"extensions":[` +
+		`{"extensionName":"python","publisher":{"publisherName":"ms-python"}},` +
+		`{"extensionName":"java","publisher":{"publisherName":"RedHat"}},` +
+		`{"extensionName":"vscode-yaml","publisher":{"publisherName":"redhat"}}],` +
+		`"resultMetadata":[{"metadataType":"ResultCount","metadataItems":[{"name":"TotalCount","count":3}]}]}]}`
+	req := httptest.NewRequest("POST", "/vscode/gallery/extensionquery", nil)
+	act := string(o.filterBlocked(req, http.Header{}, []byte(gallery)))
+	expected := `{"results":[{"extensions":[{"extensionName":"vscode-yaml","publisher":{"publisherName":"redhat"}}],"resultMetadata":[{"metadataItems":[{"count":1,"name":"TotalCount"}],"metadataType":"ResultCount"}]}]}`
+	if act != expected {
+		t.Errorf("expected %s, got %s", expected, act)
+	}
+
+	search := `{"offset":0,"totalSize":2,"extensions":[{"namespace":"ms-python","name":"python"},{"namespace":"redhat","name":"vscode-yaml"}]}`
+	req = httptest.NewRequest("GET", "/api/-/search?query=python", nil)
+	act = string(o.filterBlocked(req, http.Header{}, []byte(search)))
+	expected = `{"extensions":[{"name":"vscode-yaml","namespace":"redhat"}],"offset":0,"totalSize":1}`
+	if act != expected {
+		t.Errorf("expected %s, got %s", expected, act)
+	}
+
+	req = httptest.NewRequest("GET", "/vscode/asset/ms-python/python/1.0.0/Microsoft.VisualStudio.Services.VSIXPackage", nil)
+	if act := string(o.filterBlocked(req, http.Header{}, []byte(search))); act != search {
+		t.Errorf("expected body of other paths to be unchanged, got %s", act)
+	}
+}
@@ -43,6 +43,18 @@ func (o *OpenVSXProxy) ModifyResponse(r *http.Response) error {
 	o.metrics.IncStatusCounter(r.Request, strconv.Itoa(r.StatusCode))
 
 	if !ok {
+		if r.StatusCode != http.StatusOK || o.blockedFilter(r.Request) == nil {
+			return nil
+		}
+		rawBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			log.WithFields(logFields).WithError(err).Error("error reading response raw body")
+			return err
+		}
+		r.Body.Close()
+		rawBody = o.filterBlocked(r.Request, r.Header, rawBody)
+		r.Body = ioutil.NopCloser(bytes.NewBuffer(rawBody))
+		r.ContentLength = int64(len(rawBody))
 		return nil
 	}
 
@@ -95,6 +107,7 @@ func (o *OpenVSXProxy) ModifyResponse(r *http.Response) error {
 	}
 
 	// no error (status code < 500)
+	rawBody = o.filterBlocked(r.Request, r.Header, rawBody)
 	cacheObj := &CacheObject{
 		Header:     r.Header,
 		Body:       rawBody,
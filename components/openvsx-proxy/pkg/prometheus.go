@@ -27,6 +27,10 @@ type Prometheus struct {
 	DurationRequestProcessingHistogram  prometheus.Histogram
 	DurationUpstreamCallHistorgram      prometheus.Histogram
 	DurationResponseProcessingHistogram prometheus.Histogram
+	MirrorServeCounter                  prometheus.Counter
+	BlockedRequestsCounter              prometheus.Counter
+	MirroredExtensionsGauge             prometheus.Gauge
+	MirrorSyncErrorsCounter             prometheus.Counter
 }
 
 func (p *Prometheus) Start(cfg *Config) {
@@ -62,6 +66,10 @@ func (p *Prometheus) Start(cfg *Config) {
 		p.DurationRequestProcessingHistogram,
 		p.DurationUpstreamCallHistorgram,
 		p.DurationResponseProcessingHistogram,
+		p.MirrorServeCounter,
+		p.BlockedRequestsCounter,
+		p.MirroredExtensionsGauge,
+		p.MirrorSyncErrorsCounter,
 	}
 	for _, c := range collectors {
 		err := p.reg.Register(c)
@@ -134,6 +142,30 @@ func (p *Prometheus) createMetrics() {
 		Name:      "duration_response_processing_seconds",
 		Help:      "The duration in seconds of the processing of the HTTP responses after we have called the upstream.",
 	})
+	p.MirrorServeCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "mirror_serve_total",
+		Help:      "The total amount of requests we answered from the extension mirror without calling the upstream.",
+	})
+	p.BlockedRequestsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "blocked_requests_total",
+		Help:      "The total amount of requests we rejected because they refer to a blocked extension.",
+	})
+	p.MirroredExtensionsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "mirrored_extensions",
+		Help:      "The number of extensions in the extension mirror.",
+	})
+	p.MirrorSyncErrorsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "mirror_sync_errors_total",
+		Help:      "The total amount of extensions we could not sync with the upstream.",
+	})
 }
 
 var expectedPaths = map[string]struct{}{
@@ -35,6 +35,7 @@ type OpenVSXProxy struct {
 	cacheManager       *cache.Cache
 	metrics            *Prometheus
 	experiments        experiments.Client
+	mirror             *Mirror
 }
 
 func (o *OpenVSXProxy) GetUpstreamUrl(r *http.Request) *url.URL {
@@ -78,6 +79,13 @@ func (o *OpenVSXProxy) Setup() error {
 		return xerrors.Errorf("error parsing upstream URL: %v", err)
 	}
 
+	if o.Config.Mirror.Enabled {
+		o.mirror, err = NewMirror(o.Config, o.metrics)
+		if err != nil {
+			return xerrors.Errorf("error setting up mirror: %v", err)
+		}
+	}
+
 	http.DefaultTransport.(*http.Transport).MaxIdleConns = o.Config.MaxIdleConns
 	http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost = o.Config.MaxIdleConnsPerHost
 	return nil
@@ -89,6 +97,11 @@ func (o *OpenVSXProxy) Start() (shutdown func(context.Context) error, err error)
 			return nil, err
 		}
 	}
+	ctx, cancelMirror := context.WithCancel(context.Background())
+	if o.mirror != nil && !o.Config.Mirror.Offline {
+		go o.mirror.Start(ctx)
+	}
+
 	proxy := newSingleHostReverseProxy()
 	proxy.ErrorHandler = o.ErrorHandler
 	proxy.ModifyResponse = o.ModifyResponse
@@ -114,6 +127,7 @@ func (o *OpenVSXProxy) Start() (shutdown func(context.Context) error, err error)
 		}
 	}()
 	return func(c context.Context) error {
+		cancelMirror()
 		return srv.Shutdown(c)
 	}, nil
 }
@@ -0,0 +1,194 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	connect "github.com/bufbuild/connect-go"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// Actions recorded in the audit log, named after the RPC which performs them.
+const (
+	auditLogActionCreatePersonalAccessToken     = "createPersonalAccessToken"
+	auditLogActionRegeneratePersonalAccessToken = "regeneratePersonalAccessToken"
+	auditLogActionDeletePersonalAccessToken     = "deletePersonalAccessToken"
+	auditLogActionCreateClientConfig            = "createClientConfig"
+	auditLogActionUpdateClientConfig            = "updateClientConfig"
+	auditLogActionDeleteClientConfig            = "deleteClientConfig"
+	auditLogActionCreateSCIMToken               = "createSCIMToken"
+	auditLogActionDeleteSCIMToken               = "deleteSCIMToken"
+	auditLogActionJoinTeam                      = "joinTeam"
+	auditLogActionUpdateTeamMember              = "updateTeamMember"
+	auditLogActionDeleteTeamMember              = "deleteTeamMember"
+	auditLogActionBlockUser                     = "blockUser"
+	auditLogActionCreateWorkspaceTemplate       = "createWorkspaceTemplate"
+	auditLogActionDeleteWorkspaceTemplate       = "deleteWorkspaceTemplate"
+)
+
+func NewAuditLogsService(pool proxy.ServerConnectionPool, dbConn *gorm.DB) *AuditLogsService {
+	return &AuditLogsService{
+		connectionPool: pool,
+		dbConn:         dbConn,
+	}
+}
+
+var _ v1connect.AuditLogsServiceHandler = (*AuditLogsService)(nil)
+
+type AuditLogsService struct {
+	connectionPool proxy.ServerConnectionPool
+	dbConn         *gorm.DB
+
+	v1connect.UnimplementedAuditLogsServiceHandler
+}
+
+func (s *AuditLogsService) ListAuditLogs(ctx context.Context, req *connect.Request[v1.ListAuditLogsRequest]) (*connect.Response[v1.ListAuditLogsResponse], error) {
+	organizationID, err := validateOrganizationID(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	filter := db.ListAuditLogsFilter{
+		ActorID: req.Msg.GetActorId(),
+		Action:  req.Msg.GetAction(),
+	}
+	if req.Msg.GetFrom() != nil {
+		filter.From = req.Msg.GetFrom().AsTime()
+	}
+	if req.Msg.GetTo() != nil {
+		filter.To = req.Msg.GetTo().AsTime()
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("From must be before To."))
+	}
+
+	conn, err := getConnection(ctx, s.connectionPool)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := conn.GetLoggedInUser(ctx)
+	if err != nil {
+		return nil, proxy.ConvertError(err)
+	}
+	log.AddFields(ctx, log.UserID(user.ID))
+
+	userID, err := uuid.Parse(user.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to parse user ID as UUID. Please contact support."))
+	}
+
+	err = assertIsOrganizationOwner(ctx, s.dbConn, userID, organizationID, fmt.Sprintf("Only owners of Organization %s can list its audit logs", organizationID.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := db.ListAuditLogsForOrganization(ctx, s.dbConn, organizationID, filter, paginationToDB(req.Msg.GetPagination()))
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to list audit logs.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to list audit logs for Organization %s", organizationID.String()))
+	}
+
+	auditLogs := make([]*v1.AuditLog, 0, len(result.Results))
+	for _, entry := range result.Results {
+		auditLogs = append(auditLogs, auditLogToAPIResponse(entry))
+	}
+
+	return connect.NewResponse(&v1.ListAuditLogsResponse{
+		AuditLogs:    auditLogs,
+		TotalResults: result.Total,
+	}), nil
+}
+
+func auditLogToAPIResponse(entry db.AuditLog) *v1.AuditLog {
+	return &v1.AuditLog{
+		Id:             entry.ID.String(),
+		Timestamp:      timestamppb.New(entry.Timestamp),
+		OrganizationId: entry.OrganizationID,
+		ActorId:        entry.ActorID,
+		Action:         entry.Action,
+		Args:           string(entry.Args),
+	}
+}
+
+// recordAuditLog records an action, which has already been performed. Hence failures are only logged.
+func recordAuditLog(ctx context.Context, writer *db.AuditLogWriter, organizationID, actorID, action string, args map[string]any) {
+	logger := log.Extract(ctx).WithField("action", action)
+
+	entry := db.AuditLog{
+		Timestamp:      time.Now().UTC(),
+		OrganizationID: organizationID,
+		ActorID:        actorID,
+		Action:         action,
+	}
+	err := entry.SetArgs(args)
+	if err != nil {
+		logger.WithError(err).Error("Failed to record audit log.")
+		return
+	}
+
+	_, err = writer.Write(ctx, entry)
+	if err != nil {
+		logger.WithError(err).Error("Failed to record audit log.")
+	}
+}
+
+// recordUserAuditLog records an action concerning a user under the organization owning the user, or otherwise under
+// every organization the user is a member of, such that it can be listed by the owners of these organizations.
+func recordUserAuditLog(ctx context.Context, writer *db.AuditLogWriter, dbConn *gorm.DB, userID uuid.UUID, actorID, action string, args map[string]any) {
+	orgIDs, err := organizationsOfUser(ctx, dbConn, userID)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to retrieve organizations of user for audit log.")
+	}
+	if len(orgIDs) == 0 {
+		// still streamed to the sinks, even though it cannot be listed per organization
+		orgIDs = []string{""}
+	}
+	for _, orgID := range orgIDs {
+		recordAuditLog(ctx, writer, orgID, actorID, action, args)
+	}
+}
+
+func organizationsOfUser(ctx context.Context, dbConn *gorm.DB, userID uuid.UUID) ([]string, error) {
+	user, err := db.GetUser(ctx, dbConn, userID)
+	if err != nil && !errors.Is(err, db.ErrorNotFound) {
+		return nil, err
+	}
+	if err == nil && user.OrganizationID != "" {
+		return []string{user.OrganizationID}, nil
+	}
+
+	memberships, err := db.ListTeamMembershipsForUserIDs(ctx, dbConn, []uuid.UUID{userID})
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, m := range memberships {
+		res = append(res, m.TeamID.String())
+	}
+	return res, nil
+}
+
+// auditLogActorID returns the ID of the user performing an action, or an empty string if it cannot be determined.
+func auditLogActorID(ctx context.Context, conn protocol.APIInterface) string {
+	user, err := conn.GetLoggedInUser(ctx)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to retrieve actor of audit log.")
+		return ""
+	}
+	return user.ID
+}
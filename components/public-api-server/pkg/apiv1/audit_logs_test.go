@@ -0,0 +1,157 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	connect "github.com/bufbuild/connect-go"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func TestAuditLogsService_ListAuditLogs(t *testing.T) {
+	t.Run("invalid argument when Organization ID is not a UUID", func(t *testing.T) {
+		_, client, _ := setupAuditLogsService(t)
+
+		_, err := client.ListAuditLogs(context.Background(), connect.NewRequest(&v1.ListAuditLogsRequest{
+			OrganizationId: "foo-bar",
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("invalid argument when from is not before to", func(t *testing.T) {
+		_, client, _ := setupAuditLogsService(t)
+
+		now := time.Now()
+		_, err := client.ListAuditLogs(context.Background(), connect.NewRequest(&v1.ListAuditLogsRequest{
+			OrganizationId: uuid.NewString(),
+			From:           timestamppb.New(now),
+			To:             timestamppb.New(now.Add(-time.Hour)),
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("permission denied when user is not an owner of the organization", func(t *testing.T) {
+		serverMock, client, dbConn := setupAuditLogsService(t)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.ListAuditLogs(context.Background(), connect.NewRequest(&v1.ListAuditLogsRequest{
+			OrganizationId: orgID.String(),
+		}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("lists audit logs of the organization", func(t *testing.T) {
+		serverMock, client, dbConn := setupAuditLogsService(t)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Owner)
+
+		now := time.Now().UTC().Truncate(time.Second)
+		entries := []db.AuditLog{
+			createAuditLog(t, dbConn, db.AuditLog{Timestamp: now.Add(-time.Hour), OrganizationID: orgID.String(), ActorID: user.ID, Action: auditLogActionCreateSCIMToken}),
+			createAuditLog(t, dbConn, db.AuditLog{Timestamp: now, OrganizationID: orgID.String(), ActorID: uuid.NewString(), Action: auditLogActionDeleteTeamMember}),
+		}
+		createAuditLog(t, dbConn, db.AuditLog{Timestamp: now, OrganizationID: uuid.NewString(), ActorID: user.ID, Action: auditLogActionCreateSCIMToken})
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil).Times(2)
+
+		all, err := client.ListAuditLogs(context.Background(), connect.NewRequest(&v1.ListAuditLogsRequest{
+			OrganizationId: orgID.String(),
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.ListAuditLogsResponse{
+			AuditLogs: []*v1.AuditLog{
+				auditLogToAPIResponse(entries[1]),
+				auditLogToAPIResponse(entries[0]),
+			},
+			TotalResults: 2,
+		}, all.Msg)
+
+		byActor, err := client.ListAuditLogs(context.Background(), connect.NewRequest(&v1.ListAuditLogsRequest{
+			OrganizationId: orgID.String(),
+			ActorId:        user.ID,
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.ListAuditLogsResponse{
+			AuditLogs:    []*v1.AuditLog{auditLogToAPIResponse(entries[0])},
+			TotalResults: 1,
+		}, byActor.Msg)
+	})
+}
+
+func setupAuditLogsService(t *testing.T) (*protocol.MockAPIInterface, v1connect.AuditLogsServiceClient, *gorm.DB) {
+	t.Helper()
+
+	dbConn := dbtest.ConnectForTests(t)
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	serverMock := protocol.NewMockAPIInterface(ctrl)
+
+	svc := NewAuditLogsService(&FakeServerConnPool{api: serverMock}, dbConn)
+
+	_, handler := v1connect.NewAuditLogsServiceHandler(svc, connect.WithInterceptors(auth.NewServerInterceptor()))
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := v1connect.NewAuditLogsServiceClient(http.DefaultClient, srv.URL, connect.WithInterceptors(
+		auth.NewClientInterceptor("auth-token"),
+	))
+
+	return serverMock, client, dbConn
+}
+
+func createAuditLog(t *testing.T, dbConn *gorm.DB, entry db.AuditLog) db.AuditLog {
+	t.Helper()
+
+	entry.ID = uuid.New()
+	created, err := db.CreateAuditLog(context.Background(), dbConn, entry)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, dbConn.Delete(&created).Error)
+	})
+
+	return created
+}
+
+// requireAuditLog asserts that the actor performed the action on the organization, and returns the recorded entry.
+func requireAuditLog(t *testing.T, organizationID, actorID, action string) db.AuditLog {
+	t.Helper()
+
+	dbConn := dbtest.ConnectForTests(t)
+	result, err := db.ListAuditLogsForOrganization(context.Background(), dbConn, uuid.MustParse(organizationID), db.ListAuditLogsFilter{
+		ActorID: actorID,
+		Action:  action,
+	}, db.Pagination{PageSize: 25})
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+
+	entry := result.Results[0]
+	t.Cleanup(func() {
+		require.NoError(t, dbConn.Delete(&entry).Error)
+	})
+
+	return entry
+}
@@ -23,18 +23,22 @@ import (
 	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/scim"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"gorm.io/gorm"
 )
 
-func NewOIDCService(connPool proxy.ServerConnectionPool, expClient experiments.Client, dbConn *gorm.DB, cipher db.Cipher) *OIDCService {
+func NewOIDCService(connPool proxy.ServerConnectionPool, expClient experiments.Client, dbConn *gorm.DB, cipher db.Cipher, auditLog *db.AuditLogWriter) *OIDCService {
 	return &OIDCService{
 		connectionPool: connPool,
 		expClient:      expClient,
 		cipher:         cipher,
 		dbConn:         dbConn,
+		auditLog:       auditLog,
 	}
 }
 
@@ -42,8 +46,9 @@ type OIDCService struct {
 	expClient      experiments.Client
 	connectionPool proxy.ServerConnectionPool
 
-	cipher db.Cipher
-	dbConn *gorm.DB
+	cipher   db.Cipher
+	dbConn   *gorm.DB
+	auditLog *db.AuditLogWriter
 
 	v1connect.UnimplementedOIDCServiceHandler
 }
@@ -64,13 +69,26 @@ func (s *OIDCService) CreateClientConfig(ctx context.Context, req *connect.Reque
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
+	err = validateClaimMappings(oidcConfig.GetClaimMappings())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	err = validateEmailDomains(oidcConfig.GetEmailDomains())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 
 	conn, err := s.getConnection(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	_, _, err = s.getUser(ctx, conn)
+	_, userID, err := s.getUser(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.assertIsOrganizationOwner(ctx, userID, organizationID)
 	if err != nil {
 		return nil, err
 	}
@@ -98,6 +116,12 @@ func (s *OIDCService) CreateClientConfig(ctx context.Context, req *connect.Reque
 
 	log.AddFields(ctx, log.OIDCClientConfigID(created.ID.String()))
 
+	recordAuditLog(ctx, s.auditLog, organizationID.String(), userID.String(), auditLogActionCreateClientConfig, map[string]any{
+		"id":     created.ID.String(),
+		"issuer": created.Issuer,
+		"active": created.Active,
+	})
+
 	converted, err := dbOIDCClientConfigToAPI(created, s.cipher)
 	if err != nil {
 		log.Extract(ctx).WithError(err).Error("Failed to convert OIDC Client config to response.")
@@ -184,17 +208,129 @@ func (s *OIDCService) ListClientConfigs(ctx context.Context, req *connect.Reques
 }
 
 func (s *OIDCService) UpdateClientConfig(ctx context.Context, req *connect.Request[v1.UpdateClientConfigRequest]) (*connect.Response[v1.UpdateClientConfigResponse], error) {
+	config := req.Msg.GetConfig()
+	organizationID, err := validateOrganizationID(ctx, config.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	clientConfigID, err := validateOIDCClientConfigID(ctx, config.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	mask, err := validateFieldMask(req.Msg.GetUpdateMask(), config)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range mask.GetPaths() {
+		if !clearableOIDCClientConfigFields[path] {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Field %s can not be in the update mask.", path))
+		}
+	}
+
+	oidcConfig := config.GetOidcConfig()
+	err = validateClaimMappings(oidcConfig.GetClaimMappings())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	err = validateEmailDomains(oidcConfig.GetEmailDomains())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	conn, err := s.getConnection(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	_, _, err = s.getUser(ctx, conn)
+	_, userID, err := s.getUser(ctx, conn)
 	if err != nil {
 		return nil, err
 	}
 
-	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gitpod.experimental.v1.OIDCService.UpdateClientConfig is not implemented"))
+	err = s.assertIsOrganizationOwner(ctx, userID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := db.GetOIDCClientConfigForOrganization(ctx, s.dbConn, clientConfigID, organizationID)
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("OIDC Client Config %s for Organization %s does not exist", clientConfigID.String(), organizationID.String()))
+		}
+
+		log.Extract(ctx).WithError(err).Error("Failed to retrieve OIDC Client config.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to retrieve OIDC Client Config %s for Organization %s", clientConfigID.String(), organizationID.String()))
+	}
+
+	current, err := existing.Data.Decrypt(s.cipher)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to decrypt oidc client config.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to update OIDC Client Config %s for Organization %s", clientConfigID.String(), organizationID.String()))
+	}
+
+	issuer := oidcConfig.GetIssuer()
+	if issuer == "" {
+		issuer = existing.Issuer
+	}
+	if issuer != existing.Issuer {
+		err = assertIssuerIsReachable(ctx, issuer)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		err = assertIssuerProvidesDiscovery(ctx, issuer)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	spec := mergeDbOIDCSpec(current, config.GetOauth2Config(), oidcConfig, mask)
+	spec = rotateClientSecret(current, spec, time.Now().UTC())
+
+	active := existing.Active
+	if config.Active != nil {
+		active = config.GetActive()
+	}
+
+	data, err := db.EncryptJSON(s.cipher, spec)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to encrypt oidc client config.")
+		return nil, status.Errorf(codes.Internal, "Failed to store OIDC client config.")
+	}
+
+	updated, err := db.UpdateOIDCClientConfig(ctx, s.dbConn, db.OIDCClientConfig{
+		ID:             clientConfigID,
+		OrganizationID: organizationID,
+		Issuer:         issuer,
+		Data:           data,
+		Active:         active,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("OIDC Client Config %s for Organization %s does not exist", clientConfigID.String(), organizationID.String()))
+		}
+
+		log.Extract(ctx).WithError(err).Error("Failed to update oidc client config in the database.")
+		return nil, status.Errorf(codes.Internal, "Failed to store OIDC client config.")
+	}
+
+	recordAuditLog(ctx, s.auditLog, organizationID.String(), userID.String(), auditLogActionUpdateClientConfig, map[string]any{
+		"id":                  clientConfigID.String(),
+		"issuer":              updated.Issuer,
+		"active":              updated.Active,
+		"clientSecretRotated": spec.ClientSecret != current.ClientSecret,
+	})
+
+	converted, err := dbOIDCClientConfigToAPI(updated, s.cipher)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to convert OIDC Client config to response.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to convert OIDC Client Config %s for Organization %s to API response", clientConfigID.String(), organizationID.String()))
+	}
+
+	return connect.NewResponse(&v1.UpdateClientConfigResponse{
+		Config: converted,
+	}), nil
 }
 
 func (s *OIDCService) DeleteClientConfig(ctx context.Context, req *connect.Request[v1.DeleteClientConfigRequest]) (*connect.Response[v1.DeleteClientConfigResponse], error) {
@@ -213,7 +349,12 @@ func (s *OIDCService) DeleteClientConfig(ctx context.Context, req *connect.Reque
 		return nil, err
 	}
 
-	_, _, err = s.getUser(ctx, conn)
+	_, userID, err := s.getUser(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.assertIsOrganizationOwner(ctx, userID, organizationID)
 	if err != nil {
 		return nil, err
 	}
@@ -228,9 +369,109 @@ func (s *OIDCService) DeleteClientConfig(ctx context.Context, req *connect.Reque
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to delete OIDC Client Config %s for Organization %s", clientConfigID.String(), organizationID.String()))
 	}
 
+	recordAuditLog(ctx, s.auditLog, organizationID.String(), userID.String(), auditLogActionDeleteClientConfig, map[string]any{
+		"id": clientConfigID.String(),
+	})
+
 	return connect.NewResponse(&v1.DeleteClientConfigResponse{}), nil
 }
 
+func (s *OIDCService) CreateSCIMToken(ctx context.Context, req *connect.Request[v1.CreateSCIMTokenRequest]) (*connect.Response[v1.CreateSCIMTokenResponse], error) {
+	organizationID, err := validateOrganizationID(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := s.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, userID, err := s.getUser(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.assertIsOrganizationOwner(ctx, userID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, hash, err := scim.NewToken()
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to generate SCIM token.")
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to generate SCIM token."))
+	}
+
+	_, err = db.CreateSCIMToken(ctx, s.dbConn, db.SCIMToken{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		Hash:           hash,
+	})
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to store SCIM token.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to create SCIM token for Organization %s", organizationID.String()))
+	}
+
+	recordAuditLog(ctx, s.auditLog, organizationID.String(), userID.String(), auditLogActionCreateSCIMToken, map[string]any{})
+
+	return connect.NewResponse(&v1.CreateSCIMTokenResponse{
+		Token: token,
+	}), nil
+}
+
+func (s *OIDCService) DeleteSCIMToken(ctx context.Context, req *connect.Request[v1.DeleteSCIMTokenRequest]) (*connect.Response[v1.DeleteSCIMTokenResponse], error) {
+	organizationID, err := validateOrganizationID(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := s.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, userID, err := s.getUser(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.assertIsOrganizationOwner(ctx, userID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := db.DeleteSCIMTokensForOrganization(ctx, s.dbConn, organizationID)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to delete SCIM token.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to delete SCIM token for Organization %s", organizationID.String()))
+	}
+	if deleted == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Organization %s has no SCIM token", organizationID.String()))
+	}
+
+	recordAuditLog(ctx, s.auditLog, organizationID.String(), userID.String(), auditLogActionDeleteSCIMToken, map[string]any{})
+
+	return connect.NewResponse(&v1.DeleteSCIMTokenResponse{}), nil
+}
+
+func (s *OIDCService) assertIsOrganizationOwner(ctx context.Context, userID, organizationID uuid.UUID) error {
+	return assertIsOrganizationOwner(ctx, s.dbConn, userID, organizationID, fmt.Sprintf("Only owners of Organization %s can manage its SSO configuration", organizationID.String()))
+}
+
+// assertIsOrganizationOwner returns a PermissionDenied error with deniedMessage, unless the user is an owner of the organization.
+func assertIsOrganizationOwner(ctx context.Context, dbConn *gorm.DB, userID, organizationID uuid.UUID, deniedMessage string) error {
+	membership, err := db.GetTeamMembership(ctx, dbConn, userID, organizationID)
+	if err != nil && !errors.Is(err, db.ErrorNotFound) {
+		log.Extract(ctx).WithError(err).Error("Failed to retrieve team membership.")
+		return connect.NewError(connect.CodeInternal, errors.New("Failed to verify permissions."))
+	}
+	if err != nil || membership.Role != db.TeamMembershipRole_Owner {
+		return connect.NewError(connect.CodePermissionDenied, errors.New(deniedMessage))
+	}
+	return nil
+}
+
 func (s *OIDCService) getConnection(ctx context.Context) (protocol.APIInterface, error) {
 	token, err := auth.TokenFromContext(ctx)
 	if err != nil {
@@ -300,14 +541,16 @@ func dbOIDCClientConfigToAPI(config db.OIDCClientConfig, decryptor db.Decryptor)
 		OrganizationId: config.OrganizationID.String(),
 		Oauth2Config: &v1.OAuth2Config{
 			ClientId:              decrypted.ClientID,
-			ClientSecret:          "REDACTED",
+			ClientSecret:          redactedClientSecret,
 			AuthorizationEndpoint: decrypted.RedirectURL,
 			Scopes:                decrypted.Scopes,
 		},
 		OidcConfig: &v1.OIDCConfig{
-			Issuer: config.Issuer,
+			Issuer:        config.Issuer,
+			ClaimMappings: claimMappingsToAPI(decrypted.ClaimMappings),
+			EmailDomains:  decrypted.EmailDomains,
 		},
-		Active: config.Active,
+		Active: proto.Bool(config.Active),
 	}, nil
 }
 
@@ -326,13 +569,166 @@ func dbOIDCClientConfigsToAPI(configs []db.OIDCClientConfig, decryptor db.Decryp
 	return results, nil
 }
 
+// redactedClientSecret is returned instead of the client secret. Updates with it keep the current secret.
+const redactedClientSecret = "REDACTED"
+
+// clientSecretRotationGracePeriod is how long the previous client secret remains valid after a rotation.
+const clientSecretRotationGracePeriod = 24 * time.Hour
+
 func toDbOIDCSpec(oauth2Config *v1.OAuth2Config, oidcConfig *v1.OIDCConfig) db.OIDCSpec {
+	var emailDomains []string
+	for _, d := range oidcConfig.GetEmailDomains() {
+		emailDomains = append(emailDomains, strings.ToLower(strings.TrimSpace(d)))
+	}
+
 	return db.OIDCSpec{
-		ClientID:     oauth2Config.GetClientId(),
-		ClientSecret: oauth2Config.GetClientSecret(),
-		RedirectURL:  oauth2Config.GetAuthorizationEndpoint(),
-		Scopes:       append([]string{goidc.ScopeOpenID, "profile", "email"}, oauth2Config.GetScopes()...),
+		ClientID:      oauth2Config.GetClientId(),
+		ClientSecret:  oauth2Config.GetClientSecret(),
+		RedirectURL:   oauth2Config.GetAuthorizationEndpoint(),
+		Scopes:        withDefaultScopes(oauth2Config.GetScopes()),
+		ClaimMappings: toDbClaimMappings(oidcConfig.GetClaimMappings()),
+		EmailDomains:  emailDomains,
+	}
+}
+
+const (
+	oidcClaimMappingsField = "oidc_config.claim_mappings"
+	oidcEmailDomainsField  = "oidc_config.email_domains"
+	oauth2ScopesField      = "oauth2_config.scopes"
+)
+
+// clearableOIDCClientConfigFields are the fields which updates replace even if they are empty, if they are in the update mask.
+var clearableOIDCClientConfigFields = map[string]bool{
+	oidcClaimMappingsField: true,
+	oidcEmailDomainsField:  true,
+	oauth2ScopesField:      true,
+}
+
+// mergeDbOIDCSpec applies the fields which are set in the update, or which are in the mask, to the current spec.
+// The client secret is kept, see rotateClientSecret.
+func mergeDbOIDCSpec(current db.OIDCSpec, oauth2Config *v1.OAuth2Config, oidcConfig *v1.OIDCConfig, mask *fieldmaskpb.FieldMask) db.OIDCSpec {
+	updated := toDbOIDCSpec(oauth2Config, oidcConfig)
+	inMask := func(path string) bool {
+		for _, p := range mask.GetPaths() {
+			if p == path {
+				return true
+			}
+		}
+		return false
+	}
+
+	merged := current
+	merged.ClientSecret = updated.ClientSecret
+	if updated.ClientID != "" {
+		merged.ClientID = updated.ClientID
+	}
+	if updated.RedirectURL != "" {
+		merged.RedirectURL = updated.RedirectURL
+	}
+	if len(oauth2Config.GetScopes()) > 0 || inMask(oauth2ScopesField) {
+		merged.Scopes = updated.Scopes
+	}
+	if len(updated.ClaimMappings) > 0 || inMask(oidcClaimMappingsField) {
+		merged.ClaimMappings = updated.ClaimMappings
+	}
+	if len(updated.EmailDomains) > 0 || inMask(oidcEmailDomainsField) {
+		merged.EmailDomains = updated.EmailDomains
+	}
+	return merged
+}
+
+// withDefaultScopes prepends the scopes required for sign-ins. Configs read from the API already contain them.
+func withDefaultScopes(scopes []string) []string {
+	res := []string{goidc.ScopeOpenID, "profile", "email"}
+	for _, scope := range scopes {
+		duplicate := false
+		for _, existing := range res {
+			if scope == existing {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			res = append(res, scope)
+		}
+	}
+	return res
+}
+
+// rotateClientSecret keeps the current client secret if the update does not set a new one. Otherwise, the current
+// secret remains valid as the previous secret for a grace period, such that sign-ins keep working until the IdP
+// has been updated.
+func rotateClientSecret(current, updated db.OIDCSpec, now time.Time) db.OIDCSpec {
+	if updated.ClientSecret == "" || updated.ClientSecret == redactedClientSecret || updated.ClientSecret == current.ClientSecret {
+		updated.ClientSecret = current.ClientSecret
+		updated.PreviousClientSecret = current.PreviousClientSecret
+		updated.PreviousClientSecretExpiresAt = current.PreviousClientSecretExpiresAt
+		return updated
+	}
+
+	expiresAt := now.Add(clientSecretRotationGracePeriod)
+	updated.PreviousClientSecret = current.ClientSecret
+	updated.PreviousClientSecretExpiresAt = &expiresAt
+	return updated
+}
+
+func validateEmailDomains(domains []string) error {
+	for _, d := range domains {
+		d = strings.TrimSpace(d)
+		if d == "" || strings.ContainsAny(d, "@/ ") {
+			return fmt.Errorf("email domain %q is invalid, expected a domain like gitpod.io", d)
+		}
+	}
+	return nil
+}
+
+// validateClaimMappings ensures mappings have a claim, a value and a known role.
+func validateClaimMappings(mappings []*v1.ClaimToTeamRoleMapping) error {
+	for i, m := range mappings {
+		if m.GetClaim() == "" || m.GetValue() == "" {
+			return fmt.Errorf("claim mapping %d: claim and value are required", i)
+		}
+		if _, ok := teamRoleToDb[m.GetRole()]; !ok {
+			return fmt.Errorf("claim mapping %d: role must be either owner or member", i)
+		}
+	}
+	return nil
+}
+
+var teamRoleToDb = map[v1.TeamRole]db.TeamMembershipRole{
+	v1.TeamRole_TEAM_ROLE_OWNER:  db.TeamMembershipRole_Owner,
+	v1.TeamRole_TEAM_ROLE_MEMBER: db.TeamMembershipRole_Member,
+}
+
+func toDbClaimMappings(mappings []*v1.ClaimToTeamRoleMapping) []db.OIDCClaimMapping {
+	var res []db.OIDCClaimMapping
+	for _, m := range mappings {
+		res = append(res, db.OIDCClaimMapping{
+			Claim: m.GetClaim(),
+			Value: m.GetValue(),
+			Role:  teamRoleToDb[m.GetRole()],
+		})
+	}
+	return res
+}
+
+func claimMappingsToAPI(mappings []db.OIDCClaimMapping) []*v1.ClaimToTeamRoleMapping {
+	var res []*v1.ClaimToTeamRoleMapping
+	for _, m := range mappings {
+		role := v1.TeamRole_TEAM_ROLE_UNSPECIFIED
+		switch m.Role {
+		case db.TeamMembershipRole_Owner:
+			role = v1.TeamRole_TEAM_ROLE_OWNER
+		case db.TeamMembershipRole_Member:
+			role = v1.TeamRole_TEAM_ROLE_MEMBER
+		}
+		res = append(res, &v1.ClaimToTeamRoleMapping{
+			Claim: m.Claim,
+			Value: m.Value,
+			Role:  role,
+		})
 	}
+	return res
 }
 
 func assertIssuerIsReachable(ctx context.Context, issuer string) error {
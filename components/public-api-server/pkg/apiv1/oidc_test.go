@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -27,8 +28,11 @@ import (
 	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
 	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/scim"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 var (
@@ -136,16 +140,53 @@ func TestOIDCService_CreateClientConfig_FeatureFlagEnabled(t *testing.T) {
 		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
 	})
 
+	t.Run("permission denied when user is not an owner of the organization", func(t *testing.T) {
+		serverMock, client, dbConn := setupOIDCService(t, withOIDCFeatureEnabled)
+		issuer := newFakeIdP(t, true)
+		createTeamMembership(t, dbConn, organizationID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.CreateClientConfig(context.Background(), connect.NewRequest(&v1.CreateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				OrganizationId: organizationID.String(),
+				OidcConfig:     &v1.OIDCConfig{Issuer: issuer},
+				Oauth2Config:   &v1.OAuth2Config{ClientId: "test-id", ClientSecret: "test-secret"},
+			},
+		}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("invalid argument when claim mapping has no role", func(t *testing.T) {
+		_, client, _ := setupOIDCService(t, withOIDCFeatureEnabled)
+		issuer := newFakeIdP(t, true)
+
+		_, err := client.CreateClientConfig(context.Background(), connect.NewRequest(&v1.CreateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				OrganizationId: organizationID.String(),
+				OidcConfig: &v1.OIDCConfig{
+					Issuer: issuer,
+					ClaimMappings: []*v1.ClaimToTeamRoleMapping{
+						{Claim: "groups", Value: "eng"},
+					},
+				},
+				Oauth2Config: &v1.OAuth2Config{ClientId: "test-id", ClientSecret: "test-secret"},
+			},
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
 	t.Run("creates oidc client config", func(t *testing.T) {
 		serverMock, client, dbConn := setupOIDCService(t, withOIDCFeatureEnabled)
 		issuer := newFakeIdP(t, true)
+		createTeamMembership(t, dbConn, organizationID, uuid.MustParse(user.ID), db.TeamMembershipRole_Owner)
 
 		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
 
 		config := &v1.OIDCClientConfig{
 			OrganizationId: organizationID.String(),
 			OidcConfig:     &v1.OIDCConfig{Issuer: issuer},
-			Active:         true,
+			Active:         proto.Bool(true),
 			Oauth2Config: &v1.OAuth2Config{
 				ClientId:     "test-id",
 				ClientSecret: "test-secret",
@@ -160,7 +201,7 @@ func TestOIDCService_CreateClientConfig_FeatureFlagEnabled(t *testing.T) {
 		requireEqualProto(t, &v1.CreateClientConfigResponse{
 			Config: &v1.OIDCClientConfig{
 				Id:             response.Msg.Config.Id,
-				Active:         true,
+				Active:         proto.Bool(true),
 				OrganizationId: response.Msg.Config.OrganizationId,
 				Oauth2Config: &v1.OAuth2Config{
 					ClientId:     config.Oauth2Config.ClientId,
@@ -341,26 +382,282 @@ func TestOIDCService_ListClientConfigs_WithFeatureFlagEnabled(t *testing.T) {
 
 }
 
-func TestOIDCService_UpdateClientConfig(t *testing.T) {
+func TestOIDCService_UpdateClientConfig_WithFeatureFlagDisabled(t *testing.T) {
 	t.Run("feature flag disabled returns unauthorized", func(t *testing.T) {
 		serverMock, client, _ := setupOIDCService(t, withOIDCFeatureDisabled)
 
 		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
 		serverMock.EXPECT().GetTeams(gomock.Any()).Return(teams, nil)
 
-		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{}))
+		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				Id:             uuid.NewString(),
+				OrganizationId: uuid.NewString(),
+			},
+		}))
 		require.Error(t, err)
 		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
 	})
+}
 
-	t.Run("feature flag enabled returns unimplemented", func(t *testing.T) {
-		serverMock, client, _ := setupOIDCService(t, withOIDCFeatureEnabled)
+func TestOIDCService_UpdateClientConfig_WithFeatureFlagEnabled(t *testing.T) {
+	t.Run("invalid argument when IDs not specified", func(t *testing.T) {
+		_, client, _ := setupOIDCService(t, withOIDCFeatureEnabled)
+
+		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{OrganizationId: uuid.NewString()},
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("invalid argument when email domain is invalid", func(t *testing.T) {
+		_, client, _ := setupOIDCService(t, withOIDCFeatureEnabled)
+
+		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				Id:             uuid.NewString(),
+				OrganizationId: uuid.NewString(),
+				OidcConfig:     &v1.OIDCConfig{EmailDomains: []string{"foo@gitpod.io"}},
+			},
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("permission denied when user is not an owner of the organization", func(t *testing.T) {
+		serverMock, client, dbConn := setupOIDCService(t, withOIDCFeatureEnabled)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
 
 		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
 
-		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{}))
-		require.Error(t, err)
-		require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				Id:             uuid.NewString(),
+				OrganizationId: orgID.String(),
+			},
+		}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("not found when record does not exist", func(t *testing.T) {
+		serverMock, client, dbConn := setupOIDCService(t, withOIDCFeatureEnabled)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Owner)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				Id:             uuid.NewString(),
+				OrganizationId: orgID.String(),
+			},
+		}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("updates config and rotates client secret", func(t *testing.T) {
+		serverMock, client, dbConn := setupOIDCService(t, withOIDCFeatureEnabled)
+		issuer := newFakeIdP(t, true)
+
+		data, err := db.EncryptJSON(dbtest.CipherSet(t), db.OIDCSpec{
+			ClientID:     "client-id",
+			ClientSecret: "old-secret",
+			Scopes:       []string{"openid", "profile", "email"},
+		})
+		require.NoError(t, err)
+		created := dbtest.CreateOIDCClientConfigs(t, dbConn, db.OIDCClientConfig{
+			OrganizationID: uuid.New(),
+			Issuer:         issuer,
+			Data:           data,
+		})[0]
+		createTeamMembership(t, dbConn, created.OrganizationID, uuid.MustParse(user.ID), db.TeamMembershipRole_Owner)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil).Times(2)
+
+		resp, err := client.GetClientConfig(context.Background(), connect.NewRequest(&v1.GetClientConfigRequest{
+			Id:             created.ID.String(),
+			OrganizationId: created.OrganizationID.String(),
+		}))
+		require.NoError(t, err)
+
+		config := resp.Msg.GetConfig()
+		config.Oauth2Config.ClientSecret = "new-secret"
+		config.OidcConfig.EmailDomains = []string{"Gitpod.io"}
+		config.Active = proto.Bool(true)
+
+		updated, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: config,
+		}))
+		require.NoError(t, err)
+		require.True(t, updated.Msg.GetConfig().GetActive())
+		require.Equal(t, []string{"gitpod.io"}, updated.Msg.GetConfig().GetOidcConfig().GetEmailDomains())
+
+		stored, err := db.GetOIDCClientConfig(context.Background(), dbConn, created.ID)
+		require.NoError(t, err)
+		spec, err := stored.Data.Decrypt(dbtest.CipherSet(t))
+		require.NoError(t, err)
+		require.Equal(t, "client-id", spec.ClientID)
+		require.Equal(t, "new-secret", spec.ClientSecret)
+		require.Equal(t, "old-secret", spec.PreviousClientSecret)
+		require.NotNil(t, spec.PreviousClientSecretExpiresAt)
+		require.Equal(t, []string{"openid", "profile", "email"}, spec.Scopes, "default scopes are not duplicated")
+	})
+
+	existingSpec := db.OIDCSpec{
+		ClientID:      "client-id",
+		ClientSecret:  "old-secret",
+		RedirectURL:   "https://idp.example.com/authorize",
+		Scopes:        []string{"openid", "profile", "email", "groups"},
+		ClaimMappings: []db.OIDCClaimMapping{{Claim: "groups", Value: "admins", Role: db.TeamMembershipRole_Owner}},
+		EmailDomains:  []string{"gitpod.io"},
+	}
+	createExisting := func(t *testing.T, dbConn *gorm.DB, issuer string) db.OIDCClientConfig {
+		data, err := db.EncryptJSON(dbtest.CipherSet(t), existingSpec)
+		require.NoError(t, err)
+		created := dbtest.CreateOIDCClientConfigs(t, dbConn, db.OIDCClientConfig{
+			OrganizationID: uuid.New(),
+			Issuer:         issuer,
+			Data:           data,
+			Active:         true,
+		})[0]
+		createTeamMembership(t, dbConn, created.OrganizationID, uuid.MustParse(user.ID), db.TeamMembershipRole_Owner)
+		return created
+	}
+
+	t.Run("updates only the issuer", func(t *testing.T) {
+		serverMock, client, dbConn := setupOIDCService(t, withOIDCFeatureEnabled)
+		created := createExisting(t, dbConn, newFakeIdP(t, true))
+		newIssuer := newFakeIdP(t, true)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				Id:             created.ID.String(),
+				OrganizationId: created.OrganizationID.String(),
+				OidcConfig:     &v1.OIDCConfig{Issuer: newIssuer},
+			},
+		}))
+		require.NoError(t, err)
+
+		stored, err := db.GetOIDCClientConfig(context.Background(), dbConn, created.ID)
+		require.NoError(t, err)
+		require.Equal(t, newIssuer, stored.Issuer)
+		require.True(t, stored.Active, "active is kept when not set")
+		spec, err := stored.Data.Decrypt(dbtest.CipherSet(t))
+		require.NoError(t, err)
+		require.Equal(t, existingSpec, spec)
+	})
+
+	t.Run("updates only the client secret", func(t *testing.T) {
+		serverMock, client, dbConn := setupOIDCService(t, withOIDCFeatureEnabled)
+		issuer := newFakeIdP(t, true)
+		created := createExisting(t, dbConn, issuer)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				Id:             created.ID.String(),
+				OrganizationId: created.OrganizationID.String(),
+				Oauth2Config:   &v1.OAuth2Config{ClientSecret: "new-secret"},
+			},
+		}))
+		require.NoError(t, err)
+
+		stored, err := db.GetOIDCClientConfig(context.Background(), dbConn, created.ID)
+		require.NoError(t, err)
+		require.Equal(t, issuer, stored.Issuer)
+		require.True(t, stored.Active, "active is kept when not set")
+		spec, err := stored.Data.Decrypt(dbtest.CipherSet(t))
+		require.NoError(t, err)
+		require.Equal(t, "new-secret", spec.ClientSecret)
+		require.Equal(t, "old-secret", spec.PreviousClientSecret)
+
+		spec.ClientSecret, spec.PreviousClientSecret, spec.PreviousClientSecretExpiresAt = existingSpec.ClientSecret, "", nil
+		require.Equal(t, existingSpec, spec, "all other fields are unchanged")
+	})
+
+	t.Run("clears claim mappings and email domains in the update mask", func(t *testing.T) {
+		serverMock, client, dbConn := setupOIDCService(t, withOIDCFeatureEnabled)
+		created := createExisting(t, dbConn, newFakeIdP(t, true))
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		updated, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				Id:             created.ID.String(),
+				OrganizationId: created.OrganizationID.String(),
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"oidc_config.claim_mappings", "oidc_config.email_domains"}},
+		}))
+		require.NoError(t, err)
+		require.Empty(t, updated.Msg.GetConfig().GetOidcConfig().GetClaimMappings())
+		require.Empty(t, updated.Msg.GetConfig().GetOidcConfig().GetEmailDomains())
+
+		stored, err := db.GetOIDCClientConfig(context.Background(), dbConn, created.ID)
+		require.NoError(t, err)
+		spec, err := stored.Data.Decrypt(dbtest.CipherSet(t))
+		require.NoError(t, err)
+		require.Empty(t, spec.ClaimMappings)
+		require.Empty(t, spec.EmailDomains)
+
+		spec.ClaimMappings, spec.EmailDomains = existingSpec.ClaimMappings, existingSpec.EmailDomains
+		require.Equal(t, existingSpec, spec, "all other fields are unchanged")
+	})
+
+	t.Run("invalid argument when update mask contains a field which can not be cleared", func(t *testing.T) {
+		_, client, _ := setupOIDCService(t, withOIDCFeatureEnabled)
+
+		_, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				Id:             uuid.NewString(),
+				OrganizationId: uuid.NewString(),
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"oidc_config.issuer"}},
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("deactivates config when active is set to false", func(t *testing.T) {
+		serverMock, client, dbConn := setupOIDCService(t, withOIDCFeatureEnabled)
+		created := createExisting(t, dbConn, newFakeIdP(t, true))
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		updated, err := client.UpdateClientConfig(context.Background(), connect.NewRequest(&v1.UpdateClientConfigRequest{
+			Config: &v1.OIDCClientConfig{
+				Id:             created.ID.String(),
+				OrganizationId: created.OrganizationID.String(),
+				Active:         proto.Bool(false),
+			},
+		}))
+		require.NoError(t, err)
+		require.False(t, updated.Msg.GetConfig().GetActive())
+	})
+}
+
+func TestRotateClientSecret(t *testing.T) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(-time.Hour)
+	current := db.OIDCSpec{
+		ClientSecret:                  "current",
+		PreviousClientSecret:          "previous",
+		PreviousClientSecretExpiresAt: &expiresAt,
+	}
+
+	for _, secret := range []string{"", redactedClientSecret, "current"} {
+		t.Run("keeps current secret for "+secret, func(t *testing.T) {
+			act := rotateClientSecret(current, db.OIDCSpec{ClientSecret: secret}, now)
+			require.Equal(t, current, act)
+		})
+	}
+
+	t.Run("rotates new secret", func(t *testing.T) {
+		act := rotateClientSecret(current, db.OIDCSpec{ClientSecret: "new"}, now)
+		require.Equal(t, "new", act.ClientSecret)
+		require.Equal(t, "current", act.PreviousClientSecret)
+		require.Equal(t, now.Add(clientSecretRotationGracePeriod), *act.PreviousClientSecretExpiresAt)
 	})
 }
 
@@ -401,13 +698,15 @@ func TestOIDCService_DeleteClientConfig_WithFeatureFlagEnabled(t *testing.T) {
 	})
 
 	t.Run("not found when record does not exist", func(t *testing.T) {
-		serverMock, client, _ := setupOIDCService(t, withOIDCFeatureEnabled)
+		serverMock, client, dbConn := setupOIDCService(t, withOIDCFeatureEnabled)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Owner)
 
 		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
 
 		_, err := client.DeleteClientConfig(context.Background(), connect.NewRequest(&v1.DeleteClientConfigRequest{
 			Id:             uuid.NewString(),
-			OrganizationId: uuid.NewString(),
+			OrganizationId: orgID.String(),
 		}))
 		require.Error(t, err)
 		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
@@ -418,6 +717,7 @@ func TestOIDCService_DeleteClientConfig_WithFeatureFlagEnabled(t *testing.T) {
 		issuer := newFakeIdP(t, true)
 
 		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Owner)
 
 		created := dbtest.CreateOIDCClientConfigs(t, dbConn, db.OIDCClientConfig{
 			OrganizationID: orgID,
@@ -435,6 +735,123 @@ func TestOIDCService_DeleteClientConfig_WithFeatureFlagEnabled(t *testing.T) {
 	})
 }
 
+func TestOIDCService_SCIMToken_WithFeatureFlagEnabled(t *testing.T) {
+	t.Run("invalid argument when Organization ID not specified", func(t *testing.T) {
+		_, client, _ := setupOIDCService(t, withOIDCFeatureEnabled)
+
+		_, err := client.CreateSCIMToken(context.Background(), connect.NewRequest(&v1.CreateSCIMTokenRequest{}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("permission denied when user is not an owner of the organization", func(t *testing.T) {
+		serverMock, client, dbConn := setupOIDCService(t, withOIDCFeatureEnabled)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil).Times(2)
+
+		_, err := client.CreateSCIMToken(context.Background(), connect.NewRequest(&v1.CreateSCIMTokenRequest{
+			OrganizationId: orgID.String(),
+		}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		_, err = client.DeleteSCIMToken(context.Background(), connect.NewRequest(&v1.DeleteSCIMTokenRequest{
+			OrganizationId: orgID.String(),
+		}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("creates, rotates and deletes token", func(t *testing.T) {
+		serverMock, client, dbConn := setupOIDCService(t, withOIDCFeatureEnabled)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Owner)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil).Times(4)
+
+		first, err := client.CreateSCIMToken(context.Background(), connect.NewRequest(&v1.CreateSCIMTokenRequest{
+			OrganizationId: orgID.String(),
+		}))
+		require.NoError(t, err)
+		require.NotEmpty(t, first.Msg.GetToken())
+
+		second, err := client.CreateSCIMToken(context.Background(), connect.NewRequest(&v1.CreateSCIMTokenRequest{
+			OrganizationId: orgID.String(),
+		}))
+		require.NoError(t, err)
+		require.NotEqual(t, first.Msg.GetToken(), second.Msg.GetToken())
+
+		_, err = db.GetSCIMTokenByHash(context.Background(), dbConn, scim.HashToken(first.Msg.GetToken()))
+		require.ErrorIs(t, err, db.ErrorNotFound, "creating a token revokes the previous one")
+		stored, err := db.GetSCIMTokenByHash(context.Background(), dbConn, scim.HashToken(second.Msg.GetToken()))
+		require.NoError(t, err)
+		require.Equal(t, orgID, stored.OrganizationID)
+
+		resp, err := client.DeleteSCIMToken(context.Background(), connect.NewRequest(&v1.DeleteSCIMTokenRequest{
+			OrganizationId: orgID.String(),
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.DeleteSCIMTokenResponse{}, resp.Msg)
+
+		_, err = client.DeleteSCIMToken(context.Background(), connect.NewRequest(&v1.DeleteSCIMTokenRequest{
+			OrganizationId: orgID.String(),
+		}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
+
+func TestValidateClaimMappings(t *testing.T) {
+	for _, test := range []struct {
+		Name     string
+		Mappings []*v1.ClaimToTeamRoleMapping
+		Valid    bool
+	}{
+		{Name: "no mappings", Valid: true},
+		{
+			Name: "valid mappings",
+			Mappings: []*v1.ClaimToTeamRoleMapping{
+				{Claim: "groups", Value: "eng-admins", Role: v1.TeamRole_TEAM_ROLE_OWNER},
+				{Claim: "groups", Value: "eng", Role: v1.TeamRole_TEAM_ROLE_MEMBER},
+			},
+			Valid: true,
+		},
+		{
+			Name:     "missing value",
+			Mappings: []*v1.ClaimToTeamRoleMapping{{Claim: "groups", Role: v1.TeamRole_TEAM_ROLE_OWNER}},
+		},
+		{
+			Name:     "unspecified role",
+			Mappings: []*v1.ClaimToTeamRoleMapping{{Claim: "groups", Value: "eng"}},
+		},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			err := validateClaimMappings(test.Mappings)
+			if test.Valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestClaimMappings_RoundTrip(t *testing.T) {
+	mappings := []*v1.ClaimToTeamRoleMapping{
+		{Claim: "groups", Value: "eng-admins", Role: v1.TeamRole_TEAM_ROLE_OWNER},
+		{Claim: "groups", Value: "eng", Role: v1.TeamRole_TEAM_ROLE_MEMBER},
+	}
+
+	dbMappings := toDbClaimMappings(mappings)
+	require.Equal(t, db.TeamMembershipRole_Owner, dbMappings[0].Role)
+	require.Equal(t, db.TeamMembershipRole_Member, dbMappings[1].Role)
+
+	act := claimMappingsToAPI(dbMappings)
+	require.Len(t, act, len(mappings))
+	for i := range mappings {
+		requireEqualProto(t, mappings[i], act[i])
+	}
+}
+
 func setupOIDCService(t *testing.T, expClient experiments.Client) (*protocol.MockAPIInterface, v1connect.OIDCServiceClient, *gorm.DB) {
 	t.Helper()
 
@@ -445,7 +862,7 @@ func setupOIDCService(t *testing.T, expClient experiments.Client) (*protocol.Moc
 
 	serverMock := protocol.NewMockAPIInterface(ctrl)
 
-	svc := NewOIDCService(&FakeServerConnPool{api: serverMock}, expClient, dbConn, dbtest.CipherSet(t))
+	svc := NewOIDCService(&FakeServerConnPool{api: serverMock}, expClient, dbConn, dbtest.CipherSet(t), db.NewAuditLogWriter(dbConn))
 
 	_, handler := v1connect.NewOIDCServiceHandler(svc, connect.WithInterceptors(auth.NewServerInterceptor()))
 
@@ -462,6 +879,22 @@ func setupOIDCService(t *testing.T, expClient experiments.Client) (*protocol.Moc
 	return serverMock, client, dbConn
 }
 
+func createTeamMembership(t *testing.T, dbConn *gorm.DB, teamID, userID uuid.UUID, role db.TeamMembershipRole) {
+	t.Helper()
+
+	membership, err := db.CreateTeamMembership(context.Background(), dbConn, db.TeamMembership{
+		ID:     uuid.New(),
+		TeamID: teamID,
+		UserID: userID,
+		Role:   role,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, dbConn.Delete(&membership).Error)
+	})
+}
+
 func newFakeIdP(t *testing.T, discoveryEnabled bool) string {
 	router := chi.NewRouter()
 	ts := httptest.NewServer(router)
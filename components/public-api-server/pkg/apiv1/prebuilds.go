@@ -0,0 +1,237 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	connect "github.com/bufbuild/connect-go"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	wsmanapi "github.com/gitpod-io/gitpod/ws-manager/api"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func NewPrebuildsService(pool proxy.ServerConnectionPool, dbConn *gorm.DB, scheduler wsmanapi.PrebuildSchedulerClient) *PrebuildsService {
+	return &PrebuildsService{
+		connectionPool: pool,
+		dbConn:         dbConn,
+		scheduler:      scheduler,
+	}
+}
+
+var _ v1connect.PrebuildsServiceHandler = (*PrebuildsService)(nil)
+
+// PrebuildsService exposes the prebuilds ws-manager-mk2 schedules to the users of a project.
+type PrebuildsService struct {
+	connectionPool proxy.ServerConnectionPool
+	dbConn         *gorm.DB
+	scheduler      wsmanapi.PrebuildSchedulerClient
+
+	v1connect.UnimplementedPrebuildsServiceHandler
+}
+
+func (s *PrebuildsService) GetPrebuild(ctx context.Context, req *connect.Request[v1.GetPrebuildRequest]) (*connect.Response[v1.GetPrebuildResponse], error) {
+	projectID, err := validateProjectID(ctx, req.Msg.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+
+	commit := strings.TrimSpace(req.Msg.GetCommit())
+	if commit == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("Commit is a required argument."))
+	}
+
+	err = s.assertCanAccessProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.scheduler.GetPrebuild(ctx, &wsmanapi.GetPrebuildRequest{
+		ProjectId: projectID.String(),
+		Commit:    commit,
+	})
+	if err != nil {
+		return nil, prebuildSchedulerError(ctx, err, fmt.Sprintf("No prebuild of commit %s of project %s exists.", commit, projectID.String()))
+	}
+
+	return connect.NewResponse(&v1.GetPrebuildResponse{
+		Prebuild: prebuildToAPIResponse(resp.GetPrebuild()),
+	}), nil
+}
+
+func (s *PrebuildsService) ListPrebuilds(ctx context.Context, req *connect.Request[v1.ListPrebuildsRequest]) (*connect.Response[v1.ListPrebuildsResponse], error) {
+	projectID, err := validateProjectID(ctx, req.Msg.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.assertCanAccessProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination := validatePagination(req.Msg.GetPagination())
+	resp, err := s.scheduler.ListPrebuilds(ctx, &wsmanapi.ListPrebuildsRequest{
+		ProjectId: projectID.String(),
+		Branch:    req.Msg.GetBranch(),
+		Phase:     prebuildPhaseFromAPI(req.Msg.GetPhase()),
+		PageSize:  pagination.GetPageSize(),
+		Page:      pagination.GetPage(),
+	})
+	if err != nil {
+		return nil, prebuildSchedulerError(ctx, err, "")
+	}
+
+	prebuilds := make([]*v1.Prebuild, 0, len(resp.GetPrebuilds()))
+	for _, pb := range resp.GetPrebuilds() {
+		prebuilds = append(prebuilds, prebuildToAPIResponse(pb))
+	}
+
+	return connect.NewResponse(&v1.ListPrebuildsResponse{
+		Prebuilds:    prebuilds,
+		TotalResults: resp.GetTotalResults(),
+	}), nil
+}
+
+func (s *PrebuildsService) CancelPrebuild(ctx context.Context, req *connect.Request[v1.CancelPrebuildRequest]) (*connect.Response[v1.CancelPrebuildResponse], error) {
+	projectID, err := validateProjectID(ctx, req.Msg.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+
+	commit := strings.TrimSpace(req.Msg.GetCommit())
+	if commit == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("Commit is a required argument."))
+	}
+
+	err = s.assertCanAccessProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.scheduler.CancelPrebuild(ctx, &wsmanapi.CancelPrebuildRequest{
+		ProjectId: projectID.String(),
+		Commit:    commit,
+	})
+	if err != nil {
+		return nil, prebuildSchedulerError(ctx, err, fmt.Sprintf("No running prebuild of commit %s of project %s exists.", commit, projectID.String()))
+	}
+
+	return connect.NewResponse(&v1.CancelPrebuildResponse{}), nil
+}
+
+// assertCanAccessProject verifies that the user is a member of the Organization the project belongs to, or owns the project.
+// Projects the user cannot access are reported as not found, such that their existence is not revealed.
+func (s *PrebuildsService) assertCanAccessProject(ctx context.Context, projectID uuid.UUID) error {
+	conn, err := getConnection(ctx, s.connectionPool)
+	if err != nil {
+		return err
+	}
+
+	user, err := conn.GetLoggedInUser(ctx)
+	if err != nil {
+		return proxy.ConvertError(err)
+	}
+	log.AddFields(ctx, log.UserID(user.ID))
+
+	userID, err := uuid.Parse(user.ID)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, errors.New("Failed to parse user ID as UUID. Please contact support."))
+	}
+
+	notFound := connect.NewError(connect.CodeNotFound, fmt.Errorf("Project with ID %s does not exist.", projectID.String()))
+
+	project, err := db.GetProject(ctx, s.dbConn, projectID)
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			return notFound
+		}
+		log.Extract(ctx).WithError(err).Error("Failed to retrieve project.")
+		return connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve project."))
+	}
+
+	if !project.TeamID.Valid {
+		if project.UserID.String != userID.String() {
+			return notFound
+		}
+		return nil
+	}
+
+	organizationID, err := uuid.Parse(project.TeamID.String)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to parse organization ID of project.")
+		return connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve project."))
+	}
+	_, err = db.GetTeamMembership(ctx, s.dbConn, userID, organizationID)
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			return notFound
+		}
+		log.Extract(ctx).WithError(err).Error("Failed to retrieve team membership.")
+		return connect.NewError(connect.CodeInternal, errors.New("Failed to verify permissions."))
+	}
+
+	return nil
+}
+
+// prebuildSchedulerError converts errors returned by ws-manager-mk2. notFoundMessage is returned to the user, if ws-manager-mk2 does not find the prebuild.
+func prebuildSchedulerError(ctx context.Context, err error, notFoundMessage string) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return connect.NewError(connect.CodeNotFound, errors.New(notFoundMessage))
+	case codes.Unimplemented, codes.Unavailable:
+		log.Extract(ctx).WithError(err).Warn("Prebuilds are not available.")
+		return connect.NewError(connect.CodeUnavailable, errors.New("Prebuilds are not available at the moment."))
+	default:
+		log.Extract(ctx).WithError(err).Error("Failed to call prebuild scheduler.")
+		return connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve prebuilds."))
+	}
+}
+
+func prebuildToAPIResponse(pb *wsmanapi.Prebuild) *v1.Prebuild {
+	return &v1.Prebuild{
+		ProjectId:           pb.GetProjectId(),
+		Branch:              pb.GetBranch(),
+		Commit:              pb.GetCommit(),
+		Phase:               prebuildPhaseToAPI(pb.GetPhase()),
+		WorkspaceInstanceId: pb.GetInstanceId(),
+		Attempts:            pb.GetAttempts(),
+		Error:               pb.GetError(),
+		CreatedAt:           pb.GetCreatedAt(),
+		FinishedAt:          pb.GetFinishedAt(),
+	}
+}
+
+var prebuildPhases = map[wsmanapi.PrebuildPhase]v1.PrebuildPhase{
+	wsmanapi.PrebuildPhase_PREBUILD_PHASE_QUEUED:    v1.PrebuildPhase_PREBUILD_PHASE_QUEUED,
+	wsmanapi.PrebuildPhase_PREBUILD_PHASE_RUNNING:   v1.PrebuildPhase_PREBUILD_PHASE_RUNNING,
+	wsmanapi.PrebuildPhase_PREBUILD_PHASE_AVAILABLE: v1.PrebuildPhase_PREBUILD_PHASE_AVAILABLE,
+	wsmanapi.PrebuildPhase_PREBUILD_PHASE_FAILED:    v1.PrebuildPhase_PREBUILD_PHASE_FAILED,
+	wsmanapi.PrebuildPhase_PREBUILD_PHASE_ABORTED:   v1.PrebuildPhase_PREBUILD_PHASE_ABORTED,
+	wsmanapi.PrebuildPhase_PREBUILD_PHASE_TIMEOUT:   v1.PrebuildPhase_PREBUILD_PHASE_TIMEOUT,
+}
+
+func prebuildPhaseToAPI(phase wsmanapi.PrebuildPhase) v1.PrebuildPhase {
+	return prebuildPhases[phase]
+}
+
+func prebuildPhaseFromAPI(phase v1.PrebuildPhase) wsmanapi.PrebuildPhase {
+	for wsmanPhase, apiPhase := range prebuildPhases {
+		if apiPhase == phase {
+			return wsmanPhase
+		}
+	}
+	return wsmanapi.PrebuildPhase_PREBUILD_PHASE_UNSPECIFIED
+}
@@ -0,0 +1,376 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	connect "github.com/bufbuild/connect-go"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
+	wsmanapi "github.com/gitpod-io/gitpod/ws-manager/api"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func TestPrebuildsService_GetPrebuild(t *testing.T) {
+	t.Run("invalid argument when project ID is not a UUID", func(t *testing.T) {
+		_, client, _, _ := setupPrebuildsService(t)
+
+		_, err := client.GetPrebuild(context.Background(), connect.NewRequest(&v1.GetPrebuildRequest{
+			ProjectId: "foo-bar",
+			Commit:    "abc",
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("invalid argument when commit is empty", func(t *testing.T) {
+		_, client, _, _ := setupPrebuildsService(t)
+
+		_, err := client.GetPrebuild(context.Background(), connect.NewRequest(&v1.GetPrebuildRequest{
+			ProjectId: uuid.NewString(),
+			Commit:    " ",
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("not found when project does not exist", func(t *testing.T) {
+		serverMock, client, _, scheduler := setupPrebuildsService(t)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.GetPrebuild(context.Background(), connect.NewRequest(&v1.GetPrebuildRequest{
+			ProjectId: uuid.NewString(),
+			Commit:    "abc",
+		}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+		require.Empty(t, scheduler.calls)
+	})
+
+	t.Run("not found when user is not a member of the project's organization", func(t *testing.T) {
+		serverMock, client, dbConn, scheduler := setupPrebuildsService(t)
+		project := createProjectForTest(t, dbConn, uuid.New())
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.GetPrebuild(context.Background(), connect.NewRequest(&v1.GetPrebuildRequest{
+			ProjectId: project.ID.String(),
+			Commit:    "abc",
+		}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+		require.Empty(t, scheduler.calls)
+	})
+
+	t.Run("not found when the prebuild does not exist", func(t *testing.T) {
+		serverMock, client, dbConn, scheduler := setupPrebuildsService(t)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		project := createProjectForTest(t, dbConn, orgID)
+		scheduler.err = status.Error(codes.NotFound, "prebuild not found")
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.GetPrebuild(context.Background(), connect.NewRequest(&v1.GetPrebuildRequest{
+			ProjectId: project.ID.String(),
+			Commit:    "abc",
+		}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("unavailable when ws-manager does not serve prebuilds", func(t *testing.T) {
+		serverMock, client, dbConn, scheduler := setupPrebuildsService(t)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		project := createProjectForTest(t, dbConn, orgID)
+		scheduler.err = status.Error(codes.Unimplemented, "prebuilds are disabled")
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.GetPrebuild(context.Background(), connect.NewRequest(&v1.GetPrebuildRequest{
+			ProjectId: project.ID.String(),
+			Commit:    "abc",
+		}))
+		require.Equal(t, connect.CodeUnavailable, connect.CodeOf(err))
+	})
+
+	t.Run("returns prebuild of a project of the user's organization", func(t *testing.T) {
+		serverMock, client, dbConn, scheduler := setupPrebuildsService(t)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		project := createProjectForTest(t, dbConn, orgID)
+		created := timestamppb.Now()
+		scheduler.prebuilds = []*wsmanapi.Prebuild{{
+			ProjectId:  project.ID.String(),
+			Branch:     "main",
+			Commit:     "abc",
+			Phase:      wsmanapi.PrebuildPhase_PREBUILD_PHASE_RUNNING,
+			InstanceId: "instance-id",
+			Attempts:   1,
+			CreatedAt:  created,
+		}}
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		resp, err := client.GetPrebuild(context.Background(), connect.NewRequest(&v1.GetPrebuildRequest{
+			ProjectId: project.ID.String(),
+			Commit:    " abc ",
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.GetPrebuildResponse{
+			Prebuild: &v1.Prebuild{
+				ProjectId:           project.ID.String(),
+				Branch:              "main",
+				Commit:              "abc",
+				Phase:               v1.PrebuildPhase_PREBUILD_PHASE_RUNNING,
+				WorkspaceInstanceId: "instance-id",
+				Attempts:            1,
+				CreatedAt:           created,
+			},
+		}, resp.Msg)
+		requireEqualProto(t, &wsmanapi.GetPrebuildRequest{
+			ProjectId: project.ID.String(),
+			Commit:    "abc",
+		}, scheduler.calls[0])
+	})
+
+	t.Run("returns prebuild of a personal project of the user", func(t *testing.T) {
+		serverMock, client, dbConn, scheduler := setupPrebuildsService(t)
+		project := db.Project{
+			ID:     uuid.New(),
+			Name:   "gitpod",
+			UserID: sql.NullString{String: user.ID, Valid: true},
+		}
+		require.NoError(t, dbConn.Create(&project).Error)
+		t.Cleanup(func() {
+			require.NoError(t, dbConn.Delete(&project).Error)
+		})
+		scheduler.prebuilds = []*wsmanapi.Prebuild{{ProjectId: project.ID.String(), Commit: "abc"}}
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.GetPrebuild(context.Background(), connect.NewRequest(&v1.GetPrebuildRequest{
+			ProjectId: project.ID.String(),
+			Commit:    "abc",
+		}))
+		require.NoError(t, err)
+	})
+}
+
+func TestPrebuildsService_ListPrebuilds(t *testing.T) {
+	t.Run("invalid argument when project ID is not a UUID", func(t *testing.T) {
+		_, client, _, _ := setupPrebuildsService(t)
+
+		_, err := client.ListPrebuilds(context.Background(), connect.NewRequest(&v1.ListPrebuildsRequest{
+			ProjectId: "foo-bar",
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("not found when user is not a member of the project's organization", func(t *testing.T) {
+		serverMock, client, dbConn, scheduler := setupPrebuildsService(t)
+		project := createProjectForTest(t, dbConn, uuid.New())
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.ListPrebuilds(context.Background(), connect.NewRequest(&v1.ListPrebuildsRequest{
+			ProjectId: project.ID.String(),
+		}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+		require.Empty(t, scheduler.calls)
+	})
+
+	t.Run("lists prebuilds with the requested page and phase", func(t *testing.T) {
+		serverMock, client, dbConn, scheduler := setupPrebuildsService(t)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		project := createProjectForTest(t, dbConn, orgID)
+		scheduler.prebuilds = []*wsmanapi.Prebuild{
+			{ProjectId: project.ID.String(), Commit: "abc", Phase: wsmanapi.PrebuildPhase_PREBUILD_PHASE_FAILED, Error: "exit code 1"},
+			{ProjectId: project.ID.String(), Commit: "def", Phase: wsmanapi.PrebuildPhase_PREBUILD_PHASE_FAILED, Error: "timeout"},
+		}
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		resp, err := client.ListPrebuilds(context.Background(), connect.NewRequest(&v1.ListPrebuildsRequest{
+			ProjectId:  project.ID.String(),
+			Branch:     "main",
+			Phase:      v1.PrebuildPhase_PREBUILD_PHASE_FAILED,
+			Pagination: &v1.Pagination{PageSize: 2, Page: 3},
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.ListPrebuildsResponse{
+			Prebuilds: []*v1.Prebuild{
+				{ProjectId: project.ID.String(), Commit: "abc", Phase: v1.PrebuildPhase_PREBUILD_PHASE_FAILED, Error: "exit code 1"},
+				{ProjectId: project.ID.String(), Commit: "def", Phase: v1.PrebuildPhase_PREBUILD_PHASE_FAILED, Error: "timeout"},
+			},
+			TotalResults: 2,
+		}, resp.Msg)
+		requireEqualProto(t, &wsmanapi.ListPrebuildsRequest{
+			ProjectId: project.ID.String(),
+			Branch:    "main",
+			Phase:     wsmanapi.PrebuildPhase_PREBUILD_PHASE_FAILED,
+			PageSize:  2,
+			Page:      3,
+		}, scheduler.calls[0])
+	})
+
+	t.Run("uses default pagination", func(t *testing.T) {
+		serverMock, client, dbConn, scheduler := setupPrebuildsService(t)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		project := createProjectForTest(t, dbConn, orgID)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.ListPrebuilds(context.Background(), connect.NewRequest(&v1.ListPrebuildsRequest{
+			ProjectId: project.ID.String(),
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &wsmanapi.ListPrebuildsRequest{
+			ProjectId: project.ID.String(),
+			PageSize:  25,
+			Page:      1,
+		}, scheduler.calls[0])
+	})
+}
+
+func TestPrebuildsService_CancelPrebuild(t *testing.T) {
+	t.Run("invalid argument when commit is empty", func(t *testing.T) {
+		_, client, _, _ := setupPrebuildsService(t)
+
+		_, err := client.CancelPrebuild(context.Background(), connect.NewRequest(&v1.CancelPrebuildRequest{
+			ProjectId: uuid.NewString(),
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("not found when user is not a member of the project's organization", func(t *testing.T) {
+		serverMock, client, dbConn, scheduler := setupPrebuildsService(t)
+		project := createProjectForTest(t, dbConn, uuid.New())
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.CancelPrebuild(context.Background(), connect.NewRequest(&v1.CancelPrebuildRequest{
+			ProjectId: project.ID.String(),
+			Commit:    "abc",
+		}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+		require.Empty(t, scheduler.calls)
+	})
+
+	t.Run("cancels prebuild of a project of the user's organization", func(t *testing.T) {
+		serverMock, client, dbConn, scheduler := setupPrebuildsService(t)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		project := createProjectForTest(t, dbConn, orgID)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.CancelPrebuild(context.Background(), connect.NewRequest(&v1.CancelPrebuildRequest{
+			ProjectId: project.ID.String(),
+			Commit:    "abc",
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &wsmanapi.CancelPrebuildRequest{
+			ProjectId: project.ID.String(),
+			Commit:    "abc",
+		}, scheduler.calls[0])
+	})
+}
+
+func setupPrebuildsService(t *testing.T) (*protocol.MockAPIInterface, v1connect.PrebuildsServiceClient, *gorm.DB, *fakePrebuildScheduler) {
+	t.Helper()
+
+	dbConn := dbtest.ConnectForTests(t)
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	serverMock := protocol.NewMockAPIInterface(ctrl)
+	scheduler := &fakePrebuildScheduler{}
+
+	svc := NewPrebuildsService(&FakeServerConnPool{api: serverMock}, dbConn, scheduler)
+
+	_, handler := v1connect.NewPrebuildsServiceHandler(svc, connect.WithInterceptors(auth.NewServerInterceptor()))
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := v1connect.NewPrebuildsServiceClient(http.DefaultClient, srv.URL, connect.WithInterceptors(
+		auth.NewClientInterceptor("auth-token"),
+	))
+
+	return serverMock, client, dbConn, scheduler
+}
+
+func createProjectForTest(t *testing.T, dbConn *gorm.DB, orgID uuid.UUID) db.Project {
+	t.Helper()
+
+	project := db.Project{
+		ID:     uuid.New(),
+		Name:   "gitpod",
+		TeamID: sql.NullString{String: orgID.String(), Valid: true},
+	}
+	require.NoError(t, dbConn.Create(&project).Error)
+	t.Cleanup(func() {
+		require.NoError(t, dbConn.Delete(&project).Error)
+	})
+
+	return project
+}
+
+// fakePrebuildScheduler records the requests it receives and answers them with its prebuilds, or err.
+type fakePrebuildScheduler struct {
+	prebuilds []*wsmanapi.Prebuild
+	err       error
+
+	calls []interface{}
+}
+
+func (f *fakePrebuildScheduler) GetPrebuild(ctx context.Context, in *wsmanapi.GetPrebuildRequest, opts ...grpc.CallOption) (*wsmanapi.GetPrebuildResponse, error) {
+	f.calls = append(f.calls, in)
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, pb := range f.prebuilds {
+		if pb.GetProjectId() == in.GetProjectId() && pb.GetCommit() == in.GetCommit() {
+			return &wsmanapi.GetPrebuildResponse{Prebuild: pb}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "prebuild not found")
+}
+
+func (f *fakePrebuildScheduler) ListPrebuilds(ctx context.Context, in *wsmanapi.ListPrebuildsRequest, opts ...grpc.CallOption) (*wsmanapi.ListPrebuildsResponse, error) {
+	f.calls = append(f.calls, in)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &wsmanapi.ListPrebuildsResponse{
+		Prebuilds:    f.prebuilds,
+		TotalResults: int32(len(f.prebuilds)),
+	}, nil
+}
+
+func (f *fakePrebuildScheduler) CancelPrebuild(ctx context.Context, in *wsmanapi.CancelPrebuildRequest, opts ...grpc.CallOption) (*wsmanapi.CancelPrebuildResponse, error) {
+	f.calls = append(f.calls, in)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &wsmanapi.CancelPrebuildResponse{}, nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func NewProjectsService(pool proxy.ServerConnectionPool) *ProjectsService {
@@ -91,6 +92,27 @@ func (s *ProjectsService) CreateProject(ctx context.Context, req *connect.Reques
 	}), nil
 }
 
+func (s *ProjectsService) GetProject(ctx context.Context, req *connect.Request[v1.GetProjectRequest]) (*connect.Response[v1.GetProjectResponse], error) {
+	projectID, err := validateProjectID(ctx, req.Msg.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := s.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.findProject(ctx, conn, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&v1.GetProjectResponse{
+		Project: projectToAPIResponse(project),
+	}), nil
+}
+
 func (s *ProjectsService) ListProjects(ctx context.Context, req *connect.Request[v1.ListProjectsRequest]) (*connect.Response[v1.ListProjectsResponse], error) {
 	userID, teamID := req.Msg.GetUserId(), req.Msg.GetTeamId()
 	if userID == "" && teamID == "" {
@@ -163,6 +185,146 @@ func (s *ProjectsService) DeleteProject(ctx context.Context, req *connect.Reques
 	return connect.NewResponse(&v1.DeleteProjectResponse{}), nil
 }
 
+func (s *ProjectsService) UpdateProject(ctx context.Context, req *connect.Request[v1.UpdateProjectRequest]) (*connect.Response[v1.UpdateProjectResponse], error) {
+	const (
+		incrementalPrebuildsField   = "settings.prebuild.enable_incremental_prebuilds"
+		keepOutdatedPrebuildsField  = "settings.prebuild.keep_outdated_prebuilds_running"
+		usePreviousPrebuildsField   = "settings.prebuild.use_previous_prebuilds"
+		prebuildEveryNthField       = "settings.prebuild.prebuild_every_nth"
+		persistentVolumeClaimField  = "settings.workspace.enable_persistent_volume_claim"
+		regularWorkspaceClassField  = "settings.workspace.workspace_class.regular"
+		prebuildWorkspaceClassField = "settings.workspace.workspace_class.prebuild"
+	)
+	var (
+		updatableMask = fieldmaskpb.FieldMask{Paths: []string{
+			incrementalPrebuildsField,
+			keepOutdatedPrebuildsField,
+			usePreviousPrebuildsField,
+			prebuildEveryNthField,
+			persistentVolumeClaimField,
+			regularWorkspaceClassField,
+			prebuildWorkspaceClassField,
+		}}
+	)
+
+	projectReq := req.Msg.GetProject()
+
+	projectID, err := validateProjectID(ctx, projectReq.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	mask, err := validateFieldMask(req.Msg.GetUpdateMask(), projectReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no mask fields are specified, we treat the request as updating all updatable fields
+	if len(mask.GetPaths()) == 0 {
+		mask = &updatableMask
+	}
+
+	conn, err := s.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.findProject(ctx, conn, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Server replaces the settings as a whole, we therefore apply the update to the current settings.
+	settings := &protocol.ProjectSettings{}
+	if project.Settings != nil {
+		*settings = *project.Settings
+	}
+	workspaceClasses := &protocol.WorkspaceClassesSettings{}
+	if settings.WorkspaceClasses != nil {
+		*workspaceClasses = *settings.WorkspaceClasses
+	}
+
+	update := projectReq.GetSettings()
+	toUpdate := fieldmaskpb.Intersect(mask, &updatableMask)
+	for _, path := range toUpdate.GetPaths() {
+		switch path {
+		case incrementalPrebuildsField:
+			settings.UseIncrementalPrebuilds = update.GetPrebuild().GetEnableIncrementalPrebuilds()
+		case keepOutdatedPrebuildsField:
+			settings.KeepOutdatedPrebuildsRunning = update.GetPrebuild().GetKeepOutdatedPrebuildsRunning()
+		case usePreviousPrebuildsField:
+			settings.AllowUsingPreviousPrebuilds = update.GetPrebuild().GetUsePreviousPrebuilds()
+		case prebuildEveryNthField:
+			everyNth := update.GetPrebuild().GetPrebuildEveryNth()
+			if everyNth < 0 {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Prebuild every nth commit must not be negative."))
+			}
+			settings.PrebuildEveryNthCommit = int(everyNth)
+		case persistentVolumeClaimField:
+			settings.UsePersistentVolumeClaim = update.GetWorkspace().GetEnablePersistentVolumeClaim()
+		case regularWorkspaceClassField:
+			workspaceClasses.Regular = strings.TrimSpace(update.GetWorkspace().GetWorkspaceClass().GetRegular())
+		case prebuildWorkspaceClassField:
+			workspaceClasses.Prebuild = strings.TrimSpace(update.GetWorkspace().GetWorkspaceClass().GetPrebuild())
+		}
+	}
+	settings.WorkspaceClasses = nil
+	if workspaceClasses.Regular != "" || workspaceClasses.Prebuild != "" {
+		settings.WorkspaceClasses = workspaceClasses
+	}
+
+	err = conn.UpdateProjectPartial(ctx, &protocol.PartialProject{
+		ID:       project.ID,
+		Settings: settings,
+	})
+	if err != nil {
+		return nil, proxy.ConvertError(err)
+	}
+
+	project.Settings = settings
+
+	return connect.NewResponse(&v1.UpdateProjectResponse{
+		Project: projectToAPIResponse(project),
+	}), nil
+}
+
+// findProject finds a project amongst the projects of the user and of the teams the user is a member of.
+// Server does not support retrieving a single project, which makes this expensive.
+func (s *ProjectsService) findProject(ctx context.Context, conn protocol.APIInterface, projectID uuid.UUID) (*protocol.Project, error) {
+	projects, err := conn.GetUserProjects(ctx)
+	if err != nil {
+		return nil, proxy.ConvertError(err)
+	}
+	if project := findProjectByID(projects, projectID); project != nil {
+		return project, nil
+	}
+
+	teams, err := conn.GetTeams(ctx)
+	if err != nil {
+		return nil, proxy.ConvertError(err)
+	}
+	for _, team := range teams {
+		projects, err := conn.GetTeamProjects(ctx, team.ID)
+		if err != nil {
+			return nil, proxy.ConvertError(err)
+		}
+		if project := findProjectByID(projects, projectID); project != nil {
+			return project, nil
+		}
+	}
+
+	return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Project with ID %s does not exist.", projectID.String()))
+}
+
+func findProjectByID(projects []*protocol.Project, projectID uuid.UUID) *protocol.Project {
+	for _, p := range projects {
+		if p.ID == projectID.String() {
+			return p
+		}
+	}
+	return nil
+}
+
 func (s *ProjectsService) getConnection(ctx context.Context) (protocol.APIInterface, error) {
 	token, err := auth.TokenFromContext(ctx)
 	if err != nil {
@@ -20,6 +20,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func TestProjectsService_CreateProject(t *testing.T) {
@@ -350,6 +351,181 @@ func TestProjectsService_DeleteProject(t *testing.T) {
 	})
 }
 
+func TestProjectsService_GetProject(t *testing.T) {
+	t.Run("invalid argument when project ID is not a valid uuid", func(t *testing.T) {
+		_, client := setupProjectsService(t)
+
+		_, err := client.GetProject(context.Background(), connect.NewRequest(&v1.GetProjectRequest{
+			ProjectId: "something",
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("returns project of user", func(t *testing.T) {
+		serverMock, client := setupProjectsService(t)
+
+		project := newProject(&protocol.Project{})
+		serverMock.EXPECT().GetUserProjects(gomock.Any()).Return([]*protocol.Project{project}, nil)
+
+		resp, err := client.GetProject(context.Background(), connect.NewRequest(&v1.GetProjectRequest{
+			ProjectId: project.ID,
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.GetProjectResponse{
+			Project: projectToAPIResponse(project),
+		}, resp.Msg)
+	})
+
+	t.Run("returns project of team", func(t *testing.T) {
+		serverMock, client := setupProjectsService(t)
+
+		teamID := uuid.New().String()
+		project := newProject(&protocol.Project{
+			TeamID: teamID,
+		})
+		serverMock.EXPECT().GetUserProjects(gomock.Any()).Return([]*protocol.Project{newProject(&protocol.Project{})}, nil)
+		serverMock.EXPECT().GetTeams(gomock.Any()).Return([]*protocol.Team{{ID: teamID}}, nil)
+		serverMock.EXPECT().GetTeamProjects(gomock.Any(), teamID).Return([]*protocol.Project{project}, nil)
+
+		resp, err := client.GetProject(context.Background(), connect.NewRequest(&v1.GetProjectRequest{
+			ProjectId: project.ID,
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.GetProjectResponse{
+			Project: projectToAPIResponse(project),
+		}, resp.Msg)
+	})
+
+	t.Run("not found when project does not exist", func(t *testing.T) {
+		serverMock, client := setupProjectsService(t)
+
+		serverMock.EXPECT().GetUserProjects(gomock.Any()).Return(nil, nil)
+		serverMock.EXPECT().GetTeams(gomock.Any()).Return(nil, nil)
+
+		_, err := client.GetProject(context.Background(), connect.NewRequest(&v1.GetProjectRequest{
+			ProjectId: uuid.New().String(),
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
+
+func TestProjectsService_UpdateProject(t *testing.T) {
+	t.Run("invalid argument when project ID is not a valid uuid", func(t *testing.T) {
+		_, client := setupProjectsService(t)
+
+		_, err := client.UpdateProject(context.Background(), connect.NewRequest(&v1.UpdateProjectRequest{
+			Project: &v1.Project{Id: "something"},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("invalid argument when update mask is invalid", func(t *testing.T) {
+		_, client := setupProjectsService(t)
+
+		_, err := client.UpdateProject(context.Background(), connect.NewRequest(&v1.UpdateProjectRequest{
+			Project:    &v1.Project{Id: uuid.New().String()},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"settings.unknown"}},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("updates only settings in update mask", func(t *testing.T) {
+		serverMock, client := setupProjectsService(t)
+
+		project := newProject(&protocol.Project{})
+		serverMock.EXPECT().GetUserProjects(gomock.Any()).Return([]*protocol.Project{project}, nil)
+
+		expectedSettings := *project.Settings
+		expectedSettings.PrebuildEveryNthCommit = 10
+		expectedSettings.UseIncrementalPrebuilds = false
+		expectedSettings.WorkspaceClasses = &protocol.WorkspaceClassesSettings{
+			Regular:  "large",
+			Prebuild: "default",
+		}
+		serverMock.EXPECT().UpdateProjectPartial(gomock.Any(), &protocol.PartialProject{
+			ID:       project.ID,
+			Settings: &expectedSettings,
+		}).Return(nil)
+
+		resp, err := client.UpdateProject(context.Background(), connect.NewRequest(&v1.UpdateProjectRequest{
+			Project: &v1.Project{
+				Id: project.ID,
+				Settings: &v1.ProjectSettings{
+					Prebuild: &v1.PrebuildSettings{
+						PrebuildEveryNth: 10,
+					},
+					Workspace: &v1.WorkspaceSettings{
+						WorkspaceClass: &v1.WorkspaceClassSettings{
+							Regular: "large",
+						},
+					},
+				},
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{
+				"settings.prebuild.prebuild_every_nth",
+				"settings.prebuild.enable_incremental_prebuilds",
+				"settings.workspace.workspace_class.regular",
+			}},
+		}))
+		require.NoError(t, err)
+
+		expected := *project
+		expected.Settings = &expectedSettings
+		requireEqualProto(t, &v1.UpdateProjectResponse{
+			Project: projectToAPIResponse(&expected),
+		}, resp.Msg)
+	})
+
+	t.Run("updates all settings when update mask is empty", func(t *testing.T) {
+		serverMock, client := setupProjectsService(t)
+
+		project := newProject(&protocol.Project{})
+		serverMock.EXPECT().GetUserProjects(gomock.Any()).Return([]*protocol.Project{project}, nil)
+		serverMock.EXPECT().UpdateProjectPartial(gomock.Any(), &protocol.PartialProject{
+			ID: project.ID,
+			Settings: &protocol.ProjectSettings{
+				PrebuildEveryNthCommit: 3,
+			},
+		}).Return(nil)
+
+		_, err := client.UpdateProject(context.Background(), connect.NewRequest(&v1.UpdateProjectRequest{
+			Project: &v1.Project{
+				Id: project.ID,
+				Settings: &v1.ProjectSettings{
+					Prebuild: &v1.PrebuildSettings{
+						PrebuildEveryNth: 3,
+					},
+				},
+			},
+		}))
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid argument when prebuild every nth is negative", func(t *testing.T) {
+		serverMock, client := setupProjectsService(t)
+
+		project := newProject(&protocol.Project{})
+		serverMock.EXPECT().GetUserProjects(gomock.Any()).Return([]*protocol.Project{project}, nil)
+
+		_, err := client.UpdateProject(context.Background(), connect.NewRequest(&v1.UpdateProjectRequest{
+			Project: &v1.Project{
+				Id: project.ID,
+				Settings: &v1.ProjectSettings{
+					Prebuild: &v1.PrebuildSettings{
+						PrebuildEveryNth: -1,
+					},
+				},
+			},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
 func setupProjectsService(t *testing.T) (*protocol.MockAPIInterface, v1connect.ProjectsServiceClient) {
 	t.Helper()
 
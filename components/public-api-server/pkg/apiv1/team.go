@@ -11,15 +11,17 @@ import (
 
 	connect "github.com/bufbuild/connect-go"
 	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
 	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
 	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
 )
 
-func NewTeamsService(pool proxy.ServerConnectionPool) *TeamService {
+func NewTeamsService(pool proxy.ServerConnectionPool, auditLog *db.AuditLogWriter) *TeamService {
 	return &TeamService{
 		connectionPool: pool,
+		auditLog:       auditLog,
 	}
 }
 
@@ -27,6 +29,7 @@ var _ v1connect.TeamsServiceHandler = (*TeamService)(nil)
 
 type TeamService struct {
 	connectionPool proxy.ServerConnectionPool
+	auditLog       *db.AuditLogWriter
 
 	v1connect.UnimplementedTeamsServiceHandler
 }
@@ -176,6 +179,10 @@ func (s *TeamService) JoinTeam(ctx context.Context, req *connect.Request[v1.Join
 		return nil, proxy.ConvertError(err)
 	}
 
+	recordAuditLog(ctx, s.auditLog, team.ID, auditLogActorID(ctx, conn), auditLogActionJoinTeam, map[string]any{
+		"invitationId": req.Msg.GetInvitationId(),
+	})
+
 	response, err := s.toTeamAPIResponse(ctx, conn, team)
 	if err != nil {
 		log.Extract(ctx).WithError(err).Error("Failed to populate team with details.")
@@ -239,6 +246,11 @@ func (s *TeamService) UpdateTeamMember(ctx context.Context, req *connect.Request
 		return nil, proxy.ConvertError(err)
 	}
 
+	recordAuditLog(ctx, s.auditLog, teamID, auditLogActorID(ctx, conn), auditLogActionUpdateTeamMember, map[string]any{
+		"userId": userID,
+		"role":   role,
+	})
+
 	return connect.NewResponse(&v1.UpdateTeamMemberResponse{
 		TeamMember: req.Msg.GetTeamMember(),
 	}), nil
@@ -266,6 +278,10 @@ func (s *TeamService) DeleteTeamMember(ctx context.Context, req *connect.Request
 		return nil, proxy.ConvertError(err)
 	}
 
+	recordAuditLog(ctx, s.auditLog, teamID, auditLogActorID(ctx, conn), auditLogActionDeleteTeamMember, map[string]any{
+		"userId": memberID,
+	})
+
 	return connect.NewResponse(&v1.DeleteTeamMemberResponse{}), nil
 }
 
@@ -6,11 +6,14 @@ package apiv1
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/bufbuild/connect-go"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
 	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
 	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
 	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
@@ -207,7 +210,9 @@ func TestTeamsService_JoinTeam(t *testing.T) {
 			ID: uuid.New().String(),
 		}
 
+		user := newUser(&protocol.User{})
 		serverMock.EXPECT().JoinTeam(gomock.Any(), inviteID).Return(team, nil)
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
 		serverMock.EXPECT().GetTeamMembers(gomock.Any(), teamID).Return(teamMembers, nil)
 		serverMock.EXPECT().GetGenericInvite(gomock.Any(), teamID).Return(invite, nil)
 
@@ -218,6 +223,7 @@ func TestTeamsService_JoinTeam(t *testing.T) {
 		requireEqualProto(t, &v1.JoinTeamResponse{
 			Team: teamToAPIResponse(team, teamMembers, invite),
 		}, response.Msg)
+		requireAuditLog(t, teamID, user.ID, auditLogActionJoinTeam)
 	})
 }
 
@@ -325,7 +331,9 @@ func TestTeamsService_UpdateTeamMember(t *testing.T) {
 	t.Run("proxies request to server", func(t *testing.T) {
 		serverMock, client := setupTeamService(t)
 
+		user := newUser(&protocol.User{})
 		serverMock.EXPECT().SetTeamMemberRole(gomock.Any(), teamID, teamMemberID, protocol.TeamMember_Owner).Return(nil)
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
 
 		response, err := client.UpdateTeamMember(context.Background(), connect.NewRequest(&v1.UpdateTeamMemberRequest{
 			TeamId: teamID,
@@ -341,6 +349,9 @@ func TestTeamsService_UpdateTeamMember(t *testing.T) {
 				Role:   v1.TeamRole_TEAM_ROLE_OWNER,
 			},
 		}, response.Msg)
+
+		entry := requireAuditLog(t, teamID, user.ID, auditLogActionUpdateTeamMember)
+		require.JSONEq(t, fmt.Sprintf(`{"userId":%q,"role":"owner"}`, teamMemberID), string(entry.Args))
 	})
 }
 
@@ -371,7 +382,9 @@ func TestTeamsService_DeleteTeamMember(t *testing.T) {
 	t.Run("proxies to server", func(t *testing.T) {
 		serverMock, client := setupTeamService(t)
 
+		user := newUser(&protocol.User{})
 		serverMock.EXPECT().RemoveTeamMember(gomock.Any(), teamID, teamMemberID).Return(nil)
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
 
 		response, err := client.DeleteTeamMember(context.Background(), connect.NewRequest(&v1.DeleteTeamMemberRequest{
 			TeamId:       teamID,
@@ -379,6 +392,7 @@ func TestTeamsService_DeleteTeamMember(t *testing.T) {
 		}))
 		require.NoError(t, err)
 		requireEqualProto(t, &v1.DeleteTeamMemberResponse{}, response.Msg)
+		requireAuditLog(t, teamID, user.ID, auditLogActionDeleteTeamMember)
 	})
 }
 
@@ -500,7 +514,7 @@ func setupTeamService(t *testing.T) (*protocol.MockAPIInterface, v1connect.Teams
 
 	svc := NewTeamsService(&FakeServerConnPool{
 		api: serverMock,
-	})
+	}, db.NewAuditLogWriter(dbtest.ConnectForTests(t)))
 
 	_, handler := v1connect.NewTeamsServiceHandler(svc, connect.WithInterceptors(auth.NewServerInterceptor()))
 
@@ -28,12 +28,13 @@ import (
 	"gorm.io/gorm"
 )
 
-func NewTokensService(connPool proxy.ServerConnectionPool, expClient experiments.Client, dbConn *gorm.DB, signer auth.Signer) *TokensService {
+func NewTokensService(connPool proxy.ServerConnectionPool, expClient experiments.Client, dbConn *gorm.DB, signer auth.Signer, auditLog *db.AuditLogWriter) *TokensService {
 	return &TokensService{
 		connectionPool: connPool,
 		expClient:      expClient,
 		dbConn:         dbConn,
 		signer:         signer,
+		auditLog:       auditLog,
 	}
 }
 
@@ -42,6 +43,7 @@ type TokensService struct {
 	expClient      experiments.Client
 	dbConn         *gorm.DB
 	signer         auth.Signer
+	auditLog       *db.AuditLogWriter
 
 	v1connect.UnimplementedTokensServiceHandler
 }
@@ -93,6 +95,13 @@ func (s *TokensService) CreatePersonalAccessToken(ctx context.Context, req *conn
 		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to store personal access token."))
 	}
 
+	recordUserAuditLog(ctx, s.auditLog, s.dbConn, userID, userID.String(), auditLogActionCreatePersonalAccessToken, map[string]any{
+		"id":             token.ID.String(),
+		"name":           token.Name,
+		"scopes":         token.Scopes,
+		"expirationTime": token.ExpirationTime,
+	})
+
 	return connect.NewResponse(&v1.CreatePersonalAccessTokenResponse{
 		Token: personalAccessTokenToAPI(token, pat.String()),
 	}), nil
@@ -185,6 +194,11 @@ func (s *TokensService) RegeneratePersonalAccessToken(ctx context.Context, req *
 		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to store personal access token."))
 	}
 
+	recordUserAuditLog(ctx, s.auditLog, s.dbConn, userID, userID.String(), auditLogActionRegeneratePersonalAccessToken, map[string]any{
+		"id":             token.ID.String(),
+		"expirationTime": token.ExpirationTime,
+	})
+
 	return connect.NewResponse(&v1.RegeneratePersonalAccessTokenResponse{
 		Token: personalAccessTokenToAPI(token, pat.String()),
 	}), nil
@@ -292,6 +306,10 @@ func (s *TokensService) DeletePersonalAccessToken(ctx context.Context, req *conn
 		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to delete personal access token."))
 	}
 
+	recordUserAuditLog(ctx, s.auditLog, s.dbConn, userID, userID.String(), auditLogActionDeletePersonalAccessToken, map[string]any{
+		"id": tokenID.String(),
+	})
+
 	return connect.NewResponse(&v1.DeletePersonalAccessTokenResponse{}), nil
 }
 
@@ -395,6 +413,7 @@ func validatePersonalAccessTokenName(name string) (string, error) {
 const (
 	allFunctionsScope    = "function:*"
 	defaultResourceScope = "resource:default"
+	usageFunctionScope   = "function:getUsageBalance"
 )
 
 func validateScopes(scopes []string) ([]string, error) {
@@ -402,16 +421,18 @@ func validateScopes(scopes []string) ([]string, error) {
 	// Therefore, for now we operate in one of the following modes:
 	// * Token has no scopes - represented as the empty list of scopes
 	// * Token explicitly has access to everything the user has access to, represented as ["function:*", "resource:default"]
+	// * Token has read access to the usage of organizations the user owns, represented as ["function:getUsageBalance", "resource:default"]
 	if len(scopes) == 0 {
 		return nil, nil
 	}
 
 	sort.Strings(scopes)
 	allScopesSorted := []string{allFunctionsScope, defaultResourceScope}
+	usageScopesSorted := []string{usageFunctionScope, defaultResourceScope}
 
-	if cmp.Equal(scopes, allScopesSorted) {
+	if cmp.Equal(scopes, allScopesSorted) || cmp.Equal(scopes, usageScopesSorted) {
 		return scopes, nil
 	}
 
-	return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Tokens can currently only have no scopes (empty), or all scopes represented as [%s, %s]", allFunctionsScope, defaultResourceScope))
+	return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Tokens can currently only have no scopes (empty), all scopes represented as [%s, %s], or usage scopes represented as [%s, %s]", allFunctionsScope, defaultResourceScope, usageFunctionScope, defaultResourceScope))
 }
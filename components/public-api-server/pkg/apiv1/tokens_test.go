@@ -796,6 +796,15 @@ func TestValidateScopes(t *testing.T) {
 			Name:            "all scopes (unsorted) are permitted",
 			RequestedScopes: []string{"resource:default", "function:*"},
 		},
+		{
+			Name:            "usage scopes are permitted",
+			RequestedScopes: []string{"resource:default", "function:getUsageBalance"},
+		},
+		{
+			Name:            "usage function scope with all function scope is rejected",
+			RequestedScopes: []string{"function:getUsageBalance", "function:*", "resource:default"},
+			Error:           true,
+		},
 		{
 			Name:            "only all function scope is not permitted",
 			RequestedScopes: []string{"function:*"},
@@ -840,7 +849,7 @@ func setupTokensService(t *testing.T, expClient experiments.Client) (*protocol.M
 
 	serverMock := protocol.NewMockAPIInterface(ctrl)
 
-	svc := NewTokensService(&FakeServerConnPool{api: serverMock}, expClient, dbConn, signer)
+	svc := NewTokensService(&FakeServerConnPool{api: serverMock}, expClient, dbConn, signer, db.NewAuditLogWriter(dbConn))
 
 	_, handler := v1connect.NewTokensServiceHandler(svc, connect.WithInterceptors(auth.NewServerInterceptor()))
 
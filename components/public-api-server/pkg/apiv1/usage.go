@@ -0,0 +1,369 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	connect "github.com/bufbuild/connect-go"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultUsageQueryRange = 30 * 24 * time.Hour
+	// maxUsageQueryRange bounds the amount of usage a single request has to scan
+	maxUsageQueryRange  = 300 * 24 * time.Hour
+	usageExportPageSize = 1000
+)
+
+func NewUsageService(pool proxy.ServerConnectionPool, dbConn *gorm.DB) *UsageService {
+	return &UsageService{
+		connectionPool: pool,
+		dbConn:         dbConn,
+	}
+}
+
+var _ v1connect.UsageServiceHandler = (*UsageService)(nil)
+
+type UsageService struct {
+	connectionPool proxy.ServerConnectionPool
+	dbConn         *gorm.DB
+
+	v1connect.UnimplementedUsageServiceHandler
+}
+
+func (s *UsageService) ListUsage(ctx context.Context, req *connect.Request[v1.ListUsageRequest]) (*connect.Response[v1.ListUsageResponse], error) {
+	filter, err := s.usageFilter(ctx, req.Msg.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := db.SummarizeUsageWithFilter(ctx, s.dbConn, filter)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to summarize usage.")
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve usage."))
+	}
+
+	records, err := db.ListUsageWithFilter(ctx, s.dbConn, filter, paginationToDB(req.Msg.GetPagination()))
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to list usage.")
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve usage."))
+	}
+
+	usage, err := s.usageToAPIResponses(ctx, records)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&v1.ListUsageResponse{
+		Usage:        usage,
+		TotalResults: int32(summary.NumberOfRecords),
+		CreditsUsed:  db.CreditCents(summary.CreditCentsUsed).ToCredits(),
+	}), nil
+}
+
+func (s *UsageService) AggregateUsage(ctx context.Context, req *connect.Request[v1.AggregateUsageRequest]) (*connect.Response[v1.AggregateUsageResponse], error) {
+	var dimension db.UsageDimension
+	switch req.Msg.GetDimension() {
+	case v1.UsageDimension_USAGE_DIMENSION_USER:
+		dimension = db.UsageDimensionUser
+	case v1.UsageDimension_USAGE_DIMENSION_PROJECT:
+		dimension = db.UsageDimensionProject
+	case v1.UsageDimension_USAGE_DIMENSION_WORKSPACE_CLASS:
+		dimension = db.UsageDimensionWorkspaceClass
+	case v1.UsageDimension_USAGE_DIMENSION_DAY:
+		dimension = db.UsageDimensionDay
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Dimension is a required argument."))
+	}
+
+	filter, err := s.usageFilter(ctx, req.Msg.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	aggregates, err := db.AggregateUsageWithFilter(ctx, s.dbConn, filter, dimension)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to aggregate usage.")
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve usage."))
+	}
+
+	// Credits are summed up in cents to avoid accumulating floating point errors
+	var total db.CreditCents
+	result := make([]*v1.UsageAggregate, 0, len(aggregates))
+	for _, agg := range aggregates {
+		total += agg.CreditCents
+		result = append(result, &v1.UsageAggregate{
+			Key:     agg.Key,
+			Credits: agg.CreditCents.ToCredits(),
+			Count:   int32(agg.Count),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Credits != result[j].Credits {
+			return result[i].Credits > result[j].Credits
+		}
+		return result[i].Key < result[j].Key
+	})
+
+	return connect.NewResponse(&v1.AggregateUsageResponse{
+		Aggregates:  result,
+		CreditsUsed: total.ToCredits(),
+	}), nil
+}
+
+func (s *UsageService) ExportUsage(ctx context.Context, req *connect.Request[v1.ExportUsageRequest], stream *connect.ServerStream[v1.ExportUsageResponse]) error {
+	filter, err := s.usageFilter(ctx, req.Msg.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	// Usage is exported one page at a time, such that neither the records nor the CSV are held in memory as a whole.
+	for page := 1; ; page++ {
+		records, err := db.ListUsageWithFilter(ctx, s.dbConn, filter, db.Pagination{Page: page, PageSize: usageExportPageSize})
+		if err != nil {
+			log.Extract(ctx).WithError(err).Error("Failed to list usage.")
+			return connect.NewError(connect.CodeInternal, errors.New("Failed to export usage."))
+		}
+
+		usage, err := s.usageToAPIResponses(ctx, records)
+		if err != nil {
+			return err
+		}
+
+		content, err := usageToCSV(usage, page == 1)
+		if err != nil {
+			log.Extract(ctx).WithError(err).Error("Failed to write usage as CSV.")
+			return connect.NewError(connect.CodeInternal, errors.New("Failed to export usage."))
+		}
+
+		if content != "" {
+			err = stream.Send(&v1.ExportUsageResponse{
+				Csv: content,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(records) < usageExportPageSize {
+			return nil
+		}
+	}
+}
+
+func (s *UsageService) GetBalance(ctx context.Context, req *connect.Request[v1.GetBalanceRequest]) (*connect.Response[v1.GetBalanceResponse], error) {
+	organizationID, err := validateOrganizationID(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := getConnection(ctx, s.connectionPool)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := conn.GetUsageBalance(ctx, string(db.NewTeamAttributionID(organizationID.String())))
+	if err != nil {
+		return nil, proxy.ConvertError(err)
+	}
+
+	return connect.NewResponse(&v1.GetBalanceResponse{
+		Credits: balance,
+	}), nil
+}
+
+// usageFilter validates the filter and verifies that the caller may access the usage of the organization.
+func (s *UsageService) usageFilter(ctx context.Context, filter *v1.UsageFilter) (db.UsageFilter, error) {
+	organizationID, err := validateOrganizationID(ctx, filter.GetOrganizationId())
+	if err != nil {
+		return db.UsageFilter{}, err
+	}
+
+	to := time.Now()
+	if filter.GetTo() != nil {
+		to = filter.GetTo().AsTime()
+	}
+	from := to.Add(-defaultUsageQueryRange)
+	if filter.GetFrom() != nil {
+		from = filter.GetFrom().AsTime()
+	}
+	if !from.Before(to) {
+		return db.UsageFilter{}, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("From must be before To."))
+	}
+	if to.Sub(from) > maxUsageQueryRange {
+		return db.UsageFilter{}, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Time range must not exceed %d days.", int(maxUsageQueryRange.Hours()/24)))
+	}
+
+	result := db.UsageFilter{
+		AttributionID:  db.NewTeamAttributionID(organizationID.String()),
+		From:           from,
+		To:             to,
+		WorkspaceClass: filter.GetWorkspaceClass(),
+	}
+	if filter.GetUserId() != "" {
+		result.UserID, err = validateUserID(ctx, filter.GetUserId())
+		if err != nil {
+			return db.UsageFilter{}, err
+		}
+	}
+	if filter.GetProjectId() != "" {
+		projectID, err := validateProjectID(ctx, filter.GetProjectId())
+		if err != nil {
+			return db.UsageFilter{}, err
+		}
+		result.ProjectID = projectID.String()
+	}
+
+	conn, err := getConnection(ctx, s.connectionPool)
+	if err != nil {
+		return db.UsageFilter{}, err
+	}
+
+	err = authorizeUsageAccess(ctx, conn, string(result.AttributionID))
+	if err != nil {
+		return db.UsageFilter{}, err
+	}
+
+	return result, nil
+}
+
+func (s *UsageService) usageToAPIResponses(ctx context.Context, records []db.Usage) ([]*v1.Usage, error) {
+	usage := make([]*v1.Usage, 0, len(records))
+	for _, record := range records {
+		usage = append(usage, usageToAPIResponse(record))
+	}
+
+	err := s.resolveProjects(ctx, usage)
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// resolveProjects sets the project of usage records, because usage metadata does not contain the project of a workspace.
+func (s *UsageService) resolveProjects(ctx context.Context, usage []*v1.Usage) error {
+	var workspaceIDs []string
+	seen := make(map[string]struct{})
+	for _, u := range usage {
+		if _, ok := seen[u.WorkspaceId]; ok || u.WorkspaceId == "" {
+			continue
+		}
+		seen[u.WorkspaceId] = struct{}{}
+		workspaceIDs = append(workspaceIDs, u.WorkspaceId)
+	}
+	if len(workspaceIDs) == 0 {
+		return nil
+	}
+
+	workspaces, err := db.ListWorkspacesByID(ctx, s.dbConn, workspaceIDs)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to list workspaces of usage.")
+		return connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve usage."))
+	}
+
+	projects := make(map[string]string, len(workspaces))
+	for _, ws := range workspaces {
+		if ws.ProjectID.Valid {
+			projects[ws.ID] = ws.ProjectID.String
+		}
+	}
+	for _, u := range usage {
+		u.ProjectId = projects[u.WorkspaceId]
+	}
+
+	return nil
+}
+
+// authorizeUsageAccess verifies that the caller may access the usage of the attribution ID.
+// Server only grants access to the cost center of an organization to its owners, and enforces
+// the function scopes of personal access tokens.
+func authorizeUsageAccess(ctx context.Context, conn protocol.APIInterface, attributionID string) error {
+	_, err := conn.GetUsageBalance(ctx, attributionID)
+	if err != nil {
+		return proxy.ConvertError(err)
+	}
+	return nil
+}
+
+func usageToAPIResponse(u db.Usage) *v1.Usage {
+	result := &v1.Usage{
+		Id:            u.ID.String(),
+		Description:   u.Description,
+		Credits:       u.CreditCents.ToCredits(),
+		EffectiveTime: db.VarcharTimeToTimestamppb(u.EffectiveTime),
+		Draft:         u.Draft,
+	}
+	_, result.OrganizationId = u.AttributionID.Values()
+	if u.WorkspaceInstanceID != nil {
+		result.WorkspaceInstanceId = u.WorkspaceInstanceID.String()
+	}
+
+	var metadata db.WorkspaceInstanceUsageData
+	if err := json.Unmarshal(u.Metadata, &metadata); err == nil {
+		result.WorkspaceId = metadata.WorkspaceId
+		result.WorkspaceClass = metadata.WorkspaceClass
+		result.WorkspaceType = string(metadata.WorkspaceType)
+		if metadata.UserID != uuid.Nil {
+			result.UserId = metadata.UserID.String()
+		}
+	}
+
+	return result
+}
+
+// usageToCSV writes one row per usage record, preceded by the header row if header is set.
+func usageToCSV(usage []*v1.Usage, header bool) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if header {
+		err := w.Write([]string{"id", "effective_time", "organization_id", "user_id", "project_id", "workspace_id", "workspace_instance_id", "workspace_class", "workspace_type", "description", "credits", "draft"})
+		if err != nil {
+			return "", err
+		}
+	}
+	for _, u := range usage {
+		err := w.Write([]string{
+			u.Id,
+			u.EffectiveTime.AsTime().UTC().Format(time.RFC3339),
+			u.OrganizationId,
+			u.UserId,
+			u.ProjectId,
+			u.WorkspaceId,
+			u.WorkspaceInstanceId,
+			u.WorkspaceClass,
+			u.WorkspaceType,
+			u.Description,
+			strconv.FormatFloat(u.Credits, 'f', -1, 64),
+			strconv.FormatBool(u.Draft),
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,337 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	connect "github.com/bufbuild/connect-go"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func TestUsageService_ListUsage(t *testing.T) {
+	orgID := uuid.New().String()
+	attributionID := string(db.NewTeamAttributionID(orgID))
+
+	t.Run("invalid argument when organization ID is not a valid uuid", func(t *testing.T) {
+		_, _, client := setupUsageService(t)
+
+		_, err := client.ListUsage(context.Background(), connect.NewRequest(&v1.ListUsageRequest{
+			Filter: &v1.UsageFilter{OrganizationId: "foo-bar"},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("invalid argument when from is after to", func(t *testing.T) {
+		_, _, client := setupUsageService(t)
+
+		_, err := client.ListUsage(context.Background(), connect.NewRequest(&v1.ListUsageRequest{
+			Filter: &v1.UsageFilter{
+				OrganizationId: orgID,
+				From:           timestamppb.New(time.Now()),
+				To:             timestamppb.New(time.Now().Add(-time.Hour)),
+			},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("invalid argument when time range exceeds maximum", func(t *testing.T) {
+		_, _, client := setupUsageService(t)
+
+		_, err := client.ListUsage(context.Background(), connect.NewRequest(&v1.ListUsageRequest{
+			Filter: &v1.UsageFilter{
+				OrganizationId: orgID,
+				From:           timestamppb.New(time.Now().Add(-301 * 24 * time.Hour)),
+			},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("permission denied when server denies access to usage of organization", func(t *testing.T) {
+		serverMock, _, client := setupUsageService(t)
+
+		serverMock.EXPECT().GetUsageBalance(gomock.Any(), attributionID).Return(float64(0), &jsonrpc2.Error{
+			Code:    403,
+			Message: "not allowed",
+		})
+
+		_, err := client.ListUsage(context.Background(), connect.NewRequest(&v1.ListUsageRequest{
+			Filter: &v1.UsageFilter{OrganizationId: orgID},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("lists usage of organization and applies filters", func(t *testing.T) {
+		userID := uuid.New()
+		projectID := uuid.New().String()
+
+		serverMock, dbConn, client := setupUsageService(t)
+
+		workspaces := dbtest.CreateWorkspaces(t, dbConn,
+			dbtest.NewWorkspace(t, db.Workspace{ProjectID: sql.NullString{String: projectID, Valid: true}}),
+			dbtest.NewWorkspace(t, db.Workspace{}),
+		)
+		now := time.Now().UTC().Truncate(time.Second)
+		usage := dbtest.CreateUsageRecords(t, dbConn,
+			newUsageRecord(t, attributionID, now, 1.5, db.WorkspaceInstanceUsageData{WorkspaceId: workspaces[0].ID, WorkspaceClass: "g1-standard", UserID: userID}),
+			newUsageRecord(t, attributionID, now.Add(-time.Hour), 2, db.WorkspaceInstanceUsageData{WorkspaceId: workspaces[1].ID, WorkspaceClass: "g1-large", UserID: userID}),
+			newUsageRecord(t, attributionID, now.Add(-2*time.Hour), 0.1, db.WorkspaceInstanceUsageData{WorkspaceId: workspaces[0].ID, WorkspaceClass: "g1-large", UserID: uuid.New()}),
+			// usage of other organizations is not returned
+			newUsageRecord(t, string(db.NewTeamAttributionID(uuid.New().String())), now, 5, db.WorkspaceInstanceUsageData{WorkspaceId: workspaces[0].ID, WorkspaceClass: "g1-large", UserID: userID}),
+		)
+
+		serverMock.EXPECT().GetUsageBalance(gomock.Any(), attributionID).Return(float64(3.6), nil).Times(4)
+
+		all, err := client.ListUsage(context.Background(), connect.NewRequest(&v1.ListUsageRequest{
+			Filter: &v1.UsageFilter{OrganizationId: orgID},
+		}))
+		require.NoError(t, err)
+		require.Len(t, all.Msg.Usage, 3)
+		require.EqualValues(t, 3, all.Msg.TotalResults)
+		require.Equal(t, 3.6, all.Msg.CreditsUsed)
+		require.Equal(t, projectID, all.Msg.Usage[0].ProjectId)
+		require.Equal(t, "", all.Msg.Usage[1].ProjectId)
+		require.Equal(t, orgID, all.Msg.Usage[0].OrganizationId)
+		require.Equal(t, userID.String(), all.Msg.Usage[0].UserId)
+
+		byUser, err := client.ListUsage(context.Background(), connect.NewRequest(&v1.ListUsageRequest{
+			Filter: &v1.UsageFilter{OrganizationId: orgID, UserId: userID.String()},
+		}))
+		require.NoError(t, err)
+		require.Len(t, byUser.Msg.Usage, 2)
+		require.Equal(t, 3.5, byUser.Msg.CreditsUsed)
+
+		byProject, err := client.ListUsage(context.Background(), connect.NewRequest(&v1.ListUsageRequest{
+			Filter: &v1.UsageFilter{OrganizationId: orgID, ProjectId: projectID},
+		}))
+		require.NoError(t, err)
+		require.Len(t, byProject.Msg.Usage, 2)
+		require.Equal(t, 1.6, byProject.Msg.CreditsUsed)
+
+		byClass, err := client.ListUsage(context.Background(), connect.NewRequest(&v1.ListUsageRequest{
+			Filter:     &v1.UsageFilter{OrganizationId: orgID, WorkspaceClass: "g1-large"},
+			Pagination: &v1.Pagination{PageSize: 1, Page: 2},
+		}))
+		require.NoError(t, err)
+		require.Len(t, byClass.Msg.Usage, 1)
+		require.EqualValues(t, 2, byClass.Msg.TotalResults)
+		require.Equal(t, usage[2].ID.String(), byClass.Msg.Usage[0].Id)
+	})
+}
+
+func TestUsageService_AggregateUsage(t *testing.T) {
+	orgID := uuid.New().String()
+	attributionID := string(db.NewTeamAttributionID(orgID))
+
+	t.Run("invalid argument when dimension is not specified", func(t *testing.T) {
+		_, _, client := setupUsageService(t)
+
+		_, err := client.AggregateUsage(context.Background(), connect.NewRequest(&v1.AggregateUsageRequest{
+			Filter: &v1.UsageFilter{OrganizationId: orgID},
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("aggregates usage by dimension", func(t *testing.T) {
+		alice, bob := uuid.New(), uuid.New()
+		now := time.Now().UTC().Truncate(time.Second)
+
+		serverMock, dbConn, client := setupUsageService(t)
+		dbtest.CreateUsageRecords(t, dbConn,
+			newUsageRecord(t, attributionID, now, 1, db.WorkspaceInstanceUsageData{WorkspaceClass: "g1-standard", UserID: alice}),
+			newUsageRecord(t, attributionID, now, 2.5, db.WorkspaceInstanceUsageData{WorkspaceClass: "g1-large", UserID: bob}),
+			newUsageRecord(t, attributionID, now, 0.7, db.WorkspaceInstanceUsageData{WorkspaceClass: "g1-standard", UserID: alice}),
+		)
+
+		serverMock.EXPECT().GetUsageBalance(gomock.Any(), attributionID).Return(float64(4.2), nil).Times(2)
+
+		byUser, err := client.AggregateUsage(context.Background(), connect.NewRequest(&v1.AggregateUsageRequest{
+			Filter:    &v1.UsageFilter{OrganizationId: orgID},
+			Dimension: v1.UsageDimension_USAGE_DIMENSION_USER,
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.AggregateUsageResponse{
+			Aggregates: []*v1.UsageAggregate{
+				{Key: bob.String(), Credits: 2.5, Count: 1},
+				{Key: alice.String(), Credits: 1.7, Count: 2},
+			},
+			CreditsUsed: 4.2,
+		}, byUser.Msg)
+
+		byClass, err := client.AggregateUsage(context.Background(), connect.NewRequest(&v1.AggregateUsageRequest{
+			Filter:    &v1.UsageFilter{OrganizationId: orgID},
+			Dimension: v1.UsageDimension_USAGE_DIMENSION_WORKSPACE_CLASS,
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.AggregateUsageResponse{
+			Aggregates: []*v1.UsageAggregate{
+				{Key: "g1-large", Credits: 2.5, Count: 1},
+				{Key: "g1-standard", Credits: 1.7, Count: 2},
+			},
+			CreditsUsed: 4.2,
+		}, byClass.Msg)
+	})
+}
+
+func TestUsageService_ExportUsage(t *testing.T) {
+	orgID := uuid.New().String()
+	attributionID := string(db.NewTeamAttributionID(orgID))
+
+	t.Run("exports usage as CSV", func(t *testing.T) {
+		userID := uuid.New()
+		effectiveTime := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+		serverMock, dbConn, client := setupUsageService(t)
+		record := newUsageRecord(t, attributionID, effectiveTime, 1.25, db.WorkspaceInstanceUsageData{
+			WorkspaceId:    "gitpodio-gitpod-abc",
+			WorkspaceType:  db.WorkspaceType_Regular,
+			WorkspaceClass: "g1-standard",
+			UserID:         userID,
+		})
+		record.Description = "Usage of g1-standard, \"quoted\""
+		dbtest.CreateUsageRecords(t, dbConn, record)
+
+		serverMock.EXPECT().GetUsageBalance(gomock.Any(), attributionID).Return(float64(1.25), nil)
+
+		chunks := exportUsage(t, client, &v1.UsageFilter{
+			OrganizationId: orgID,
+			From:           timestamppb.New(effectiveTime.Add(-time.Hour)),
+			To:             timestamppb.New(effectiveTime.Add(time.Hour)),
+		})
+		require.Equal(t, []string{"id,effective_time,organization_id,user_id,project_id,workspace_id,workspace_instance_id,workspace_class,workspace_type,description,credits,draft\n" +
+			record.ID.String() + ",2023-03-01T10:00:00Z," + orgID + "," + userID.String() + ",,gitpodio-gitpod-abc," + record.WorkspaceInstanceID.String() + ",g1-standard,regular,\"Usage of g1-standard, \"\"quoted\"\"\",1.25,false\n"}, chunks)
+	})
+
+	t.Run("streams one chunk per page", func(t *testing.T) {
+		orgID := uuid.New().String()
+		attributionID := string(db.NewTeamAttributionID(orgID))
+		effectiveTime := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+		serverMock, dbConn, client := setupUsageService(t)
+		var records []db.Usage
+		for i := 0; i < usageExportPageSize+1; i++ {
+			records = append(records, newUsageRecord(t, attributionID, effectiveTime.Add(-time.Duration(i)*time.Second), 1, db.WorkspaceInstanceUsageData{}))
+		}
+		dbtest.CreateUsageRecords(t, dbConn, records...)
+
+		serverMock.EXPECT().GetUsageBalance(gomock.Any(), attributionID).Return(float64(0), nil)
+
+		chunks := exportUsage(t, client, &v1.UsageFilter{
+			OrganizationId: orgID,
+			From:           timestamppb.New(effectiveTime.Add(-time.Hour)),
+			To:             timestamppb.New(effectiveTime.Add(time.Hour)),
+		})
+		require.Len(t, chunks, 2)
+		require.Equal(t, usageExportPageSize+1, strings.Count(chunks[0], "\n"), "first chunk contains the header and a full page")
+		require.Equal(t, 1, strings.Count(chunks[1], "\n"), "second chunk contains the remaining row")
+		require.True(t, strings.HasPrefix(chunks[1], records[usageExportPageSize].ID.String()+","))
+	})
+}
+
+func exportUsage(t *testing.T, client v1connect.UsageServiceClient, filter *v1.UsageFilter) []string {
+	t.Helper()
+
+	stream, err := client.ExportUsage(context.Background(), connect.NewRequest(&v1.ExportUsageRequest{
+		Filter: filter,
+	}))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	var chunks []string
+	for stream.Receive() {
+		chunks = append(chunks, stream.Msg().GetCsv())
+	}
+	require.NoError(t, stream.Err())
+	return chunks
+}
+
+func TestUsageService_GetBalance(t *testing.T) {
+	t.Run("invalid argument when organization ID is not a valid uuid", func(t *testing.T) {
+		_, _, client := setupUsageService(t)
+
+		_, err := client.GetBalance(context.Background(), connect.NewRequest(&v1.GetBalanceRequest{
+			OrganizationId: "foo-bar",
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("proxies request to server", func(t *testing.T) {
+		serverMock, _, client := setupUsageService(t)
+
+		orgID := uuid.New().String()
+		serverMock.EXPECT().GetUsageBalance(gomock.Any(), string(db.NewTeamAttributionID(orgID))).Return(float64(42.5), nil)
+
+		resp, err := client.GetBalance(context.Background(), connect.NewRequest(&v1.GetBalanceRequest{
+			OrganizationId: orgID,
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.GetBalanceResponse{
+			Credits: 42.5,
+		}, resp.Msg)
+	})
+}
+
+func setupUsageService(t *testing.T) (*protocol.MockAPIInterface, *gorm.DB, v1connect.UsageServiceClient) {
+	t.Helper()
+
+	dbConn := dbtest.ConnectForTests(t)
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	serverMock := protocol.NewMockAPIInterface(ctrl)
+
+	svc := NewUsageService(&FakeServerConnPool{api: serverMock}, dbConn)
+
+	_, handler := v1connect.NewUsageServiceHandler(svc, connect.WithInterceptors(auth.NewServerInterceptor()))
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := v1connect.NewUsageServiceClient(http.DefaultClient, srv.URL, connect.WithInterceptors(
+		auth.NewClientInterceptor("auth-token"),
+	))
+
+	return serverMock, dbConn, client
+}
+
+func newUsageRecord(t *testing.T, attributionID string, effectiveTime time.Time, credits float64, metadata db.WorkspaceInstanceUsageData) db.Usage {
+	t.Helper()
+
+	b, err := json.Marshal(metadata)
+	require.NoError(t, err)
+
+	return dbtest.NewUsage(t, db.Usage{
+		AttributionID: db.AttributionID(attributionID),
+		Description:   "Usage of " + metadata.WorkspaceClass,
+		CreditCents:   db.NewCreditCents(credits),
+		EffectiveTime: db.NewVarCharTime(effectiveTime),
+		Metadata:      b,
+	})
+}
@@ -6,18 +6,24 @@ package apiv1
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	connect "github.com/bufbuild/connect-go"
 	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
 	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
 	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	"gorm.io/gorm"
 )
 
-func NewUserService(pool proxy.ServerConnectionPool) *UserService {
+func NewUserService(pool proxy.ServerConnectionPool, dbConn *gorm.DB, auditLog *db.AuditLogWriter) *UserService {
 	return &UserService{
 		connectionPool: pool,
+		dbConn:         dbConn,
+		auditLog:       auditLog,
 	}
 }
 
@@ -25,6 +31,8 @@ var _ v1connect.UserServiceHandler = (*UserService)(nil)
 
 type UserService struct {
 	connectionPool proxy.ServerConnectionPool
+	dbConn         *gorm.DB
+	auditLog       *db.AuditLogWriter
 
 	v1connect.UnimplementedUserServiceHandler
 }
@@ -69,6 +77,82 @@ func (s *UserService) ListSSHKeys(ctx context.Context, req *connect.Request[v1.L
 	}), nil
 }
 
+func (s *UserService) CreateSSHKey(ctx context.Context, req *connect.Request[v1.CreateSSHKeyRequest]) (*connect.Response[v1.CreateSSHKeyResponse], error) {
+	name := strings.TrimSpace(req.Msg.GetName())
+	if name == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Name is a required argument."))
+	}
+
+	key := strings.TrimSpace(req.Msg.GetKey())
+	if key == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Key is a required argument."))
+	}
+
+	conn, err := getConnection(ctx, s.connectionPool)
+	if err != nil {
+		return nil, err
+	}
+
+	sshKey, err := conn.AddSSHPublicKey(ctx, &protocol.SSHPublicKeyValue{
+		Name: name,
+		Key:  key,
+	})
+	if err != nil {
+		return nil, proxy.ConvertError(err)
+	}
+
+	return connect.NewResponse(&v1.CreateSSHKeyResponse{
+		Key: sshKeyToAPIResponse(sshKey),
+	}), nil
+}
+
+func (s *UserService) GetSSHKey(ctx context.Context, req *connect.Request[v1.GetSSHKeyRequest]) (*connect.Response[v1.GetSSHKeyResponse], error) {
+	keyID, err := validateSSHKeyID(ctx, req.Msg.GetKeyId())
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := getConnection(ctx, s.connectionPool)
+	if err != nil {
+		return nil, err
+	}
+
+	// Server does not support retrieving a single key, we find it in the list of keys instead.
+	sshKeys, err := conn.GetSSHPublicKeys(ctx)
+	if err != nil {
+		return nil, proxy.ConvertError(err)
+	}
+
+	for _, k := range sshKeys {
+		if k.ID == keyID.String() {
+			return connect.NewResponse(&v1.GetSSHKeyResponse{
+				Key: sshKeyToAPIResponse(k),
+			}), nil
+		}
+	}
+
+	return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("SSH Key with ID %s does not exist.", keyID.String()))
+}
+
+func (s *UserService) DeleteSSHKey(ctx context.Context, req *connect.Request[v1.DeleteSSHKeyRequest]) (*connect.Response[v1.DeleteSSHKeyResponse], error) {
+	keyID, err := validateSSHKeyID(ctx, req.Msg.GetKeyId())
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := getConnection(ctx, s.connectionPool)
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.DeleteSSHPublicKey(ctx, keyID.String())
+	if err != nil {
+		return nil, proxy.ConvertError(err)
+	}
+
+	return connect.NewResponse(&v1.DeleteSSHKeyResponse{}), nil
+}
+
 func (s *UserService) GetGitToken(ctx context.Context, req *connect.Request[v1.GetGitTokenRequest]) (*connect.Response[v1.GetGitTokenResponse], error) {
 	conn, err := getConnection(ctx, s.connectionPool)
 	if err != nil {
@@ -87,6 +171,39 @@ func (s *UserService) GetGitToken(ctx context.Context, req *connect.Request[v1.G
 	}), nil
 }
 
+func (s *UserService) BlockUser(ctx context.Context, req *connect.Request[v1.BlockUserRequest]) (*connect.Response[v1.BlockUserResponse], error) {
+	userID, err := validateUserID(ctx, req.Msg.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	reason := strings.TrimSpace(req.Msg.GetReason())
+	if reason == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Reason is a required argument."))
+	}
+
+	conn, err := getConnection(ctx, s.connectionPool)
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.AdminBlockUser(ctx, &protocol.AdminBlockUserRequest{
+		UserID:    userID.String(),
+		IsBlocked: true,
+	})
+	if err != nil {
+		return nil, proxy.ConvertError(err)
+	}
+
+	log.Extract(ctx).WithField("reason", reason).Info("Blocked user.")
+	recordUserAuditLog(ctx, s.auditLog, s.dbConn, userID, auditLogActorID(ctx, conn), auditLogActionBlockUser, map[string]any{
+		"userId": userID.String(),
+		"reason": reason,
+	})
+
+	return connect.NewResponse(&v1.BlockUserResponse{}), nil
+}
+
 func userToAPIResponse(user *protocol.User) *v1.User {
 	name := user.Name
 	if name == "" {
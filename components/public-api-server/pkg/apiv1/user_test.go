@@ -6,11 +6,14 @@ package apiv1
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/bufbuild/connect-go"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
 	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
 	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
 	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
@@ -62,6 +65,119 @@ func TestUserService_ListSSHKeys(t *testing.T) {
 	})
 }
 
+func TestUserService_CreateSSHKey(t *testing.T) {
+	t.Run("invalid argument when name is empty", func(t *testing.T) {
+		_, client := setupUserService(t)
+
+		_, err := client.CreateSSHKey(context.Background(), connect.NewRequest(&v1.CreateSSHKeyRequest{
+			Key: "ssh-ed25519 AAAAB3NzaC1yc2EAAAADAQABAAACAQDCnrN9UdK1bNGPmZfenTW",
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("invalid argument when key is empty", func(t *testing.T) {
+		_, client := setupUserService(t)
+
+		_, err := client.CreateSSHKey(context.Background(), connect.NewRequest(&v1.CreateSSHKeyRequest{
+			Name: "test key",
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("proxies request to server", func(t *testing.T) {
+		serverMock, client := setupUserService(t)
+
+		key := newSSHKey(&protocol.UserSSHPublicKeyValue{
+			Name: "test key",
+		})
+
+		serverMock.EXPECT().AddSSHPublicKey(gomock.Any(), &protocol.SSHPublicKeyValue{
+			Name: key.Name,
+			Key:  key.Key,
+		}).Return(key, nil)
+
+		retrieved, err := client.CreateSSHKey(context.Background(), connect.NewRequest(&v1.CreateSSHKeyRequest{
+			Name: key.Name,
+			Key:  key.Key,
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.CreateSSHKeyResponse{
+			Key: sshKeyToAPIResponse(key),
+		}, retrieved.Msg)
+	})
+}
+
+func TestUserService_GetSSHKey(t *testing.T) {
+	t.Run("invalid argument when key ID is not a valid uuid", func(t *testing.T) {
+		_, client := setupUserService(t)
+
+		_, err := client.GetSSHKey(context.Background(), connect.NewRequest(&v1.GetSSHKeyRequest{
+			KeyId: "something",
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("not found when key does not exist", func(t *testing.T) {
+		serverMock, client := setupUserService(t)
+
+		serverMock.EXPECT().GetSSHPublicKeys(gomock.Any()).Return([]*protocol.UserSSHPublicKeyValue{
+			newSSHKey(&protocol.UserSSHPublicKeyValue{}),
+		}, nil)
+
+		_, err := client.GetSSHKey(context.Background(), connect.NewRequest(&v1.GetSSHKeyRequest{
+			KeyId: uuid.New().String(),
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("returns key with matching ID", func(t *testing.T) {
+		serverMock, client := setupUserService(t)
+
+		key := newSSHKey(&protocol.UserSSHPublicKeyValue{
+			Name: "second key",
+		})
+		serverMock.EXPECT().GetSSHPublicKeys(gomock.Any()).Return([]*protocol.UserSSHPublicKeyValue{
+			newSSHKey(&protocol.UserSSHPublicKeyValue{}),
+			key,
+		}, nil)
+
+		retrieved, err := client.GetSSHKey(context.Background(), connect.NewRequest(&v1.GetSSHKeyRequest{
+			KeyId: key.ID,
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.GetSSHKeyResponse{
+			Key: sshKeyToAPIResponse(key),
+		}, retrieved.Msg)
+	})
+}
+
+func TestUserService_DeleteSSHKey(t *testing.T) {
+	t.Run("invalid argument when key ID is empty", func(t *testing.T) {
+		_, client := setupUserService(t)
+
+		_, err := client.DeleteSSHKey(context.Background(), connect.NewRequest(&v1.DeleteSSHKeyRequest{}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("proxies request to server", func(t *testing.T) {
+		serverMock, client := setupUserService(t)
+
+		keyID := uuid.New().String()
+		serverMock.EXPECT().DeleteSSHPublicKey(gomock.Any(), keyID).Return(nil)
+
+		retrieved, err := client.DeleteSSHKey(context.Background(), connect.NewRequest(&v1.DeleteSSHKeyRequest{
+			KeyId: keyID,
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.DeleteSSHKeyResponse{}, retrieved.Msg)
+	})
+}
+
 func TestUserService_GetGitToken(t *testing.T) {
 	t.Run("proxies request to server", func(t *testing.T) {
 		serverMock, client := setupUserService(t)
@@ -80,6 +196,82 @@ func TestUserService_GetGitToken(t *testing.T) {
 	})
 }
 
+func TestUserService_BlockUser(t *testing.T) {
+	t.Run("invalid argument when user ID is not a valid uuid", func(t *testing.T) {
+		_, client := setupUserService(t)
+
+		_, err := client.BlockUser(context.Background(), connect.NewRequest(&v1.BlockUserRequest{
+			UserId: "something",
+			Reason: "abuse",
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("invalid argument when reason is empty", func(t *testing.T) {
+		_, client := setupUserService(t)
+
+		_, err := client.BlockUser(context.Background(), connect.NewRequest(&v1.BlockUserRequest{
+			UserId: uuid.New().String(),
+		}))
+		require.Error(t, err)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("proxies request to server", func(t *testing.T) {
+		serverMock, client := setupUserService(t)
+
+		userID := uuid.New().String()
+		serverMock.EXPECT().AdminBlockUser(gomock.Any(), &protocol.AdminBlockUserRequest{
+			UserID:    userID,
+			IsBlocked: true,
+		}).Return(nil)
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(newUser(&protocol.User{}), nil)
+
+		retrieved, err := client.BlockUser(context.Background(), connect.NewRequest(&v1.BlockUserRequest{
+			UserId: userID,
+			Reason: "abuse",
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.BlockUserResponse{}, retrieved.Msg)
+	})
+
+	t.Run("records audit log in the organizations of the user", func(t *testing.T) {
+		serverMock, client := setupUserService(t)
+		dbConn := dbtest.ConnectForTests(t)
+
+		actor := newUser(&protocol.User{})
+		userID := uuid.New()
+		orgIDs := []uuid.UUID{uuid.New(), uuid.New()}
+		for _, orgID := range orgIDs {
+			_, err := db.CreateTeamMembership(context.Background(), dbConn, db.TeamMembership{
+				ID:     uuid.New(),
+				TeamID: orgID,
+				UserID: userID,
+				Role:   db.TeamMembershipRole_Member,
+			})
+			require.NoError(t, err)
+		}
+		t.Cleanup(func() {
+			require.NoError(t, dbConn.Where("userId = ?", userID.String()).Delete(&db.TeamMembership{}).Error)
+		})
+
+		serverMock.EXPECT().AdminBlockUser(gomock.Any(), gomock.Any()).Return(nil)
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(actor, nil)
+
+		_, err := client.BlockUser(context.Background(), connect.NewRequest(&v1.BlockUserRequest{
+			UserId: userID.String(),
+			Reason: "abuse",
+		}))
+		require.NoError(t, err)
+
+		for _, orgID := range orgIDs {
+			entry := requireAuditLog(t, orgID.String(), actor.ID, auditLogActionBlockUser)
+			require.JSONEq(t, fmt.Sprintf(`{"userId": %q, "reason": "abuse"}`, userID.String()), string(entry.Args))
+		}
+	})
+}
+
 func setupUserService(t *testing.T) (*protocol.MockAPIInterface, v1connect.UserServiceClient) {
 	t.Helper()
 
@@ -88,9 +280,10 @@ func setupUserService(t *testing.T) (*protocol.MockAPIInterface, v1connect.UserS
 
 	serverMock := protocol.NewMockAPIInterface(ctrl)
 
+	dbConn := dbtest.ConnectForTests(t)
 	svc := NewUserService(&FakeServerConnPool{
 		api: serverMock,
-	})
+	}, dbConn, db.NewAuditLogWriter(dbConn))
 
 	_, handler := v1connect.NewUserServiceHandler(svc, connect.WithInterceptors(auth.NewServerInterceptor()))
 
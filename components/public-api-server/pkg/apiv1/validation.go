@@ -70,6 +70,25 @@ func validateProjectID(ctx context.Context, id string) (uuid.UUID, error) {
 	return projectID, nil
 }
 
+func validateUserID(ctx context.Context, id string) (uuid.UUID, error) {
+	log.AddFields(ctx, log.UserID(id))
+	userID, err := validateUUID(id)
+	if err != nil {
+		return uuid.Nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("User ID must be a valid UUID."))
+	}
+
+	return userID, nil
+}
+
+func validateSSHKeyID(ctx context.Context, id string) (uuid.UUID, error) {
+	keyID, err := validateUUID(id)
+	if err != nil {
+		return uuid.Nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("SSH Key ID must be a valid UUID."))
+	}
+
+	return keyID, nil
+}
+
 func validatePersonalAccessTokenID(ctx context.Context, id string) (uuid.UUID, error) {
 	log.AddFields(ctx, log.PersonalAccessTokenID(id))
 	tokenID, err := validateUUID(id)
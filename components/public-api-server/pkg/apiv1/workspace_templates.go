@@ -0,0 +1,613 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	connect "github.com/bufbuild/connect-go"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// DefaultWorkspaceTemplateKeepVersions is the number of versions retained of every template, unless configured otherwise.
+const DefaultWorkspaceTemplateKeepVersions = 5
+
+var (
+	// alpha-numeric characters, dashes, underscores, dots, between 1 and 63 chars
+	workspaceTemplateNameRegex = regexp.MustCompile(`^[a-zA-Z0-9-_.]{1,63}$`)
+
+	// matches the environment variable names accepted in context URLs by server
+	workspaceTemplateEnvVarNameRegex = regexp.MustCompile(`^[a-zA-Z0-9-_]+$`)
+)
+
+func NewWorkspaceTemplatesService(pool proxy.ServerConnectionPool, dbConn *gorm.DB, contentService csapi.WorkspaceServiceClient, keepVersions int, auditLog *db.AuditLogWriter) *WorkspaceTemplatesService {
+	if keepVersions < 1 {
+		keepVersions = DefaultWorkspaceTemplateKeepVersions
+	}
+
+	return &WorkspaceTemplatesService{
+		connectionPool: pool,
+		dbConn:         dbConn,
+		contentService: contentService,
+		keepVersions:   keepVersions,
+		auditLog:       auditLog,
+	}
+}
+
+var _ v1connect.WorkspaceTemplatesServiceHandler = (*WorkspaceTemplatesService)(nil)
+
+type WorkspaceTemplatesService struct {
+	connectionPool proxy.ServerConnectionPool
+	dbConn         *gorm.DB
+	contentService csapi.WorkspaceServiceClient
+	keepVersions   int
+	auditLog       *db.AuditLogWriter
+
+	v1connect.UnimplementedWorkspaceTemplatesServiceHandler
+}
+
+func (s *WorkspaceTemplatesService) CreateWorkspaceTemplate(ctx context.Context, req *connect.Request[v1.CreateWorkspaceTemplateRequest]) (*connect.Response[v1.CreateWorkspaceTemplateResponse], error) {
+	organizationID, err := validateOrganizationID(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Msg.GetName())
+	if !workspaceTemplateNameRegex.MatchString(name) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("Name must be between 1 and 63 characters, and may only contain alpha-numeric characters, dashes, underscores and dots."))
+	}
+
+	snapshotID, err := validateUUID(req.Msg.GetSnapshotId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("Snapshot ID must be a valid UUID"))
+	}
+
+	envVars := make(map[string]string, len(req.Msg.GetEnvVars()))
+	for _, envVar := range req.Msg.GetEnvVars() {
+		if !workspaceTemplateEnvVarNameRegex.MatchString(envVar.GetName()) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Environment variable name %q may only contain alpha-numeric characters, dashes and underscores.", envVar.GetName()))
+		}
+		if envVar.GetValue() == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Environment variable %s must have a value.", envVar.GetName()))
+		}
+		envVars[envVar.GetName()] = envVar.GetValue()
+	}
+
+	_, user, userID, err := s.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.assertIsOrganizationMember(ctx, userID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := db.GetSnapshot(ctx, s.dbConn, snapshotID)
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Snapshot %s does not exist.", snapshotID.String()))
+		}
+		log.Extract(ctx).WithError(err).Error("Failed to retrieve snapshot.")
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve snapshot."))
+	}
+	if snapshot.State != db.SnapshotState_Available {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Snapshot %s is not available yet, its state is %s.", snapshotID.String(), snapshot.State))
+	}
+
+	workspaces, err := db.ListWorkspacesByID(ctx, s.dbConn, []string{snapshot.OriginalWorkspaceID})
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to retrieve workspace of snapshot.")
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve workspace of snapshot."))
+	}
+	// Snapshots do not carry an owner, hence we only allow templates of snapshots taken from the user's own workspaces.
+	if len(workspaces) == 0 || workspaces[0].OwnerID != userID {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Snapshot %s does not exist.", snapshotID.String()))
+	}
+	workspace := workspaces[0]
+	// The content of a workspace includes its snapshots. Once a workspace is referenced by a template it is exempt from garbage collection.
+	if workspace.ContentDeletedTime.IsSet() {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("Snapshot %s has been deleted together with its workspace.", snapshotID.String()))
+	}
+
+	projectID := strings.TrimSpace(req.Msg.GetProjectId())
+	if projectID != "" {
+		err = s.assertIsOrganizationProject(ctx, organizationID, projectID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tmpl := db.WorkspaceTemplate{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		ProjectID:      projectID,
+		Name:           name,
+		Description:    req.Msg.GetDescription(),
+		SnapshotID:     snapshotID,
+		OwnsSnapshot:   req.Msg.GetOwnsSnapshot(),
+		CreatorID:      userID,
+		Image:          workspace.ImageNameResolved,
+		WorkspaceClass: req.Msg.GetWorkspaceClass(),
+		IDE:            req.Msg.GetIde(),
+	}
+	err = tmpl.SetEnvVars(envVars)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to store environment variables of template."))
+	}
+
+	created, err := db.CreateWorkspaceTemplate(ctx, s.dbConn, tmpl)
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to create workspace template.")
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to create workspace template."))
+	}
+
+	recordAuditLog(ctx, s.auditLog, organizationID.String(), user.ID, auditLogActionCreateWorkspaceTemplate, map[string]any{
+		"templateId": created.ID.String(),
+		"projectId":  created.ProjectID,
+		"name":       created.Name,
+		"version":    created.Version,
+		"snapshotId": created.SnapshotID.String(),
+	})
+
+	// The template has been created already, hence failures to clean up outdated versions are only logged.
+	s.deleteOutdatedVersions(ctx, created)
+
+	result, err := workspaceTemplateToAPIResponse(created)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to read workspace template."))
+	}
+
+	return connect.NewResponse(&v1.CreateWorkspaceTemplateResponse{
+		Template: result,
+	}), nil
+}
+
+func (s *WorkspaceTemplatesService) GetWorkspaceTemplate(ctx context.Context, req *connect.Request[v1.GetWorkspaceTemplateRequest]) (*connect.Response[v1.GetWorkspaceTemplateResponse], error) {
+	templateID, err := validateWorkspaceTemplateID(req.Msg.GetTemplateId())
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, userID, err := s.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := s.getTemplate(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := workspaceTemplateToAPIResponse(tmpl)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to read workspace template."))
+	}
+
+	return connect.NewResponse(&v1.GetWorkspaceTemplateResponse{
+		Template: result,
+	}), nil
+}
+
+func (s *WorkspaceTemplatesService) ListWorkspaceTemplates(ctx context.Context, req *connect.Request[v1.ListWorkspaceTemplatesRequest]) (*connect.Response[v1.ListWorkspaceTemplatesResponse], error) {
+	organizationID, err := validateOrganizationID(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, userID, err := s.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.assertIsOrganizationMember(ctx, userID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := db.ListWorkspaceTemplatesForOrganization(ctx, s.dbConn, organizationID, db.ListWorkspaceTemplatesFilter{
+		ProjectID:   req.Msg.GetProjectId(),
+		Name:        req.Msg.GetName(),
+		AllVersions: req.Msg.GetAllVersions(),
+	}, paginationToDB(req.Msg.GetPagination()))
+	if err != nil {
+		log.Extract(ctx).WithError(err).Error("Failed to list workspace templates.")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("Failed to list workspace templates for Organization %s", organizationID.String()))
+	}
+
+	templates := make([]*v1.WorkspaceTemplate, 0, len(result.Results))
+	for _, tmpl := range result.Results {
+		converted, err := workspaceTemplateToAPIResponse(tmpl)
+		if err != nil {
+			log.Extract(ctx).WithError(err).WithField("templateId", tmpl.ID.String()).Error("Failed to read workspace template.")
+			return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to read workspace templates."))
+		}
+		templates = append(templates, converted)
+	}
+
+	return connect.NewResponse(&v1.ListWorkspaceTemplatesResponse{
+		Templates:    templates,
+		TotalResults: result.Total,
+	}), nil
+}
+
+func (s *WorkspaceTemplatesService) DeleteWorkspaceTemplate(ctx context.Context, req *connect.Request[v1.DeleteWorkspaceTemplateRequest]) (*connect.Response[v1.DeleteWorkspaceTemplateResponse], error) {
+	templateID, err := validateWorkspaceTemplateID(req.Msg.GetTemplateId())
+	if err != nil {
+		return nil, err
+	}
+
+	_, user, userID, err := s.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := s.getTemplate(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tmpl.CreatorID != userID {
+		err = assertIsOrganizationOwner(ctx, s.dbConn, userID, tmpl.OrganizationID, "Only the creator of a template, or owners of its Organization can delete it.")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.deleteVersion(ctx, tmpl)
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Workspace template %s does not exist.", templateID.String()))
+		}
+		log.Extract(ctx).WithError(err).Error("Failed to delete workspace template.")
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to delete workspace template."))
+	}
+
+	recordAuditLog(ctx, s.auditLog, tmpl.OrganizationID.String(), user.ID, auditLogActionDeleteWorkspaceTemplate, map[string]any{
+		"templateId": tmpl.ID.String(),
+		"projectId":  tmpl.ProjectID,
+		"name":       tmpl.Name,
+		"version":    tmpl.Version,
+	})
+
+	return connect.NewResponse(&v1.DeleteWorkspaceTemplateResponse{}), nil
+}
+
+func (s *WorkspaceTemplatesService) StartWorkspaceFromTemplate(ctx context.Context, req *connect.Request[v1.StartWorkspaceFromTemplateRequest]) (*connect.Response[v1.StartWorkspaceFromTemplateResponse], error) {
+	var (
+		templateID uuid.UUID
+		name       *v1.WorkspaceTemplateName
+		err        error
+	)
+	switch t := req.Msg.GetTemplate().(type) {
+	case *v1.StartWorkspaceFromTemplateRequest_TemplateId:
+		templateID, err = validateWorkspaceTemplateID(t.TemplateId)
+		if err != nil {
+			return nil, err
+		}
+	case *v1.StartWorkspaceFromTemplateRequest_Name:
+		name = t.Name
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("Either a template ID, or a template name must be specified."))
+	}
+
+	var organizationID uuid.UUID
+	if name != nil {
+		organizationID, err = validateOrganizationID(ctx, name.GetOrganizationId())
+		if err != nil {
+			return nil, err
+		}
+		if name.GetName() == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("Template name must not be empty."))
+		}
+	}
+
+	conn, _, userID, err := s.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tmpl db.WorkspaceTemplate
+	if name == nil {
+		tmpl, err = s.getTemplate(ctx, userID, templateID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		err = s.assertIsOrganizationMember(ctx, userID, organizationID)
+		if err != nil {
+			return nil, err
+		}
+
+		tmpl, err = db.GetLatestWorkspaceTemplate(ctx, s.dbConn, organizationID, name.GetProjectId(), name.GetName())
+		if err != nil {
+			if errors.Is(err, db.ErrorNotFound) {
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("Workspace template %s does not exist.", name.GetName()))
+			}
+			log.Extract(ctx).WithError(err).Error("Failed to retrieve workspace template.")
+			return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve workspace template."))
+		}
+	}
+	log.AddFields(ctx, log.OrganizationID(tmpl.OrganizationID.String()))
+
+	contextURL, err := workspaceTemplateContextURL(tmpl)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to read workspace template."))
+	}
+
+	options := &protocol.CreateWorkspaceOptions{
+		ContextURL: contextURL,
+		StartWorkspaceOptions: protocol.StartWorkspaceOptions{
+			WorkspaceClass: tmpl.WorkspaceClass,
+		},
+	}
+	if tmpl.IDE != "" {
+		options.IdeSettings = &protocol.IDESettings{
+			DefaultIde: tmpl.IDE,
+		}
+	}
+
+	created, err := conn.CreateWorkspace(ctx, options)
+	if err != nil {
+		return nil, proxy.ConvertError(err)
+	}
+	if created.CreatedWorkspaceID == "" {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("Failed to create workspace from template."))
+	}
+
+	return connect.NewResponse(&v1.StartWorkspaceFromTemplateResponse{
+		WorkspaceId:  created.CreatedWorkspaceID,
+		WorkspaceUrl: created.WorkspaceURL,
+	}), nil
+}
+
+func (s *WorkspaceTemplatesService) getUser(ctx context.Context) (protocol.APIInterface, *protocol.User, uuid.UUID, error) {
+	conn, err := getConnection(ctx, s.connectionPool)
+	if err != nil {
+		return nil, nil, uuid.Nil, err
+	}
+
+	user, err := conn.GetLoggedInUser(ctx)
+	if err != nil {
+		return nil, nil, uuid.Nil, proxy.ConvertError(err)
+	}
+	log.AddFields(ctx, log.UserID(user.ID))
+
+	userID, err := uuid.Parse(user.ID)
+	if err != nil {
+		return nil, nil, uuid.Nil, connect.NewError(connect.CodeInternal, errors.New("Failed to parse user ID as UUID. Please contact support."))
+	}
+
+	return conn, user, userID, nil
+}
+
+// getTemplate returns the template, if the user is a member of the Organization it belongs to.
+func (s *WorkspaceTemplatesService) getTemplate(ctx context.Context, userID, templateID uuid.UUID) (db.WorkspaceTemplate, error) {
+	tmpl, err := db.GetWorkspaceTemplate(ctx, s.dbConn, templateID)
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			return db.WorkspaceTemplate{}, connect.NewError(connect.CodeNotFound, fmt.Errorf("Workspace template %s does not exist.", templateID.String()))
+		}
+		log.Extract(ctx).WithError(err).Error("Failed to retrieve workspace template.")
+		return db.WorkspaceTemplate{}, connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve workspace template."))
+	}
+
+	// We do not reveal the existence of templates to non-members.
+	err = s.assertIsOrganizationMember(ctx, userID, tmpl.OrganizationID)
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodePermissionDenied {
+			return db.WorkspaceTemplate{}, connect.NewError(connect.CodeNotFound, fmt.Errorf("Workspace template %s does not exist.", templateID.String()))
+		}
+		return db.WorkspaceTemplate{}, err
+	}
+
+	return tmpl, nil
+}
+
+func (s *WorkspaceTemplatesService) assertIsOrganizationMember(ctx context.Context, userID, organizationID uuid.UUID) error {
+	_, err := db.GetTeamMembership(ctx, s.dbConn, userID, organizationID)
+	if err != nil {
+		if errors.Is(err, db.ErrorNotFound) {
+			return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("Only members of Organization %s can use its workspace templates", organizationID.String()))
+		}
+		log.Extract(ctx).WithError(err).Error("Failed to retrieve team membership.")
+		return connect.NewError(connect.CodeInternal, errors.New("Failed to verify permissions."))
+	}
+	return nil
+}
+
+// deleteOutdatedVersions deletes all versions of the template, which exceed the configured number of versions to keep.
+func (s *WorkspaceTemplatesService) deleteOutdatedVersions(ctx context.Context, tmpl db.WorkspaceTemplate) {
+	logger := log.Extract(ctx).WithField("name", tmpl.Name)
+
+	outdated, err := db.ListOutdatedWorkspaceTemplateVersions(ctx, s.dbConn, tmpl.OrganizationID, tmpl.ProjectID, tmpl.Name, s.keepVersions)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list outdated versions of workspace template.")
+		return
+	}
+
+	for _, version := range outdated {
+		err = s.deleteVersion(ctx, version)
+		if err != nil {
+			logger.WithError(err).WithField("version", version.Version).Error("Failed to delete outdated version of workspace template.")
+		}
+	}
+}
+
+// assertIsOrganizationProject verifies that the project belongs to the organization.
+func (s *WorkspaceTemplatesService) assertIsOrganizationProject(ctx context.Context, organizationID uuid.UUID, projectID string) error {
+	id, err := validateUUID(projectID)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("Project ID must be a valid UUID"))
+	}
+
+	project, err := db.GetProject(ctx, s.dbConn, id)
+	if err != nil && !errors.Is(err, db.ErrorNotFound) {
+		log.Extract(ctx).WithError(err).Error("Failed to retrieve project.")
+		return connect.NewError(connect.CodeInternal, errors.New("Failed to retrieve project."))
+	}
+	if err != nil || project.TeamID.String != organizationID.String() {
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("Project %s does not exist in organization %s.", projectID, organizationID.String()))
+	}
+
+	return nil
+}
+
+// deleteVersion deletes a single version of a template. The snapshot is deleted as well if it was taken for the template,
+// unless another version was created from the same snapshot.
+func (s *WorkspaceTemplatesService) deleteVersion(ctx context.Context, tmpl db.WorkspaceTemplate) error {
+	err := db.DeleteWorkspaceTemplate(ctx, s.dbConn, tmpl.ID)
+	if err != nil {
+		return err
+	}
+
+	if s.contentService == nil {
+		return nil
+	}
+
+	count, err := db.CountWorkspaceTemplatesForSnapshot(ctx, s.dbConn, tmpl.SnapshotID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	owned, err := db.WorkspaceTemplateOwnsSnapshot(ctx, s.dbConn, tmpl.SnapshotID)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return nil
+	}
+
+	err = s.deleteSnapshot(ctx, tmpl.SnapshotID)
+	if err != nil {
+		// The template is deleted already, an orphaned snapshot is removed together with its workspace eventually.
+		log.Extract(ctx).WithError(err).WithField("snapshotId", tmpl.SnapshotID.String()).Warn("Failed to delete snapshot of workspace template.")
+	}
+	return nil
+}
+
+func (s *WorkspaceTemplatesService) deleteSnapshot(ctx context.Context, snapshotID uuid.UUID) error {
+	snapshot, err := db.GetSnapshot(ctx, s.dbConn, snapshotID)
+	if err != nil {
+		return err
+	}
+
+	filename, err := snapshot.Filename()
+	if err != nil {
+		return err
+	}
+
+	workspaces, err := db.ListWorkspacesByID(ctx, s.dbConn, []string{snapshot.OriginalWorkspaceID})
+	if err != nil {
+		return err
+	}
+	if len(workspaces) == 0 {
+		return fmt.Errorf("workspace %s of snapshot does not exist", snapshot.OriginalWorkspaceID)
+	}
+
+	_, err = s.contentService.DeleteSnapshot(ctx, &csapi.DeleteSnapshotRequest{
+		OwnerId:     workspaces[0].OwnerID.String(),
+		WorkspaceId: snapshot.OriginalWorkspaceID,
+		Filename:    filename,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Workspaces can no longer be started from the snapshot, hence it must not be offered anymore.
+	return db.DeleteSnapshot(ctx, s.dbConn, snapshotID)
+}
+
+func validateWorkspaceTemplateID(id string) (uuid.UUID, error) {
+	templateID, err := validateUUID(id)
+	if err != nil {
+		return uuid.Nil, connect.NewError(connect.CodeInvalidArgument, errors.New("Template ID must be a valid UUID"))
+	}
+
+	return templateID, nil
+}
+
+// workspaceTemplateContextURL returns the context URL which starts a workspace from the snapshot of the template.
+// Environment variables are passed as context URL prefix, e.g. `FOO=bar/snapshot/<id>`.
+func workspaceTemplateContextURL(tmpl db.WorkspaceTemplate) (string, error) {
+	envVars, err := tmpl.GetEnvVars()
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, len(envVars))
+	for name := range envVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var prefix []string
+	for _, name := range names {
+		// server decodes values with decodeURIComponent, which does not treat `+` as space
+		value := strings.ReplaceAll(url.QueryEscape(envVars[name]), "+", "%20")
+		prefix = append(prefix, name+"="+value)
+	}
+
+	contextURL := "snapshot/" + tmpl.SnapshotID.String()
+	if len(prefix) > 0 {
+		contextURL = strings.Join(prefix, ",") + "/" + contextURL
+	}
+	return contextURL, nil
+}
+
+func workspaceTemplateToAPIResponse(tmpl db.WorkspaceTemplate) (*v1.WorkspaceTemplate, error) {
+	envVars, err := tmpl.GetEnvVars()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(envVars))
+	for name := range envVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	apiEnvVars := make([]*v1.WorkspaceTemplateEnvVar, 0, len(names))
+	for _, name := range names {
+		apiEnvVars = append(apiEnvVars, &v1.WorkspaceTemplateEnvVar{
+			Name:  name,
+			Value: envVars[name],
+		})
+	}
+
+	return &v1.WorkspaceTemplate{
+		Id:             tmpl.ID.String(),
+		OrganizationId: tmpl.OrganizationID.String(),
+		ProjectId:      tmpl.ProjectID,
+		Name:           tmpl.Name,
+		Version:        int32(tmpl.Version),
+		Description:    tmpl.Description,
+		SnapshotId:     tmpl.SnapshotID.String(),
+		Image:          tmpl.Image,
+		WorkspaceClass: tmpl.WorkspaceClass,
+		Ide:            tmpl.IDE,
+		EnvVars:        apiEnvVars,
+		CreatorId:      tmpl.CreatorID.String(),
+		CreatedAt:      timestamppb.New(tmpl.CreatedAt),
+	}, nil
+}
@@ -0,0 +1,404 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package apiv1
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	connect "github.com/bufbuild/connect-go"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	protocol "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+func TestWorkspaceTemplatesService_CreateWorkspaceTemplate(t *testing.T) {
+	t.Run("invalid argument when Organization ID is not a UUID", func(t *testing.T) {
+		_, client, _, _ := setupWorkspaceTemplatesService(t, 0)
+
+		_, err := client.CreateWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.CreateWorkspaceTemplateRequest{
+			OrganizationId: "foo-bar",
+			Name:           "node",
+			SnapshotId:     uuid.NewString(),
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("invalid argument when name is invalid", func(t *testing.T) {
+		_, client, _, _ := setupWorkspaceTemplatesService(t, 0)
+
+		_, err := client.CreateWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.CreateWorkspaceTemplateRequest{
+			OrganizationId: uuid.NewString(),
+			Name:           "my/template",
+			SnapshotId:     uuid.NewString(),
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("invalid argument when environment variable name is invalid", func(t *testing.T) {
+		_, client, _, _ := setupWorkspaceTemplatesService(t, 0)
+
+		_, err := client.CreateWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.CreateWorkspaceTemplateRequest{
+			OrganizationId: uuid.NewString(),
+			Name:           "node",
+			SnapshotId:     uuid.NewString(),
+			EnvVars:        []*v1.WorkspaceTemplateEnvVar{{Name: "FOO=BAR", Value: "baz"}},
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("permission denied when user is not a member of the organization", func(t *testing.T) {
+		serverMock, client, _, _ := setupWorkspaceTemplatesService(t, 0)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.CreateWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.CreateWorkspaceTemplateRequest{
+			OrganizationId: uuid.NewString(),
+			Name:           "node",
+			SnapshotId:     uuid.NewString(),
+		}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("not found when snapshot was taken from a workspace of another user", func(t *testing.T) {
+		serverMock, client, dbConn, _ := setupWorkspaceTemplatesService(t, 0)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		snapshot := createSnapshot(t, dbConn, uuid.New())
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.CreateWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.CreateWorkspaceTemplateRequest{
+			OrganizationId: orgID.String(),
+			Name:           "node",
+			SnapshotId:     snapshot.ID.String(),
+		}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("failed precondition when the content of the snapshot's workspace was deleted", func(t *testing.T) {
+		serverMock, client, dbConn, _ := setupWorkspaceTemplatesService(t, 0)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		snapshot := createSnapshot(t, dbConn, uuid.MustParse(user.ID))
+		require.NoError(t, dbConn.Model(&db.Workspace{ID: snapshot.OriginalWorkspaceID}).Update("contentDeletedTime", db.NewVarCharTime(time.Now())).Error)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.CreateWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.CreateWorkspaceTemplateRequest{
+			OrganizationId: orgID.String(),
+			Name:           "node",
+			SnapshotId:     snapshot.ID.String(),
+		}))
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	})
+
+	t.Run("not found when project belongs to another organization", func(t *testing.T) {
+		serverMock, client, dbConn, _ := setupWorkspaceTemplatesService(t, 0)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		snapshot := createSnapshot(t, dbConn, uuid.MustParse(user.ID))
+		project := db.Project{
+			ID:     uuid.New(),
+			Name:   "gitpod",
+			TeamID: sql.NullString{String: uuid.NewString(), Valid: true},
+		}
+		require.NoError(t, dbConn.Create(&project).Error)
+		t.Cleanup(func() {
+			require.NoError(t, dbConn.Delete(&project).Error)
+		})
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.CreateWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.CreateWorkspaceTemplateRequest{
+			OrganizationId: orgID.String(),
+			ProjectId:      project.ID.String(),
+			Name:           "node",
+			SnapshotId:     snapshot.ID.String(),
+		}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("creates new versions and deletes outdated versions with their snapshots", func(t *testing.T) {
+		serverMock, client, dbConn, contentService := setupWorkspaceTemplatesService(t, 1)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		first := createSnapshot(t, dbConn, uuid.MustParse(user.ID))
+		second := createSnapshot(t, dbConn, uuid.MustParse(user.ID))
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil).Times(3)
+
+		v1Response, err := client.CreateWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.CreateWorkspaceTemplateRequest{
+			OrganizationId: orgID.String(),
+			Name:           "node",
+			SnapshotId:     first.ID.String(),
+			OwnsSnapshot:   true,
+			Ide:            "code",
+			EnvVars:        []*v1.WorkspaceTemplateEnvVar{{Name: "FOO", Value: "bar"}},
+		}))
+		require.NoError(t, err)
+		t.Cleanup(func() { deleteWorkspaceTemplate(t, dbConn, v1Response.Msg.GetTemplate().GetId()) })
+
+		require.EqualValues(t, 1, v1Response.Msg.GetTemplate().GetVersion())
+		require.Equal(t, "eu.gcr.io/gitpod/workspace-images:node", v1Response.Msg.GetTemplate().GetImage())
+		require.Equal(t, "code", v1Response.Msg.GetTemplate().GetIde())
+		requireEqualProto(t, []*v1.WorkspaceTemplateEnvVar{{Name: "FOO", Value: "bar"}}, v1Response.Msg.GetTemplate().GetEnvVars())
+		require.Empty(t, contentService.deleted())
+
+		v2Response, err := client.CreateWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.CreateWorkspaceTemplateRequest{
+			OrganizationId: orgID.String(),
+			Name:           "node",
+			SnapshotId:     second.ID.String(),
+		}))
+		require.NoError(t, err)
+		t.Cleanup(func() { deleteWorkspaceTemplate(t, dbConn, v2Response.Msg.GetTemplate().GetId()) })
+		require.EqualValues(t, 2, v2Response.Msg.GetTemplate().GetVersion())
+
+		filename, err := first.Filename()
+		require.NoError(t, err)
+		require.Equal(t, []*csapi.DeleteSnapshotRequest{{
+			OwnerId:     user.ID,
+			WorkspaceId: first.OriginalWorkspaceID,
+			Filename:    filename,
+		}}, contentService.deleted())
+		_, err = db.GetSnapshot(context.Background(), dbConn, first.ID)
+		require.ErrorIs(t, err, db.ErrorNotFound, "snapshot record is deleted together with its content")
+
+		_, err = client.GetWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.GetWorkspaceTemplateRequest{
+			TemplateId: v1Response.Msg.GetTemplate().GetId(),
+		}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
+
+func TestWorkspaceTemplatesService_DeleteWorkspaceTemplate(t *testing.T) {
+	t.Run("permission denied when user is neither the creator, nor an owner", func(t *testing.T) {
+		serverMock, client, dbConn, _ := setupWorkspaceTemplatesService(t, 0)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		tmpl := createWorkspaceTemplateForTest(t, dbConn, db.WorkspaceTemplate{OrganizationID: orgID, Name: "node", CreatorID: uuid.New()})
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.DeleteWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.DeleteWorkspaceTemplateRequest{
+			TemplateId: tmpl.ID.String(),
+		}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("retains snapshot which is used by another template", func(t *testing.T) {
+		serverMock, client, dbConn, contentService := setupWorkspaceTemplatesService(t, 0)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		snapshot := createSnapshot(t, dbConn, uuid.MustParse(user.ID))
+		tmpl := createWorkspaceTemplateForTest(t, dbConn, db.WorkspaceTemplate{OrganizationID: orgID, Name: "node", SnapshotID: snapshot.ID, CreatorID: uuid.MustParse(user.ID)})
+		createWorkspaceTemplateForTest(t, dbConn, db.WorkspaceTemplate{OrganizationID: orgID, Name: "go", SnapshotID: snapshot.ID, CreatorID: uuid.MustParse(user.ID)})
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.DeleteWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.DeleteWorkspaceTemplateRequest{
+			TemplateId: tmpl.ID.String(),
+		}))
+		require.NoError(t, err)
+		require.Empty(t, contentService.deleted())
+
+		requireAuditLog(t, orgID.String(), user.ID, auditLogActionDeleteWorkspaceTemplate)
+	})
+
+	t.Run("retains snapshot which was not taken for the template", func(t *testing.T) {
+		serverMock, client, dbConn, contentService := setupWorkspaceTemplatesService(t, 0)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		snapshot := createSnapshot(t, dbConn, uuid.MustParse(user.ID))
+		tmpl := createWorkspaceTemplateForTest(t, dbConn, db.WorkspaceTemplate{OrganizationID: orgID, Name: "node", SnapshotID: snapshot.ID, CreatorID: uuid.MustParse(user.ID)})
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.DeleteWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.DeleteWorkspaceTemplateRequest{
+			TemplateId: tmpl.ID.String(),
+		}))
+		require.NoError(t, err)
+		require.Empty(t, contentService.deleted())
+		_, err = db.GetSnapshot(context.Background(), dbConn, snapshot.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("deletes snapshot which was taken for the template", func(t *testing.T) {
+		serverMock, client, dbConn, contentService := setupWorkspaceTemplatesService(t, 0)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		snapshot := createSnapshot(t, dbConn, uuid.MustParse(user.ID))
+		tmpl := createWorkspaceTemplateForTest(t, dbConn, db.WorkspaceTemplate{OrganizationID: orgID, Name: "node", SnapshotID: snapshot.ID, OwnsSnapshot: true, CreatorID: uuid.MustParse(user.ID)})
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.DeleteWorkspaceTemplate(context.Background(), connect.NewRequest(&v1.DeleteWorkspaceTemplateRequest{
+			TemplateId: tmpl.ID.String(),
+		}))
+		require.NoError(t, err)
+		require.Len(t, contentService.deleted(), 1)
+		_, err = db.GetSnapshot(context.Background(), dbConn, snapshot.ID)
+		require.ErrorIs(t, err, db.ErrorNotFound)
+	})
+}
+
+func TestWorkspaceTemplatesService_StartWorkspaceFromTemplate(t *testing.T) {
+	t.Run("invalid argument when no template is specified", func(t *testing.T) {
+		_, client, _, _ := setupWorkspaceTemplatesService(t, 0)
+
+		_, err := client.StartWorkspaceFromTemplate(context.Background(), connect.NewRequest(&v1.StartWorkspaceFromTemplateRequest{}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("starts the latest version of a template by name", func(t *testing.T) {
+		serverMock, client, dbConn, _ := setupWorkspaceTemplatesService(t, 0)
+		orgID := uuid.New()
+		createTeamMembership(t, dbConn, orgID, uuid.MustParse(user.ID), db.TeamMembershipRole_Member)
+		createWorkspaceTemplateForTest(t, dbConn, db.WorkspaceTemplate{OrganizationID: orgID, Name: "node"})
+		latest := db.WorkspaceTemplate{OrganizationID: orgID, Name: "node", WorkspaceClass: "g1-large", IDE: "intellij"}
+		require.NoError(t, latest.SetEnvVars(map[string]string{"FOO": "bar baz", "A": "1"}))
+		latest = createWorkspaceTemplateForTest(t, dbConn, latest)
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+		serverMock.EXPECT().CreateWorkspace(gomock.Any(), &protocol.CreateWorkspaceOptions{
+			ContextURL: "A=1,FOO=bar%20baz/snapshot/" + latest.SnapshotID.String(),
+			StartWorkspaceOptions: protocol.StartWorkspaceOptions{
+				WorkspaceClass: "g1-large",
+				IdeSettings:    &protocol.IDESettings{DefaultIde: "intellij"},
+			},
+		}).Return(&protocol.WorkspaceCreationResult{
+			CreatedWorkspaceID: "gitpodio-gitpod-abc123",
+			WorkspaceURL:       "https://gitpodio-gitpod-abc123.gitpod.io",
+		}, nil)
+
+		resp, err := client.StartWorkspaceFromTemplate(context.Background(), connect.NewRequest(&v1.StartWorkspaceFromTemplateRequest{
+			Template: &v1.StartWorkspaceFromTemplateRequest_Name{
+				Name: &v1.WorkspaceTemplateName{OrganizationId: orgID.String(), Name: "node"},
+			},
+		}))
+		require.NoError(t, err)
+		requireEqualProto(t, &v1.StartWorkspaceFromTemplateResponse{
+			WorkspaceId:  "gitpodio-gitpod-abc123",
+			WorkspaceUrl: "https://gitpodio-gitpod-abc123.gitpod.io",
+		}, resp.Msg)
+	})
+
+	t.Run("not found when user is not a member of the organization", func(t *testing.T) {
+		serverMock, client, dbConn, _ := setupWorkspaceTemplatesService(t, 0)
+		tmpl := createWorkspaceTemplateForTest(t, dbConn, db.WorkspaceTemplate{OrganizationID: uuid.New(), Name: "node"})
+
+		serverMock.EXPECT().GetLoggedInUser(gomock.Any()).Return(user, nil)
+
+		_, err := client.StartWorkspaceFromTemplate(context.Background(), connect.NewRequest(&v1.StartWorkspaceFromTemplateRequest{
+			Template: &v1.StartWorkspaceFromTemplateRequest_TemplateId{TemplateId: tmpl.ID.String()},
+		}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
+
+func setupWorkspaceTemplatesService(t *testing.T, keepVersions int) (*protocol.MockAPIInterface, v1connect.WorkspaceTemplatesServiceClient, *gorm.DB, *fakeContentService) {
+	t.Helper()
+
+	dbConn := dbtest.ConnectForTests(t)
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	serverMock := protocol.NewMockAPIInterface(ctrl)
+	contentService := &fakeContentService{}
+
+	svc := NewWorkspaceTemplatesService(&FakeServerConnPool{api: serverMock}, dbConn, contentService, keepVersions, db.NewAuditLogWriter(dbConn))
+
+	_, handler := v1connect.NewWorkspaceTemplatesServiceHandler(svc, connect.WithInterceptors(auth.NewServerInterceptor()))
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := v1connect.NewWorkspaceTemplatesServiceClient(http.DefaultClient, srv.URL, connect.WithInterceptors(
+		auth.NewClientInterceptor("auth-token"),
+	))
+
+	return serverMock, client, dbConn, contentService
+}
+
+// createSnapshot creates a snapshot of a new workspace owned by ownerID.
+func createSnapshot(t *testing.T, dbConn *gorm.DB, ownerID uuid.UUID) db.Snapshot {
+	t.Helper()
+
+	ws := dbtest.CreateWorkspaces(t, dbConn, dbtest.NewWorkspace(t, db.Workspace{
+		OwnerID:           ownerID,
+		ImageNameResolved: "eu.gcr.io/gitpod/workspace-images:node",
+	}))[0]
+
+	snapshot := db.Snapshot{
+		ID:                  uuid.New(),
+		OriginalWorkspaceID: ws.ID,
+		BucketID:            "workspaces/" + ws.ID + "/snapshot-" + uuid.NewString() + ".tar@gitpod-user-" + ownerID.String(),
+		State:               db.SnapshotState_Available,
+	}
+	require.NoError(t, dbConn.Create(&snapshot).Error)
+	t.Cleanup(func() {
+		require.NoError(t, dbConn.Delete(&snapshot).Error)
+	})
+
+	return snapshot
+}
+
+func createWorkspaceTemplateForTest(t *testing.T, dbConn *gorm.DB, tmpl db.WorkspaceTemplate) db.WorkspaceTemplate {
+	t.Helper()
+
+	tmpl.ID = uuid.New()
+	if tmpl.SnapshotID == uuid.Nil {
+		tmpl.SnapshotID = uuid.New()
+	}
+	created, err := db.CreateWorkspaceTemplate(context.Background(), dbConn, tmpl)
+	require.NoError(t, err)
+	t.Cleanup(func() { deleteWorkspaceTemplate(t, dbConn, created.ID.String()) })
+
+	return created
+}
+
+func deleteWorkspaceTemplate(t *testing.T, dbConn *gorm.DB, id string) {
+	t.Helper()
+
+	require.NoError(t, dbConn.Delete(&db.WorkspaceTemplate{ID: uuid.MustParse(id)}).Error)
+}
+
+// fakeContentService records the snapshots it is asked to delete.
+type fakeContentService struct {
+	csapi.WorkspaceServiceClient
+
+	mu       sync.Mutex
+	requests []*csapi.DeleteSnapshotRequest
+}
+
+func (f *fakeContentService) DeleteSnapshot(ctx context.Context, in *csapi.DeleteSnapshotRequest, opts ...grpc.CallOption) (*csapi.DeleteSnapshotResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, in)
+	return &csapi.DeleteSnapshotResponse{}, nil
+}
+
+func (f *fakeContentService) deleted() []*csapi.DeleteSnapshotRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.requests
+}
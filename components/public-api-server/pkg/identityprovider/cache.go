@@ -7,7 +7,9 @@ package identityprovider
 import (
 	"context"
 	"crypto/rsa"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"strings"
@@ -15,6 +17,7 @@ import (
 	"time"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	"github.com/redis/go-redis/v9"
 	"gopkg.in/square/go-jose.v2"
 )
@@ -30,6 +33,9 @@ type KeyCache interface {
 	// Signer produces a new key signer or nil if Set() hasn't been called yet
 	Signer(ctx context.Context) (jose.Signer, error)
 
+	// Created returns when the current key was set, or the zero time if Set() hasn't been called yet
+	Created(ctx context.Context) (time.Time, error)
+
 	// PublicKeys returns all un-expired public keys as JSON-encoded *jose.JSONWebKeySet.
 	// This function returns the JSON-encoded form directly instead of the *jose.JSONWebKeySet
 	// to allow for persisted JSON implementations of this interface.
@@ -40,15 +46,20 @@ type inMemoryKey struct {
 	ID      string
 	Created time.Time
 	Key     *rsa.PublicKey
+
+	// Retired is set once the key was rotated out, the zero time for the current key
+	Retired time.Time
 }
 
 func NewInMemoryCache() *InMemoryCache {
 	return &InMemoryCache{
-		keys: make(map[string]*inMemoryKey),
+		keys:  make(map[string]*inMemoryKey),
+		keyID: func(current *rsa.PrivateKey) string { return fmt.Sprintf("id%d%d", time.Now().Unix(), rand.Int()) },
 	}
 }
 
 type InMemoryCache struct {
+	keyID     func(current *rsa.PrivateKey) string
 	mu        sync.RWMutex
 	current   *rsa.PrivateKey
 	currentID string
@@ -61,12 +72,17 @@ func (imc *InMemoryCache) Set(ctx context.Context, current *rsa.PrivateKey) erro
 	imc.mu.Lock()
 	defer imc.mu.Unlock()
 
-	id := fmt.Sprintf("id%d%d", time.Now().Unix(), rand.Int())
+	now := time.Now()
+	if previous, ok := imc.keys[imc.currentID]; ok {
+		previous.Retired = now
+	}
+
+	id := imc.keyID(current)
 	imc.currentID = id
 	imc.current = current
 	imc.keys[id] = &inMemoryKey{
 		ID:      id,
-		Created: time.Now(),
+		Created: now,
 		Key:     &current.PublicKey,
 	}
 	return nil
@@ -74,6 +90,9 @@ func (imc *InMemoryCache) Set(ctx context.Context, current *rsa.PrivateKey) erro
 
 // Signer produces a new key signer or nil if Set() hasn't been called yet
 func (imc *InMemoryCache) Signer(ctx context.Context) (jose.Signer, error) {
+	imc.mu.RLock()
+	defer imc.mu.RUnlock()
+
 	if imc.current == nil {
 		return nil, nil
 	}
@@ -81,16 +100,37 @@ func (imc *InMemoryCache) Signer(ctx context.Context) (jose.Signer, error) {
 	return jose.NewSigner(jose.SigningKey{
 		Algorithm: jose.RS256,
 		Key:       imc.current,
-	}, nil)
+	}, &jose.SignerOptions{
+		ExtraHeaders: map[jose.HeaderKey]interface{}{
+			jose.HeaderKey("kid"): imc.currentID,
+		},
+	})
 }
 
-// PublicKeys returns all un-expired public keys
-func (imc *InMemoryCache) PublicKeys(ctx context.Context) ([]byte, error) {
+// Created returns when the current key was set
+func (imc *InMemoryCache) Created(ctx context.Context) (time.Time, error) {
 	imc.mu.RLock()
 	defer imc.mu.RUnlock()
 
+	key, ok := imc.keys[imc.currentID]
+	if !ok {
+		return time.Time{}, nil
+	}
+	return key.Created, nil
+}
+
+// PublicKeys returns all un-expired public keys. Retired keys are returned until all
+// ID tokens signed with them have expired.
+func (imc *InMemoryCache) PublicKeys(ctx context.Context) ([]byte, error) {
+	imc.mu.Lock()
+	defer imc.mu.Unlock()
+
 	var jwks jose.JSONWebKeySet
-	for _, key := range imc.keys {
+	for id, key := range imc.keys {
+		if !key.Retired.IsZero() && time.Since(key.Retired) > idTokenLifetime {
+			delete(imc.keys, id)
+			continue
+		}
 		jwks.Keys = append(jwks.Keys, jose.JSONWebKey{
 			Key:       key.Key,
 			KeyID:     key.ID,
@@ -102,15 +142,32 @@ func (imc *InMemoryCache) PublicKeys(ctx context.Context) ([]byte, error) {
 }
 
 const (
-	redisCacheDefaultTTL = 1 * time.Hour
+	// redisCacheDefaultTTL must not be shorter than the ID token lifetime, as the TTL is extended
+	// whenever a token is signed and retired keys must remain available until their tokens have expired.
+	redisCacheDefaultTTL = idTokenLifetime
 	redisIDPKeyPrefix    = "idp:keys:"
+	redisIDPSigningKey   = "idp:signing-key"
 )
 
-func NewRedisCache(client *redis.Client) *RedisCache {
-	return &RedisCache{
+type RedisCacheOption func(*RedisCache)
+
+// WithSigningKeyCipher stores the current signing key encrypted in Redis, such that
+// it is shared between all replicas and survives restarts.
+func WithSigningKeyCipher(cipher db.Cipher) RedisCacheOption {
+	return func(rc *RedisCache) {
+		rc.cipher = cipher
+	}
+}
+
+func NewRedisCache(client *redis.Client, opts ...RedisCacheOption) *RedisCache {
+	rc := &RedisCache{
 		Client: client,
 		keyID:  defaultKeyID,
 	}
+	for _, opt := range opts {
+		opt(rc)
+	}
+	return rc
 }
 
 func defaultKeyID(current *rsa.PrivateKey) string {
@@ -121,9 +178,18 @@ type RedisCache struct {
 	Client *redis.Client
 
 	keyID     func(current *rsa.PrivateKey) string
+	cipher    db.Cipher
 	mu        sync.RWMutex
 	current   *rsa.PrivateKey
 	currentID string
+	created   time.Time
+}
+
+// storedSigningKey is the JSON form of the current signing key when stored in Redis
+type storedSigningKey struct {
+	ID      string           `json:"id"`
+	Created time.Time        `json:"created"`
+	Key     db.EncryptedData `json:"key"`
 }
 
 // PublicKeys implements KeyCache
@@ -134,9 +200,13 @@ func (rc *RedisCache) PublicKeys(ctx context.Context) ([]byte, error) {
 		hasCurrentKey = false
 	)
 
-	if rc.current != nil && rc.currentID != "" {
+	rc.mu.RLock()
+	current, currentID := rc.current, rc.currentID
+	rc.mu.RUnlock()
+
+	if current != nil && currentID != "" {
 		hasCurrentKey = true
-		fc, err := serializePublicKeyAsJSONWebKey(rc.currentID, &rc.current.PublicKey)
+		fc, err := serializePublicKeyAsJSONWebKey(currentID, &current.PublicKey)
 		if err != nil {
 			return nil, err
 		}
@@ -147,7 +217,7 @@ func (rc *RedisCache) PublicKeys(ctx context.Context) ([]byte, error) {
 	iter := rc.Client.Scan(ctx, 0, redisIDPKeyPrefix+"*", 0).Iterator()
 	for iter.Next(ctx) {
 		idx := iter.Val()
-		if hasCurrentKey && strings.HasSuffix(idx, rc.currentID) {
+		if hasCurrentKey && strings.HasSuffix(idx, currentID) {
 			// We've already added the public key we hold in memory
 			continue
 		}
@@ -184,42 +254,144 @@ func (rc *RedisCache) Set(ctx context.Context, current *rsa.PrivateKey) error {
 	defer rc.mu.Unlock()
 
 	id := rc.keyID(current)
+	created := time.Now()
 
-	publicKeyJSON, err := serializePublicKeyAsJSONWebKey(id, &current.PublicKey)
+	err := rc.storePublicKey(ctx, id, &current.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	if rc.cipher != nil {
+		err = rc.storeSigningKey(ctx, id, created, current)
+		if err != nil {
+			return err
+		}
+	}
+
+	rc.currentID = id
+	rc.current = current
+	rc.created = created
+
+	return nil
+}
+
+func (rc *RedisCache) storePublicKey(ctx context.Context, id string, key *rsa.PublicKey) error {
+	publicKeyJSON, err := serializePublicKeyAsJSONWebKey(id, key)
 	if err != nil {
 		return err
 	}
 
 	redisKey := fmt.Sprintf("%s%s", redisIDPKeyPrefix, id)
-	err = rc.Client.Set(ctx, redisKey, string(publicKeyJSON), redisCacheDefaultTTL).Err()
+	return rc.Client.Set(ctx, redisKey, string(publicKeyJSON), redisCacheDefaultTTL).Err()
+}
+
+func (rc *RedisCache) storeSigningKey(ctx context.Context, id string, created time.Time, key *rsa.PrivateKey) error {
+	encrypted, err := rc.cipher.Encrypt(x509.MarshalPKCS1PrivateKey(key))
+	if err != nil {
+		return fmt.Errorf("cannot encrypt IDP signing key: %w", err)
+	}
+
+	stored, err := json.Marshal(storedSigningKey{
+		ID:      id,
+		Created: created,
+		Key:     encrypted,
+	})
 	if err != nil {
 		return err
 	}
-	rc.currentID = id
-	rc.current = current
+
+	return rc.Client.Set(ctx, redisIDPSigningKey, string(stored), 0).Err()
+}
+
+// loadSigningKey makes the signing key stored in Redis the current key, if it was set more recently
+// than the current key, e.g. by another replica.
+func (rc *RedisCache) loadSigningKey(ctx context.Context) error {
+	stored, err := rc.Client.Get(ctx, redisIDPSigningKey).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var signingKey storedSigningKey
+	err = json.Unmarshal(stored, &signingKey)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal IDP signing key: %w", err)
+	}
+
+	rc.mu.RLock()
+	upToDate := signingKey.ID == rc.currentID || !signingKey.Created.After(rc.created)
+	rc.mu.RUnlock()
+	if upToDate {
+		return nil
+	}
+
+	decrypted, err := rc.cipher.Decrypt(signingKey.Key)
+	if err != nil {
+		return fmt.Errorf("cannot decrypt IDP signing key: %w", err)
+	}
+	key, err := x509.ParsePKCS1PrivateKey(decrypted)
+	if err != nil {
+		return fmt.Errorf("cannot parse IDP signing key: %w", err)
+	}
+
+	// The public key may have expired already if no token was signed in a while
+	err = rc.storePublicKey(ctx, signingKey.ID, &key.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.current = key
+	rc.currentID = signingKey.ID
+	rc.created = signingKey.Created
 
 	return nil
 }
 
+// Created implements KeyCache. When a signing key cipher is configured, the key stored in Redis
+// becomes the current key if it's more recent.
+func (rc *RedisCache) Created(ctx context.Context) (time.Time, error) {
+	if rc.cipher != nil {
+		err := rc.loadSigningKey(ctx)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.created, nil
+}
+
 // Signer implements KeyCache
 func (rc *RedisCache) Signer(ctx context.Context) (jose.Signer, error) {
-	if rc.current == nil {
+	rc.mu.RLock()
+	current, currentID := rc.current, rc.currentID
+	rc.mu.RUnlock()
+
+	if current == nil {
 		return nil, nil
 	}
 
-	err := rc.Client.Expire(ctx, redisIDPKeyPrefix+rc.currentID, redisCacheDefaultTTL).Err()
+	err := rc.Client.Expire(ctx, redisIDPKeyPrefix+currentID, redisCacheDefaultTTL).Err()
 	if err != nil {
-		log.WithField("keyID", rc.currentID).WithError(err).Warn("cannot extend cached IDP public key TTL")
+		log.WithField("keyID", currentID).WithError(err).Warn("cannot extend cached IDP public key TTL")
 	}
 
 	return jose.NewSigner(jose.SigningKey{
 		Algorithm: jose.RS256,
-		Key:       rc.current,
+		Key:       current,
 	}, &jose.SignerOptions{
 		ExtraHeaders: map[jose.HeaderKey]interface{}{
-			jose.HeaderKey("kid"): rc.currentID,
+			jose.HeaderKey("kid"): currentID,
 		},
 	})
 }
 
-var _ KeyCache = ((*RedisCache)(nil))
+var (
+	_ KeyCache = ((*InMemoryCache)(nil))
+	_ KeyCache = ((*RedisCache)(nil))
+)
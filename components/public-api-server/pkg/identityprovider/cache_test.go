@@ -12,8 +12,10 @@ import (
 	"encoding/json"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/alicebob/miniredis/v2"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
 	"github.com/google/go-cmp/cmp"
 	"github.com/redis/go-redis/v9"
 	"gopkg.in/square/go-jose.v2"
@@ -193,3 +195,111 @@ func TestRedisCacheSigner(t *testing.T) {
 		t.Errorf("Returned signer does not sign with currently set key")
 	}
 }
+
+func TestRedisCacheSigningKeyCipher(t *testing.T) {
+	s := miniredis.RunT(t)
+	cipher := dbtest.CipherSet(t)
+
+	first := NewRedisCache(redis.NewClient(&redis.Options{Addr: s.Addr()}), WithSigningKeyCipher(cipher))
+	second := NewRedisCache(redis.NewClient(&redis.Options{Addr: s.Addr()}), WithSigningKeyCipher(cipher))
+	withoutCipher := NewRedisCache(redis.NewClient(&redis.Options{Addr: s.Addr()}))
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = first.Set(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := s.Get(redisIDPSigningKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var signingKey storedSigningKey
+	err = json.Unmarshal([]byte(stored), &signingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signingKey.ID != first.currentID || signingKey.Key.EncodedData == "" {
+		t.Errorf("Set() did not store the encrypted signing key, got %s", stored)
+	}
+
+	created, err := withoutCipher.Created(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created.IsZero() {
+		t.Error("Created() loaded the stored signing key without a cipher")
+	}
+
+	created, err = second.Created(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created.Equal(first.created) {
+		t.Errorf("Created() = %v, expected the stored signing key's creation time %v", created, first.created)
+	}
+
+	sig, err := second.Signer(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := sig.Sign([]byte("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = signature.Verify(&key.PublicKey)
+	if err != nil {
+		t.Errorf("Signer() does not sign with the stored signing key")
+	}
+}
+
+func TestInMemoryCachePublicKeys(t *testing.T) {
+	cache := NewInMemoryCache()
+	cache.keyID = testKeyID
+
+	var keys []*rsa.PrivateKey
+	for i := 0; i < 2; i++ {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = cache.Set(context.Background(), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+	}
+
+	keyIDs := func() []string {
+		fc, err := cache.PublicKeys(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var jwks jose.JSONWebKeySet
+		err = json.Unmarshal(fc, &jwks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sortKeys(&jwks)
+
+		var res []string
+		for _, k := range jwks.Keys {
+			res = append(res, k.KeyID)
+		}
+		return res
+	}
+
+	retained := []string{testKeyID(keys[0]), testKeyID(keys[1])}
+	sort.Strings(retained)
+	if diff := cmp.Diff(retained, keyIDs()); diff != "" {
+		t.Errorf("PublicKeys() did not retain the retired key (-want +got):\n%s", diff)
+	}
+
+	cache.keys[testKeyID(keys[0])].Retired = time.Now().Add(-idTokenLifetime - time.Minute)
+	if diff := cmp.Diff([]string{testKeyID(keys[1])}, keyIDs()); diff != "" {
+		t.Errorf("PublicKeys() returned a key whose tokens have all expired (-want +got):\n%s", diff)
+	}
+}
@@ -23,35 +23,93 @@ import (
 	"gopkg.in/square/go-jose.v2"
 )
 
-func NewService(issuerBaseURL string, keyCache KeyCache) (*Service, error) {
-	idpKey, err := rsa.GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		return nil, fmt.Errorf("cannot produce IDP private key: %w", err)
-	}
-	err = keyCache.Set(context.Background(), idpKey)
-	if err != nil {
-		return nil, fmt.Errorf("cannot cache IDP key: %w", err)
+const (
+	// idTokenLifetime is the time an ID token is valid for after it was issued
+	idTokenLifetime = 60 * time.Minute
+
+	// DefaultKeyLifetime is the time after which the signing key is rotated, unless configured otherwise
+	DefaultKeyLifetime = 24 * time.Hour
+
+	keyRotationInterval = 1 * time.Minute
+)
+
+// NewService produces a new identity provider which signs ID tokens with keys from keyCache.
+// The signing key is rotated once it's older than keyLifetime, see RunKeyRotation.
+func NewService(issuerBaseURL string, keyCache KeyCache, keyLifetime time.Duration) (*Service, error) {
+	if keyLifetime <= 0 {
+		return nil, fmt.Errorf("key lifetime must be positive")
 	}
 
 	tokenEncryptionCode := make([]byte, 128)
-	_, err = io.ReadFull(rand.Reader, tokenEncryptionCode)
+	_, err := io.ReadFull(rand.Reader, tokenEncryptionCode)
 	if err != nil {
 		return nil, fmt.Errorf("cannot produce random token encryption code: %w", err)
 	}
 
-	return &Service{
+	service := &Service{
 		IssuerBaseURL:       issuerBaseURL,
 		TokenEncryptionCode: tokenEncryptionCode,
+		KeyLifetime:         keyLifetime,
 		keys:                keyCache,
-	}, nil
+	}
+
+	err = service.RotateKeys(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
 }
 
 type Service struct {
 	IssuerBaseURL       string
 	TokenEncryptionCode []byte
+	KeyLifetime         time.Duration
 	keys                KeyCache
 }
 
+// RotateKeys produces a new signing key if there is none yet, or the current one is older than the key lifetime.
+// Retired keys remain part of the JWKS until all tokens signed with them have expired.
+func (kp *Service) RotateKeys(ctx context.Context) error {
+	created, err := kp.keys.Created(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot retrieve current IDP key: %w", err)
+	}
+	if !created.IsZero() && time.Since(created) < kp.KeyLifetime {
+		return nil
+	}
+
+	idpKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return fmt.Errorf("cannot produce IDP private key: %w", err)
+	}
+	err = kp.keys.Set(ctx, idpKey)
+	if err != nil {
+		return fmt.Errorf("cannot cache IDP key: %w", err)
+	}
+
+	log.WithField("previousKeyCreated", created).Info("rotated IDP signing key")
+	return nil
+}
+
+// RunKeyRotation rotates the signing key whenever it exceeds its lifetime. It blocks until ctx is canceled.
+func (kp *Service) RunKeyRotation(ctx context.Context) {
+	ticker := time.NewTicker(keyRotationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := kp.RotateKeys(ctx)
+			if err != nil {
+				log.WithError(err).Error("cannot rotate IDP signing key")
+			}
+		}
+	}
+}
+
 func (kp *Service) Router() http.Handler {
 	mux := chi.NewRouter()
 	mux.Get(oidc.DiscoveryEndpoint, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -144,7 +202,7 @@ func (kp *Service) IDToken(ctx context.Context, org string, audience []string, u
 		return "", fmt.Errorf("user info cannot be nil")
 	}
 
-	claims := oidc.NewIDTokenClaims(kp.IssuerBaseURL, user.GetSubject(), audience, time.Now().Add(60*time.Minute), time.Now(), "", "", nil, audience[0], 0)
+	claims := oidc.NewIDTokenClaims(kp.IssuerBaseURL, user.GetSubject(), audience, time.Now().Add(idTokenLifetime), time.Now(), "", "", nil, audience[0], 0)
 	claims.SetUserinfo(user)
 
 	codeHash, err := oidc.ClaimHash(string(kp.TokenEncryptionCode), jose.RS256)
@@ -7,10 +7,12 @@ package identityprovider
 import (
 	"context"
 	"crypto"
+	"crypto/rsa"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/go-cmp/cmp"
@@ -53,7 +55,7 @@ func TestRouter(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
-			service, err := NewService(issuerBaseURL, NewInMemoryCache())
+			service, err := NewService(issuerBaseURL, NewInMemoryCache(), DefaultKeyLifetime)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -120,7 +122,7 @@ func TestIDToken(t *testing.T) {
 			Expectation: Expectation{
 				Token: &jwt.Token{
 					Method: &jwt.SigningMethodRSA{Name: "RS256", Hash: crypto.SHA256},
-					Header: map[string]interface{}{"alg": string(jose.RS256)},
+					Header: map[string]interface{}{"alg": string(jose.RS256), "kid": "test-key"},
 					Claims: jwt.MapClaims{
 						"aud":  []any{string("some.audience.com")},
 						"azp":  string("some.audience.com"),
@@ -137,7 +139,8 @@ func TestIDToken(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
 			cache := NewInMemoryCache()
-			service, err := NewService(issuerBaseURL, cache)
+			cache.keyID = func(*rsa.PrivateKey) string { return "test-key" }
+			service, err := NewService(issuerBaseURL, cache, DefaultKeyLifetime)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -161,6 +164,47 @@ func TestIDToken(t *testing.T) {
 	}
 }
 
+func TestRotateKeys(t *testing.T) {
+	cache := NewInMemoryCache()
+	service, err := NewService(issuerBaseURL, cache, DefaultKeyLifetime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	initialKeyID := cache.currentID
+	if initialKeyID == "" {
+		t.Fatal("NewService() did not set a signing key")
+	}
+
+	err = service.RotateKeys(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cache.currentID != initialKeyID {
+		t.Error("RotateKeys() rotated a key which hasn't exceeded its lifetime")
+	}
+
+	service.KeyLifetime = time.Nanosecond
+	err = service.RotateKeys(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cache.currentID == initialKeyID {
+		t.Fatal("RotateKeys() did not rotate a key which exceeded its lifetime")
+	}
+
+	token, err := service.IDToken(context.Background(), "", []string{"some.audience.com"}, oidc.NewUserInfo())
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsedToken, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) { return &cache.current.PublicKey, nil })
+	if err != nil {
+		t.Fatalf("cannot parse IDToken result: %v", err)
+	}
+	if kid := parsedToken.Header["kid"]; kid != cache.currentID {
+		t.Errorf("IDToken() was signed with key %v, expected the rotated key %s", kid, cache.currentID)
+	}
+}
+
 func cmpJWTToken() []cmp.Option {
 	return []cmp.Option{
 		cmpopts.IgnoreFields(jwt.Token{}, "Raw", "Signature"),
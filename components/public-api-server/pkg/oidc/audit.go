@@ -0,0 +1,46 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package oidc
+
+import (
+	"context"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/uuid"
+)
+
+// Actions are named like the corresponding actions of the API, such that audit logs can be filtered by action
+// regardless of whether a membership was changed by a user or based on OIDC claims.
+const (
+	auditLogActionJoinTeam         = "joinTeam"
+	auditLogActionUpdateTeamMember = "updateTeamMember"
+	auditLogActionDeleteTeamMember = "deleteTeamMember"
+)
+
+// recordAuditLog records a membership change based on the claims of the identity provider. The change has already
+// been performed, hence failures are only logged. The actor is empty, because the change is not performed by a user.
+// Instead, the client config whose claim mappings caused the change is recorded.
+func (s *Service) recordAuditLog(ctx context.Context, config *ClientConfig, teamID uuid.UUID, action string, args map[string]any) {
+	logger := log.Extract(ctx).WithField("action", action)
+
+	args["clientConfigId"] = config.ID
+	entry := db.AuditLog{
+		Timestamp:      time.Now().UTC(),
+		OrganizationID: teamID.String(),
+		Action:         action,
+	}
+	err := entry.SetArgs(args)
+	if err != nil {
+		logger.WithError(err).Error("Failed to record audit log.")
+		return
+	}
+
+	_, err = s.auditLog.Write(ctx, entry)
+	if err != nil {
+		logger.WithError(err).Error("Failed to record audit log.")
+	}
+}
@@ -79,8 +79,15 @@ func (s *Service) OAuth2Middleware(next http.Handler) http.Handler {
 			return
 		}
 
+		// PKCE code verifier, written during flow start request
+		verifierCookie, err := r.Cookie(verifierCookieName)
+		if err != nil {
+			http.Error(rw, "verifier cookie not found", http.StatusBadRequest)
+			return
+		}
+
 		config.OAuth2Config.RedirectURL = getCallbackURL(r.Host)
-		oauth2Token, err := config.OAuth2Config.Exchange(r.Context(), code)
+		oauth2Token, err := s.Exchange(r.Context(), config, code, oauth2.SetAuthURLParam("code_verifier", verifierCookie.Value))
 		if err != nil {
 			http.Error(rw, "failed to exchange token: "+err.Error(), http.StatusInternalServerError)
 			return
@@ -29,8 +29,9 @@ func Router(s *Service) *chi.Mux {
 }
 
 const (
-	stateCookieName = "state"
-	nonceCookieName = "nonce"
+	stateCookieName    = "state"
+	nonceCookieName    = "nonce"
+	verifierCookieName = "verifier"
 )
 
 func (s *Service) getStartHandler() http.HandlerFunc {
@@ -66,6 +67,7 @@ func (s *Service) getStartHandler() http.HandlerFunc {
 
 		http.SetCookie(rw, newCallbackCookie(r, nonceCookieName, startParams.Nonce))
 		http.SetCookie(rw, newCallbackCookie(r, stateCookieName, startParams.State))
+		http.SetCookie(rw, newCallbackCookie(r, verifierCookieName, startParams.CodeVerifier))
 
 		http.Redirect(rw, r, startParams.AuthCodeURL, http.StatusTemporaryRedirect)
 	}
@@ -130,13 +132,21 @@ func (s *Service) getCallbackHandler() http.HandlerFunc {
 			}
 		}
 
-		cookie, err := s.CreateSession(r.Context(), result, config.OrganizationID)
+		session, err := s.CreateSession(r.Context(), result, config.OrganizationID)
 		if err != nil {
 			log.Warn("Failed to create session: " + err.Error())
 			http.Error(rw, "Failed to create session", http.StatusInternalServerError)
 			return
 		}
-		http.SetCookie(rw, cookie)
+
+		// The session exists at this point, so a failed sync must not fail the login. Memberships are
+		// reconciled again on the next login.
+		err = s.SyncTeamMemberships(r.Context(), config, session.UserID, result.Claims)
+		if err != nil {
+			log.WithError(err).WithField("userId", session.UserID).Error("Failed to sync team memberships based on OIDC claims.")
+		}
+
+		http.SetCookie(rw, session.Cookie)
 		http.Redirect(rw, r, oauth2Result.ReturnToURL, http.StatusTemporaryRedirect)
 	}
 }
@@ -44,6 +44,17 @@ func TestRoute_start(t *testing.T) {
 	redirectUrl, err := resp.Location()
 	require.NoError(t, err)
 	require.Contains(t, redirectUrl.String(), idpUrl, "should redirect to IdP")
+	require.Equal(t, "S256", redirectUrl.Query().Get("code_challenge_method"), "should use PKCE")
+	require.NotEmpty(t, redirectUrl.Query().Get("code_challenge"), "should contain code challenge")
+
+	var verifierCookie *http.Cookie
+	for _, c := range resp.Cookies() {
+		if c.Name == verifierCookieName {
+			verifierCookie = c
+		}
+	}
+	require.NotNil(t, verifierCookie, "should set verifier cookie")
+	require.Equal(t, codeChallengeS256(verifierCookie.Value), redirectUrl.Query().Get("code_challenge"))
 
 	state := redirectUrl.Query().Get("state")
 	require.NotEmpty(t, state, "should contain state param")
@@ -87,6 +98,9 @@ func TestRoute_callback(t *testing.T) {
 	req.AddCookie(&http.Cookie{
 		Name: "nonce", Value: "111", MaxAge: 60,
 	})
+	req.AddCookie(&http.Cookie{
+		Name: "verifier", Value: "verifier", MaxAge: 60,
+	})
 	resp, err := client.Do(req)
 	require.NoError(t, err)
 	defer resp.Body.Close()
@@ -8,11 +8,14 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
@@ -28,8 +31,9 @@ import (
 )
 
 type Service struct {
-	dbConn *gorm.DB
-	cipher db.Cipher
+	dbConn   *gorm.DB
+	cipher   db.Cipher
+	auditLog *db.AuditLogWriter
 
 	// jwts
 	stateExpiry    time.Duration
@@ -45,14 +49,21 @@ type ClientConfig struct {
 	ID             string
 	OrganizationID string
 	Issuer         string
+	Active         bool
 	OAuth2Config   *oauth2.Config
 	VerifierConfig *goidc.Config
+	ClaimMappings  []db.OIDCClaimMapping
+	EmailDomains   []string
+
+	// PreviousClientSecret is set during the grace period after a rotation of the client secret.
+	PreviousClientSecret string
 }
 
 type StartParams struct {
-	State       string
-	Nonce       string
-	AuthCodeURL string
+	State        string
+	Nonce        string
+	CodeVerifier string
+	AuthCodeURL  string
 }
 
 type AuthFlowResult struct {
@@ -60,12 +71,13 @@ type AuthFlowResult struct {
 	Claims  map[string]interface{} `json:"claims"`
 }
 
-func NewService(sessionServiceAddress string, dbConn *gorm.DB, cipher db.Cipher, signerVerifier jws.SignerVerifier, stateExpiry time.Duration) *Service {
+func NewService(sessionServiceAddress string, dbConn *gorm.DB, cipher db.Cipher, auditLog *db.AuditLogWriter, signerVerifier jws.SignerVerifier, stateExpiry time.Duration) *Service {
 	return &Service{
 		sessionServiceAddress: sessionServiceAddress,
 
-		dbConn: dbConn,
-		cipher: cipher,
+		dbConn:   dbConn,
+		cipher:   cipher,
+		auditLog: auditLog,
 
 		signerVerifier: signerVerifier,
 		stateExpiry:    stateExpiry,
@@ -85,17 +97,33 @@ func (s *Service) GetStartParams(config *ClientConfig, redirectURL string, state
 		return nil, fmt.Errorf("failed to create nonce")
 	}
 
+	// PKCE, see https://www.rfc-editor.org/rfc/rfc7636
+	codeVerifier, err := randString(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create code verifier")
+	}
+
 	// Configuring `AuthCodeOption`s, e.g. nonce
 	config.OAuth2Config.RedirectURL = redirectURL
-	authCodeURL := config.OAuth2Config.AuthCodeURL(state, goidc.Nonce(nonce))
+	authCodeURL := config.OAuth2Config.AuthCodeURL(state,
+		goidc.Nonce(nonce),
+		oauth2.SetAuthURLParam("code_challenge", codeChallengeS256(codeVerifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
 
 	return &StartParams{
-		AuthCodeURL: authCodeURL,
-		State:       state,
-		Nonce:       nonce,
+		AuthCodeURL:  authCodeURL,
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
 	}, nil
 }
 
+func codeChallengeS256(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
 func (s *Service) encodeStateParam(state StateParams) (string, error) {
 	now := time.Now().UTC()
 	expiry := now.Add(s.stateExpiry)
@@ -129,11 +157,16 @@ func randString(size int) (string, error) {
 func (s *Service) GetClientConfigFromStartRequest(r *http.Request) (*ClientConfig, error) {
 	orgSlug := r.URL.Query().Get("orgSlug")
 	if orgSlug != "" {
-		dbEntry, err := db.GetOIDCClientConfigByOrgSlug(r.Context(), s.dbConn, orgSlug)
+		dbEntries, err := db.ListOIDCClientConfigsByOrgSlug(r.Context(), s.dbConn, orgSlug)
 		if err != nil {
 			return nil, fmt.Errorf("Failed to find OIDC clients: %w", err)
 		}
 
+		dbEntry, err := s.selectClientConfig(dbEntries, r.URL.Query().Get("idp"), r.URL.Query().Get("email"))
+		if err != nil {
+			return nil, err
+		}
+
 		config, err := s.convertClientConfig(r.Context(), dbEntry)
 		if err != nil {
 			return nil, fmt.Errorf("Failed to find OIDC clients: %w", err)
@@ -158,6 +191,67 @@ func (s *Service) GetClientConfigFromStartRequest(r *http.Request) (*ClientConfi
 	return nil, fmt.Errorf("failed to find OIDC config")
 }
 
+// selectClientConfig selects the config of an organization to sign in with. An explicit idp (the ID of a config) takes
+// precedence, otherwise the active config with the domain of the email is selected. Without a matching domain, the only
+// active config or the first active config without email domains is selected. Inactive configs are only considered
+// if the organization has no active config.
+func (s *Service) selectClientConfig(configs []db.OIDCClientConfig, idp, email string) (db.OIDCClientConfig, error) {
+	if idp != "" {
+		for _, c := range configs {
+			if c.ID.String() == idp {
+				return c, nil
+			}
+		}
+		return db.OIDCClientConfig{}, fmt.Errorf("OIDC client %s not found", idp)
+	}
+
+	var candidates []db.OIDCClientConfig
+	for _, c := range configs {
+		if c.Active {
+			candidates = append(candidates, c)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = configs
+	}
+	if len(candidates) == 0 {
+		return db.OIDCClientConfig{}, fmt.Errorf("no OIDC clients configured")
+	}
+
+	var catchAll []db.OIDCClientConfig
+	domain := emailDomain(email)
+	for _, c := range candidates {
+		spec, err := c.Data.Decrypt(s.cipher)
+		if err != nil {
+			return db.OIDCClientConfig{}, fmt.Errorf("failed to decrypt OIDC client config %s: %w", c.ID.String(), err)
+		}
+		if len(spec.EmailDomains) == 0 {
+			catchAll = append(catchAll, c)
+		}
+		for _, d := range spec.EmailDomains {
+			if domain != "" && strings.EqualFold(d, domain) {
+				return c, nil
+			}
+		}
+	}
+
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	if len(catchAll) > 0 {
+		return catchAll[0], nil
+	}
+	return db.OIDCClientConfig{}, fmt.Errorf("several OIDC clients configured, the idp or email parameter is required")
+}
+
+func emailDomain(email string) string {
+	i := strings.LastIndex(email, "@")
+	if i < 0 {
+		return ""
+	}
+	return email[i+1:]
+}
+
 func (s *Service) GetClientConfigFromCallbackRequest(r *http.Request) (*ClientConfig, *StateParams, error) {
 	stateParam := r.URL.Query().Get("state")
 	if stateParam == "" {
@@ -214,10 +308,16 @@ func (s *Service) convertClientConfig(ctx context.Context, dbEntry db.OIDCClient
 		return ClientConfig{}, err
 	}
 
+	var previousClientSecret string
+	if spec.PreviousClientSecretExpiresAt != nil && time.Now().Before(*spec.PreviousClientSecretExpiresAt) {
+		previousClientSecret = spec.PreviousClientSecret
+	}
+
 	return ClientConfig{
 		ID:             dbEntry.ID.String(),
 		OrganizationID: dbEntry.OrganizationID.String(),
 		Issuer:         dbEntry.Issuer,
+		Active:         dbEntry.Active,
 		OAuth2Config: &oauth2.Config{
 			ClientID:     spec.ClientID,
 			ClientSecret: spec.ClientSecret,
@@ -227,9 +327,36 @@ func (s *Service) convertClientConfig(ctx context.Context, dbEntry db.OIDCClient
 		VerifierConfig: &goidc.Config{
 			ClientID: spec.ClientID,
 		},
+		ClaimMappings:        spec.ClaimMappings,
+		EmailDomains:         spec.EmailDomains,
+		PreviousClientSecret: previousClientSecret,
 	}, nil
 }
 
+// Exchange exchanges the authorization code for a token. During the grace period after a rotation of the client
+// secret, the previous secret is used if the IdP rejects the current one.
+func (s *Service) Exchange(ctx context.Context, config *ClientConfig, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	token, err := config.OAuth2Config.Exchange(ctx, code, opts...)
+	if err == nil || config.PreviousClientSecret == "" {
+		return token, err
+	}
+
+	var retrieveErr *oauth2.RetrieveError
+	if !errors.As(err, &retrieveErr) {
+		return nil, err
+	}
+
+	previous := *config.OAuth2Config
+	previous.ClientSecret = config.PreviousClientSecret
+	token, previousErr := previous.Exchange(ctx, code, opts...)
+	if previousErr != nil {
+		return nil, err
+	}
+
+	log.WithField("clientConfigId", config.ID).Warn("Exchanged OIDC code with the previous client secret, the IdP has not been updated with the rotated secret yet.")
+	return token, nil
+}
+
 type AuthenticateParams struct {
 	Config           *ClientConfig
 	OAuth2Result     *OAuth2Result
@@ -268,7 +395,12 @@ func (s *Service) Authenticate(ctx context.Context, params AuthenticateParams) (
 	}, nil
 }
 
-func (s *Service) CreateSession(ctx context.Context, flowResult *AuthFlowResult, organizationId string) (*http.Cookie, error) {
+type Session struct {
+	Cookie *http.Cookie
+	UserID string
+}
+
+func (s *Service) CreateSession(ctx context.Context, flowResult *AuthFlowResult, organizationId string) (*Session, error) {
 	type CreateSessionPayload struct {
 		AuthFlowResult
 		OrganizationID string `json:"organizationId"`
@@ -288,12 +420,22 @@ func (s *Service) CreateSession(ctx context.Context, flowResult *AuthFlowResult,
 		return nil, err
 	}
 
+	defer res.Body.Close()
+
 	if res.StatusCode == http.StatusOK {
 		cookies := res.Cookies()
-		if len(cookies) == 1 {
-			return cookies[0], nil
+		if len(cookies) != 1 {
+			return nil, fmt.Errorf("unexpected count of cookies: %v", len(cookies))
+		}
+
+		var body struct {
+			UserID string `json:"userId"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode session response: %w", err)
 		}
-		return nil, fmt.Errorf("unexpected count of cookies: %v", len(cookies))
+		return &Session{Cookie: cookies[0], UserID: body.UserID}, nil
 	}
 	message, _ := io.ReadAll(res.Body)
 	log.WithField("create-session-error", message).Error("Failed to create session (via server)")
@@ -68,6 +68,96 @@ func TestGetStartParams(t *testing.T) {
 	require.Contains(t, params.AuthCodeURL, url.QueryEscape(redirectURL))
 	require.Contains(t, params.AuthCodeURL, url.QueryEscape(params.Nonce))
 	require.Contains(t, params.AuthCodeURL, url.QueryEscape(params.State))
+
+	require.NotEmpty(t, params.CodeVerifier)
+	require.Contains(t, params.AuthCodeURL, "code_challenge_method=S256")
+	require.Contains(t, params.AuthCodeURL, "code_challenge="+codeChallengeS256(params.CodeVerifier))
+}
+
+func TestSelectClientConfig(t *testing.T) {
+	service, _ := setupOIDCServiceForTests(t)
+	newConfig := func(active bool, domains ...string) db.OIDCClientConfig {
+		data, err := db.EncryptJSON(dbtest.CipherSet(t), db.OIDCSpec{EmailDomains: domains})
+		require.NoError(t, err)
+		return db.OIDCClientConfig{ID: uuid.New(), Active: active, Data: data}
+	}
+
+	var (
+		inactive  = newConfig(false)
+		catchAll  = newConfig(true)
+		gitpod    = newConfig(true, "gitpod.io")
+		example   = newConfig(true, "example.com")
+		exampleIn = newConfig(false, "example.com")
+	)
+
+	testCases := []struct {
+		Name          string
+		Configs       []db.OIDCClientConfig
+		IdP           string
+		Email         string
+		Expected      db.OIDCClientConfig
+		ExpectedError bool
+	}{
+		{Name: "no configs", ExpectedError: true},
+		{Name: "explicit idp", Configs: []db.OIDCClientConfig{gitpod, example}, IdP: example.ID.String(), Email: "foo@gitpod.io", Expected: example},
+		{Name: "unknown idp", Configs: []db.OIDCClientConfig{gitpod}, IdP: uuid.NewString(), ExpectedError: true},
+		{Name: "email domain", Configs: []db.OIDCClientConfig{catchAll, gitpod, example}, Email: "foo@Example.com", Expected: example},
+		{Name: "inactive config with email domain", Configs: []db.OIDCClientConfig{catchAll, exampleIn}, Email: "foo@example.com", Expected: catchAll},
+		{Name: "single active config", Configs: []db.OIDCClientConfig{inactive, gitpod}, Email: "foo@example.com", Expected: gitpod},
+		{Name: "catch-all config", Configs: []db.OIDCClientConfig{gitpod, catchAll, example}, Expected: catchAll},
+		{Name: "only inactive configs", Configs: []db.OIDCClientConfig{inactive}, Expected: inactive},
+		{Name: "ambiguous configs", Configs: []db.OIDCClientConfig{gitpod, example}, Email: "foo@gitlab.com", ExpectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			config, err := service.selectClientConfig(tc.Configs, tc.IdP, tc.Email)
+			if tc.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected.ID, config.ID)
+		})
+	}
+}
+
+func TestExchange_previousClientSecret(t *testing.T) {
+	router := chi.NewRouter()
+	ts := httptest.NewServer(router)
+	t.Cleanup(ts.Close)
+	router.Post("/token", func(w http.ResponseWriter, r *http.Request) {
+		_, secret, ok := r.BasicAuth()
+		if !ok {
+			secret = r.FormValue("client_secret")
+		}
+		if secret != "previous-secret" {
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "invalid_client"}`))
+			return
+		}
+		w.Header().Add("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "token", "token_type": "Bearer"}`))
+	})
+
+	service, _ := setupOIDCServiceForTests(t)
+	config := &ClientConfig{
+		OAuth2Config: &oauth2.Config{
+			ClientID:     "client-id",
+			ClientSecret: "new-secret",
+			Endpoint:     oauth2.Endpoint{TokenURL: ts.URL + "/token", AuthStyle: oauth2.AuthStyleInParams},
+		},
+	}
+
+	_, err := service.Exchange(context.Background(), config, "code")
+	require.Error(t, err, "should fail without previous secret")
+
+	config.PreviousClientSecret = "previous-secret"
+	token, err := service.Exchange(context.Background(), config, "code")
+	require.NoError(t, err)
+	require.Equal(t, "token", token.AccessToken)
+	require.Equal(t, "new-secret", config.OAuth2Config.ClientSecret, "should not modify config")
 }
 
 func TestGetClientConfigFromStartRequest(t *testing.T) {
@@ -80,6 +170,15 @@ func TestGetClientConfigFromStartRequest(t *testing.T) {
 	})
 	configID := config.ID.String()
 
+	secondData, err := db.EncryptJSON(dbtest.CipherSet(t), db.OIDCSpec{EmailDomains: []string{"gitpod.io"}})
+	require.NoError(t, err)
+	secondConfig := dbtest.CreateOIDCClientConfigs(t, dbConn, db.OIDCClientConfig{
+		OrganizationID: team.ID,
+		Issuer:         issuer,
+		Data:           secondData,
+	})[0]
+	secondConfigID := secondConfig.ID.String()
+
 	testCases := []struct {
 		Location      string
 		ExpectedError bool
@@ -105,6 +204,21 @@ func TestGetClientConfigFromStartRequest(t *testing.T) {
 			ExpectedError: false,
 			ExpectedId:    configID,
 		},
+		{
+			Location:      "/start?orgSlug=" + team.Slug + "&idp=" + secondConfigID,
+			ExpectedError: false,
+			ExpectedId:    secondConfigID,
+		},
+		{
+			Location:      "/start?orgSlug=" + team.Slug + "&email=" + url.QueryEscape("foo@gitpod.io"),
+			ExpectedError: false,
+			ExpectedId:    secondConfigID,
+		},
+		{
+			Location:      "/start?orgSlug=" + team.Slug + "&idp=" + uuid.NewString(),
+			ExpectedError: true,
+			ExpectedId:    "",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -235,7 +349,7 @@ func setupOIDCServiceForTests(t *testing.T) (*Service, *gorm.DB) {
 	keyset := jwstest.GenerateKeySet(t)
 	signerVerifier := jws.NewHS256FromKeySet(keyset)
 
-	service := NewService(sessionServerAddress, dbConn, cipher, signerVerifier, 5*time.Minute)
+	service := NewService(sessionServerAddress, dbConn, cipher, db.NewAuditLogWriter(dbConn), signerVerifier, 5*time.Minute)
 	service.skipVerifyIdToken = true
 	return service, dbConn
 }
@@ -267,6 +381,8 @@ func createConfig(t *testing.T, dbConn *gorm.DB, config *ClientConfig) (db.OIDCC
 	return created, team
 }
 
+const fakeSessionUserID = "8f4b6fbe-cc1b-4f4e-9f0c-1b9a8f6c2a3e"
+
 func newFakeSessionServer(t *testing.T) string {
 	router := chi.NewRouter()
 	ts := httptest.NewServer(router)
@@ -284,7 +400,9 @@ func newFakeSessionServer(t *testing.T) string {
 			HttpOnly: true,
 			Expires:  time.Now().AddDate(0, 0, 1),
 		})
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"sessionId":"session-1","userId":"` + fakeSessionUserID + `"}`))
 	})
 
 	t.Cleanup(ts.Close)
@@ -391,3 +509,14 @@ func newFakeIdP(t *testing.T) string {
 	t.Cleanup(ts.Close)
 	return url
 }
+
+func TestCreateSession(t *testing.T) {
+	service := NewService(newFakeSessionServer(t), nil, nil, nil, nil, 5*time.Minute)
+
+	session, err := service.CreateSession(context.Background(), &AuthFlowResult{
+		Claims: map[string]interface{}{"sub": "123"},
+	}, uuid.NewString())
+	require.NoError(t, err)
+	require.Equal(t, fakeSessionUserID, session.UserID)
+	require.Equal(t, "test-cookie", session.Cookie.Name)
+}
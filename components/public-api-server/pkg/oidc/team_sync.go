@@ -0,0 +1,157 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/uuid"
+)
+
+var roleRank = map[db.TeamMembershipRole]int{
+	db.TeamMembershipRole_Member: 1,
+	db.TeamMembershipRole_Owner:  2,
+}
+
+// desiredTeamRole computes the role of the user in the organization of the config from its claim mappings.
+// If several mappings match, the highest role wins. If no mapping matches, the role is empty.
+func desiredTeamRole(config *ClientConfig, claims map[string]interface{}) (db.TeamMembershipRole, error) {
+	var res db.TeamMembershipRole
+	for _, m := range config.ClaimMappings {
+		if _, ok := roleRank[m.Role]; !ok {
+			return "", fmt.Errorf("claim mapping for %s=%s has unknown role %q", m.Claim, m.Value, m.Role)
+		}
+
+		if claimContains(claims[m.Claim], m.Value) && roleRank[m.Role] > roleRank[res] {
+			res = m.Role
+		}
+	}
+	return res, nil
+}
+
+// claimContains returns true if the claim is the value, or a list containing the value.
+func claimContains(claim interface{}, value string) bool {
+	switch c := claim.(type) {
+	case string:
+		return c == value
+	case []string:
+		for _, v := range c {
+			if v == value {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, v := range c {
+			if s, ok := v.(string); ok && s == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SyncTeamMemberships reconciles the membership of the user in the organization of the config with its claim
+// mappings. Users who no longer match any mapping are removed from the organization. The last owner of the
+// organization is never demoted or removed.
+func (s *Service) SyncTeamMemberships(ctx context.Context, config *ClientConfig, userID string, claims map[string]interface{}) error {
+	if len(config.ClaimMappings) == 0 {
+		return nil
+	}
+
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID %q: %w", userID, err)
+	}
+	orgID, err := uuid.Parse(config.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("invalid organization ID %q: %w", config.OrganizationID, err)
+	}
+	role, err := desiredTeamRole(config, claims)
+	if err != nil {
+		return err
+	}
+
+	return s.syncTeamMembership(ctx, config, uid, orgID, role)
+}
+
+func (s *Service) syncTeamMembership(ctx context.Context, config *ClientConfig, userID, teamID uuid.UUID, role db.TeamMembershipRole) error {
+	logger := log.WithField("userId", userID.String()).WithField("teamId", teamID.String())
+
+	team, err := db.GetTeam(ctx, s.dbConn, teamID)
+	if errors.Is(err, db.ErrorNotFound) || (err == nil && team.MarkedDeleted) {
+		logger.Warn("Organization of OIDC client config does not exist, skipping team sync.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	membership, err := db.GetTeamMembership(ctx, s.dbConn, userID, teamID)
+	if errors.Is(err, db.ErrorNotFound) {
+		if role == "" {
+			return nil
+		}
+		_, err = db.CreateTeamMembership(ctx, s.dbConn, db.TeamMembership{
+			ID:     uuid.New(),
+			TeamID: teamID,
+			UserID: userID,
+			Role:   role,
+		})
+		if err != nil {
+			return err
+		}
+		logger.WithField("role", role).Info("Added user to team based on OIDC claims.")
+		s.recordAuditLog(ctx, config, teamID, auditLogActionJoinTeam, map[string]any{
+			"userId": userID.String(),
+			"role":   role,
+		})
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if membership.Role == role {
+		return nil
+	}
+
+	if membership.Role == db.TeamMembershipRole_Owner {
+		owners, err := db.CountTeamMembershipsWithRole(ctx, s.dbConn, teamID, db.TeamMembershipRole_Owner)
+		if err != nil {
+			return err
+		}
+		if owners <= 1 {
+			logger.Warn("Not demoting or removing the last owner of the team based on OIDC claims.")
+			return nil
+		}
+	}
+
+	if role == "" {
+		err = db.DeleteTeamMembership(ctx, s.dbConn, membership.ID)
+		if err != nil {
+			return err
+		}
+		logger.Info("Removed user from team based on OIDC claims.")
+		s.recordAuditLog(ctx, config, teamID, auditLogActionDeleteTeamMember, map[string]any{
+			"userId": userID.String(),
+		})
+		return nil
+	}
+
+	err = db.UpdateTeamMembershipRole(ctx, s.dbConn, membership.ID, role)
+	if err != nil {
+		return err
+	}
+	logger.WithField("role", role).Info("Updated role of user in team based on OIDC claims.")
+	s.recordAuditLog(ctx, config, teamID, auditLogActionUpdateTeamMember, map[string]any{
+		"userId": userID.String(),
+		"role":   role,
+	})
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDesiredTeamRole(t *testing.T) {
+	config := &ClientConfig{
+		ID:             uuid.NewString(),
+		OrganizationID: uuid.NewString(),
+		ClaimMappings: []db.OIDCClaimMapping{
+			{Claim: "groups", Value: "eng", Role: db.TeamMembershipRole_Member},
+			{Claim: "groups", Value: "eng-admins", Role: db.TeamMembershipRole_Owner},
+			{Claim: "department", Value: "platform", Role: db.TeamMembershipRole_Member},
+		},
+	}
+
+	for _, test := range []struct {
+		Name        string
+		Claims      map[string]interface{}
+		Expectation db.TeamMembershipRole
+	}{
+		{
+			Name:        "no matching claims",
+			Claims:      map[string]interface{}{"groups": []interface{}{"sales"}},
+			Expectation: "",
+		},
+		{
+			Name:        "highest role wins",
+			Claims:      map[string]interface{}{"groups": []interface{}{"eng", "eng-admins"}},
+			Expectation: db.TeamMembershipRole_Owner,
+		},
+		{
+			Name:        "string claim",
+			Claims:      map[string]interface{}{"department": "platform"},
+			Expectation: db.TeamMembershipRole_Member,
+		},
+		{
+			Name:        "non-string list entries are ignored",
+			Claims:      map[string]interface{}{"groups": []interface{}{42, true}},
+			Expectation: "",
+		},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := desiredTeamRole(config, test.Claims)
+			require.NoError(t, err)
+			require.Equal(t, test.Expectation, act)
+		})
+	}
+}
+
+func TestDesiredTeamRole_InvalidMapping(t *testing.T) {
+	_, err := desiredTeamRole(&ClientConfig{
+		OrganizationID: uuid.NewString(),
+		ClaimMappings:  []db.OIDCClaimMapping{{Claim: "groups", Value: "eng", Role: "admin"}},
+	}, nil)
+	require.Error(t, err)
+}
+
+func TestSyncTeamMemberships(t *testing.T) {
+	ctx := context.Background()
+	service, dbConn := setupOIDCServiceForTests(t)
+
+	newTeam := func() uuid.UUID {
+		team, err := db.CreateTeam(ctx, dbConn, db.Team{ID: uuid.New(), Name: "Team", Slug: uuid.NewString()})
+		require.NoError(t, err)
+		return team.ID
+	}
+	addMember := func(teamID, userID uuid.UUID, role db.TeamMembershipRole) {
+		_, err := db.CreateTeamMembership(ctx, dbConn, db.TeamMembership{ID: uuid.New(), TeamID: teamID, UserID: userID, Role: role})
+		require.NoError(t, err)
+	}
+	roleOf := func(teamID, userID uuid.UUID) db.TeamMembershipRole {
+		m, err := db.GetTeamMembership(ctx, dbConn, userID, teamID)
+		if err != nil {
+			require.ErrorIs(t, err, db.ErrorNotFound)
+			return ""
+		}
+		return m.Role
+	}
+
+	membershipID := func(teamID, userID uuid.UUID) uuid.UUID {
+		m, err := db.GetTeamMembership(ctx, dbConn, userID, teamID)
+		require.NoError(t, err)
+		return m.ID
+	}
+
+	orgID, otherTeamID := newTeam(), newTeam()
+	userID, otherOwnerID := uuid.New(), uuid.New()
+	addMember(orgID, userID, db.TeamMembershipRole_Member)
+	addMember(orgID, otherOwnerID, db.TeamMembershipRole_Owner)
+	addMember(otherTeamID, userID, db.TeamMembershipRole_Member)
+
+	config := &ClientConfig{
+		ID:             uuid.NewString(),
+		OrganizationID: orgID.String(),
+		ClaimMappings: []db.OIDCClaimMapping{
+			{Claim: "groups", Value: "eng", Role: db.TeamMembershipRole_Member},
+			{Claim: "groups", Value: "admins", Role: db.TeamMembershipRole_Owner},
+		},
+	}
+	t.Cleanup(func() {
+		require.NoError(t, dbConn.Where("organizationId = ?", orgID.String()).Delete(&db.AuditLog{}).Error)
+	})
+	auditLogs := func(action string) []map[string]string {
+		logs, err := db.ListAuditLogsForOrganization(ctx, dbConn, orgID, db.ListAuditLogsFilter{Action: action}, db.Pagination{PageSize: 25})
+		require.NoError(t, err)
+
+		var res []map[string]string
+		for _, entry := range logs.Results {
+			require.Empty(t, entry.ActorID)
+
+			var args map[string]string
+			require.NoError(t, json.Unmarshal(entry.Args, &args))
+			require.Equal(t, userID.String(), args["userId"])
+			require.Equal(t, config.ID, args["clientConfigId"])
+			res = append(res, args)
+		}
+		return res
+	}
+
+	err := service.SyncTeamMemberships(ctx, config, userID.String(), map[string]interface{}{
+		"groups": []interface{}{"eng", "admins"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.TeamMembershipRole_Owner, roleOf(orgID, userID), "promoted in the organization")
+
+	err = service.SyncTeamMemberships(ctx, config, userID.String(), map[string]interface{}{
+		"groups": []interface{}{"eng"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.TeamMembershipRole_Member, roleOf(orgID, userID), "demoted when the owner group is deprovisioned")
+
+	err = service.SyncTeamMemberships(ctx, config, userID.String(), map[string]interface{}{
+		"groups": []interface{}{},
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.TeamMembershipRole(""), roleOf(orgID, userID), "removed when no group matches any more")
+	require.Equal(t, db.TeamMembershipRole_Owner, roleOf(orgID, otherOwnerID), "unrelated memberships are unchanged")
+	require.Equal(t, db.TeamMembershipRole_Member, roleOf(otherTeamID, userID), "teams outside of the organization are not changed")
+
+	var roles []string
+	for _, args := range auditLogs(auditLogActionUpdateTeamMember) {
+		roles = append(roles, args["role"])
+	}
+	require.ElementsMatch(t, []string{"owner", "member"}, roles, "promotion and demotion are recorded")
+	require.Len(t, auditLogs(auditLogActionDeleteTeamMember), 1, "removal is recorded")
+
+	err = service.SyncTeamMemberships(ctx, config, userID.String(), map[string]interface{}{
+		"groups": []interface{}{"eng"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.TeamMembershipRole_Member, roleOf(orgID, userID), "added again when a group matches")
+
+	// the user is the only owner of the organization, and must neither be demoted nor removed
+	err = db.UpdateTeamMembershipRole(ctx, dbConn, membershipID(orgID, userID), db.TeamMembershipRole_Owner)
+	require.NoError(t, err)
+	err = db.UpdateTeamMembershipRole(ctx, dbConn, membershipID(orgID, otherOwnerID), db.TeamMembershipRole_Member)
+	require.NoError(t, err)
+
+	for _, groups := range [][]interface{}{{"eng"}, {}} {
+		err = service.SyncTeamMemberships(ctx, config, userID.String(), map[string]interface{}{
+			"groups": groups,
+		})
+		require.NoError(t, err)
+		require.Equal(t, db.TeamMembershipRole_Owner, roleOf(orgID, userID), "last owner is kept")
+	}
+}
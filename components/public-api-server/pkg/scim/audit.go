@@ -0,0 +1,78 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package scim
+
+import (
+	"context"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/uuid"
+)
+
+// Actions are named like the corresponding actions of the API, such that audit logs can be filtered by action
+// regardless of whether a change was made by a user or through SCIM.
+const (
+	auditLogActionProvisionUser    = "provisionUser"
+	auditLogActionUpdateUser       = "updateProvisionedUser"
+	auditLogActionDeprovisionUser  = "deprovisionUser"
+	auditLogActionBlockUser        = "blockUser"
+	auditLogActionUpdateTeamMember = "updateTeamMember"
+	auditLogActionDeleteTeamMember = "deleteTeamMember"
+)
+
+// recordAuditLog records an action of the identity provider, which has already been performed. Hence failures are
+// only logged. The actor is empty, because no user performs the action. Instead, the SCIM token is recorded.
+func (s *Service) recordAuditLog(ctx context.Context, orgID uuid.UUID, action string, args map[string]any) {
+	logger := log.Extract(ctx).WithField("action", action)
+
+	if token, ok := tokenFromContext(ctx); ok {
+		args["scimTokenId"] = token.ID.String()
+	}
+
+	entry := db.AuditLog{
+		Timestamp:      time.Now().UTC(),
+		OrganizationID: orgID.String(),
+		Action:         action,
+	}
+	err := entry.SetArgs(args)
+	if err != nil {
+		logger.WithError(err).Error("Failed to record audit log.")
+		return
+	}
+
+	_, err = s.auditLog.Write(ctx, entry)
+	if err != nil {
+		logger.WithError(err).Error("Failed to record audit log.")
+	}
+}
+
+func (s *Service) recordUserUpdate(ctx context.Context, orgID uuid.UUID, u orgUser) {
+	s.recordAuditLog(ctx, orgID, auditLogActionUpdateUser, map[string]any{
+		"userId":   u.user.ID.String(),
+		"userName": u.identity.AuthName,
+	})
+}
+
+// recordMembershipChanges records the changes between the roles of the members of an organization before and
+// after a change of its groups.
+func (s *Service) recordMembershipChanges(ctx context.Context, orgID uuid.UUID, before, after map[uuid.UUID]db.TeamMembershipRole) {
+	for userID, role := range before {
+		changed, ok := after[userID]
+		if !ok {
+			s.recordAuditLog(ctx, orgID, auditLogActionDeleteTeamMember, map[string]any{
+				"userId": userID.String(),
+			})
+			continue
+		}
+		if changed != role {
+			s.recordAuditLog(ctx, orgID, auditLogActionUpdateTeamMember, map[string]any{
+				"userId": userID.String(),
+				"role":   changed,
+			})
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// filter is an equality filter on a single attribute. This is the subset of the SCIM filter syntax which identity
+// providers use to look up resources before provisioning them.
+// See https://www.rfc-editor.org/rfc/rfc7644#section-3.4.2.2
+type filter struct {
+	Attribute string
+	Value     string
+}
+
+var filterRegexp = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// parseFilter parses the filter expression, and returns nil if it is empty. Attribute names are case-insensitive,
+// and are returned as listed in supported.
+func parseFilter(expression string, supported ...string) (*filter, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, nil
+	}
+
+	match := filterRegexp.FindStringSubmatch(expression)
+	if match == nil {
+		return nil, newError(http.StatusBadRequest, "invalidFilter", "Unsupported filter %q, only 'attribute eq \"value\"' is supported.", expression)
+	}
+
+	var value string
+	if err := json.Unmarshal([]byte(match[2]), &value); err != nil {
+		return nil, newError(http.StatusBadRequest, "invalidFilter", "Invalid value in filter %q.", expression)
+	}
+
+	for _, attr := range supported {
+		if strings.EqualFold(attr, match[1]) {
+			return &filter{Attribute: attr, Value: value}, nil
+		}
+	}
+
+	return nil, newError(http.StatusBadRequest, "invalidFilter", "Filtering by %q is not supported.", match[1])
+}
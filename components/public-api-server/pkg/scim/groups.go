@@ -0,0 +1,313 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Every organization has one group per role, with the role as ID. Adding a user to a group grants the role,
+// removing an owner from the owners demotes them to a member, and removing a member from the members removes
+// them from the organization.
+var groupRoles = []db.TeamMembershipRole{db.TeamMembershipRole_Owner, db.TeamMembershipRole_Member}
+
+func groupDisplayName(role db.TeamMembershipRole) string {
+	switch role {
+	case db.TeamMembershipRole_Owner:
+		return "Owners"
+	case db.TeamMembershipRole_Member:
+		return "Members"
+	default:
+		return string(role)
+	}
+}
+
+func groupRole(id string) (db.TeamMembershipRole, error) {
+	for _, role := range groupRoles {
+		if string(role) == id {
+			return role, nil
+		}
+	}
+	return "", newError(http.StatusNotFound, "", "Group %s not found.", id)
+}
+
+func (s *Service) ListGroups(ctx context.Context, orgID uuid.UUID, f *filter, withMembers bool) ([]Group, error) {
+	users, err := s.listOrgUsers(ctx, s.dbConn, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Group
+	for _, role := range groupRoles {
+		group := s.toSCIMGroup(role, users, withMembers)
+		if f != nil && !groupMatches(group, f) {
+			continue
+		}
+		res = append(res, group)
+	}
+	return res, nil
+}
+
+func groupMatches(g Group, f *filter) bool {
+	switch f.Attribute {
+	case "id":
+		return g.ID == f.Value
+	case "displayName":
+		return strings.EqualFold(g.DisplayName, f.Value)
+	}
+	return false
+}
+
+func (s *Service) GetGroup(ctx context.Context, orgID uuid.UUID, id string, withMembers bool) (Group, error) {
+	role, err := groupRole(id)
+	if err != nil {
+		return Group{}, err
+	}
+
+	users, err := s.listOrgUsers(ctx, s.dbConn, orgID)
+	if err != nil {
+		return Group{}, err
+	}
+	return s.toSCIMGroup(role, users, withMembers), nil
+}
+
+func (s *Service) PatchGroup(ctx context.Context, orgID uuid.UUID, id string, ops []PatchOperation) (Group, error) {
+	role, err := groupRole(id)
+	if err != nil {
+		return Group{}, err
+	}
+
+	var patches []*groupPatch
+	for _, op := range ops {
+		patch, err := parseGroupPatch(op)
+		if err != nil {
+			return Group{}, err
+		}
+		if patch != nil {
+			patches = append(patches, patch)
+		}
+	}
+
+	var before, after map[uuid.UUID]db.TeamMembershipRole
+	err = s.dbConn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if before, err = s.memberRoles(ctx, tx, orgID); err != nil {
+			return err
+		}
+
+		for _, patch := range patches {
+			var err error
+			switch patch.op {
+			case opAdd:
+				err = s.addToGroup(ctx, tx, orgID, role, patch.members)
+			case opReplace:
+				err = s.replaceGroupMembers(ctx, tx, orgID, role, patch.members)
+			case opRemove:
+				members := patch.members
+				if members == nil {
+					members, err = s.groupMemberIDs(ctx, tx, orgID, role)
+					if err != nil {
+						return err
+					}
+				}
+				err = s.removeFromGroup(ctx, tx, orgID, role, members)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		after, err = s.memberRoles(ctx, tx, orgID)
+		return err
+	})
+	if err != nil {
+		return Group{}, err
+	}
+	s.recordMembershipChanges(ctx, orgID, before, after)
+
+	return s.GetGroup(ctx, orgID, id, true)
+}
+
+// ReplaceGroup replaces the members of the group. The displayName of groups cannot be changed.
+func (s *Service) ReplaceGroup(ctx context.Context, orgID uuid.UUID, id string, in Group) (Group, error) {
+	role, err := groupRole(id)
+	if err != nil {
+		return Group{}, err
+	}
+
+	members := make([]string, 0, len(in.Members))
+	for _, m := range in.Members {
+		members = append(members, m.Value)
+	}
+
+	var before, after map[uuid.UUID]db.TeamMembershipRole
+	err = s.dbConn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if before, err = s.memberRoles(ctx, tx, orgID); err != nil {
+			return err
+		}
+		if err := s.replaceGroupMembers(ctx, tx, orgID, role, members); err != nil {
+			return err
+		}
+		after, err = s.memberRoles(ctx, tx, orgID)
+		return err
+	})
+	if err != nil {
+		return Group{}, err
+	}
+	s.recordMembershipChanges(ctx, orgID, before, after)
+
+	return s.GetGroup(ctx, orgID, id, true)
+}
+
+func (s *Service) replaceGroupMembers(ctx context.Context, tx *gorm.DB, orgID uuid.UUID, role db.TeamMembershipRole, members []string) error {
+	current, err := s.groupMemberIDs(ctx, tx, orgID, role)
+	if err != nil {
+		return err
+	}
+
+	desired := make(map[string]struct{}, len(members))
+	for _, id := range members {
+		desired[id] = struct{}{}
+	}
+	var removed []string
+	for _, id := range current {
+		if _, ok := desired[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+
+	// Members are added first, such that replacing the owners does not fail on removing the last owner.
+	if err := s.addToGroup(ctx, tx, orgID, role, members); err != nil {
+		return err
+	}
+	return s.removeFromGroup(ctx, tx, orgID, role, removed)
+}
+
+func (s *Service) addToGroup(ctx context.Context, tx *gorm.DB, orgID uuid.UUID, role db.TeamMembershipRole, members []string) error {
+	for _, id := range members {
+		u, err := s.getGroupMember(ctx, tx, orgID, id)
+		if err != nil {
+			return err
+		}
+
+		if u.membership.Role == role || role == db.TeamMembershipRole_Member {
+			// Adding an owner to the members does not demote them.
+			continue
+		}
+		err = db.UpdateTeamMembershipRole(ctx, tx, u.membership.ID, role)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) removeFromGroup(ctx context.Context, tx *gorm.DB, orgID uuid.UUID, role db.TeamMembershipRole, members []string) error {
+	for _, id := range members {
+		u, err := s.getGroupMember(ctx, tx, orgID, id)
+		if err != nil {
+			return err
+		}
+
+		if u.membership.Role != role {
+			continue
+		}
+		if role == db.TeamMembershipRole_Owner {
+			if err := s.checkIsNotLastOwner(ctx, tx, orgID); err != nil {
+				return err
+			}
+			err = db.UpdateTeamMembershipRole(ctx, tx, u.membership.ID, db.TeamMembershipRole_Member)
+		} else {
+			err = db.DeleteTeamMembership(ctx, tx, u.membership.ID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) checkIsNotLastOwner(ctx context.Context, tx *gorm.DB, orgID uuid.UUID) error {
+	owners, err := db.CountTeamMembershipsWithRole(ctx, tx, orgID, db.TeamMembershipRole_Owner)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return newError(http.StatusConflict, "", "The last owner of the organization cannot be removed.")
+	}
+	return nil
+}
+
+// getGroupMember returns the user of the organization which is referenced as a group member.
+func (s *Service) getGroupMember(ctx context.Context, tx *gorm.DB, orgID uuid.UUID, id string) (orgUser, error) {
+	u, err := s.getOrgUser(ctx, tx, orgID, id)
+	var scimErr *Error
+	if errors.As(err, &scimErr) && scimErr.Status == http.StatusNotFound {
+		return orgUser{}, newError(http.StatusBadRequest, "invalidValue", "Member %s is not a user of the organization.", id)
+	}
+	return u, err
+}
+
+// memberRoles returns the roles of the members of the organization by user.
+func (s *Service) memberRoles(ctx context.Context, tx *gorm.DB, orgID uuid.UUID) (map[uuid.UUID]db.TeamMembershipRole, error) {
+	memberships, err := db.ListTeamMembershipsForTeam(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[uuid.UUID]db.TeamMembershipRole, len(memberships))
+	for _, m := range memberships {
+		res[m.UserID] = m.Role
+	}
+	return res, nil
+}
+
+func (s *Service) groupMemberIDs(ctx context.Context, tx *gorm.DB, orgID uuid.UUID, role db.TeamMembershipRole) ([]string, error) {
+	users, err := s.listOrgUsers(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []string
+	for _, u := range users {
+		if u.membership.Role == role {
+			res = append(res, u.user.ID.String())
+		}
+	}
+	return res, nil
+}
+
+func (s *Service) toSCIMGroup(role db.TeamMembershipRole, users []orgUser, withMembers bool) Group {
+	res := Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          string(role),
+		DisplayName: groupDisplayName(role),
+		Meta: &Meta{
+			ResourceType: "Group",
+			Location:     s.location("Groups", string(role)),
+		},
+	}
+	if !withMembers {
+		return res
+	}
+
+	for _, u := range users {
+		if u.membership.Role != role {
+			continue
+		}
+		res.Members = append(res.Members, Member{
+			Value:   u.user.ID.String(),
+			Display: u.identity.AuthName,
+			Ref:     s.location("Users", u.user.ID.String()),
+		})
+	}
+	return res
+}
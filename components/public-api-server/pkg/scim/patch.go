@@ -0,0 +1,208 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	opAdd     = "add"
+	opReplace = "replace"
+	opRemove  = "remove"
+)
+
+// patchOp returns the normalized operation. Operation names are case-insensitive, as some identity providers
+// (e.g. Azure AD) capitalize them.
+func patchOp(op PatchOperation) (string, error) {
+	switch name := strings.ToLower(op.Op); name {
+	case opAdd, opReplace, opRemove:
+		return name, nil
+	default:
+		return "", newError(http.StatusBadRequest, "invalidSyntax", "Unsupported patch operation %q.", op.Op)
+	}
+}
+
+// patchUser applies the operation to the user. Attributes which Gitpod does not store are ignored, such that
+// identity providers can sync their default attribute mappings.
+func patchUser(u *User, op PatchOperation) error {
+	name, err := patchOp(op)
+	if err != nil {
+		return err
+	}
+
+	if op.Path == "" {
+		if name == opRemove {
+			return newError(http.StatusBadRequest, "noTarget", "Remove operations require a path.")
+		}
+
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return newError(http.StatusBadRequest, "invalidValue", "Patch operations without a path require an object value.")
+		}
+		for attr, value := range attrs {
+			if err := setUserAttribute(u, attr, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if name == opRemove {
+		return setUserAttribute(u, op.Path, nil)
+	}
+	return setUserAttribute(u, op.Path, op.Value)
+}
+
+// emailValuePath matches paths to the value of an email, e.g. emails[type eq "work"].value
+var emailValuePath = regexp.MustCompile(`^emails(\[[^\]]*\])?\.value$`)
+
+// setUserAttribute sets the attribute at path to value, or removes it if value is nil.
+func setUserAttribute(u *User, path string, value json.RawMessage) error {
+	attr := strings.ToLower(strings.TrimPrefix(path, SchemaUser+":"))
+	remove := value == nil
+
+	switch {
+	case attr == "active":
+		if remove {
+			return newError(http.StatusBadRequest, "mutability", "active cannot be removed.")
+		}
+		var active Bool
+		if err := unmarshalValue(path, value, &active); err != nil {
+			return err
+		}
+		u.Active = &active
+	case attr == "username":
+		if remove {
+			return newError(http.StatusBadRequest, "mutability", "userName cannot be removed.")
+		}
+		return unmarshalValue(path, value, &u.UserName)
+	case attr == "externalid":
+		if remove {
+			return newError(http.StatusBadRequest, "mutability", "externalId cannot be removed.")
+		}
+		return unmarshalValue(path, value, &u.ExternalID)
+	case attr == "displayname":
+		u.DisplayName = ""
+		if !remove {
+			return unmarshalValue(path, value, &u.DisplayName)
+		}
+	case attr == "name":
+		u.Name = nil
+		if !remove {
+			return unmarshalValue(path, value, &u.Name)
+		}
+	case strings.HasPrefix(attr, "name."):
+		if u.Name == nil {
+			u.Name = &Name{}
+		}
+		var v string
+		if !remove {
+			if err := unmarshalValue(path, value, &v); err != nil {
+				return err
+			}
+		}
+		switch strings.TrimPrefix(attr, "name.") {
+		case "formatted":
+			u.Name.Formatted = v
+		case "givenname":
+			u.Name.GivenName = v
+		case "familyname":
+			u.Name.FamilyName = v
+		}
+	case attr == "emails":
+		u.Emails = nil
+		if !remove {
+			return unmarshalValue(path, value, &u.Emails)
+		}
+	case emailValuePath.MatchString(attr):
+		if remove {
+			u.Emails = nil
+			return nil
+		}
+		var v string
+		if err := unmarshalValue(path, value, &v); err != nil {
+			return err
+		}
+		u.Emails = []Email{{Value: v, Type: "work", Primary: true}}
+	}
+	return nil
+}
+
+func unmarshalValue(path string, value json.RawMessage, v interface{}) error {
+	if err := json.Unmarshal(value, v); err != nil {
+		return newError(http.StatusBadRequest, "invalidValue", "Invalid value for %s.", path)
+	}
+	return nil
+}
+
+// memberValuePath matches paths which select members by ID, e.g. members[value eq "<user-id>"]
+var memberValuePath = regexp.MustCompile(`^(?i:members)\[\s*(?i:value)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*")\s*\]$`)
+
+// groupPatch is the change of the members of a group described by a patch operation.
+type groupPatch struct {
+	op string
+
+	// members are the user IDs of the operation. For remove operations, nil removes all members.
+	members []string
+}
+
+func parseGroupPatch(op PatchOperation) (*groupPatch, error) {
+	name, err := patchOp(op)
+	if err != nil {
+		return nil, err
+	}
+
+	if op.Path == "" {
+		if name == opRemove {
+			return nil, newError(http.StatusBadRequest, "noTarget", "Remove operations require a path.")
+		}
+
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return nil, newError(http.StatusBadRequest, "invalidValue", "Patch operations without a path require an object value.")
+		}
+		for attr, value := range attrs {
+			if strings.EqualFold(attr, "members") {
+				return parseGroupPatch(PatchOperation{Op: name, Path: "members", Value: value})
+			}
+		}
+		// Other attributes of groups, like the displayName, cannot be changed.
+		return nil, nil
+	}
+
+	if match := memberValuePath.FindStringSubmatch(op.Path); match != nil {
+		var id string
+		if err := json.Unmarshal([]byte(match[1]), &id); err != nil {
+			return nil, newError(http.StatusBadRequest, "invalidPath", "Invalid path %q.", op.Path)
+		}
+		if name != opRemove {
+			return nil, newError(http.StatusBadRequest, "invalidPath", "Only remove operations can select members.")
+		}
+		return &groupPatch{op: name, members: []string{id}}, nil
+	}
+
+	if !strings.EqualFold(op.Path, "members") {
+		return nil, nil
+	}
+
+	res := &groupPatch{op: name}
+	if name == opRemove && len(op.Value) == 0 {
+		return res, nil
+	}
+
+	var members []Member
+	if err := json.Unmarshal(op.Value, &members); err != nil {
+		return nil, newError(http.StatusBadRequest, "invalidValue", "Invalid value for members.")
+	}
+	res.members = make([]string, 0, len(members))
+	for _, m := range members {
+		res.members = append(res.members, m.Value)
+	}
+	return res, nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	for _, test := range []struct {
+		Name       string
+		Expression string
+		Expected   *filter
+		Error      bool
+	}{
+		{Name: "empty", Expression: ""},
+		{Name: "equality", Expression: `userName eq "alice@example.com"`, Expected: &filter{Attribute: "userName", Value: "alice@example.com"}},
+		{Name: "case-insensitive attribute and operator", Expression: `USERNAME EQ "alice"`, Expected: &filter{Attribute: "userName", Value: "alice"}},
+		{Name: "escaped quotes", Expression: `externalId eq "a\"b"`, Expected: &filter{Attribute: "externalId", Value: `a"b`}},
+		{Name: "unsupported operator", Expression: `userName co "alice"`, Error: true},
+		{Name: "unsupported attribute", Expression: `title eq "CEO"`, Error: true},
+		{Name: "logical expression", Expression: `userName eq "a" and externalId eq "b"`, Error: true},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			f, err := parseFilter(test.Expression, "userName", "externalId")
+			if test.Error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.Expected, f)
+		})
+	}
+}
+
+func TestPatchUser(t *testing.T) {
+	active := Bool(true)
+	base := func() User {
+		return User{
+			UserName:    "alice",
+			DisplayName: "Alice",
+			Name:        &Name{Formatted: "Alice Example"},
+			Emails:      []Email{{Value: "alice@example.com", Primary: true}},
+			Active:      &active,
+		}
+	}
+	inactive := Bool(false)
+
+	for _, test := range []struct {
+		Name     string
+		Op       PatchOperation
+		Expected func(u *User)
+		Error    bool
+	}{
+		{
+			Name:     "replace active with string boolean",
+			Op:       PatchOperation{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)},
+			Expected: func(u *User) { u.Active = &inactive },
+		},
+		{
+			Name:     "replace without path",
+			Op:       PatchOperation{Op: "replace", Value: json.RawMessage(`{"active": false, "displayName": "Al"}`)},
+			Expected: func(u *User) { u.Active = &inactive; u.DisplayName = "Al" },
+		},
+		{
+			Name:     "replace attribute with schema prefix",
+			Op:       PatchOperation{Op: "replace", Path: SchemaUser + ":userName", Value: json.RawMessage(`"al"`)},
+			Expected: func(u *User) { u.UserName = "al" },
+		},
+		{
+			Name:     "replace email value",
+			Op:       PatchOperation{Op: "replace", Path: `emails[type eq "work"].value`, Value: json.RawMessage(`"al@example.com"`)},
+			Expected: func(u *User) { u.Emails = []Email{{Value: "al@example.com", Type: "work", Primary: true}} },
+		},
+		{
+			Name:     "add given name",
+			Op:       PatchOperation{Op: "add", Path: "name.givenName", Value: json.RawMessage(`"Alice"`)},
+			Expected: func(u *User) { u.Name.GivenName = "Alice" },
+		},
+		{
+			Name:     "remove display name",
+			Op:       PatchOperation{Op: "remove", Path: "displayName"},
+			Expected: func(u *User) { u.DisplayName = "" },
+		},
+		{
+			Name:     "unsupported attributes are ignored",
+			Op:       PatchOperation{Op: "replace", Path: "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department", Value: json.RawMessage(`"eng"`)},
+			Expected: func(u *User) {},
+		},
+		{Name: "remove userName", Op: PatchOperation{Op: "remove", Path: "userName"}, Error: true},
+		{Name: "remove without path", Op: PatchOperation{Op: "remove"}, Error: true},
+		{Name: "invalid value", Op: PatchOperation{Op: "replace", Path: "active", Value: json.RawMessage(`"maybe"`)}, Error: true},
+		{Name: "unsupported operation", Op: PatchOperation{Op: "move", Path: "active"}, Error: true},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			act := base()
+			err := patchUser(&act, test.Op)
+			if test.Error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			expected := base()
+			test.Expected(&expected)
+			require.Equal(t, expected, act)
+		})
+	}
+}
+
+func TestParseGroupPatch(t *testing.T) {
+	for _, test := range []struct {
+		Name     string
+		Op       PatchOperation
+		Expected *groupPatch
+		Error    bool
+	}{
+		{
+			Name:     "add members",
+			Op:       PatchOperation{Op: "Add", Path: "members", Value: json.RawMessage(`[{"value": "a"}, {"value": "b"}]`)},
+			Expected: &groupPatch{op: opAdd, members: []string{"a", "b"}},
+		},
+		{
+			Name:     "remove member by filter",
+			Op:       PatchOperation{Op: "Remove", Path: `members[value eq "a"]`},
+			Expected: &groupPatch{op: opRemove, members: []string{"a"}},
+		},
+		{
+			Name:     "remove all members",
+			Op:       PatchOperation{Op: "remove", Path: "members"},
+			Expected: &groupPatch{op: opRemove},
+		},
+		{
+			Name:     "replace members without path",
+			Op:       PatchOperation{Op: "replace", Value: json.RawMessage(`{"members": [{"value": "a"}]}`)},
+			Expected: &groupPatch{op: opReplace, members: []string{"a"}},
+		},
+		{
+			Name: "display name is ignored",
+			Op:   PatchOperation{Op: "replace", Path: "displayName", Value: json.RawMessage(`"Admins"`)},
+		},
+		{Name: "invalid members", Op: PatchOperation{Op: "add", Path: "members", Value: json.RawMessage(`"a"`)}, Error: true},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := parseGroupPatch(test.Op)
+			if test.Error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.Expected, act)
+		})
+	}
+}
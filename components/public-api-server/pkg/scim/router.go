@@ -0,0 +1,336 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const maxRequestBodySize = 1 << 20
+
+// Router serves the SCIM 2.0 protocol, see https://www.rfc-editor.org/rfc/rfc7644
+// Requests need to be authenticated with the SCIM token of an organization as bearer token.
+func (s *Service) Router() http.Handler {
+	router := chi.NewRouter()
+	router.Use(s.authenticate)
+	router.NotFound(func(rw http.ResponseWriter, r *http.Request) {
+		writeError(rw, r, newError(http.StatusNotFound, "", "Endpoint %s not found.", r.URL.Path))
+	})
+	router.MethodNotAllowed(func(rw http.ResponseWriter, r *http.Request) {
+		writeError(rw, r, newError(http.StatusMethodNotAllowed, "", "Method %s is not supported for %s.", r.Method, r.URL.Path))
+	})
+
+	router.Get("/ServiceProviderConfig", s.getServiceProviderConfig)
+	router.Get("/ResourceTypes", s.getResourceTypes)
+
+	router.Route("/Users", func(r chi.Router) {
+		r.Get("/", s.listUsers)
+		r.Post("/", s.createUser)
+		r.Get("/{id}", s.getUser)
+		r.Put("/{id}", s.replaceUser)
+		r.Patch("/{id}", s.patchUser)
+		r.Delete("/{id}", s.deleteUser)
+	})
+
+	router.Route("/Groups", func(r chi.Router) {
+		r.Get("/", s.listGroups)
+		r.Post("/", notImplemented)
+		r.Get("/{id}", s.getGroup)
+		r.Put("/{id}", s.replaceGroup)
+		r.Patch("/{id}", s.patchGroup)
+		r.Delete("/{id}", notImplemented)
+	})
+
+	return router
+}
+
+type tokenKey struct{}
+
+func (s *Service) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		token := strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		record, err := s.Authenticate(r.Context(), token)
+		if err != nil {
+			writeError(rw, r, err)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), tokenKey{}, record)
+		next.ServeHTTP(rw, r.WithContext(ctx))
+	})
+}
+
+func tokenFromContext(ctx context.Context) (db.SCIMToken, bool) {
+	token, ok := ctx.Value(tokenKey{}).(db.SCIMToken)
+	return token, ok
+}
+
+func organizationFromContext(ctx context.Context) uuid.UUID {
+	token, _ := tokenFromContext(ctx)
+	return token.OrganizationID
+}
+
+func (s *Service) listUsers(rw http.ResponseWriter, r *http.Request) {
+	f, err := parseFilter(r.URL.Query().Get("filter"), "id", "externalId", "userName", "emails.value")
+	if err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	users, err := s.ListUsers(r.Context(), organizationFromContext(r.Context()), f)
+	if err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	writeList(rw, r, users)
+}
+
+func (s *Service) createUser(rw http.ResponseWriter, r *http.Request) {
+	var in User
+	if err := readJSON(r, &in); err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	user, err := s.CreateUser(r.Context(), organizationFromContext(r.Context()), in)
+	if err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	rw.Header().Set("Location", user.Meta.Location)
+	writeJSON(rw, http.StatusCreated, user)
+}
+
+func (s *Service) getUser(rw http.ResponseWriter, r *http.Request) {
+	user, err := s.GetUser(r.Context(), organizationFromContext(r.Context()), chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	writeJSON(rw, http.StatusOK, user)
+}
+
+func (s *Service) replaceUser(rw http.ResponseWriter, r *http.Request) {
+	var in User
+	if err := readJSON(r, &in); err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	user, err := s.ReplaceUser(r.Context(), organizationFromContext(r.Context()), chi.URLParam(r, "id"), in)
+	if err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	writeJSON(rw, http.StatusOK, user)
+}
+
+func (s *Service) patchUser(rw http.ResponseWriter, r *http.Request) {
+	var in PatchRequest
+	if err := readJSON(r, &in); err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	user, err := s.PatchUser(r.Context(), organizationFromContext(r.Context()), chi.URLParam(r, "id"), in.Operations)
+	if err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	writeJSON(rw, http.StatusOK, user)
+}
+
+func (s *Service) deleteUser(rw http.ResponseWriter, r *http.Request) {
+	err := s.DeleteUser(r.Context(), organizationFromContext(r.Context()), chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Service) listGroups(rw http.ResponseWriter, r *http.Request) {
+	f, err := parseFilter(r.URL.Query().Get("filter"), "id", "displayName")
+	if err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	groups, err := s.ListGroups(r.Context(), organizationFromContext(r.Context()), f, includeMembers(r))
+	if err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	writeList(rw, r, groups)
+}
+
+func (s *Service) getGroup(rw http.ResponseWriter, r *http.Request) {
+	group, err := s.GetGroup(r.Context(), organizationFromContext(r.Context()), chi.URLParam(r, "id"), includeMembers(r))
+	if err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	writeJSON(rw, http.StatusOK, group)
+}
+
+func (s *Service) replaceGroup(rw http.ResponseWriter, r *http.Request) {
+	var in Group
+	if err := readJSON(r, &in); err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	group, err := s.ReplaceGroup(r.Context(), organizationFromContext(r.Context()), chi.URLParam(r, "id"), in)
+	if err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	writeJSON(rw, http.StatusOK, group)
+}
+
+func (s *Service) patchGroup(rw http.ResponseWriter, r *http.Request) {
+	var in PatchRequest
+	if err := readJSON(r, &in); err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	group, err := s.PatchGroup(r.Context(), organizationFromContext(r.Context()), chi.URLParam(r, "id"), in.Operations)
+	if err != nil {
+		writeError(rw, r, err)
+		return
+	}
+
+	writeJSON(rw, http.StatusOK, group)
+}
+
+func notImplemented(rw http.ResponseWriter, r *http.Request) {
+	writeError(rw, r, newError(http.StatusNotImplemented, "", "Groups are managed by Gitpod, and cannot be created or deleted."))
+}
+
+// includeMembers returns false if the client excluded the members of groups, which identity providers do to
+// avoid listing large groups.
+func includeMembers(r *http.Request) bool {
+	for _, attr := range strings.Split(r.URL.Query().Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), "members") {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Service) getServiceProviderConfig(rw http.ResponseWriter, r *http.Request) {
+	supported := func(v bool) map[string]bool {
+		return map[string]bool{"supported": v}
+	}
+
+	writeJSON(rw, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{SchemaServiceProviderConfig},
+		"patch":          supported(true),
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": 0},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with the SCIM token of the organization.",
+			"primary":     true,
+		}},
+		"meta": Meta{ResourceType: "ServiceProviderConfig", Location: s.baseURL + "/ServiceProviderConfig"},
+	})
+}
+
+func (s *Service) getResourceTypes(rw http.ResponseWriter, r *http.Request) {
+	resourceType := func(name, endpoint, schema string) map[string]interface{} {
+		return map[string]interface{}{
+			"schemas":  []string{SchemaResourceType},
+			"id":       name,
+			"name":     name,
+			"endpoint": endpoint,
+			"schema":   schema,
+			"meta":     Meta{ResourceType: "ResourceType", Location: s.baseURL + "/ResourceTypes/" + name},
+		}
+	}
+
+	writeList(rw, r, []map[string]interface{}{
+		resourceType("User", "/Users", SchemaUser),
+		resourceType("Group", "/Groups", SchemaGroup),
+	})
+}
+
+func readJSON(r *http.Request, v interface{}) error {
+	err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxRequestBodySize)).Decode(v)
+	if err != nil {
+		return newError(http.StatusBadRequest, "invalidSyntax", "Failed to parse request body: %s", err.Error())
+	}
+	return nil
+}
+
+// writeList writes a page of the resources, see https://www.rfc-editor.org/rfc/rfc7644#section-3.4.2.4
+func writeList[T any](rw http.ResponseWriter, r *http.Request, resources []T) {
+	startIndex, err := strconv.Atoi(r.URL.Query().Get("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+	count, err := strconv.Atoi(r.URL.Query().Get("count"))
+	if err != nil || count < 0 {
+		count = len(resources)
+	}
+
+	page := []T{}
+	if startIndex <= len(resources) {
+		page = resources[startIndex-1:]
+	}
+	if count < len(page) {
+		page = page[:count]
+	}
+
+	writeJSON(rw, http.StatusOK, ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	})
+}
+
+func writeError(rw http.ResponseWriter, r *http.Request, err error) {
+	var scimErr *Error
+	if !errors.As(err, &scimErr) {
+		log.Extract(r.Context()).WithError(err).Error("Failed to handle SCIM request.")
+		scimErr = newError(http.StatusInternalServerError, "", "Failed to handle request. If this issue persists, please contact Gitpod Support.")
+	}
+
+	writeJSON(rw, scimErr.Status, scimErr)
+}
+
+func writeJSON(rw http.ResponseWriter, status int, v interface{}) {
+	rw.Header().Set("Content-Type", ContentType)
+	rw.WriteHeader(status)
+	if err := json.NewEncoder(rw).Encode(v); err != nil {
+		log.WithError(err).Error("Failed to write SCIM response.")
+	}
+}
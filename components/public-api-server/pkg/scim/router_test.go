@@ -0,0 +1,413 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const testBaseURL = "https://gitpod.example.com/scim/v2"
+
+func TestRouter_Authentication(t *testing.T) {
+	client, _, _ := setupSCIM(t, true)
+
+	for _, token := range []string{"", "not-a-token"} {
+		client.token = token
+
+		var scimErr map[string]interface{}
+		status := client.do(http.MethodGet, "/Users", nil, &scimErr)
+		require.Equal(t, http.StatusUnauthorized, status)
+		require.Equal(t, []interface{}{SchemaError}, scimErr["schemas"])
+		require.Equal(t, "401", scimErr["status"])
+	}
+}
+
+func TestRouter_ServiceProviderConfig(t *testing.T) {
+	client, _, _ := setupSCIM(t, true)
+
+	var config map[string]interface{}
+	require.Equal(t, http.StatusOK, client.do(http.MethodGet, "/ServiceProviderConfig", nil, &config))
+	require.Equal(t, map[string]interface{}{"supported": true}, config["patch"])
+
+	var resourceTypes ListResponse
+	require.Equal(t, http.StatusOK, client.do(http.MethodGet, "/ResourceTypes", nil, &resourceTypes))
+	require.Equal(t, 2, resourceTypes.TotalResults)
+}
+
+func TestRouter_Users(t *testing.T) {
+	client, orgID, dbConn := setupSCIM(t, true)
+	ctx := context.Background()
+
+	var owner User
+	status := client.do(http.MethodPost, "/Users", newSCIMUser("alice"), &owner)
+	require.Equal(t, http.StatusCreated, status)
+	require.NotEmpty(t, owner.ID)
+	require.Equal(t, "alice-id", owner.ExternalID)
+	require.Equal(t, "Alice Example", owner.DisplayName)
+	require.Equal(t, "alice@example.com", owner.Emails[0].Value)
+	require.True(t, bool(*owner.Active))
+	require.Equal(t, testBaseURL+"/Users/"+owner.ID, owner.Meta.Location)
+	require.Equal(t, "owner", owner.Groups[0].Value, "the first member of the organization becomes owner")
+
+	var member User
+	require.Equal(t, http.StatusCreated, client.do(http.MethodPost, "/Users", newSCIMUser("bob"), &member))
+	require.Equal(t, "member", member.Groups[0].Value)
+
+	t.Run("provisioned users sign in through the identity of the organization's issuer", func(t *testing.T) {
+		issuer, err := (&Service{dbConn: dbConn}).issuer(ctx, dbConn, orgID)
+		require.NoError(t, err)
+
+		identity, err := db.GetIdentity(ctx, dbConn, issuer, "bob-id")
+		require.NoError(t, err)
+		require.Equal(t, member.ID, identity.UserID.String())
+
+		user, err := db.GetUser(ctx, dbConn, identity.UserID)
+		require.NoError(t, err)
+		require.Equal(t, orgID.String(), user.OrganizationID)
+	})
+
+	t.Run("requires externalId", func(t *testing.T) {
+		withoutExternalID := newSCIMUser("carol")
+		withoutExternalID.ExternalID = ""
+
+		var scimErr map[string]interface{}
+		require.Equal(t, http.StatusBadRequest, client.do(http.MethodPost, "/Users", withoutExternalID, &scimErr))
+		require.Equal(t, "invalidValue", scimErr["scimType"])
+	})
+
+	t.Run("conflict on duplicate externalId or userName", func(t *testing.T) {
+		var scimErr map[string]interface{}
+		require.Equal(t, http.StatusConflict, client.do(http.MethodPost, "/Users", newSCIMUser("alice"), &scimErr))
+		require.Equal(t, "uniqueness", scimErr["scimType"])
+
+		duplicateName := newSCIMUser("carol")
+		duplicateName.UserName = "ALICE@example.com"
+		require.Equal(t, http.StatusConflict, client.do(http.MethodPost, "/Users", duplicateName, &scimErr))
+		require.Equal(t, "uniqueness", scimErr["scimType"])
+	})
+
+	t.Run("lists and filters users", func(t *testing.T) {
+		var list ListResponse
+		require.Equal(t, http.StatusOK, client.do(http.MethodGet, "/Users", nil, &list))
+		require.Equal(t, 2, list.TotalResults)
+
+		var filtered struct {
+			ListResponse
+			Resources []User `json:"Resources"`
+		}
+		require.Equal(t, http.StatusOK, client.do(http.MethodGet, `/Users?filter=userName+eq+"bob@example.com"`, nil, &filtered))
+		require.Equal(t, 1, filtered.TotalResults)
+		require.Equal(t, member.ID, filtered.Resources[0].ID)
+
+		require.Equal(t, http.StatusOK, client.do(http.MethodGet, `/Users?filter=externalId+eq+"unknown"`, nil, &filtered))
+		require.Equal(t, 0, filtered.TotalResults)
+		require.Empty(t, filtered.Resources)
+
+		require.Equal(t, http.StatusOK, client.do(http.MethodGet, "/Users?startIndex=2&count=1", nil, &filtered))
+		require.Equal(t, 2, filtered.TotalResults)
+		require.Equal(t, 1, filtered.ItemsPerPage)
+		require.Equal(t, member.ID, filtered.Resources[0].ID)
+
+		var scimErr map[string]interface{}
+		require.Equal(t, http.StatusBadRequest, client.do(http.MethodGet, `/Users?filter=userName+co+"bob"`, nil, &scimErr))
+		require.Equal(t, "invalidFilter", scimErr["scimType"])
+	})
+
+	t.Run("deactivates user with patch", func(t *testing.T) {
+		var patched User
+		status := client.do(http.MethodPatch, "/Users/"+member.ID, PatchRequest{
+			Schemas: []string{SchemaPatchOp},
+			Operations: []PatchOperation{
+				{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)},
+				{Op: "Replace", Value: json.RawMessage(`{"displayName": "Bobby", "emails[type eq \"work\"].value": "bobby@example.com"}`)},
+			},
+		}, &patched)
+		require.Equal(t, http.StatusOK, status)
+		require.False(t, bool(*patched.Active))
+		require.Equal(t, "Bobby", patched.DisplayName)
+		require.Equal(t, "bobby@example.com", patched.Emails[0].Value)
+
+		user, err := db.GetUser(ctx, dbConn, uuid.MustParse(member.ID))
+		require.NoError(t, err)
+		require.True(t, user.Blocked)
+		require.Equal(t, "Bobby", user.Name)
+
+		require.Equal(t, http.StatusOK, client.do(http.MethodPatch, "/Users/"+member.ID, PatchRequest{
+			Operations: []PatchOperation{{Op: "replace", Path: "active", Value: json.RawMessage(`true`)}},
+		}, &patched))
+		require.True(t, bool(*patched.Active))
+	})
+
+	t.Run("replaces user, but not its externalId", func(t *testing.T) {
+		replacement := newSCIMUser("bob")
+		replacement.Name = &Name{GivenName: "Robert", FamilyName: "Example"}
+
+		var replaced User
+		require.Equal(t, http.StatusOK, client.do(http.MethodPut, "/Users/"+member.ID, replacement, &replaced))
+		require.Equal(t, "Robert Example", replaced.Name.Formatted)
+
+		replacement.ExternalID = "other-id"
+		var scimErr map[string]interface{}
+		require.Equal(t, http.StatusBadRequest, client.do(http.MethodPut, "/Users/"+member.ID, replacement, &scimErr))
+		require.Equal(t, "mutability", scimErr["scimType"])
+	})
+
+	t.Run("deletes user by blocking it and removing it from the organization", func(t *testing.T) {
+		require.Equal(t, http.StatusNoContent, client.do(http.MethodDelete, "/Users/"+member.ID, nil, nil))
+		require.Equal(t, http.StatusNotFound, client.do(http.MethodGet, "/Users/"+member.ID, nil, nil))
+
+		user, err := db.GetUser(ctx, dbConn, uuid.MustParse(member.ID))
+		require.NoError(t, err)
+		require.True(t, user.Blocked)
+
+		_, err = db.GetTeamMembership(ctx, dbConn, user.ID, orgID)
+		require.ErrorIs(t, err, db.ErrorNotFound)
+
+		for _, action := range []string{auditLogActionProvisionUser, auditLogActionBlockUser, auditLogActionDeprovisionUser} {
+			logs, err := db.ListAuditLogsForOrganization(ctx, dbConn, orgID, db.ListAuditLogsFilter{Action: action}, db.Pagination{Page: 1, PageSize: 10})
+			require.NoError(t, err)
+			require.NotEmpty(t, logs.Results, action)
+			require.Empty(t, logs.Results[0].ActorID, "actions of the identity provider have no actor")
+		}
+	})
+
+	t.Run("restores deleted user when provisioned again", func(t *testing.T) {
+		var restored User
+		require.Equal(t, http.StatusCreated, client.do(http.MethodPost, "/Users", newSCIMUser("bob"), &restored))
+		require.Equal(t, member.ID, restored.ID)
+		require.True(t, bool(*restored.Active))
+		require.Equal(t, "member", restored.Groups[0].Value)
+	})
+
+	t.Run("not found for unknown users", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, client.do(http.MethodGet, "/Users/"+uuid.NewString(), nil, nil))
+		require.Equal(t, http.StatusNotFound, client.do(http.MethodGet, "/Users/not-a-uuid", nil, nil))
+	})
+}
+
+func TestRouter_Users_WithoutSSOConfig(t *testing.T) {
+	client, _, _ := setupSCIM(t, false)
+
+	var scimErr map[string]interface{}
+	require.Equal(t, http.StatusBadRequest, client.do(http.MethodPost, "/Users", newSCIMUser("alice"), &scimErr))
+}
+
+func TestRouter_Groups(t *testing.T) {
+	client, orgID, dbConn := setupSCIM(t, true)
+	ctx := context.Background()
+
+	var alice, bob User
+	require.Equal(t, http.StatusCreated, client.do(http.MethodPost, "/Users", newSCIMUser("alice"), &alice))
+	require.Equal(t, http.StatusCreated, client.do(http.MethodPost, "/Users", newSCIMUser("bob"), &bob))
+
+	t.Run("lists groups", func(t *testing.T) {
+		var list struct {
+			ListResponse
+			Resources []Group `json:"Resources"`
+		}
+		require.Equal(t, http.StatusOK, client.do(http.MethodGet, "/Groups?excludedAttributes=members", nil, &list))
+		require.Equal(t, 2, list.TotalResults)
+		require.Empty(t, list.Resources[0].Members)
+
+		require.Equal(t, http.StatusOK, client.do(http.MethodGet, `/Groups?filter=displayName+eq+"Owners"`, nil, &list))
+		require.Equal(t, 1, list.TotalResults)
+		require.Equal(t, "owner", list.Resources[0].ID)
+		require.Equal(t, []Member{{Value: alice.ID, Display: alice.UserName, Ref: testBaseURL + "/Users/" + alice.ID}}, list.Resources[0].Members)
+	})
+
+	t.Run("adds and removes owners", func(t *testing.T) {
+		var group Group
+		status := client.do(http.MethodPatch, "/Groups/owner", PatchRequest{
+			Operations: []PatchOperation{{Op: "Add", Path: "members", Value: json.RawMessage(fmt.Sprintf(`[{"value": %q}]`, bob.ID))}},
+		}, &group)
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, group.Members, 2)
+		requireRole(t, dbConn, bob.ID, orgID, db.TeamMembershipRole_Owner)
+
+		status = client.do(http.MethodPatch, "/Groups/owner", PatchRequest{
+			Operations: []PatchOperation{{Op: "Remove", Path: fmt.Sprintf(`members[value eq %q]`, alice.ID)}},
+		}, &group)
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, group.Members, 1)
+		requireRole(t, dbConn, alice.ID, orgID, db.TeamMembershipRole_Member)
+
+		var scimErr map[string]interface{}
+		status = client.do(http.MethodPatch, "/Groups/owner", PatchRequest{
+			Operations: []PatchOperation{{Op: "remove", Path: "members"}},
+		}, &scimErr)
+		require.Equal(t, http.StatusConflict, status, "the last owner cannot be removed")
+		requireRole(t, dbConn, bob.ID, orgID, db.TeamMembershipRole_Owner)
+	})
+
+	t.Run("replaces owners", func(t *testing.T) {
+		var group Group
+		status := client.do(http.MethodPut, "/Groups/owner", Group{
+			DisplayName: "Owners",
+			Members:     []Member{{Value: alice.ID}},
+		}, &group)
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, group.Members, 1)
+		requireRole(t, dbConn, alice.ID, orgID, db.TeamMembershipRole_Owner)
+		requireRole(t, dbConn, bob.ID, orgID, db.TeamMembershipRole_Member)
+
+		logs, err := db.ListAuditLogsForOrganization(ctx, dbConn, orgID, db.ListAuditLogsFilter{Action: auditLogActionUpdateTeamMember}, db.Pagination{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		require.Len(t, logs.Results, 4, "bob was promoted and demoted, alice was demoted and promoted")
+	})
+
+	t.Run("removing a member removes it from the organization", func(t *testing.T) {
+		var group Group
+		status := client.do(http.MethodPatch, "/Groups/member", PatchRequest{
+			Operations: []PatchOperation{{Op: "remove", Path: fmt.Sprintf(`members[value eq %q]`, bob.ID)}},
+		}, &group)
+		require.Equal(t, http.StatusOK, status)
+		require.Empty(t, group.Members)
+
+		_, err := db.GetTeamMembership(ctx, dbConn, uuid.MustParse(bob.ID), orgID)
+		require.ErrorIs(t, err, db.ErrorNotFound)
+	})
+
+	t.Run("rejects unknown members", func(t *testing.T) {
+		var scimErr map[string]interface{}
+		status := client.do(http.MethodPatch, "/Groups/owner", PatchRequest{
+			Operations: []PatchOperation{{Op: "add", Path: "members", Value: json.RawMessage(fmt.Sprintf(`[{"value": %q}]`, uuid.NewString()))}},
+		}, &scimErr)
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Equal(t, "invalidValue", scimErr["scimType"])
+	})
+
+	t.Run("groups cannot be created or deleted", func(t *testing.T) {
+		require.Equal(t, http.StatusNotImplemented, client.do(http.MethodPost, "/Groups", Group{DisplayName: "Admins"}, nil))
+		require.Equal(t, http.StatusNotImplemented, client.do(http.MethodDelete, "/Groups/owner", nil, nil))
+		require.Equal(t, http.StatusNotFound, client.do(http.MethodGet, "/Groups/admins", nil, nil))
+	})
+}
+
+func requireRole(t *testing.T, dbConn *gorm.DB, userID string, orgID uuid.UUID, role db.TeamMembershipRole) {
+	t.Helper()
+
+	membership, err := db.GetTeamMembership(context.Background(), dbConn, uuid.MustParse(userID), orgID)
+	require.NoError(t, err)
+	require.Equal(t, role, membership.Role)
+}
+
+func newSCIMUser(name string) User {
+	active := Bool(true)
+	return User{
+		Schemas:     []string{SchemaUser},
+		ExternalID:  name + "-id",
+		UserName:    name + "@example.com",
+		DisplayName: fmt.Sprintf("%s%s Example", string(name[0]-'a'+'A'), name[1:]),
+		Emails:      []Email{{Value: name + "@example.com", Type: "work", Primary: true}},
+		Active:      &active,
+	}
+}
+
+// scimClient is a minimal SCIM client, which sends requests the way identity providers do.
+type scimClient struct {
+	t     *testing.T
+	url   string
+	token string
+}
+
+func (c *scimClient) do(method, path string, body interface{}, out interface{}) int {
+	c.t.Helper()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		require.NoError(c.t, json.NewEncoder(&reqBody).Encode(body))
+	}
+
+	req, err := http.NewRequest(method, c.url+path, &reqBody)
+	require.NoError(c.t, err)
+	req.Header.Set("Content-Type", ContentType)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(c.t, err)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		require.Equal(c.t, ContentType, resp.Header.Get("Content-Type"))
+		if out != nil {
+			require.NoError(c.t, json.NewDecoder(resp.Body).Decode(out))
+		}
+	}
+	return resp.StatusCode
+}
+
+func setupSCIM(t *testing.T, withSSOConfig bool) (*scimClient, uuid.UUID, *gorm.DB) {
+	t.Helper()
+
+	ctx := context.Background()
+	dbConn := dbtest.ConnectForTests(t)
+	orgID := uuid.New()
+	issuer := "https://issuer.example.com/" + orgID.String()
+
+	if withSSOConfig {
+		dbtest.CreateOIDCClientConfigs(t, dbConn, db.OIDCClientConfig{
+			OrganizationID: orgID,
+			Issuer:         issuer,
+		})
+	}
+
+	token, hash, err := NewToken()
+	require.NoError(t, err)
+	_, err = db.CreateSCIMToken(ctx, dbConn, db.SCIMToken{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		Hash:           hash,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, dbConn.Where("organizationId = ?", orgID).Delete(&db.SCIMToken{}).Error)
+		require.NoError(t, dbConn.Where("teamId = ?", orgID).Delete(&db.TeamMembership{}).Error)
+		require.NoError(t, dbConn.Where("authProviderId = ?", issuer).Delete(&db.Identity{}).Error)
+		require.NoError(t, dbConn.Where("organizationId = ?", orgID.String()).Delete(&db.User{}).Error)
+		require.NoError(t, dbConn.Where("organizationId = ?", orgID.String()).Delete(&db.AuditLog{}).Error)
+	})
+
+	_, usersHandler := v1connect.NewUserServiceHandler(&fakeUserService{dbConn: dbConn})
+	users := httptest.NewServer(usersHandler)
+	t.Cleanup(users.Close)
+
+	ts := httptest.NewServer(NewService(dbConn, v1connect.NewUserServiceClient(http.DefaultClient, users.URL), db.NewAuditLogWriter(dbConn), testBaseURL).Router())
+	t.Cleanup(ts.Close)
+
+	return &scimClient{t: t, url: ts.URL, token: token}, orgID, dbConn
+}
+
+// fakeUserService blocks users in the database, like server does. Server also stops their workspaces.
+type fakeUserService struct {
+	v1connect.UnimplementedUserServiceHandler
+
+	dbConn *gorm.DB
+}
+
+func (s *fakeUserService) BlockUser(ctx context.Context, req *connect.Request[v1.BlockUserRequest]) (*connect.Response[v1.BlockUserResponse], error) {
+	err := s.dbConn.WithContext(ctx).Model(&db.User{}).Where("id = ?", req.Msg.GetUserId()).Update("blocked", true).Error
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&v1.BlockUserResponse{}), nil
+}
@@ -0,0 +1,645 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	connect "github.com/bufbuild/connect-go"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	"github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1/v1connect"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// builtinAdminUserID is the user which owns organizations created during the installation.
+// It is not counted when determining the first member of an organization.
+var builtinAdminUserID = uuid.MustParse("f071bb8e-b5d1-46cf-a436-da03ae63bcd2")
+
+// Service provisions the users of organizations through SCIM.
+// Users are provisioned with an identity of the OIDC client config of the organization, such that they are
+// signed in to the provisioned user when they log in through SSO.
+type Service struct {
+	dbConn *gorm.DB
+
+	// users is the user service of server. Users are blocked through it, such that their running workspaces
+	// are stopped as well.
+	users v1connect.UserServiceClient
+
+	auditLog *db.AuditLogWriter
+
+	// baseURL is the public URL of the SCIM endpoint, used for resource locations.
+	baseURL string
+}
+
+func NewService(dbConn *gorm.DB, users v1connect.UserServiceClient, auditLog *db.AuditLogWriter, baseURL string) *Service {
+	return &Service{
+		dbConn:   dbConn,
+		users:    users,
+		auditLog: auditLog,
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Authenticate returns the SCIM token record of the token.
+func (s *Service) Authenticate(ctx context.Context, token string) (db.SCIMToken, error) {
+	if token == "" {
+		return db.SCIMToken{}, newError(http.StatusUnauthorized, "", "No bearer token present on request.")
+	}
+
+	record, err := db.GetSCIMTokenByHash(ctx, s.dbConn, HashToken(token))
+	if errors.Is(err, db.ErrorNotFound) {
+		return db.SCIMToken{}, newError(http.StatusUnauthorized, "", "Invalid bearer token.")
+	}
+	if err != nil {
+		return db.SCIMToken{}, err
+	}
+
+	return record, nil
+}
+
+// orgUser is a user owned by, and member of, an organization.
+type orgUser struct {
+	user       db.User
+	identity   db.Identity
+	membership db.TeamMembership
+}
+
+func (s *Service) ListUsers(ctx context.Context, orgID uuid.UUID, f *filter) ([]User, error) {
+	users, err := s.listOrgUsers(ctx, s.dbConn, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []User
+	for _, u := range users {
+		scimUser := s.toSCIMUser(u)
+		if f != nil && !userMatches(scimUser, f) {
+			continue
+		}
+		res = append(res, scimUser)
+	}
+	return res, nil
+}
+
+func userMatches(u User, f *filter) bool {
+	switch f.Attribute {
+	case "id":
+		return u.ID == f.Value
+	case "externalId":
+		return u.ExternalID == f.Value
+	case "userName":
+		// userName is case-insensitive, see https://www.rfc-editor.org/rfc/rfc7643#section-4.1.1
+		return strings.EqualFold(u.UserName, f.Value)
+	case "emails.value":
+		for _, e := range u.Emails {
+			if strings.EqualFold(e.Value, f.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Service) GetUser(ctx context.Context, orgID uuid.UUID, id string) (User, error) {
+	u, err := s.getOrgUser(ctx, s.dbConn, orgID, id)
+	if err != nil {
+		return User{}, err
+	}
+	return s.toSCIMUser(u), nil
+}
+
+func (s *Service) CreateUser(ctx context.Context, orgID uuid.UUID, in User) (User, error) {
+	if err := validateUser(in); err != nil {
+		return User{}, err
+	}
+
+	var created orgUser
+	err := s.dbConn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		issuer, err := s.issuer(ctx, tx, orgID)
+		if err != nil {
+			return err
+		}
+
+		identity, err := db.GetIdentity(ctx, tx, issuer, in.ExternalID)
+		if err == nil {
+			created, err = s.restoreUser(ctx, tx, orgID, identity, in)
+			return err
+		}
+		if !errors.Is(err, db.ErrorNotFound) {
+			return err
+		}
+
+		if err := s.checkUserNameIsUnique(ctx, tx, orgID, in.UserName, uuid.Nil); err != nil {
+			return err
+		}
+
+		profile := profileOf(in)
+		user, err := db.CreateUser(ctx, tx, db.User{
+			ID:             uuid.New(),
+			OrganizationID: orgID.String(),
+			Name:           profile.name,
+			FullName:       profile.fullName,
+			Blocked:        !isActive(in),
+		}, db.Identity{
+			AuthProviderID: issuer,
+			AuthID:         in.ExternalID,
+			AuthName:       in.UserName,
+			PrimaryEmail:   profile.email,
+		})
+		if err != nil {
+			return err
+		}
+
+		membership, err := s.addMember(ctx, tx, orgID, user.ID)
+		if err != nil {
+			return err
+		}
+
+		created, err = s.getOrgUser(ctx, tx, orgID, user.ID.String())
+		if err != nil {
+			return err
+		}
+		created.membership = membership
+		return nil
+	})
+	if err != nil {
+		return User{}, err
+	}
+
+	s.recordAuditLog(ctx, orgID, auditLogActionProvisionUser, map[string]any{
+		"userId":     created.user.ID.String(),
+		"externalId": created.identity.AuthID,
+		"userName":   created.identity.AuthName,
+		"role":       created.membership.Role,
+	})
+	if err := s.deactivate(ctx, orgID, &created, in); err != nil {
+		return User{}, err
+	}
+
+	return s.toSCIMUser(created), nil
+}
+
+// restoreUser re-adds a previously deprovisioned user of the organization.
+func (s *Service) restoreUser(ctx context.Context, tx *gorm.DB, orgID uuid.UUID, identity db.Identity, in User) (orgUser, error) {
+	conflict := newError(http.StatusConflict, "uniqueness", "User with externalId %q already exists.", identity.AuthID)
+
+	user, err := db.GetUser(ctx, tx, identity.UserID)
+	if errors.Is(err, db.ErrorNotFound) {
+		return orgUser{}, conflict
+	}
+	if err != nil {
+		return orgUser{}, err
+	}
+	if user.OrganizationID != orgID.String() {
+		return orgUser{}, conflict
+	}
+
+	_, err = db.GetTeamMembership(ctx, tx, user.ID, orgID)
+	if err == nil {
+		return orgUser{}, conflict
+	}
+	if !errors.Is(err, db.ErrorNotFound) {
+		return orgUser{}, err
+	}
+
+	if err := s.checkUserNameIsUnique(ctx, tx, orgID, in.UserName, user.ID); err != nil {
+		return orgUser{}, err
+	}
+
+	membership, err := s.addMember(ctx, tx, orgID, user.ID)
+	if err != nil {
+		return orgUser{}, err
+	}
+
+	return s.save(ctx, tx, orgUser{user: user, identity: identity, membership: membership}, in)
+}
+
+// ReplaceUser replaces the attributes of the user, see https://www.rfc-editor.org/rfc/rfc7644#section-3.5.1
+func (s *Service) ReplaceUser(ctx context.Context, orgID uuid.UUID, id string, in User) (User, error) {
+	if err := validateUser(in); err != nil {
+		return User{}, err
+	}
+
+	var updated orgUser
+	err := s.dbConn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := s.getOrgUser(ctx, tx, orgID, id)
+		if err != nil {
+			return err
+		}
+
+		updated, err = s.update(ctx, tx, orgID, current, in)
+		return err
+	})
+	if err != nil {
+		return User{}, err
+	}
+
+	s.recordUserUpdate(ctx, orgID, updated)
+	if err := s.deactivate(ctx, orgID, &updated, in); err != nil {
+		return User{}, err
+	}
+
+	return s.toSCIMUser(updated), nil
+}
+
+// PatchUser modifies the attributes of the user, see https://www.rfc-editor.org/rfc/rfc7644#section-3.5.2
+func (s *Service) PatchUser(ctx context.Context, orgID uuid.UUID, id string, ops []PatchOperation) (User, error) {
+	var (
+		updated orgUser
+		in      User
+	)
+	err := s.dbConn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := s.getOrgUser(ctx, tx, orgID, id)
+		if err != nil {
+			return err
+		}
+
+		in = s.toSCIMUser(current)
+		for _, op := range ops {
+			if err := patchUser(&in, op); err != nil {
+				return err
+			}
+		}
+		if err := validateUser(in); err != nil {
+			return err
+		}
+
+		updated, err = s.update(ctx, tx, orgID, current, in)
+		return err
+	})
+	if err != nil {
+		return User{}, err
+	}
+
+	s.recordUserUpdate(ctx, orgID, updated)
+	if err := s.deactivate(ctx, orgID, &updated, in); err != nil {
+		return User{}, err
+	}
+
+	return s.toSCIMUser(updated), nil
+}
+
+// DeleteUser deprovisions the user: the user is blocked, and removed from the organization.
+// The user is blocked first, such that a failure to block it can be retried by the identity provider.
+func (s *Service) DeleteUser(ctx context.Context, orgID uuid.UUID, id string) error {
+	current, err := s.getOrgUser(ctx, s.dbConn.WithContext(ctx), orgID, id)
+	if err != nil {
+		return err
+	}
+	if !current.user.Blocked {
+		if err := s.blockUser(ctx, orgID, current.user.ID, "deprovisioned by the identity provider of the organization"); err != nil {
+			return err
+		}
+	}
+
+	err = db.DeleteTeamMembership(ctx, s.dbConn, current.membership.ID)
+	if err != nil {
+		return err
+	}
+
+	s.recordAuditLog(ctx, orgID, auditLogActionDeprovisionUser, map[string]any{
+		"userId": current.user.ID.String(),
+	})
+	return nil
+}
+
+// deactivate blocks a user which the identity provider set inactive. Users are never blocked within a transaction,
+// because server blocks them, and stops their running workspaces.
+func (s *Service) deactivate(ctx context.Context, orgID uuid.UUID, u *orgUser, in User) error {
+	if isActive(in) || u.user.Blocked {
+		return nil
+	}
+	if err := s.blockUser(ctx, orgID, u.user.ID, "deactivated by the identity provider of the organization"); err != nil {
+		return err
+	}
+	u.user.Blocked = true
+	return nil
+}
+
+// blockUser blocks a user through server, which also stops the running workspaces of the user.
+func (s *Service) blockUser(ctx context.Context, orgID, userID uuid.UUID, reason string) error {
+	_, err := s.users.BlockUser(ctx, connect.NewRequest(&v1.BlockUserRequest{
+		UserId: userID.String(),
+		Reason: reason,
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to block user %s: %w", userID, err)
+	}
+
+	s.recordAuditLog(ctx, orgID, auditLogActionBlockUser, map[string]any{
+		"userId": userID.String(),
+		"reason": reason,
+	})
+	return nil
+}
+
+func (s *Service) update(ctx context.Context, tx *gorm.DB, orgID uuid.UUID, current orgUser, in User) (orgUser, error) {
+	if in.ExternalID != current.identity.AuthID {
+		return orgUser{}, newError(http.StatusBadRequest, "mutability", "externalId cannot be changed.")
+	}
+
+	if !strings.EqualFold(in.UserName, current.identity.AuthName) {
+		if err := s.checkUserNameIsUnique(ctx, tx, orgID, in.UserName, current.user.ID); err != nil {
+			return orgUser{}, err
+		}
+	}
+
+	return s.save(ctx, tx, current, in)
+}
+
+func (s *Service) save(ctx context.Context, tx *gorm.DB, current orgUser, in User) (orgUser, error) {
+	profile := profileOf(in)
+
+	current.user.Name = profile.name
+	current.user.FullName = profile.fullName
+	// Inactive users are blocked once the transaction is committed, see deactivate.
+	current.user.Blocked = current.user.Blocked && !isActive(in)
+	user, err := db.UpdateUser(ctx, tx, current.user)
+	if err != nil {
+		return orgUser{}, err
+	}
+
+	current.identity.AuthName = in.UserName
+	current.identity.PrimaryEmail = profile.email
+	if err := db.UpdateIdentity(ctx, tx, current.identity); err != nil {
+		return orgUser{}, err
+	}
+
+	current.user = user
+	return current, nil
+}
+
+// addMember adds the user to the organization. Like for users signing in through SSO, the first member of the
+// organization becomes its owner.
+func (s *Service) addMember(ctx context.Context, tx *gorm.DB, orgID, userID uuid.UUID) (db.TeamMembership, error) {
+	memberships, err := db.ListTeamMembershipsForTeam(ctx, tx, orgID)
+	if err != nil {
+		return db.TeamMembership{}, err
+	}
+
+	role := db.TeamMembershipRole_Owner
+	for _, m := range memberships {
+		if m.UserID != builtinAdminUserID {
+			role = db.TeamMembershipRole_Member
+			break
+		}
+	}
+
+	return db.CreateTeamMembership(ctx, tx, db.TeamMembership{
+		ID:     uuid.New(),
+		TeamID: orgID,
+		UserID: userID,
+		Role:   role,
+	})
+}
+
+func (s *Service) checkUserNameIsUnique(ctx context.Context, tx *gorm.DB, orgID uuid.UUID, userName string, userID uuid.UUID) error {
+	users, err := s.listOrgUsers(ctx, tx, orgID)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		if u.user.ID != userID && strings.EqualFold(u.identity.AuthName, userName) {
+			return newError(http.StatusConflict, "uniqueness", "User with userName %q already exists.", userName)
+		}
+	}
+	return nil
+}
+
+// issuer returns the issuer of the OIDC client config of the organization, which is the auth provider of the
+// identities of provisioned users.
+func (s *Service) issuer(ctx context.Context, tx *gorm.DB, orgID uuid.UUID) (string, error) {
+	issuers, err := s.issuers(ctx, tx, orgID)
+	if err != nil {
+		return "", err
+	}
+	if len(issuers) == 0 {
+		return "", newError(http.StatusBadRequest, "", "The organization has no SSO configuration, users cannot be provisioned.")
+	}
+	return issuers[0], nil
+}
+
+// issuers returns the issuers of all OIDC client configs of the organization, active ones first.
+func (s *Service) issuers(ctx context.Context, tx *gorm.DB, orgID uuid.UUID) ([]string, error) {
+	configs, err := db.ListOIDCClientConfigsForOrganization(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var active, inactive []string
+	for _, c := range configs {
+		if c.Active {
+			active = append(active, c.Issuer)
+		} else {
+			inactive = append(inactive, c.Issuer)
+		}
+	}
+	return append(active, inactive...), nil
+}
+
+func (s *Service) listOrgUsers(ctx context.Context, tx *gorm.DB, orgID uuid.UUID) ([]orgUser, error) {
+	memberships, err := db.ListTeamMembershipsForTeam(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	membershipByUser := make(map[uuid.UUID]db.TeamMembership, len(memberships))
+	for _, m := range memberships {
+		membershipByUser[m.UserID] = m
+	}
+
+	users, err := db.ListUsersForOrganization(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var userIDs []uuid.UUID
+	for _, u := range users {
+		userIDs = append(userIDs, u.ID)
+	}
+	identities, err := db.ListIdentitiesForUserIDs(ctx, tx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	issuers, err := s.issuers(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []orgUser
+	for _, u := range users {
+		membership, ok := membershipByUser[u.ID]
+		if !ok {
+			continue
+		}
+		res = append(res, orgUser{
+			user:       u,
+			identity:   selectIdentity(u.ID, identities, issuers),
+			membership: membership,
+		})
+	}
+	return res, nil
+}
+
+func (s *Service) getOrgUser(ctx context.Context, tx *gorm.DB, orgID uuid.UUID, id string) (orgUser, error) {
+	notFound := newError(http.StatusNotFound, "", "User %s not found.", id)
+
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return orgUser{}, notFound
+	}
+
+	user, err := db.GetUser(ctx, tx, userID)
+	if errors.Is(err, db.ErrorNotFound) {
+		return orgUser{}, notFound
+	}
+	if err != nil {
+		return orgUser{}, err
+	}
+	if user.OrganizationID != orgID.String() {
+		return orgUser{}, notFound
+	}
+
+	membership, err := db.GetTeamMembership(ctx, tx, userID, orgID)
+	if errors.Is(err, db.ErrorNotFound) {
+		return orgUser{}, notFound
+	}
+	if err != nil {
+		return orgUser{}, err
+	}
+
+	identities, err := db.ListIdentitiesForUserIDs(ctx, tx, []uuid.UUID{userID})
+	if err != nil {
+		return orgUser{}, err
+	}
+	issuers, err := s.issuers(ctx, tx, orgID)
+	if err != nil {
+		return orgUser{}, err
+	}
+
+	return orgUser{
+		user:       user,
+		identity:   selectIdentity(userID, identities, issuers),
+		membership: membership,
+	}, nil
+}
+
+// selectIdentity returns the identity of the user with the first of the issuers, or any identity of the user.
+func selectIdentity(userID uuid.UUID, identities []db.Identity, issuers []string) db.Identity {
+	var res *db.Identity
+	rank := len(issuers)
+	for i := range identities {
+		if identities[i].UserID != userID {
+			continue
+		}
+		r := len(issuers)
+		for j, issuer := range issuers {
+			if identities[i].AuthProviderID == issuer {
+				r = j
+				break
+			}
+		}
+		if res == nil || r < rank {
+			res, rank = &identities[i], r
+		}
+	}
+	if res == nil {
+		return db.Identity{UserID: userID}
+	}
+	return *res
+}
+
+func (s *Service) toSCIMUser(u orgUser) User {
+	active := Bool(!u.user.Blocked)
+	res := User{
+		Schemas:     []string{SchemaUser},
+		ID:          u.user.ID.String(),
+		ExternalID:  u.identity.AuthID,
+		UserName:    u.identity.AuthName,
+		DisplayName: u.user.Name,
+		Active:      &active,
+		Groups: []Member{{
+			Value:   string(u.membership.Role),
+			Display: groupDisplayName(u.membership.Role),
+			Ref:     s.location("Groups", string(u.membership.Role)),
+		}},
+		Meta: &Meta{
+			ResourceType: "User",
+			LastModified: u.user.LastModified.UTC().Format(time.RFC3339),
+			Location:     s.location("Users", u.user.ID.String()),
+		},
+	}
+	if u.user.CreationDate.IsSet() {
+		res.Meta.Created = u.user.CreationDate.Time().UTC().Format(time.RFC3339)
+	}
+	if u.user.FullName != "" {
+		res.Name = &Name{Formatted: u.user.FullName}
+	}
+	if u.identity.PrimaryEmail != "" {
+		res.Emails = []Email{{Value: u.identity.PrimaryEmail, Type: "work", Primary: true}}
+	}
+	return res
+}
+
+func (s *Service) location(resourceType, id string) string {
+	return fmt.Sprintf("%s/%s/%s", s.baseURL, resourceType, id)
+}
+
+func validateUser(u User) error {
+	if strings.TrimSpace(u.UserName) == "" {
+		return newError(http.StatusBadRequest, "invalidValue", "userName is required.")
+	}
+	// The identities of provisioned users are matched against the subject of the ID tokens issued to them.
+	if strings.TrimSpace(u.ExternalID) == "" {
+		return newError(http.StatusBadRequest, "invalidValue", "externalId is required, and must be the subject (sub) of the ID tokens issued to the user.")
+	}
+	return nil
+}
+
+func isActive(u User) bool {
+	return u.Active == nil || bool(*u.Active)
+}
+
+type profile struct {
+	name     string
+	fullName string
+	email    string
+}
+
+func profileOf(u User) profile {
+	var res profile
+	if u.Name != nil {
+		res.fullName = u.Name.Formatted
+		if res.fullName == "" {
+			res.fullName = strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+		}
+	}
+
+	res.name = u.DisplayName
+	if res.name == "" {
+		res.name = res.fullName
+	}
+	if res.name == "" {
+		res.name = u.UserName
+	}
+
+	for _, e := range u.Emails {
+		if e.Primary {
+			res.email = e.Value
+			break
+		}
+	}
+	if res.email == "" && len(u.Emails) > 0 {
+		res.email = u.Emails[0].Value
+	}
+	return res
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/bufbuild/connect-go"
 	"github.com/gitpod-io/gitpod/common-go/experiments"
+	common_grpc "github.com/gitpod-io/gitpod/common-go/grpc"
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/go-chi/chi/v5"
 	chi_middleware "github.com/go-chi/chi/v5/middleware"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/gitpod-io/gitpod/common-go/baseserver"
 	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/public-api-server/middleware"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/apiv1"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/auth"
@@ -37,8 +39,13 @@ import (
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/oidc"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/origin"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/proxy"
+	"github.com/gitpod-io/gitpod/public-api-server/pkg/scim"
 	"github.com/gitpod-io/gitpod/public-api-server/pkg/webhooks"
+	wsmanapi "github.com/gitpod-io/gitpod/ws-manager/api"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 func Start(logger *logrus.Entry, version string, cfg *config.Configuration) error {
@@ -85,6 +92,12 @@ func Start(logger *logrus.Entry, version string, cfg *config.Configuration) erro
 		return fmt.Errorf("failed to initialize public api server: %w", err)
 	}
 
+	auditLogSinks, err := db.NewAuditLogSinks(auditLogSinksConfig(cfg.AuditLogSinks))
+	if err != nil {
+		return fmt.Errorf("failed to setup audit log sinks: %w", err)
+	}
+	auditLog := db.NewAuditLogWriter(dbConn, auditLogSinks...)
+
 	var billingService billingservice.Interface = &billingservice.NoOpClient{}
 	if cfg.BillingServiceAddress != "" {
 		billingService, err = billingservice.New(cfg.BillingServiceAddress)
@@ -93,6 +106,46 @@ func Start(logger *logrus.Entry, version string, cfg *config.Configuration) erro
 		}
 	}
 
+	var workspaceTemplatesService *apiv1.WorkspaceTemplatesService
+	if cfg.WorkspaceTemplates != nil {
+		var contentService csapi.WorkspaceServiceClient
+		if cfg.WorkspaceTemplates.ContentServiceAddress != "" {
+			contentServiceConn, err := grpc.Dial(cfg.WorkspaceTemplates.ContentServiceAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				return fmt.Errorf("failed to dial content service gRPC server: %w", err)
+			}
+			contentService = csapi.NewWorkspaceServiceClient(contentServiceConn)
+		} else {
+			log.Info("No content service address is configured, snapshots of deleted workspace templates will be retained.")
+		}
+		workspaceTemplatesService = apiv1.NewWorkspaceTemplatesService(connPool, dbConn, contentService, cfg.WorkspaceTemplates.KeepVersions, auditLog)
+	} else {
+		log.Info("No workspace templates configuration, WorkspaceTemplates service will be disabled.")
+	}
+
+	var prebuildsService *apiv1.PrebuildsService
+	if cfg.Prebuilds != nil {
+		dialOption := grpc.WithTransportCredentials(insecure.NewCredentials())
+		if tlsCfg := cfg.Prebuilds.TLS; tlsCfg != nil {
+			tlsConfig, err := common_grpc.ClientAuthTLSConfig(
+				tlsCfg.CA, tlsCfg.Certificate, tlsCfg.PrivateKey,
+				common_grpc.WithSetRootCAs(true),
+				common_grpc.WithServerName("ws-manager"),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to load ws-manager TLS certificates: %w", err)
+			}
+			dialOption = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+		}
+		wsmanConn, err := grpc.Dial(cfg.Prebuilds.WorkspaceManagerAddress, dialOption)
+		if err != nil {
+			return fmt.Errorf("failed to dial ws-manager gRPC server: %w", err)
+		}
+		prebuildsService = apiv1.NewPrebuildsService(connPool, dbConn, wsmanapi.NewPrebuildSchedulerClient(wsmanConn))
+	} else {
+		log.Info("No prebuilds configuration, Prebuilds service will be disabled.")
+	}
+
 	keyset, err := jws.NewKeySetFromAuthPKI(cfg.Auth.PKI)
 	if err != nil {
 		return fmt.Errorf("failed to setup JWS Keyset: %w", err)
@@ -128,15 +181,26 @@ func Start(logger *logrus.Entry, version string, cfg *config.Configuration) erro
 
 	srv.HTTPMux().Handle("/stripe/invoices/webhook", handlers.ContentTypeHandler(stripeWebhookHandler, "application/json"))
 
-	oidcService := oidc.NewService(cfg.SessionServiceAddress, dbConn, cipherSet, hs256, 5*time.Minute)
+	oidcService := oidc.NewService(cfg.SessionServiceAddress, dbConn, cipherSet, auditLog, hs256, 5*time.Minute)
 
 	if redisClient == nil {
 		return fmt.Errorf("no Redis configiured")
 	}
-	idpService, err := identityprovider.NewService(strings.TrimSuffix(cfg.PublicURL, "/")+"/idp", identityprovider.NewRedisCache(redisClient))
+	var idpCacheOpts []identityprovider.RedisCacheOption
+	if cfg.IdentityProvider.StoreSigningKey {
+		idpCacheOpts = append(idpCacheOpts, identityprovider.WithSigningKeyCipher(cipherSet))
+	}
+	idpKeyLifetime := time.Duration(cfg.IdentityProvider.KeyLifetime)
+	if idpKeyLifetime == 0 {
+		idpKeyLifetime = identityprovider.DefaultKeyLifetime
+	}
+	idpService, err := identityprovider.NewService(strings.TrimSuffix(cfg.PublicURL, "/")+"/idp", identityprovider.NewRedisCache(redisClient, idpCacheOpts...), idpKeyLifetime)
 	if err != nil {
 		return err
 	}
+	idpCtx, cancelIDPKeyRotation := context.WithCancel(context.Background())
+	defer cancelIDPKeyRotation()
+	go idpService.RunKeyRotation(idpCtx)
 
 	if registerErr := register(srv, &registerDependencies{
 		connPool:        connPool,
@@ -146,8 +210,13 @@ func Start(logger *logrus.Entry, version string, cfg *config.Configuration) erro
 		cipher:          cipherSet,
 		oidcService:     oidcService,
 		idpService:      idpService,
+		scimService:     scim.NewService(dbConn, v1connect.NewUserServiceClient(http.DefaultClient, fmt.Sprintf("http://%s", cfg.ServerAddress)), auditLog, strings.TrimSuffix(cfg.PublicURL, "/")+"/scim/v2"),
+		auditLog:        auditLog,
 		authCfg:         cfg.Auth,
 		sessionVerifier: rsa256,
+
+		workspaceTemplatesService: workspaceTemplatesService,
+		prebuildsService:          prebuildsService,
 	}); registerErr != nil {
 		return fmt.Errorf("failed to register services: %w", registerErr)
 	}
@@ -167,6 +236,11 @@ type registerDependencies struct {
 	cipher      db.Cipher
 	oidcService *oidc.Service
 	idpService  *identityprovider.Service
+	scimService *scim.Service
+	auditLog    *db.AuditLogWriter
+
+	workspaceTemplatesService *apiv1.WorkspaceTemplatesService
+	prebuildsService          *apiv1.PrebuildsService
 
 	sessionVerifier jws.SignerVerifier
 	authCfg         config.AuthConfiguration
@@ -197,15 +271,26 @@ func register(srv *baseserver.Server, deps *registerDependencies) error {
 	}
 
 	rootHandler.Mount(v1connect.NewWorkspacesServiceHandler(apiv1.NewWorkspaceService(deps.connPool), handlerOptions...))
-	rootHandler.Mount(v1connect.NewTeamsServiceHandler(apiv1.NewTeamsService(deps.connPool), handlerOptions...))
-	rootHandler.Mount(v1connect.NewUserServiceHandler(apiv1.NewUserService(deps.connPool), handlerOptions...))
+	rootHandler.Mount(v1connect.NewTeamsServiceHandler(apiv1.NewTeamsService(deps.connPool, deps.auditLog), handlerOptions...))
+	rootHandler.Mount(v1connect.NewUserServiceHandler(apiv1.NewUserService(deps.connPool, deps.dbConn, deps.auditLog), handlerOptions...))
 	rootHandler.Mount(v1connect.NewIDEClientServiceHandler(apiv1.NewIDEClientService(deps.connPool), handlerOptions...))
 	rootHandler.Mount(v1connect.NewProjectsServiceHandler(apiv1.NewProjectsService(deps.connPool), handlerOptions...))
-	rootHandler.Mount(v1connect.NewOIDCServiceHandler(apiv1.NewOIDCService(deps.connPool, deps.expClient, deps.dbConn, deps.cipher), handlerOptions...))
+	rootHandler.Mount(v1connect.NewOIDCServiceHandler(apiv1.NewOIDCService(deps.connPool, deps.expClient, deps.dbConn, deps.cipher, deps.auditLog), handlerOptions...))
 	rootHandler.Mount(v1connect.NewIdentityProviderServiceHandler(apiv1.NewIdentityProviderService(deps.connPool, deps.idpService), handlerOptions...))
+	rootHandler.Mount(v1connect.NewAuditLogsServiceHandler(apiv1.NewAuditLogsService(deps.connPool, deps.dbConn), handlerOptions...))
 
 	if deps.signer != nil {
-		rootHandler.Mount(v1connect.NewTokensServiceHandler(apiv1.NewTokensService(deps.connPool, deps.expClient, deps.dbConn, deps.signer), handlerOptions...))
+		rootHandler.Mount(v1connect.NewTokensServiceHandler(apiv1.NewTokensService(deps.connPool, deps.expClient, deps.dbConn, deps.signer, deps.auditLog), handlerOptions...))
+	}
+
+	rootHandler.Mount(v1connect.NewUsageServiceHandler(apiv1.NewUsageService(deps.connPool, deps.dbConn), handlerOptions...))
+
+	if deps.workspaceTemplatesService != nil {
+		rootHandler.Mount(v1connect.NewWorkspaceTemplatesServiceHandler(deps.workspaceTemplatesService, handlerOptions...))
+	}
+
+	if deps.prebuildsService != nil {
+		rootHandler.Mount(v1connect.NewPrebuildsServiceHandler(deps.prebuildsService, handlerOptions...))
 	}
 
 	// OIDC sign-in handlers
@@ -216,12 +301,35 @@ func register(srv *baseserver.Server, deps *registerDependencies) error {
 	// See https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationRequest
 	rootHandler.Mount("/idp", deps.idpService.Router())
 
+	// SCIM provisioning of organization users, see https://www.rfc-editor.org/rfc/rfc7644
+	rootHandler.Mount("/scim/v2", deps.scimService.Router())
+
 	// All requests are handled by our root router
 	srv.HTTPMux().Handle("/", rootHandler)
 
 	return nil
 }
 
+func auditLogSinksConfig(cfg *config.AuditLogSinksConfiguration) *db.AuditLogSinksConfig {
+	if cfg == nil {
+		return nil
+	}
+
+	result := &db.AuditLogSinksConfig{}
+	if cfg.Webhook != nil {
+		result.Webhook = &db.WebhookAuditLogSinkConfig{
+			URL:     cfg.Webhook.URL,
+			Headers: cfg.Webhook.Headers,
+		}
+	}
+	if cfg.File != nil {
+		result.File = &db.FileAuditLogSinkConfig{
+			Path: cfg.File.Path,
+		}
+	}
+	return result
+}
+
 func readSecretFromFile(path string) (string, error) {
 	b, err := os.ReadFile(path)
 	if err != nil {
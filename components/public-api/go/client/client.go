@@ -21,6 +21,10 @@ type Gitpod struct {
 	Projects             gitpod_experimental_v1connect.ProjectsServiceClient
 	PersonalAccessTokens gitpod_experimental_v1connect.TokensServiceClient
 	IdentityProvider     gitpod_experimental_v1connect.IdentityProviderServiceClient
+	Usage                gitpod_experimental_v1connect.UsageServiceClient
+	AuditLogs            gitpod_experimental_v1connect.AuditLogsServiceClient
+	WorkspaceTemplates   gitpod_experimental_v1connect.WorkspaceTemplatesServiceClient
+	Prebuilds            gitpod_experimental_v1connect.PrebuildsServiceClient
 }
 
 func New(options ...Option) (*Gitpod, error) {
@@ -47,6 +51,10 @@ func New(options ...Option) (*Gitpod, error) {
 	tokens := gitpod_experimental_v1connect.NewTokensServiceClient(client, url, serviceOpts...)
 	workspaces := gitpod_experimental_v1connect.NewWorkspacesServiceClient(client, url, serviceOpts...)
 	idp := gitpod_experimental_v1connect.NewIdentityProviderServiceClient(client, url, serviceOpts...)
+	usage := gitpod_experimental_v1connect.NewUsageServiceClient(client, url, serviceOpts...)
+	auditLogs := gitpod_experimental_v1connect.NewAuditLogsServiceClient(client, url, serviceOpts...)
+	workspaceTemplates := gitpod_experimental_v1connect.NewWorkspaceTemplatesServiceClient(client, url, serviceOpts...)
+	prebuilds := gitpod_experimental_v1connect.NewPrebuildsServiceClient(client, url, serviceOpts...)
 
 	return &Gitpod{
 		cfg:                  opts,
@@ -55,6 +63,10 @@ func New(options ...Option) (*Gitpod, error) {
 		PersonalAccessTokens: tokens,
 		Workspaces:           workspaces,
 		IdentityProvider:     idp,
+		Usage:                usage,
+		AuditLogs:            auditLogs,
+		WorkspaceTemplates:   workspaceTemplates,
+		Prebuilds:            prebuilds,
 	}, nil
 }
 
@@ -6,6 +6,7 @@ package config
 
 import (
 	"github.com/gitpod-io/gitpod/common-go/baseserver"
+	"github.com/gitpod-io/gitpod/common-go/util"
 )
 
 type Configuration struct {
@@ -14,6 +15,9 @@ type Configuration struct {
 
 	GitpodServiceURL string `json:"gitpodServiceUrl"`
 
+	// ServerAddress is the address of the internal API of server, e.g. to block users which are deprovisioned through SCIM
+	ServerAddress string `json:"serverAddress"`
+
 	BillingServiceAddress string `json:"billingServiceAddress,omitempty"`
 
 	// Address to use for creating new sessions
@@ -34,6 +38,19 @@ type Configuration struct {
 	// Authentication configuration
 	Auth AuthConfiguration `json:"auth"`
 
+	// IdentityProvider configures the identity provider which issues ID tokens, e.g. for `gp idp login`
+	IdentityProvider IdentityProviderConfiguration `json:"identityProvider"`
+
+	// AuditLogSinks configures external sinks to which audit logs are streamed, e.g. for ingestion into a SIEM.
+	// Audit logs are always stored in the database.
+	AuditLogSinks *AuditLogSinksConfiguration `json:"auditLogSinks,omitempty"`
+
+	// WorkspaceTemplates enables the WorkspaceTemplates service, if set
+	WorkspaceTemplates *WorkspaceTemplatesConfiguration `json:"workspaceTemplates,omitempty"`
+
+	// Prebuilds enables the Prebuilds service, if set
+	Prebuilds *PrebuildsConfiguration `json:"prebuilds,omitempty"`
+
 	Server *baseserver.Configuration `json:"server,omitempty"`
 }
 
@@ -43,6 +60,55 @@ type RedisConfiguration struct {
 	Address string `json:"address"`
 }
 
+type IdentityProviderConfiguration struct {
+	// KeyLifetime is the time after which the signing key is rotated. Defaults to 24h.
+	KeyLifetime util.Duration `json:"keyLifetime,omitempty"`
+
+	// StoreSigningKey stores the signing key in Redis, encrypted with the database cipher set.
+	// This shares the key between all replicas and retains it across restarts.
+	StoreSigningKey bool `json:"storeSigningKey,omitempty"`
+}
+
+type AuditLogSinksConfiguration struct {
+	Webhook *AuditLogWebhookSinkConfiguration `json:"webhook,omitempty"`
+	File    *AuditLogFileSinkConfiguration    `json:"file,omitempty"`
+}
+
+type AuditLogWebhookSinkConfiguration struct {
+	// URL receives every audit log as JSON in a POST request
+	URL string `json:"url"`
+
+	// Headers are added to every request, e.g. to authenticate with the receiver
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type AuditLogFileSinkConfiguration struct {
+	// Path of the file to which audit logs are appended as JSON lines
+	Path string `json:"path"`
+}
+
+type WorkspaceTemplatesConfiguration struct {
+	// ContentServiceAddress is used to delete the snapshots of template versions which are no longer retained
+	ContentServiceAddress string `json:"contentServiceAddress"`
+
+	// KeepVersions is the number of most recent versions which are retained of every template. Defaults to 5.
+	KeepVersions int `json:"keepVersions,omitempty"`
+}
+
+type PrebuildsConfiguration struct {
+	// WorkspaceManagerAddress is the address of ws-manager-mk2, which schedules and tracks prebuilds
+	WorkspaceManagerAddress string `json:"workspaceManagerAddress"`
+
+	// TLS configures the client certificate presented to ws-manager-mk2. Connections are insecure, if unset.
+	TLS *ClientTLSConfiguration `json:"tls,omitempty"`
+}
+
+type ClientTLSConfiguration struct {
+	CA          string `json:"ca"`
+	Certificate string `json:"crt"`
+	PrivateKey  string `json:"key"`
+}
+
 type AuthConfiguration struct {
 	PKI     AuthPKIConfiguration `json:"pki"`
 	Session SessionConfig        `json:"session"`
@@ -0,0 +1,113 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: gitpod/experimental/v1/audit_logs.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AuditLogsServiceClient is the client API for AuditLogsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuditLogsServiceClient interface {
+	// Lists the audit logs of an organization, most recent first.
+	// Only owners of the organization can list its audit logs.
+	ListAuditLogs(ctx context.Context, in *ListAuditLogsRequest, opts ...grpc.CallOption) (*ListAuditLogsResponse, error)
+}
+
+type auditLogsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuditLogsServiceClient(cc grpc.ClientConnInterface) AuditLogsServiceClient {
+	return &auditLogsServiceClient{cc}
+}
+
+func (c *auditLogsServiceClient) ListAuditLogs(ctx context.Context, in *ListAuditLogsRequest, opts ...grpc.CallOption) (*ListAuditLogsResponse, error) {
+	out := new(ListAuditLogsResponse)
+	err := c.cc.Invoke(ctx, "/gitpod.experimental.v1.AuditLogsService/ListAuditLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuditLogsServiceServer is the server API for AuditLogsService service.
+// All implementations must embed UnimplementedAuditLogsServiceServer
+// for forward compatibility
+type AuditLogsServiceServer interface {
+	// Lists the audit logs of an organization, most recent first.
+	// Only owners of the organization can list its audit logs.
+	ListAuditLogs(context.Context, *ListAuditLogsRequest) (*ListAuditLogsResponse, error)
+	mustEmbedUnimplementedAuditLogsServiceServer()
+}
+
+// UnimplementedAuditLogsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAuditLogsServiceServer struct {
+}
+
+func (UnimplementedAuditLogsServiceServer) ListAuditLogs(context.Context, *ListAuditLogsRequest) (*ListAuditLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditLogs not implemented")
+}
+func (UnimplementedAuditLogsServiceServer) mustEmbedUnimplementedAuditLogsServiceServer() {}
+
+// UnsafeAuditLogsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuditLogsServiceServer will
+// result in compilation errors.
+type UnsafeAuditLogsServiceServer interface {
+	mustEmbedUnimplementedAuditLogsServiceServer()
+}
+
+func RegisterAuditLogsServiceServer(s grpc.ServiceRegistrar, srv AuditLogsServiceServer) {
+	s.RegisterService(&AuditLogsService_ServiceDesc, srv)
+}
+
+func _AuditLogsService_ListAuditLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditLogsServiceServer).ListAuditLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpod.experimental.v1.AuditLogsService/ListAuditLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditLogsServiceServer).ListAuditLogs(ctx, req.(*ListAuditLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuditLogsService_ServiceDesc is the grpc.ServiceDesc for AuditLogsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuditLogsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gitpod.experimental.v1.AuditLogsService",
+	HandlerType: (*AuditLogsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAuditLogs",
+			Handler:    _AuditLogsService_ListAuditLogs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gitpod/experimental/v1/audit_logs.proto",
+}
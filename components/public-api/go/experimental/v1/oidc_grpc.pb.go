@@ -36,6 +36,11 @@ type OIDCServiceClient interface {
 	UpdateClientConfig(ctx context.Context, in *UpdateClientConfigRequest, opts ...grpc.CallOption) (*UpdateClientConfigResponse, error)
 	// Removes a OIDC client configuration by ID.
 	DeleteClientConfig(ctx context.Context, in *DeleteClientConfigRequest, opts ...grpc.CallOption) (*DeleteClientConfigResponse, error)
+	// Creates a token which authenticates SCIM provisioning requests for an organization.
+	// Any previous token of the organization is revoked.
+	CreateSCIMToken(ctx context.Context, in *CreateSCIMTokenRequest, opts ...grpc.CallOption) (*CreateSCIMTokenResponse, error)
+	// Revokes the SCIM token of an organization.
+	DeleteSCIMToken(ctx context.Context, in *DeleteSCIMTokenRequest, opts ...grpc.CallOption) (*DeleteSCIMTokenResponse, error)
 }
 
 type oIDCServiceClient struct {
@@ -91,6 +96,24 @@ func (c *oIDCServiceClient) DeleteClientConfig(ctx context.Context, in *DeleteCl
 	return out, nil
 }
 
+func (c *oIDCServiceClient) CreateSCIMToken(ctx context.Context, in *CreateSCIMTokenRequest, opts ...grpc.CallOption) (*CreateSCIMTokenResponse, error) {
+	out := new(CreateSCIMTokenResponse)
+	err := c.cc.Invoke(ctx, "/gitpod.experimental.v1.OIDCService/CreateSCIMToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oIDCServiceClient) DeleteSCIMToken(ctx context.Context, in *DeleteSCIMTokenRequest, opts ...grpc.CallOption) (*DeleteSCIMTokenResponse, error) {
+	out := new(DeleteSCIMTokenResponse)
+	err := c.cc.Invoke(ctx, "/gitpod.experimental.v1.OIDCService/DeleteSCIMToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OIDCServiceServer is the server API for OIDCService service.
 // All implementations must embed UnimplementedOIDCServiceServer
 // for forward compatibility
@@ -105,6 +128,11 @@ type OIDCServiceServer interface {
 	UpdateClientConfig(context.Context, *UpdateClientConfigRequest) (*UpdateClientConfigResponse, error)
 	// Removes a OIDC client configuration by ID.
 	DeleteClientConfig(context.Context, *DeleteClientConfigRequest) (*DeleteClientConfigResponse, error)
+	// Creates a token which authenticates SCIM provisioning requests for an organization.
+	// Any previous token of the organization is revoked.
+	CreateSCIMToken(context.Context, *CreateSCIMTokenRequest) (*CreateSCIMTokenResponse, error)
+	// Revokes the SCIM token of an organization.
+	DeleteSCIMToken(context.Context, *DeleteSCIMTokenRequest) (*DeleteSCIMTokenResponse, error)
 	mustEmbedUnimplementedOIDCServiceServer()
 }
 
@@ -127,6 +155,12 @@ func (UnimplementedOIDCServiceServer) UpdateClientConfig(context.Context, *Updat
 func (UnimplementedOIDCServiceServer) DeleteClientConfig(context.Context, *DeleteClientConfigRequest) (*DeleteClientConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteClientConfig not implemented")
 }
+func (UnimplementedOIDCServiceServer) CreateSCIMToken(context.Context, *CreateSCIMTokenRequest) (*CreateSCIMTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSCIMToken not implemented")
+}
+func (UnimplementedOIDCServiceServer) DeleteSCIMToken(context.Context, *DeleteSCIMTokenRequest) (*DeleteSCIMTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSCIMToken not implemented")
+}
 func (UnimplementedOIDCServiceServer) mustEmbedUnimplementedOIDCServiceServer() {}
 
 // UnsafeOIDCServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -230,6 +264,42 @@ func _OIDCService_DeleteClientConfig_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OIDCService_CreateSCIMToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSCIMTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OIDCServiceServer).CreateSCIMToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpod.experimental.v1.OIDCService/CreateSCIMToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OIDCServiceServer).CreateSCIMToken(ctx, req.(*CreateSCIMTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OIDCService_DeleteSCIMToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSCIMTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OIDCServiceServer).DeleteSCIMToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpod.experimental.v1.OIDCService/DeleteSCIMToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OIDCServiceServer).DeleteSCIMToken(ctx, req.(*DeleteSCIMTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OIDCService_ServiceDesc is the grpc.ServiceDesc for OIDCService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -257,6 +327,14 @@ var OIDCService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteClientConfig",
 			Handler:    _OIDCService_DeleteClientConfig_Handler,
 		},
+		{
+			MethodName: "CreateSCIMToken",
+			Handler:    _OIDCService_CreateSCIMToken_Handler,
+		},
+		{
+			MethodName: "DeleteSCIMToken",
+			Handler:    _OIDCService_DeleteSCIMToken_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "gitpod/experimental/v1/oidc.proto",
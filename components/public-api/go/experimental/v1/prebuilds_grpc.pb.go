@@ -0,0 +1,187 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: gitpod/experimental/v1/prebuilds.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PrebuildsServiceClient is the client API for PrebuildsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PrebuildsServiceClient interface {
+	// GetPrebuild returns the prebuild of a commit of a project.
+	GetPrebuild(ctx context.Context, in *GetPrebuildRequest, opts ...grpc.CallOption) (*GetPrebuildResponse, error)
+	// ListPrebuilds lists the prebuilds of a project, most recent first.
+	ListPrebuilds(ctx context.Context, in *ListPrebuildsRequest, opts ...grpc.CallOption) (*ListPrebuildsResponse, error)
+	// CancelPrebuild stops all running and queued attempts of a prebuild.
+	CancelPrebuild(ctx context.Context, in *CancelPrebuildRequest, opts ...grpc.CallOption) (*CancelPrebuildResponse, error)
+}
+
+type prebuildsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPrebuildsServiceClient(cc grpc.ClientConnInterface) PrebuildsServiceClient {
+	return &prebuildsServiceClient{cc}
+}
+
+func (c *prebuildsServiceClient) GetPrebuild(ctx context.Context, in *GetPrebuildRequest, opts ...grpc.CallOption) (*GetPrebuildResponse, error) {
+	out := new(GetPrebuildResponse)
+	err := c.cc.Invoke(ctx, "/gitpod.experimental.v1.PrebuildsService/GetPrebuild", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prebuildsServiceClient) ListPrebuilds(ctx context.Context, in *ListPrebuildsRequest, opts ...grpc.CallOption) (*ListPrebuildsResponse, error) {
+	out := new(ListPrebuildsResponse)
+	err := c.cc.Invoke(ctx, "/gitpod.experimental.v1.PrebuildsService/ListPrebuilds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prebuildsServiceClient) CancelPrebuild(ctx context.Context, in *CancelPrebuildRequest, opts ...grpc.CallOption) (*CancelPrebuildResponse, error) {
+	out := new(CancelPrebuildResponse)
+	err := c.cc.Invoke(ctx, "/gitpod.experimental.v1.PrebuildsService/CancelPrebuild", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PrebuildsServiceServer is the server API for PrebuildsService service.
+// All implementations must embed UnimplementedPrebuildsServiceServer
+// for forward compatibility
+type PrebuildsServiceServer interface {
+	// GetPrebuild returns the prebuild of a commit of a project.
+	GetPrebuild(context.Context, *GetPrebuildRequest) (*GetPrebuildResponse, error)
+	// ListPrebuilds lists the prebuilds of a project, most recent first.
+	ListPrebuilds(context.Context, *ListPrebuildsRequest) (*ListPrebuildsResponse, error)
+	// CancelPrebuild stops all running and queued attempts of a prebuild.
+	CancelPrebuild(context.Context, *CancelPrebuildRequest) (*CancelPrebuildResponse, error)
+	mustEmbedUnimplementedPrebuildsServiceServer()
+}
+
+// UnimplementedPrebuildsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPrebuildsServiceServer struct {
+}
+
+func (UnimplementedPrebuildsServiceServer) GetPrebuild(context.Context, *GetPrebuildRequest) (*GetPrebuildResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPrebuild not implemented")
+}
+func (UnimplementedPrebuildsServiceServer) ListPrebuilds(context.Context, *ListPrebuildsRequest) (*ListPrebuildsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPrebuilds not implemented")
+}
+func (UnimplementedPrebuildsServiceServer) CancelPrebuild(context.Context, *CancelPrebuildRequest) (*CancelPrebuildResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelPrebuild not implemented")
+}
+func (UnimplementedPrebuildsServiceServer) mustEmbedUnimplementedPrebuildsServiceServer() {}
+
+// UnsafePrebuildsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PrebuildsServiceServer will
+// result in compilation errors.
+type UnsafePrebuildsServiceServer interface {
+	mustEmbedUnimplementedPrebuildsServiceServer()
+}
+
+func RegisterPrebuildsServiceServer(s grpc.ServiceRegistrar, srv PrebuildsServiceServer) {
+	s.RegisterService(&PrebuildsService_ServiceDesc, srv)
+}
+
+func _PrebuildsService_GetPrebuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPrebuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrebuildsServiceServer).GetPrebuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpod.experimental.v1.PrebuildsService/GetPrebuild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrebuildsServiceServer).GetPrebuild(ctx, req.(*GetPrebuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrebuildsService_ListPrebuilds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPrebuildsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrebuildsServiceServer).ListPrebuilds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpod.experimental.v1.PrebuildsService/ListPrebuilds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrebuildsServiceServer).ListPrebuilds(ctx, req.(*ListPrebuildsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrebuildsService_CancelPrebuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPrebuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrebuildsServiceServer).CancelPrebuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpod.experimental.v1.PrebuildsService/CancelPrebuild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrebuildsServiceServer).CancelPrebuild(ctx, req.(*CancelPrebuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PrebuildsService_ServiceDesc is the grpc.ServiceDesc for PrebuildsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PrebuildsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gitpod.experimental.v1.PrebuildsService",
+	HandlerType: (*PrebuildsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPrebuild",
+			Handler:    _PrebuildsService_GetPrebuild_Handler,
+		},
+		{
+			MethodName: "ListPrebuilds",
+			Handler:    _PrebuildsService_ListPrebuilds_Handler,
+		},
+		{
+			MethodName: "CancelPrebuild",
+			Handler:    _PrebuildsService_CancelPrebuild_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gitpod/experimental/v1/prebuilds.proto",
+}
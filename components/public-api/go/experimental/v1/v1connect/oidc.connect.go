@@ -41,6 +41,11 @@ type OIDCServiceClient interface {
 	UpdateClientConfig(context.Context, *connect_go.Request[v1.UpdateClientConfigRequest]) (*connect_go.Response[v1.UpdateClientConfigResponse], error)
 	// Removes a OIDC client configuration by ID.
 	DeleteClientConfig(context.Context, *connect_go.Request[v1.DeleteClientConfigRequest]) (*connect_go.Response[v1.DeleteClientConfigResponse], error)
+	// Creates a token which authenticates SCIM provisioning requests for an organization.
+	// Any previous token of the organization is revoked.
+	CreateSCIMToken(context.Context, *connect_go.Request[v1.CreateSCIMTokenRequest]) (*connect_go.Response[v1.CreateSCIMTokenResponse], error)
+	// Revokes the SCIM token of an organization.
+	DeleteSCIMToken(context.Context, *connect_go.Request[v1.DeleteSCIMTokenRequest]) (*connect_go.Response[v1.DeleteSCIMTokenResponse], error)
 }
 
 // NewOIDCServiceClient constructs a client for the gitpod.experimental.v1.OIDCService service. By
@@ -78,6 +83,16 @@ func NewOIDCServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts
 			baseURL+"/gitpod.experimental.v1.OIDCService/DeleteClientConfig",
 			opts...,
 		),
+		createSCIMToken: connect_go.NewClient[v1.CreateSCIMTokenRequest, v1.CreateSCIMTokenResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.OIDCService/CreateSCIMToken",
+			opts...,
+		),
+		deleteSCIMToken: connect_go.NewClient[v1.DeleteSCIMTokenRequest, v1.DeleteSCIMTokenResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.OIDCService/DeleteSCIMToken",
+			opts...,
+		),
 	}
 }
 
@@ -88,6 +103,8 @@ type oIDCServiceClient struct {
 	listClientConfigs  *connect_go.Client[v1.ListClientConfigsRequest, v1.ListClientConfigsResponse]
 	updateClientConfig *connect_go.Client[v1.UpdateClientConfigRequest, v1.UpdateClientConfigResponse]
 	deleteClientConfig *connect_go.Client[v1.DeleteClientConfigRequest, v1.DeleteClientConfigResponse]
+	createSCIMToken    *connect_go.Client[v1.CreateSCIMTokenRequest, v1.CreateSCIMTokenResponse]
+	deleteSCIMToken    *connect_go.Client[v1.DeleteSCIMTokenRequest, v1.DeleteSCIMTokenResponse]
 }
 
 // CreateClientConfig calls gitpod.experimental.v1.OIDCService.CreateClientConfig.
@@ -115,6 +132,16 @@ func (c *oIDCServiceClient) DeleteClientConfig(ctx context.Context, req *connect
 	return c.deleteClientConfig.CallUnary(ctx, req)
 }
 
+// CreateSCIMToken calls gitpod.experimental.v1.OIDCService.CreateSCIMToken.
+func (c *oIDCServiceClient) CreateSCIMToken(ctx context.Context, req *connect_go.Request[v1.CreateSCIMTokenRequest]) (*connect_go.Response[v1.CreateSCIMTokenResponse], error) {
+	return c.createSCIMToken.CallUnary(ctx, req)
+}
+
+// DeleteSCIMToken calls gitpod.experimental.v1.OIDCService.DeleteSCIMToken.
+func (c *oIDCServiceClient) DeleteSCIMToken(ctx context.Context, req *connect_go.Request[v1.DeleteSCIMTokenRequest]) (*connect_go.Response[v1.DeleteSCIMTokenResponse], error) {
+	return c.deleteSCIMToken.CallUnary(ctx, req)
+}
+
 // OIDCServiceHandler is an implementation of the gitpod.experimental.v1.OIDCService service.
 type OIDCServiceHandler interface {
 	// Creates a new OIDC client configuration.
@@ -127,6 +154,11 @@ type OIDCServiceHandler interface {
 	UpdateClientConfig(context.Context, *connect_go.Request[v1.UpdateClientConfigRequest]) (*connect_go.Response[v1.UpdateClientConfigResponse], error)
 	// Removes a OIDC client configuration by ID.
 	DeleteClientConfig(context.Context, *connect_go.Request[v1.DeleteClientConfigRequest]) (*connect_go.Response[v1.DeleteClientConfigResponse], error)
+	// Creates a token which authenticates SCIM provisioning requests for an organization.
+	// Any previous token of the organization is revoked.
+	CreateSCIMToken(context.Context, *connect_go.Request[v1.CreateSCIMTokenRequest]) (*connect_go.Response[v1.CreateSCIMTokenResponse], error)
+	// Revokes the SCIM token of an organization.
+	DeleteSCIMToken(context.Context, *connect_go.Request[v1.DeleteSCIMTokenRequest]) (*connect_go.Response[v1.DeleteSCIMTokenResponse], error)
 }
 
 // NewOIDCServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -161,6 +193,16 @@ func NewOIDCServiceHandler(svc OIDCServiceHandler, opts ...connect_go.HandlerOpt
 		svc.DeleteClientConfig,
 		opts...,
 	))
+	mux.Handle("/gitpod.experimental.v1.OIDCService/CreateSCIMToken", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.OIDCService/CreateSCIMToken",
+		svc.CreateSCIMToken,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.OIDCService/DeleteSCIMToken", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.OIDCService/DeleteSCIMToken",
+		svc.DeleteSCIMToken,
+		opts...,
+	))
 	return "/gitpod.experimental.v1.OIDCService/", mux
 }
 
@@ -186,3 +228,11 @@ func (UnimplementedOIDCServiceHandler) UpdateClientConfig(context.Context, *conn
 func (UnimplementedOIDCServiceHandler) DeleteClientConfig(context.Context, *connect_go.Request[v1.DeleteClientConfigRequest]) (*connect_go.Response[v1.DeleteClientConfigResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.OIDCService.DeleteClientConfig is not implemented"))
 }
+
+func (UnimplementedOIDCServiceHandler) CreateSCIMToken(context.Context, *connect_go.Request[v1.CreateSCIMTokenRequest]) (*connect_go.Response[v1.CreateSCIMTokenResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.OIDCService.CreateSCIMToken is not implemented"))
+}
+
+func (UnimplementedOIDCServiceHandler) DeleteSCIMToken(context.Context, *connect_go.Request[v1.DeleteSCIMTokenRequest]) (*connect_go.Response[v1.DeleteSCIMTokenResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.OIDCService.DeleteSCIMToken is not implemented"))
+}
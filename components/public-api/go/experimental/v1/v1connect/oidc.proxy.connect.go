@@ -68,3 +68,23 @@ func (s *ProxyOIDCServiceHandler) DeleteClientConfig(ctx context.Context, req *c
 
 	return connect_go.NewResponse(resp), nil
 }
+
+func (s *ProxyOIDCServiceHandler) CreateSCIMToken(ctx context.Context, req *connect_go.Request[v1.CreateSCIMTokenRequest]) (*connect_go.Response[v1.CreateSCIMTokenResponse], error) {
+	resp, err := s.Client.CreateSCIMToken(ctx, req.Msg)
+	if err != nil {
+		// TODO(milan): Convert to correct status code
+		return nil, err
+	}
+
+	return connect_go.NewResponse(resp), nil
+}
+
+func (s *ProxyOIDCServiceHandler) DeleteSCIMToken(ctx context.Context, req *connect_go.Request[v1.DeleteSCIMTokenRequest]) (*connect_go.Response[v1.DeleteSCIMTokenResponse], error) {
+	resp, err := s.Client.DeleteSCIMToken(ctx, req.Msg)
+	if err != nil {
+		// TODO(milan): Convert to correct status code
+		return nil, err
+	}
+
+	return connect_go.NewResponse(resp), nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: gitpod/experimental/v1/prebuilds.proto
+
+package v1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// PrebuildsServiceName is the fully-qualified name of the PrebuildsService service.
+	PrebuildsServiceName = "gitpod.experimental.v1.PrebuildsService"
+)
+
+// PrebuildsServiceClient is a client for the gitpod.experimental.v1.PrebuildsService service.
+type PrebuildsServiceClient interface {
+	// GetPrebuild returns the prebuild of a commit of a project.
+	GetPrebuild(context.Context, *connect_go.Request[v1.GetPrebuildRequest]) (*connect_go.Response[v1.GetPrebuildResponse], error)
+	// ListPrebuilds lists the prebuilds of a project, most recent first.
+	ListPrebuilds(context.Context, *connect_go.Request[v1.ListPrebuildsRequest]) (*connect_go.Response[v1.ListPrebuildsResponse], error)
+	// CancelPrebuild stops all running and queued attempts of a prebuild.
+	CancelPrebuild(context.Context, *connect_go.Request[v1.CancelPrebuildRequest]) (*connect_go.Response[v1.CancelPrebuildResponse], error)
+}
+
+// NewPrebuildsServiceClient constructs a client for the gitpod.experimental.v1.PrebuildsService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewPrebuildsServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) PrebuildsServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &prebuildsServiceClient{
+		getPrebuild: connect_go.NewClient[v1.GetPrebuildRequest, v1.GetPrebuildResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.PrebuildsService/GetPrebuild",
+			opts...,
+		),
+		listPrebuilds: connect_go.NewClient[v1.ListPrebuildsRequest, v1.ListPrebuildsResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.PrebuildsService/ListPrebuilds",
+			opts...,
+		),
+		cancelPrebuild: connect_go.NewClient[v1.CancelPrebuildRequest, v1.CancelPrebuildResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.PrebuildsService/CancelPrebuild",
+			opts...,
+		),
+	}
+}
+
+// prebuildsServiceClient implements PrebuildsServiceClient.
+type prebuildsServiceClient struct {
+	getPrebuild    *connect_go.Client[v1.GetPrebuildRequest, v1.GetPrebuildResponse]
+	listPrebuilds  *connect_go.Client[v1.ListPrebuildsRequest, v1.ListPrebuildsResponse]
+	cancelPrebuild *connect_go.Client[v1.CancelPrebuildRequest, v1.CancelPrebuildResponse]
+}
+
+// GetPrebuild calls gitpod.experimental.v1.PrebuildsService.GetPrebuild.
+func (c *prebuildsServiceClient) GetPrebuild(ctx context.Context, req *connect_go.Request[v1.GetPrebuildRequest]) (*connect_go.Response[v1.GetPrebuildResponse], error) {
+	return c.getPrebuild.CallUnary(ctx, req)
+}
+
+// ListPrebuilds calls gitpod.experimental.v1.PrebuildsService.ListPrebuilds.
+func (c *prebuildsServiceClient) ListPrebuilds(ctx context.Context, req *connect_go.Request[v1.ListPrebuildsRequest]) (*connect_go.Response[v1.ListPrebuildsResponse], error) {
+	return c.listPrebuilds.CallUnary(ctx, req)
+}
+
+// CancelPrebuild calls gitpod.experimental.v1.PrebuildsService.CancelPrebuild.
+func (c *prebuildsServiceClient) CancelPrebuild(ctx context.Context, req *connect_go.Request[v1.CancelPrebuildRequest]) (*connect_go.Response[v1.CancelPrebuildResponse], error) {
+	return c.cancelPrebuild.CallUnary(ctx, req)
+}
+
+// PrebuildsServiceHandler is an implementation of the gitpod.experimental.v1.PrebuildsService
+// service.
+type PrebuildsServiceHandler interface {
+	// GetPrebuild returns the prebuild of a commit of a project.
+	GetPrebuild(context.Context, *connect_go.Request[v1.GetPrebuildRequest]) (*connect_go.Response[v1.GetPrebuildResponse], error)
+	// ListPrebuilds lists the prebuilds of a project, most recent first.
+	ListPrebuilds(context.Context, *connect_go.Request[v1.ListPrebuildsRequest]) (*connect_go.Response[v1.ListPrebuildsResponse], error)
+	// CancelPrebuild stops all running and queued attempts of a prebuild.
+	CancelPrebuild(context.Context, *connect_go.Request[v1.CancelPrebuildRequest]) (*connect_go.Response[v1.CancelPrebuildResponse], error)
+}
+
+// NewPrebuildsServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewPrebuildsServiceHandler(svc PrebuildsServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle("/gitpod.experimental.v1.PrebuildsService/GetPrebuild", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.PrebuildsService/GetPrebuild",
+		svc.GetPrebuild,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.PrebuildsService/ListPrebuilds", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.PrebuildsService/ListPrebuilds",
+		svc.ListPrebuilds,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.PrebuildsService/CancelPrebuild", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.PrebuildsService/CancelPrebuild",
+		svc.CancelPrebuild,
+		opts...,
+	))
+	return "/gitpod.experimental.v1.PrebuildsService/", mux
+}
+
+// UnimplementedPrebuildsServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedPrebuildsServiceHandler struct{}
+
+func (UnimplementedPrebuildsServiceHandler) GetPrebuild(context.Context, *connect_go.Request[v1.GetPrebuildRequest]) (*connect_go.Response[v1.GetPrebuildResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.PrebuildsService.GetPrebuild is not implemented"))
+}
+
+func (UnimplementedPrebuildsServiceHandler) ListPrebuilds(context.Context, *connect_go.Request[v1.ListPrebuildsRequest]) (*connect_go.Response[v1.ListPrebuildsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.PrebuildsService.ListPrebuilds is not implemented"))
+}
+
+func (UnimplementedPrebuildsServiceHandler) CancelPrebuild(context.Context, *connect_go.Request[v1.CancelPrebuildRequest]) (*connect_go.Response[v1.CancelPrebuildResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.PrebuildsService.CancelPrebuild is not implemented"))
+}
@@ -39,6 +39,8 @@ type ProjectsServiceClient interface {
 	ListProjects(context.Context, *connect_go.Request[v1.ListProjectsRequest]) (*connect_go.Response[v1.ListProjectsResponse], error)
 	// Deletes a project.
 	DeleteProject(context.Context, *connect_go.Request[v1.DeleteProjectRequest]) (*connect_go.Response[v1.DeleteProjectResponse], error)
+	// Updates the settings of a project.
+	UpdateProject(context.Context, *connect_go.Request[v1.UpdateProjectRequest]) (*connect_go.Response[v1.UpdateProjectResponse], error)
 }
 
 // NewProjectsServiceClient constructs a client for the gitpod.experimental.v1.ProjectsService
@@ -71,6 +73,11 @@ func NewProjectsServiceClient(httpClient connect_go.HTTPClient, baseURL string,
 			baseURL+"/gitpod.experimental.v1.ProjectsService/DeleteProject",
 			opts...,
 		),
+		updateProject: connect_go.NewClient[v1.UpdateProjectRequest, v1.UpdateProjectResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.ProjectsService/UpdateProject",
+			opts...,
+		),
 	}
 }
 
@@ -80,6 +87,7 @@ type projectsServiceClient struct {
 	getProject    *connect_go.Client[v1.GetProjectRequest, v1.GetProjectResponse]
 	listProjects  *connect_go.Client[v1.ListProjectsRequest, v1.ListProjectsResponse]
 	deleteProject *connect_go.Client[v1.DeleteProjectRequest, v1.DeleteProjectResponse]
+	updateProject *connect_go.Client[v1.UpdateProjectRequest, v1.UpdateProjectResponse]
 }
 
 // CreateProject calls gitpod.experimental.v1.ProjectsService.CreateProject.
@@ -102,6 +110,11 @@ func (c *projectsServiceClient) DeleteProject(ctx context.Context, req *connect_
 	return c.deleteProject.CallUnary(ctx, req)
 }
 
+// UpdateProject calls gitpod.experimental.v1.ProjectsService.UpdateProject.
+func (c *projectsServiceClient) UpdateProject(ctx context.Context, req *connect_go.Request[v1.UpdateProjectRequest]) (*connect_go.Response[v1.UpdateProjectResponse], error) {
+	return c.updateProject.CallUnary(ctx, req)
+}
+
 // ProjectsServiceHandler is an implementation of the gitpod.experimental.v1.ProjectsService
 // service.
 type ProjectsServiceHandler interface {
@@ -113,6 +126,8 @@ type ProjectsServiceHandler interface {
 	ListProjects(context.Context, *connect_go.Request[v1.ListProjectsRequest]) (*connect_go.Response[v1.ListProjectsResponse], error)
 	// Deletes a project.
 	DeleteProject(context.Context, *connect_go.Request[v1.DeleteProjectRequest]) (*connect_go.Response[v1.DeleteProjectResponse], error)
+	// Updates the settings of a project.
+	UpdateProject(context.Context, *connect_go.Request[v1.UpdateProjectRequest]) (*connect_go.Response[v1.UpdateProjectResponse], error)
 }
 
 // NewProjectsServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -142,6 +157,11 @@ func NewProjectsServiceHandler(svc ProjectsServiceHandler, opts ...connect_go.Ha
 		svc.DeleteProject,
 		opts...,
 	))
+	mux.Handle("/gitpod.experimental.v1.ProjectsService/UpdateProject", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.ProjectsService/UpdateProject",
+		svc.UpdateProject,
+		opts...,
+	))
 	return "/gitpod.experimental.v1.ProjectsService/", mux
 }
 
@@ -163,3 +183,7 @@ func (UnimplementedProjectsServiceHandler) ListProjects(context.Context, *connec
 func (UnimplementedProjectsServiceHandler) DeleteProject(context.Context, *connect_go.Request[v1.DeleteProjectRequest]) (*connect_go.Response[v1.DeleteProjectResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.ProjectsService.DeleteProject is not implemented"))
 }
+
+func (UnimplementedProjectsServiceHandler) UpdateProject(context.Context, *connect_go.Request[v1.UpdateProjectRequest]) (*connect_go.Response[v1.UpdateProjectResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.ProjectsService.UpdateProject is not implemented"))
+}
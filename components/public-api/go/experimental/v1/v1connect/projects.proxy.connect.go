@@ -58,3 +58,13 @@ func (s *ProxyProjectsServiceHandler) DeleteProject(ctx context.Context, req *co
 
 	return connect_go.NewResponse(resp), nil
 }
+
+func (s *ProxyProjectsServiceHandler) UpdateProject(ctx context.Context, req *connect_go.Request[v1.UpdateProjectRequest]) (*connect_go.Response[v1.UpdateProjectResponse], error) {
+	resp, err := s.Client.UpdateProject(ctx, req.Msg)
+	if err != nil {
+		// TODO(milan): Convert to correct status code
+		return nil, err
+	}
+
+	return connect_go.NewResponse(resp), nil
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: gitpod/experimental/v1/usage.proto
+
+package v1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// UsageServiceName is the fully-qualified name of the UsageService service.
+	UsageServiceName = "gitpod.experimental.v1.UsageService"
+)
+
+// UsageServiceClient is a client for the gitpod.experimental.v1.UsageService service.
+type UsageServiceClient interface {
+	// Lists the usage of an organization.
+	ListUsage(context.Context, *connect_go.Request[v1.ListUsageRequest]) (*connect_go.Response[v1.ListUsageResponse], error)
+	// Aggregates the usage of an organization by a dimension.
+	AggregateUsage(context.Context, *connect_go.Request[v1.AggregateUsageRequest]) (*connect_go.Response[v1.AggregateUsageResponse], error)
+	// Exports the usage of an organization as CSV. The CSV is streamed in chunks,
+	// which need to be concatenated in order.
+	ExportUsage(context.Context, *connect_go.Request[v1.ExportUsageRequest]) (*connect_go.ServerStreamForClient[v1.ExportUsageResponse], error)
+	// Retrieves the current credit balance of an organization.
+	GetBalance(context.Context, *connect_go.Request[v1.GetBalanceRequest]) (*connect_go.Response[v1.GetBalanceResponse], error)
+}
+
+// NewUsageServiceClient constructs a client for the gitpod.experimental.v1.UsageService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewUsageServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) UsageServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &usageServiceClient{
+		listUsage: connect_go.NewClient[v1.ListUsageRequest, v1.ListUsageResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.UsageService/ListUsage",
+			opts...,
+		),
+		aggregateUsage: connect_go.NewClient[v1.AggregateUsageRequest, v1.AggregateUsageResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.UsageService/AggregateUsage",
+			opts...,
+		),
+		exportUsage: connect_go.NewClient[v1.ExportUsageRequest, v1.ExportUsageResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.UsageService/ExportUsage",
+			opts...,
+		),
+		getBalance: connect_go.NewClient[v1.GetBalanceRequest, v1.GetBalanceResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.UsageService/GetBalance",
+			opts...,
+		),
+	}
+}
+
+// usageServiceClient implements UsageServiceClient.
+type usageServiceClient struct {
+	listUsage      *connect_go.Client[v1.ListUsageRequest, v1.ListUsageResponse]
+	aggregateUsage *connect_go.Client[v1.AggregateUsageRequest, v1.AggregateUsageResponse]
+	exportUsage    *connect_go.Client[v1.ExportUsageRequest, v1.ExportUsageResponse]
+	getBalance     *connect_go.Client[v1.GetBalanceRequest, v1.GetBalanceResponse]
+}
+
+// ListUsage calls gitpod.experimental.v1.UsageService.ListUsage.
+func (c *usageServiceClient) ListUsage(ctx context.Context, req *connect_go.Request[v1.ListUsageRequest]) (*connect_go.Response[v1.ListUsageResponse], error) {
+	return c.listUsage.CallUnary(ctx, req)
+}
+
+// AggregateUsage calls gitpod.experimental.v1.UsageService.AggregateUsage.
+func (c *usageServiceClient) AggregateUsage(ctx context.Context, req *connect_go.Request[v1.AggregateUsageRequest]) (*connect_go.Response[v1.AggregateUsageResponse], error) {
+	return c.aggregateUsage.CallUnary(ctx, req)
+}
+
+// ExportUsage calls gitpod.experimental.v1.UsageService.ExportUsage.
+func (c *usageServiceClient) ExportUsage(ctx context.Context, req *connect_go.Request[v1.ExportUsageRequest]) (*connect_go.ServerStreamForClient[v1.ExportUsageResponse], error) {
+	return c.exportUsage.CallServerStream(ctx, req)
+}
+
+// GetBalance calls gitpod.experimental.v1.UsageService.GetBalance.
+func (c *usageServiceClient) GetBalance(ctx context.Context, req *connect_go.Request[v1.GetBalanceRequest]) (*connect_go.Response[v1.GetBalanceResponse], error) {
+	return c.getBalance.CallUnary(ctx, req)
+}
+
+// UsageServiceHandler is an implementation of the gitpod.experimental.v1.UsageService service.
+type UsageServiceHandler interface {
+	// Lists the usage of an organization.
+	ListUsage(context.Context, *connect_go.Request[v1.ListUsageRequest]) (*connect_go.Response[v1.ListUsageResponse], error)
+	// Aggregates the usage of an organization by a dimension.
+	AggregateUsage(context.Context, *connect_go.Request[v1.AggregateUsageRequest]) (*connect_go.Response[v1.AggregateUsageResponse], error)
+	// Exports the usage of an organization as CSV. The CSV is streamed in chunks,
+	// which need to be concatenated in order.
+	ExportUsage(context.Context, *connect_go.Request[v1.ExportUsageRequest], *connect_go.ServerStream[v1.ExportUsageResponse]) error
+	// Retrieves the current credit balance of an organization.
+	GetBalance(context.Context, *connect_go.Request[v1.GetBalanceRequest]) (*connect_go.Response[v1.GetBalanceResponse], error)
+}
+
+// NewUsageServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewUsageServiceHandler(svc UsageServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle("/gitpod.experimental.v1.UsageService/ListUsage", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.UsageService/ListUsage",
+		svc.ListUsage,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.UsageService/AggregateUsage", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.UsageService/AggregateUsage",
+		svc.AggregateUsage,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.UsageService/ExportUsage", connect_go.NewServerStreamHandler(
+		"/gitpod.experimental.v1.UsageService/ExportUsage",
+		svc.ExportUsage,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.UsageService/GetBalance", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.UsageService/GetBalance",
+		svc.GetBalance,
+		opts...,
+	))
+	return "/gitpod.experimental.v1.UsageService/", mux
+}
+
+// UnimplementedUsageServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedUsageServiceHandler struct{}
+
+func (UnimplementedUsageServiceHandler) ListUsage(context.Context, *connect_go.Request[v1.ListUsageRequest]) (*connect_go.Response[v1.ListUsageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.UsageService.ListUsage is not implemented"))
+}
+
+func (UnimplementedUsageServiceHandler) AggregateUsage(context.Context, *connect_go.Request[v1.AggregateUsageRequest]) (*connect_go.Response[v1.AggregateUsageResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.UsageService.AggregateUsage is not implemented"))
+}
+
+func (UnimplementedUsageServiceHandler) ExportUsage(context.Context, *connect_go.Request[v1.ExportUsageRequest], *connect_go.ServerStream[v1.ExportUsageResponse]) error {
+	return connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.UsageService.ExportUsage is not implemented"))
+}
+
+func (UnimplementedUsageServiceHandler) GetBalance(context.Context, *connect_go.Request[v1.GetBalanceRequest]) (*connect_go.Response[v1.GetBalanceResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.UsageService.GetBalance is not implemented"))
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: gitpod/experimental/v1/workspace_templates.proto
+
+package v1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "github.com/gitpod-io/gitpod/components/public-api/go/experimental/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// WorkspaceTemplatesServiceName is the fully-qualified name of the WorkspaceTemplatesService
+	// service.
+	WorkspaceTemplatesServiceName = "gitpod.experimental.v1.WorkspaceTemplatesService"
+)
+
+// WorkspaceTemplatesServiceClient is a client for the
+// gitpod.experimental.v1.WorkspaceTemplatesService service.
+type WorkspaceTemplatesServiceClient interface {
+	// CreateWorkspaceTemplate creates a new version of a template from a snapshot.
+	CreateWorkspaceTemplate(context.Context, *connect_go.Request[v1.CreateWorkspaceTemplateRequest]) (*connect_go.Response[v1.CreateWorkspaceTemplateResponse], error)
+	// GetWorkspaceTemplate returns a single version of a template.
+	GetWorkspaceTemplate(context.Context, *connect_go.Request[v1.GetWorkspaceTemplateRequest]) (*connect_go.Response[v1.GetWorkspaceTemplateResponse], error)
+	// ListWorkspaceTemplates lists the templates of an organization.
+	ListWorkspaceTemplates(context.Context, *connect_go.Request[v1.ListWorkspaceTemplatesRequest]) (*connect_go.Response[v1.ListWorkspaceTemplatesResponse], error)
+	// DeleteWorkspaceTemplate deletes a single version of a template.
+	DeleteWorkspaceTemplate(context.Context, *connect_go.Request[v1.DeleteWorkspaceTemplateRequest]) (*connect_go.Response[v1.DeleteWorkspaceTemplateResponse], error)
+	// StartWorkspaceFromTemplate creates and starts a workspace from a template.
+	StartWorkspaceFromTemplate(context.Context, *connect_go.Request[v1.StartWorkspaceFromTemplateRequest]) (*connect_go.Response[v1.StartWorkspaceFromTemplateResponse], error)
+}
+
+// NewWorkspaceTemplatesServiceClient constructs a client for the
+// gitpod.experimental.v1.WorkspaceTemplatesService service. By default, it uses the Connect
+// protocol with the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed
+// requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewWorkspaceTemplatesServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) WorkspaceTemplatesServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &workspaceTemplatesServiceClient{
+		createWorkspaceTemplate: connect_go.NewClient[v1.CreateWorkspaceTemplateRequest, v1.CreateWorkspaceTemplateResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.WorkspaceTemplatesService/CreateWorkspaceTemplate",
+			opts...,
+		),
+		getWorkspaceTemplate: connect_go.NewClient[v1.GetWorkspaceTemplateRequest, v1.GetWorkspaceTemplateResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.WorkspaceTemplatesService/GetWorkspaceTemplate",
+			opts...,
+		),
+		listWorkspaceTemplates: connect_go.NewClient[v1.ListWorkspaceTemplatesRequest, v1.ListWorkspaceTemplatesResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.WorkspaceTemplatesService/ListWorkspaceTemplates",
+			opts...,
+		),
+		deleteWorkspaceTemplate: connect_go.NewClient[v1.DeleteWorkspaceTemplateRequest, v1.DeleteWorkspaceTemplateResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.WorkspaceTemplatesService/DeleteWorkspaceTemplate",
+			opts...,
+		),
+		startWorkspaceFromTemplate: connect_go.NewClient[v1.StartWorkspaceFromTemplateRequest, v1.StartWorkspaceFromTemplateResponse](
+			httpClient,
+			baseURL+"/gitpod.experimental.v1.WorkspaceTemplatesService/StartWorkspaceFromTemplate",
+			opts...,
+		),
+	}
+}
+
+// workspaceTemplatesServiceClient implements WorkspaceTemplatesServiceClient.
+type workspaceTemplatesServiceClient struct {
+	createWorkspaceTemplate    *connect_go.Client[v1.CreateWorkspaceTemplateRequest, v1.CreateWorkspaceTemplateResponse]
+	getWorkspaceTemplate       *connect_go.Client[v1.GetWorkspaceTemplateRequest, v1.GetWorkspaceTemplateResponse]
+	listWorkspaceTemplates     *connect_go.Client[v1.ListWorkspaceTemplatesRequest, v1.ListWorkspaceTemplatesResponse]
+	deleteWorkspaceTemplate    *connect_go.Client[v1.DeleteWorkspaceTemplateRequest, v1.DeleteWorkspaceTemplateResponse]
+	startWorkspaceFromTemplate *connect_go.Client[v1.StartWorkspaceFromTemplateRequest, v1.StartWorkspaceFromTemplateResponse]
+}
+
+// CreateWorkspaceTemplate calls
+// gitpod.experimental.v1.WorkspaceTemplatesService.CreateWorkspaceTemplate.
+func (c *workspaceTemplatesServiceClient) CreateWorkspaceTemplate(ctx context.Context, req *connect_go.Request[v1.CreateWorkspaceTemplateRequest]) (*connect_go.Response[v1.CreateWorkspaceTemplateResponse], error) {
+	return c.createWorkspaceTemplate.CallUnary(ctx, req)
+}
+
+// GetWorkspaceTemplate calls gitpod.experimental.v1.WorkspaceTemplatesService.GetWorkspaceTemplate.
+func (c *workspaceTemplatesServiceClient) GetWorkspaceTemplate(ctx context.Context, req *connect_go.Request[v1.GetWorkspaceTemplateRequest]) (*connect_go.Response[v1.GetWorkspaceTemplateResponse], error) {
+	return c.getWorkspaceTemplate.CallUnary(ctx, req)
+}
+
+// ListWorkspaceTemplates calls
+// gitpod.experimental.v1.WorkspaceTemplatesService.ListWorkspaceTemplates.
+func (c *workspaceTemplatesServiceClient) ListWorkspaceTemplates(ctx context.Context, req *connect_go.Request[v1.ListWorkspaceTemplatesRequest]) (*connect_go.Response[v1.ListWorkspaceTemplatesResponse], error) {
+	return c.listWorkspaceTemplates.CallUnary(ctx, req)
+}
+
+// DeleteWorkspaceTemplate calls
+// gitpod.experimental.v1.WorkspaceTemplatesService.DeleteWorkspaceTemplate.
+func (c *workspaceTemplatesServiceClient) DeleteWorkspaceTemplate(ctx context.Context, req *connect_go.Request[v1.DeleteWorkspaceTemplateRequest]) (*connect_go.Response[v1.DeleteWorkspaceTemplateResponse], error) {
+	return c.deleteWorkspaceTemplate.CallUnary(ctx, req)
+}
+
+// StartWorkspaceFromTemplate calls
+// gitpod.experimental.v1.WorkspaceTemplatesService.StartWorkspaceFromTemplate.
+func (c *workspaceTemplatesServiceClient) StartWorkspaceFromTemplate(ctx context.Context, req *connect_go.Request[v1.StartWorkspaceFromTemplateRequest]) (*connect_go.Response[v1.StartWorkspaceFromTemplateResponse], error) {
+	return c.startWorkspaceFromTemplate.CallUnary(ctx, req)
+}
+
+// WorkspaceTemplatesServiceHandler is an implementation of the
+// gitpod.experimental.v1.WorkspaceTemplatesService service.
+type WorkspaceTemplatesServiceHandler interface {
+	// CreateWorkspaceTemplate creates a new version of a template from a snapshot.
+	CreateWorkspaceTemplate(context.Context, *connect_go.Request[v1.CreateWorkspaceTemplateRequest]) (*connect_go.Response[v1.CreateWorkspaceTemplateResponse], error)
+	// GetWorkspaceTemplate returns a single version of a template.
+	GetWorkspaceTemplate(context.Context, *connect_go.Request[v1.GetWorkspaceTemplateRequest]) (*connect_go.Response[v1.GetWorkspaceTemplateResponse], error)
+	// ListWorkspaceTemplates lists the templates of an organization.
+	ListWorkspaceTemplates(context.Context, *connect_go.Request[v1.ListWorkspaceTemplatesRequest]) (*connect_go.Response[v1.ListWorkspaceTemplatesResponse], error)
+	// DeleteWorkspaceTemplate deletes a single version of a template.
+	DeleteWorkspaceTemplate(context.Context, *connect_go.Request[v1.DeleteWorkspaceTemplateRequest]) (*connect_go.Response[v1.DeleteWorkspaceTemplateResponse], error)
+	// StartWorkspaceFromTemplate creates and starts a workspace from a template.
+	StartWorkspaceFromTemplate(context.Context, *connect_go.Request[v1.StartWorkspaceFromTemplateRequest]) (*connect_go.Response[v1.StartWorkspaceFromTemplateResponse], error)
+}
+
+// NewWorkspaceTemplatesServiceHandler builds an HTTP handler from the service implementation. It
+// returns the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewWorkspaceTemplatesServiceHandler(svc WorkspaceTemplatesServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle("/gitpod.experimental.v1.WorkspaceTemplatesService/CreateWorkspaceTemplate", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.WorkspaceTemplatesService/CreateWorkspaceTemplate",
+		svc.CreateWorkspaceTemplate,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.WorkspaceTemplatesService/GetWorkspaceTemplate", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.WorkspaceTemplatesService/GetWorkspaceTemplate",
+		svc.GetWorkspaceTemplate,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.WorkspaceTemplatesService/ListWorkspaceTemplates", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.WorkspaceTemplatesService/ListWorkspaceTemplates",
+		svc.ListWorkspaceTemplates,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.WorkspaceTemplatesService/DeleteWorkspaceTemplate", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.WorkspaceTemplatesService/DeleteWorkspaceTemplate",
+		svc.DeleteWorkspaceTemplate,
+		opts...,
+	))
+	mux.Handle("/gitpod.experimental.v1.WorkspaceTemplatesService/StartWorkspaceFromTemplate", connect_go.NewUnaryHandler(
+		"/gitpod.experimental.v1.WorkspaceTemplatesService/StartWorkspaceFromTemplate",
+		svc.StartWorkspaceFromTemplate,
+		opts...,
+	))
+	return "/gitpod.experimental.v1.WorkspaceTemplatesService/", mux
+}
+
+// UnimplementedWorkspaceTemplatesServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedWorkspaceTemplatesServiceHandler struct{}
+
+func (UnimplementedWorkspaceTemplatesServiceHandler) CreateWorkspaceTemplate(context.Context, *connect_go.Request[v1.CreateWorkspaceTemplateRequest]) (*connect_go.Response[v1.CreateWorkspaceTemplateResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.WorkspaceTemplatesService.CreateWorkspaceTemplate is not implemented"))
+}
+
+func (UnimplementedWorkspaceTemplatesServiceHandler) GetWorkspaceTemplate(context.Context, *connect_go.Request[v1.GetWorkspaceTemplateRequest]) (*connect_go.Response[v1.GetWorkspaceTemplateResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.WorkspaceTemplatesService.GetWorkspaceTemplate is not implemented"))
+}
+
+func (UnimplementedWorkspaceTemplatesServiceHandler) ListWorkspaceTemplates(context.Context, *connect_go.Request[v1.ListWorkspaceTemplatesRequest]) (*connect_go.Response[v1.ListWorkspaceTemplatesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.WorkspaceTemplatesService.ListWorkspaceTemplates is not implemented"))
+}
+
+func (UnimplementedWorkspaceTemplatesServiceHandler) DeleteWorkspaceTemplate(context.Context, *connect_go.Request[v1.DeleteWorkspaceTemplateRequest]) (*connect_go.Response[v1.DeleteWorkspaceTemplateResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.WorkspaceTemplatesService.DeleteWorkspaceTemplate is not implemented"))
+}
+
+func (UnimplementedWorkspaceTemplatesServiceHandler) StartWorkspaceFromTemplate(context.Context, *connect_go.Request[v1.StartWorkspaceFromTemplateRequest]) (*connect_go.Response[v1.StartWorkspaceFromTemplateResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("gitpod.experimental.v1.WorkspaceTemplatesService.StartWorkspaceFromTemplate is not implemented"))
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: gitpod/experimental/v1/workspace_templates.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// WorkspaceTemplatesServiceClient is the client API for WorkspaceTemplatesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WorkspaceTemplatesServiceClient interface {
+	// CreateWorkspaceTemplate creates a new version of a template from a snapshot.
+	CreateWorkspaceTemplate(ctx context.Context, in *CreateWorkspaceTemplateRequest, opts ...grpc.CallOption) (*CreateWorkspaceTemplateResponse, error)
+	// GetWorkspaceTemplate returns a single version of a template.
+	GetWorkspaceTemplate(ctx context.Context, in *GetWorkspaceTemplateRequest, opts ...grpc.CallOption) (*GetWorkspaceTemplateResponse, error)
+	// ListWorkspaceTemplates lists the templates of an organization.
+	ListWorkspaceTemplates(ctx context.Context, in *ListWorkspaceTemplatesRequest, opts ...grpc.CallOption) (*ListWorkspaceTemplatesResponse, error)
+	// DeleteWorkspaceTemplate deletes a single version of a template.
+	DeleteWorkspaceTemplate(ctx context.Context, in *DeleteWorkspaceTemplateRequest, opts ...grpc.CallOption) (*DeleteWorkspaceTemplateResponse, error)
+	// StartWorkspaceFromTemplate creates and starts a workspace from a template.
+	StartWorkspaceFromTemplate(ctx context.Context, in *StartWorkspaceFromTemplateRequest, opts ...grpc.CallOption) (*StartWorkspaceFromTemplateResponse, error)
+}
+
+type workspaceTemplatesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWorkspaceTemplatesServiceClient(cc grpc.ClientConnInterface) WorkspaceTemplatesServiceClient {
+	return &workspaceTemplatesServiceClient{cc}
+}
+
+func (c *workspaceTemplatesServiceClient) CreateWorkspaceTemplate(ctx context.Context, in *CreateWorkspaceTemplateRequest, opts ...grpc.CallOption) (*CreateWorkspaceTemplateResponse, error) {
+	out := new(CreateWorkspaceTemplateResponse)
+	err := c.cc.Invoke(ctx, "/gitpod.experimental.v1.WorkspaceTemplatesService/CreateWorkspaceTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceTemplatesServiceClient) GetWorkspaceTemplate(ctx context.Context, in *GetWorkspaceTemplateRequest, opts ...grpc.CallOption) (*GetWorkspaceTemplateResponse, error) {
+	out := new(GetWorkspaceTemplateResponse)
+	err := c.cc.Invoke(ctx, "/gitpod.experimental.v1.WorkspaceTemplatesService/GetWorkspaceTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceTemplatesServiceClient) ListWorkspaceTemplates(ctx context.Context, in *ListWorkspaceTemplatesRequest, opts ...grpc.CallOption) (*ListWorkspaceTemplatesResponse, error) {
+	out := new(ListWorkspaceTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/gitpod.experimental.v1.WorkspaceTemplatesService/ListWorkspaceTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceTemplatesServiceClient) DeleteWorkspaceTemplate(ctx context.Context, in *DeleteWorkspaceTemplateRequest, opts ...grpc.CallOption) (*DeleteWorkspaceTemplateResponse, error) {
+	out := new(DeleteWorkspaceTemplateResponse)
+	err := c.cc.Invoke(ctx, "/gitpod.experimental.v1.WorkspaceTemplatesService/DeleteWorkspaceTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceTemplatesServiceClient) StartWorkspaceFromTemplate(ctx context.Context, in *StartWorkspaceFromTemplateRequest, opts ...grpc.CallOption) (*StartWorkspaceFromTemplateResponse, error) {
+	out := new(StartWorkspaceFromTemplateResponse)
+	err := c.cc.Invoke(ctx, "/gitpod.experimental.v1.WorkspaceTemplatesService/StartWorkspaceFromTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorkspaceTemplatesServiceServer is the server API for WorkspaceTemplatesService service.
+// All implementations must embed UnimplementedWorkspaceTemplatesServiceServer
+// for forward compatibility
+type WorkspaceTemplatesServiceServer interface {
+	// CreateWorkspaceTemplate creates a new version of a template from a snapshot.
+	CreateWorkspaceTemplate(context.Context, *CreateWorkspaceTemplateRequest) (*CreateWorkspaceTemplateResponse, error)
+	// GetWorkspaceTemplate returns a single version of a template.
+	GetWorkspaceTemplate(context.Context, *GetWorkspaceTemplateRequest) (*GetWorkspaceTemplateResponse, error)
+	// ListWorkspaceTemplates lists the templates of an organization.
+	ListWorkspaceTemplates(context.Context, *ListWorkspaceTemplatesRequest) (*ListWorkspaceTemplatesResponse, error)
+	// DeleteWorkspaceTemplate deletes a single version of a template.
+	DeleteWorkspaceTemplate(context.Context, *DeleteWorkspaceTemplateRequest) (*DeleteWorkspaceTemplateResponse, error)
+	// StartWorkspaceFromTemplate creates and starts a workspace from a template.
+	StartWorkspaceFromTemplate(context.Context, *StartWorkspaceFromTemplateRequest) (*StartWorkspaceFromTemplateResponse, error)
+	mustEmbedUnimplementedWorkspaceTemplatesServiceServer()
+}
+
+// UnimplementedWorkspaceTemplatesServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWorkspaceTemplatesServiceServer struct {
+}
+
+func (UnimplementedWorkspaceTemplatesServiceServer) CreateWorkspaceTemplate(context.Context, *CreateWorkspaceTemplateRequest) (*CreateWorkspaceTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWorkspaceTemplate not implemented")
+}
+func (UnimplementedWorkspaceTemplatesServiceServer) GetWorkspaceTemplate(context.Context, *GetWorkspaceTemplateRequest) (*GetWorkspaceTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkspaceTemplate not implemented")
+}
+func (UnimplementedWorkspaceTemplatesServiceServer) ListWorkspaceTemplates(context.Context, *ListWorkspaceTemplatesRequest) (*ListWorkspaceTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkspaceTemplates not implemented")
+}
+func (UnimplementedWorkspaceTemplatesServiceServer) DeleteWorkspaceTemplate(context.Context, *DeleteWorkspaceTemplateRequest) (*DeleteWorkspaceTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWorkspaceTemplate not implemented")
+}
+func (UnimplementedWorkspaceTemplatesServiceServer) StartWorkspaceFromTemplate(context.Context, *StartWorkspaceFromTemplateRequest) (*StartWorkspaceFromTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartWorkspaceFromTemplate not implemented")
+}
+func (UnimplementedWorkspaceTemplatesServiceServer) mustEmbedUnimplementedWorkspaceTemplatesServiceServer() {
+}
+
+// UnsafeWorkspaceTemplatesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WorkspaceTemplatesServiceServer will
+// result in compilation errors.
+type UnsafeWorkspaceTemplatesServiceServer interface {
+	mustEmbedUnimplementedWorkspaceTemplatesServiceServer()
+}
+
+func RegisterWorkspaceTemplatesServiceServer(s grpc.ServiceRegistrar, srv WorkspaceTemplatesServiceServer) {
+	s.RegisterService(&WorkspaceTemplatesService_ServiceDesc, srv)
+}
+
+func _WorkspaceTemplatesService_CreateWorkspaceTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkspaceTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceTemplatesServiceServer).CreateWorkspaceTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpod.experimental.v1.WorkspaceTemplatesService/CreateWorkspaceTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceTemplatesServiceServer).CreateWorkspaceTemplate(ctx, req.(*CreateWorkspaceTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceTemplatesService_GetWorkspaceTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkspaceTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceTemplatesServiceServer).GetWorkspaceTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpod.experimental.v1.WorkspaceTemplatesService/GetWorkspaceTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceTemplatesServiceServer).GetWorkspaceTemplate(ctx, req.(*GetWorkspaceTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceTemplatesService_ListWorkspaceTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkspaceTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceTemplatesServiceServer).ListWorkspaceTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpod.experimental.v1.WorkspaceTemplatesService/ListWorkspaceTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceTemplatesServiceServer).ListWorkspaceTemplates(ctx, req.(*ListWorkspaceTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceTemplatesService_DeleteWorkspaceTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWorkspaceTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceTemplatesServiceServer).DeleteWorkspaceTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpod.experimental.v1.WorkspaceTemplatesService/DeleteWorkspaceTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceTemplatesServiceServer).DeleteWorkspaceTemplate(ctx, req.(*DeleteWorkspaceTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceTemplatesService_StartWorkspaceFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartWorkspaceFromTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceTemplatesServiceServer).StartWorkspaceFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpod.experimental.v1.WorkspaceTemplatesService/StartWorkspaceFromTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceTemplatesServiceServer).StartWorkspaceFromTemplate(ctx, req.(*StartWorkspaceFromTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WorkspaceTemplatesService_ServiceDesc is the grpc.ServiceDesc for WorkspaceTemplatesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WorkspaceTemplatesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gitpod.experimental.v1.WorkspaceTemplatesService",
+	HandlerType: (*WorkspaceTemplatesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWorkspaceTemplate",
+			Handler:    _WorkspaceTemplatesService_CreateWorkspaceTemplate_Handler,
+		},
+		{
+			MethodName: "GetWorkspaceTemplate",
+			Handler:    _WorkspaceTemplatesService_GetWorkspaceTemplate_Handler,
+		},
+		{
+			MethodName: "ListWorkspaceTemplates",
+			Handler:    _WorkspaceTemplatesService_ListWorkspaceTemplates_Handler,
+		},
+		{
+			MethodName: "DeleteWorkspaceTemplate",
+			Handler:    _WorkspaceTemplatesService_DeleteWorkspaceTemplate_Handler,
+		},
+		{
+			MethodName: "StartWorkspaceFromTemplate",
+			Handler:    _WorkspaceTemplatesService_StartWorkspaceFromTemplate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gitpod/experimental/v1/workspace_templates.proto",
+}
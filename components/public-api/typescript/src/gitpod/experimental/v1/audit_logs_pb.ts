@@ -0,0 +1,220 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// @generated by protoc-gen-es v0.1.1 with parameter "target=ts"
+// @generated from file gitpod/experimental/v1/audit_logs.proto (package gitpod.experimental.v1, syntax proto3)
+/* eslint-disable */
+/* @ts-nocheck */
+
+import type {BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage} from "@bufbuild/protobuf";
+import {Message, proto3, protoInt64, Timestamp} from "@bufbuild/protobuf";
+import {Pagination} from "./pagination_pb.js";
+
+/**
+ * AuditLog records an administrative action, such as changing the OIDC client config of an organization.
+ *
+ * @generated from message gitpod.experimental.v1.AuditLog
+ */
+export class AuditLog extends Message<AuditLog> {
+  /**
+   * id is the unique identifier of the audit log
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * timestamp is the time at which the action was performed
+   *
+   * @generated from field: google.protobuf.Timestamp timestamp = 2;
+   */
+  timestamp?: Timestamp;
+
+  /**
+   * organization_id is the Organization affected by the action
+   *
+   * @generated from field: string organization_id = 3;
+   */
+  organizationId = "";
+
+  /**
+   * actor_id is the ID of the user who performed the action.
+   * Empty if the action was performed by the system.
+   *
+   * @generated from field: string actor_id = 4;
+   */
+  actorId = "";
+
+  /**
+   * action is the kind of action, e.g. `updateTeamMember`
+   *
+   * @generated from field: string action = 5;
+   */
+  action = "";
+
+  /**
+   * args is a JSON object describing the arguments of the action
+   *
+   * @generated from field: string args = 6;
+   */
+  args = "";
+
+  constructor(data?: PartialMessage<AuditLog>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.AuditLog";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "timestamp", kind: "message", T: Timestamp },
+    { no: 3, name: "organization_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "actor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "action", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "args", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AuditLog {
+    return new AuditLog().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AuditLog {
+    return new AuditLog().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AuditLog {
+    return new AuditLog().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AuditLog | PlainMessage<AuditLog> | undefined, b: AuditLog | PlainMessage<AuditLog> | undefined): boolean {
+    return proto3.util.equals(AuditLog, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListAuditLogsRequest
+ */
+export class ListAuditLogsRequest extends Message<ListAuditLogsRequest> {
+  /**
+   * Page information
+   *
+   * @generated from field: gitpod.experimental.v1.Pagination pagination = 1;
+   */
+  pagination?: Pagination;
+
+  /**
+   * organization_id is the Organization to list audit logs for.
+   * Required.
+   *
+   * @generated from field: string organization_id = 2;
+   */
+  organizationId = "";
+
+  /**
+   * actor_id only lists actions performed by the given user, if set
+   *
+   * @generated from field: string actor_id = 3;
+   */
+  actorId = "";
+
+  /**
+   * action only lists actions of the given kind, if set
+   *
+   * @generated from field: string action = 4;
+   */
+  action = "";
+
+  /**
+   * from is the start of the time range, inclusive
+   *
+   * @generated from field: google.protobuf.Timestamp from = 5;
+   */
+  from?: Timestamp;
+
+  /**
+   * to is the end of the time range, exclusive
+   *
+   * @generated from field: google.protobuf.Timestamp to = 6;
+   */
+  to?: Timestamp;
+
+  constructor(data?: PartialMessage<ListAuditLogsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListAuditLogsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "pagination", kind: "message", T: Pagination },
+    { no: 2, name: "organization_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "actor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "action", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "from", kind: "message", T: Timestamp },
+    { no: 6, name: "to", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListAuditLogsRequest {
+    return new ListAuditLogsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListAuditLogsRequest {
+    return new ListAuditLogsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListAuditLogsRequest {
+    return new ListAuditLogsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListAuditLogsRequest | PlainMessage<ListAuditLogsRequest> | undefined, b: ListAuditLogsRequest | PlainMessage<ListAuditLogsRequest> | undefined): boolean {
+    return proto3.util.equals(ListAuditLogsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListAuditLogsResponse
+ */
+export class ListAuditLogsResponse extends Message<ListAuditLogsResponse> {
+  /**
+   * @generated from field: repeated gitpod.experimental.v1.AuditLog audit_logs = 1;
+   */
+  auditLogs: AuditLog[] = [];
+
+  /**
+   * @generated from field: int64 total_results = 2;
+   */
+  totalResults = protoInt64.zero;
+
+  constructor(data?: PartialMessage<ListAuditLogsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListAuditLogsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "audit_logs", kind: "message", T: AuditLog, repeated: true },
+    { no: 2, name: "total_results", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListAuditLogsResponse {
+    return new ListAuditLogsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListAuditLogsResponse {
+    return new ListAuditLogsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListAuditLogsResponse {
+    return new ListAuditLogsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListAuditLogsResponse | PlainMessage<ListAuditLogsResponse> | undefined, b: ListAuditLogsResponse | PlainMessage<ListAuditLogsResponse> | undefined): boolean {
+    return proto3.util.equals(ListAuditLogsResponse, a, b);
+  }
+}
+
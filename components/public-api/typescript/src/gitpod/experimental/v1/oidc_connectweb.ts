@@ -9,7 +9,7 @@
 /* eslint-disable */
 /* @ts-nocheck */
 
-import {CreateClientConfigRequest, CreateClientConfigResponse, DeleteClientConfigRequest, DeleteClientConfigResponse, GetClientConfigRequest, GetClientConfigResponse, ListClientConfigsRequest, ListClientConfigsResponse, UpdateClientConfigRequest, UpdateClientConfigResponse} from "./oidc_pb.js";
+import {CreateClientConfigRequest, CreateClientConfigResponse, CreateSCIMTokenRequest, CreateSCIMTokenResponse, DeleteClientConfigRequest, DeleteClientConfigResponse, DeleteSCIMTokenRequest, DeleteSCIMTokenResponse, GetClientConfigRequest, GetClientConfigResponse, ListClientConfigsRequest, ListClientConfigsResponse, UpdateClientConfigRequest, UpdateClientConfigResponse} from "./oidc_pb.js";
 import {MethodKind} from "@bufbuild/protobuf";
 
 /**
@@ -73,6 +73,29 @@ export const OIDCService = {
       O: DeleteClientConfigResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Creates a token which authenticates SCIM provisioning requests for an organization.
+     * Any previous token of the organization is revoked.
+     *
+     * @generated from rpc gitpod.experimental.v1.OIDCService.CreateSCIMToken
+     */
+    createSCIMToken: {
+      name: "CreateSCIMToken",
+      I: CreateSCIMTokenRequest,
+      O: CreateSCIMTokenResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Revokes the SCIM token of an organization.
+     *
+     * @generated from rpc gitpod.experimental.v1.OIDCService.DeleteSCIMToken
+     */
+    deleteSCIMToken: {
+      name: "DeleteSCIMToken",
+      I: DeleteSCIMTokenRequest,
+      O: DeleteSCIMTokenResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
@@ -10,8 +10,9 @@
 /* @ts-nocheck */
 
 import type {BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage} from "@bufbuild/protobuf";
-import {Message, proto3, protoInt64, Timestamp} from "@bufbuild/protobuf";
+import {FieldMask, Message, proto3, protoInt64, Timestamp} from "@bufbuild/protobuf";
 import {Pagination} from "./pagination_pb.js";
+import {TeamRole} from "./teams_pb.js";
 
 /**
  * Configuration of an OpenID client.
@@ -80,12 +81,12 @@ export class OIDCClientConfig extends Message<OIDCClientConfig> {
 
   /**
    * Whether this config can be used for sign-ins.
-   * Defaults to false.
+   * Defaults to false. Updates which do not set it keep the current value.
    * Optional.
    *
-   * @generated from field: bool active = 9;
+   * @generated from field: optional bool active = 9;
    */
-  active = false;
+  active?: boolean;
 
   constructor(data?: PartialMessage<OIDCClientConfig>) {
     super();
@@ -103,7 +104,7 @@ export class OIDCClientConfig extends Message<OIDCClientConfig> {
     { no: 6, name: "id_token_signing_alg_values_supported", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
     { no: 7, name: "creation_time", kind: "message", T: Timestamp },
     { no: 8, name: "status", kind: "message", T: OIDCClientConfigStatus },
-    { no: 9, name: "active", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 9, name: "active", kind: "scalar", T: 8 /* ScalarType.BOOL */, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OIDCClientConfig {
@@ -168,6 +169,24 @@ export class OIDCConfig extends Message<OIDCConfig> {
    */
   overrideClaimMapping?: ClaimMappingOverride;
 
+  /**
+   * Mappings from claims of the id_token to team roles. Team memberships of
+   * users are reconciled with these mappings on every login.
+   * Optional.
+   *
+   * @generated from field: repeated gitpod.experimental.v1.ClaimToTeamRoleMapping claim_mappings = 6;
+   */
+  claimMappings: ClaimToTeamRoleMapping[] = [];
+
+  /**
+   * Email domains of the users which sign in through this config, e.g. gitpod.io.
+   * Used to select the config when an organization has several active configs.
+   * Optional.
+   *
+   * @generated from field: repeated string email_domains = 7;
+   */
+  emailDomains: string[] = [];
+
   constructor(data?: PartialMessage<OIDCConfig>) {
     super();
     proto3.util.initPartial(data, this);
@@ -181,6 +200,8 @@ export class OIDCConfig extends Message<OIDCConfig> {
     { no: 3, name: "jwks_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 4, name: "hints", kind: "message", T: ConsentScreenHints },
     { no: 5, name: "override_claim_mapping", kind: "message", T: ClaimMappingOverride },
+    { no: 6, name: "claim_mappings", kind: "message", T: ClaimToTeamRoleMapping, repeated: true },
+    { no: 7, name: "email_domains", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OIDCConfig {
@@ -259,6 +280,68 @@ export class ConsentScreenHints extends Message<ConsentScreenHints> {
   }
 }
 
+/**
+ * ClaimToTeamRoleMapping grants a role in the organization of the client config
+ * to users whose id_token contains the value in the claim, e.g. `eng-admins` in `groups`.
+ * Users matching no mapping are removed from the organization on login.
+ *
+ * @generated from message gitpod.experimental.v1.ClaimToTeamRoleMapping
+ */
+export class ClaimToTeamRoleMapping extends Message<ClaimToTeamRoleMapping> {
+  /**
+   * The name of the claim, e.g. groups. The claim may be a string, or a list of strings.
+   * Required.
+   *
+   * @generated from field: string claim = 1;
+   */
+  claim = "";
+
+  /**
+   * The value the claim needs to have, or contain.
+   * Required.
+   *
+   * @generated from field: string value = 2;
+   */
+  value = "";
+
+  /**
+   * The role which is granted.
+   * Required.
+   *
+   * @generated from field: gitpod.experimental.v1.TeamRole role = 4;
+   */
+  role = TeamRole.UNSPECIFIED;
+
+  constructor(data?: PartialMessage<ClaimToTeamRoleMapping>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ClaimToTeamRoleMapping";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "claim", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "role", kind: "enum", T: proto3.getEnumType(TeamRole) },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ClaimToTeamRoleMapping {
+    return new ClaimToTeamRoleMapping().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ClaimToTeamRoleMapping {
+    return new ClaimToTeamRoleMapping().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ClaimToTeamRoleMapping {
+    return new ClaimToTeamRoleMapping().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ClaimToTeamRoleMapping | PlainMessage<ClaimToTeamRoleMapping> | undefined, b: ClaimToTeamRoleMapping | PlainMessage<ClaimToTeamRoleMapping> | undefined): boolean {
+    return proto3.util.equals(ClaimToTeamRoleMapping, a, b);
+  }
+}
+
 /**
  * Optional overrides for key mapping to be applied when extracting claims from id_tokens.
  *
@@ -746,10 +829,26 @@ export class ListClientConfigsResponse extends Message<ListClientConfigsResponse
  */
 export class UpdateClientConfigRequest extends Message<UpdateClientConfigRequest> {
   /**
+   * The fields to update. Fields which are not set keep their current value.
+   * An empty or redacted client secret keeps the current secret. A new client
+   * secret rotates the secret, and the previous secret remains valid for a
+   * grace period.
+   *
    * @generated from field: gitpod.experimental.v1.OIDCClientConfig config = 1;
    */
   config?: OIDCClientConfig;
 
+  /**
+   * Fields of config which are replaced even if they are empty, e.g.
+   * `oidc_config.claim_mappings` to remove all claim mappings. Supports
+   * `oidc_config.claim_mappings`, `oidc_config.email_domains` and
+   * `oauth2_config.scopes`.
+   * Optional.
+   *
+   * @generated from field: google.protobuf.FieldMask update_mask = 2;
+   */
+  updateMask?: FieldMask;
+
   constructor(data?: PartialMessage<UpdateClientConfigRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -759,6 +858,7 @@ export class UpdateClientConfigRequest extends Message<UpdateClientConfigRequest
   static readonly typeName = "gitpod.experimental.v1.UpdateClientConfigRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "config", kind: "message", T: OIDCClientConfig },
+    { no: 2, name: "update_mask", kind: "message", T: FieldMask },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpdateClientConfigRequest {
@@ -782,6 +882,11 @@ export class UpdateClientConfigRequest extends Message<UpdateClientConfigRequest
  * @generated from message gitpod.experimental.v1.UpdateClientConfigResponse
  */
 export class UpdateClientConfigResponse extends Message<UpdateClientConfigResponse> {
+  /**
+   * @generated from field: gitpod.experimental.v1.OIDCClientConfig config = 1;
+   */
+  config?: OIDCClientConfig;
+
   constructor(data?: PartialMessage<UpdateClientConfigResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -790,6 +895,7 @@ export class UpdateClientConfigResponse extends Message<UpdateClientConfigRespon
   static readonly runtime = proto3;
   static readonly typeName = "gitpod.experimental.v1.UpdateClientConfigResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "config", kind: "message", T: OIDCClientConfig },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpdateClientConfigResponse {
@@ -882,3 +988,147 @@ export class DeleteClientConfigResponse extends Message<DeleteClientConfigRespon
     return proto3.util.equals(DeleteClientConfigResponse, a, b);
   }
 }
+
+/**
+ * @generated from message gitpod.experimental.v1.CreateSCIMTokenRequest
+ */
+export class CreateSCIMTokenRequest extends Message<CreateSCIMTokenRequest> {
+  /**
+   * @generated from field: string organization_id = 1;
+   */
+  organizationId = "";
+
+  constructor(data?: PartialMessage<CreateSCIMTokenRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.CreateSCIMTokenRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "organization_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateSCIMTokenRequest {
+    return new CreateSCIMTokenRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateSCIMTokenRequest {
+    return new CreateSCIMTokenRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateSCIMTokenRequest {
+    return new CreateSCIMTokenRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateSCIMTokenRequest | PlainMessage<CreateSCIMTokenRequest> | undefined, b: CreateSCIMTokenRequest | PlainMessage<CreateSCIMTokenRequest> | undefined): boolean {
+    return proto3.util.equals(CreateSCIMTokenRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.CreateSCIMTokenResponse
+ */
+export class CreateSCIMTokenResponse extends Message<CreateSCIMTokenResponse> {
+  /**
+   * The token is only returned once, it cannot be retrieved later.
+   *
+   * @generated from field: string token = 1;
+   */
+  token = "";
+
+  constructor(data?: PartialMessage<CreateSCIMTokenResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.CreateSCIMTokenResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateSCIMTokenResponse {
+    return new CreateSCIMTokenResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateSCIMTokenResponse {
+    return new CreateSCIMTokenResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateSCIMTokenResponse {
+    return new CreateSCIMTokenResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateSCIMTokenResponse | PlainMessage<CreateSCIMTokenResponse> | undefined, b: CreateSCIMTokenResponse | PlainMessage<CreateSCIMTokenResponse> | undefined): boolean {
+    return proto3.util.equals(CreateSCIMTokenResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.DeleteSCIMTokenRequest
+ */
+export class DeleteSCIMTokenRequest extends Message<DeleteSCIMTokenRequest> {
+  /**
+   * @generated from field: string organization_id = 1;
+   */
+  organizationId = "";
+
+  constructor(data?: PartialMessage<DeleteSCIMTokenRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.DeleteSCIMTokenRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "organization_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteSCIMTokenRequest {
+    return new DeleteSCIMTokenRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteSCIMTokenRequest {
+    return new DeleteSCIMTokenRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteSCIMTokenRequest {
+    return new DeleteSCIMTokenRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteSCIMTokenRequest | PlainMessage<DeleteSCIMTokenRequest> | undefined, b: DeleteSCIMTokenRequest | PlainMessage<DeleteSCIMTokenRequest> | undefined): boolean {
+    return proto3.util.equals(DeleteSCIMTokenRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.DeleteSCIMTokenResponse
+ */
+export class DeleteSCIMTokenResponse extends Message<DeleteSCIMTokenResponse> {
+  constructor(data?: PartialMessage<DeleteSCIMTokenResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.DeleteSCIMTokenResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteSCIMTokenResponse {
+    return new DeleteSCIMTokenResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteSCIMTokenResponse {
+    return new DeleteSCIMTokenResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteSCIMTokenResponse {
+    return new DeleteSCIMTokenResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteSCIMTokenResponse | PlainMessage<DeleteSCIMTokenResponse> | undefined, b: DeleteSCIMTokenResponse | PlainMessage<DeleteSCIMTokenResponse> | undefined): boolean {
+    return proto3.util.equals(DeleteSCIMTokenResponse, a, b);
+  }
+}
@@ -0,0 +1,55 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// @generated by protoc-gen-connect-web v0.2.1 with parameter "target=ts"
+// @generated from file gitpod/experimental/v1/prebuilds.proto (package gitpod.experimental.v1, syntax proto3)
+/* eslint-disable */
+/* @ts-nocheck */
+
+import {CancelPrebuildRequest, CancelPrebuildResponse, GetPrebuildRequest, GetPrebuildResponse, ListPrebuildsRequest, ListPrebuildsResponse} from "./prebuilds_pb.js";
+import {MethodKind} from "@bufbuild/protobuf";
+
+/**
+ * @generated from service gitpod.experimental.v1.PrebuildsService
+ */
+export const PrebuildsService = {
+  typeName: "gitpod.experimental.v1.PrebuildsService",
+  methods: {
+    /**
+     * GetPrebuild returns the prebuild of a commit of a project.
+     *
+     * @generated from rpc gitpod.experimental.v1.PrebuildsService.GetPrebuild
+     */
+    getPrebuild: {
+      name: "GetPrebuild",
+      I: GetPrebuildRequest,
+      O: GetPrebuildResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ListPrebuilds lists the prebuilds of a project, most recent first.
+     *
+     * @generated from rpc gitpod.experimental.v1.PrebuildsService.ListPrebuilds
+     */
+    listPrebuilds: {
+      name: "ListPrebuilds",
+      I: ListPrebuildsRequest,
+      O: ListPrebuildsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * CancelPrebuild stops all running and queued attempts of a prebuild.
+     *
+     * @generated from rpc gitpod.experimental.v1.PrebuildsService.CancelPrebuild
+     */
+    cancelPrebuild: {
+      name: "CancelPrebuild",
+      I: CancelPrebuildRequest,
+      O: CancelPrebuildResponse,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
@@ -0,0 +1,441 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// @generated by protoc-gen-es v0.1.1 with parameter "target=ts"
+// @generated from file gitpod/experimental/v1/prebuilds.proto (package gitpod.experimental.v1, syntax proto3)
+/* eslint-disable */
+/* @ts-nocheck */
+
+import type {BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage} from "@bufbuild/protobuf";
+import {Message, proto3, Timestamp} from "@bufbuild/protobuf";
+import {Pagination} from "./pagination_pb.js";
+
+/**
+ * @generated from enum gitpod.experimental.v1.PrebuildPhase
+ */
+export enum PrebuildPhase {
+  /**
+   * @generated from enum value: PREBUILD_PHASE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Queued means the prebuild waits until the project has capacity to run it.
+   *
+   * @generated from enum value: PREBUILD_PHASE_QUEUED = 1;
+   */
+  QUEUED = 1,
+
+  /**
+   * Running means the prebuild tasks are running.
+   *
+   * @generated from enum value: PREBUILD_PHASE_RUNNING = 2;
+   */
+  RUNNING = 2,
+
+  /**
+   * Available means the prebuild finished successfully and can be used by workspaces.
+   *
+   * @generated from enum value: PREBUILD_PHASE_AVAILABLE = 3;
+   */
+  AVAILABLE = 3,
+
+  /**
+   * Failed means a prebuild task or the prebuild workspace failed.
+   *
+   * @generated from enum value: PREBUILD_PHASE_FAILED = 4;
+   */
+  FAILED = 4,
+
+  /**
+   * Aborted means the prebuild was cancelled, e.g. because it was superseded by a newer commit.
+   *
+   * @generated from enum value: PREBUILD_PHASE_ABORTED = 5;
+   */
+  ABORTED = 5,
+
+  /**
+   * Timeout means the prebuild took longer than its timeout.
+   *
+   * @generated from enum value: PREBUILD_PHASE_TIMEOUT = 6;
+   */
+  TIMEOUT = 6,
+}
+// Retrieve enum metadata with: proto3.getEnumType(PrebuildPhase)
+proto3.util.setEnumType(PrebuildPhase, "gitpod.experimental.v1.PrebuildPhase", [
+  { no: 0, name: "PREBUILD_PHASE_UNSPECIFIED" },
+  { no: 1, name: "PREBUILD_PHASE_QUEUED" },
+  { no: 2, name: "PREBUILD_PHASE_RUNNING" },
+  { no: 3, name: "PREBUILD_PHASE_AVAILABLE" },
+  { no: 4, name: "PREBUILD_PHASE_FAILED" },
+  { no: 5, name: "PREBUILD_PHASE_ABORTED" },
+  { no: 6, name: "PREBUILD_PHASE_TIMEOUT" },
+]);
+
+/**
+ * Prebuild is the prebuild of a commit of a project, across all attempts to build it.
+ *
+ * @generated from message gitpod.experimental.v1.Prebuild
+ */
+export class Prebuild extends Message<Prebuild> {
+  /**
+   * project_id is the Project the prebuild belongs to
+   *
+   * @generated from field: string project_id = 1;
+   */
+  projectId = "";
+
+  /**
+   * branch is the branch the commit was pushed to
+   *
+   * @generated from field: string branch = 2;
+   */
+  branch = "";
+
+  /**
+   * commit is the SHA of the commit which is prebuilt
+   *
+   * @generated from field: string commit = 3;
+   */
+  commit = "";
+
+  /**
+   * phase is the phase of the most recent attempt
+   *
+   * @generated from field: gitpod.experimental.v1.PrebuildPhase phase = 4;
+   */
+  phase = PrebuildPhase.UNSPECIFIED;
+
+  /**
+   * workspace_instance_id is the ID of the workspace instance which runs the most recent attempt
+   *
+   * @generated from field: string workspace_instance_id = 5;
+   */
+  workspaceInstanceId = "";
+
+  /**
+   * attempts is the number of times the commit was prebuilt, i.e. 1 plus the number of retries
+   *
+   * @generated from field: int32 attempts = 6;
+   */
+  attempts = 0;
+
+  /**
+   * error explains why the most recent attempt failed, was aborted or timed out
+   *
+   * @generated from field: string error = 7;
+   */
+  error = "";
+
+  /**
+   * created_at is the time at which the first attempt was created
+   *
+   * @generated from field: google.protobuf.Timestamp created_at = 8;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * finished_at is the time at which the most recent attempt finished. Unset while it has not finished.
+   *
+   * @generated from field: google.protobuf.Timestamp finished_at = 9;
+   */
+  finishedAt?: Timestamp;
+
+  constructor(data?: PartialMessage<Prebuild>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.Prebuild";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "project_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "branch", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "commit", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "phase", kind: "enum", T: proto3.getEnumType(PrebuildPhase) },
+    { no: 5, name: "workspace_instance_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "attempts", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 7, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "created_at", kind: "message", T: Timestamp },
+    { no: 9, name: "finished_at", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Prebuild {
+    return new Prebuild().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Prebuild {
+    return new Prebuild().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Prebuild {
+    return new Prebuild().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Prebuild | PlainMessage<Prebuild> | undefined, b: Prebuild | PlainMessage<Prebuild> | undefined): boolean {
+    return proto3.util.equals(Prebuild, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.GetPrebuildRequest
+ */
+export class GetPrebuildRequest extends Message<GetPrebuildRequest> {
+  /**
+   * @generated from field: string project_id = 1;
+   */
+  projectId = "";
+
+  /**
+   * @generated from field: string commit = 2;
+   */
+  commit = "";
+
+  constructor(data?: PartialMessage<GetPrebuildRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.GetPrebuildRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "project_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "commit", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetPrebuildRequest {
+    return new GetPrebuildRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetPrebuildRequest {
+    return new GetPrebuildRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetPrebuildRequest {
+    return new GetPrebuildRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetPrebuildRequest | PlainMessage<GetPrebuildRequest> | undefined, b: GetPrebuildRequest | PlainMessage<GetPrebuildRequest> | undefined): boolean {
+    return proto3.util.equals(GetPrebuildRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.GetPrebuildResponse
+ */
+export class GetPrebuildResponse extends Message<GetPrebuildResponse> {
+  /**
+   * @generated from field: gitpod.experimental.v1.Prebuild prebuild = 1;
+   */
+  prebuild?: Prebuild;
+
+  constructor(data?: PartialMessage<GetPrebuildResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.GetPrebuildResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "prebuild", kind: "message", T: Prebuild },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetPrebuildResponse {
+    return new GetPrebuildResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetPrebuildResponse {
+    return new GetPrebuildResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetPrebuildResponse {
+    return new GetPrebuildResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetPrebuildResponse | PlainMessage<GetPrebuildResponse> | undefined, b: GetPrebuildResponse | PlainMessage<GetPrebuildResponse> | undefined): boolean {
+    return proto3.util.equals(GetPrebuildResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListPrebuildsRequest
+ */
+export class ListPrebuildsRequest extends Message<ListPrebuildsRequest> {
+  /**
+   * Page information
+   *
+   * @generated from field: gitpod.experimental.v1.Pagination pagination = 1;
+   */
+  pagination?: Pagination;
+
+  /**
+   * @generated from field: string project_id = 2;
+   */
+  projectId = "";
+
+  /**
+   * branch only lists prebuilds of the given branch, if set
+   *
+   * @generated from field: string branch = 3;
+   */
+  branch = "";
+
+  /**
+   * phase only lists prebuilds in the given phase, if set
+   *
+   * @generated from field: gitpod.experimental.v1.PrebuildPhase phase = 4;
+   */
+  phase = PrebuildPhase.UNSPECIFIED;
+
+  constructor(data?: PartialMessage<ListPrebuildsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListPrebuildsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "pagination", kind: "message", T: Pagination },
+    { no: 2, name: "project_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "branch", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "phase", kind: "enum", T: proto3.getEnumType(PrebuildPhase) },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListPrebuildsRequest {
+    return new ListPrebuildsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListPrebuildsRequest {
+    return new ListPrebuildsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListPrebuildsRequest {
+    return new ListPrebuildsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListPrebuildsRequest | PlainMessage<ListPrebuildsRequest> | undefined, b: ListPrebuildsRequest | PlainMessage<ListPrebuildsRequest> | undefined): boolean {
+    return proto3.util.equals(ListPrebuildsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListPrebuildsResponse
+ */
+export class ListPrebuildsResponse extends Message<ListPrebuildsResponse> {
+  /**
+   * @generated from field: repeated gitpod.experimental.v1.Prebuild prebuilds = 1;
+   */
+  prebuilds: Prebuild[] = [];
+
+  /**
+   * total_results is the total number of prebuilds which match the request
+   *
+   * @generated from field: int32 total_results = 2;
+   */
+  totalResults = 0;
+
+  constructor(data?: PartialMessage<ListPrebuildsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListPrebuildsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "prebuilds", kind: "message", T: Prebuild, repeated: true },
+    { no: 2, name: "total_results", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListPrebuildsResponse {
+    return new ListPrebuildsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListPrebuildsResponse {
+    return new ListPrebuildsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListPrebuildsResponse {
+    return new ListPrebuildsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListPrebuildsResponse | PlainMessage<ListPrebuildsResponse> | undefined, b: ListPrebuildsResponse | PlainMessage<ListPrebuildsResponse> | undefined): boolean {
+    return proto3.util.equals(ListPrebuildsResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.CancelPrebuildRequest
+ */
+export class CancelPrebuildRequest extends Message<CancelPrebuildRequest> {
+  /**
+   * @generated from field: string project_id = 1;
+   */
+  projectId = "";
+
+  /**
+   * @generated from field: string commit = 2;
+   */
+  commit = "";
+
+  constructor(data?: PartialMessage<CancelPrebuildRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.CancelPrebuildRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "project_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "commit", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CancelPrebuildRequest {
+    return new CancelPrebuildRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CancelPrebuildRequest {
+    return new CancelPrebuildRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CancelPrebuildRequest {
+    return new CancelPrebuildRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CancelPrebuildRequest | PlainMessage<CancelPrebuildRequest> | undefined, b: CancelPrebuildRequest | PlainMessage<CancelPrebuildRequest> | undefined): boolean {
+    return proto3.util.equals(CancelPrebuildRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.CancelPrebuildResponse
+ */
+export class CancelPrebuildResponse extends Message<CancelPrebuildResponse> {
+  constructor(data?: PartialMessage<CancelPrebuildResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.CancelPrebuildResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CancelPrebuildResponse {
+    return new CancelPrebuildResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CancelPrebuildResponse {
+    return new CancelPrebuildResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CancelPrebuildResponse {
+    return new CancelPrebuildResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CancelPrebuildResponse | PlainMessage<CancelPrebuildResponse> | undefined, b: CancelPrebuildResponse | PlainMessage<CancelPrebuildResponse> | undefined): boolean {
+    return proto3.util.equals(CancelPrebuildResponse, a, b);
+  }
+}
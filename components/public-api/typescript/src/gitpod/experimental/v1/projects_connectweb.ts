@@ -9,7 +9,7 @@
 /* eslint-disable */
 /* @ts-nocheck */
 
-import {CreateProjectRequest, CreateProjectResponse, DeleteProjectRequest, DeleteProjectResponse, GetProjectRequest, GetProjectResponse, ListProjectsRequest, ListProjectsResponse} from "./projects_pb.js";
+import {CreateProjectRequest, CreateProjectResponse, DeleteProjectRequest, DeleteProjectResponse, GetProjectRequest, GetProjectResponse, ListProjectsRequest, ListProjectsResponse, UpdateProjectRequest, UpdateProjectResponse} from "./projects_pb.js";
 import {MethodKind} from "@bufbuild/protobuf";
 
 /**
@@ -62,6 +62,17 @@ export const ProjectsService = {
       O: DeleteProjectResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Updates the settings of a project.
+     *
+     * @generated from rpc gitpod.experimental.v1.ProjectsService.UpdateProject
+     */
+    updateProject: {
+      name: "UpdateProject",
+      I: UpdateProjectRequest,
+      O: UpdateProjectResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
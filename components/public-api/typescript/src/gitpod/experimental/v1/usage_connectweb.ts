@@ -0,0 +1,68 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// @generated by protoc-gen-connect-web v0.2.1 with parameter "target=ts"
+// @generated from file gitpod/experimental/v1/usage.proto (package gitpod.experimental.v1, syntax proto3)
+/* eslint-disable */
+/* @ts-nocheck */
+
+import {AggregateUsageRequest, AggregateUsageResponse, ExportUsageRequest, ExportUsageResponse, GetBalanceRequest, GetBalanceResponse, ListUsageRequest, ListUsageResponse} from "./usage_pb.js";
+import {MethodKind} from "@bufbuild/protobuf";
+
+/**
+ * @generated from service gitpod.experimental.v1.UsageService
+ */
+export const UsageService = {
+  typeName: "gitpod.experimental.v1.UsageService",
+  methods: {
+    /**
+     * Lists the usage of an organization.
+     *
+     * @generated from rpc gitpod.experimental.v1.UsageService.ListUsage
+     */
+    listUsage: {
+      name: "ListUsage",
+      I: ListUsageRequest,
+      O: ListUsageResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Aggregates the usage of an organization by a dimension.
+     *
+     * @generated from rpc gitpod.experimental.v1.UsageService.AggregateUsage
+     */
+    aggregateUsage: {
+      name: "AggregateUsage",
+      I: AggregateUsageRequest,
+      O: AggregateUsageResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Exports the usage of an organization as CSV. The CSV is streamed in chunks,
+     * which need to be concatenated in order.
+     *
+     * @generated from rpc gitpod.experimental.v1.UsageService.ExportUsage
+     */
+    exportUsage: {
+      name: "ExportUsage",
+      I: ExportUsageRequest,
+      O: ExportUsageResponse,
+      kind: MethodKind.ServerStreaming,
+    },
+    /**
+     * Retrieves the current credit balance of an organization.
+     *
+     * @generated from rpc gitpod.experimental.v1.UsageService.GetBalance
+     */
+    getBalance: {
+      name: "GetBalance",
+      I: GetBalanceRequest,
+      O: GetBalanceResponse,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
+
@@ -0,0 +1,658 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// @generated by protoc-gen-es v0.1.1 with parameter "target=ts"
+// @generated from file gitpod/experimental/v1/usage.proto (package gitpod.experimental.v1, syntax proto3)
+/* eslint-disable */
+/* @ts-nocheck */
+
+import type {BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage} from "@bufbuild/protobuf";
+import {Message, proto3, Timestamp} from "@bufbuild/protobuf";
+import {Pagination} from "./pagination_pb.js";
+
+/**
+ * @generated from message gitpod.experimental.v1.Usage
+ */
+export class Usage extends Message<Usage> {
+  /**
+   * ID is the unique identifier of the usage record.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * Organization ID is the Organization the usage is attributed to.
+   *
+   * @generated from field: string organization_id = 2;
+   */
+  organizationId = "";
+
+  /**
+   * @generated from field: string description = 3;
+   */
+  description = "";
+
+  /**
+   * Credits used by this record.
+   *
+   * @generated from field: double credits = 4;
+   */
+  credits = 0;
+
+  /**
+   * Effective time is the time at which the usage took effect.
+   *
+   * @generated from field: google.protobuf.Timestamp effective_time = 5;
+   */
+  effectiveTime?: Timestamp;
+
+  /**
+   * @generated from field: string workspace_instance_id = 6;
+   */
+  workspaceInstanceId = "";
+
+  /**
+   * @generated from field: string workspace_id = 7;
+   */
+  workspaceId = "";
+
+  /**
+   * Project ID is empty if the workspace was not started from a project.
+   *
+   * @generated from field: string project_id = 8;
+   */
+  projectId = "";
+
+  /**
+   * User ID is the User who caused the usage.
+   *
+   * @generated from field: string user_id = 9;
+   */
+  userId = "";
+
+  /**
+   * @generated from field: string workspace_class = 10;
+   */
+  workspaceClass = "";
+
+  /**
+   * Workspace type is either `regular` or `prebuild`.
+   *
+   * @generated from field: string workspace_type = 11;
+   */
+  workspaceType = "";
+
+  /**
+   * Draft is true if the usage record may still change, e.g. because the workspace is still running.
+   *
+   * @generated from field: bool draft = 12;
+   */
+  draft = false;
+
+  constructor(data?: PartialMessage<Usage>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.Usage";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "organization_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "credits", kind: "scalar", T: 1 /* ScalarType.DOUBLE */ },
+    { no: 5, name: "effective_time", kind: "message", T: Timestamp },
+    { no: 6, name: "workspace_instance_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "workspace_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "project_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 9, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 10, name: "workspace_class", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 11, name: "workspace_type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 12, name: "draft", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Usage {
+    return new Usage().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Usage {
+    return new Usage().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Usage {
+    return new Usage().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Usage | PlainMessage<Usage> | undefined, b: Usage | PlainMessage<Usage> | undefined): boolean {
+    return proto3.util.equals(Usage, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.UsageFilter
+ */
+export class UsageFilter extends Message<UsageFilter> {
+  /**
+   * Organization ID is the Organization to retrieve usage for.
+   * Required.
+   *
+   * @generated from field: string organization_id = 1;
+   */
+  organizationId = "";
+
+  /**
+   * From is the start of the time range, inclusive.
+   * Defaults to 30 days before `to`.
+   *
+   * @generated from field: google.protobuf.Timestamp from = 2;
+   */
+  from?: Timestamp;
+
+  /**
+   * To is the end of the time range, exclusive.
+   * Defaults to now.
+   *
+   * @generated from field: google.protobuf.Timestamp to = 3;
+   */
+  to?: Timestamp;
+
+  /**
+   * User ID filters usage caused by user_id
+   *
+   * @generated from field: string user_id = 4;
+   */
+  userId = "";
+
+  /**
+   * Project ID filters usage of workspaces started from project_id
+   *
+   * @generated from field: string project_id = 5;
+   */
+  projectId = "";
+
+  /**
+   * Workspace class filters usage of workspaces with workspace_class
+   *
+   * @generated from field: string workspace_class = 6;
+   */
+  workspaceClass = "";
+
+  constructor(data?: PartialMessage<UsageFilter>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.UsageFilter";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "organization_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "from", kind: "message", T: Timestamp },
+    { no: 3, name: "to", kind: "message", T: Timestamp },
+    { no: 4, name: "user_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "project_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "workspace_class", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UsageFilter {
+    return new UsageFilter().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UsageFilter {
+    return new UsageFilter().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UsageFilter {
+    return new UsageFilter().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UsageFilter | PlainMessage<UsageFilter> | undefined, b: UsageFilter | PlainMessage<UsageFilter> | undefined): boolean {
+    return proto3.util.equals(UsageFilter, a, b);
+  }
+}
+
+/**
+ * @generated from enum gitpod.experimental.v1.UsageDimension
+ */
+export enum UsageDimension {
+  /**
+   * @generated from enum value: USAGE_DIMENSION_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * USAGE_DIMENSION_USER aggregates usage by user ID.
+   *
+   * @generated from enum value: USAGE_DIMENSION_USER = 1;
+   */
+  USER = 1,
+
+  /**
+   * USAGE_DIMENSION_PROJECT aggregates usage by project ID.
+   *
+   * @generated from enum value: USAGE_DIMENSION_PROJECT = 2;
+   */
+  PROJECT = 2,
+
+  /**
+   * USAGE_DIMENSION_WORKSPACE_CLASS aggregates usage by workspace class.
+   *
+   * @generated from enum value: USAGE_DIMENSION_WORKSPACE_CLASS = 3;
+   */
+  WORKSPACE_CLASS = 3,
+
+  /**
+   * USAGE_DIMENSION_DAY aggregates usage by the day (UTC) of the effective time, formatted as YYYY-MM-DD.
+   *
+   * @generated from enum value: USAGE_DIMENSION_DAY = 4;
+   */
+  DAY = 4,
+}
+// Retrieve enum metadata with: proto3.getEnumType(UsageDimension)
+proto3.util.setEnumType(UsageDimension, "gitpod.experimental.v1.UsageDimension", [
+  { no: 0, name: "USAGE_DIMENSION_UNSPECIFIED" },
+  { no: 1, name: "USAGE_DIMENSION_USER" },
+  { no: 2, name: "USAGE_DIMENSION_PROJECT" },
+  { no: 3, name: "USAGE_DIMENSION_WORKSPACE_CLASS" },
+  { no: 4, name: "USAGE_DIMENSION_DAY" },
+]);
+
+/**
+ * @generated from message gitpod.experimental.v1.UsageAggregate
+ */
+export class UsageAggregate extends Message<UsageAggregate> {
+  /**
+   * Key is the value of the dimension, e.g. the user ID when aggregating by user.
+   *
+   * @generated from field: string key = 1;
+   */
+  key = "";
+
+  /**
+   * @generated from field: double credits = 2;
+   */
+  credits = 0;
+
+  /**
+   * Count is the number of usage records aggregated.
+   *
+   * @generated from field: int32 count = 3;
+   */
+  count = 0;
+
+  constructor(data?: PartialMessage<UsageAggregate>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.UsageAggregate";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "key", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "credits", kind: "scalar", T: 1 /* ScalarType.DOUBLE */ },
+    { no: 3, name: "count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UsageAggregate {
+    return new UsageAggregate().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UsageAggregate {
+    return new UsageAggregate().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UsageAggregate {
+    return new UsageAggregate().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UsageAggregate | PlainMessage<UsageAggregate> | undefined, b: UsageAggregate | PlainMessage<UsageAggregate> | undefined): boolean {
+    return proto3.util.equals(UsageAggregate, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListUsageRequest
+ */
+export class ListUsageRequest extends Message<ListUsageRequest> {
+  /**
+   * @generated from field: gitpod.experimental.v1.UsageFilter filter = 1;
+   */
+  filter?: UsageFilter;
+
+  /**
+   * Page information
+   *
+   * @generated from field: gitpod.experimental.v1.Pagination pagination = 2;
+   */
+  pagination?: Pagination;
+
+  constructor(data?: PartialMessage<ListUsageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListUsageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "filter", kind: "message", T: UsageFilter },
+    { no: 2, name: "pagination", kind: "message", T: Pagination },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListUsageRequest {
+    return new ListUsageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListUsageRequest {
+    return new ListUsageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListUsageRequest {
+    return new ListUsageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListUsageRequest | PlainMessage<ListUsageRequest> | undefined, b: ListUsageRequest | PlainMessage<ListUsageRequest> | undefined): boolean {
+    return proto3.util.equals(ListUsageRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListUsageResponse
+ */
+export class ListUsageResponse extends Message<ListUsageResponse> {
+  /**
+   * Usage records ordered by effective time, most recent first.
+   *
+   * @generated from field: repeated gitpod.experimental.v1.Usage usage = 1;
+   */
+  usage: Usage[] = [];
+
+  /**
+   * @generated from field: int32 total_results = 2;
+   */
+  totalResults = 0;
+
+  /**
+   * Credits used by all usage records matching the filter.
+   *
+   * @generated from field: double credits_used = 3;
+   */
+  creditsUsed = 0;
+
+  constructor(data?: PartialMessage<ListUsageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListUsageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "usage", kind: "message", T: Usage, repeated: true },
+    { no: 2, name: "total_results", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "credits_used", kind: "scalar", T: 1 /* ScalarType.DOUBLE */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListUsageResponse {
+    return new ListUsageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListUsageResponse {
+    return new ListUsageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListUsageResponse {
+    return new ListUsageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListUsageResponse | PlainMessage<ListUsageResponse> | undefined, b: ListUsageResponse | PlainMessage<ListUsageResponse> | undefined): boolean {
+    return proto3.util.equals(ListUsageResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.AggregateUsageRequest
+ */
+export class AggregateUsageRequest extends Message<AggregateUsageRequest> {
+  /**
+   * @generated from field: gitpod.experimental.v1.UsageFilter filter = 1;
+   */
+  filter?: UsageFilter;
+
+  /**
+   * Required.
+   *
+   * @generated from field: gitpod.experimental.v1.UsageDimension dimension = 2;
+   */
+  dimension = UsageDimension.UNSPECIFIED;
+
+  constructor(data?: PartialMessage<AggregateUsageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.AggregateUsageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "filter", kind: "message", T: UsageFilter },
+    { no: 2, name: "dimension", kind: "enum", T: proto3.getEnumType(UsageDimension) },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AggregateUsageRequest {
+    return new AggregateUsageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AggregateUsageRequest {
+    return new AggregateUsageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AggregateUsageRequest {
+    return new AggregateUsageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AggregateUsageRequest | PlainMessage<AggregateUsageRequest> | undefined, b: AggregateUsageRequest | PlainMessage<AggregateUsageRequest> | undefined): boolean {
+    return proto3.util.equals(AggregateUsageRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.AggregateUsageResponse
+ */
+export class AggregateUsageResponse extends Message<AggregateUsageResponse> {
+  /**
+   * Aggregates ordered by credits, highest first.
+   *
+   * @generated from field: repeated gitpod.experimental.v1.UsageAggregate aggregates = 1;
+   */
+  aggregates: UsageAggregate[] = [];
+
+  /**
+   * Credits used by all usage records matching the filter.
+   *
+   * @generated from field: double credits_used = 2;
+   */
+  creditsUsed = 0;
+
+  constructor(data?: PartialMessage<AggregateUsageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.AggregateUsageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "aggregates", kind: "message", T: UsageAggregate, repeated: true },
+    { no: 2, name: "credits_used", kind: "scalar", T: 1 /* ScalarType.DOUBLE */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AggregateUsageResponse {
+    return new AggregateUsageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AggregateUsageResponse {
+    return new AggregateUsageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AggregateUsageResponse {
+    return new AggregateUsageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AggregateUsageResponse | PlainMessage<AggregateUsageResponse> | undefined, b: AggregateUsageResponse | PlainMessage<AggregateUsageResponse> | undefined): boolean {
+    return proto3.util.equals(AggregateUsageResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ExportUsageRequest
+ */
+export class ExportUsageRequest extends Message<ExportUsageRequest> {
+  /**
+   * @generated from field: gitpod.experimental.v1.UsageFilter filter = 1;
+   */
+  filter?: UsageFilter;
+
+  constructor(data?: PartialMessage<ExportUsageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ExportUsageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "filter", kind: "message", T: UsageFilter },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ExportUsageRequest {
+    return new ExportUsageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ExportUsageRequest {
+    return new ExportUsageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ExportUsageRequest {
+    return new ExportUsageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ExportUsageRequest | PlainMessage<ExportUsageRequest> | undefined, b: ExportUsageRequest | PlainMessage<ExportUsageRequest> | undefined): boolean {
+    return proto3.util.equals(ExportUsageRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ExportUsageResponse
+ */
+export class ExportUsageResponse extends Message<ExportUsageResponse> {
+  /**
+   * A chunk of the CSV. The first chunk starts with the header row, which is
+   * followed by one row per usage record. Chunks only contain complete rows.
+   *
+   * @generated from field: string csv = 1;
+   */
+  csv = "";
+
+  constructor(data?: PartialMessage<ExportUsageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ExportUsageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "csv", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ExportUsageResponse {
+    return new ExportUsageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ExportUsageResponse {
+    return new ExportUsageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ExportUsageResponse {
+    return new ExportUsageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ExportUsageResponse | PlainMessage<ExportUsageResponse> | undefined, b: ExportUsageResponse | PlainMessage<ExportUsageResponse> | undefined): boolean {
+    return proto3.util.equals(ExportUsageResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.GetBalanceRequest
+ */
+export class GetBalanceRequest extends Message<GetBalanceRequest> {
+  /**
+   * @generated from field: string organization_id = 1;
+   */
+  organizationId = "";
+
+  constructor(data?: PartialMessage<GetBalanceRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.GetBalanceRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "organization_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetBalanceRequest {
+    return new GetBalanceRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetBalanceRequest {
+    return new GetBalanceRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetBalanceRequest {
+    return new GetBalanceRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetBalanceRequest | PlainMessage<GetBalanceRequest> | undefined, b: GetBalanceRequest | PlainMessage<GetBalanceRequest> | undefined): boolean {
+    return proto3.util.equals(GetBalanceRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.GetBalanceResponse
+ */
+export class GetBalanceResponse extends Message<GetBalanceResponse> {
+  /**
+   * Credits used since the balance was last reset, e.g. at the start of the billing cycle.
+   *
+   * @generated from field: double credits = 1;
+   */
+  credits = 0;
+
+  constructor(data?: PartialMessage<GetBalanceResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.GetBalanceResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "credits", kind: "scalar", T: 1 /* ScalarType.DOUBLE */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetBalanceResponse {
+    return new GetBalanceResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetBalanceResponse {
+    return new GetBalanceResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetBalanceResponse {
+    return new GetBalanceResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetBalanceResponse | PlainMessage<GetBalanceResponse> | undefined, b: GetBalanceResponse | PlainMessage<GetBalanceResponse> | undefined): boolean {
+    return proto3.util.equals(GetBalanceResponse, a, b);
+  }
+}
+
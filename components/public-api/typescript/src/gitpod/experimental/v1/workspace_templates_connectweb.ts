@@ -0,0 +1,77 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// @generated by protoc-gen-connect-web v0.2.1 with parameter "target=ts"
+// @generated from file gitpod/experimental/v1/workspace_templates.proto (package gitpod.experimental.v1, syntax proto3)
+/* eslint-disable */
+/* @ts-nocheck */
+
+import {CreateWorkspaceTemplateRequest, CreateWorkspaceTemplateResponse, DeleteWorkspaceTemplateRequest, DeleteWorkspaceTemplateResponse, GetWorkspaceTemplateRequest, GetWorkspaceTemplateResponse, ListWorkspaceTemplatesRequest, ListWorkspaceTemplatesResponse, StartWorkspaceFromTemplateRequest, StartWorkspaceFromTemplateResponse} from "./workspace_templates_pb.js";
+import {MethodKind} from "@bufbuild/protobuf";
+
+/**
+ * @generated from service gitpod.experimental.v1.WorkspaceTemplatesService
+ */
+export const WorkspaceTemplatesService = {
+  typeName: "gitpod.experimental.v1.WorkspaceTemplatesService",
+  methods: {
+    /**
+     * CreateWorkspaceTemplate creates a new version of a template from a snapshot.
+     *
+     * @generated from rpc gitpod.experimental.v1.WorkspaceTemplatesService.CreateWorkspaceTemplate
+     */
+    createWorkspaceTemplate: {
+      name: "CreateWorkspaceTemplate",
+      I: CreateWorkspaceTemplateRequest,
+      O: CreateWorkspaceTemplateResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetWorkspaceTemplate returns a single version of a template.
+     *
+     * @generated from rpc gitpod.experimental.v1.WorkspaceTemplatesService.GetWorkspaceTemplate
+     */
+    getWorkspaceTemplate: {
+      name: "GetWorkspaceTemplate",
+      I: GetWorkspaceTemplateRequest,
+      O: GetWorkspaceTemplateResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ListWorkspaceTemplates lists the templates of an organization.
+     *
+     * @generated from rpc gitpod.experimental.v1.WorkspaceTemplatesService.ListWorkspaceTemplates
+     */
+    listWorkspaceTemplates: {
+      name: "ListWorkspaceTemplates",
+      I: ListWorkspaceTemplatesRequest,
+      O: ListWorkspaceTemplatesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * DeleteWorkspaceTemplate deletes a single version of a template.
+     *
+     * @generated from rpc gitpod.experimental.v1.WorkspaceTemplatesService.DeleteWorkspaceTemplate
+     */
+    deleteWorkspaceTemplate: {
+      name: "DeleteWorkspaceTemplate",
+      I: DeleteWorkspaceTemplateRequest,
+      O: DeleteWorkspaceTemplateResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * StartWorkspaceFromTemplate creates and starts a workspace from a template.
+     *
+     * @generated from rpc gitpod.experimental.v1.WorkspaceTemplatesService.StartWorkspaceFromTemplate
+     */
+    startWorkspaceFromTemplate: {
+      name: "StartWorkspaceFromTemplate",
+      I: StartWorkspaceFromTemplateRequest,
+      O: StartWorkspaceFromTemplateResponse,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
@@ -0,0 +1,729 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// @generated by protoc-gen-es v0.1.1 with parameter "target=ts"
+// @generated from file gitpod/experimental/v1/workspace_templates.proto (package gitpod.experimental.v1, syntax proto3)
+/* eslint-disable */
+/* @ts-nocheck */
+
+import type {BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage} from "@bufbuild/protobuf";
+import {Message, proto3, protoInt64, Timestamp} from "@bufbuild/protobuf";
+import {Pagination} from "./pagination_pb.js";
+
+/**
+ * WorkspaceTemplate is a version of a named, reusable starting point for workspaces.
+ * A template is created from a snapshot, and every time a template is saved under an existing name, a new version is created.
+ *
+ * @generated from message gitpod.experimental.v1.WorkspaceTemplate
+ */
+export class WorkspaceTemplate extends Message<WorkspaceTemplate> {
+  /**
+   * id is the unique identifier of this version of the template
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * organization_id is the Organization the template belongs to
+   *
+   * @generated from field: string organization_id = 2;
+   */
+  organizationId = "";
+
+  /**
+   * project_id is the Project the template belongs to.
+   * Empty if the template is shared across the whole organization.
+   *
+   * @generated from field: string project_id = 3;
+   */
+  projectId = "";
+
+  /**
+   * name identifies the template within its organization, or project
+   *
+   * @generated from field: string name = 4;
+   */
+  name = "";
+
+  /**
+   * version starts at 1, and increases every time the template is saved
+   *
+   * @generated from field: int32 version = 5;
+   */
+  version = 0;
+
+  /**
+   * @generated from field: string description = 6;
+   */
+  description = "";
+
+  /**
+   * snapshot_id is the snapshot from which workspaces based on the template restore their content
+   *
+   * @generated from field: string snapshot_id = 7;
+   */
+  snapshotId = "";
+
+  /**
+   * image is the workspace image the snapshot was taken in
+   *
+   * @generated from field: string image = 8;
+   */
+  image = "";
+
+  /**
+   * workspace_class is the class of workspaces started from the template
+   *
+   * @generated from field: string workspace_class = 9;
+   */
+  workspaceClass = "";
+
+  /**
+   * ide is the IDE of workspaces started from the template, e.g. `code`
+   *
+   * @generated from field: string ide = 10;
+   */
+  ide = "";
+
+  /**
+   * env_vars are set in workspaces started from the template
+   *
+   * @generated from field: repeated gitpod.experimental.v1.WorkspaceTemplateEnvVar env_vars = 11;
+   */
+  envVars: WorkspaceTemplateEnvVar[] = [];
+
+  /**
+   * creator_id is the ID of the user who created this version
+   *
+   * @generated from field: string creator_id = 12;
+   */
+  creatorId = "";
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 13;
+   */
+  createdAt?: Timestamp;
+
+  constructor(data?: PartialMessage<WorkspaceTemplate>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.WorkspaceTemplate";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "organization_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "project_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "version", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 6, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "image", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 9, name: "workspace_class", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 10, name: "ide", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 11, name: "env_vars", kind: "message", T: WorkspaceTemplateEnvVar, repeated: true },
+    { no: 12, name: "creator_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 13, name: "created_at", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): WorkspaceTemplate {
+    return new WorkspaceTemplate().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): WorkspaceTemplate {
+    return new WorkspaceTemplate().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): WorkspaceTemplate {
+    return new WorkspaceTemplate().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: WorkspaceTemplate | PlainMessage<WorkspaceTemplate> | undefined, b: WorkspaceTemplate | PlainMessage<WorkspaceTemplate> | undefined): boolean {
+    return proto3.util.equals(WorkspaceTemplate, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.WorkspaceTemplateEnvVar
+ */
+export class WorkspaceTemplateEnvVar extends Message<WorkspaceTemplateEnvVar> {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * @generated from field: string value = 2;
+   */
+  value = "";
+
+  constructor(data?: PartialMessage<WorkspaceTemplateEnvVar>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.WorkspaceTemplateEnvVar";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): WorkspaceTemplateEnvVar {
+    return new WorkspaceTemplateEnvVar().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): WorkspaceTemplateEnvVar {
+    return new WorkspaceTemplateEnvVar().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): WorkspaceTemplateEnvVar {
+    return new WorkspaceTemplateEnvVar().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: WorkspaceTemplateEnvVar | PlainMessage<WorkspaceTemplateEnvVar> | undefined, b: WorkspaceTemplateEnvVar | PlainMessage<WorkspaceTemplateEnvVar> | undefined): boolean {
+    return proto3.util.equals(WorkspaceTemplateEnvVar, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.CreateWorkspaceTemplateRequest
+ */
+export class CreateWorkspaceTemplateRequest extends Message<CreateWorkspaceTemplateRequest> {
+  /**
+   * @generated from field: string organization_id = 1;
+   */
+  organizationId = "";
+
+  /**
+   * project_id scopes the template to a project, if set
+   *
+   * @generated from field: string project_id = 2;
+   */
+  projectId = "";
+
+  /**
+   * @generated from field: string name = 3;
+   */
+  name = "";
+
+  /**
+   * @generated from field: string description = 4;
+   */
+  description = "";
+
+  /**
+   * snapshot_id is the snapshot the template is created from, as returned by `gp snapshot`
+   *
+   * @generated from field: string snapshot_id = 5;
+   */
+  snapshotId = "";
+
+  /**
+   * workspace_class overrides the class of the workspace the snapshot was taken in, if set
+   *
+   * @generated from field: string workspace_class = 6;
+   */
+  workspaceClass = "";
+
+  /**
+   * ide overrides the IDE of the workspace the snapshot was taken in, if set
+   *
+   * @generated from field: string ide = 7;
+   */
+  ide = "";
+
+  /**
+   * @generated from field: repeated gitpod.experimental.v1.WorkspaceTemplateEnvVar env_vars = 8;
+   */
+  envVars: WorkspaceTemplateEnvVar[] = [];
+
+  /**
+   * owns_snapshot marks the snapshot as taken for the template. It is deleted once no version of a template uses it anymore.
+   * Snapshots which are not owned by a template are never deleted with it, as they may have been shared already.
+   *
+   * @generated from field: bool owns_snapshot = 9;
+   */
+  ownsSnapshot = false;
+
+  constructor(data?: PartialMessage<CreateWorkspaceTemplateRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.CreateWorkspaceTemplateRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "organization_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "project_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "workspace_class", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "ide", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "env_vars", kind: "message", T: WorkspaceTemplateEnvVar, repeated: true },
+    { no: 9, name: "owns_snapshot", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateWorkspaceTemplateRequest {
+    return new CreateWorkspaceTemplateRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateWorkspaceTemplateRequest {
+    return new CreateWorkspaceTemplateRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateWorkspaceTemplateRequest {
+    return new CreateWorkspaceTemplateRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateWorkspaceTemplateRequest | PlainMessage<CreateWorkspaceTemplateRequest> | undefined, b: CreateWorkspaceTemplateRequest | PlainMessage<CreateWorkspaceTemplateRequest> | undefined): boolean {
+    return proto3.util.equals(CreateWorkspaceTemplateRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.CreateWorkspaceTemplateResponse
+ */
+export class CreateWorkspaceTemplateResponse extends Message<CreateWorkspaceTemplateResponse> {
+  /**
+   * @generated from field: gitpod.experimental.v1.WorkspaceTemplate template = 1;
+   */
+  template?: WorkspaceTemplate;
+
+  constructor(data?: PartialMessage<CreateWorkspaceTemplateResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.CreateWorkspaceTemplateResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "template", kind: "message", T: WorkspaceTemplate },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateWorkspaceTemplateResponse {
+    return new CreateWorkspaceTemplateResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateWorkspaceTemplateResponse {
+    return new CreateWorkspaceTemplateResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateWorkspaceTemplateResponse {
+    return new CreateWorkspaceTemplateResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateWorkspaceTemplateResponse | PlainMessage<CreateWorkspaceTemplateResponse> | undefined, b: CreateWorkspaceTemplateResponse | PlainMessage<CreateWorkspaceTemplateResponse> | undefined): boolean {
+    return proto3.util.equals(CreateWorkspaceTemplateResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.GetWorkspaceTemplateRequest
+ */
+export class GetWorkspaceTemplateRequest extends Message<GetWorkspaceTemplateRequest> {
+  /**
+   * @generated from field: string template_id = 1;
+   */
+  templateId = "";
+
+  constructor(data?: PartialMessage<GetWorkspaceTemplateRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.GetWorkspaceTemplateRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "template_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetWorkspaceTemplateRequest {
+    return new GetWorkspaceTemplateRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetWorkspaceTemplateRequest {
+    return new GetWorkspaceTemplateRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetWorkspaceTemplateRequest {
+    return new GetWorkspaceTemplateRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetWorkspaceTemplateRequest | PlainMessage<GetWorkspaceTemplateRequest> | undefined, b: GetWorkspaceTemplateRequest | PlainMessage<GetWorkspaceTemplateRequest> | undefined): boolean {
+    return proto3.util.equals(GetWorkspaceTemplateRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.GetWorkspaceTemplateResponse
+ */
+export class GetWorkspaceTemplateResponse extends Message<GetWorkspaceTemplateResponse> {
+  /**
+   * @generated from field: gitpod.experimental.v1.WorkspaceTemplate template = 1;
+   */
+  template?: WorkspaceTemplate;
+
+  constructor(data?: PartialMessage<GetWorkspaceTemplateResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.GetWorkspaceTemplateResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "template", kind: "message", T: WorkspaceTemplate },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetWorkspaceTemplateResponse {
+    return new GetWorkspaceTemplateResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetWorkspaceTemplateResponse {
+    return new GetWorkspaceTemplateResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetWorkspaceTemplateResponse {
+    return new GetWorkspaceTemplateResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetWorkspaceTemplateResponse | PlainMessage<GetWorkspaceTemplateResponse> | undefined, b: GetWorkspaceTemplateResponse | PlainMessage<GetWorkspaceTemplateResponse> | undefined): boolean {
+    return proto3.util.equals(GetWorkspaceTemplateResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListWorkspaceTemplatesRequest
+ */
+export class ListWorkspaceTemplatesRequest extends Message<ListWorkspaceTemplatesRequest> {
+  /**
+   * Page information
+   *
+   * @generated from field: gitpod.experimental.v1.Pagination pagination = 1;
+   */
+  pagination?: Pagination;
+
+  /**
+   * @generated from field: string organization_id = 2;
+   */
+  organizationId = "";
+
+  /**
+   * project_id only lists templates of the project, if set
+   *
+   * @generated from field: string project_id = 3;
+   */
+  projectId = "";
+
+  /**
+   * name only lists the template with the given name, if set
+   *
+   * @generated from field: string name = 4;
+   */
+  name = "";
+
+  /**
+   * all_versions lists every version of the templates, instead of only the most recent one
+   *
+   * @generated from field: bool all_versions = 5;
+   */
+  allVersions = false;
+
+  constructor(data?: PartialMessage<ListWorkspaceTemplatesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListWorkspaceTemplatesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "pagination", kind: "message", T: Pagination },
+    { no: 2, name: "organization_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "project_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "all_versions", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListWorkspaceTemplatesRequest {
+    return new ListWorkspaceTemplatesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListWorkspaceTemplatesRequest {
+    return new ListWorkspaceTemplatesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListWorkspaceTemplatesRequest {
+    return new ListWorkspaceTemplatesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListWorkspaceTemplatesRequest | PlainMessage<ListWorkspaceTemplatesRequest> | undefined, b: ListWorkspaceTemplatesRequest | PlainMessage<ListWorkspaceTemplatesRequest> | undefined): boolean {
+    return proto3.util.equals(ListWorkspaceTemplatesRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.ListWorkspaceTemplatesResponse
+ */
+export class ListWorkspaceTemplatesResponse extends Message<ListWorkspaceTemplatesResponse> {
+  /**
+   * @generated from field: repeated gitpod.experimental.v1.WorkspaceTemplate templates = 1;
+   */
+  templates: WorkspaceTemplate[] = [];
+
+  /**
+   * total_results is the total number of templates which match the request
+   *
+   * @generated from field: int64 total_results = 2;
+   */
+  totalResults = protoInt64.zero;
+
+  constructor(data?: PartialMessage<ListWorkspaceTemplatesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.ListWorkspaceTemplatesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "templates", kind: "message", T: WorkspaceTemplate, repeated: true },
+    { no: 2, name: "total_results", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListWorkspaceTemplatesResponse {
+    return new ListWorkspaceTemplatesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListWorkspaceTemplatesResponse {
+    return new ListWorkspaceTemplatesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListWorkspaceTemplatesResponse {
+    return new ListWorkspaceTemplatesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListWorkspaceTemplatesResponse | PlainMessage<ListWorkspaceTemplatesResponse> | undefined, b: ListWorkspaceTemplatesResponse | PlainMessage<ListWorkspaceTemplatesResponse> | undefined): boolean {
+    return proto3.util.equals(ListWorkspaceTemplatesResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.DeleteWorkspaceTemplateRequest
+ */
+export class DeleteWorkspaceTemplateRequest extends Message<DeleteWorkspaceTemplateRequest> {
+  /**
+   * @generated from field: string template_id = 1;
+   */
+  templateId = "";
+
+  constructor(data?: PartialMessage<DeleteWorkspaceTemplateRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.DeleteWorkspaceTemplateRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "template_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteWorkspaceTemplateRequest {
+    return new DeleteWorkspaceTemplateRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteWorkspaceTemplateRequest {
+    return new DeleteWorkspaceTemplateRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteWorkspaceTemplateRequest {
+    return new DeleteWorkspaceTemplateRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteWorkspaceTemplateRequest | PlainMessage<DeleteWorkspaceTemplateRequest> | undefined, b: DeleteWorkspaceTemplateRequest | PlainMessage<DeleteWorkspaceTemplateRequest> | undefined): boolean {
+    return proto3.util.equals(DeleteWorkspaceTemplateRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.DeleteWorkspaceTemplateResponse
+ */
+export class DeleteWorkspaceTemplateResponse extends Message<DeleteWorkspaceTemplateResponse> {
+  constructor(data?: PartialMessage<DeleteWorkspaceTemplateResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.DeleteWorkspaceTemplateResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteWorkspaceTemplateResponse {
+    return new DeleteWorkspaceTemplateResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteWorkspaceTemplateResponse {
+    return new DeleteWorkspaceTemplateResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteWorkspaceTemplateResponse {
+    return new DeleteWorkspaceTemplateResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteWorkspaceTemplateResponse | PlainMessage<DeleteWorkspaceTemplateResponse> | undefined, b: DeleteWorkspaceTemplateResponse | PlainMessage<DeleteWorkspaceTemplateResponse> | undefined): boolean {
+    return proto3.util.equals(DeleteWorkspaceTemplateResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.StartWorkspaceFromTemplateRequest
+ */
+export class StartWorkspaceFromTemplateRequest extends Message<StartWorkspaceFromTemplateRequest> {
+  /**
+   * @generated from oneof gitpod.experimental.v1.StartWorkspaceFromTemplateRequest.template
+   */
+  template: {
+    /**
+     * template_id starts a specific version of a template
+     *
+     * @generated from field: string template_id = 1;
+     */
+    value: string;
+    case: "templateId";
+  } | {
+    /**
+     * name starts the most recent version of the template with the given name
+     *
+     * @generated from field: gitpod.experimental.v1.WorkspaceTemplateName name = 2;
+     */
+    value: WorkspaceTemplateName;
+    case: "name";
+  } | { case: undefined; value?: undefined } = { case: undefined };
+
+  constructor(data?: PartialMessage<StartWorkspaceFromTemplateRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.StartWorkspaceFromTemplateRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "template_id", kind: "scalar", T: 9 /* ScalarType.STRING */, oneof: "template" },
+    { no: 2, name: "name", kind: "message", T: WorkspaceTemplateName, oneof: "template" },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StartWorkspaceFromTemplateRequest {
+    return new StartWorkspaceFromTemplateRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StartWorkspaceFromTemplateRequest {
+    return new StartWorkspaceFromTemplateRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StartWorkspaceFromTemplateRequest {
+    return new StartWorkspaceFromTemplateRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: StartWorkspaceFromTemplateRequest | PlainMessage<StartWorkspaceFromTemplateRequest> | undefined, b: StartWorkspaceFromTemplateRequest | PlainMessage<StartWorkspaceFromTemplateRequest> | undefined): boolean {
+    return proto3.util.equals(StartWorkspaceFromTemplateRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.WorkspaceTemplateName
+ */
+export class WorkspaceTemplateName extends Message<WorkspaceTemplateName> {
+  /**
+   * @generated from field: string organization_id = 1;
+   */
+  organizationId = "";
+
+  /**
+   * @generated from field: string project_id = 2;
+   */
+  projectId = "";
+
+  /**
+   * @generated from field: string name = 3;
+   */
+  name = "";
+
+  constructor(data?: PartialMessage<WorkspaceTemplateName>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.WorkspaceTemplateName";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "organization_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "project_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): WorkspaceTemplateName {
+    return new WorkspaceTemplateName().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): WorkspaceTemplateName {
+    return new WorkspaceTemplateName().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): WorkspaceTemplateName {
+    return new WorkspaceTemplateName().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: WorkspaceTemplateName | PlainMessage<WorkspaceTemplateName> | undefined, b: WorkspaceTemplateName | PlainMessage<WorkspaceTemplateName> | undefined): boolean {
+    return proto3.util.equals(WorkspaceTemplateName, a, b);
+  }
+}
+
+/**
+ * @generated from message gitpod.experimental.v1.StartWorkspaceFromTemplateResponse
+ */
+export class StartWorkspaceFromTemplateResponse extends Message<StartWorkspaceFromTemplateResponse> {
+  /**
+   * @generated from field: string workspace_id = 1;
+   */
+  workspaceId = "";
+
+  /**
+   * workspace_url is the URL under which the workspace is reachable once it is running
+   *
+   * @generated from field: string workspace_url = 2;
+   */
+  workspaceUrl = "";
+
+  constructor(data?: PartialMessage<StartWorkspaceFromTemplateResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime = proto3;
+  static readonly typeName = "gitpod.experimental.v1.StartWorkspaceFromTemplateResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "workspace_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "workspace_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StartWorkspaceFromTemplateResponse {
+    return new StartWorkspaceFromTemplateResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StartWorkspaceFromTemplateResponse {
+    return new StartWorkspaceFromTemplateResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StartWorkspaceFromTemplateResponse {
+    return new StartWorkspaceFromTemplateResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: StartWorkspaceFromTemplateResponse | PlainMessage<StartWorkspaceFromTemplateResponse> | undefined, b: StartWorkspaceFromTemplateResponse | PlainMessage<StartWorkspaceFromTemplateResponse> | undefined): boolean {
+    return proto3.util.equals(StartWorkspaceFromTemplateResponse, a, b);
+  }
+}
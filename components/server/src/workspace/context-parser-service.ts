@@ -149,7 +149,7 @@ export class ContextParser {
                         subContext = JSON.parse(JSON.stringify(context));
                     }
 
-                    subRepoCommits.push({
+                    const checkoutInfo: GitCheckoutInfo = {
                         ...subContext,
                         checkoutLocation: subRepo.checkoutLocation || subContext.repository.name,
                         upstreamRemoteURI: this.buildUpstreamCloneUrl(subContext),
@@ -157,7 +157,14 @@ export class ContextParser {
                         ref: context.ref,
                         refType: context.refType,
                         localBranch: context.localBranch,
-                    });
+                    };
+                    if (subRepo.ref) {
+                        // an explicitly configured branch takes precedence, and is checked out at its remote head.
+                        checkoutInfo.ref = subRepo.ref;
+                        checkoutInfo.refType = "branch";
+                        checkoutInfo.localBranch = undefined;
+                    }
+                    subRepoCommits.push(checkoutInfo);
                 }
                 context.additionalRepositoryCheckoutInfo = subRepoCommits;
             }
@@ -3069,6 +3069,13 @@ export class GitpodServerImpl implements GitpodServerWithTracing, Disposable {
         const user = this.checkAndBlockUser("updateOrgSettings");
         traceAPIParams(ctx, { orgId, userId: user.id });
         await this.guardTeamOperation(orgId, "update", "org_write");
+        if (settings.dotfileRepos?.some((repo) => !repo || /[\s,]/.test(repo))) {
+            // supervisor receives the repositories as a whitespace or comma separated list
+            throw new ResponseError(
+                ErrorCodes.BAD_REQUEST,
+                "dotfileRepos must not be empty or contain whitespace or commas",
+            );
+        }
         await this.teamDB.setOrgSettings(orgId, settings);
         return (await this.teamDB.findOrgSettings(orgId))!;
     }
@@ -511,7 +511,7 @@ export class WorkspaceStarter {
             );
 
             // create start workspace request
-            const metadata = await this.createMetadata(workspace);
+            const metadata = await this.createMetadata({ span }, workspace);
             const startRequest = new StartWorkspaceRequest();
             startRequest.setId(instance.id);
             startRequest.setMetadata(metadata);
@@ -642,7 +642,7 @@ export class WorkspaceStarter {
         ctx.span?.setTag("failedInstanceStartReason", reason);
     }
 
-    protected async createMetadata(workspace: Workspace): Promise<WorkspaceMetadata> {
+    protected async createMetadata(ctx: TraceContext, workspace: Workspace): Promise<WorkspaceMetadata> {
         let metadata = new WorkspaceMetadata();
         metadata.setOwner(workspace.ownerId);
         metadata.setMetaId(workspace.id);
@@ -653,6 +653,18 @@ export class WorkspaceStarter {
                 metadata.setTeam(project.teamId);
             }
         }
+        if (workspace.type === "prebuild") {
+            // ws-manager schedules the prebuilds of a project based on these annotations
+            const prebuild = await this.workspaceDb.trace(ctx).findPrebuildByWorkspaceID(workspace.id);
+            if (prebuild?.projectId) {
+                const annotations = metadata.getAnnotationsMap();
+                annotations.set("gitpod.io/prebuildProject", prebuild.projectId);
+                annotations.set("gitpod.io/prebuildCommit", prebuild.commit);
+                if (prebuild.branch) {
+                    annotations.set("gitpod.io/prebuildBranch", prebuild.branch);
+                }
+            }
+        }
 
         return metadata;
     }
@@ -1377,6 +1389,25 @@ export class WorkspaceStarter {
         dotfileEnv.setValue(user.additionalData?.dotfileRepo || "");
         envvars.push(dotfileEnv);
 
+        // the dotfiles of the organization are installed before the user's own, such that the latter take precedence
+        const orgSettings = workspace.organizationId
+            ? await this.teamDB.findOrgSettings(workspace.organizationId)
+            : undefined;
+        if (orgSettings?.dotfileRepos?.length) {
+            const orgDotfilesEnv = new EnvironmentVariable();
+            orgDotfilesEnv.setName("SUPERVISOR_DOTFILE_REPOS");
+            orgDotfilesEnv.setValue(orgSettings.dotfileRepos.join(" "));
+            envvars.push(orgDotfilesEnv);
+        }
+
+        // supervisor validates the sinks and ignores them if they are invalid
+        if (user.additionalData?.notificationSinks) {
+            const notificationSinksEnv = new EnvironmentVariable();
+            notificationSinksEnv.setName("SUPERVISOR_NOTIFICATION_SINKS");
+            notificationSinksEnv.setValue(JSON.stringify(user.additionalData.notificationSinks));
+            envvars.push(notificationSinksEnv);
+        }
+
         if (workspace.config.coreDump?.enabled) {
             // default core dump size is 262144 blocks (if blocksize is 4096)
             const defaultLimit: number = 1073741824;
@@ -1650,6 +1681,9 @@ export class WorkspaceStarter {
             const backup = new FromBackupInitializer();
             if (CommitContext.is(context)) {
                 backup.setCheckoutLocation(context.checkoutLocation || "");
+                for (const additional of context.additionalRepositoryCheckoutInfo || []) {
+                    backup.addAdditionalCheckoutLocations(additional.checkoutLocation || additional.repository.name);
+                }
             }
             result.setBackup(backup);
         } else if (SnapshotContext.is(context)) {
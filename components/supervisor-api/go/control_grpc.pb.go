@@ -5,7 +5,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.2.0
-// - protoc             v3.20.1
+// - protoc        v3.20.1
 // source: control.proto
 
 package api
@@ -32,6 +32,10 @@ type ControlServiceClient interface {
 	CreateSSHKeyPair(ctx context.Context, in *CreateSSHKeyPairRequest, opts ...grpc.CallOption) (*CreateSSHKeyPairResponse, error)
 	// CreateDebugEnv creates a debug workspace envs
 	CreateDebugEnv(ctx context.Context, in *CreateDebugEnvRequest, opts ...grpc.CallOption) (*CreateDebugEnvResponse, error)
+	// RestartService restarts a background service configured in .gitpod.yml
+	RestartService(ctx context.Context, in *RestartServiceRequest, opts ...grpc.CallOption) (*RestartServiceResponse, error)
+	// UpdateDotfiles updates the dotfiles repositories and runs their installation scripts if they changed
+	UpdateDotfiles(ctx context.Context, in *UpdateDotfilesRequest, opts ...grpc.CallOption) (*UpdateDotfilesResponse, error)
 }
 
 type controlServiceClient struct {
@@ -69,6 +73,24 @@ func (c *controlServiceClient) CreateDebugEnv(ctx context.Context, in *CreateDeb
 	return out, nil
 }
 
+func (c *controlServiceClient) RestartService(ctx context.Context, in *RestartServiceRequest, opts ...grpc.CallOption) (*RestartServiceResponse, error) {
+	out := new(RestartServiceResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ControlService/RestartService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) UpdateDotfiles(ctx context.Context, in *UpdateDotfilesRequest, opts ...grpc.CallOption) (*UpdateDotfilesResponse, error) {
+	out := new(UpdateDotfilesResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ControlService/UpdateDotfiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ControlServiceServer is the server API for ControlService service.
 // All implementations must embed UnimplementedControlServiceServer
 // for forward compatibility
@@ -79,6 +101,10 @@ type ControlServiceServer interface {
 	CreateSSHKeyPair(context.Context, *CreateSSHKeyPairRequest) (*CreateSSHKeyPairResponse, error)
 	// CreateDebugEnv creates a debug workspace envs
 	CreateDebugEnv(context.Context, *CreateDebugEnvRequest) (*CreateDebugEnvResponse, error)
+	// RestartService restarts a background service configured in .gitpod.yml
+	RestartService(context.Context, *RestartServiceRequest) (*RestartServiceResponse, error)
+	// UpdateDotfiles updates the dotfiles repositories and runs their installation scripts if they changed
+	UpdateDotfiles(context.Context, *UpdateDotfilesRequest) (*UpdateDotfilesResponse, error)
 	mustEmbedUnimplementedControlServiceServer()
 }
 
@@ -95,6 +121,12 @@ func (UnimplementedControlServiceServer) CreateSSHKeyPair(context.Context, *Crea
 func (UnimplementedControlServiceServer) CreateDebugEnv(context.Context, *CreateDebugEnvRequest) (*CreateDebugEnvResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateDebugEnv not implemented")
 }
+func (UnimplementedControlServiceServer) RestartService(context.Context, *RestartServiceRequest) (*RestartServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartService not implemented")
+}
+func (UnimplementedControlServiceServer) UpdateDotfiles(context.Context, *UpdateDotfilesRequest) (*UpdateDotfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDotfiles not implemented")
+}
 func (UnimplementedControlServiceServer) mustEmbedUnimplementedControlServiceServer() {}
 
 // UnsafeControlServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -162,6 +194,42 @@ func _ControlService_CreateDebugEnv_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ControlService_RestartService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestartServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).RestartService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ControlService/RestartService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).RestartService(ctx, req.(*RestartServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_UpdateDotfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDotfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).UpdateDotfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ControlService/UpdateDotfiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).UpdateDotfiles(ctx, req.(*UpdateDotfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ControlService_ServiceDesc is the grpc.ServiceDesc for ControlService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -181,6 +249,14 @@ var ControlService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateDebugEnv",
 			Handler:    _ControlService_CreateDebugEnv_Handler,
 		},
+		{
+			MethodName: "RestartService",
+			Handler:    _ControlService_RestartService_Handler,
+		},
+		{
+			MethodName: "UpdateDotfiles",
+			Handler:    _ControlService_UpdateDotfiles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "control.proto",
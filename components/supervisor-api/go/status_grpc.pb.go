@@ -5,7 +5,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.2.0
-// - protoc             v3.20.1
+// - protoc        v3.20.1
 // source: status.proto
 
 package api
@@ -41,6 +41,10 @@ type StatusServiceClient interface {
 	PortsStatus(ctx context.Context, in *PortsStatusRequest, opts ...grpc.CallOption) (StatusService_PortsStatusClient, error)
 	// TasksStatus provides tasks status information.
 	TasksStatus(ctx context.Context, in *TasksStatusRequest, opts ...grpc.CallOption) (StatusService_TasksStatusClient, error)
+	// ServicesStatus provides status information of the background services configured in .gitpod.yml.
+	ServicesStatus(ctx context.Context, in *ServicesStatusRequest, opts ...grpc.CallOption) (StatusService_ServicesStatusClient, error)
+	// DotfilesStatus provides status information of the dotfiles repositories installed in the workspace.
+	DotfilesStatus(ctx context.Context, in *DotfilesStatusRequest, opts ...grpc.CallOption) (*DotfilesStatusResponse, error)
 	// ResourcesStatus provides workspace resources status information.
 	ResourcesStatus(ctx context.Context, in *ResourcesStatuRequest, opts ...grpc.CallOption) (*ResourcesStatusResponse, error)
 }
@@ -153,6 +157,47 @@ func (x *statusServiceTasksStatusClient) Recv() (*TasksStatusResponse, error) {
 	return m, nil
 }
 
+func (c *statusServiceClient) ServicesStatus(ctx context.Context, in *ServicesStatusRequest, opts ...grpc.CallOption) (StatusService_ServicesStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StatusService_ServiceDesc.Streams[2], "/supervisor.StatusService/ServicesStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &statusServiceServicesStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StatusService_ServicesStatusClient interface {
+	Recv() (*ServicesStatusResponse, error)
+	grpc.ClientStream
+}
+
+type statusServiceServicesStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *statusServiceServicesStatusClient) Recv() (*ServicesStatusResponse, error) {
+	m := new(ServicesStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *statusServiceClient) DotfilesStatus(ctx context.Context, in *DotfilesStatusRequest, opts ...grpc.CallOption) (*DotfilesStatusResponse, error) {
+	out := new(DotfilesStatusResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.StatusService/DotfilesStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *statusServiceClient) ResourcesStatus(ctx context.Context, in *ResourcesStatuRequest, opts ...grpc.CallOption) (*ResourcesStatusResponse, error) {
 	out := new(ResourcesStatusResponse)
 	err := c.cc.Invoke(ctx, "/supervisor.StatusService/ResourcesStatus", in, out, opts...)
@@ -181,6 +226,10 @@ type StatusServiceServer interface {
 	PortsStatus(*PortsStatusRequest, StatusService_PortsStatusServer) error
 	// TasksStatus provides tasks status information.
 	TasksStatus(*TasksStatusRequest, StatusService_TasksStatusServer) error
+	// ServicesStatus provides status information of the background services configured in .gitpod.yml.
+	ServicesStatus(*ServicesStatusRequest, StatusService_ServicesStatusServer) error
+	// DotfilesStatus provides status information of the dotfiles repositories installed in the workspace.
+	DotfilesStatus(context.Context, *DotfilesStatusRequest) (*DotfilesStatusResponse, error)
 	// ResourcesStatus provides workspace resources status information.
 	ResourcesStatus(context.Context, *ResourcesStatuRequest) (*ResourcesStatusResponse, error)
 	mustEmbedUnimplementedStatusServiceServer()
@@ -208,6 +257,12 @@ func (UnimplementedStatusServiceServer) PortsStatus(*PortsStatusRequest, StatusS
 func (UnimplementedStatusServiceServer) TasksStatus(*TasksStatusRequest, StatusService_TasksStatusServer) error {
 	return status.Errorf(codes.Unimplemented, "method TasksStatus not implemented")
 }
+func (UnimplementedStatusServiceServer) ServicesStatus(*ServicesStatusRequest, StatusService_ServicesStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method ServicesStatus not implemented")
+}
+func (UnimplementedStatusServiceServer) DotfilesStatus(context.Context, *DotfilesStatusRequest) (*DotfilesStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DotfilesStatus not implemented")
+}
 func (UnimplementedStatusServiceServer) ResourcesStatus(context.Context, *ResourcesStatuRequest) (*ResourcesStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResourcesStatus not implemented")
 }
@@ -338,6 +393,45 @@ func (x *statusServiceTasksStatusServer) Send(m *TasksStatusResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _StatusService_ServicesStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ServicesStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatusServiceServer).ServicesStatus(m, &statusServiceServicesStatusServer{stream})
+}
+
+type StatusService_ServicesStatusServer interface {
+	Send(*ServicesStatusResponse) error
+	grpc.ServerStream
+}
+
+type statusServiceServicesStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *statusServiceServicesStatusServer) Send(m *ServicesStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StatusService_DotfilesStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DotfilesStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServiceServer).DotfilesStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.StatusService/DotfilesStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServiceServer).DotfilesStatus(ctx, req.(*DotfilesStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StatusService_ResourcesStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ResourcesStatuRequest)
 	if err := dec(in); err != nil {
@@ -379,6 +473,10 @@ var StatusService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BackupStatus",
 			Handler:    _StatusService_BackupStatus_Handler,
 		},
+		{
+			MethodName: "DotfilesStatus",
+			Handler:    _StatusService_DotfilesStatus_Handler,
+		},
 		{
 			MethodName: "ResourcesStatus",
 			Handler:    _StatusService_ResourcesStatus_Handler,
@@ -395,6 +493,11 @@ var StatusService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _StatusService_TasksStatus_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ServicesStatus",
+			Handler:       _StatusService_ServicesStatus_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "status.proto",
 }
@@ -278,9 +278,18 @@ type WorkspaceConfig struct {
 	WorkspaceClusterHost string `env:"GITPOD_WORKSPACE_CLUSTER_HOST"`
 
 	// DotfileRepo is a user-configurable repository which contains their dotfiles to customise
-	// the in-workspace epxerience.
+	// the in-workspace epxerience. It can be pinned to a branch or tag by appending #<ref> to its URL.
 	DotfileRepo string `env:"SUPERVISOR_DOTFILE_REPO"`
 
+	// DotfileRepos is a whitespace or comma separated list of additional dotfile repositories, e.g. provided
+	// by the user's organization. They are installed in order before DotfileRepo, such that the user's own
+	// dotfiles take precedence. Repositories can be pinned to a branch or tag by appending #<ref> to their URL.
+	DotfileRepos string `env:"SUPERVISOR_DOTFILE_REPOS"`
+
+	// NotificationSinks configures where notifications are forwarded to if no IDE is attached.
+	// The format is expected to be JSON in the form of NotificationSinksConfig.
+	NotificationSinks string `env:"SUPERVISOR_NOTIFICATION_SINKS"`
+
 	// EnvvarOTS points to a URL from which environment variables for child processes can be downloaded from.
 	// This provides a safer means to transport environment variables compared to shipping them on the Kubernetes pod.
 	//
@@ -387,6 +396,24 @@ func (c WorkspaceConfig) GetTokens(downloadOTS bool) ([]WorkspaceGitpodToken, er
 	return tks, nil
 }
 
+// GetNotificationSinks parses the notification sinks configuration, or returns nil if none is configured.
+func (c WorkspaceConfig) GetNotificationSinks() (*NotificationSinksConfig, error) {
+	if c.NotificationSinks == "" {
+		return nil, nil
+	}
+
+	var res NotificationSinksConfig
+	err := json.Unmarshal([]byte(c.NotificationSinks), &res)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot parse notification sinks: %w", err)
+	}
+	err = res.Validate()
+	if err != nil {
+		return nil, xerrors.Errorf("invalid notification sinks: %w", err)
+	}
+	return &res, nil
+}
+
 // GitpodAPIEndpoint produces the data required to connect to the Gitpod API.
 func (c WorkspaceConfig) GitpodAPIEndpoint() (endpoint, host string, err error) {
 	gphost, err := url.Parse(c.GitpodHost)
@@ -0,0 +1,569 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unicode"
+
+	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/content-service/pkg/git"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+const (
+	dotfilesTimeout = 120 * time.Second
+
+	// dotfilesInstalledMarker is stored in the .git directory of a dotfiles repository
+	// and contains the commit which was installed last.
+	dotfilesInstalledMarker = "gitpod-dotfiles-installed"
+)
+
+var dotfilesInstallCandidates = []string{
+	"install.sh",
+	"install",
+	"bootstrap.sh",
+	"bootstrap",
+	"script/bootstrap",
+	"setup.sh",
+	"setup",
+	"script/setup",
+}
+
+// dotfilesSource is a dotfiles repository which is installed into the workspace.
+type dotfilesSource struct {
+	Repository string
+	// Ref is the branch or tag to check out, the default branch is used if empty.
+	Ref      string
+	Location string
+}
+
+var dotfilesNameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// parseDotfilesSources returns the dotfiles sources in the order they are installed.
+// The additional repositories come first such that the personal dotfiles take precedence.
+func parseDotfilesSources(home, personal, additional string) []*dotfilesSource {
+	var res []*dotfilesSource
+	repos := strings.FieldsFunc(additional, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	for i, r := range repos {
+		repo, ref := splitDotfilesRef(r)
+		name := strings.TrimSuffix(filepath.Base(strings.TrimRight(repo, "/")), ".git")
+		name = dotfilesNameSanitizer.ReplaceAllString(name, "-")
+		res = append(res, &dotfilesSource{
+			Repository: repo,
+			Ref:        ref,
+			Location:   filepath.Join(home, ".dotfiles.d", fmt.Sprintf("%02d-%s", i, name)),
+		})
+	}
+
+	personal = strings.TrimSpace(personal)
+	if personal != "" {
+		repo, ref := splitDotfilesRef(personal)
+		res = append(res, &dotfilesSource{
+			Repository: repo,
+			Ref:        ref,
+			Location:   filepath.Join(home, ".dotfiles"),
+		})
+	}
+	return res
+}
+
+// splitDotfilesRef splits a repository of the form <url>#<ref> into its URL and ref.
+func splitDotfilesRef(repo string) (string, string) {
+	idx := strings.LastIndex(repo, "#")
+	if idx < 0 {
+		return repo, ""
+	}
+	return repo[:idx], repo[idx+1:]
+}
+
+type dotfilesManager struct {
+	home         string
+	sources      []*dotfilesSource
+	tokenService *InMemoryTokenService
+	newCommand   func(name string, args ...string) *exec.Cmd
+	// chown changes the owner of the cloned files to the gitpod user if true
+	chown bool
+
+	// installMu serialises installations
+	installMu sync.Mutex
+
+	mu     sync.RWMutex
+	status []*api.DotfilesSourceStatus
+}
+
+func newDotfilesManager(cfg *Config, tokenService *InMemoryTokenService) *dotfilesManager {
+	const home = "/home/gitpod"
+	return newDotfilesManagerWithSources(home, parseDotfilesSources(home, cfg.DotfileRepo, cfg.DotfileRepos), tokenService)
+}
+
+func newDotfilesManagerWithSources(home string, sources []*dotfilesSource, tokenService *InMemoryTokenService) *dotfilesManager {
+	dm := &dotfilesManager{
+		home:         home,
+		sources:      sources,
+		tokenService: tokenService,
+		newCommand:   newDotfilesCommand,
+		chown:        true,
+	}
+	for _, src := range sources {
+		dm.status = append(dm.status, &api.DotfilesSourceStatus{
+			Repository: src.Repository,
+			Ref:        src.Ref,
+			Location:   src.Location,
+			State:      api.DotfilesSourceStatus_pending,
+		})
+	}
+	return dm
+}
+
+func newDotfilesCommand(name string, args ...string) *exec.Cmd {
+	return runAsGitpodUser(exec.Command(name, args...))
+}
+
+func (dm *dotfilesManager) logFile() string {
+	return filepath.Join(dm.home, ".dotfiles.log")
+}
+
+// Install clones or updates all dotfiles repositories and runs their installation in order
+// if they changed since they were installed last. If reinstall is true, the repositories are
+// cloned from scratch and installed regardless.
+func (dm *dotfilesManager) Install(ctx context.Context, reinstall bool) error {
+	return dm.install(ctx, reinstall, true)
+}
+
+// InstallOnStartup installs the dotfiles before the IDE starts, because they may be changing the path which
+// affects the IDE. Only repositories which were not cloned yet are fetched, such that restarts are not delayed
+// by slow remotes. Existing repositories are updated in the background.
+func (dm *dotfilesManager) InstallOnStartup(ctx context.Context) {
+	var cloned bool
+	for _, src := range dm.sources {
+		cloned = cloned || git.IsWorkingCopy(src.Location)
+	}
+
+	_ = dm.install(ctx, false, false)
+	if cloned {
+		go func() {
+			_ = dm.Install(ctx, false)
+		}()
+	}
+}
+
+func (dm *dotfilesManager) install(ctx context.Context, reinstall, update bool) error {
+	if len(dm.sources) == 0 {
+		return nil
+	}
+
+	dm.installMu.Lock()
+	defer dm.installMu.Unlock()
+
+	out, err := os.OpenFile(dm.logFile(), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	// repositories are fetched concurrently, such that slow remotes don't add up, but installed in order
+	fetched := make([]fetchedDotfiles, len(dm.sources))
+	var wg sync.WaitGroup
+	for i, src := range dm.sources {
+		dm.updateStatus(i, func(s *api.DotfilesSourceStatus) {
+			s.State = api.DotfilesSourceStatus_installing
+			s.Message = ""
+		})
+
+		wg.Add(1)
+		go func(f *fetchedDotfiles, src *dotfilesSource) {
+			defer wg.Done()
+			f.installed, f.err = dm.fetchSource(ctx, src, reinstall, update, &f.log)
+		}(&fetched[i], src)
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, src := range dm.sources {
+		_, _ = out.WriteString(fmt.Sprintf("# installing dotfiles from %s\n", src.Repository))
+		_, _ = fetched[i].log.WriteTo(out)
+
+		err := fetched[i].err
+		if err == nil {
+			err = dm.installSource(ctx, i, src, fetched[i].installed, out)
+		}
+		dm.updateStatus(i, func(s *api.DotfilesSourceStatus) {
+			if err != nil {
+				s.State = api.DotfilesSourceStatus_failed
+				s.Message = err.Error()
+			} else {
+				s.State = api.DotfilesSourceStatus_installed
+			}
+		})
+		if err != nil {
+			_, _ = out.WriteString(fmt.Sprintf("# dotfile init failed: %s\n", err.Error()))
+			log.WithError(err).WithField("repo", src.Repository).Warn("installing dotfiles failed")
+			failed = append(failed, src.Repository)
+		}
+	}
+	if len(failed) > 0 {
+		return xerrors.Errorf("installing dotfiles from %s failed", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// fetchedDotfiles is the outcome of fetching a dotfiles repository
+type fetchedDotfiles struct {
+	// installed is the commit which was installed before, if any
+	installed string
+	err       error
+	log       bytes.Buffer
+}
+
+// fetchSource clones a dotfiles repository, or updates it if it was cloned before and update is true.
+// It returns the commit which was installed before.
+func (dm *dotfilesManager) fetchSource(ctx context.Context, src *dotfilesSource, reinstall, update bool, out io.Writer) (installed string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, dotfilesTimeout)
+	defer cancel()
+
+	if reinstall {
+		err = os.RemoveAll(src.Location)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if git.IsWorkingCopy(src.Location) {
+		installed = readDotfilesInstalledMarker(src.Location)
+		if installed == "" {
+			// the repository was installed before we kept track of installed commits
+			installed, _ = dm.head(src)
+		}
+		if !update {
+			return installed, nil
+		}
+		_, _ = fmt.Fprintf(out, "# updating %s\n", src.Location)
+		err = dm.updateRepo(ctx, src, out)
+		if err != nil {
+			return "", xerrors.Errorf("cannot update dotfiles repo: %w", err)
+		}
+		return installed, nil
+	}
+
+	if _, err := os.Stat(src.Location); err == nil {
+		return "", xerrors.Errorf("%s exists and is not a git repository", src.Location)
+	}
+	// git runs as the gitpod user, who must be able to create the repository
+	parent := filepath.Dir(src.Location)
+	err = os.MkdirAll(parent, 0755)
+	if err != nil {
+		return "", err
+	}
+	if dm.chown {
+		err = os.Chown(parent, gitpodUID, gitpodGID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	_, _ = fmt.Fprintf(out, "# cloning into %s\n", src.Location)
+	err = dm.git(ctx, src, parent, out, "clone", "--depth=1", "--shallow-submodules", src.Repository, src.Location)
+	if err == nil && src.Ref != "" {
+		err = dm.checkoutRef(ctx, src, out)
+	}
+	if err != nil {
+		return "", xerrors.Errorf("cannot clone dotfiles repo: %w", err)
+	}
+	return "", nil
+}
+
+// installSource runs the installation of a fetched dotfiles repository, unless its commit is installed already.
+func (dm *dotfilesManager) installSource(ctx context.Context, idx int, src *dotfilesSource, installed string, out io.Writer) error {
+	head, err := dm.head(src)
+	if err != nil {
+		return err
+	}
+	dm.updateStatus(idx, func(s *api.DotfilesSourceStatus) {
+		s.Commit = head
+	})
+
+	if head == installed {
+		_, _ = fmt.Fprintf(out, "# %s is up to date\n", src.Location)
+		script := findDotfilesInstallScript(src.Location, io.Discard)
+		dm.updateStatus(idx, func(s *api.DotfilesSourceStatus) {
+			s.InstallScript = script
+		})
+		return nil
+	}
+
+	script := findDotfilesInstallScript(src.Location, out)
+	dm.updateStatus(idx, func(s *api.DotfilesSourceStatus) {
+		s.InstallScript = script
+	})
+	if script != "" {
+		err = dm.runInstallScript(ctx, script, out)
+	} else {
+		err = dm.linkDotfiles(src.Location, out)
+	}
+	if err != nil {
+		return err
+	}
+
+	marker := filepath.Join(src.Location, ".git", dotfilesInstalledMarker)
+	err = os.WriteFile(marker, []byte(head), 0644)
+	if err == nil && dm.chown {
+		err = os.Chown(marker, gitpodUID, gitpodGID)
+	}
+	if err != nil {
+		log.WithError(err).WithField("location", src.Location).Warn("cannot store installed dotfiles commit")
+	}
+	return nil
+}
+
+// git runs git as the gitpod user, such that the repositories are owned by the user and git does not
+// consider them unsafe. It is killed if it does not finish before the context is done.
+func (dm *dotfilesManager) git(ctx context.Context, src *dotfilesSource, dir string, out io.Writer, args ...string) error {
+	subcommand := args[0]
+	var env []string
+	if dm.tokenService != nil {
+		user, pwd, err := dm.credentials(ctx, src)
+		if err != nil {
+			return err
+		}
+		args = append([]string{"-c", "credential.helper=/bin/sh -c \"echo username=$GIT_AUTH_USER; echo password=$GIT_AUTH_PASSWORD\""}, args...)
+		env = append(env, "GIT_AUTH_USER="+user, "GIT_AUTH_PASSWORD="+pwd)
+	}
+
+	cmd := dm.newCommand("git", args...)
+	cmd.Dir = dir
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, env...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	err := runWithTimeout(ctx, dotfilesTimeout, cmd)
+	if err != nil {
+		return xerrors.Errorf("git %s: %w", subcommand, err)
+	}
+	return nil
+}
+
+func (dm *dotfilesManager) credentials(ctx context.Context, src *dotfilesSource) (username string, password string, err error) {
+	repoUrl, err := url.Parse(src.Repository)
+	if err != nil {
+		return
+	}
+	resp, err := dm.tokenService.GetToken(ctx, &api.GetTokenRequest{
+		Host: repoUrl.Host,
+		Kind: KindGit,
+	})
+	if err != nil {
+		return
+	}
+	username = resp.User
+	password = resp.Token
+	return
+}
+
+// updateRepo fetches the latest changes of the pinned ref, or of the default branch if no ref is pinned.
+func (dm *dotfilesManager) updateRepo(ctx context.Context, src *dotfilesSource, out io.Writer) error {
+	if src.Ref != "" {
+		return dm.checkoutRef(ctx, src, out)
+	}
+	err := dm.git(ctx, src, src.Location, out, "fetch", "origin", "HEAD")
+	if err != nil {
+		return err
+	}
+	return dm.git(ctx, src, src.Location, out, "merge", "--ff-only", "FETCH_HEAD")
+}
+
+func (dm *dotfilesManager) checkoutRef(ctx context.Context, src *dotfilesSource, out io.Writer) error {
+	err := dm.git(ctx, src, src.Location, out, "fetch", "--depth=1", "origin", src.Ref)
+	if err != nil {
+		return err
+	}
+	return dm.git(ctx, src, src.Location, out, "checkout", "--detach", "FETCH_HEAD")
+}
+
+func (dm *dotfilesManager) head(src *dotfilesSource) (string, error) {
+	cmd := dm.newCommand("git", "rev-parse", "HEAD")
+	cmd.Dir = src.Location
+	out, err := cmd.Output()
+	if err != nil {
+		return "", xerrors.Errorf("cannot determine dotfiles commit: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func readDotfilesInstalledMarker(location string) string {
+	content, err := os.ReadFile(filepath.Join(location, ".git", dotfilesInstalledMarker))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// findDotfilesInstallScript returns the first executable installation script candidate, or an empty string.
+func findDotfilesInstallScript(location string, out io.Writer) string {
+	for _, c := range dotfilesInstallCandidates {
+		fn := filepath.Join(location, c)
+		stat, err := os.Stat(fn)
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "# installation script candidate %s is not available\n", fn)
+			continue
+		}
+		if stat.IsDir() {
+			_, _ = fmt.Fprintf(out, "# installation script candidate %s is a directory\n", fn)
+			continue
+		}
+		if stat.Mode()&0111 == 0 {
+			_, _ = fmt.Fprintf(out, "# installation script candidate %s is not executable\n", fn)
+			continue
+		}
+		return fn
+	}
+	return ""
+}
+
+func (dm *dotfilesManager) runInstallScript(ctx context.Context, fn string, out io.Writer) error {
+	_, _ = fmt.Fprintf(out, "# executing installation script candidate %s\n", fn)
+
+	cmd := dm.newCommand("/bin/sh", "-c", "exec "+fn)
+	cmd.Dir = dm.home
+	cmd.Stdout = out
+	cmd.Stderr = out
+	err := runWithTimeout(ctx, dotfilesTimeout, cmd)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return xerrors.Errorf("installation process %s took longer than %s", fn, dotfilesTimeout)
+	}
+	return err
+}
+
+// linkDotfiles symlinks the content of a dotfiles repository into the home directory.
+// Existing files are kept, unless they are symlinks into a dotfiles repository, in which case
+// the later repository takes precedence.
+func (dm *dotfilesManager) linkDotfiles(location string, out io.Writer) error {
+	return filepath.Walk(location, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.Contains(path, "/.git") {
+			// don't symlink the .git directory or any of its content
+			return nil
+		}
+
+		homeFN := filepath.Join(dm.home, strings.TrimPrefix(path, location))
+		if info.IsDir() {
+			return os.MkdirAll(homeFN, info.Mode().Perm())
+		}
+
+		if stat, err := os.Lstat(homeFN); err == nil {
+			if stat.Mode()&os.ModeSymlink == 0 {
+				// homeFN exists already and is not a symlink - do nothing
+				return nil
+			}
+			target, err := os.Readlink(homeFN)
+			if err != nil || !dm.isDotfilesPath(target) {
+				return nil
+			}
+			if target == path {
+				return nil
+			}
+			err = os.Remove(homeFN)
+			if err != nil {
+				return err
+			}
+		}
+
+		// write some feedback to the terminal
+		_, _ = fmt.Fprintf(out, "# echo linking %s -> %s\n", path, homeFN)
+
+		return os.Symlink(path, homeFN)
+	})
+}
+
+func (dm *dotfilesManager) isDotfilesPath(path string) bool {
+	for _, src := range dm.sources {
+		if strings.HasPrefix(path, src.Location+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (dm *dotfilesManager) updateStatus(idx int, update func(s *api.DotfilesSourceStatus)) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	update(dm.status[idx])
+}
+
+// Status returns the status of all dotfiles sources in the order they are installed.
+func (dm *dotfilesManager) Status() []*api.DotfilesSourceStatus {
+	dm.mu.RLock()
+	defer dm.mu.RUnlock()
+
+	res := make([]*api.DotfilesSourceStatus, 0, len(dm.status))
+	for _, s := range dm.status {
+		res = append(res, proto.Clone(s).(*api.DotfilesSourceStatus))
+	}
+	return res
+}
+
+// Log returns the output of the last installation.
+func (dm *dotfilesManager) Log() (string, error) {
+	content, err := os.ReadFile(dm.logFile())
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// runWithTimeout runs a command and kills it, including its child processes, if it does not finish within
+// the timeout or before the context is done.
+func runWithTimeout(ctx context.Context, timeout time.Duration, cmd *exec.Cmd) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		<-done
+		return ctx.Err()
+	}
+}
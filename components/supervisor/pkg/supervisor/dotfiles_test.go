@@ -0,0 +1,298 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestParseDotfilesSources(t *testing.T) {
+	tests := []struct {
+		Desc       string
+		Personal   string
+		Additional string
+		Expected   []*dotfilesSource
+	}{
+		{Desc: "none"},
+		{
+			Desc:     "personal only",
+			Personal: "https://github.com/foo/dotfiles",
+			Expected: []*dotfilesSource{
+				{Repository: "https://github.com/foo/dotfiles", Location: "/home/gitpod/.dotfiles"},
+			},
+		},
+		{
+			Desc:       "additional before personal",
+			Personal:   "https://github.com/foo/dotfiles#main",
+			Additional: "https://github.com/org/team-dotfiles.git#v1.0, https://gitlab.com/org/base/\n",
+			Expected: []*dotfilesSource{
+				{Repository: "https://github.com/org/team-dotfiles.git", Ref: "v1.0", Location: "/home/gitpod/.dotfiles.d/00-team-dotfiles"},
+				{Repository: "https://gitlab.com/org/base/", Location: "/home/gitpod/.dotfiles.d/01-base"},
+				{Repository: "https://github.com/foo/dotfiles", Ref: "main", Location: "/home/gitpod/.dotfiles"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act := parseDotfilesSources("/home/gitpod", test.Personal, test.Additional)
+			if diff := cmp.Diff(test.Expected, act); diff != "" {
+				t.Errorf("unexpected sources (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDotfilesManager_Install(t *testing.T) {
+	log.Log.Logger.SetLevel(logrus.FatalLevel)
+
+	remote := newTestDotfilesRemote(t)
+	remote.commit(t, "install.sh", "#!/bin/sh\necho run >> \"$HOME_DIR/runs\"\n", 0755)
+
+	dm, home := newTestDotfilesManager(t, &dotfilesSource{Repository: remote.url})
+	runs := func() int {
+		content, _ := os.ReadFile(filepath.Join(home, "runs"))
+		return strings.Count(string(content), "run\n")
+	}
+
+	ctx := context.Background()
+	if err := dm.Install(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if runs() != 1 {
+		t.Errorf("expected install script to run once, ran %d times", runs())
+	}
+	status := dm.Status()[0]
+	if status.State != api.DotfilesSourceStatus_installed || status.Commit != remote.head(t) || !strings.HasSuffix(status.InstallScript, "install.sh") {
+		t.Errorf("unexpected status: %v", status)
+	}
+
+	// unchanged repositories are not installed again
+	if err := dm.Install(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if runs() != 1 {
+		t.Errorf("expected install script not to run for an unchanged repository, ran %d times", runs())
+	}
+
+	// changes are pulled and installed
+	remote.commit(t, "README.md", "hello", 0644)
+	if err := dm.Install(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if runs() != 2 {
+		t.Errorf("expected install script to run after update, ran %d times", runs())
+	}
+	if act := dm.Status()[0].Commit; act != remote.head(t) {
+		t.Errorf("expected commit %s, got %s", remote.head(t), act)
+	}
+
+	// reinstall runs the installation regardless
+	if err := dm.Install(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	if runs() != 3 {
+		t.Errorf("expected install script to run on reinstall, ran %d times", runs())
+	}
+
+	logContent, err := dm.Log()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(logContent, "executing installation script candidate") {
+		t.Errorf("expected installation in log, got:\n%s", logContent)
+	}
+}
+
+func TestDotfilesManager_InstallPinned(t *testing.T) {
+	log.Log.Logger.SetLevel(logrus.FatalLevel)
+
+	remote := newTestDotfilesRemote(t)
+	remote.commit(t, ".bashrc", "v1", 0644)
+	remote.git(t, "tag", "v1")
+	pinned := remote.head(t)
+	remote.commit(t, ".bashrc", "v2", 0644)
+
+	dm, home := newTestDotfilesManager(t, &dotfilesSource{Repository: remote.url, Ref: "v1"})
+	if err := dm.Install(context.Background(), false); err != nil {
+		t.Fatal(err)
+	}
+	if act := dm.Status()[0].Commit; act != pinned {
+		t.Errorf("expected pinned commit %s, got %s", pinned, act)
+	}
+	content, err := os.ReadFile(filepath.Join(home, ".bashrc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "v1" {
+		t.Errorf("expected pinned content, got %q", content)
+	}
+}
+
+func TestDotfilesManager_InstallLayered(t *testing.T) {
+	log.Log.Logger.SetLevel(logrus.FatalLevel)
+
+	team := newTestDotfilesRemote(t)
+	team.commit(t, ".bashrc", "team", 0644)
+	team.commit(t, ".vimrc", "team", 0644)
+	personal := newTestDotfilesRemote(t)
+	personal.commit(t, ".bashrc", "personal", 0644)
+	personal.commit(t, ".profile", "personal", 0644)
+	broken := newTestDotfilesRemote(t)
+
+	dm, home := newTestDotfilesManager(t,
+		&dotfilesSource{Repository: team.url},
+		&dotfilesSource{Repository: broken.url + "-missing"},
+		&dotfilesSource{Repository: personal.url},
+	)
+	err := os.WriteFile(filepath.Join(home, ".profile"), []byte("user"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dm.Install(context.Background(), false); err == nil {
+		t.Error("expected installation of missing repository to fail")
+	}
+
+	for fn, expected := range map[string]string{
+		".bashrc":  "personal",
+		".vimrc":   "team",
+		".profile": "user",
+	} {
+		content, err := os.ReadFile(filepath.Join(home, fn))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != expected {
+			t.Errorf("expected %s to contain %q, got %q", fn, expected, content)
+		}
+	}
+
+	var states []api.DotfilesSourceStatus_State
+	for _, s := range dm.Status() {
+		states = append(states, s.State)
+	}
+	expected := []api.DotfilesSourceStatus_State{api.DotfilesSourceStatus_installed, api.DotfilesSourceStatus_failed, api.DotfilesSourceStatus_installed}
+	if diff := cmp.Diff(expected, states); diff != "" {
+		t.Errorf("unexpected states (-want +got):\n%s", diff)
+	}
+}
+
+func TestDotfilesManager_InstallOnStartup(t *testing.T) {
+	log.Log.Logger.SetLevel(logrus.FatalLevel)
+
+	remote := newTestDotfilesRemote(t)
+	remote.commit(t, ".bashrc", "v1", 0644)
+
+	dm, _ := newTestDotfilesManager(t, &dotfilesSource{Repository: remote.url})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := dm.Install(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	installed := remote.head(t)
+	remote.commit(t, ".bashrc", "v2", 0644)
+
+	// existing repositories are not fetched before the IDE starts
+	if err := dm.install(ctx, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if act := dm.Status()[0].Commit; act != installed {
+		t.Errorf("expected commit %s before the update, got %s", installed, act)
+	}
+
+	dm.InstallOnStartup(ctx)
+	deadline := time.Now().Add(10 * time.Second)
+	for dm.Status()[0].Commit != remote.head(t) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected dotfiles to be updated in the background, got commit %s", dm.Status()[0].Commit)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// wait for the background installation to finish
+	dm.installMu.Lock()
+	defer dm.installMu.Unlock()
+}
+
+func TestRunWithTimeout(t *testing.T) {
+	// the background process keeps stdout open, such that waiting only returns once it is killed as well
+	cmd := exec.Command("/bin/sh", "-c", "sleep 30 & wait")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	start := time.Now()
+	err := runWithTimeout(context.Background(), 100*time.Millisecond, cmd)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("expected command to be killed, took %s", d)
+	}
+}
+
+type testDotfilesRemote struct {
+	dir string
+	url string
+}
+
+func newTestDotfilesRemote(t *testing.T) *testDotfilesRemote {
+	dir := t.TempDir()
+	r := &testDotfilesRemote{dir: dir, url: "file://" + dir}
+	r.git(t, "init", "-q", "-b", "main")
+	return r
+}
+
+func (r *testDotfilesRemote) git(t *testing.T, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@gitpod.io"}, args...)...)
+	cmd.Dir = r.dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v: %s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func (r *testDotfilesRemote) commit(t *testing.T, fn, content string, mode os.FileMode) {
+	t.Helper()
+	err := os.WriteFile(filepath.Join(r.dir, fn), []byte(content), mode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.git(t, "add", fn)
+	r.git(t, "commit", "-q", "-m", "update "+fn)
+}
+
+func (r *testDotfilesRemote) head(t *testing.T) string {
+	return r.git(t, "rev-parse", "HEAD")
+}
+
+func newTestDotfilesManager(t *testing.T, sources ...*dotfilesSource) (*dotfilesManager, string) {
+	home := t.TempDir()
+	for i, src := range sources {
+		src.Location = filepath.Join(home, ".dotfiles.d", string(rune('a'+i)))
+	}
+	dm := newDotfilesManagerWithSources(home, sources, nil)
+	dm.chown = false
+	dm.newCommand = func(name string, args ...string) *exec.Cmd {
+		cmd := exec.Command(name, args...)
+		cmd.Env = append(os.Environ(), "HOME_DIR="+home)
+		return cmd
+	}
+	return dm, home
+}
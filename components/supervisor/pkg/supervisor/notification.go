@@ -24,12 +24,28 @@ const (
 	SubscriberMaxSubscriptions        = 10
 )
 
+// NotificationServiceOption customizes the notification service.
+type NotificationServiceOption func(*NotificationService)
+
+// WithNotificationSinks forwards notifications to the sinks configured in cfg, which must be valid.
+func WithNotificationSinks(cfg *NotificationSinksConfig, workspaceID, workspaceURL string) NotificationServiceOption {
+	return func(srv *NotificationService) {
+		srv.forwarder = newNotificationForwarder(cfg, workspaceID, workspaceURL)
+	}
+}
+
 // NewNotificationService creates a new notification service.
-func NewNotificationService() *NotificationService {
-	return &NotificationService{
+func NewNotificationService(opts ...NotificationServiceOption) *NotificationService {
+	srv := &NotificationService{
 		subscriptions:        make(map[uint64]*subscription),
 		pendingNotifications: make(map[uint64]*pendingNotification),
+		// by default notifications are only forwarded to sink subscribers if no IDE is attached
+		forwarder: newNotificationForwarder(&NotificationSinksConfig{}, "", ""),
+	}
+	for _, o := range opts {
+		o(srv)
 	}
+	return srv
 }
 
 // NotificationService implements the notification service API.
@@ -39,6 +55,7 @@ type NotificationService struct {
 	subscriptions        map[uint64]*subscription
 	nextNotificationID   uint64
 	pendingNotifications map[uint64]*pendingNotification
+	forwarder            *notificationForwarder
 
 	api.UnimplementedNotificationServiceServer
 }
@@ -58,7 +75,9 @@ func (pending *pendingNotification) close() {
 }
 
 type subscription struct {
-	id      uint64
+	id uint64
+	// sink subscribers only receive forwarded notifications
+	sink    bool
 	channel chan *api.SubscribeResponse
 	once    sync.Once
 	closed  bool
@@ -124,7 +143,20 @@ func (srv *NotificationService) notifySubscribers(req *api.NotifyRequest) *pendi
 		}
 	)
 	srv.nextNotificationID++
+	var ideSubscribers int
 	for _, subscription := range srv.subscriptions {
+		if !subscription.sink {
+			ideSubscribers++
+		}
+	}
+	forward := srv.forwarder.shouldForward(req, ideSubscribers)
+	if forward {
+		srv.forwarder.forward(req)
+	}
+	for _, subscription := range srv.subscriptions {
+		if subscription.sink && !forward {
+			continue
+		}
 		select {
 		case subscription.channel <- message:
 			// all good
@@ -186,11 +218,14 @@ func (srv *NotificationService) subscribeLocked(req *api.SubscribeRequest, resp
 		capacity = SubscriberMaxPendingNotifications
 	}
 	channel := make(chan *api.SubscribeResponse, capacity)
-	log.WithField("pending", len(srv.pendingNotifications)).Info("sending pending notifications")
-	for id, pending := range srv.pendingNotifications {
-		channel <- pending.message
-		if len(pending.message.Request.Actions) == 0 {
-			delete(srv.pendingNotifications, id)
+	// sinks don't receive pending notifications, such that they are still delivered to the next IDE
+	if !req.Sink {
+		log.WithField("pending", len(srv.pendingNotifications)).Info("sending pending notifications")
+		for id, pending := range srv.pendingNotifications {
+			channel <- pending.message
+			if len(pending.message.Request.Actions) == 0 {
+				delete(srv.pendingNotifications, id)
+			}
 		}
 	}
 	id := srv.nextSubscriptionID
@@ -199,6 +234,7 @@ func (srv *NotificationService) subscribeLocked(req *api.SubscribeRequest, resp
 	subscription := &subscription{
 		channel: channel,
 		id:      id,
+		sink:    req.Sink,
 		cancel:  cancel,
 	}
 	srv.subscriptions[id] = subscription
@@ -0,0 +1,245 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+const (
+	// NotificationForwardUnattended forwards notifications only if no IDE is subscribed to them.
+	NotificationForwardUnattended = "unattended"
+	// NotificationForwardAlways forwards all notifications.
+	NotificationForwardAlways = "always"
+
+	notificationSinkTimeout = 10 * time.Second
+)
+
+// NotificationSinksConfig configures where notifications are forwarded to in addition to the IDE.
+type NotificationSinksConfig struct {
+	// Forward is either "unattended" (default) or "always".
+	Forward string `json:"forward,omitempty"`
+
+	// MinLevel is the minimum level of forwarded notifications, i.e. "error", "warning" or "info" (default).
+	MinLevel string `json:"minLevel,omitempty"`
+
+	Webhook *WebhookNotificationSinkConfig `json:"webhook,omitempty"`
+	SMTP    *SMTPNotificationSinkConfig    `json:"smtp,omitempty"`
+}
+
+// WebhookNotificationSinkConfig configures a webhook to which notifications are posted as JSON.
+type WebhookNotificationSinkConfig struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// SMTPNotificationSinkConfig configures an SMTP relay through which notifications are sent as email.
+type SMTPNotificationSinkConfig struct {
+	// Address of the SMTP relay, e.g. localhost:25
+	Address string   `json:"address"`
+	From    string   `json:"from"`
+	To      []string `json:"to"`
+}
+
+// Validate validates the notification sinks configuration.
+func (c *NotificationSinksConfig) Validate() error {
+	if c.Forward != "" && c.Forward != NotificationForwardUnattended && c.Forward != NotificationForwardAlways {
+		return xerrors.Errorf("forward must be either %s or %s", NotificationForwardUnattended, NotificationForwardAlways)
+	}
+	if _, err := parseNotificationLevel(c.MinLevel); err != nil {
+		return err
+	}
+	if c.Webhook != nil && !strings.HasPrefix(c.Webhook.URL, "http://") && !strings.HasPrefix(c.Webhook.URL, "https://") {
+		return xerrors.Errorf("webhook URL must be an http(s) URL")
+	}
+	if c.SMTP != nil && (c.SMTP.Address == "" || c.SMTP.From == "" || len(c.SMTP.To) == 0) {
+		return xerrors.Errorf("smtp requires address, from and to")
+	}
+	return nil
+}
+
+func parseNotificationLevel(level string) (api.NotifyRequest_Level, error) {
+	if level == "" {
+		return api.NotifyRequest_INFO, nil
+	}
+	lvl, ok := api.NotifyRequest_Level_value[strings.ToUpper(level)]
+	if !ok {
+		return 0, xerrors.Errorf("unknown notification level: %s", level)
+	}
+	return api.NotifyRequest_Level(lvl), nil
+}
+
+// ForwardedNotification is a notification which is forwarded to a sink.
+type ForwardedNotification struct {
+	WorkspaceID  string    `json:"workspaceId"`
+	WorkspaceURL string    `json:"workspaceUrl"`
+	Level        string    `json:"level"`
+	Message      string    `json:"message"`
+	Actions      []string  `json:"actions,omitempty"`
+	Time         time.Time `json:"time"`
+}
+
+// NotificationSink delivers notifications to the user outside of the IDE.
+type NotificationSink interface {
+	Name() string
+	Send(ctx context.Context, n *ForwardedNotification) error
+}
+
+type webhookNotificationSink struct {
+	cfg    *WebhookNotificationSinkConfig
+	client *http.Client
+}
+
+func (s *webhookNotificationSink) Name() string {
+	return "webhook"
+}
+
+func (s *webhookNotificationSink) Send(ctx context.Context, n *ForwardedNotification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return xerrors.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+type smtpNotificationSink struct {
+	cfg      *SMTPNotificationSinkConfig
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func (s *smtpNotificationSink) Name() string {
+	return "smtp"
+}
+
+func (s *smtpNotificationSink) Send(ctx context.Context, n *ForwardedNotification) error {
+	// the message must not end the header, hence the subject is limited to its first line and encoded if needed
+	subject := mime.QEncoding.Encode("utf-8", fmt.Sprintf("[%s] %s", n.WorkspaceID, firstLine(n.Message)))
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.cfg.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", n.Time.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s\r\n\r\n", crlfReplacer.Replace(n.Message))
+	fmt.Fprintf(&msg, "Level: %s\r\nWorkspace: %s\r\n", n.Level, n.WorkspaceURL)
+
+	// the SMTP relay is expected to run locally, hence we neither authenticate nor require TLS
+	return s.sendMail(s.cfg.Address, nil, s.cfg.From, s.cfg.To, msg.Bytes())
+}
+
+// crlfReplacer turns all line endings into CRLF, as required by SMTP.
+var crlfReplacer = strings.NewReplacer("\r\n", "\r\n", "\r", "\r\n", "\n", "\r\n")
+
+// firstLine returns s up to the first line break, i.e. without any CR or LF.
+func firstLine(s string) string {
+	if idx := strings.IndexAny(s, "\r\n"); idx >= 0 {
+		return s[:idx]
+	}
+	return s
+}
+
+// newNotificationSinks creates the sinks configured in cfg.
+func newNotificationSinks(cfg *NotificationSinksConfig) []NotificationSink {
+	var sinks []NotificationSink
+	if cfg.Webhook != nil {
+		sinks = append(sinks, &webhookNotificationSink{
+			cfg:    cfg.Webhook,
+			client: &http.Client{Timeout: notificationSinkTimeout},
+		})
+	}
+	if cfg.SMTP != nil {
+		sinks = append(sinks, &smtpNotificationSink{
+			cfg:      cfg.SMTP,
+			sendMail: smtp.SendMail,
+		})
+	}
+	return sinks
+}
+
+// notificationForwarder decides which notifications are forwarded and delivers them to the sinks.
+type notificationForwarder struct {
+	always       bool
+	minLevel     api.NotifyRequest_Level
+	sinks        []NotificationSink
+	workspaceID  string
+	workspaceURL string
+}
+
+func newNotificationForwarder(cfg *NotificationSinksConfig, workspaceID, workspaceURL string) *notificationForwarder {
+	minLevel, err := parseNotificationLevel(cfg.MinLevel)
+	if err != nil {
+		minLevel = api.NotifyRequest_INFO
+	}
+	return &notificationForwarder{
+		always:       cfg.Forward == NotificationForwardAlways,
+		minLevel:     minLevel,
+		sinks:        newNotificationSinks(cfg),
+		workspaceID:  workspaceID,
+		workspaceURL: workspaceURL,
+	}
+}
+
+// shouldForward returns true if a notification should be forwarded given the number of IDE subscribers.
+func (f *notificationForwarder) shouldForward(req *api.NotifyRequest, ideSubscribers int) bool {
+	// lower levels are more severe
+	if req.Level > f.minLevel {
+		return false
+	}
+	return f.always || ideSubscribers == 0
+}
+
+// forward delivers the notification to all sinks asynchronously.
+func (f *notificationForwarder) forward(req *api.NotifyRequest) {
+	if len(f.sinks) == 0 {
+		return
+	}
+	n := &ForwardedNotification{
+		WorkspaceID:  f.workspaceID,
+		WorkspaceURL: f.workspaceURL,
+		Level:        strings.ToLower(req.Level.String()),
+		Message:      req.Message,
+		Actions:      req.Actions,
+		Time:         time.Now(),
+	}
+	for _, sink := range f.sinks {
+		go func(sink NotificationSink) {
+			ctx, cancel := context.WithTimeout(context.Background(), notificationSinkTimeout)
+			defer cancel()
+			err := sink.Send(ctx, n)
+			if err != nil {
+				log.WithError(err).WithField("sink", sink.Name()).Warn("cannot forward notification")
+			}
+		}(sink)
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestNotificationSinksConfigValidate(t *testing.T) {
+	tests := []struct {
+		Desc   string
+		Config NotificationSinksConfig
+		Valid  bool
+	}{
+		{Desc: "empty", Valid: true},
+		{Desc: "always", Config: NotificationSinksConfig{Forward: NotificationForwardAlways, MinLevel: "warning"}, Valid: true},
+		{Desc: "invalid forward", Config: NotificationSinksConfig{Forward: "sometimes"}},
+		{Desc: "invalid level", Config: NotificationSinksConfig{MinLevel: "debug"}},
+		{Desc: "webhook", Config: NotificationSinksConfig{Webhook: &WebhookNotificationSinkConfig{URL: "https://example.com/hook"}}, Valid: true},
+		{Desc: "invalid webhook", Config: NotificationSinksConfig{Webhook: &WebhookNotificationSinkConfig{URL: "file:///etc/passwd"}}},
+		{Desc: "smtp", Config: NotificationSinksConfig{SMTP: &SMTPNotificationSinkConfig{Address: "localhost:25", From: "gitpod@localhost", To: []string{"foo@example.com"}}}, Valid: true},
+		{Desc: "smtp without recipients", Config: NotificationSinksConfig{SMTP: &SMTPNotificationSinkConfig{Address: "localhost:25", From: "gitpod@localhost"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			err := test.Config.Validate()
+			if test.Valid && err != nil {
+				t.Errorf("expected config to be valid, got %v", err)
+			}
+			if !test.Valid && err == nil {
+				t.Error("expected config to be invalid")
+			}
+		})
+	}
+}
+
+type testNotificationSink struct {
+	notifications chan *ForwardedNotification
+}
+
+func (s *testNotificationSink) Name() string { return "test" }
+
+func (s *testNotificationSink) Send(ctx context.Context, n *ForwardedNotification) error {
+	s.notifications <- n
+	return nil
+}
+
+func TestNotificationForwarding(t *testing.T) {
+	tests := []struct {
+		Desc          string
+		Config        NotificationSinksConfig
+		IDESubscriber bool
+		Level         api.NotifyRequest_Level
+		Forwarded     bool
+	}{
+		{Desc: "unattended without IDE", Forwarded: true},
+		{Desc: "unattended with IDE", IDESubscriber: true},
+		{Desc: "always with IDE", Config: NotificationSinksConfig{Forward: NotificationForwardAlways}, IDESubscriber: true, Forwarded: true},
+		{Desc: "level below minimum", Config: NotificationSinksConfig{MinLevel: "warning"}, Level: api.NotifyRequest_INFO},
+		{Desc: "level above minimum", Config: NotificationSinksConfig{MinLevel: "warning"}, Level: api.NotifyRequest_ERROR, Forwarded: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			sink := &testNotificationSink{notifications: make(chan *ForwardedNotification, 1)}
+			srv := NewNotificationService(WithNotificationSinks(&test.Config, "ws-1", "https://ws-1.gitpod.io"))
+			srv.forwarder.sinks = []NotificationSink{sink}
+
+			sinkSubscriber := NewSubscribeServer()
+			defer sinkSubscriber.cancel()
+			go func() { _ = srv.Subscribe(&api.SubscribeRequest{Sink: true}, sinkSubscriber) }()
+			expectedSubscriptions := 1
+			if test.IDESubscriber {
+				ideSubscriber := NewSubscribeServer()
+				defer ideSubscriber.cancel()
+				go func() { _ = srv.Subscribe(&api.SubscribeRequest{}, ideSubscriber) }()
+				expectedSubscriptions++
+			}
+			waitForSubscriptions(t, srv, expectedSubscriptions)
+
+			_, err := srv.Notify(context.Background(), &api.NotifyRequest{Level: test.Level, Message: "build finished"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case n := <-sink.notifications:
+				if !test.Forwarded {
+					t.Fatalf("unexpected forwarded notification: %v", n)
+				}
+				expected := &ForwardedNotification{
+					WorkspaceID:  "ws-1",
+					WorkspaceURL: "https://ws-1.gitpod.io",
+					Level:        strings.ToLower(test.Level.String()),
+					Message:      "build finished",
+				}
+				if diff := cmp.Diff(expected, n, cmpopts.IgnoreFields(ForwardedNotification{}, "Time")); diff != "" {
+					t.Errorf("unexpected notification (-want +got):\n%s", diff)
+				}
+			case <-time.After(100 * time.Millisecond):
+				if test.Forwarded {
+					t.Fatal("notification was not forwarded to sink")
+				}
+			}
+
+			select {
+			case resp := <-sinkSubscriber.resps:
+				if !test.Forwarded {
+					t.Fatalf("unexpected notification for sink subscriber: %v", resp)
+				}
+			case <-time.After(100 * time.Millisecond):
+				if test.Forwarded {
+					t.Fatal("notification was not sent to sink subscriber")
+				}
+			}
+		})
+	}
+}
+
+func TestNotificationForwardingPending(t *testing.T) {
+	srv := NewNotificationService()
+	_, err := srv.Notify(context.Background(), &api.NotifyRequest{Message: "fired before subscription"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sinkSubscriber := NewSubscribeServer()
+	defer sinkSubscriber.cancel()
+	go func() { _ = srv.Subscribe(&api.SubscribeRequest{Sink: true}, sinkSubscriber) }()
+	waitForSubscriptions(t, srv, 1)
+
+	select {
+	case resp := <-sinkSubscriber.resps:
+		t.Fatalf("sink subscriber must not receive pending notifications, got %v", resp)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	srv.mutex.Lock()
+	pending := len(srv.pendingNotifications)
+	srv.mutex.Unlock()
+	if pending != 1 {
+		t.Errorf("expected notification to remain pending for the IDE, got %d pending notifications", pending)
+	}
+}
+
+func TestWebhookNotificationSink(t *testing.T) {
+	received := make(chan *ForwardedNotification, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var n ForwardedNotification
+		err := json.NewDecoder(r.Body).Decode(&n)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- &n
+	}))
+	defer srv.Close()
+
+	n := &ForwardedNotification{WorkspaceID: "ws-1", Level: "error", Message: "build failed", Time: time.Now().UTC().Truncate(time.Second)}
+	sink := newNotificationSinks(&NotificationSinksConfig{Webhook: &WebhookNotificationSinkConfig{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer secret"}}})[0]
+	err := sink.Send(context.Background(), n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(n, <-received); diff != "" {
+		t.Errorf("unexpected notification (-want +got):\n%s", diff)
+	}
+
+	sink = newNotificationSinks(&NotificationSinksConfig{Webhook: &WebhookNotificationSinkConfig{URL: srv.URL}})[0]
+	err = sink.Send(context.Background(), n)
+	if err == nil {
+		t.Error("expected error for unauthorized webhook request")
+	}
+}
+
+func TestSMTPNotificationSink(t *testing.T) {
+	var (
+		addr, from string
+		to         []string
+		msg        string
+	)
+	sink := &smtpNotificationSink{
+		cfg: &SMTPNotificationSinkConfig{Address: "localhost:25", From: "gitpod@localhost", To: []string{"foo@example.com"}},
+		sendMail: func(a string, _ smtp.Auth, f string, t []string, m []byte) error {
+			addr, from, to, msg = a, f, t, string(m)
+			return nil
+		},
+	}
+	err := sink.Send(context.Background(), &ForwardedNotification{WorkspaceID: "ws-1", WorkspaceURL: "https://ws-1.gitpod.io", Level: "info", Message: "tests passed\nall 42 of them"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr != "localhost:25" || from != "gitpod@localhost" || len(to) != 1 || to[0] != "foo@example.com" {
+		t.Errorf("unexpected envelope: %s %s %v", addr, from, to)
+	}
+	for _, expected := range []string{"Subject: [ws-1] tests passed\r\n", "tests passed\r\nall 42 of them", "Workspace: https://ws-1.gitpod.io"} {
+		if !strings.Contains(msg, expected) {
+			t.Errorf("expected message to contain %q, got:\n%s", expected, msg)
+		}
+	}
+
+	for _, message := range []string{"tests passed\rBcc: bar@example.com", "tests passed\r\nBcc: bar@example.com"} {
+		err = sink.Send(context.Background(), &ForwardedNotification{WorkspaceID: "ws-1", Message: message})
+		if err != nil {
+			t.Fatal(err)
+		}
+		header := msg[:strings.Index(msg, "\r\n\r\n")]
+		if strings.Contains(header, "Bcc") || !strings.Contains(header, "Subject: [ws-1] tests passed\r\n") {
+			t.Errorf("message %q must not add headers, got:\n%s", message, header)
+		}
+		if strings.Contains(strings.ReplaceAll(msg, "\r\n", ""), "\r") {
+			t.Errorf("message %q must only contain CRLF line endings, got:\n%q", message, msg)
+		}
+	}
+}
+
+func waitForSubscriptions(t *testing.T, srv *NotificationService, count int) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		srv.mutex.Lock()
+		n := len(srv.subscriptions)
+		srv.mutex.Unlock()
+		if n == count {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected %d subscriptions", count)
+}
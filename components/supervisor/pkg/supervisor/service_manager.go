@@ -0,0 +1,667 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/content-service/pkg/logs"
+	gitpod "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/config"
+)
+
+const (
+	restartPolicyAlways    = "always"
+	restartPolicyOnFailure = "on-failure"
+	restartPolicyNever     = "never"
+
+	defaultBackoffInitial = 1 * time.Second
+	defaultBackoffMax     = 1 * time.Minute
+	// a service which ran for at least backoffResetDuration is considered stable, i.e. its backoff starts over.
+	backoffResetDuration = 1 * time.Minute
+
+	defaultProbeInterval         = 10 * time.Second
+	defaultProbeTimeout          = 5 * time.Second
+	defaultProbeFailureThreshold = 3
+
+	// serviceStopTimeout is the time a service gets to terminate after SIGTERM before it is killed.
+	serviceStopTimeout = 10 * time.Second
+	// maxServiceLogSize is the size at which a service log file is rotated.
+	maxServiceLogSize = 10 * 1024 * 1024
+)
+
+var serviceNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// ErrServiceNotFound is returned when a service is not configured.
+var ErrServiceNotFound = errors.New("service not found")
+
+type servicesSubscription struct {
+	updates chan []*api.ServiceStatus
+	Close   func() error
+}
+
+func (sub *servicesSubscription) Updates() <-chan []*api.ServiceStatus {
+	return sub.updates
+}
+
+// servicesManager runs the services configured in .gitpod.yml as managed processes.
+type servicesManager struct {
+	config      config.ConfigInterface
+	logLocation string
+	// newCommand creates the command which runs a service or an exec probe.
+	newCommand func(command string, env map[string]string) *exec.Cmd
+
+	mu            sync.RWMutex
+	services      []*managedService
+	subscriptions map[*servicesSubscription]struct{}
+}
+
+func newServicesManager(config config.ConfigInterface) *servicesManager {
+	return &servicesManager{
+		config:        config,
+		logLocation:   filepath.Join(logs.TerminalStoreLocation, "services"),
+		newCommand:    newServiceCommand,
+		subscriptions: make(map[*servicesSubscription]struct{}),
+	}
+}
+
+func newServiceCommand(command string, env map[string]string) *exec.Cmd {
+	cmd := runAsGitpodUser(exec.Command("/bin/bash", "-c", command))
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	return cmd
+}
+
+type managedService struct {
+	config  *gitpod.ServicesItems
+	logFile string
+
+	// state is guarded by the mutex of the services manager
+	state    api.ServiceStatus_State
+	ready    bool
+	healthy  bool
+	restarts uint32
+	pid      int64
+	exitCode int32
+	message  string
+
+	restart chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// Run starts and stops services whenever the config changes. Services are stopped when the context is canceled.
+func (sm *servicesManager) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	defer log.Debug("servicesManager shutdown")
+
+	configs := sm.config.Observe(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			sm.update(ctx, nil)
+			return
+		case cfg, ok := <-configs:
+			if !ok {
+				sm.update(ctx, nil)
+				return
+			}
+			var services []*gitpod.ServicesItems
+			if cfg != nil {
+				services = cfg.Services
+			}
+			sm.update(ctx, services)
+		}
+	}
+}
+
+// update stops services which were removed or changed, and starts services which were added or changed.
+func (sm *servicesManager) update(ctx context.Context, configs []*gitpod.ServicesItems) {
+	desired := make([]*gitpod.ServicesItems, 0, len(configs))
+	names := make(map[string]struct{}, len(configs))
+	for _, c := range configs {
+		if c == nil {
+			continue
+		}
+		if err := validateServiceConfig(c); err != nil {
+			log.WithError(err).WithField("service", c.Name).Error("invalid service config")
+			continue
+		}
+		if _, exists := names[c.Name]; exists {
+			log.WithField("service", c.Name).Error("duplicate service name")
+			continue
+		}
+		names[c.Name] = struct{}{}
+		desired = append(desired, c)
+	}
+
+	sm.mu.RLock()
+	current := make(map[string]*managedService, len(sm.services))
+	for _, s := range sm.services {
+		current[s.config.Name] = s
+	}
+	sm.mu.RUnlock()
+
+	var (
+		services []*managedService
+		stopped  []*managedService
+		started  []*managedService
+	)
+	for _, c := range desired {
+		if s, ok := current[c.Name]; ok && reflect.DeepEqual(s.config, c) {
+			services = append(services, s)
+			delete(current, c.Name)
+			continue
+		}
+		s := &managedService{
+			config:  c,
+			logFile: filepath.Join(sm.logLocation, c.Name+".log"),
+			healthy: true,
+			restart: make(chan struct{}, 1),
+			done:    make(chan struct{}),
+		}
+		services = append(services, s)
+		started = append(started, s)
+	}
+	for _, s := range current {
+		stopped = append(stopped, s)
+	}
+	if len(stopped) == 0 && len(started) == 0 {
+		return
+	}
+
+	for _, s := range stopped {
+		log.WithField("service", s.config.Name).Info("stopping service")
+		s.cancel()
+		<-s.done
+	}
+
+	sm.updateState(func() bool {
+		sm.services = services
+		return true
+	})
+
+	for _, s := range started {
+		var serviceCtx context.Context
+		serviceCtx, s.cancel = context.WithCancel(ctx)
+		go sm.run(serviceCtx, s)
+	}
+}
+
+func validateServiceConfig(c *gitpod.ServicesItems) error {
+	if !serviceNameRegexp.MatchString(c.Name) {
+		return fmt.Errorf("invalid name %q", c.Name)
+	}
+	if c.Command == "" {
+		return fmt.Errorf("command is required")
+	}
+	switch c.RestartPolicy {
+	case "", restartPolicyAlways, restartPolicyOnFailure, restartPolicyNever:
+	default:
+		return fmt.Errorf("unknown restart policy %q", c.RestartPolicy)
+	}
+	return nil
+}
+
+// run runs the service process until the context is canceled, and restarts it according to its restart policy.
+func (sm *servicesManager) run(ctx context.Context, s *managedService) {
+	defer close(s.done)
+
+	serviceLog := log.WithField("service", s.config.Name)
+	var failures int
+	for {
+		started := time.Now()
+		failed, err := sm.runOnce(ctx, s)
+		if ctx.Err() != nil {
+			sm.setState(func() {
+				s.state = api.ServiceStatus_stopped
+				s.ready = false
+				s.pid = 0
+			})
+			return
+		}
+		if errors.Is(err, errServiceRestartRequested) {
+			serviceLog.Info("restarting service")
+			failures = 0
+			sm.setState(func() { s.restarts++ })
+			continue
+		}
+		if err != nil {
+			serviceLog.WithError(err).Warn("service stopped")
+		}
+
+		if time.Since(started) >= backoffResetDuration {
+			failures = 0
+		}
+		if !shouldRestartService(s.config.RestartPolicy, failed) {
+			sm.setState(func() { s.state = api.ServiceStatus_stopped })
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.restart:
+				failures = 0
+				sm.setState(func() { s.restarts++ })
+				continue
+			}
+		}
+
+		failures++
+		delay := serviceBackoff(s.config.Backoff, failures)
+		serviceLog.WithField("delay", delay.String()).Info("restarting service after backoff")
+		sm.setState(func() { s.state = api.ServiceStatus_backoff })
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			sm.setState(func() { s.state = api.ServiceStatus_stopped })
+			return
+		case <-s.restart:
+			timer.Stop()
+			failures = 0
+		case <-timer.C:
+		}
+		sm.setState(func() { s.restarts++ })
+	}
+}
+
+var errServiceRestartRequested = errors.New("restart requested")
+
+// runOnce starts the service process and waits until it exits. It returns true if the service failed,
+// i.e. exited with an error or was killed because its liveness probe failed.
+func (sm *servicesManager) runOnce(ctx context.Context, s *managedService) (failed bool, err error) {
+	out, err := openServiceLog(s.logFile)
+	if err != nil {
+		log.WithError(err).WithField("service", s.config.Name).Warn("cannot open service log file")
+	}
+	if out != nil {
+		defer out.Close()
+		fmt.Fprintf(out, "--- %s: starting %s ---\n", time.Now().Format(time.RFC3339), s.config.Name)
+	}
+
+	cmd := sm.newCommand(s.config.Command, s.config.Env)
+	if out != nil {
+		cmd.Stdout = out
+		cmd.Stderr = out
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	// run the service in its own process group, such that all of its processes can be stopped
+	cmd.SysProcAttr.Setpgid = true
+	if err := cmd.Start(); err != nil {
+		sm.setState(func() {
+			s.ready = false
+			s.pid = 0
+			s.exitCode = -1
+			s.message = fmt.Sprintf("cannot start service: %v", err)
+		})
+		return true, err
+	}
+
+	sm.setState(func() {
+		s.pid = int64(cmd.Process.Pid)
+		s.healthy = true
+		s.message = ""
+		if s.config.ReadinessProbe == nil {
+			s.state = api.ServiceStatus_running
+			s.ready = true
+		} else {
+			s.state = api.ServiceStatus_starting
+			s.ready = false
+		}
+	})
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	probeCtx, cancelProbes := context.WithCancel(ctx)
+	defer cancelProbes()
+	livenessFailed := make(chan string, 1)
+	if p := s.config.ReadinessProbe; p != nil {
+		go sm.watchProbe(probeCtx, p, true, func(ok bool, msg string) {
+			sm.setState(func() {
+				s.ready = ok
+				s.message = msg
+				if ok {
+					s.state = api.ServiceStatus_running
+				}
+			})
+		})
+	}
+	if p := s.config.LivenessProbe; p != nil {
+		go sm.watchProbe(probeCtx, p, false, func(ok bool, msg string) {
+			if ok {
+				return
+			}
+			sm.setState(func() {
+				s.healthy = false
+				s.message = msg
+			})
+			select {
+			case livenessFailed <- msg:
+			default:
+			}
+		})
+	}
+
+	var waitErr error
+	select {
+	case waitErr = <-exited:
+	case <-ctx.Done():
+		stopServiceProcess(cmd, exited)
+		return false, ctx.Err()
+	case <-s.restart:
+		stopServiceProcess(cmd, exited)
+		return false, errServiceRestartRequested
+	case msg := <-livenessFailed:
+		log.WithField("service", s.config.Name).WithField("reason", msg).Warn("liveness probe failed, stopping service")
+		stopServiceProcess(cmd, exited)
+		failed = true
+		waitErr = fmt.Errorf("liveness probe failed: %s", msg)
+	}
+	cancelProbes()
+
+	exitCode := int32(-1)
+	if cmd.ProcessState != nil {
+		exitCode = int32(cmd.ProcessState.ExitCode())
+	}
+	failed = failed || waitErr != nil
+	sm.setState(func() {
+		s.ready = false
+		s.pid = 0
+		s.exitCode = exitCode
+		if waitErr != nil {
+			s.message = waitErr.Error()
+		}
+	})
+	if out != nil {
+		fmt.Fprintf(out, "--- %s: %s exited with code %d ---\n", time.Now().Format(time.RFC3339), s.config.Name, exitCode)
+	}
+	return failed, waitErr
+}
+
+// stopServiceProcess terminates the process group of the service, and kills it if it does not exit in time.
+func stopServiceProcess(cmd *exec.Cmd, exited <-chan error) {
+	pgid := -cmd.Process.Pid
+	_ = syscall.Kill(pgid, syscall.SIGTERM)
+	select {
+	case <-exited:
+	case <-time.After(serviceStopTimeout):
+		_ = syscall.Kill(pgid, syscall.SIGKILL)
+		<-exited
+	}
+	// make sure no children outlive the service's main process
+	_ = syscall.Kill(pgid, syscall.SIGKILL)
+}
+
+func shouldRestartService(policy string, failed bool) bool {
+	switch policy {
+	case restartPolicyAlways:
+		return true
+	case restartPolicyNever:
+		return false
+	default:
+		return failed
+	}
+}
+
+// serviceBackoff returns the delay before the restart after the given number of consecutive failures.
+func serviceBackoff(cfg *gitpod.Backoff, failures int) time.Duration {
+	initial, max := defaultBackoffInitial, defaultBackoffMax
+	if cfg != nil {
+		initial = parseDurationOrDefault(cfg.Initial, initial)
+		max = parseDurationOrDefault(cfg.Max, max)
+	}
+
+	delay := initial
+	for i := 1; i < failures && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+func parseDurationOrDefault(value string, defaultValue time.Duration) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.WithField("value", value).Warn("invalid duration, using default")
+		return defaultValue
+	}
+	return d
+}
+
+// watchProbe performs the probe periodically and reports when the probe succeeds, or fails failureThreshold
+// times in a row. Readiness probes are performed immediately, liveness probes only after the first interval.
+func (sm *servicesManager) watchProbe(ctx context.Context, p *gitpod.ServiceProbe, immediate bool, report func(ok bool, msg string)) {
+	interval := parseDurationOrDefault(p.Interval, defaultProbeInterval)
+	threshold := p.FailureThreshold
+	if threshold <= 0 {
+		threshold = defaultProbeFailureThreshold
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var (
+		failures  int
+		succeeded bool
+	)
+	if !immediate {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+	for {
+		err := sm.probe(ctx, p)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			failures = 0
+			if !succeeded {
+				succeeded = true
+				report(true, "")
+			}
+		} else {
+			failures++
+			if failures == threshold {
+				succeeded = false
+				report(false, fmt.Sprintf("probe failed %d times: %v", failures, err))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (sm *servicesManager) probe(ctx context.Context, p *gitpod.ServiceProbe) error {
+	ctx, cancel := context.WithTimeout(ctx, parseDurationOrDefault(p.Timeout, defaultProbeTimeout))
+	defer cancel()
+
+	switch {
+	case p.Tcp != 0:
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort("localhost", strconv.Itoa(p.Tcp)))
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	case p.Http != "":
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.Http, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		return nil
+	case p.Exec != "":
+		cmd := sm.newCommand(p.Exec, nil)
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+		exited := make(chan error, 1)
+		go func() {
+			exited <- cmd.Wait()
+		}()
+		select {
+		case err := <-exited:
+			return err
+		case <-ctx.Done():
+			_ = cmd.Process.Kill()
+			<-exited
+			return ctx.Err()
+		}
+	default:
+		return fmt.Errorf("probe has neither tcp, http nor exec configured")
+	}
+}
+
+// openServiceLog opens the log file of a service for appending, and rotates it if it has grown too large.
+func openServiceLog(fn string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+		return nil, err
+	}
+	if stat, err := os.Stat(fn); err == nil && stat.Size() > maxServiceLogSize {
+		_ = os.Rename(fn, fn+".1")
+	}
+	return os.OpenFile(fn, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+}
+
+// Restart restarts a service, regardless of its restart policy and backoff.
+func (sm *servicesManager) Restart(name string) error {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	for _, s := range sm.services {
+		if s.config.Name != name {
+			continue
+		}
+		select {
+		case s.restart <- struct{}{}:
+		default:
+			// a restart is pending already
+		}
+		return nil
+	}
+	return ErrServiceNotFound
+}
+
+func (sm *servicesManager) Status() []*api.ServiceStatus {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.getStatus()
+}
+
+// getStatus produces an API compatible service status list.
+// Callers are expected to hold mu.
+func (sm *servicesManager) getStatus() []*api.ServiceStatus {
+	status := make([]*api.ServiceStatus, 0, len(sm.services))
+	for _, s := range sm.services {
+		status = append(status, &api.ServiceStatus{
+			Name:     s.config.Name,
+			State:    s.state,
+			Ready:    s.ready,
+			Healthy:  s.healthy,
+			Restarts: s.restarts,
+			Pid:      s.pid,
+			ExitCode: s.exitCode,
+			Message:  s.message,
+			LogFile:  s.logFile,
+		})
+	}
+	return status
+}
+
+func (sm *servicesManager) Subscribe() *servicesSubscription {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if len(sm.subscriptions) > maxSubscriptions {
+		return nil
+	}
+
+	sub := &servicesSubscription{updates: make(chan []*api.ServiceStatus, 5)}
+	sub.Close = func() error {
+		sm.mu.Lock()
+		defer sm.mu.Unlock()
+
+		// We can safely close the channel here even though we're not the
+		// producer writing to it, because we're holding mu.
+		close(sub.updates)
+		delete(sm.subscriptions, sub)
+
+		return nil
+	}
+	sm.subscriptions[sub] = struct{}{}
+
+	// makes sure that no updates can happen between clients receiving an initial status and subscribing
+	sub.updates <- sm.getStatus()
+	return sub
+}
+
+func (sm *servicesManager) setState(update func()) {
+	sm.updateState(func() bool {
+		update()
+		return true
+	})
+}
+
+func (sm *servicesManager) updateState(doUpdate func() (changed bool)) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	changed := doUpdate()
+	if !changed {
+		return
+	}
+
+	updates := sm.getStatus()
+	for sub := range sm.subscriptions {
+		select {
+		case sub.updates <- updates:
+		case <-time.After(5 * time.Second):
+			// we hold mu already, hence cannot call sub.Close
+			log.Error("services subscription dropped out")
+			close(sub.updates)
+			delete(sm.subscriptions, sub)
+		}
+	}
+}
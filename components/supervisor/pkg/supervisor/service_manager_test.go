@@ -0,0 +1,291 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	gitpod "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestServiceBackoff(t *testing.T) {
+	tests := []struct {
+		Desc     string
+		Config   *gitpod.Backoff
+		Failures int
+		Expected time.Duration
+	}{
+		{Desc: "default first failure", Failures: 1, Expected: defaultBackoffInitial},
+		{Desc: "default doubles", Failures: 3, Expected: 4 * defaultBackoffInitial},
+		{Desc: "default is capped", Failures: 100, Expected: defaultBackoffMax},
+		{Desc: "custom", Config: &gitpod.Backoff{Initial: "100ms", Max: "1s"}, Failures: 2, Expected: 200 * time.Millisecond},
+		{Desc: "custom is capped", Config: &gitpod.Backoff{Initial: "100ms", Max: "1s"}, Failures: 5, Expected: time.Second},
+		{Desc: "invalid falls back to default", Config: &gitpod.Backoff{Initial: "soon"}, Failures: 1, Expected: defaultBackoffInitial},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act := serviceBackoff(test.Config, test.Failures)
+			if act != test.Expected {
+				t.Errorf("unexpected backoff: expected %s, got %s", test.Expected, act)
+			}
+		})
+	}
+}
+
+func TestShouldRestartService(t *testing.T) {
+	tests := []struct {
+		Policy   string
+		Failed   bool
+		Expected bool
+	}{
+		{Policy: "", Failed: true, Expected: true},
+		{Policy: "", Failed: false, Expected: false},
+		{Policy: restartPolicyOnFailure, Failed: true, Expected: true},
+		{Policy: restartPolicyOnFailure, Failed: false, Expected: false},
+		{Policy: restartPolicyAlways, Failed: false, Expected: true},
+		{Policy: restartPolicyNever, Failed: true, Expected: false},
+	}
+	for _, test := range tests {
+		act := shouldRestartService(test.Policy, test.Failed)
+		if act != test.Expected {
+			t.Errorf("unexpected result for policy %q and failed=%v: expected %v, got %v", test.Policy, test.Failed, test.Expected, act)
+		}
+	}
+}
+
+func TestServicesManager(t *testing.T) {
+	log.Log.Logger.SetLevel(logrus.FatalLevel)
+
+	fastBackoff := &gitpod.Backoff{Initial: "10ms", Max: "10ms"}
+	tcpPort := func(t *testing.T) int {
+		l, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { l.Close() })
+		return l.Addr().(*net.TCPAddr).Port
+	}
+
+	tests := []struct {
+		Desc      string
+		Config    func(t *testing.T) *gitpod.ServicesItems
+		Condition func(s *api.ServiceStatus) bool
+	}{
+		{
+			Desc: "restarts failed service",
+			Config: func(t *testing.T) *gitpod.ServicesItems {
+				return &gitpod.ServicesItems{Name: "db", Command: "exit 1", Backoff: fastBackoff}
+			},
+			Condition: func(s *api.ServiceStatus) bool { return s.Restarts >= 2 && s.ExitCode == 1 },
+		},
+		{
+			Desc: "does not restart successful service on failure policy",
+			Config: func(t *testing.T) *gitpod.ServicesItems {
+				return &gitpod.ServicesItems{Name: "db", Command: "exit 0", Backoff: fastBackoff}
+			},
+			Condition: func(s *api.ServiceStatus) bool {
+				return s.State == api.ServiceStatus_stopped && s.Restarts == 0 && s.ExitCode == 0
+			},
+		},
+		{
+			Desc: "does not restart with never policy",
+			Config: func(t *testing.T) *gitpod.ServicesItems {
+				return &gitpod.ServicesItems{Name: "db", Command: "exit 1", RestartPolicy: restartPolicyNever, Backoff: fastBackoff}
+			},
+			Condition: func(s *api.ServiceStatus) bool {
+				return s.State == api.ServiceStatus_stopped && s.Restarts == 0 && s.ExitCode == 1
+			},
+		},
+		{
+			Desc: "restarts successful service with always policy",
+			Config: func(t *testing.T) *gitpod.ServicesItems {
+				return &gitpod.ServicesItems{Name: "db", Command: "exit 0", RestartPolicy: restartPolicyAlways, Backoff: fastBackoff}
+			},
+			Condition: func(s *api.ServiceStatus) bool { return s.Restarts >= 2 },
+		},
+		{
+			Desc: "service without readiness probe is ready",
+			Config: func(t *testing.T) *gitpod.ServicesItems {
+				return &gitpod.ServicesItems{Name: "db", Command: "sleep 60"}
+			},
+			Condition: func(s *api.ServiceStatus) bool {
+				return s.State == api.ServiceStatus_running && s.Ready && s.Healthy && s.Pid != 0
+			},
+		},
+		{
+			Desc: "ready after tcp probe succeeds",
+			Config: func(t *testing.T) *gitpod.ServicesItems {
+				return &gitpod.ServicesItems{Name: "db", Command: "sleep 60", ReadinessProbe: &gitpod.ServiceProbe{Tcp: tcpPort(t), Interval: "10ms"}}
+			},
+			Condition: func(s *api.ServiceStatus) bool { return s.State == api.ServiceStatus_running && s.Ready },
+		},
+		{
+			Desc: "not ready while http probe fails",
+			Config: func(t *testing.T) *gitpod.ServicesItems {
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}))
+				t.Cleanup(srv.Close)
+				return &gitpod.ServicesItems{Name: "db", Command: "sleep 60", ReadinessProbe: &gitpod.ServiceProbe{Http: srv.URL, Interval: "10ms", FailureThreshold: 1}}
+			},
+			Condition: func(s *api.ServiceStatus) bool {
+				return s.State == api.ServiceStatus_starting && !s.Ready && strings.Contains(s.Message, "503")
+			},
+		},
+		{
+			Desc: "restarts service after liveness probe fails",
+			Config: func(t *testing.T) *gitpod.ServicesItems {
+				return &gitpod.ServicesItems{Name: "db", Command: "sleep 60", Backoff: fastBackoff, LivenessProbe: &gitpod.ServiceProbe{Exec: "exit 1", Interval: "10ms", FailureThreshold: 2}}
+			},
+			Condition: func(s *api.ServiceStatus) bool { return s.Restarts >= 1 },
+		},
+		{
+			Desc: "sets environment variables",
+			Config: func(t *testing.T) *gitpod.ServicesItems {
+				return &gitpod.ServicesItems{Name: "db", Command: `test "$FOO" = bar && exit 3`, RestartPolicy: restartPolicyNever, Env: map[string]string{"FOO": "bar"}}
+			},
+			Condition: func(s *api.ServiceStatus) bool { return s.State == api.ServiceStatus_stopped && s.ExitCode == 3 },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			sm := newTestServicesManager(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer func() {
+				cancel()
+				sm.update(ctx, nil)
+			}()
+
+			sm.update(ctx, []*gitpod.ServicesItems{test.Config(t)})
+			waitForService(t, sm, "db", test.Condition)
+		})
+	}
+}
+
+func TestServicesManager_Restart(t *testing.T) {
+	log.Log.Logger.SetLevel(logrus.FatalLevel)
+
+	sm := newTestServicesManager(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sm.update(ctx, []*gitpod.ServicesItems{{Name: "db", Command: "echo started; exec sleep 60", RestartPolicy: restartPolicyNever}})
+	status := waitForService(t, sm, "db", func(s *api.ServiceStatus) bool { return s.Pid != 0 })
+
+	if err := sm.Restart("unknown"); err != ErrServiceNotFound {
+		t.Errorf("expected ErrServiceNotFound, got %v", err)
+	}
+	if err := sm.Restart("db"); err != nil {
+		t.Fatal(err)
+	}
+	restarted := waitForService(t, sm, "db", func(s *api.ServiceStatus) bool {
+		return s.Restarts == 1 && s.Pid != 0 && s.Pid != status.Pid
+	})
+
+	// removing the service stops it
+	sm.update(ctx, nil)
+	if diff := cmp.Diff(0, len(sm.Status())); diff != "" {
+		t.Errorf("unexpected services (-want +got):\n%s", diff)
+	}
+	if err := syscall.Kill(-int(restarted.Pid), 0); err == nil {
+		t.Errorf("service process group %d is still running", restarted.Pid)
+	}
+
+	content, err := os.ReadFile(restarted.LogFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := strings.Count(string(content), "started\n"); c != 2 {
+		t.Errorf("expected output of both runs in the log file, got:\n%s", content)
+	}
+}
+
+func TestServicesManager_Subscribe(t *testing.T) {
+	log.Log.Logger.SetLevel(logrus.FatalLevel)
+
+	sm := newTestServicesManager(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		sm.update(ctx, nil)
+	}()
+
+	sub := sm.Subscribe()
+	defer sub.Close()
+	if initial := <-sub.Updates(); len(initial) != 0 {
+		t.Fatalf("expected no services initially, got %v", initial)
+	}
+
+	sm.update(ctx, []*gitpod.ServicesItems{
+		{Name: "db", Command: "sleep 60"},
+		{Name: "invalid name", Command: "sleep 60"},
+		{Name: "db", Command: "sleep 30"},
+	})
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case <-timeout:
+			t.Fatal("service did not start in time")
+		case update := <-sub.Updates():
+			if len(update) != 1 || update[0].Name != "db" {
+				t.Fatalf("expected only the first valid service, got %v", update)
+			}
+			if update[0].State == api.ServiceStatus_running {
+				return
+			}
+		}
+	}
+}
+
+func newTestServicesManager(t *testing.T) *servicesManager {
+	sm := newServicesManager(nil)
+	sm.logLocation = filepath.Join(t.TempDir(), "services")
+	sm.newCommand = func(command string, env map[string]string) *exec.Cmd {
+		cmd := exec.Command("/bin/sh", "-c", command)
+		cmd.Env = os.Environ()
+		for k, v := range env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+		return cmd
+	}
+	return sm
+}
+
+func waitForService(t *testing.T, sm *servicesManager, name string, condition func(s *api.ServiceStatus) bool) *api.ServiceStatus {
+	t.Helper()
+
+	var last *api.ServiceStatus
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, s := range sm.Status() {
+			if s.Name != name {
+				continue
+			}
+			last = s
+			if condition(s) {
+				return s
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("service %s did not reach the expected state, last status: %v", name, last)
+	return nil
+}
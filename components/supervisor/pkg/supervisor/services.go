@@ -97,6 +97,8 @@ type statusService struct {
 	ContentState    ContentState
 	Ports           *ports.Manager
 	Tasks           *tasksManager
+	Services        *servicesManager
+	Dotfiles        *dotfilesManager
 	ideReady        *ideReadyState
 	desktopIdeReady *ideReadyState
 	topService      *TopService
@@ -290,6 +292,36 @@ func (s *statusService) TasksStatus(req *api.TasksStatusRequest, srv api.StatusS
 	}
 }
 
+func (s *statusService) ServicesStatus(req *api.ServicesStatusRequest, srv api.StatusService_ServicesStatusServer) error {
+	if !req.Observe {
+		return srv.Send(&api.ServicesStatusResponse{
+			Services: s.Services.Status(),
+		})
+	}
+
+	sub := s.Services.Subscribe()
+	if sub == nil {
+		log.Warn("potentially leaking subscription to services status: too many subscriptions")
+		return status.Error(codes.ResourceExhausted, "too many subscriptions")
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case update, ok := <-sub.Updates():
+			if !ok {
+				return nil
+			}
+			err := srv.Send(&api.ServicesStatusResponse{Services: update})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // RegistrableTokenService can register the token service.
 type RegistrableTokenService struct {
 	Service api.TokenServiceServer
@@ -717,7 +749,9 @@ func (is *InfoService) WorkspaceInfo(context.Context, *api.WorkspaceInfoRequest)
 
 // ControlService implements the supervisor control service.
 type ControlService struct {
-	portsManager *ports.Manager
+	portsManager    *ports.Manager
+	servicesManager *servicesManager
+	dotfilesManager *dotfilesManager
 
 	privateKey string
 	publicKey  string
@@ -736,6 +770,36 @@ func (c *ControlService) ExposePort(ctx context.Context, req *api.ExposePortRequ
 	return &api.ExposePortResponse{}, err
 }
 
+// RestartService restarts a background service.
+func (c *ControlService) RestartService(ctx context.Context, req *api.RestartServiceRequest) (*api.RestartServiceResponse, error) {
+	err := c.servicesManager.Restart(req.Name)
+	if errors.Is(err, ErrServiceNotFound) {
+		return nil, status.Errorf(codes.NotFound, "service %s not found", req.Name)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.RestartServiceResponse{}, nil
+}
+
+// UpdateDotfiles updates the dotfiles repositories and installs them if they changed.
+func (c *ControlService) UpdateDotfiles(ctx context.Context, req *api.UpdateDotfilesRequest) (*api.UpdateDotfilesResponse, error) {
+	if len(c.dotfilesManager.sources) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "no dotfiles repository is configured")
+	}
+	// failures of individual repositories are reported through their status and the log
+	_ = c.dotfilesManager.Install(ctx, req.Reinstall)
+
+	logContent, err := c.dotfilesManager.Log()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.UpdateDotfilesResponse{
+		Sources: c.dotfilesManager.Status(),
+		Log:     logContent,
+	}, nil
+}
+
 // CreateSSHKeyPair create a ssh key pair for the workspace.
 func (ss *ControlService) CreateSSHKeyPair(context.Context, *api.CreateSSHKeyPairRequest) (response *api.CreateSSHKeyPairResponse, err error) {
 	home := "/home/gitpod/"
@@ -984,6 +1048,21 @@ func (s *portService) RetryAutoExpose(ctx context.Context, req *api.RetryAutoExp
 	return &api.RetryAutoExposeResponse{}, nil
 }
 
+// DotfilesStatus provides status information of the dotfiles repositories.
+func (s *statusService) DotfilesStatus(ctx context.Context, req *api.DotfilesStatusRequest) (*api.DotfilesStatusResponse, error) {
+	resp := &api.DotfilesStatusResponse{
+		Sources: s.Dotfiles.Status(),
+	}
+	if req.IncludeLog {
+		logContent, err := s.Dotfiles.Log()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Log = logContent
+	}
+	return resp, nil
+}
+
 // ResourcesStatus provides workspace resources status information.
 func (s *statusService) ResourcesStatus(ctx context.Context, in *api.ResourcesStatuRequest) (*api.ResourcesStatusResponse, error) {
 	return s.topService.data, nil
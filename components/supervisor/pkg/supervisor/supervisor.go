@@ -12,7 +12,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"io/fs"
 	"io/ioutil"
 	"math"
 	"net"
@@ -49,7 +48,6 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/pprof"
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/content-service/pkg/executor"
-	"github.com/gitpod-io/gitpod/content-service/pkg/git"
 	gitpod "github.com/gitpod-io/gitpod/gitpod-protocol"
 	"github.com/gitpod-io/gitpod/supervisor/api"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/activation"
@@ -253,9 +251,18 @@ func Run(options ...RunOption) {
 		cstate        = NewInMemoryContentState(cfg.RepoRoot)
 		gitpodService serverapi.APIInterface
 
-		notificationService = NewNotificationService()
+		notificationService *NotificationService
 	)
 
+	var notificationOpts []NotificationServiceOption
+	notificationSinks, err := cfg.GetNotificationSinks()
+	if err != nil {
+		log.WithError(err).Warn("notifications are not forwarded")
+	} else if notificationSinks != nil {
+		notificationOpts = append(notificationOpts, WithNotificationSinks(notificationSinks, cfg.WorkspaceID, cfg.WorkspaceUrl))
+	}
+	notificationService = NewNotificationService(notificationOpts...)
+
 	if !opts.RunGP {
 		gitpodService = serverapi.NewServerApiService(ctx, &serverapi.ServiceConfig{
 			Host:              host,
@@ -295,7 +302,7 @@ func Run(options ...RunOption) {
 		internalPorts...,
 	)
 
-	topService := NewTopService()
+	topService := NewTopService(notificationService)
 	if !opts.RunGP {
 		topService.Observe(ctx)
 	}
@@ -344,6 +351,8 @@ func Run(options ...RunOption) {
 	}
 
 	taskManager := newTasksManager(cfg, termMuxSrv, cstate, nil, ideReady, desktopIdeReady)
+	servicesManager := newServicesManager(gitpodConfigService)
+	dotfilesManager := newDotfilesManager(cfg, tokenService)
 
 	willShutdownCtx, fireWillShutdown := context.WithCancel(ctx)
 	apiServices := []RegisterableService{
@@ -352,6 +361,8 @@ func Run(options ...RunOption) {
 			ContentState:    cstate,
 			Ports:           portMgmt,
 			Tasks:           taskManager,
+			Services:        servicesManager,
+			Dotfiles:        dotfilesManager,
 			ideReady:        ideReady,
 			desktopIdeReady: desktopIdeReady,
 			topService:      topService,
@@ -360,7 +371,7 @@ func Run(options ...RunOption) {
 		RegistrableTokenService{Service: tokenService},
 		notificationService,
 		&InfoService{cfg: cfg, ContentState: cstate},
-		&ControlService{portsManager: portMgmt},
+		&ControlService{portsManager: portMgmt, servicesManager: servicesManager, dotfilesManager: dotfilesManager},
 		&portService{portsManager: portMgmt},
 	}
 	apiServices = append(apiServices, additionalServices...)
@@ -368,7 +379,8 @@ func Run(options ...RunOption) {
 	if !cfg.isPrebuild() {
 		// We need to checkout dotfiles first, because they may be changing the path which affects the IDE.
 		// TODO(cw): provide better feedback if the IDE start fails because of the dotfiles (provide any feedback at all).
-		installDotfiles(ctx, cfg, tokenService)
+		// Existing dotfiles repositories are updated in the background, such that changes reach workspaces on restart.
+		dotfilesManager.InstallOnStartup(ctx)
 	}
 
 	var ideWG sync.WaitGroup
@@ -403,6 +415,9 @@ func Run(options ...RunOption) {
 	tasksSuccessChan := make(chan taskSuccess, 1)
 	go taskManager.Run(ctx, &wg, tasksSuccessChan)
 
+	wg.Add(1)
+	go servicesManager.Run(ctx, &wg)
+
 	if !opts.RunGP {
 		wg.Add(1)
 		go socketActivationForDocker(ctx, &wg, termMux, cfg, telemetry)
@@ -429,27 +444,25 @@ func Run(options ...RunOption) {
 
 	if !cfg.isPrebuild() && !opts.RunGP && !cfg.isDebugWorkspace() {
 		go func() {
-			for _, repoRoot := range strings.Split(cfg.RepoRoots, ",") {
-				<-cstate.ContentReady()
-				waitForIde(ctx, ideReady, desktopIdeReady, 1*time.Second)
-
-				start := time.Now()
-				defer func() {
-					log.Debugf("unshallow of local repository took %v", time.Since(start))
-				}()
+			<-cstate.ContentReady()
+			waitForIde(ctx, ideReady, desktopIdeReady, 1*time.Second)
 
+			// every repository of a multi-repository workspace is unshallowed, not only the first one
+			for _, repoRoot := range strings.Split(cfg.RepoRoots, ",") {
 				if !isShallowRepository(repoRoot) {
-					return
+					continue
 				}
 
+				start := time.Now()
 				cmd := runAsGitpodUser(exec.Command("git", "fetch", "--unshallow", "--tags"))
 				cmd.Dir = repoRoot
 				cmd.Stdout = os.Stdout
 				cmd.Stderr = os.Stderr
 				err := cmd.Run()
 				if err != nil {
-					log.WithError(err).Error("git fetch error")
+					log.WithError(err).WithField("repoRoot", repoRoot).Error("git fetch error")
 				}
+				log.WithField("repoRoot", repoRoot).Debugf("unshallow of local repository took %v", time.Since(start))
 			}
 		}()
 	}
@@ -495,171 +508,6 @@ func isShallowRepository(rootDir string) bool {
 	return isShallow
 }
 
-func installDotfiles(ctx context.Context, cfg *Config, tokenService *InMemoryTokenService) {
-	repo := cfg.DotfileRepo
-	if repo == "" {
-		return
-	}
-
-	const dotfilePath = "/home/gitpod/.dotfiles"
-	if _, err := os.Stat(dotfilePath); err == nil {
-		// dotfile path exists already - nothing to do here
-		return
-	}
-
-	prep := func(cfg *Config, out io.Writer, name string, args ...string) *exec.Cmd {
-		cmd := exec.Command(name, args...)
-		cmd.Dir = "/home/gitpod"
-		runAsGitpodUser(cmd)
-		cmd.Stdout = out
-		cmd.Stderr = out
-		return cmd
-	}
-
-	err := func() (err error) {
-		out, err := os.OpenFile("/home/gitpod/.dotfiles.log", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
-		if err != nil {
-			return err
-		}
-		defer out.Close()
-
-		defer func() {
-			if err != nil {
-				out.WriteString(fmt.Sprintf("# dotfile init failed: %s\n", err.Error()))
-			}
-		}()
-
-		done := make(chan error, 1)
-		go func() {
-			repoUrl, err := url.Parse(repo)
-			if err != nil {
-				done <- err
-				close(done)
-				return
-			}
-			authProvider := func() (username string, password string, err error) {
-				resp, err := tokenService.GetToken(ctx, &api.GetTokenRequest{
-					Host: repoUrl.Host,
-					Kind: KindGit,
-				})
-				if err != nil {
-					return
-				}
-				username = resp.User
-				password = resp.Token
-				return
-			}
-			client := &git.Client{
-				AuthProvider: authProvider,
-				AuthMethod:   git.BasicAuth,
-				Location:     dotfilePath,
-				RemoteURI:    repo,
-			}
-			done <- client.Clone(ctx)
-			close(done)
-		}()
-		select {
-		case err := <-done:
-			if err != nil {
-				return err
-			}
-		case <-time.After(120 * time.Second):
-			return xerrors.Errorf("dotfiles repo clone did not finish within two minutes")
-		}
-
-		filepath.Walk(dotfilePath, func(name string, info os.FileInfo, err error) error {
-			if err == nil {
-				err = os.Chown(name, gitpodUID, gitpodGID)
-			}
-			return err
-		})
-
-		// at this point we have the dotfile repo cloned, let's try and install it
-		var candidates = []string{
-			"install.sh",
-			"install",
-			"bootstrap.sh",
-			"bootstrap",
-			"script/bootstrap",
-			"setup.sh",
-			"setup",
-			"script/setup",
-		}
-		for _, c := range candidates {
-			fn := filepath.Join(dotfilePath, c)
-			stat, err := os.Stat(fn)
-			if err != nil {
-				_, _ = out.WriteString(fmt.Sprintf("# installation script candidate %s is not available\n", fn))
-				continue
-			}
-			if stat.IsDir() {
-				_, _ = out.WriteString(fmt.Sprintf("# installation script candidate %s is a directory\n", fn))
-				continue
-			}
-			if stat.Mode()&0111 == 0 {
-				_, _ = out.WriteString(fmt.Sprintf("# installation script candidate %s is not executable\n", fn))
-				continue
-			}
-
-			_, _ = out.WriteString(fmt.Sprintf("# executing installation script candidate %s\n", fn))
-
-			// looks like we've found a candidate, let's run it
-			cmd := prep(cfg, out, "/bin/sh", "-c", "exec "+fn)
-			err = cmd.Start()
-			if err != nil {
-				return err
-			}
-			done := make(chan error, 1)
-			go func() {
-				done <- cmd.Wait()
-				close(done)
-			}()
-
-			select {
-			case err = <-done:
-				return err
-			case <-time.After(120 * time.Second):
-				cmd.Process.Kill()
-				return xerrors.Errorf("installation process %s tool longer than 120 seconds", fn)
-			}
-		}
-
-		// no installation script candidate was found, let's try and symlink this stuff
-		err = filepath.Walk(dotfilePath, func(path string, info fs.FileInfo, err error) error {
-			if strings.Contains(path, "/.git") {
-				// don't symlink the .git directory or any of its content
-				return nil
-			}
-
-			homeFN := filepath.Join("/home/gitpod", strings.TrimPrefix(path, dotfilePath))
-			if _, err := os.Stat(homeFN); err == nil {
-				// homeFN exists already - do nothing
-				return nil
-			}
-
-			if info.IsDir() {
-				err = os.MkdirAll(homeFN, info.Mode().Perm())
-				if err != nil {
-					return err
-				}
-				return nil
-			}
-
-			// write some feedback to the terminal
-			out.WriteString(fmt.Sprintf("# echo linking %s -> %s\n", path, homeFN))
-
-			return os.Symlink(path, homeFN)
-		})
-
-		return nil
-	}()
-	if err != nil {
-		// installing the dotfiles failed for some reason - we must tell the user
-		// TODO(cw): tell the user
-		log.WithError(err).Warn("installing dotfiles failed")
-	}
-}
-
 func createExposedPortsImpl(cfg *Config, gitpodService serverapi.APIInterface) ports.ExposedPortsInterface {
 	if gitpodService == nil {
 		log.Error("auto-port exposure won't work")
@@ -6,6 +6,7 @@ package supervisor
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"strconv"
@@ -29,12 +30,23 @@ type TopService struct {
 	ready     chan struct{}
 	readyOnce sync.Once
 	top       func(ctx context.Context) (*api.ResourcesStatusResponse, error)
+
+	notifications   notifier
+	lastMemoryEvent uint64
+}
+
+// notifier sends notifications to the user, see NotificationService
+type notifier interface {
+	Notify(ctx context.Context, req *api.NotifyRequest) (*api.NotifyResponse, error)
 }
 
-func NewTopService() *TopService {
+// NewTopService creates a new top service. If notifications is not nil, memory pressure warnings
+// and OOM kills are sent to the user as notifications.
+func NewTopService(notifications notifier) *TopService {
 	log.Debug("gitpod top service: initialized")
 	return &TopService{
-		top: Top,
+		top:           Top,
+		notifications: notifications,
 	}
 }
 
@@ -55,6 +67,7 @@ func (t *TopService) Observe(ctx context.Context) {
 			if err == nil {
 				delay = minReconnectionDelay
 				t.data = data
+				t.notifyMemoryEvents(ctx, data.MemoryEvents)
 
 				t.readyOnce.Do(func() {
 					close(t.ready)
@@ -76,6 +89,71 @@ func (t *TopService) Observe(ctx context.Context) {
 	}()
 }
 
+// notifyMemoryEvents notifies the user about memory events which were not notified before
+func (t *TopService) notifyMemoryEvents(ctx context.Context, events []*api.MemoryEvent) {
+	if len(events) == 0 {
+		return
+	}
+	if newest := events[len(events)-1].Seq; newest < t.lastMemoryEvent {
+		// ws-daemon was restarted and lost its events, hence the sequence starts over
+		t.lastMemoryEvent = 0
+	}
+
+	for _, evt := range events {
+		if evt.Seq <= t.lastMemoryEvent {
+			continue
+		}
+		t.lastMemoryEvent = evt.Seq
+		if t.notifications == nil {
+			continue
+		}
+
+		_, err := t.notifications.Notify(ctx, memoryEventNotification(evt))
+		if err != nil {
+			log.WithError(err).WithField("event", evt).Warn("cannot notify about memory event")
+		}
+	}
+}
+
+func memoryEventNotification(evt *api.MemoryEvent) *api.NotifyRequest {
+	processes := make([]string, 0, len(evt.Processes))
+	for _, p := range evt.Processes {
+		processes = append(processes, fmt.Sprintf("%s (%s)", p.Name, formatMemory(p.Rss)))
+	}
+
+	switch evt.Type {
+	case api.MemoryEventType_oom_kill:
+		msg := "A process was terminated because your workspace ran out of memory."
+		if len(processes) > 0 {
+			msg = fmt.Sprintf("%s was terminated because your workspace ran out of memory.", strings.Join(processes, ", "))
+		}
+		if evt.Limit > 0 {
+			msg += fmt.Sprintf(" The memory limit of this workspace is %s.", formatMemory(evt.Limit))
+		}
+		return &api.NotifyRequest{
+			Level:   api.NotifyRequest_ERROR,
+			Message: msg,
+		}
+	default:
+		msg := "Your workspace is running low on memory"
+		if evt.Limit > 0 {
+			msg += fmt.Sprintf(" (%s of %s used)", formatMemory(evt.Used), formatMemory(evt.Limit))
+		}
+		msg += "."
+		if len(processes) > 0 {
+			msg += fmt.Sprintf(" The largest processes are %s.", strings.Join(processes, ", "))
+		}
+		return &api.NotifyRequest{
+			Level:   api.NotifyRequest_WARNING,
+			Message: msg,
+		}
+	}
+}
+
+func formatMemory(bytes int64) string {
+	return fmt.Sprintf("%dMi", bytes/(1024*1024))
+}
+
 func calcSeverity(value int64) api.ResourceStatusSeverity {
 	switch {
 	case value >= 95:
@@ -127,7 +205,7 @@ func Top(ctx context.Context) (*api.ResourcesStatusResponse, error) {
 		cpuPercentage := int64((float64(resp.Resources.Cpu.Used) / float64(resp.Resources.Cpu.Limit)) * 100)
 		memoryPercentage := int64((float64(resp.Resources.Memory.Used) / float64(resp.Resources.Memory.Limit)) * 100)
 
-		return &api.ResourcesStatusResponse{
+		res := &api.ResourcesStatusResponse{
 			Memory: &api.ResourceStatus{
 				Limit:    resp.Resources.Memory.Limit,
 				Used:     resp.Resources.Memory.Used,
@@ -138,7 +216,74 @@ func Top(ctx context.Context) (*api.ResourcesStatusResponse, error) {
 				Used:     resp.Resources.Cpu.Used,
 				Severity: calcSeverity(cpuPercentage),
 			},
-		}, nil
+		}
+		for _, evt := range resp.MemoryEvents {
+			res.MemoryEvents = append(res.MemoryEvents, toMemoryEvent(evt))
+		}
+		if disk := resp.Resources.Disk; disk != nil && disk.Limit > 0 {
+			diskPercentage := int64((float64(disk.Used) / float64(disk.Limit)) * 100)
+			res.Disk = &api.ResourceStatus{
+				Limit:    disk.Limit,
+				Used:     disk.Used,
+				Severity: calcSeverity(diskPercentage),
+			}
+		}
+		res.Io = toIOStatus(resp.Resources.Io)
+		res.Network = toNetworkStatus(resp.Resources.Network)
+		return res, nil
+	}
+}
+
+func toMemoryEvent(evt *daemonapi.MemoryEvent) *api.MemoryEvent {
+	res := &api.MemoryEvent{
+		Seq:   evt.Seq,
+		Time:  evt.Time,
+		Used:  evt.Used,
+		Limit: evt.Limit,
+	}
+	if evt.Type == daemonapi.MemoryEventType_OOM_KILL {
+		res.Type = api.MemoryEventType_oom_kill
+	} else {
+		res.Type = api.MemoryEventType_memory_pressure
+	}
+	for _, p := range evt.Processes {
+		res.Processes = append(res.Processes, &api.MemoryEventProcess{
+			Pid:  p.Pid,
+			Name: p.Name,
+			Rss:  p.Rss,
+		})
+	}
+	return res
+}
+
+func toIOStatus(io *daemonapi.IO) *api.IOStatus {
+	if io == nil {
+		return nil
+	}
+	return &api.IOStatus{
+		ReadBandwidthLimit:  io.ReadBandwidthLimit,
+		WriteBandwidthLimit: io.WriteBandwidthLimit,
+		ReadIopsLimit:       io.ReadIopsLimit,
+		WriteIopsLimit:      io.WriteIopsLimit,
+		ReadBytes:           io.ReadBytes,
+		WriteBytes:          io.WriteBytes,
+		ThrottledPeriods:    io.ThrottledPeriods,
+		StalledUsec:         io.StalledUsec,
+		Bursting:            io.Bursting,
+		NodeSaturated:       io.NodeSaturated,
+	}
+}
+
+func toNetworkStatus(network *daemonapi.Network) *api.NetworkStatus {
+	if network == nil {
+		return nil
+	}
+	return &api.NetworkStatus{
+		RxBytes:              network.RxBytes,
+		TxBytes:              network.TxBytes,
+		EgressBandwidthLimit: network.EgressBandwidthLimit,
+		EgressPolicy:         network.EgressPolicy,
+		EgressDeniedPackets:  network.EgressDeniedPackets,
 	}
 }
 
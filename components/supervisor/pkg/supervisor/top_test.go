@@ -11,7 +11,9 @@ import (
 	"time"
 
 	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
 	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 var isGHAction = os.Getenv("GITHUB_ACTIONS") == "true"
@@ -22,7 +24,7 @@ func TestTopServiceHappyPath(t *testing.T) {
 	}
 	ctx := context.Background()
 
-	topService := NewTopService()
+	topService := NewTopService(nil)
 	topService.Observe(ctx)
 
 	<-topService.ready
@@ -43,7 +45,7 @@ func TestTopServiceWithUpstreamFailure(t *testing.T) {
 
 	var isFirstRun = true
 
-	topService := NewTopService()
+	topService := NewTopService(nil)
 	topService.top = func(ctx context.Context) (*api.ResourcesStatusResponse, error) {
 		if isFirstRun {
 			isFirstRun = false
@@ -92,3 +94,51 @@ func TestTopServiceWithUpstreamFailure(t *testing.T) {
 		t.Errorf("Total Cpu should be 5")
 	}
 }
+
+type fakeNotifier struct {
+	requests []*api.NotifyRequest
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, req *api.NotifyRequest) (*api.NotifyResponse, error) {
+	n.requests = append(n.requests, req)
+	return &api.NotifyResponse{}, nil
+}
+
+func TestTopServiceNotifyMemoryEvents(t *testing.T) {
+	var (
+		notifications = &fakeNotifier{}
+		topService    = NewTopService(notifications)
+		pressure      = func(seq uint64) *api.MemoryEvent {
+			return &api.MemoryEvent{
+				Seq:       seq,
+				Type:      api.MemoryEventType_memory_pressure,
+				Used:      7 * 1024 * 1024 * 1024,
+				Limit:     8 * 1024 * 1024 * 1024,
+				Processes: []*api.MemoryEventProcess{{Name: "java", Rss: 4 * 1024 * 1024 * 1024}, {Name: "node", Rss: 1024 * 1024 * 1024}},
+			}
+		}
+		oomKill = func(seq uint64) *api.MemoryEvent {
+			return &api.MemoryEvent{
+				Seq:       seq,
+				Type:      api.MemoryEventType_oom_kill,
+				Limit:     8 * 1024 * 1024 * 1024,
+				Processes: []*api.MemoryEventProcess{{Name: "java", Rss: 6 * 1024 * 1024 * 1024}},
+			}
+		}
+	)
+
+	topService.notifyMemoryEvents(context.Background(), []*api.MemoryEvent{pressure(1), oomKill(2)})
+	topService.notifyMemoryEvents(context.Background(), []*api.MemoryEvent{pressure(1), oomKill(2), pressure(3)})
+	// ws-daemon restarted
+	topService.notifyMemoryEvents(context.Background(), []*api.MemoryEvent{oomKill(1)})
+
+	expectation := []*api.NotifyRequest{
+		{Level: api.NotifyRequest_WARNING, Message: "Your workspace is running low on memory (7168Mi of 8192Mi used). The largest processes are java (4096Mi), node (1024Mi)."},
+		{Level: api.NotifyRequest_ERROR, Message: "java (6144Mi) was terminated because your workspace ran out of memory. The memory limit of this workspace is 8192Mi."},
+		{Level: api.NotifyRequest_WARNING, Message: "Your workspace is running low on memory (7168Mi of 8192Mi used). The largest processes are java (4096Mi), node (1024Mi)."},
+		{Level: api.NotifyRequest_ERROR, Message: "java (6144Mi) was terminated because your workspace ran out of memory. The memory limit of this workspace is 8192Mi."},
+	}
+	if diff := cmp.Diff(expectation, notifications.requests, protocmp.Transform()); diff != "" {
+		t.Errorf("notifyMemoryEvents() mismatch (-want +got):\n%s", diff)
+	}
+}
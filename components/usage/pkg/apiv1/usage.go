@@ -29,6 +29,7 @@ type UsageService struct {
 	nowFunc           func() time.Time
 	pricer            *WorkspacePricer
 	costCenterManager *db.CostCenterManager
+	auditLog          *db.AuditLogWriter
 
 	v1.UnimplementedUsageServiceServer
 }
@@ -233,11 +234,37 @@ func (s *UsageService) SetCostCenter(ctx context.Context, in *v1.SetCostCenterRe
 	if err != nil {
 		return nil, err
 	}
+
+	s.recordSetCostCenter(ctx, result)
+
 	return &v1.SetCostCenterResponse{
 		CostCenter: dbCostCenterToAPI(result),
 	}, nil
 }
 
+// recordSetCostCenter records the update in the audit log. The cost center has already been updated, hence failures are only logged.
+func (s *UsageService) recordSetCostCenter(ctx context.Context, costCenter db.CostCenter) {
+	// Cost centers are updated by the system on behalf of users, the actor is therefore left empty.
+	entry := db.AuditLog{
+		Action: "setCostCenter",
+	}
+	if costCenter.ID.IsEntity(db.AttributionEntity_Team) {
+		_, entry.OrganizationID = costCenter.ID.Values()
+	}
+
+	err := entry.SetArgs(map[string]any{
+		"attributionId":   string(costCenter.ID),
+		"spendingLimit":   costCenter.SpendingLimit,
+		"billingStrategy": string(costCenter.BillingStrategy),
+	})
+	if err == nil {
+		_, err = s.auditLog.Write(ctx, entry)
+	}
+	if err != nil {
+		log.WithError(err).WithField("attribution_id", costCenter.ID).Error("Failed to record audit log.")
+	}
+}
+
 func (s *UsageService) ResetUsage(ctx context.Context, req *v1.ResetUsageRequest) (*v1.ResetUsageResponse, error) {
 	now := time.Now()
 	costCentersToUpdate, err := s.costCenterManager.ListManagedCostCentersWithBillingTimeBefore(ctx, now)
@@ -448,10 +475,11 @@ func dedupeWorkspaceInstancesForUsage(instances []db.WorkspaceInstanceForUsage)
 	return set
 }
 
-func NewUsageService(conn *gorm.DB, pricer *WorkspacePricer, costCenterManager *db.CostCenterManager) *UsageService {
+func NewUsageService(conn *gorm.DB, pricer *WorkspacePricer, costCenterManager *db.CostCenterManager, auditLog *db.AuditLogWriter) *UsageService {
 	return &UsageService{
 		conn:              conn,
 		costCenterManager: costCenterManager,
+		auditLog:          auditLog,
 		nowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
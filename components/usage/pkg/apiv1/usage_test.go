@@ -85,7 +85,7 @@ func newUsageService(t *testing.T, dbconn *gorm.DB) v1.UsageServiceClient {
 		MinForUsersOnStripe: 1000,
 	})
 
-	v1.RegisterUsageServiceServer(srv.GRPC(), NewUsageService(dbconn, DefaultWorkspacePricer, costCenterManager))
+	v1.RegisterUsageServiceServer(srv.GRPC(), NewUsageService(dbconn, DefaultWorkspacePricer, costCenterManager, db.NewAuditLogWriter(dbconn)))
 	baseserver.StartServerForTests(t, srv)
 
 	conn, err := grpc.Dial(srv.GRPCAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -284,6 +284,17 @@ func TestGetAndSetCostCenter(t *testing.T) {
 
 		require.Equal(t, costCenter.SpendingLimit, retrieved.CostCenter.SpendingLimit)
 		require.Equal(t, costCenter.BillingStrategy, retrieved.CostCenter.BillingStrategy)
+
+		attributionID := db.AttributionID(costCenter.AttributionId)
+		if !attributionID.IsEntity(db.AttributionEntity_Team) {
+			continue
+		}
+		_, teamID := attributionID.Values()
+		auditLogs, err := db.ListAuditLogsForOrganization(context.Background(), conn, uuid.MustParse(teamID), db.ListAuditLogsFilter{Action: "setCostCenter"}, db.Pagination{PageSize: 25})
+		require.NoError(t, err)
+		require.Len(t, auditLogs.Results, 1)
+		t.Cleanup(func() { require.NoError(t, conn.Delete(&auditLogs.Results[0]).Error) })
+		require.JSONEq(t, fmt.Sprintf(`{"attributionId":%q,"spendingLimit":%d,"billingStrategy":%q}`, costCenter.AttributionId, costCenter.SpendingLimit, convertBillingStrategyToDB(costCenter.BillingStrategy)), string(auditLogs.Results[0].Args))
 	}
 }
 
@@ -48,6 +48,9 @@ type Config struct {
 
 	// Where to find the gRPC/Connect APIs on the server component
 	ServerAddress string `json:"serverAddress"`
+
+	// AuditLogSinks configures where audit logs are forwarded to, in addition to the database.
+	AuditLogSinks *db.AuditLogSinksConfig `json:"auditLogSinks,omitempty"`
 }
 
 func Start(cfg Config, version string) error {
@@ -172,7 +175,12 @@ func Start(cfg Config, version string) error {
 
 func registerGRPCServices(srv *baseserver.Server, conn *gorm.DB, stripeClient *stripe.Client, pricer *apiv1.WorkspacePricer, cfg Config) error {
 	ccManager := db.NewCostCenterManager(conn, cfg.DefaultSpendingLimit)
-	v1.RegisterUsageServiceServer(srv.GRPC(), apiv1.NewUsageService(conn, pricer, ccManager))
+
+	auditLogSinks, err := db.NewAuditLogSinks(cfg.AuditLogSinks)
+	if err != nil {
+		return fmt.Errorf("failed to setup audit log sinks: %w", err)
+	}
+	v1.RegisterUsageServiceServer(srv.GRPC(), apiv1.NewUsageService(conn, pricer, ccManager, db.NewAuditLogWriter(conn, auditLogSinks...)))
 
 	teamsService := v1connect.NewTeamsServiceClient(http.DefaultClient, fmt.Sprintf("http://%s", cfg.ServerAddress))
 	userService := v1connect.NewUserServiceClient(http.DefaultClient, fmt.Sprintf("http://%s", cfg.ServerAddress))
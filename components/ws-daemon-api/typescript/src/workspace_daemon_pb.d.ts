@@ -351,6 +351,11 @@ export class WorkspaceInfoResponse extends jspb.Message {
     getResources(): Resources | undefined;
     setResources(value?: Resources): WorkspaceInfoResponse;
 
+    clearMemoryEventsList(): void;
+    getMemoryEventsList(): Array<MemoryEvent>;
+    setMemoryEventsList(value: Array<MemoryEvent>): WorkspaceInfoResponse;
+    addMemoryEvents(value?: MemoryEvent, index?: number): MemoryEvent;
+
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): WorkspaceInfoResponse.AsObject;
     static toObject(includeInstance: boolean, msg: WorkspaceInfoResponse): WorkspaceInfoResponse.AsObject;
@@ -367,6 +372,7 @@ export class WorkspaceInfoResponse extends jspb.Message {
 export namespace WorkspaceInfoResponse {
     export type AsObject = {
         resources?: Resources.AsObject;
+        memoryEventsList: Array<MemoryEvent.AsObject>;
     };
 }
 
@@ -381,6 +387,21 @@ export class Resources extends jspb.Message {
     getMemory(): Memory | undefined;
     setMemory(value?: Memory): Resources;
 
+    hasDisk(): boolean;
+    clearDisk(): void;
+    getDisk(): Disk | undefined;
+    setDisk(value?: Disk): Resources;
+
+    hasIo(): boolean;
+    clearIo(): void;
+    getIo(): IO | undefined;
+    setIo(value?: IO): Resources;
+
+    hasNetwork(): boolean;
+    clearNetwork(): void;
+    getNetwork(): Resourcesetwork | undefined;
+    setNetwork(value?: Resourcesetwork): Resources;
+
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): Resources.AsObject;
     static toObject(includeInstance: boolean, msg: Resources): Resources.AsObject;
@@ -395,6 +416,9 @@ export namespace Resources {
     export type AsObject = {
         cpu?: Cpu.AsObject;
         memory?: Memory.AsObject;
+        disk?: Disk.AsObject;
+        io?: IO.AsObject;
+        network?: Network.AsObject;
     };
 }
 
@@ -444,7 +468,177 @@ export namespace Memory {
     };
 }
 
+export class Disk extends jspb.Message {
+    getUsed(): number;
+    setUsed(value: number): Disk;
+    getLimit(): number;
+    setLimit(value: number): Disk;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): Disk.AsObject;
+    static toObject(includeInstance: boolean, msg: Disk): Disk.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: Disk, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): Disk;
+    static deserializeBinaryFromReader(message: Disk, reader: jspb.BinaryReader): Disk;
+}
+
+export namespace Disk {
+    export type AsObject = {
+        used: number;
+        limit: number;
+    };
+}
+
+export class IO extends jspb.Message {
+    getReadBandwidthLimit(): number;
+    setReadBandwidthLimit(value: number): IO;
+    getWriteBandwidthLimit(): number;
+    setWriteBandwidthLimit(value: number): IO;
+    getReadIopsLimit(): number;
+    setReadIopsLimit(value: number): IO;
+    getWriteIopsLimit(): number;
+    setWriteIopsLimit(value: number): IO;
+    getReadBytes(): number;
+    setReadBytes(value: number): IO;
+    getWriteBytes(): number;
+    setWriteBytes(value: number): IO;
+    getThrottledPeriods(): number;
+    setThrottledPeriods(value: number): IO;
+    getStalledUsec(): number;
+    setStalledUsec(value: number): IO;
+    getBursting(): boolean;
+    setBursting(value: boolean): IO;
+    getNodeSaturated(): boolean;
+    setNodeSaturated(value: boolean): IO;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): IO.AsObject;
+    static toObject(includeInstance: boolean, msg: IO): IO.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: IO, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): IO;
+    static deserializeBinaryFromReader(message: IO, reader: jspb.BinaryReader): IO;
+}
+
+export namespace IO {
+    export type AsObject = {
+        readBandwidthLimit: number;
+        writeBandwidthLimit: number;
+        readIopsLimit: number;
+        writeIopsLimit: number;
+        readBytes: number;
+        writeBytes: number;
+        throttledPeriods: number;
+        stalledUsec: number;
+        bursting: boolean;
+        nodeSaturated: boolean;
+    };
+}
+
+export class Network extends jspb.Message {
+    getRxBytes(): number;
+    setRxBytes(value: number): Network;
+    getTxBytes(): number;
+    setTxBytes(value: number): Network;
+    getEgressBandwidthLimit(): number;
+    setEgressBandwidthLimit(value: number): Network;
+    getEgressPolicy(): string;
+    setEgressPolicy(value: string): Network;
+    getEgressDeniedPackets(): number;
+    setEgressDeniedPackets(value: number): Network;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): Network.AsObject;
+    static toObject(includeInstance: boolean, msg: Network): Network.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: Network, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): Network;
+    static deserializeBinaryFromReader(message: Network, reader: jspb.BinaryReader): Network;
+}
+
+export namespace Network {
+    export type AsObject = {
+        rxBytes: number;
+        txBytes: number;
+        egressBandwidthLimit: number;
+        egressPolicy: string;
+        egressDeniedPackets: number;
+    };
+}
+
+export class MemoryEvent extends jspb.Message {
+    getSeq(): number;
+    setSeq(value: number): MemoryEvent;
+    getType(): MemoryEventType;
+    setType(value: MemoryEventType): MemoryEvent;
+    getTime(): number;
+    setTime(value: number): MemoryEvent;
+    getUsed(): number;
+    setUsed(value: number): MemoryEvent;
+    getLimit(): number;
+    setLimit(value: number): MemoryEvent;
+    clearProcessesList(): void;
+    getProcessesList(): Array<MemoryEventProcess>;
+    setProcessesList(value: Array<MemoryEventProcess>): MemoryEvent;
+    addProcesses(value?: MemoryEventProcess, index?: number): MemoryEventProcess;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): MemoryEvent.AsObject;
+    static toObject(includeInstance: boolean, msg: MemoryEvent): MemoryEvent.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: MemoryEvent, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): MemoryEvent;
+    static deserializeBinaryFromReader(message: MemoryEvent, reader: jspb.BinaryReader): MemoryEvent;
+}
+
+export namespace MemoryEvent {
+    export type AsObject = {
+        seq: number;
+        type: MemoryEventType;
+        time: number;
+        used: number;
+        limit: number;
+        processesList: Array<MemoryEventProcess.AsObject>;
+    };
+}
+
+export class MemoryEventProcess extends jspb.Message {
+    getPid(): number;
+    setPid(value: number): MemoryEventProcess;
+    getName(): string;
+    setName(value: string): MemoryEventProcess;
+    getRss(): number;
+    setRss(value: number): MemoryEventProcess;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): MemoryEventProcess.AsObject;
+    static toObject(includeInstance: boolean, msg: MemoryEventProcess): MemoryEventProcess.AsObject;
+    static extensions: { [key: number]: jspb.ExtensionFieldInfo<jspb.Message> };
+    static extensionsBinary: { [key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message> };
+    static serializeBinaryToWriter(message: MemoryEventProcess, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): MemoryEventProcess;
+    static deserializeBinaryFromReader(message: MemoryEventProcess, reader: jspb.BinaryReader): MemoryEventProcess;
+}
+
+export namespace MemoryEventProcess {
+    export type AsObject = {
+        pid: number;
+        name: string;
+        rss: number;
+    };
+}
+
 export enum FSShiftMethod {
     SHIFTFS = 0,
     FUSE = 1,
 }
+
+export enum MemoryEventType {
+    MEMORY_PRESSURE = 0,
+    OOM_KILL = 1,
+}
@@ -24,12 +24,18 @@ var global = function () {
 }.call(null);
 
 goog.exportSymbol("proto.iws.Cpu", null, global);
+goog.exportSymbol("proto.iws.Disk", null, global);
 goog.exportSymbol("proto.iws.EvacuateCGroupRequest", null, global);
 goog.exportSymbol("proto.iws.EvacuateCGroupResponse", null, global);
 goog.exportSymbol("proto.iws.FSShiftMethod", null, global);
+goog.exportSymbol("proto.iws.IO", null, global);
 goog.exportSymbol("proto.iws.Memory", null, global);
+goog.exportSymbol("proto.iws.MemoryEvent", null, global);
+goog.exportSymbol("proto.iws.MemoryEventProcess", null, global);
+goog.exportSymbol("proto.iws.MemoryEventType", null, global);
 goog.exportSymbol("proto.iws.MountProcRequest", null, global);
 goog.exportSymbol("proto.iws.MountProcResponse", null, global);
+goog.exportSymbol("proto.iws.Network", null, global);
 goog.exportSymbol("proto.iws.PrepareForUserNSRequest", null, global);
 goog.exportSymbol("proto.iws.PrepareForUserNSResponse", null, global);
 goog.exportSymbol("proto.iws.Resources", null, global);
@@ -391,7 +397,7 @@ if (goog.DEBUG && !COMPILED) {
  * @constructor
  */
 proto.iws.WorkspaceInfoResponse = function (opt_data) {
-    jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+    jspb.Message.initialize(this, opt_data, 0, -1, proto.iws.WorkspaceInfoResponse.repeatedFields_, null);
 };
 goog.inherits(proto.iws.WorkspaceInfoResponse, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
@@ -464,6 +470,113 @@ if (goog.DEBUG && !COMPILED) {
      */
     proto.iws.Memory.displayName = "proto.iws.Memory";
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.iws.Disk = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.iws.Disk, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.iws.Disk.displayName = "proto.iws.Disk";
+}
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.iws.IO = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.iws.IO, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.iws.IO.displayName = "proto.iws.IO";
+}
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.iws.Network = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.iws.Network, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.iws.Network.displayName = "proto.iws.Network";
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.iws.MemoryEvent = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, proto.iws.MemoryEvent.repeatedFields_, null);
+};
+goog.inherits(proto.iws.MemoryEvent, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.iws.MemoryEvent.displayName = "proto.iws.MemoryEvent";
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.iws.MemoryEventProcess = function (opt_data) {
+    jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.iws.MemoryEventProcess, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+    /**
+     * @public
+     * @override
+     */
+    proto.iws.MemoryEventProcess.displayName = "proto.iws.MemoryEventProcess";
+}
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
     /**
@@ -2391,6 +2504,13 @@ proto.iws.WorkspaceInfoRequest.serializeBinaryToWriter = function (message, writ
     var f = undefined;
 };
 
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.iws.WorkspaceInfoResponse.repeatedFields_ = [2];
+
 if (jspb.Message.GENERATE_TO_OBJECT) {
     /**
      * Creates an object representation of this proto.
@@ -2421,6 +2541,11 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
         var f,
             obj = {
                 resources: (f = msg.getResources()) && proto.iws.Resources.toObject(includeInstance, f),
+                memoryEventsList: jspb.Message.toObjectList(
+                    msg.getMemoryEventsList(),
+                    proto.iws.MemoryEvent.toObject,
+                    includeInstance,
+                ),
             };
 
         if (includeInstance) {
@@ -2460,6 +2585,11 @@ proto.iws.WorkspaceInfoResponse.deserializeBinaryFromReader = function (msg, rea
                 reader.readMessage(value, proto.iws.Resources.deserializeBinaryFromReader);
                 msg.setResources(value);
                 break;
+            case 2:
+                var value = new proto.iws.MemoryEvent();
+                reader.readMessage(value, proto.iws.MemoryEvent.deserializeBinaryFromReader);
+                msg.addMemoryEvents(value);
+                break;
             default:
                 reader.skipField();
                 break;
@@ -2491,6 +2621,10 @@ proto.iws.WorkspaceInfoResponse.serializeBinaryToWriter = function (message, wri
     if (f != null) {
         writer.writeMessage(1, f, proto.iws.Resources.serializeBinaryToWriter);
     }
+    f = message.getMemoryEventsList();
+    if (f.length > 0) {
+        writer.writeRepeatedMessage(2, f, proto.iws.MemoryEvent.serializeBinaryToWriter);
+    }
 };
 
 /**
@@ -2525,6 +2659,41 @@ proto.iws.WorkspaceInfoResponse.prototype.hasResources = function () {
     return jspb.Message.getField(this, 1) != null;
 };
 
+/**
+ * repeated MemoryEvent memory_events = 2;
+ * @return {!Array<!proto.iws.MemoryEvent>}
+ */
+proto.iws.WorkspaceInfoResponse.prototype.getMemoryEventsList = function () {
+    return /** @type{!Array<!proto.iws.MemoryEvent>} */ (
+        jspb.Message.getRepeatedWrapperField(this, proto.iws.MemoryEvent, 2)
+    );
+};
+
+/**
+ * @param {!Array<!proto.iws.MemoryEvent>} value
+ * @return {!proto.iws.WorkspaceInfoResponse} returns this
+ */
+proto.iws.WorkspaceInfoResponse.prototype.setMemoryEventsList = function (value) {
+    return jspb.Message.setRepeatedWrapperField(this, 2, value);
+};
+
+/**
+ * @param {!proto.iws.MemoryEvent=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.iws.MemoryEvent}
+ */
+proto.iws.WorkspaceInfoResponse.prototype.addMemoryEvents = function (opt_value, opt_index) {
+    return jspb.Message.addToRepeatedWrapperField(this, 2, opt_value, proto.iws.MemoryEvent, opt_index);
+};
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.iws.WorkspaceInfoResponse} returns this
+ */
+proto.iws.WorkspaceInfoResponse.prototype.clearMemoryEventsList = function () {
+    return this.setMemoryEventsList([]);
+};
+
 if (jspb.Message.GENERATE_TO_OBJECT) {
     /**
      * Creates an object representation of this proto.
@@ -2556,6 +2725,9 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
             obj = {
                 cpu: (f = msg.getCpu()) && proto.iws.Cpu.toObject(includeInstance, f),
                 memory: (f = msg.getMemory()) && proto.iws.Memory.toObject(includeInstance, f),
+                disk: (f = msg.getDisk()) && proto.iws.Disk.toObject(includeInstance, f),
+                io: (f = msg.getIo()) && proto.iws.IO.toObject(includeInstance, f),
+                network: (f = msg.getNetwork()) && proto.iws.Network.toObject(includeInstance, f),
             };
 
         if (includeInstance) {
@@ -2600,6 +2772,21 @@ proto.iws.Resources.deserializeBinaryFromReader = function (msg, reader) {
                 reader.readMessage(value, proto.iws.Memory.deserializeBinaryFromReader);
                 msg.setMemory(value);
                 break;
+            case 3:
+                var value = new proto.iws.Disk();
+                reader.readMessage(value, proto.iws.Disk.deserializeBinaryFromReader);
+                msg.setDisk(value);
+                break;
+            case 4:
+                var value = new proto.iws.IO();
+                reader.readMessage(value, proto.iws.IO.deserializeBinaryFromReader);
+                msg.setIo(value);
+                break;
+            case 5:
+                var value = new proto.iws.Network();
+                reader.readMessage(value, proto.iws.Network.deserializeBinaryFromReader);
+                msg.setNetwork(value);
+                break;
             default:
                 reader.skipField();
                 break;
@@ -2635,6 +2822,18 @@ proto.iws.Resources.serializeBinaryToWriter = function (message, writer) {
     if (f != null) {
         writer.writeMessage(2, f, proto.iws.Memory.serializeBinaryToWriter);
     }
+    f = message.getDisk();
+    if (f != null) {
+        writer.writeMessage(3, f, proto.iws.Disk.serializeBinaryToWriter);
+    }
+    f = message.getIo();
+    if (f != null) {
+        writer.writeMessage(4, f, proto.iws.IO.serializeBinaryToWriter);
+    }
+    f = message.getNetwork();
+    if (f != null) {
+        writer.writeMessage(5, f, proto.iws.Network.serializeBinaryToWriter);
+    }
 };
 
 /**
@@ -2701,6 +2900,102 @@ proto.iws.Resources.prototype.hasMemory = function () {
     return jspb.Message.getField(this, 2) != null;
 };
 
+/**
+ * optional Disk disk = 3;
+ * @return {?proto.iws.Disk}
+ */
+proto.iws.Resources.prototype.getDisk = function () {
+    return /** @type{?proto.iws.Disk} */ (jspb.Message.getWrapperField(this, proto.iws.Disk, 3));
+};
+
+/**
+ * @param {?proto.iws.Disk|undefined} value
+ * @return {!proto.iws.Resources} returns this
+ */
+proto.iws.Resources.prototype.setDisk = function (value) {
+    return jspb.Message.setWrapperField(this, 3, value);
+};
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.iws.Resources} returns this
+ */
+proto.iws.Resources.prototype.clearDisk = function () {
+    return this.setDisk(undefined);
+};
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.iws.Resources.prototype.hasDisk = function () {
+    return jspb.Message.getField(this, 3) != null;
+};
+
+/**
+ * optional IO io = 4;
+ * @return {?proto.iws.IO}
+ */
+proto.iws.Resources.prototype.getIo = function () {
+    return /** @type{?proto.iws.IO} */ (jspb.Message.getWrapperField(this, proto.iws.IO, 4));
+};
+
+/**
+ * @param {?proto.iws.IO|undefined} value
+ * @return {!proto.iws.Resources} returns this
+ */
+proto.iws.Resources.prototype.setIo = function (value) {
+    return jspb.Message.setWrapperField(this, 4, value);
+};
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.iws.Resources} returns this
+ */
+proto.iws.Resources.prototype.clearIo = function () {
+    return this.setIo(undefined);
+};
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.iws.Resources.prototype.hasIo = function () {
+    return jspb.Message.getField(this, 4) != null;
+};
+
+/**
+ * optional Network network = 5;
+ * @return {?proto.iws.Network}
+ */
+proto.iws.Resources.prototype.getNetwork = function () {
+    return /** @type{?proto.iws.Network} */ (jspb.Message.getWrapperField(this, proto.iws.Network, 5));
+};
+
+/**
+ * @param {?proto.iws.Network|undefined} value
+ * @return {!proto.iws.Resources} returns this
+ */
+proto.iws.Resources.prototype.setNetwork = function (value) {
+    return jspb.Message.setWrapperField(this, 5, value);
+};
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.iws.Resources} returns this
+ */
+proto.iws.Resources.prototype.clearNetwork = function () {
+    return this.setNetwork(undefined);
+};
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.iws.Resources.prototype.hasNetwork = function () {
+    return jspb.Message.getField(this, 5) != null;
+};
+
 if (jspb.Message.GENERATE_TO_OBJECT) {
     /**
      * Creates an object representation of this proto.
@@ -2985,12 +3280,1161 @@ proto.iws.Memory.prototype.setLimit = function (value) {
     return jspb.Message.setProto3IntField(this, 2, value);
 };
 
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.iws.Disk.prototype.toObject = function (opt_includeInstance) {
+        return proto.iws.Disk.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.iws.Disk} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.iws.Disk.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                used: jspb.Message.getFieldWithDefault(msg, 1, 0),
+                limit: jspb.Message.getFieldWithDefault(msg, 2, 0),
+            };
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.iws.Disk}
+ */
+proto.iws.Disk.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.iws.Disk();
+    return proto.iws.Disk.deserializeBinaryFromReader(msg, reader);
+};
+
 /**
- * @enum {number}
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.iws.Disk} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.iws.Disk}
  */
-proto.iws.FSShiftMethod = {
-    SHIFTFS: 0,
-    FUSE: 1,
+proto.iws.Disk.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setUsed(value);
+                break;
+            case 2:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setLimit(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.iws.Disk.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.iws.Disk.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.iws.Disk} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.Disk.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getUsed();
+    if (f !== 0) {
+        writer.writeInt64(1, f);
+    }
+    f = message.getLimit();
+    if (f !== 0) {
+        writer.writeInt64(2, f);
+    }
+};
+
+/**
+ * optional int64 used = 1;
+ * @return {number}
+ */
+proto.iws.Disk.prototype.getUsed = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.Disk} returns this
+ */
+proto.iws.Disk.prototype.setUsed = function (value) {
+    return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+/**
+ * optional int64 limit = 2;
+ * @return {number}
+ */
+proto.iws.Disk.prototype.getLimit = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.Disk} returns this
+ */
+proto.iws.Disk.prototype.setLimit = function (value) {
+    return jspb.Message.setProto3IntField(this, 2, value);
+};
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.iws.IO.prototype.toObject = function (opt_includeInstance) {
+        return proto.iws.IO.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.iws.IO} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.iws.IO.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                readBandwidthLimit: jspb.Message.getFieldWithDefault(msg, 1, 0),
+                writeBandwidthLimit: jspb.Message.getFieldWithDefault(msg, 2, 0),
+                readIopsLimit: jspb.Message.getFieldWithDefault(msg, 3, 0),
+                writeIopsLimit: jspb.Message.getFieldWithDefault(msg, 4, 0),
+                readBytes: jspb.Message.getFieldWithDefault(msg, 5, 0),
+                writeBytes: jspb.Message.getFieldWithDefault(msg, 6, 0),
+                throttledPeriods: jspb.Message.getFieldWithDefault(msg, 7, 0),
+                stalledUsec: jspb.Message.getFieldWithDefault(msg, 8, 0),
+                bursting: jspb.Message.getBooleanFieldWithDefault(msg, 9, false),
+                nodeSaturated: jspb.Message.getBooleanFieldWithDefault(msg, 10, false),
+            };
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.iws.IO}
+ */
+proto.iws.IO.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.iws.IO();
+    return proto.iws.IO.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.iws.IO} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.iws.IO}
+ */
+proto.iws.IO.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setReadBandwidthLimit(value);
+                break;
+            case 2:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setWriteBandwidthLimit(value);
+                break;
+            case 3:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setReadIopsLimit(value);
+                break;
+            case 4:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setWriteIopsLimit(value);
+                break;
+            case 5:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setReadBytes(value);
+                break;
+            case 6:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setWriteBytes(value);
+                break;
+            case 7:
+                var value = /** @type {number} */ (reader.readUint64());
+                msg.setThrottledPeriods(value);
+                break;
+            case 8:
+                var value = /** @type {number} */ (reader.readUint64());
+                msg.setStalledUsec(value);
+                break;
+            case 9:
+                var value = /** @type {boolean} */ (reader.readBool());
+                msg.setBursting(value);
+                break;
+            case 10:
+                var value = /** @type {boolean} */ (reader.readBool());
+                msg.setNodeSaturated(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.iws.IO.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.iws.IO.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.iws.IO} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.IO.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getReadBandwidthLimit();
+    if (f !== 0) {
+        writer.writeInt64(1, f);
+    }
+    f = message.getWriteBandwidthLimit();
+    if (f !== 0) {
+        writer.writeInt64(2, f);
+    }
+    f = message.getReadIopsLimit();
+    if (f !== 0) {
+        writer.writeInt64(3, f);
+    }
+    f = message.getWriteIopsLimit();
+    if (f !== 0) {
+        writer.writeInt64(4, f);
+    }
+    f = message.getReadBytes();
+    if (f !== 0) {
+        writer.writeInt64(5, f);
+    }
+    f = message.getWriteBytes();
+    if (f !== 0) {
+        writer.writeInt64(6, f);
+    }
+    f = message.getThrottledPeriods();
+    if (f !== 0) {
+        writer.writeUint64(7, f);
+    }
+    f = message.getStalledUsec();
+    if (f !== 0) {
+        writer.writeUint64(8, f);
+    }
+    f = message.getBursting();
+    if (f) {
+        writer.writeBool(9, f);
+    }
+    f = message.getNodeSaturated();
+    if (f) {
+        writer.writeBool(10, f);
+    }
+};
+
+/**
+ * optional int64 read_bandwidth_limit = 1;
+ * @return {number}
+ */
+proto.iws.IO.prototype.getReadBandwidthLimit = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.IO} returns this
+ */
+proto.iws.IO.prototype.setReadBandwidthLimit = function (value) {
+    return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+/**
+ * optional int64 write_bandwidth_limit = 2;
+ * @return {number}
+ */
+proto.iws.IO.prototype.getWriteBandwidthLimit = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.IO} returns this
+ */
+proto.iws.IO.prototype.setWriteBandwidthLimit = function (value) {
+    return jspb.Message.setProto3IntField(this, 2, value);
+};
+
+/**
+ * optional int64 read_iops_limit = 3;
+ * @return {number}
+ */
+proto.iws.IO.prototype.getReadIopsLimit = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.IO} returns this
+ */
+proto.iws.IO.prototype.setReadIopsLimit = function (value) {
+    return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+/**
+ * optional int64 write_iops_limit = 4;
+ * @return {number}
+ */
+proto.iws.IO.prototype.getWriteIopsLimit = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.IO} returns this
+ */
+proto.iws.IO.prototype.setWriteIopsLimit = function (value) {
+    return jspb.Message.setProto3IntField(this, 4, value);
+};
+
+/**
+ * optional int64 read_bytes = 5;
+ * @return {number}
+ */
+proto.iws.IO.prototype.getReadBytes = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.IO} returns this
+ */
+proto.iws.IO.prototype.setReadBytes = function (value) {
+    return jspb.Message.setProto3IntField(this, 5, value);
+};
+
+/**
+ * optional int64 write_bytes = 6;
+ * @return {number}
+ */
+proto.iws.IO.prototype.getWriteBytes = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.IO} returns this
+ */
+proto.iws.IO.prototype.setWriteBytes = function (value) {
+    return jspb.Message.setProto3IntField(this, 6, value);
+};
+
+/**
+ * optional uint64 throttled_periods = 7;
+ * @return {number}
+ */
+proto.iws.IO.prototype.getThrottledPeriods = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.IO} returns this
+ */
+proto.iws.IO.prototype.setThrottledPeriods = function (value) {
+    return jspb.Message.setProto3IntField(this, 7, value);
+};
+
+/**
+ * optional uint64 stalled_usec = 8;
+ * @return {number}
+ */
+proto.iws.IO.prototype.getStalledUsec = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 8, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.IO} returns this
+ */
+proto.iws.IO.prototype.setStalledUsec = function (value) {
+    return jspb.Message.setProto3IntField(this, 8, value);
+};
+
+/**
+ * optional bool bursting = 9;
+ * @return {boolean}
+ */
+proto.iws.IO.prototype.getBursting = function () {
+    return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 9, false));
+};
+
+/**
+ * @param {boolean} value
+ * @return {!proto.iws.IO} returns this
+ */
+proto.iws.IO.prototype.setBursting = function (value) {
+    return jspb.Message.setProto3BooleanField(this, 9, value);
+};
+
+/**
+ * optional bool node_saturated = 10;
+ * @return {boolean}
+ */
+proto.iws.IO.prototype.getNodeSaturated = function () {
+    return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 10, false));
+};
+
+/**
+ * @param {boolean} value
+ * @return {!proto.iws.IO} returns this
+ */
+proto.iws.IO.prototype.setNodeSaturated = function (value) {
+    return jspb.Message.setProto3BooleanField(this, 10, value);
+};
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.iws.Network.prototype.toObject = function (opt_includeInstance) {
+        return proto.iws.Network.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.iws.Network} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.iws.Network.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                rxBytes: jspb.Message.getFieldWithDefault(msg, 1, 0),
+                txBytes: jspb.Message.getFieldWithDefault(msg, 2, 0),
+                egressBandwidthLimit: jspb.Message.getFieldWithDefault(msg, 3, 0),
+                egressPolicy: jspb.Message.getFieldWithDefault(msg, 4, ""),
+                egressDeniedPackets: jspb.Message.getFieldWithDefault(msg, 5, 0),
+            };
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.iws.Network}
+ */
+proto.iws.Network.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.iws.Network();
+    return proto.iws.Network.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.iws.Network} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.iws.Network}
+ */
+proto.iws.Network.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setRxBytes(value);
+                break;
+            case 2:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setTxBytes(value);
+                break;
+            case 3:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setEgressBandwidthLimit(value);
+                break;
+            case 4:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setEgressPolicy(value);
+                break;
+            case 5:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setEgressDeniedPackets(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.iws.Network.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.iws.Network.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.iws.Network} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.Network.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getRxBytes();
+    if (f !== 0) {
+        writer.writeInt64(1, f);
+    }
+    f = message.getTxBytes();
+    if (f !== 0) {
+        writer.writeInt64(2, f);
+    }
+    f = message.getEgressBandwidthLimit();
+    if (f !== 0) {
+        writer.writeInt64(3, f);
+    }
+    f = message.getEgressPolicy();
+    if (f.length > 0) {
+        writer.writeString(4, f);
+    }
+    f = message.getEgressDeniedPackets();
+    if (f !== 0) {
+        writer.writeInt64(5, f);
+    }
+};
+
+/**
+ * optional int64 rx_bytes = 1;
+ * @return {number}
+ */
+proto.iws.Network.prototype.getRxBytes = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.Network} returns this
+ */
+proto.iws.Network.prototype.setRxBytes = function (value) {
+    return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+/**
+ * optional int64 tx_bytes = 2;
+ * @return {number}
+ */
+proto.iws.Network.prototype.getTxBytes = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.Network} returns this
+ */
+proto.iws.Network.prototype.setTxBytes = function (value) {
+    return jspb.Message.setProto3IntField(this, 2, value);
+};
+
+/**
+ * optional int64 egress_bandwidth_limit = 3;
+ * @return {number}
+ */
+proto.iws.Network.prototype.getEgressBandwidthLimit = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.Network} returns this
+ */
+proto.iws.Network.prototype.setEgressBandwidthLimit = function (value) {
+    return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+/**
+ * optional string egress_policy = 4;
+ * @return {string}
+ */
+proto.iws.Network.prototype.getEgressPolicy = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.iws.Network} returns this
+ */
+proto.iws.Network.prototype.setEgressPolicy = function (value) {
+    return jspb.Message.setProto3StringField(this, 4, value);
+};
+
+/**
+ * optional int64 egress_denied_packets = 5;
+ * @return {number}
+ */
+proto.iws.Network.prototype.getEgressDeniedPackets = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.Network} returns this
+ */
+proto.iws.Network.prototype.setEgressDeniedPackets = function (value) {
+    return jspb.Message.setProto3IntField(this, 5, value);
+};
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.iws.MemoryEvent.repeatedFields_ = [6];
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.iws.MemoryEvent.prototype.toObject = function (opt_includeInstance) {
+        return proto.iws.MemoryEvent.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.iws.MemoryEvent} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.iws.MemoryEvent.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                seq: jspb.Message.getFieldWithDefault(msg, 1, 0),
+                type: jspb.Message.getFieldWithDefault(msg, 2, 0),
+                time: jspb.Message.getFieldWithDefault(msg, 3, 0),
+                used: jspb.Message.getFieldWithDefault(msg, 4, 0),
+                limit: jspb.Message.getFieldWithDefault(msg, 5, 0),
+                processesList: jspb.Message.toObjectList(
+                    msg.getProcessesList(),
+                    proto.iws.MemoryEventProcess.toObject,
+                    includeInstance,
+                ),
+            };
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.iws.MemoryEvent}
+ */
+proto.iws.MemoryEvent.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.iws.MemoryEvent();
+    return proto.iws.MemoryEvent.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.iws.MemoryEvent} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.iws.MemoryEvent}
+ */
+proto.iws.MemoryEvent.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {number} */ (reader.readUint64());
+                msg.setSeq(value);
+                break;
+            case 2:
+                var value = /** @type {!proto.iws.MemoryEventType} */ (reader.readEnum());
+                msg.setType(value);
+                break;
+            case 3:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setTime(value);
+                break;
+            case 4:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setUsed(value);
+                break;
+            case 5:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setLimit(value);
+                break;
+            case 6:
+                var value = new proto.iws.MemoryEventProcess();
+                reader.readMessage(value, proto.iws.MemoryEventProcess.deserializeBinaryFromReader);
+                msg.addProcesses(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.iws.MemoryEvent.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.iws.MemoryEvent.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.iws.MemoryEvent} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.MemoryEvent.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getSeq();
+    if (f !== 0) {
+        writer.writeUint64(1, f);
+    }
+    f = message.getType();
+    if (f !== 0.0) {
+        writer.writeEnum(2, f);
+    }
+    f = message.getTime();
+    if (f !== 0) {
+        writer.writeInt64(3, f);
+    }
+    f = message.getUsed();
+    if (f !== 0) {
+        writer.writeInt64(4, f);
+    }
+    f = message.getLimit();
+    if (f !== 0) {
+        writer.writeInt64(5, f);
+    }
+    f = message.getProcessesList();
+    if (f.length > 0) {
+        writer.writeRepeatedMessage(6, f, proto.iws.MemoryEventProcess.serializeBinaryToWriter);
+    }
+};
+
+/**
+ * optional uint64 seq = 1;
+ * @return {number}
+ */
+proto.iws.MemoryEvent.prototype.getSeq = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.MemoryEvent} returns this
+ */
+proto.iws.MemoryEvent.prototype.setSeq = function (value) {
+    return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+/**
+ * optional MemoryEventType type = 2;
+ * @return {!proto.iws.MemoryEventType}
+ */
+proto.iws.MemoryEvent.prototype.getType = function () {
+    return /** @type {!proto.iws.MemoryEventType} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+/**
+ * @param {!proto.iws.MemoryEventType} value
+ * @return {!proto.iws.MemoryEvent} returns this
+ */
+proto.iws.MemoryEvent.prototype.setType = function (value) {
+    return jspb.Message.setProto3EnumField(this, 2, value);
+};
+
+/**
+ * optional int64 time = 3;
+ * @return {number}
+ */
+proto.iws.MemoryEvent.prototype.getTime = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.MemoryEvent} returns this
+ */
+proto.iws.MemoryEvent.prototype.setTime = function (value) {
+    return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+/**
+ * optional int64 used = 4;
+ * @return {number}
+ */
+proto.iws.MemoryEvent.prototype.getUsed = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.MemoryEvent} returns this
+ */
+proto.iws.MemoryEvent.prototype.setUsed = function (value) {
+    return jspb.Message.setProto3IntField(this, 4, value);
+};
+
+/**
+ * optional int64 limit = 5;
+ * @return {number}
+ */
+proto.iws.MemoryEvent.prototype.getLimit = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.MemoryEvent} returns this
+ */
+proto.iws.MemoryEvent.prototype.setLimit = function (value) {
+    return jspb.Message.setProto3IntField(this, 5, value);
+};
+
+/**
+ * repeated MemoryEventProcess processes = 6;
+ * @return {!Array<!proto.iws.MemoryEventProcess>}
+ */
+proto.iws.MemoryEvent.prototype.getProcessesList = function () {
+    return /** @type{!Array<!proto.iws.MemoryEventProcess>} */ (
+        jspb.Message.getRepeatedWrapperField(this, proto.iws.MemoryEventProcess, 6)
+    );
+};
+
+/**
+ * @param {!Array<!proto.iws.MemoryEventProcess>} value
+ * @return {!proto.iws.MemoryEvent} returns this
+ */
+proto.iws.MemoryEvent.prototype.setProcessesList = function (value) {
+    return jspb.Message.setRepeatedWrapperField(this, 6, value);
+};
+
+/**
+ * @param {!proto.iws.MemoryEventProcess=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.iws.MemoryEventProcess}
+ */
+proto.iws.MemoryEvent.prototype.addProcesses = function (opt_value, opt_index) {
+    return jspb.Message.addToRepeatedWrapperField(this, 6, opt_value, proto.iws.MemoryEventProcess, opt_index);
+};
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.iws.MemoryEvent} returns this
+ */
+proto.iws.MemoryEvent.prototype.clearProcessesList = function () {
+    return this.setProcessesList([]);
+};
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+    /**
+     * Creates an object representation of this proto.
+     * Field names that are reserved in JavaScript and will be renamed to pb_name.
+     * Optional fields that are not set will be set to undefined.
+     * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+     * For the list of reserved names please see:
+     *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+     * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+     *     JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @return {!Object}
+     */
+    proto.iws.MemoryEventProcess.prototype.toObject = function (opt_includeInstance) {
+        return proto.iws.MemoryEventProcess.toObject(opt_includeInstance, this);
+    };
+
+    /**
+     * Static version of the {@see toObject} method.
+     * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+     *     the JSPB instance for transitional soy proto support:
+     *     http://goto/soy-param-migration
+     * @param {!proto.iws.MemoryEventProcess} msg The msg instance to transform.
+     * @return {!Object}
+     * @suppress {unusedLocalVariables} f is only used for nested messages
+     */
+    proto.iws.MemoryEventProcess.toObject = function (includeInstance, msg) {
+        var f,
+            obj = {
+                pid: jspb.Message.getFieldWithDefault(msg, 1, 0),
+                name: jspb.Message.getFieldWithDefault(msg, 2, ""),
+                rss: jspb.Message.getFieldWithDefault(msg, 3, 0),
+            };
+
+        if (includeInstance) {
+            obj.$jspbMessageInstance = msg;
+        }
+        return obj;
+    };
+}
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.iws.MemoryEventProcess}
+ */
+proto.iws.MemoryEventProcess.deserializeBinary = function (bytes) {
+    var reader = new jspb.BinaryReader(bytes);
+    var msg = new proto.iws.MemoryEventProcess();
+    return proto.iws.MemoryEventProcess.deserializeBinaryFromReader(msg, reader);
+};
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.iws.MemoryEventProcess} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.iws.MemoryEventProcess}
+ */
+proto.iws.MemoryEventProcess.deserializeBinaryFromReader = function (msg, reader) {
+    while (reader.nextField()) {
+        if (reader.isEndGroup()) {
+            break;
+        }
+        var field = reader.getFieldNumber();
+        switch (field) {
+            case 1:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setPid(value);
+                break;
+            case 2:
+                var value = /** @type {string} */ (reader.readString());
+                msg.setName(value);
+                break;
+            case 3:
+                var value = /** @type {number} */ (reader.readInt64());
+                msg.setRss(value);
+                break;
+            default:
+                reader.skipField();
+                break;
+        }
+    }
+    return msg;
+};
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.iws.MemoryEventProcess.prototype.serializeBinary = function () {
+    var writer = new jspb.BinaryWriter();
+    proto.iws.MemoryEventProcess.serializeBinaryToWriter(this, writer);
+    return writer.getResultBuffer();
+};
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.iws.MemoryEventProcess} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.iws.MemoryEventProcess.serializeBinaryToWriter = function (message, writer) {
+    var f = undefined;
+    f = message.getPid();
+    if (f !== 0) {
+        writer.writeInt64(1, f);
+    }
+    f = message.getName();
+    if (f.length > 0) {
+        writer.writeString(2, f);
+    }
+    f = message.getRss();
+    if (f !== 0) {
+        writer.writeInt64(3, f);
+    }
+};
+
+/**
+ * optional int64 pid = 1;
+ * @return {number}
+ */
+proto.iws.MemoryEventProcess.prototype.getPid = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.MemoryEventProcess} returns this
+ */
+proto.iws.MemoryEventProcess.prototype.setPid = function (value) {
+    return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+/**
+ * optional string name = 2;
+ * @return {string}
+ */
+proto.iws.MemoryEventProcess.prototype.getName = function () {
+    return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+/**
+ * @param {string} value
+ * @return {!proto.iws.MemoryEventProcess} returns this
+ */
+proto.iws.MemoryEventProcess.prototype.setName = function (value) {
+    return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+/**
+ * optional int64 rss = 3;
+ * @return {number}
+ */
+proto.iws.MemoryEventProcess.prototype.getRss = function () {
+    return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+/**
+ * @param {number} value
+ * @return {!proto.iws.MemoryEventProcess} returns this
+ */
+proto.iws.MemoryEventProcess.prototype.setRss = function (value) {
+    return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+/**
+ * @enum {number}
+ */
+proto.iws.FSShiftMethod = {
+    SHIFTFS: 0,
+    FUSE: 1,
+};
+
+/**
+ * @enum {number}
+ */
+proto.iws.MemoryEventType = {
+    MEMORY_PRESSURE: 0,
+    OOM_KILL: 1,
 };
 
 goog.object.extend(exports, proto.iws);
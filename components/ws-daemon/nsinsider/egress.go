@@ -0,0 +1,317 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package main
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/google/nftables"
+	"github.com/google/nftables/binaryutil"
+	"github.com/google/nftables/expr"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"golang.org/x/xerrors"
+)
+
+const (
+	egressTable       = "gitpod-egress"
+	egressDeniedStats = "ws-egress-denied-stats"
+
+	// minEgressBurst is the minimum number of bytes the egress shaper lets through at once
+	minEgressBurst = 32 * 1024
+)
+
+// egressRule matches outbound traffic to a network, and optionally a TCP or UDP port
+type egressRule struct {
+	Net  *net.IPNet
+	Port uint16
+}
+
+// parseEgressRules parses rules in the form CIDR or CIDR:port, e.g. 10.0.0.0/8:443 or 2001:db8::/32:443
+func parseEgressRules(specs []string) ([]egressRule, error) {
+	var res []egressRule
+	for _, spec := range specs {
+		// IPv6 addresses contain colons, hence the port follows the prefix length
+		addr, prefix, _ := strings.Cut(spec, "/")
+		prefix, port, hasPort := strings.Cut(prefix, ":")
+		_, ipnet, err := net.ParseCIDR(addr + "/" + prefix)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid egress rule %s: %w", spec, err)
+		}
+
+		rule := egressRule{Net: ipnet}
+		if hasPort {
+			p, err := strconv.ParseUint(port, 10, 16)
+			if err != nil {
+				return nil, xerrors.Errorf("invalid egress rule %s: %w", spec, err)
+			}
+			rule.Port = uint16(p)
+		}
+		res = append(res, rule)
+	}
+	return res, nil
+}
+
+// exprs returns the expressions which match the rule, one list per transport protocol
+func (r egressRule) exprs() [][]expr.Any {
+	var (
+		nfproto byte   = unix.NFPROTO_IPV4
+		offset  uint32 = 16
+		ip             = r.Net.IP.To4()
+	)
+	if ip == nil {
+		nfproto, offset, ip = unix.NFPROTO_IPV6, 24, r.Net.IP.To16()
+	}
+
+	// meta nfproto ipv4|ipv6 ip[6] daddr & mask == network
+	daddr := []expr.Any{
+		&expr.Meta{Key: expr.MetaKeyNFPROTO, Register: 1},
+		&expr.Cmp{
+			Register: 1,
+			Op:       expr.CmpOpEq,
+			Data:     []byte{nfproto},
+		},
+		&expr.Payload{
+			DestRegister: 1,
+			Base:         expr.PayloadBaseNetworkHeader,
+			Offset:       offset,
+			Len:          uint32(len(ip)),
+		},
+		&expr.Bitwise{
+			DestRegister:   1,
+			SourceRegister: 1,
+			Len:            uint32(len(ip)),
+			Mask:           []byte(r.Net.Mask),
+			Xor:            make([]byte, len(ip)),
+		},
+		&expr.Cmp{
+			Register: 1,
+			Op:       expr.CmpOpEq,
+			Data:     []byte(ip),
+		},
+	}
+	if r.Port == 0 {
+		return [][]expr.Any{daddr}
+	}
+
+	var res [][]expr.Any
+	for _, proto := range []byte{unix.IPPROTO_TCP, unix.IPPROTO_UDP} {
+		exprs := append([]expr.Any{}, daddr...)
+		exprs = append(exprs,
+			// meta l4proto tcp|udp
+			&expr.Meta{Key: expr.MetaKeyL4PROTO, Register: 1},
+			&expr.Cmp{
+				Register: 1,
+				Op:       expr.CmpOpEq,
+				Data:     []byte{proto},
+			},
+			// th dport port
+			&expr.Payload{
+				DestRegister: 1,
+				Base:         expr.PayloadBaseTransportHeader,
+				Offset:       uint32(2),
+				Len:          uint32(2),
+			},
+			&expr.Cmp{
+				Register: 1,
+				Op:       expr.CmpOpEq,
+				Data:     binaryutil.BigEndian.PutUint16(r.Port),
+			},
+		)
+		res = append(res, exprs)
+	}
+	return res
+}
+
+// setupEgressFilter drops outbound traffic to denied destinations unless it is explicitly allowed,
+// or belongs to a connection which was established from the outside. If there are only allowed
+// destinations, all other outbound traffic is dropped.
+//
+// The filter lives in a table of its own, which is replaced as a whole. Hence, applying it again,
+// e.g. after a restart of ws-daemon, does not duplicate any rules.
+func setupEgressFilter(allow, deny []egressRule) error {
+	links, err := defaultRouteLinks()
+	if err != nil {
+		return err
+	}
+
+	nftcon := nftables.Conn{}
+
+	// nft add table inet gitpod-egress; nft delete table inet gitpod-egress; nft add table inet gitpod-egress
+	table := &nftables.Table{
+		Family: nftables.TableFamilyINet,
+		Name:   egressTable,
+	}
+	nftcon.AddTable(table)
+	nftcon.DelTable(table)
+	nftcon.AddTable(table)
+
+	// nft add chain inet gitpod-egress egress { type filter hook postrouting priority 0 \; }
+	egress := nftcon.AddChain(&nftables.Chain{
+		Table:    table,
+		Name:     "egress",
+		Type:     nftables.ChainTypeFilter,
+		Hooknum:  nftables.ChainHookPostrouting,
+		Priority: nftables.ChainPriorityFilter,
+	})
+
+	// nft add chain inet gitpod-egress filter
+	filter := nftcon.AddChain(&nftables.Chain{
+		Table: table,
+		Name:  "filter",
+	})
+
+	// nft add counter inet gitpod-egress ws-egress-denied-stats
+	nftcon.AddObject(&nftables.CounterObj{
+		Table: table,
+		Name:  egressDeniedStats,
+	})
+
+	// only traffic which leaves the workspace is filtered, not traffic forwarded into it
+	// nft add rule inet gitpod-egress egress meta oif <link> jump filter
+	for _, link := range links {
+		nftcon.AddRule(&nftables.Rule{
+			Table: table,
+			Chain: egress,
+			Exprs: []expr.Any{
+				&expr.Meta{Key: expr.MetaKeyOIF, Register: 1},
+				&expr.Cmp{
+					Register: 1,
+					Op:       expr.CmpOpEq,
+					Data:     binaryutil.NativeEndian.PutUint32(uint32(link)),
+				},
+				&expr.Verdict{Kind: expr.VerdictJump, Chain: filter.Name},
+			},
+		})
+	}
+
+	// nft add rule inet gitpod-egress filter ct state established,related accept
+	nftcon.AddRule(&nftables.Rule{
+		Table: table,
+		Chain: filter,
+		Exprs: []expr.Any{
+			&expr.Ct{
+				Key:      expr.CtKeySTATE,
+				Register: 1,
+			},
+			&expr.Bitwise{
+				DestRegister:   1,
+				SourceRegister: 1,
+				Len:            4,
+				Mask:           binaryutil.NativeEndian.PutUint32(expr.CtStateBitESTABLISHED | expr.CtStateBitRELATED),
+				Xor:            binaryutil.NativeEndian.PutUint32(0),
+			},
+			&expr.Cmp{
+				Register: 1,
+				Op:       expr.CmpOpNeq,
+				Data:     []byte{0, 0, 0, 0},
+			},
+			&expr.Verdict{Kind: expr.VerdictAccept},
+		},
+	})
+
+	// nft add rule inet gitpod-egress filter ip[6] daddr <cidr> [th dport <port>] accept
+	for _, r := range allow {
+		for _, exprs := range r.exprs() {
+			nftcon.AddRule(&nftables.Rule{
+				Table: table,
+				Chain: filter,
+				Exprs: append(exprs, &expr.Verdict{Kind: expr.VerdictAccept}),
+			})
+		}
+	}
+
+	drop := []expr.Any{
+		&expr.Objref{
+			Type: 1,
+			Name: egressDeniedStats,
+		},
+		&expr.Verdict{Kind: expr.VerdictDrop},
+	}
+
+	// nft add rule inet gitpod-egress filter ip[6] daddr <cidr> [th dport <port>] counter name ws-egress-denied-stats drop
+	for _, r := range deny {
+		for _, exprs := range r.exprs() {
+			nftcon.AddRule(&nftables.Rule{
+				Table: table,
+				Chain: filter,
+				Exprs: append(exprs, drop...),
+			})
+		}
+	}
+
+	// nft add rule inet gitpod-egress filter counter name ws-egress-denied-stats drop
+	if len(deny) == 0 {
+		nftcon.AddRule(&nftables.Rule{
+			Table: table,
+			Chain: filter,
+			Exprs: drop,
+		})
+	}
+
+	if err := nftcon.Flush(); err != nil {
+		return xerrors.Errorf("failed to apply egress policy: %v", err)
+	}
+	return nil
+}
+
+// defaultRouteLinks returns the indices of the links which carry an IPv4 or IPv6 default route
+func defaultRouteLinks() ([]int, error) {
+	routes, err := netlink.RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list routes: %v", err)
+	}
+
+	var (
+		res  []int
+		seen = make(map[int]struct{})
+	)
+	for _, r := range routes {
+		if r.Dst != nil {
+			continue
+		}
+		if _, ok := seen[r.LinkIndex]; ok {
+			continue
+		}
+		seen[r.LinkIndex] = struct{}{}
+		res = append(res, r.LinkIndex)
+	}
+	if len(res) == 0 {
+		return nil, xerrors.Errorf("cannot find default route")
+	}
+	return res, nil
+}
+
+// setupEgressShaping shapes the traffic leaving through the interfaces of the default routes to rate bytes per second
+func setupEgressShaping(rate uint64) error {
+	links, err := defaultRouteLinks()
+	if err != nil {
+		return err
+	}
+
+	// allow bursts of 100ms worth of traffic, and queue up to 50ms worth of traffic on top of that
+	burst := rate / 10
+	if burst < minEgressBurst {
+		burst = minEgressBurst
+	}
+	for _, link := range links {
+		tbf := &netlink.Tbf{
+			QdiscAttrs: netlink.QdiscAttrs{
+				LinkIndex: link,
+				Handle:    netlink.MakeHandle(1, 0),
+				Parent:    netlink.HANDLE_ROOT,
+			},
+			Rate:   rate,
+			Buffer: netlink.Xmittime(rate, uint32(burst)),
+			Limit:  uint32(rate/20 + burst),
+		}
+		if err := netlink.QdiscReplace(tbf); err != nil {
+			return xerrors.Errorf("cannot shape egress bandwidth: %v", err)
+		}
+	}
+	return nil
+}
@@ -624,6 +624,50 @@ func main() {
 						return xerrors.Errorf("failed to apply connection limit: %v", err)
 					}
 
+					return nil
+				},
+			},
+			{
+				Name:  "setup-egress-policy",
+				Usage: "set up network egress filtering and bandwidth shaping",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:  "allow",
+						Usage: "destination which is allowed even if denied, as CIDR or CIDR:port. Without denied destinations, all others are denied",
+					},
+					&cli.StringSliceFlag{
+						Name:  "deny",
+						Usage: "destination which is denied, as CIDR or CIDR:port",
+					},
+					&cli.Int64Flag{
+						Name:  "rate",
+						Usage: "egress bandwidth in bytes per second, zero to not shape the bandwidth",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					allow, err := parseEgressRules(c.StringSlice("allow"))
+					if err != nil {
+						return err
+					}
+					deny, err := parseEgressRules(c.StringSlice("deny"))
+					if err != nil {
+						return err
+					}
+
+					if len(allow) > 0 || len(deny) > 0 {
+						err = setupEgressFilter(allow, deny)
+						if err != nil {
+							return err
+						}
+					}
+
+					if rate := c.Int64("rate"); rate > 0 {
+						err = setupEgressShaping(uint64(rate))
+						if err != nil {
+							return err
+						}
+					}
+
 					return nil
 				},
 			},
@@ -89,6 +89,7 @@ func (host *PluginHost) WorkspaceAdded(ctx context.Context, ws *dispatch.Workspa
 		CgroupPath:  cgroupPath,
 		InstanceId:  ws.InstanceID,
 		Annotations: ws.Pod.Annotations,
+		Labels:      ws.Pod.Labels,
 	}
 
 	for _, plg := range host.Plugins {
@@ -131,4 +132,5 @@ type PluginOptions struct {
 	CgroupPath  string
 	InstanceId  string
 	Annotations map[string]string
+	Labels      map[string]string
 }
@@ -6,104 +6,484 @@ package cgroup
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
-	v2 "github.com/containerd/cgroups/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/gitpod-io/gitpod/common-go/cgroups"
+	cgroupsv2 "github.com/gitpod-io/gitpod/common-go/cgroups/v2"
+	"github.com/gitpod-io/gitpod/common-go/kubernetes"
 	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/util"
+	"github.com/gitpod-io/gitpod/ws-daemon/api"
 )
 
-type IOLimiterV2 struct {
-	limits *v2.Resources
+const (
+	// ioThrottledFraction is the fraction of a limit above which we consider a workspace to be throttled
+	ioThrottledFraction = 0.95
+	// defaultIOControlPeriod is used if the config does not specify a control period
+	defaultIOControlPeriod = 10 * time.Second
+)
+
+// IOLimitConfig configures the IO limits of workspaces
+type IOLimitConfig struct {
+	// IOLimits apply to all workspaces whose class has no limits of its own
+	IOLimits
+
+	// Classes configures the IO limits of workspaces by workspace class
+	Classes map[string]IOLimits `json:"classes,omitempty"`
+	// Saturation reduces the limits of all workspaces while the node's disks are saturated
+	Saturation IOSaturationConfig `json:"saturation"`
+	// ControlPeriod is the period in which IO usage is sampled and limits are adjusted
+	ControlPeriod util.Duration `json:"controlPeriod"`
+}
+
+// limits returns the IO limits of a workspace class
+func (c IOLimitConfig) limits(class string) IOLimits {
+	if l, ok := c.Classes[class]; ok {
+		return l
+	}
+	return c.IOLimits
+}
+
+// IORate configures bandwidth and operation limits. Zero values are not limited.
+type IORate struct {
+	WriteBWPerSecond resource.Quantity `json:"writeBandwidthPerSecond"`
+	ReadBWPerSecond  resource.Quantity `json:"readBandwidthPerSecond"`
+	WriteIOPS        int64             `json:"writeIOPS"`
+	ReadIOPS         int64             `json:"readIOPS"`
+}
+
+func (r IORate) max() ioMax {
+	return ioMax{
+		ReadBPS:   r.ReadBWPerSecond.Value(),
+		WriteBPS:  r.WriteBWPerSecond.Value(),
+		ReadIOPS:  r.ReadIOPS,
+		WriteIOPS: r.WriteIOPS,
+	}
+}
+
+// IOLimits are the sustained IO limits of a workspace and the bursts it may spend before them
+type IOLimits struct {
+	IORate
+
+	// Burst lists budgets of IO which workspaces spend at higher limits before the sustained limits apply.
+	// Bursts are spent in order.
+	Burst []IOBurst `json:"burst,omitempty"`
+}
+
+// IOBurst grants higher limits until the workspace has read and written Budget bytes
+type IOBurst struct {
+	IORate
+
+	Budget resource.Quantity `json:"budget"`
+}
+
+// limit decides on the IO limits based on the bytes a workspace has transferred, much like cpulimit.BucketLimiter:
+// the current limits are those of the burst whose budget the workspace is spending. Once all bursts are spent,
+// the sustained limits apply.
+func (l IOLimits) limit(transferred int64) (res ioMax, bursting bool) {
+	for _, b := range l.Burst {
+		budget := b.Budget.Value()
+		if transferred < budget {
+			return b.IORate.max(), true
+		}
+		transferred -= budget
+	}
+
+	return l.IORate.max(), false
+}
+
+// IOSaturationConfig configures how workspace IO limits are reduced while the node's disks are saturated
+type IOSaturationConfig struct {
+	Enabled bool `json:"enabled"`
+	// Threshold is the fraction of time in which any of the node's block devices was busy during one control period,
+	// above which the node counts as saturated, e.g. 0.9
+	Threshold float64 `json:"threshold"`
+	// Factor scales the limits of all workspaces while the node is saturated, e.g. 0.5
+	Factor float64 `json:"factor"`
+}
+
+// ioMax are the limits we write to io.max. Zero values are not limited.
+type ioMax struct {
+	ReadBPS   int64
+	WriteBPS  int64
+	ReadIOPS  int64
+	WriteIOPS int64
+}
+
+// scale scales all limits by f, keeping unlimited values unlimited
+func (m ioMax) scale(f float64) ioMax {
+	scale := func(v int64) int64 {
+		if v <= 0 {
+			return v
+		}
+		res := int64(float64(v) * f)
+		if res < 1 {
+			res = 1
+		}
+		return res
+	}
+	return ioMax{
+		ReadBPS:   scale(m.ReadBPS),
+		WriteBPS:  scale(m.WriteBPS),
+		ReadIOPS:  scale(m.ReadIOPS),
+		WriteIOPS: scale(m.WriteIOPS),
+	}
+}
+
+// reached returns true if the IO between both samples ran at one of the limits
+func (m ioMax) reached(t0, t1 *ioSample) bool {
+	dt := t1.Time.Sub(t0.Time).Seconds()
+	if dt <= 0 {
+		return false
+	}
+	atLimit := func(limit int64, v0, v1 uint64) bool {
+		if limit <= 0 || v1 < v0 {
+			return false
+		}
+		return float64(v1-v0)/dt >= ioThrottledFraction*float64(limit)
+	}
 
-	cond *sync.Cond
+	return atLimit(m.ReadBPS, t0.Stat.ReadBytes, t1.Stat.ReadBytes) ||
+		atLimit(m.WriteBPS, t0.Stat.WriteBytes, t1.Stat.WriteBytes) ||
+		atLimit(m.ReadIOPS, t0.Stat.ReadIOs, t1.Stat.ReadIOs) ||
+		atLimit(m.WriteIOPS, t0.Stat.WriteIOs, t1.Stat.WriteIOs)
+}
+
+// IOLimiterV2 limits the IO of workspaces depending on their workspace class, burst budgets and the saturation
+// of the node's disks.
+type IOLimiterV2 struct {
+	cfg  IOLimitConfig
+	cfgM sync.RWMutex
 
 	devices []string
+	disks   diskSaturation
+
+	workspaces map[string]*ioWorkspace
+	mu         sync.RWMutex
+
+	throttledTotal *prometheus.CounterVec
+	nodeSaturated  prometheus.Gauge
 }
 
-func NewIOLimiterV2(writeBytesPerSecond, readBytesPerSecond, writeIOPs, readIOPs int64) (*IOLimiterV2, error) {
+func NewIOLimiterV2(cfg IOLimitConfig) (*IOLimiterV2, error) {
 	devices := buildDevices()
 	log.WithField("devices", devices).Debug("io limiting devices")
 	return &IOLimiterV2{
-		limits: buildV2Limits(writeBytesPerSecond, readBytesPerSecond, writeIOPs, readIOPs, devices),
-
-		cond:    sync.NewCond(&sync.Mutex{}),
-		devices: devices,
+		cfg:        cfg,
+		devices:    devices,
+		workspaces: make(map[string]*ioWorkspace),
+		throttledTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "workspace_io_throttled_periods_total",
+			Help: "counts the control periods in which workspaces ran at one of their IO limits",
+		}, []string{"class"}),
+		nodeSaturated: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "node_io_saturated",
+			Help: "1 if workspace IO limits are reduced because the node's disks are saturated",
+		}),
 	}, nil
 }
 
 func (c *IOLimiterV2) Name() string  { return "iolimiter-v2" }
 func (c *IOLimiterV2) Type() Version { return Version2 }
 
-func (c *IOLimiterV2) Apply(ctx context.Context, opts *PluginOptions) error {
-	update := make(chan struct{}, 1)
-	go func() {
-		// TODO(cw): this Go-routine will leak per workspace, until we update config or restart ws-daemon
-		defer close(update)
-
-		for {
-			c.cond.L.Lock()
-			c.cond.Wait()
-			c.cond.L.Unlock()
-
-			if ctx.Err() != nil {
-				return
-			}
+func (c *IOLimiterV2) Describe(ch chan<- *prometheus.Desc) {
+	c.throttledTotal.Describe(ch)
+	c.nodeSaturated.Describe(ch)
+}
 
-			update <- struct{}{}
-		}
+func (c *IOLimiterV2) Collect(ch chan<- prometheus.Metric) {
+	c.throttledTotal.Collect(ch)
+	c.nodeSaturated.Collect(ch)
+}
+
+// Update changes the configuration for all workspaces, starting with their next control period
+func (c *IOLimiterV2) Update(cfg IOLimitConfig) {
+	c.cfgM.Lock()
+	defer c.cfgM.Unlock()
+
+	c.cfg = cfg
+	log.WithField("config", cfg).Info("updating I/O cgroups v2 limits")
+}
+
+func (c *IOLimiterV2) config() IOLimitConfig {
+	c.cfgM.RLock()
+	defer c.cfgM.RUnlock()
+
+	return c.cfg
+}
+
+// IOStatus returns the IO limits and usage of a workspace instance, nil if they are unknown
+func (c *IOLimiterV2) IOStatus(instanceID string) *api.IO {
+	c.mu.RLock()
+	ws, ok := c.workspaces[instanceID]
+	c.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	return ws.Status()
+}
+
+func (c *IOLimiterV2) Apply(ctx context.Context, opts *PluginOptions) error {
+	var (
+		fullPath = filepath.Join(opts.BasePath, opts.CgroupPath)
+		class    = opts.Labels[kubernetes.WorkspaceClassLabel]
+		io       = cgroupsv2.NewIOController(fullPath)
+		ws       = &ioWorkspace{}
+	)
+	c.mu.Lock()
+	c.workspaces[opts.InstanceId] = ws
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.workspaces, opts.InstanceId)
+		c.mu.Unlock()
 	}()
 
-	go func() {
-		log.WithField("cgroupPath", opts.CgroupPath).Debug("starting io limiting")
+	log.WithField("cgroupPath", opts.CgroupPath).WithField("class", class).Debug("starting io limiting")
 
-		_, err := v2.NewManager(opts.BasePath, filepath.Join("/", opts.CgroupPath), c.limits)
-		if err != nil {
-			log.WithError(err).WithField("basePath", opts.BasePath).WithField("cgroupPath", opts.CgroupPath).WithField("limits", c.limits).Warn("cannot write IO limits")
+	var applied *ioMax
+	for {
+		cfg := c.config()
+		sample, err := sampleIO(io)
+		if errors.Is(err, fs.ErrNotExist) {
+			// the workspace cgroup has gone
+			return nil
 		}
-
-		for {
-			select {
-			case <-update:
-				_, err := v2.NewManager(opts.BasePath, filepath.Join("/", opts.CgroupPath), c.limits)
-				if err != nil {
-					log.WithError(err).WithField("basePath", opts.BasePath).WithField("cgroupPath", opts.CgroupPath).WithField("limits", c.limits).Error("cannot write IO limits")
-				}
-			case <-ctx.Done():
-				// Prior to shutting down though, we need to reset the IO limits to ensure we don't have
-				// processes stuck in the uninterruptable "D" (disk sleep) state. This would prevent the
-				// workspace pod from shutting down.
-				_, err := v2.NewManager(opts.BasePath, filepath.Join("/", opts.CgroupPath), &v2.Resources{})
+		if err != nil {
+			log.WithError(err).WithField("cgroupPath", opts.CgroupPath).Warn("cannot sample workspace IO")
+		} else {
+			limits, throttled := ws.Observe(cfg.limits(class), cfg.Saturation, c.saturated(cfg), sample)
+			if throttled {
+				c.throttledTotal.WithLabelValues(class).Inc()
+			}
+			if applied == nil || *applied != limits {
+				err := writeV2Limits(fullPath, limits, c.devices)
 				if err != nil {
-					log.WithError(err).WithField("cgroupPath", opts.CgroupPath).Error("cannot write IO limits")
+					log.WithError(err).WithField("basePath", opts.BasePath).WithField("cgroupPath", opts.CgroupPath).WithField("limits", limits).Warn("cannot write IO limits")
+				} else {
+					applied = &limits
 				}
-				log.WithField("cgroupPath", opts.CgroupPath).Debug("stopping io limiting")
-				return
 			}
 		}
-	}()
 
-	return nil
+		period := time.Duration(cfg.ControlPeriod)
+		if period <= 0 {
+			period = defaultIOControlPeriod
+		}
+		select {
+		case <-time.After(period):
+		case <-ctx.Done():
+			// Prior to shutting down though, we need to reset the IO limits to ensure we don't have
+			// processes stuck in the uninterruptable "D" (disk sleep) state. This would prevent the
+			// workspace pod from shutting down.
+			err := writeV2Limits(fullPath, ioMax{}, c.devices)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				log.WithError(err).WithField("cgroupPath", opts.CgroupPath).Error("cannot write IO limits")
+			}
+			log.WithField("cgroupPath", opts.CgroupPath).Debug("stopping io limiting")
+			return nil
+		}
+	}
+}
+
+// saturated samples the node's block devices at most once per control period and returns true if they are saturated
+func (c *IOLimiterV2) saturated(cfg IOLimitConfig) bool {
+	if !cfg.Saturation.Enabled {
+		c.nodeSaturated.Set(0)
+		return false
+	}
+
+	period := time.Duration(cfg.ControlPeriod)
+	if period <= 0 {
+		period = defaultIOControlPeriod
+	}
+	res := c.disks.Sample(time.Now(), period, cfg.Saturation.Threshold, c.devices, readIOTicks)
+	if res {
+		c.nodeSaturated.Set(1)
+	} else {
+		c.nodeSaturated.Set(0)
+	}
+	return res
 }
 
-func (c *IOLimiterV2) Update(writeBytesPerSecond, readBytesPerSecond, writeIOPs, readIOPs int64) {
-	c.cond.L.Lock()
-	defer c.cond.L.Unlock()
+type ioSample struct {
+	Time time.Time
+	Stat cgroups.IOStat
+	// PSI is nil if the kernel does not report pressure stall information
+	PSI *cgroups.PSI
+}
 
-	c.limits = buildV2Limits(writeBytesPerSecond, readBytesPerSecond, writeIOPs, readIOPs, c.devices)
-	log.WithField("limits", c.limits.IO).Info("updating I/O cgroups v2 limits")
+func sampleIO(io *cgroupsv2.IO) (*ioSample, error) {
+	stat, err := io.Stat()
+	if err != nil {
+		return nil, err
+	}
+	res := &ioSample{Time: time.Now(), Stat: *stat}
 
-	c.cond.Broadcast()
+	psi, err := io.PSI()
+	if err == nil {
+		res.PSI = &psi
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return res, nil
 }
 
-func buildV2Limits(writeBytesPerSecond, readBytesPerSecond, writeIOPs, readIOPs int64, devices []string) *v2.Resources {
-	resources := &v2.Resources{
-		IO: &v2.IO{},
+// ioWorkspace tracks the IO of a workspace and decides on its limits
+type ioWorkspace struct {
+	mu sync.Mutex
+
+	first, last      *ioSample
+	limits           ioMax
+	bursting         bool
+	saturated        bool
+	throttledPeriods uint64
+}
+
+// Observe records the sample and returns the limits the workspace should run with until the next sample,
+// and whether the workspace ran at one of its limits since the previous sample.
+func (w *ioWorkspace) Observe(limits IOLimits, saturation IOSaturationConfig, saturated bool, sample *ioSample) (res ioMax, throttled bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.first == nil {
+		w.first = sample
+	}
+	if w.last != nil && w.limits.reached(w.last, sample) {
+		throttled = true
+		w.throttledPeriods++
 	}
 
+	var transferred int64
+	if total, t0 := sample.Stat.ReadBytes+sample.Stat.WriteBytes, w.first.Stat.ReadBytes+w.first.Stat.WriteBytes; total > t0 {
+		transferred = int64(total - t0)
+	}
+	res, w.bursting = limits.limit(transferred)
+
+	w.saturated = saturated && saturation.Factor > 0
+	if w.saturated {
+		res = res.scale(saturation.Factor)
+	}
+
+	w.last = sample
+	w.limits = res
+	return res, throttled
+}
+
+// Status reports the limits and IO usage as of the last sample, nil if there is none
+func (w *ioWorkspace) Status() *api.IO {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.last == nil {
+		return nil
+	}
+
+	res := &api.IO{
+		ReadBandwidthLimit:  w.limits.ReadBPS,
+		WriteBandwidthLimit: w.limits.WriteBPS,
+		ReadIopsLimit:       w.limits.ReadIOPS,
+		WriteIopsLimit:      w.limits.WriteIOPS,
+		ReadBytes:           int64(w.last.Stat.ReadBytes),
+		WriteBytes:          int64(w.last.Stat.WriteBytes),
+		ThrottledPeriods:    w.throttledPeriods,
+		Bursting:            w.bursting,
+		NodeSaturated:       w.saturated,
+	}
+	if w.last.PSI != nil {
+		res.StalledUsec = w.last.PSI.Some
+	}
+	return res
+}
+
+// diskSaturation decides whether the node's block devices are saturated, based on the time they spent doing IO
+type diskSaturation struct {
+	mu sync.Mutex
+
+	last      time.Time
+	ticks     map[string]uint64
+	saturated bool
+}
+
+// Sample reads the IO ticks of all devices unless the last sample is younger than half the period,
+// and returns true if any device was busy for more than threshold of the time in between.
+func (d *diskSaturation) Sample(now time.Time, period time.Duration, threshold float64, devices []string, readTicks func(device string) (uint64, error)) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.last.IsZero() && now.Sub(d.last) < period/2 {
+		return d.saturated
+	}
+
+	ticks := make(map[string]uint64, len(devices))
+	for _, dev := range devices {
+		t, err := readTicks(dev)
+		if err != nil {
+			log.WithError(err).WithField("device", dev).Warn("cannot read IO ticks of device")
+			continue
+		}
+		ticks[dev] = t
+	}
+
+	var utilization float64
+	if elapsed := now.Sub(d.last).Milliseconds(); !d.last.IsZero() && elapsed > 0 {
+		for dev, t := range ticks {
+			t0, ok := d.ticks[dev]
+			if !ok || t < t0 {
+				continue
+			}
+			if u := float64(t-t0) / float64(elapsed); u > utilization {
+				utilization = u
+			}
+		}
+	}
+
+	if d.saturated != (utilization > threshold) {
+		log.WithField("utilization", utilization).WithField("saturated", !d.saturated).Info("node disk saturation changed")
+	}
+	d.last = now
+	d.ticks = ticks
+	d.saturated = utilization > threshold
+	return d.saturated
+}
+
+// readIOTicks reads the number of milliseconds a block device has spent doing IO
+func readIOTicks(device string) (uint64, error) {
+	content, err := os.ReadFile(filepath.Join("/sys/dev/block", device, "stat"))
+	if err != nil {
+		return 0, err
+	}
+	return parseIOTicks(string(content))
+}
+
+// parseIOTicks parses the io_ticks field of a block device stat file, see
+// https://www.kernel.org/doc/Documentation/block/stat.txt
+func parseIOTicks(stat string) (uint64, error) {
+	fields := strings.Fields(stat)
+	if len(fields) < 10 {
+		return 0, fmt.Errorf("invalid block device stat: %s", stat)
+	}
+
+	return strconv.ParseUint(fields[9], 10, 64)
+}
+
+// buildV2Limits renders one io.max entry per device. Fields without a limit are written as max, such that limits
+// which have been set before, e.g. during a burst, are lifted. The kernel keeps fields which are not written.
+func buildV2Limits(limits ioMax, devices []string) []string {
+	var res []string
 	for _, device := range devices {
 		majmin := strings.Split(device, ":")
 		if len(majmin) != 2 {
@@ -123,26 +503,31 @@ func buildV2Limits(writeBytesPerSecond, readBytesPerSecond, writeIOPs, readIOPs
 			continue
 		}
 
-		if readBytesPerSecond > 0 {
-			resources.IO.Max = append(resources.IO.Max, v2.Entry{Major: major, Minor: minor, Type: v2.ReadBPS, Rate: uint64(readBytesPerSecond)})
-		}
+		res = append(res, fmt.Sprintf("%d:%d rbps=%s wbps=%s riops=%s wiops=%s", major, minor,
+			ioMaxValue(limits.ReadBPS), ioMaxValue(limits.WriteBPS), ioMaxValue(limits.ReadIOPS), ioMaxValue(limits.WriteIOPS)))
+	}
 
-		if readIOPs > 0 {
-			resources.IO.Max = append(resources.IO.Max, v2.Entry{Major: major, Minor: minor, Type: v2.ReadIOPS, Rate: uint64(readIOPs)})
-		}
+	log.WithField("ioMax", res).Debug("cgroups v2 limits")
 
-		if writeBytesPerSecond > 0 {
-			resources.IO.Max = append(resources.IO.Max, v2.Entry{Major: major, Minor: minor, Type: v2.WriteBPS, Rate: uint64(writeBytesPerSecond)})
-		}
+	return res
+}
 
-		if writeIOPs > 0 {
-			resources.IO.Max = append(resources.IO.Max, v2.Entry{Major: major, Minor: minor, Type: v2.WriteIOPS, Rate: uint64(writeIOPs)})
-		}
+func ioMaxValue(v int64) string {
+	if v <= 0 {
+		return "max"
 	}
+	return strconv.FormatInt(v, 10)
+}
 
-	log.WithField("resources", resources).Debug("cgroups v2 limits")
-
-	return resources
+// writeV2Limits writes the limits to the io.max file of the cgroup, one device per write as the kernel expects.
+func writeV2Limits(cgroupPath string, limits ioMax, devices []string) error {
+	for _, entry := range buildV2Limits(limits, devices) {
+		err := os.WriteFile(filepath.Join(cgroupPath, "io.max"), []byte(entry), 0644)
+		if err != nil {
+			return fmt.Errorf("cannot write io.max entry %q: %w", entry, err)
+		}
+	}
+	return nil
 }
 
 // TODO: enable custom configuration
@@ -0,0 +1,273 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cgroup
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/gitpod-io/gitpod/common-go/cgroups"
+)
+
+func TestIOLimitsLimit(t *testing.T) {
+	limits := IOLimits{
+		IORate: IORate{ReadBWPerSecond: resource.MustParse("100Mi"), WriteBWPerSecond: resource.MustParse("50Mi")},
+		Burst: []IOBurst{
+			{Budget: resource.MustParse("1Gi"), IORate: IORate{ReadBWPerSecond: resource.MustParse("1Gi"), WriteBWPerSecond: resource.MustParse("500Mi")}},
+			{Budget: resource.MustParse("2Gi"), IORate: IORate{ReadBWPerSecond: resource.MustParse("500Mi"), WriteBWPerSecond: resource.MustParse("200Mi")}},
+		},
+	}
+
+	tests := []struct {
+		Name        string
+		Limits      IOLimits
+		Transferred int64
+		Expectation ioMax
+		Bursting    bool
+	}{
+		{
+			Name:        "no bursts",
+			Limits:      IOLimits{IORate: IORate{ReadIOPS: 1000, WriteIOPS: 500}},
+			Transferred: 1 << 40,
+			Expectation: ioMax{ReadIOPS: 1000, WriteIOPS: 500},
+		},
+		{
+			Name:        "first burst",
+			Limits:      limits,
+			Transferred: 512 << 20,
+			Expectation: ioMax{ReadBPS: 1 << 30, WriteBPS: 500 << 20},
+			Bursting:    true,
+		},
+		{
+			Name:        "second burst",
+			Limits:      limits,
+			Transferred: 2 << 30,
+			Expectation: ioMax{ReadBPS: 500 << 20, WriteBPS: 200 << 20},
+			Bursting:    true,
+		},
+		{
+			Name:        "bursts spent",
+			Limits:      limits,
+			Transferred: 3 << 30,
+			Expectation: ioMax{ReadBPS: 100 << 20, WriteBPS: 50 << 20},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, bursting := test.Limits.limit(test.Transferred)
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("limit() mismatch (-want +got):\n%s", diff)
+			}
+			if bursting != test.Bursting {
+				t.Errorf("expected bursting to be %v, got %v", test.Bursting, bursting)
+			}
+		})
+	}
+}
+
+func TestIOWorkspaceObserve(t *testing.T) {
+	var (
+		start  = time.Unix(1680000000, 0)
+		limits = IOLimits{
+			IORate: IORate{WriteBWPerSecond: resource.MustParse("1000"), WriteIOPS: 100},
+			Burst: []IOBurst{
+				{Budget: resource.MustParse("5000"), IORate: IORate{WriteBWPerSecond: resource.MustParse("2000"), WriteIOPS: 200}},
+			},
+		}
+		saturation = IOSaturationConfig{Enabled: true, Threshold: 0.9, Factor: 0.5}
+	)
+	sample := func(offset time.Duration, writeBytes uint64) *ioSample {
+		return &ioSample{
+			Time: start.Add(offset),
+			Stat: cgroups.IOStat{WriteBytes: writeBytes},
+			PSI:  &cgroups.PSI{Some: 42},
+		}
+	}
+
+	type observation struct {
+		Limits    ioMax
+		Throttled bool
+	}
+	tests := []struct {
+		Name        string
+		Samples     []*ioSample
+		Saturated   []bool
+		Expectation []observation
+	}{
+		{
+			Name:        "first sample bursts",
+			Samples:     []*ioSample{sample(0, 100000)},
+			Expectation: []observation{{Limits: ioMax{WriteBPS: 2000, WriteIOPS: 200}}},
+		},
+		{
+			Name:    "burst is spent",
+			Samples: []*ioSample{sample(0, 0), sample(time.Second, 1000), sample(4*time.Second, 6000)},
+			Expectation: []observation{
+				{Limits: ioMax{WriteBPS: 2000, WriteIOPS: 200}},
+				{Limits: ioMax{WriteBPS: 2000, WriteIOPS: 200}},
+				{Limits: ioMax{WriteBPS: 1000, WriteIOPS: 100}},
+			},
+		},
+		{
+			Name:    "throttled at limit",
+			Samples: []*ioSample{sample(0, 0), sample(time.Second, 1990), sample(2*time.Second, 2500)},
+			Expectation: []observation{
+				{Limits: ioMax{WriteBPS: 2000, WriteIOPS: 200}},
+				{Limits: ioMax{WriteBPS: 2000, WriteIOPS: 200}, Throttled: true},
+				{Limits: ioMax{WriteBPS: 2000, WriteIOPS: 200}},
+			},
+		},
+		{
+			Name:      "saturated node",
+			Samples:   []*ioSample{sample(0, 0), sample(time.Second, 0), sample(2*time.Second, 0)},
+			Saturated: []bool{false, true, false},
+			Expectation: []observation{
+				{Limits: ioMax{WriteBPS: 2000, WriteIOPS: 200}},
+				{Limits: ioMax{WriteBPS: 1000, WriteIOPS: 100}},
+				{Limits: ioMax{WriteBPS: 2000, WriteIOPS: 200}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				ws  ioWorkspace
+				act []observation
+			)
+			for i, s := range test.Samples {
+				var saturated bool
+				if i < len(test.Saturated) {
+					saturated = test.Saturated[i]
+				}
+				limits, throttled := ws.Observe(limits, saturation, saturated, s)
+				act = append(act, observation{Limits: limits, Throttled: throttled})
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildV2Limits(t *testing.T) {
+	act := buildV2Limits(ioMax{ReadBPS: 1 << 20, WriteIOPS: 100}, []string{"259:0", "invalid", "8:0"})
+	expectation := []string{
+		"259:0 rbps=1048576 wbps=max riops=max wiops=100",
+		"8:0 rbps=1048576 wbps=max riops=max wiops=100",
+	}
+	if diff := cmp.Diff(expectation, act); diff != "" {
+		t.Errorf("buildV2Limits() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildV2LimitsBurstEnds(t *testing.T) {
+	limits := IOLimits{
+		IORate: IORate{WriteBWPerSecond: resource.MustParse("50Mi")},
+		Burst: []IOBurst{
+			{Budget: resource.MustParse("1Gi"), IORate: IORate{ReadBWPerSecond: resource.MustParse("1Gi"), WriteBWPerSecond: resource.MustParse("500Mi"), ReadIOPS: 2000}},
+		},
+	}
+
+	// ioMaxFile mimics the kernel, which only changes the fields of a device that are written
+	ioMaxFile := make(map[string]map[string]string)
+	write := func(l ioMax) {
+		for _, entry := range buildV2Limits(l, []string{"259:0"}) {
+			fields := strings.Fields(entry)
+			if ioMaxFile[fields[0]] == nil {
+				ioMaxFile[fields[0]] = make(map[string]string)
+			}
+			for _, f := range fields[1:] {
+				kv := strings.SplitN(f, "=", 2)
+				ioMaxFile[fields[0]][kv[0]] = kv[1]
+			}
+		}
+	}
+
+	burst, bursting := limits.limit(0)
+	if !bursting {
+		t.Fatal("expected to burst")
+	}
+	write(burst)
+	sustained, bursting := limits.limit(2 << 30)
+	if bursting {
+		t.Fatal("expected the burst to have ended")
+	}
+	write(sustained)
+
+	expectation := map[string]map[string]string{
+		"259:0": {"rbps": "max", "wbps": "52428800", "riops": "max", "wiops": "max"},
+	}
+	if diff := cmp.Diff(expectation, ioMaxFile); diff != "" {
+		t.Errorf("io.max after the burst mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestIOWorkspaceStatus(t *testing.T) {
+	var ws ioWorkspace
+	if status := ws.Status(); status != nil {
+		t.Fatalf("expected no status before the first sample, got %v", status)
+	}
+
+	ws.Observe(IOLimits{IORate: IORate{ReadIOPS: 10}}, IOSaturationConfig{}, false, &ioSample{
+		Time: time.Now(),
+		Stat: cgroups.IOStat{ReadBytes: 1, WriteBytes: 2},
+		PSI:  &cgroups.PSI{Some: 3},
+	})
+	status := ws.Status()
+	if status.ReadIopsLimit != 10 || status.ReadBytes != 1 || status.WriteBytes != 2 || status.StalledUsec != 3 {
+		t.Errorf("unexpected status: %v", status)
+	}
+}
+
+func TestDiskSaturationSample(t *testing.T) {
+	var (
+		start = time.Unix(1680000000, 0)
+		ticks = map[string]uint64{"259:0": 0, "253:0": 0}
+		read  = func(device string) (uint64, error) { return ticks[device], nil }
+		disks diskSaturation
+	)
+	devices := []string{"259:0", "253:0"}
+
+	if disks.Sample(start, 10*time.Second, 0.9, devices, read) {
+		t.Fatal("first sample must not be saturated")
+	}
+
+	ticks["253:0"] = 9500
+	if !disks.Sample(start.Add(10*time.Second), 10*time.Second, 0.9, devices, read) {
+		t.Fatal("expected node to be saturated")
+	}
+
+	// samples younger than half the period return the previous result
+	ticks["253:0"] = 9500
+	if !disks.Sample(start.Add(12*time.Second), 10*time.Second, 0.9, devices, read) {
+		t.Fatal("expected cached result")
+	}
+
+	ticks["259:0"] = 1000
+	if disks.Sample(start.Add(20*time.Second), 10*time.Second, 0.9, devices, read) {
+		t.Fatal("expected node not to be saturated")
+	}
+}
+
+func TestParseIOTicks(t *testing.T) {
+	ticks, err := parseIOTicks("  305064     4622 17455262   135528   283218   160373 10464296  1069348        0   322540  1217824        0        0        0        0    14431    12946\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticks != 322540 {
+		t.Errorf("expected 322540 io ticks, got %d", ticks)
+	}
+
+	if _, err := parseIOTicks("1 2 3"); err == nil {
+		t.Error("expected an error for a short stat line")
+	}
+}
@@ -0,0 +1,408 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cgroup
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/process"
+
+	"github.com/gitpod-io/gitpod/common-go/cgroups"
+	v2 "github.com/gitpod-io/gitpod/common-go/cgroups/v2"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/util"
+	"github.com/gitpod-io/gitpod/ws-daemon/api"
+)
+
+const (
+	// maxMemoryEvents is the number of events we keep per workspace
+	maxMemoryEvents = 10
+	// memoryPressureTopConsumers is the number of processes named in a memory pressure warning
+	memoryPressureTopConsumers = 3
+)
+
+// MemoryPressureConfig configures the memory pressure warnings of workspaces
+type MemoryPressureConfig struct {
+	Enabled bool `json:"enabled"`
+	// UsageThreshold is the fraction of the memory limit above which a warning is issued, e.g. 0.9
+	UsageThreshold float64 `json:"usageThreshold"`
+	// PressureThreshold is the fraction of time in which some processes stalled on memory during one interval,
+	// above which a warning is issued, e.g. 0.2
+	PressureThreshold float64 `json:"pressureThreshold"`
+	// Interval is the period in which memory usage, events and pressure are sampled
+	Interval util.Duration `json:"interval"`
+	// WarningCooldown is the minimum time between two warnings for the same workspace
+	WarningCooldown util.Duration `json:"warningCooldown"`
+}
+
+// MemoryPressureV2 watches memory.events and the memory pressure of workspaces. It records warnings when a
+// workspace is about to run out of memory, and OOM kills including the names of the killed processes.
+type MemoryPressureV2 struct {
+	cfg  MemoryPressureConfig
+	cfgM sync.RWMutex
+
+	workspaces map[string]*memoryEventLog
+	mu         sync.RWMutex
+
+	eventsTotal *prometheus.CounterVec
+}
+
+func NewMemoryPressureV2(cfg MemoryPressureConfig) *MemoryPressureV2 {
+	return &MemoryPressureV2{
+		cfg:        cfg,
+		workspaces: make(map[string]*memoryEventLog),
+		eventsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "workspace_memory_events_total",
+			Help: "counts the memory pressure warnings and OOM kills of workspaces",
+		}, []string{"type"}),
+	}
+}
+
+func (c *MemoryPressureV2) Name() string  { return "memory-pressure-v2" }
+func (c *MemoryPressureV2) Type() Version { return Version2 }
+
+func (c *MemoryPressureV2) Describe(ch chan<- *prometheus.Desc) { c.eventsTotal.Describe(ch) }
+func (c *MemoryPressureV2) Collect(ch chan<- prometheus.Metric) { c.eventsTotal.Collect(ch) }
+
+// Update changes the configuration for all workspaces, starting with their next sample
+func (c *MemoryPressureV2) Update(cfg MemoryPressureConfig) {
+	c.cfgM.Lock()
+	defer c.cfgM.Unlock()
+
+	c.cfg = cfg
+	log.WithField("config", cfg).Info("updating memory pressure config")
+}
+
+func (c *MemoryPressureV2) config() MemoryPressureConfig {
+	c.cfgM.RLock()
+	defer c.cfgM.RUnlock()
+
+	return c.cfg
+}
+
+// MemoryEvents returns the most recent memory events of a workspace instance, oldest first
+func (c *MemoryPressureV2) MemoryEvents(instanceID string) []*api.MemoryEvent {
+	c.mu.RLock()
+	events, ok := c.workspaces[instanceID]
+	c.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	return events.List()
+}
+
+func (c *MemoryPressureV2) Apply(ctx context.Context, opts *PluginOptions) error {
+	fullPath := filepath.Join(opts.BasePath, opts.CgroupPath)
+	if _, err := os.Stat(fullPath); err != nil {
+		return err
+	}
+
+	events := &memoryEventLog{}
+	c.mu.Lock()
+	c.workspaces[opts.InstanceId] = events
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.workspaces, opts.InstanceId)
+		c.mu.Unlock()
+	}()
+
+	var (
+		memory  = v2.NewMemoryController(fullPath)
+		watcher memoryWatcher
+	)
+	for {
+		interval := time.Duration(c.config().Interval)
+		if interval <= 0 {
+			interval = 10 * time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+
+		cfg := c.config()
+		if !cfg.Enabled {
+			continue
+		}
+
+		sample, err := sampleMemory(memory, fullPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			// the workspace cgroup has gone
+			return nil
+		}
+		if err != nil {
+			log.WithError(err).WithField("instanceId", opts.InstanceId).Warn("cannot sample workspace memory")
+			continue
+		}
+
+		for _, evt := range watcher.Observe(cfg, sample) {
+			log.WithField("instanceId", opts.InstanceId).WithField("event", evt).Info("recording memory event")
+			c.eventsTotal.WithLabelValues(evt.Type.String()).Inc()
+			events.Add(evt)
+		}
+	}
+}
+
+// memoryEventLog keeps the most recent memory events of a workspace
+type memoryEventLog struct {
+	seq    uint64
+	events []*api.MemoryEvent
+	mu     sync.Mutex
+}
+
+// Add assigns the next sequence number to the event and records it
+func (l *memoryEventLog) Add(evt *api.MemoryEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.seq++
+	evt.Seq = l.seq
+	l.events = append(l.events, evt)
+	if len(l.events) > maxMemoryEvents {
+		l.events = l.events[len(l.events)-maxMemoryEvents:]
+	}
+}
+
+// List returns the recorded events, oldest first
+func (l *memoryEventLog) List() []*api.MemoryEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	res := make([]*api.MemoryEvent, len(l.events))
+	copy(res, l.events)
+	return res
+}
+
+// memoryConsumer is a process of a workspace and its resident set size
+type memoryConsumer struct {
+	PID  int64
+	Name string
+	RSS  uint64
+}
+
+type memorySample struct {
+	Time time.Time
+	// Used is the memory usage in bytes excluding inactive file caches, like reported by gp top
+	Used uint64
+	// Limit is the memory limit in bytes, or zero if the workspace has no limit
+	Limit  uint64
+	Events cgroups.MemoryEvents
+	// PSI is nil if the kernel does not report pressure stall information
+	PSI *cgroups.PSI
+	// Consumers are the processes of the workspace, ordered by RSS descending
+	Consumers []memoryConsumer
+}
+
+func sampleMemory(memory *v2.Memory, cgroupPath string) (*memorySample, error) {
+	res := &memorySample{Time: time.Now()}
+
+	limit, err := memory.Max()
+	if err != nil {
+		return nil, err
+	}
+	if limit != math.MaxUint64 {
+		res.Limit = limit
+	}
+
+	res.Used, err = memory.Current()
+	if err != nil {
+		return nil, err
+	}
+	stats, err := memory.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if res.Used < stats.InactiveFileTotal {
+		res.Used = 0
+	} else {
+		res.Used -= stats.InactiveFileTotal
+	}
+
+	events, err := memory.Events()
+	if err != nil {
+		return nil, err
+	}
+	res.Events = *events
+
+	psi, err := memory.PSI()
+	if err == nil {
+		res.PSI = &psi
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	res.Consumers, err = listMemoryConsumers(cgroupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// listMemoryConsumers lists all processes in the cgroup and its descendants, ordered by RSS descending
+func listMemoryConsumers(cgroupPath string) ([]memoryConsumer, error) {
+	var res []memoryConsumer
+	err := filepath.WalkDir(cgroupPath, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path != cgroupPath {
+			// the child cgroup has gone in the meantime
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != "cgroup.procs" {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			// the child cgroup has gone in the meantime
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if len(line) == 0 {
+				continue
+			}
+
+			pid, err := strconv.ParseInt(line, 10, 64)
+			if err != nil {
+				log.WithError(err).WithField("line", line).Warn("cannot parse pid")
+				continue
+			}
+
+			proc, err := process.NewProcess(int32(pid))
+			if err != nil {
+				// the process has exited in the meantime
+				continue
+			}
+			name, err := proc.Name()
+			if err != nil {
+				continue
+			}
+			mem, err := proc.MemoryInfo()
+			if err != nil {
+				continue
+			}
+
+			res = append(res, memoryConsumer{PID: pid, Name: name, RSS: mem.RSS})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].RSS > res[j].RSS })
+	return res, nil
+}
+
+// memoryWatcher turns consecutive memory samples of a workspace into memory events
+type memoryWatcher struct {
+	last        *memorySample
+	lastWarning time.Time
+}
+
+// Observe compares the sample to the previous one and produces the events that happened in between
+func (w *memoryWatcher) Observe(cfg MemoryPressureConfig, sample *memorySample) []*api.MemoryEvent {
+	last := w.last
+	w.last = sample
+	if last == nil {
+		// memory.events and PSI are counters, hence we need two samples to make sense of them
+		return nil
+	}
+
+	var res []*api.MemoryEvent
+	if killed := sample.Events.OOMKill; killed > last.Events.OOMKill {
+		res = append(res, &api.MemoryEvent{
+			Type:      api.MemoryEventType_OOM_KILL,
+			Time:      sample.Time.Unix(),
+			Used:      int64(sample.Used),
+			Limit:     int64(sample.Limit),
+			Processes: killedProcesses(last.Consumers, sample.Consumers, int(killed-last.Events.OOMKill)),
+		})
+	}
+
+	var underPressure bool
+	if cfg.UsageThreshold > 0 && sample.Limit > 0 && float64(sample.Used) >= cfg.UsageThreshold*float64(sample.Limit) {
+		underPressure = true
+	}
+	if cfg.PressureThreshold > 0 && sample.PSI != nil && last.PSI != nil && sample.PSI.Some >= last.PSI.Some {
+		// PSI totals are reported in microseconds
+		elapsed := sample.Time.Sub(last.Time).Microseconds()
+		if elapsed > 0 && float64(sample.PSI.Some-last.PSI.Some)/float64(elapsed) >= cfg.PressureThreshold {
+			underPressure = true
+		}
+	}
+	if underPressure && sample.Time.Sub(w.lastWarning) >= time.Duration(cfg.WarningCooldown) {
+		w.lastWarning = sample.Time
+
+		consumers := sample.Consumers
+		if len(consumers) > memoryPressureTopConsumers {
+			consumers = consumers[:memoryPressureTopConsumers]
+		}
+		res = append(res, &api.MemoryEvent{
+			Type:      api.MemoryEventType_MEMORY_PRESSURE,
+			Time:      sample.Time.Unix(),
+			Used:      int64(sample.Used),
+			Limit:     int64(sample.Limit),
+			Processes: toMemoryEventProcesses(consumers),
+		})
+	}
+
+	return res
+}
+
+// killedProcesses guesses which processes the OOM killer terminated: memory.events does not name them,
+// but the killer picks the largest processes, so we take the largest ones that vanished since the last sample.
+// Processes which started and were killed in between two samples cannot be named.
+func killedProcesses(before, after []memoryConsumer, count int) []*api.MemoryEventProcess {
+	alive := make(map[int64]struct{}, len(after))
+	for _, p := range after {
+		alive[p.PID] = struct{}{}
+	}
+
+	var gone []memoryConsumer
+	for _, p := range before {
+		if _, ok := alive[p.PID]; ok {
+			continue
+		}
+		gone = append(gone, p)
+		if len(gone) == count {
+			break
+		}
+	}
+	return toMemoryEventProcesses(gone)
+}
+
+func toMemoryEventProcesses(consumers []memoryConsumer) []*api.MemoryEventProcess {
+	res := make([]*api.MemoryEventProcess, 0, len(consumers))
+	for _, p := range consumers {
+		res = append(res, &api.MemoryEventProcess{
+			Pid:  p.PID,
+			Name: p.Name,
+			Rss:  int64(p.RSS),
+		})
+	}
+	return res
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cgroup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/gitpod-io/gitpod/common-go/cgroups"
+	"github.com/gitpod-io/gitpod/common-go/util"
+	"github.com/gitpod-io/gitpod/ws-daemon/api"
+)
+
+func TestMemoryWatcherObserve(t *testing.T) {
+	var (
+		start = time.Unix(1680000000, 0)
+		cfg   = MemoryPressureConfig{
+			Enabled:           true,
+			UsageThreshold:    0.9,
+			PressureThreshold: 0.2,
+			Interval:          util.Duration(10 * time.Second),
+			WarningCooldown:   util.Duration(time.Minute),
+		}
+		consumers = []memoryConsumer{
+			{PID: 10, Name: "java", RSS: 3000},
+			{PID: 11, Name: "node", RSS: 2000},
+			{PID: 12, Name: "gopls", RSS: 1000},
+			{PID: 13, Name: "bash", RSS: 10},
+		}
+	)
+	sample := func(offset time.Duration, used uint64, oomKill uint64, psiSome uint64, consumers []memoryConsumer) *memorySample {
+		return &memorySample{
+			Time:      start.Add(offset),
+			Used:      used,
+			Limit:     10000,
+			Events:    cgroups.MemoryEvents{OOMKill: oomKill},
+			PSI:       &cgroups.PSI{Some: psiSome},
+			Consumers: consumers,
+		}
+	}
+
+	tests := []struct {
+		Name        string
+		Samples     []*memorySample
+		Expectation []*api.MemoryEvent
+	}{
+		{
+			Name: "first sample",
+			Samples: []*memorySample{
+				sample(0, 9500, 3, 0, consumers),
+			},
+		},
+		{
+			Name: "no pressure",
+			Samples: []*memorySample{
+				sample(0, 1000, 0, 0, consumers),
+				sample(10*time.Second, 2000, 0, 1000, consumers),
+			},
+		},
+		{
+			Name: "usage above threshold",
+			Samples: []*memorySample{
+				sample(0, 1000, 0, 0, consumers),
+				sample(10*time.Second, 9500, 0, 0, consumers),
+			},
+			Expectation: []*api.MemoryEvent{
+				{
+					Type:  api.MemoryEventType_MEMORY_PRESSURE,
+					Time:  start.Add(10 * time.Second).Unix(),
+					Used:  9500,
+					Limit: 10000,
+					Processes: []*api.MemoryEventProcess{
+						{Pid: 10, Name: "java", Rss: 3000},
+						{Pid: 11, Name: "node", Rss: 2000},
+						{Pid: 12, Name: "gopls", Rss: 1000},
+					},
+				},
+			},
+		},
+		{
+			Name: "stalled above threshold",
+			Samples: []*memorySample{
+				sample(0, 1000, 0, 0, consumers[3:]),
+				sample(10*time.Second, 1000, 0, 3_000_000, consumers[3:]),
+			},
+			Expectation: []*api.MemoryEvent{
+				{
+					Type:      api.MemoryEventType_MEMORY_PRESSURE,
+					Time:      start.Add(10 * time.Second).Unix(),
+					Used:      1000,
+					Limit:     10000,
+					Processes: []*api.MemoryEventProcess{{Pid: 13, Name: "bash", Rss: 10}},
+				},
+			},
+		},
+		{
+			Name: "warnings respect cooldown",
+			Samples: []*memorySample{
+				sample(0, 1000, 0, 0, consumers[3:]),
+				sample(10*time.Second, 9500, 0, 0, consumers[3:]),
+				sample(20*time.Second, 9500, 0, 0, consumers[3:]),
+				sample(70*time.Second, 9500, 0, 0, consumers[3:]),
+			},
+			Expectation: []*api.MemoryEvent{
+				{
+					Type:      api.MemoryEventType_MEMORY_PRESSURE,
+					Time:      start.Add(10 * time.Second).Unix(),
+					Used:      9500,
+					Limit:     10000,
+					Processes: []*api.MemoryEventProcess{{Pid: 13, Name: "bash", Rss: 10}},
+				},
+				{
+					Type:      api.MemoryEventType_MEMORY_PRESSURE,
+					Time:      start.Add(70 * time.Second).Unix(),
+					Used:      9500,
+					Limit:     10000,
+					Processes: []*api.MemoryEventProcess{{Pid: 13, Name: "bash", Rss: 10}},
+				},
+			},
+		},
+		{
+			Name: "OOM kill names the largest vanished processes",
+			Samples: []*memorySample{
+				sample(0, 8000, 1, 0, consumers),
+				sample(10*time.Second, 2000, 3, 0, []memoryConsumer{consumers[1], consumers[3]}),
+			},
+			Expectation: []*api.MemoryEvent{
+				{
+					Type:  api.MemoryEventType_OOM_KILL,
+					Time:  start.Add(10 * time.Second).Unix(),
+					Used:  2000,
+					Limit: 10000,
+					Processes: []*api.MemoryEventProcess{
+						{Pid: 10, Name: "java", Rss: 3000},
+						{Pid: 12, Name: "gopls", Rss: 1000},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				watcher memoryWatcher
+				act     []*api.MemoryEvent
+			)
+			for _, s := range test.Samples {
+				act = append(act, watcher.Observe(cfg, s)...)
+			}
+
+			if diff := cmp.Diff(test.Expectation, act, protocmp.Transform()); diff != "" {
+				t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMemoryEventLog(t *testing.T) {
+	var log memoryEventLog
+	for i := 0; i < maxMemoryEvents+2; i++ {
+		log.Add(&api.MemoryEvent{})
+	}
+
+	events := log.List()
+	if len(events) != maxMemoryEvents {
+		t.Fatalf("expected %d events, got %d", maxMemoryEvents, len(events))
+	}
+	if first, last := events[0].Seq, events[len(events)-1].Seq; first != 3 || last != maxMemoryEvents+2 {
+		t.Errorf("expected the most recent events 3..%d, got %d..%d", maxMemoryEvents+2, first, last)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/util"
 	cntntcfg "github.com/gitpod-io/gitpod/content-service/api/config"
 	"github.com/gitpod-io/gitpod/ws-daemon/api"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/quota"
 	"golang.org/x/xerrors"
 )
 
@@ -39,6 +40,10 @@ type Config struct {
 
 	// Initializer configures the isolated content initializer runtime
 	Initializer InitializerConfig `json:"initializer"`
+
+	// QuotaBackend selects how workspace disk quota is enforced: "native" (XFS and ext4), "xfs_quota" (XFS only),
+	// or empty to use the native backend if supported and xfs_quota otherwise.
+	QuotaBackend quota.BackendKind `json:"quotaBackend,omitempty"`
 }
 
 type BackupConfig struct {
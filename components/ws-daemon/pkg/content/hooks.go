@@ -23,10 +23,10 @@ import (
 )
 
 // WorkspaceLifecycleHooks configures the lifecycle hooks for all workspaces
-func WorkspaceLifecycleHooks(cfg Config, workspaceCIDR string, workspaceExistenceCheck WorkspaceExistenceCheck, uidmapper *iws.Uidmapper, xfs *quota.XFS, cgroupMountPoint string) map[session.WorkspaceState][]session.WorkspaceLivecycleHook {
+func WorkspaceLifecycleHooks(cfg Config, workspaceCIDR string, workspaceExistenceCheck WorkspaceExistenceCheck, uidmapper *iws.Uidmapper, diskQuota quota.Backend, cgroupMountPoint string, memoryEvents iws.MemoryEventSource, ioStatus iws.IOStatusSource, networkStatus iws.NetworkStatusSource) map[session.WorkspaceState][]session.WorkspaceLivecycleHook {
 	// startIWS starts the in-workspace service for a workspace. This lifecycle hook is idempotent, hence can - and must -
 	// be called on initialization and ready. The on-ready hook exists only to support ws-daemon restarts.
-	startIWS := iws.ServeWorkspace(uidmapper, api.FSShiftMethod(cfg.UserNamespaces.FSShift), cgroupMountPoint, workspaceCIDR)
+	startIWS := iws.ServeWorkspace(uidmapper, api.FSShiftMethod(cfg.UserNamespaces.FSShift), cgroupMountPoint, workspaceCIDR, diskQuota, memoryEvents, ioStatus, networkStatus)
 
 	return map[session.WorkspaceState][]session.WorkspaceLivecycleHook{
 		session.WorkspaceInitializing: {
@@ -36,16 +36,16 @@ func WorkspaceLifecycleHooks(cfg Config, workspaceCIDR string, workspaceExistenc
 			// When starting a workspace, use soft limit for the following reason to ensure content is restored
 			// - workspacekit needs to generate some temporary file when starting a workspace
 			// - when extracting tar file, tar command create some symlinks following a original content
-			hookInstallQuota(xfs, false),
+			hookInstallQuota(diskQuota, false),
 		},
 		session.WorkspaceReady: {
 			startIWS,
 			hookSetupRemoteStorage(cfg),
-			hookInstallQuota(xfs, true),
+			hookInstallQuota(diskQuota, true),
 		},
 		session.WorkspaceDisposed: {
 			iws.StopServingWorkspace,
-			hookRemoveQuota(xfs),
+			hookRemoveQuota(diskQuota),
 		},
 	}
 }
@@ -106,12 +106,12 @@ func hookSetupWorkspaceLocation(ctx context.Context, ws *session.Workspace) (err
 }
 
 // hookInstallQuota enforces filesystem quota on the workspace location (if the filesystem supports it)
-func hookInstallQuota(xfs *quota.XFS, isHard bool) session.WorkspaceLivecycleHook {
+func hookInstallQuota(diskQuota quota.Backend, isHard bool) session.WorkspaceLivecycleHook {
 	return func(ctx context.Context, ws *session.Workspace) (err error) {
 		span, _ := opentracing.StartSpanFromContext(ctx, "hook.InstallQuota")
 		defer tracing.FinishSpan(span, &err)
 
-		if xfs == nil {
+		if diskQuota == nil {
 			return nil
 		}
 
@@ -127,10 +127,10 @@ func hookInstallQuota(xfs *quota.XFS, isHard bool) session.WorkspaceLivecycleHoo
 			prj int
 		)
 		if ws.XFSProjectID != 0 {
-			xfs.RegisterProject(ws.XFSProjectID)
-			prj, err = xfs.SetQuotaWithPrjId(ws.Location, size, ws.XFSProjectID, isHard)
+			diskQuota.RegisterProject(ws.XFSProjectID)
+			prj, err = diskQuota.SetQuotaWithPrjId(ws.Location, size, ws.XFSProjectID, isHard)
 		} else {
-			prj, err = xfs.SetQuota(ws.Location, size, isHard)
+			prj, err = diskQuota.SetQuota(ws.Location, size, isHard)
 		}
 
 		if err != nil {
@@ -143,22 +143,18 @@ func hookInstallQuota(xfs *quota.XFS, isHard bool) session.WorkspaceLivecycleHoo
 }
 
 // hookRemoveQuota removes the filesystem quota, freeing up resources if need be
-func hookRemoveQuota(xfs *quota.XFS) session.WorkspaceLivecycleHook {
+func hookRemoveQuota(diskQuota quota.Backend) session.WorkspaceLivecycleHook {
 	return func(ctx context.Context, ws *session.Workspace) (err error) {
 		span, _ := opentracing.StartSpanFromContext(ctx, "hook.RemoveQuota")
 		defer tracing.FinishSpan(span, &err)
 
-		if xfs == nil {
-			return nil
-		}
-
-		if xfs == nil {
+		if diskQuota == nil {
 			return nil
 		}
 		if ws.XFSProjectID == 0 {
 			return nil
 		}
 
-		return xfs.RemoveQuota(ws.XFSProjectID)
+		return diskQuota.RemoveQuota(ws.XFSProjectID)
 	}
 }
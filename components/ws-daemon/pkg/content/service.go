@@ -69,7 +69,7 @@ type WorkspaceService struct {
 type WorkspaceExistenceCheck func(instanceID string) bool
 
 // NewWorkspaceService creates a new workspce initialization service, starts housekeeping and the Prometheus integration
-func NewWorkspaceService(ctx context.Context, cfg Config, runtime container.Runtime, wec WorkspaceExistenceCheck, uidmapper *iws.Uidmapper, cgroupMountPoint string, reg prometheus.Registerer, workspaceCIDR string) (res *WorkspaceService, err error) {
+func NewWorkspaceService(ctx context.Context, cfg Config, runtime container.Runtime, wec WorkspaceExistenceCheck, uidmapper *iws.Uidmapper, cgroupMountPoint string, reg prometheus.Registerer, workspaceCIDR string, memoryEvents iws.MemoryEventSource, ioStatus iws.IOStatusSource, networkStatus iws.NetworkStatusSource) (res *WorkspaceService, err error) {
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "NewWorkspaceService")
 	defer tracing.FinishSpan(span, &err)
@@ -80,14 +80,14 @@ func NewWorkspaceService(ctx context.Context, cfg Config, runtime container.Runt
 		return nil, xerrors.Errorf("cannot create working area: %w", err)
 	}
 
-	xfs, err := quota.NewXFS(cfg.WorkingArea)
+	diskQuota, err := quota.New(cfg.WorkingArea, cfg.QuotaBackend)
 	if err != nil {
 		return nil, err
 	}
 
 	// read all session json files
 	store, err := session.NewStore(ctx, cfg.WorkingArea,
-		WorkspaceLifecycleHooks(cfg, workspaceCIDR, wec, uidmapper, xfs, cgroupMountPoint),
+		WorkspaceLifecycleHooks(cfg, workspaceCIDR, wec, uidmapper, diskQuota, cgroupMountPoint, memoryEvents, ioStatus, networkStatus),
 	)
 	if err != nil {
 		return nil, xerrors.Errorf("cannot create session store: %w", err)
@@ -350,24 +350,29 @@ func (s *WorkspaceService) recordInitializerMetrics(path string) {
 }
 
 func (s *WorkspaceService) creator(req *api.InitWorkspaceRequest) session.WorkspaceFactory {
-	var checkoutLocation string
+	var (
+		checkoutLocation            string
+		additionalCheckoutLocations []string
+	)
 	allLocations := csapi.GetCheckoutLocationsFromInitializer(req.Initializer)
 	if len(allLocations) > 0 {
 		checkoutLocation = allLocations[0]
+		additionalCheckoutLocations = allLocations[1:]
 	}
 	return func(ctx context.Context, location string) (res *session.Workspace, err error) {
 		return &session.Workspace{
-			Location:              location,
-			CheckoutLocation:      checkoutLocation,
-			CreatedAt:             time.Now(),
-			Owner:                 req.Metadata.Owner,
-			WorkspaceID:           req.Metadata.MetaId,
-			InstanceID:            req.Id,
-			FullWorkspaceBackup:   req.FullWorkspaceBackup,
-			PersistentVolumeClaim: req.PersistentVolumeClaim,
-			ContentManifest:       req.ContentManifest,
-			RemoteStorageDisabled: req.RemoteStorageDisabled,
-			StorageQuota:          int(req.StorageQuotaBytes),
+			Location:                    location,
+			CheckoutLocation:            checkoutLocation,
+			AdditionalCheckoutLocations: additionalCheckoutLocations,
+			CreatedAt:                   time.Now(),
+			Owner:                       req.Metadata.Owner,
+			WorkspaceID:                 req.Metadata.MetaId,
+			InstanceID:                  req.Id,
+			FullWorkspaceBackup:         req.FullWorkspaceBackup,
+			PersistentVolumeClaim:       req.PersistentVolumeClaim,
+			ContentManifest:             req.ContentManifest,
+			RemoteStorageDisabled:       req.RemoteStorageDisabled,
+			StorageQuota:                int(req.StorageQuotaBytes),
 
 			ServiceLocDaemon: filepath.Join(s.config.WorkingArea, ServiceDirName(req.Id)),
 			ServiceLocNode:   filepath.Join(s.config.WorkingAreaNode, ServiceDirName(req.Id)),
@@ -16,6 +16,7 @@ import (
 	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/container"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/content"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/internal/session"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/iws"
 	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
 	"github.com/opentracing/opentracing-go"
@@ -271,7 +272,7 @@ func (wsc *WorkspaceController) handleWorkspaceStop(ctx context.Context, ws *wor
 		}
 	}
 
-	gitStatus, disposeErr := wsc.operations.BackupWorkspace(ctx, BackupOptions{
+	backup, disposeErr := wsc.operations.BackupWorkspace(ctx, BackupOptions{
 		Meta: WorkspaceMeta{
 			Owner:       ws.Spec.Ownership.Owner,
 			WorkspaceID: ws.Spec.Ownership.WorkspaceID,
@@ -288,7 +289,10 @@ func (wsc *WorkspaceController) handleWorkspaceStop(ctx context.Context, ws *wor
 			return err
 		}
 
-		ws.Status.GitStatus = toWorkspaceGitStatus(gitStatus)
+		if backup != nil {
+			ws.Status.GitStatus = toWorkspaceGitStatus(backup.GitStatus)
+			ws.Status.AdditionalGitStatus = toWorkspaceAdditionalGitStatus(backup.AdditionalGitStatus)
+		}
 
 		if disposeErr != nil {
 			log.Error(disposeErr, "failed to backup workspace", "name", ws.Name)
@@ -363,6 +367,21 @@ func toWorkspaceGitStatus(status *csapi.GitStatus) *workspacev1.GitStatus {
 	}
 }
 
+func toWorkspaceAdditionalGitStatus(status []session.RepositoryGitStatus) []workspacev1.RepositoryGitStatus {
+	var res []workspacev1.RepositoryGitStatus
+	for _, repo := range status {
+		gitStatus := toWorkspaceGitStatus(repo.GitStatus)
+		if gitStatus == nil {
+			continue
+		}
+		res = append(res, workspacev1.RepositoryGitStatus{
+			CheckoutLocation: repo.CheckoutLocation,
+			GitStatus:        *gitStatus,
+		})
+	}
+	return res
+}
+
 type workspaceMetrics struct {
 	initializeTimeHistVec *prometheus.HistogramVec
 	finalizeTimeHistVec   *prometheus.HistogramVec
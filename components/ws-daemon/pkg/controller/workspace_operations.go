@@ -32,7 +32,7 @@ type WorkspaceOperations interface {
 	// InitWorkspace initializes the workspace content
 	InitWorkspace(ctx context.Context, options InitOptions) (string, error)
 	// BackupWorkspace backups the content of the workspace
-	BackupWorkspace(ctx context.Context, opts BackupOptions) (*csapi.GitStatus, error)
+	BackupWorkspace(ctx context.Context, opts BackupOptions) (*BackupResult, error)
 	// DeleteWorkspace deletes the content of the workspace from disk
 	DeleteWorkspace(ctx context.Context, instanceID string) error
 	// SnapshotIDs generates the name and url for a snapshot
@@ -70,6 +70,14 @@ type BackupOptions struct {
 	SnapshotName      string
 }
 
+// BackupResult is the outcome of a successful workspace backup.
+type BackupResult struct {
+	// GitStatus is the Git status of the main repository, if requested and available
+	GitStatus *csapi.GitStatus
+	// AdditionalGitStatus is the Git status of the additional repositories of a multi-repository workspace
+	AdditionalGitStatus []session.RepositoryGitStatus
+}
+
 func NewWorkspaceOperations(config content.Config, provider *WorkspaceProvider, reg prometheus.Registerer) (WorkspaceOperations, error) {
 	waitingTimeHist, waitingTimeoutCounter, err := content.RegisterConcurrentBackupMetrics(reg, "_mk2")
 	if err != nil {
@@ -153,25 +161,30 @@ func (wso *DefaultWorkspaceOperations) InitWorkspace(ctx context.Context, option
 }
 
 func (wso *DefaultWorkspaceOperations) creator(owner, workspaceID, instanceID string, init *csapi.WorkspaceInitializer, storageDisabled bool) session.WorkspaceFactory {
-	var checkoutLocation string
+	var (
+		checkoutLocation            string
+		additionalCheckoutLocations []string
+	)
 	allLocations := csapi.GetCheckoutLocationsFromInitializer(init)
 	if len(allLocations) > 0 {
 		checkoutLocation = allLocations[0]
+		additionalCheckoutLocations = allLocations[1:]
 	}
 
 	serviceDirName := instanceID + "-daemon"
 	return func(ctx context.Context, location string) (res *session.Workspace, err error) {
 		return &session.Workspace{
-			Location:              location,
-			CheckoutLocation:      checkoutLocation,
-			CreatedAt:             time.Now(),
-			Owner:                 owner,
-			WorkspaceID:           workspaceID,
-			InstanceID:            instanceID,
-			FullWorkspaceBackup:   false,
-			PersistentVolumeClaim: false,
-			RemoteStorageDisabled: storageDisabled,
-			IsMk2:                 true,
+			Location:                    location,
+			CheckoutLocation:            checkoutLocation,
+			AdditionalCheckoutLocations: additionalCheckoutLocations,
+			CreatedAt:                   time.Now(),
+			Owner:                       owner,
+			WorkspaceID:                 workspaceID,
+			InstanceID:                  instanceID,
+			FullWorkspaceBackup:         false,
+			PersistentVolumeClaim:       false,
+			RemoteStorageDisabled:       storageDisabled,
+			IsMk2:                       true,
 
 			ServiceLocDaemon: filepath.Join(wso.config.WorkingArea, serviceDirName),
 			ServiceLocNode:   filepath.Join(wso.config.WorkingAreaNode, serviceDirName),
@@ -179,7 +192,7 @@ func (wso *DefaultWorkspaceOperations) creator(owner, workspaceID, instanceID st
 	}
 }
 
-func (wso *DefaultWorkspaceOperations) BackupWorkspace(ctx context.Context, opts BackupOptions) (*csapi.GitStatus, error) {
+func (wso *DefaultWorkspaceOperations) BackupWorkspace(ctx context.Context, opts BackupOptions) (*BackupResult, error) {
 	ws, err := wso.provider.Get(ctx, opts.Meta.InstanceID)
 	if err != nil {
 		return nil, fmt.Errorf("cannot find workspace %s during DisposeWorkspace: %w", opts.Meta.InstanceID, err)
@@ -202,10 +215,11 @@ func (wso *DefaultWorkspaceOperations) BackupWorkspace(ctx context.Context, opts
 		return nil, fmt.Errorf("final backup failed for workspace %s", opts.Meta.InstanceID)
 	}
 
-	var repo *csapi.GitStatus
+	var res BackupResult
 	if opts.UpdateGitStatus {
 		// Update the git status prior to deleting the workspace
-		repo, err = ws.UpdateGitStatus(ctx, false)
+		res.GitStatus, err = ws.UpdateGitStatus(ctx, false)
+		res.AdditionalGitStatus = ws.LastAdditionalGitStatus
 		if err != nil {
 			// do not fail workspace because we were unable to get git status
 			// which can happen for various reasons, including user corrupting his .git folder somehow
@@ -215,7 +229,7 @@ func (wso *DefaultWorkspaceOperations) BackupWorkspace(ctx context.Context, opts
 		}
 	}
 
-	return repo, nil
+	return &res, nil
 }
 
 func (wso *DefaultWorkspaceOperations) DeleteWorkspace(ctx context.Context, instanceID string) error {
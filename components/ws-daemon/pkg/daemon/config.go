@@ -14,22 +14,22 @@ import (
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/diskguard"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/iws"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/netlimit"
-	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // Config configures the workspace node daemon
 type Config struct {
 	Runtime RuntimeConfig `json:"runtime"`
 
-	Content             content.Config            `json:"content"`
-	Uidmapper           iws.UidmapperConfig       `json:"uidmapper"`
-	CPULimit            cpulimit.Config           `json:"cpulimit"`
-	IOLimit             IOLimitConfig             `json:"ioLimit"`
-	ProcLimit           int64                     `json:"procLimit"`
-	NetLimit            netlimit.Config           `json:"netlimit"`
-	OOMScores           cgroup.OOMScoreAdjConfig  `json:"oomScores"`
-	DiskSpaceGuard      diskguard.Config          `json:"disk"`
-	WorkspaceController WorkspaceControllerConfig `json:"workspaceController"`
+	Content             content.Config              `json:"content"`
+	Uidmapper           iws.UidmapperConfig         `json:"uidmapper"`
+	CPULimit            cpulimit.Config             `json:"cpulimit"`
+	IOLimit             cgroup.IOLimitConfig        `json:"ioLimit"`
+	ProcLimit           int64                       `json:"procLimit"`
+	NetLimit            netlimit.Config             `json:"netlimit"`
+	OOMScores           cgroup.OOMScoreAdjConfig    `json:"oomScores"`
+	MemoryPressure      cgroup.MemoryPressureConfig `json:"memoryPressure"`
+	DiskSpaceGuard      diskguard.Config            `json:"disk"`
+	WorkspaceController WorkspaceControllerConfig   `json:"workspaceController"`
 }
 
 type WorkspaceControllerConfig struct {
@@ -47,13 +47,6 @@ type RuntimeConfig struct {
 	WorkspaceCIDR string `json:"workspaceCIDR,omitempty"`
 }
 
-type IOLimitConfig struct {
-	WriteBWPerSecond resource.Quantity `json:"writeBandwidthPerSecond"`
-	ReadBWPerSecond  resource.Quantity `json:"readBandwidthPerSecond"`
-	WriteIOPS        int64             `json:"writeIOPS"`
-	ReadIOPS         int64             `json:"readIOPS"`
-}
-
 type ConfigReloader interface {
 	ReloadConfig(context.Context, *Config) error
 }
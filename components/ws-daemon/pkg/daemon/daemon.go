@@ -96,7 +96,7 @@ func NewDaemon(config Config) (*Daemon, error) {
 		return nil, err
 	}
 
-	cgroupV2IOLimiter, err := cgroup.NewIOLimiterV2(config.IOLimit.WriteBWPerSecond.Value(), config.IOLimit.ReadBWPerSecond.Value(), config.IOLimit.WriteIOPS, config.IOLimit.ReadIOPS)
+	cgroupV2IOLimiter, err := cgroup.NewIOLimiterV2(config.IOLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +106,8 @@ func NewDaemon(config Config) (*Daemon, error) {
 		return nil, err
 	}
 
+	memoryPressurePlugin := cgroup.NewMemoryPressureV2(config.MemoryPressure)
+
 	cgroupPlugins, err := cgroup.NewPluginHost(config.CPULimit.CGroupBasePath,
 		&cgroup.FuseDeviceEnablerV2{},
 		cgroupV2IOLimiter,
@@ -132,6 +134,7 @@ func NewDaemon(config Config) (*Daemon, error) {
 		},
 		procV2Plugin,
 		cgroup.NewPSIMetrics(wrappedReg),
+		memoryPressurePlugin,
 	)
 	if err != nil {
 		return nil, err
@@ -159,8 +162,9 @@ func NewDaemon(config Config) (*Daemon, error) {
 
 	var configReloader CompositeConfigReloader
 	configReloader = append(configReloader, ConfigReloaderFunc(func(ctx context.Context, config *Config) error {
-		cgroupV2IOLimiter.Update(config.IOLimit.WriteBWPerSecond.Value(), config.IOLimit.ReadBWPerSecond.Value(), config.IOLimit.WriteIOPS, config.IOLimit.ReadIOPS)
+		cgroupV2IOLimiter.Update(config.IOLimit)
 		procV2Plugin.Update(config.ProcLimit)
+		memoryPressurePlugin.Update(config.MemoryPressure)
 		if config.NetLimit.Enabled {
 			netlimiter.Update(config.NetLimit)
 		}
@@ -187,7 +191,7 @@ func NewDaemon(config Config) (*Daemon, error) {
 		contentCfg.WorkingArea += config.WorkspaceController.WorkingAreaSuffix
 		contentCfg.WorkingAreaNode += config.WorkspaceController.WorkingAreaSuffix
 
-		xfs, err := quota.NewXFS(contentCfg.WorkingArea)
+		diskQuota, err := quota.New(contentCfg.WorkingArea, contentCfg.QuotaBackend)
 		if err != nil {
 			return nil, err
 		}
@@ -197,8 +201,11 @@ func NewDaemon(config Config) (*Daemon, error) {
 			config.Runtime.WorkspaceCIDR,
 			func(instanceID string) bool { return true },
 			&iws.Uidmapper{Config: config.Uidmapper, Runtime: containerRuntime},
-			xfs,
+			diskQuota,
 			config.CPULimit.CGroupBasePath,
+			memoryPressurePlugin,
+			cgroupV2IOLimiter,
+			netlimiter,
 		)
 
 		workspaceOps, err := controller.NewWorkspaceOperations(contentCfg, controller.NewWorkspaceProvider(hooks, contentCfg.WorkingArea), wrappedReg)
@@ -241,6 +248,9 @@ func NewDaemon(config Config) (*Daemon, error) {
 		config.CPULimit.CGroupBasePath,
 		wrappedReg,
 		config.Runtime.WorkspaceCIDR,
+		memoryPressurePlugin,
+		cgroupV2IOLimiter,
+		netlimiter,
 	)
 	if err != nil {
 		return nil, xerrors.Errorf("cannot create content service: %w", err)
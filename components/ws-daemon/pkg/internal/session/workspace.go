@@ -56,6 +56,9 @@ type Workspace struct {
 	// CheckoutLocation is the path relative to location where the main Git working copy of this
 	// workspace resides. If this workspace has no Git working copy, this field is an empty string.
 	CheckoutLocation string `json:"checkoutLocation"`
+	// AdditionalCheckoutLocations are the paths relative to location where the Git working copies of the
+	// additional repositories of a multi-repository workspace reside.
+	AdditionalCheckoutLocations []string `json:"additionalCheckoutLocations,omitempty"`
 
 	CreatedAt             time.Time        `json:"createdAt"`
 	DoBackup              bool             `json:"doBackup"`
@@ -67,6 +70,9 @@ type Workspace struct {
 	PersistentVolumeClaim bool             `json:"persistentVolumeClaim"`
 	ContentManifest       []byte           `json:"contentManifest"`
 
+	// LastAdditionalGitStatus is the latest Git status of the repositories at AdditionalCheckoutLocations.
+	LastAdditionalGitStatus []RepositoryGitStatus `json:"lastAdditionalGitStatus,omitempty"`
+
 	ServiceLocNode   string `json:"serviceLocNode"`
 	ServiceLocDaemon string `json:"serviceLocDaemon"`
 
@@ -85,6 +91,12 @@ type Workspace struct {
 	operatingCondition *sync.Cond
 }
 
+// RepositoryGitStatus is the Git status of an additional repository of a workspace.
+type RepositoryGitStatus struct {
+	CheckoutLocation string           `json:"checkoutLocation"`
+	GitStatus        *csapi.GitStatus `json:"gitStatus"`
+}
+
 // OWI produces the owner, workspace, instance log metadata from the information
 // of this workspace.
 func (s *Workspace) OWI() logrus.Fields {
@@ -304,20 +316,29 @@ func (s *Workspace) UpdateGitStatus(ctx context.Context, persistentVolumeClaim b
 			return
 		}
 
-		loc = filepath.Join(loc, s.CheckoutLocation)
-		if !git.IsWorkingCopy(loc) {
-			log.WithField("loc", loc).WithField("checkout location", s.CheckoutLocation).WithFields(s.OWI()).Debug("did not find a Git working copy - not updating Git status")
-			return nil, nil
-		}
-
-		c := git.Client{Location: loc}
-
-		stat, err := c.Status(ctx)
+		stat, err := s.gitStatus(ctx, s.CheckoutLocation)
 		if err != nil {
 			return nil, err
 		}
+		if stat == nil {
+			return nil, nil
+		}
+		s.LastGitStatus = stat
 
-		s.LastGitStatus = toGitStatus(stat)
+		var additional []RepositoryGitStatus
+		for _, checkoutLocation := range s.AdditionalCheckoutLocations {
+			stat, err := s.gitStatus(ctx, checkoutLocation)
+			if err != nil {
+				// we don't want to lose the status of the main repository because of a broken additional one
+				log.WithError(err).WithField("checkout location", checkoutLocation).WithFields(s.OWI()).Warn("cannot get Git status of additional repository")
+				continue
+			}
+			if stat == nil {
+				continue
+			}
+			additional = append(additional, RepositoryGitStatus{CheckoutLocation: checkoutLocation, GitStatus: stat})
+		}
+		s.LastAdditionalGitStatus = additional
 	}
 
 	err = s.Persist()
@@ -328,6 +349,24 @@ func (s *Workspace) UpdateGitStatus(ctx context.Context, persistentVolumeClaim b
 	return s.LastGitStatus, nil
 }
 
+// gitStatus produces the Git status of the working copy at checkoutLocation, or nil if there is none.
+func (s *Workspace) gitStatus(ctx context.Context, checkoutLocation string) (*csapi.GitStatus, error) {
+	loc := filepath.Join(s.Location, checkoutLocation)
+	if !git.IsWorkingCopy(loc) {
+		log.WithField("loc", loc).WithField("checkout location", checkoutLocation).WithFields(s.OWI()).Debug("did not find a Git working copy - not updating Git status")
+		return nil, nil
+	}
+
+	c := git.Client{Location: loc}
+
+	stat, err := c.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGitStatus(stat), nil
+}
+
 func toGitStatus(s *git.Status) *csapi.GitStatus {
 	limit := func(entries []string) []string {
 		if len(entries) > maxPendingChanges {
@@ -8,6 +8,7 @@ import (
 	"context"
 	"math/rand"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"sync"
@@ -317,3 +318,58 @@ func TestWaitOrMarkForDisposal(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateGitStatusOfAdditionalRepositories(t *testing.T) {
+	store, err := getTestStore()
+	if err != nil {
+		t.Fatalf("cannot create test store: %v", err)
+	}
+
+	location := filepath.Join(store.Location, "multi-repo")
+	for _, repo := range []string{"main", "backend"} {
+		err := exec.Command("git", "init", "-q", filepath.Join(location, repo)).Run()
+		if err != nil {
+			t.Fatalf("cannot initialize repository %s: %v", repo, err)
+		}
+	}
+	err = os.WriteFile(filepath.Join(location, "backend", "service.go"), []byte("package service"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// not a Git working copy, e.g. because the clone failed
+	err = os.MkdirAll(filepath.Join(location, "frontend"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := store.NewWorkspace(context.Background(), "multi-repo", location, func(ctx context.Context, loc string) (*Workspace, error) {
+		return &Workspace{
+			Location:                    loc,
+			CheckoutLocation:            "main",
+			AdditionalCheckoutLocations: []string{"backend", "frontend"},
+			InstanceID:                  "multi-repo",
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("cannot create test workspace: %v", err)
+	}
+
+	status, err := ws.UpdateGitStatus(context.Background(), false)
+	if err != nil {
+		t.Fatalf("UpdateGitStatus returned an error: %v", err)
+	}
+	if status == nil {
+		t.Fatal("expected Git status of the main repository")
+	}
+
+	var locations []string
+	for _, repo := range ws.LastAdditionalGitStatus {
+		locations = append(locations, repo.CheckoutLocation)
+	}
+	if diff := cmp.Diff([]string{"backend"}, locations); diff != "" {
+		t.Errorf("unexpected additional repositories (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"service.go"}, ws.LastAdditionalGitStatus[0].GitStatus.UntrackedFiles); diff != "" {
+		t.Errorf("unexpected untracked files (-want +got):\n%s", diff)
+	}
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/container"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/internal/session"
 	nsi "github.com/gitpod-io/gitpod/ws-daemon/pkg/nsinsider"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/quota"
 )
 
 //
@@ -84,7 +85,7 @@ var (
 )
 
 // ServeWorkspace establishes the IWS server for a workspace
-func ServeWorkspace(uidmapper *Uidmapper, fsshift api.FSShiftMethod, cgroupMountPoint string, workspaceCIDR string) func(ctx context.Context, ws *session.Workspace) error {
+func ServeWorkspace(uidmapper *Uidmapper, fsshift api.FSShiftMethod, cgroupMountPoint string, workspaceCIDR string, diskQuota quota.Backend, memoryEvents MemoryEventSource, ioStatus IOStatusSource, networkStatus NetworkStatusSource) func(ctx context.Context, ws *session.Workspace) error {
 	return func(ctx context.Context, ws *session.Workspace) (err error) {
 		span, _ := opentracing.StartSpanFromContext(ctx, "iws.ServeWorkspace")
 		defer tracing.FinishSpan(span, &err)
@@ -99,6 +100,10 @@ func ServeWorkspace(uidmapper *Uidmapper, fsshift api.FSShiftMethod, cgroupMount
 			FSShift:          fsshift,
 			CGroupMountPoint: cgroupMountPoint,
 			WorkspaceCIDR:    workspaceCIDR,
+			DiskQuota:        diskQuota,
+			MemoryEvents:     memoryEvents,
+			IOStatus:         ioStatus,
+			NetworkStatus:    networkStatus,
 		}
 		err = iws.Start()
 		if err != nil {
@@ -133,6 +138,30 @@ func StopServingWorkspace(ctx context.Context, ws *session.Workspace) (err error
 	return nil
 }
 
+// MemoryEventSource provides the memory pressure warnings and OOM kills of workspaces
+type MemoryEventSource interface {
+	// MemoryEvents returns the most recent memory events of a workspace instance, oldest first
+	MemoryEvents(instanceID string) []*api.MemoryEvent
+}
+
+// IOStatusSource provides the IO limits and IO usage of workspaces
+type IOStatusSource interface {
+	// IOStatus returns the IO limits and usage of a workspace instance, nil if they are unknown
+	IOStatus(instanceID string) *api.IO
+}
+
+// NetworkStatusSource provides the network traffic and egress restrictions of workspaces
+type NetworkStatusSource interface {
+	// NetworkStatus returns the network traffic and egress restrictions of a workspace instance, nil if they are unknown
+	NetworkStatus(instanceID string) *api.Network
+
+	// WaitForEgress blocks until the egress of a workspace instance has been restricted, and returns the error of doing so
+	WaitForEgress(ctx context.Context, instanceID string) error
+}
+
+// egressSetupTimeout is how long SetupPairVeths waits for the egress of a workspace to be restricted
+const egressSetupTimeout = 2 * time.Minute
+
 // InWorkspaceServiceServer implements the workspace facing backup services
 type InWorkspaceServiceServer struct {
 	Uidmapper        *Uidmapper
@@ -142,6 +171,18 @@ type InWorkspaceServiceServer struct {
 
 	WorkspaceCIDR string
 
+	// DiskQuota reports the disk usage of the workspace, nil if no quota is enforced
+	DiskQuota quota.Backend
+
+	// MemoryEvents provides the memory pressure warnings and OOM kills of the workspace, nil if they are not recorded
+	MemoryEvents MemoryEventSource
+
+	// IOStatus provides the IO limits and IO usage of the workspace, nil if they are not tracked
+	IOStatus IOStatusSource
+
+	// NetworkStatus provides the network traffic and egress restrictions of the workspace, nil if they are not tracked
+	NetworkStatus NetworkStatusSource
+
 	srv  *grpc.Server
 	sckt io.Closer
 
@@ -363,6 +404,17 @@ func (wbs *InWorkspaceServiceServer) SetupPairVeths(ctx context.Context, req *ap
 		return nil, status.Errorf(codes.Internal, "cannnot setup a pair of veths")
 	}
 
+	// the workspace gets network access through the veths, hence its egress must be restricted before
+	if wbs.NetworkStatus != nil {
+		egressCtx, cancel := context.WithTimeout(ctx, egressSetupTimeout)
+		err = wbs.NetworkStatus.WaitForEgress(egressCtx, wbs.Session.InstanceID)
+		cancel()
+		if err != nil {
+			log.WithError(err).WithFields(wbs.Session.OWI()).Error("SetupPairVeths: cannot restrict network egress")
+			return nil, status.Errorf(codes.FailedPrecondition, "cannot restrict network egress")
+		}
+	}
+
 	err = nsi.Nsinsider(wbs.Session.InstanceID, int(containerPID), func(c *exec.Cmd) {
 		c.Args = append(c.Args, "setup-pair-veths",
 			"--target-pid", strconv.Itoa(int(req.Pid)),
@@ -949,9 +1001,22 @@ func (wbs *InWorkspaceServiceServer) WorkspaceInfo(ctx context.Context, req *api
 		}
 		return nil, status.Error(codes.Unknown, err.Error())
 	}
+	resources.Disk = wbs.getDiskResourceInfo()
+	if wbs.IOStatus != nil {
+		resources.Io = wbs.IOStatus.IOStatus(wbs.Session.InstanceID)
+	}
+	if wbs.NetworkStatus != nil {
+		resources.Network = wbs.NetworkStatus.NetworkStatus(wbs.Session.InstanceID)
+	}
+
+	var memoryEvents []*api.MemoryEvent
+	if wbs.MemoryEvents != nil {
+		memoryEvents = wbs.MemoryEvents.MemoryEvents(wbs.Session.InstanceID)
+	}
 
 	return &api.WorkspaceInfoResponse{
-		Resources: resources,
+		Resources:    resources,
+		MemoryEvents: memoryEvents,
 	}, nil
 }
 
@@ -972,6 +1037,29 @@ func getWorkspaceResourceInfo(mountPoint, cgroupPath string) (*api.Resources, er
 	}, nil
 }
 
+// getDiskResourceInfo returns the disk usage of the workspace, or nil if no quota is enforced.
+// Failing to read the usage must not fail the whole WorkspaceInfo call, hence errors are only logged.
+func (wbs *InWorkspaceServiceServer) getDiskResourceInfo() *api.Disk {
+	if wbs.DiskQuota == nil || wbs.Session.XFSProjectID == 0 {
+		return nil
+	}
+
+	usage, err := wbs.DiskQuota.GetUsage(wbs.Session.XFSProjectID)
+	if err != nil {
+		log.WithError(err).WithFields(wbs.Session.OWI()).Warn("cannot get disk usage")
+		return nil
+	}
+
+	limit := int64(usage.Limit())
+	if limit == 0 {
+		limit = int64(wbs.Session.StorageQuota)
+	}
+	return &api.Disk{
+		Used:  int64(usage.Used),
+		Limit: limit,
+	}
+}
+
 func getCpuResourceInfoV2(mountPoint, cgroupPath string) (*api.Cpu, error) {
 	cpu := v2.NewCpuControllerWithMount(mountPoint, cgroupPath)
 
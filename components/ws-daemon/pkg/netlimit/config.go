@@ -4,9 +4,50 @@
 
 package netlimit
 
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
 type Config struct {
 	Enabled              bool  `json:"enabled"`
 	Enforce              bool  `json:"enforce"`
 	ConnectionsPerMinute int64 `json:"connectionsPerMinute"`
 	BucketSize           int64 `json:"bucketSize"`
+
+	// EgressPolicies restrict the destinations workspaces can connect to.
+	// A workspace is subject to the first policy which matches its team or project.
+	EgressPolicies []EgressPolicy `json:"egressPolicies,omitempty"`
+	// Bandwidth shapes the egress bandwidth of workspaces
+	Bandwidth BandwidthConfig `json:"bandwidth"`
+}
+
+// EgressPolicy allows or denies outbound connections of workspaces
+type EgressPolicy struct {
+	Name string `json:"name"`
+	// Teams and Projects select the workspaces this policy applies to by their team or project ID.
+	// A policy without teams and projects applies to all workspaces.
+	Teams    []string `json:"teams,omitempty"`
+	Projects []string `json:"projects,omitempty"`
+	// Allow lists destinations which are allowed even if they are denied.
+	// A policy with allowed but without denied destinations denies all other destinations.
+	Allow []EgressRule `json:"allow,omitempty"`
+	// Deny lists destinations workspaces cannot connect to. Replies to inbound connections are never denied.
+	Deny []EgressRule `json:"deny,omitempty"`
+}
+
+// EgressRule matches outbound traffic by its destination
+type EgressRule struct {
+	// CIDR is the IPv4 or IPv6 destination network, e.g. 10.0.0.0/8 or fd00::/8
+	CIDR string `json:"cidr"`
+	// Ports restricts the rule to TCP and UDP destination ports. A rule without ports matches all traffic.
+	Ports []uint16 `json:"ports,omitempty"`
+}
+
+// BandwidthConfig configures the egress bandwidth of workspaces
+type BandwidthConfig struct {
+	// Default is the egress bandwidth in bytes per second of workspaces whose class has none configured.
+	// Zero does not shape the bandwidth.
+	Default resource.Quantity `json:"default"`
+	// Classes configures the egress bandwidth in bytes per second by workspace class
+	Classes map[string]resource.Quantity `json:"classes,omitempty"`
 }
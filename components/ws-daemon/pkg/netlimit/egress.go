@@ -0,0 +1,143 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package netlimit
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gitpod-io/gitpod/common-go/kubernetes"
+)
+
+// policyFor returns the first egress policy which matches the workspace labels, or nil if none does
+func (c Config) policyFor(labels map[string]string) *EgressPolicy {
+	for i, p := range c.EgressPolicies {
+		if len(p.Teams) == 0 && len(p.Projects) == 0 {
+			return &c.EgressPolicies[i]
+		}
+		if team := labels[kubernetes.TeamLabel]; team != "" && contains(p.Teams, team) {
+			return &c.EgressPolicies[i]
+		}
+		if project := labels[kubernetes.ProjectLabel]; project != "" && contains(p.Projects, project) {
+			return &c.EgressPolicies[i]
+		}
+	}
+	return nil
+}
+
+// rate returns the egress bandwidth in bytes per second of a workspace class, zero if it is not shaped
+func (c BandwidthConfig) rate(class string) int64 {
+	if r, ok := c.Classes[class]; ok {
+		return r.Value()
+	}
+	return c.Default.Value()
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate ensures all CIDRs of the policy are valid IPv4 or IPv6 networks
+func (p EgressPolicy) Validate() error {
+	for _, r := range append(append([]EgressRule{}, p.Allow...), p.Deny...) {
+		_, _, err := net.ParseCIDR(r.CIDR)
+		if err != nil {
+			return fmt.Errorf("egress policy %s: %w", p.Name, err)
+		}
+	}
+	return nil
+}
+
+// nsinsiderRules renders rules in the form nsinsider setup-egress-policy expects them, i.e. CIDR or CIDR:port.
+// The port follows the prefix length, hence IPv6 networks need no brackets.
+func nsinsiderRules(rules []EgressRule) []string {
+	var res []string
+	for _, r := range rules {
+		if len(r.Ports) == 0 {
+			res = append(res, r.CIDR)
+			continue
+		}
+		for _, port := range r.Ports {
+			res = append(res, fmt.Sprintf("%s:%d", r.CIDR, port))
+		}
+	}
+	return res
+}
+
+// Traffic is the network traffic of a workspace in bytes
+type Traffic struct {
+	RxBytes uint64
+	TxBytes uint64
+}
+
+// readTraffic reads the traffic of the interface which carries the default route in the network namespace of pid
+func readTraffic(pid uint64) (*Traffic, error) {
+	procNet := filepath.Join("/proc", strconv.FormatUint(pid, 10), "net")
+
+	route, err := os.ReadFile(filepath.Join(procNet, "route"))
+	if err != nil {
+		return nil, err
+	}
+	iface, err := parseDefaultRouteInterface(string(route))
+	if err != nil {
+		return nil, err
+	}
+
+	dev, err := os.ReadFile(filepath.Join(procNet, "dev"))
+	if err != nil {
+		return nil, err
+	}
+	return parseInterfaceTraffic(string(dev), iface)
+}
+
+// parseDefaultRouteInterface finds the interface of the default route in the content of /proc/net/route
+func parseDefaultRouteInterface(route string) (string, error) {
+	for _, line := range strings.Split(route, "\n")[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 8 {
+			continue
+		}
+		// the destination and mask of the default route are both 0.0.0.0
+		if fields[1] == "00000000" && fields[7] == "00000000" {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("no default route found")
+}
+
+// parseInterfaceTraffic reads the received and transmitted bytes of an interface from the content of /proc/net/dev
+func parseInterfaceTraffic(dev, iface string) (*Traffic, error) {
+	for _, line := range strings.Split(dev, "\n") {
+		name, stats, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(name) != iface {
+			continue
+		}
+
+		// the receive section has 8 fields, the first of the transmit section are the transmitted bytes
+		fields := strings.Fields(stats)
+		if len(fields) < 9 {
+			return nil, fmt.Errorf("invalid statistics for interface %s", iface)
+		}
+		rx, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse received bytes of %s: %w", iface, err)
+		}
+		tx, err := strconv.ParseUint(fields[8], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse transmitted bytes of %s: %w", iface, err)
+		}
+		return &Traffic{RxBytes: rx, TxBytes: tx}, nil
+	}
+	return nil, fmt.Errorf("interface %s not found", iface)
+}
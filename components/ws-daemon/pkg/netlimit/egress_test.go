@@ -0,0 +1,175 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package netlimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/gitpod-io/gitpod/common-go/kubernetes"
+)
+
+func TestPolicyFor(t *testing.T) {
+	cfg := Config{
+		EgressPolicies: []EgressPolicy{
+			{Name: "trusted-team", Teams: []string{"team-a"}},
+			{Name: "trusted-project", Projects: []string{"project-b"}},
+			{Name: "untrusted", Deny: []EgressRule{{CIDR: "10.0.0.0/8"}}},
+		},
+	}
+
+	tests := []struct {
+		Name        string
+		Config      Config
+		Labels      map[string]string
+		Expectation string
+	}{
+		{
+			Name:        "team",
+			Config:      cfg,
+			Labels:      map[string]string{kubernetes.TeamLabel: "team-a", kubernetes.ProjectLabel: "project-b"},
+			Expectation: "trusted-team",
+		},
+		{
+			Name:        "project",
+			Config:      cfg,
+			Labels:      map[string]string{kubernetes.TeamLabel: "team-c", kubernetes.ProjectLabel: "project-b"},
+			Expectation: "trusted-project",
+		},
+		{
+			Name:        "fallback",
+			Config:      cfg,
+			Labels:      map[string]string{kubernetes.TeamLabel: "team-c"},
+			Expectation: "untrusted",
+		},
+		{
+			Name:   "no policy",
+			Config: Config{EgressPolicies: cfg.EgressPolicies[:2]},
+			Labels: map[string]string{kubernetes.TeamLabel: ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var act string
+			if p := test.Config.policyFor(test.Labels); p != nil {
+				act = p.Name
+			}
+			if act != test.Expectation {
+				t.Errorf("expected policy %q, got %q", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestBandwidthRate(t *testing.T) {
+	cfg := BandwidthConfig{
+		Default: resource.MustParse("10Mi"),
+		Classes: map[string]resource.Quantity{"large": resource.MustParse("50Mi")},
+	}
+
+	if act := cfg.rate("large"); act != 50<<20 {
+		t.Errorf("expected rate of large class to be %d, got %d", 50<<20, act)
+	}
+	if act := cfg.rate("small"); act != 10<<20 {
+		t.Errorf("expected default rate %d, got %d", 10<<20, act)
+	}
+	if act := (BandwidthConfig{}).rate("small"); act != 0 {
+		t.Errorf("expected no rate, got %d", act)
+	}
+}
+
+func TestEgressPolicyValidate(t *testing.T) {
+	valid := EgressPolicy{Name: "valid", Deny: []EgressRule{{CIDR: "10.0.0.0/8", Ports: []uint16{22}}, {CIDR: "fd00::/8"}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, cidr := range []string{"10.0.0.0", "fd00::", "10.0.0.0/33"} {
+		invalid := EgressPolicy{Name: "invalid", Allow: []EgressRule{{CIDR: cidr}}}
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("expected an error for %s", cidr)
+		}
+	}
+}
+
+func TestNsinsiderRules(t *testing.T) {
+	act := nsinsiderRules([]EgressRule{
+		{CIDR: "10.0.0.0/8"},
+		{CIDR: "172.16.0.0/12", Ports: []uint16{22, 443}},
+		{CIDR: "fd00::/8", Ports: []uint16{443}},
+	})
+	exp := []string{"10.0.0.0/8", "172.16.0.0/12:22", "172.16.0.0/12:443", "fd00::/8:443"}
+	if diff := cmp.Diff(exp, act); diff != "" {
+		t.Errorf("nsinsiderRules() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseTraffic(t *testing.T) {
+	route := `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+eth0	00000000	0100580A	0003	0	0	0	00000000	0	0	0
+eth0	0000580A	00000000	0001	0	0	0	00FFFFFF	0	0	0
+veth0	0200000A	00000000	0001	0	0	0	FCFFFFFF	0	0	0
+`
+	iface, err := parseDefaultRouteInterface(route)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iface != "eth0" {
+		t.Fatalf("expected eth0, got %s", iface)
+	}
+
+	dev := `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:    1000      10    0    0    0     0          0         0     1000      10    0    0    0     0       0          0
+ veth0: 5000000    4000    0    0    0     0          0         0  9000000    6000    0    0    0     0       0          0
+  eth0: 12345678   9000    0    0    0     0          0         0  2345678    7000    0    0    0     0       0          0
+`
+	traffic, err := parseInterfaceTraffic(dev, iface)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&Traffic{RxBytes: 12345678, TxBytes: 2345678}, traffic); diff != "" {
+		t.Errorf("parseInterfaceTraffic() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := parseDefaultRouteInterface("Iface\tDestination\n"); err == nil {
+		t.Error("expected an error without default route")
+	}
+}
+
+func TestWaitForEgress(t *testing.T) {
+	disabled := NewConnLimiter(Config{}, nil)
+	if err := disabled.WaitForEgress(context.Background(), "disabled"); err != nil {
+		t.Errorf("unexpected error when disabled: %v", err)
+	}
+
+	limiter := NewConnLimiter(Config{Enabled: true}, prometheus.NewRegistry())
+	failure := errors.New("cannot apply egress policy")
+	limiter.mu.Lock()
+	limiter.egressSetupFor("failed").finish(failure)
+	limiter.egressSetupFor("failed").finish(nil)
+	limiter.egressSetupFor("restricted").finish(nil)
+	limiter.mu.Unlock()
+
+	if err := limiter.WaitForEgress(context.Background(), "failed"); !errors.Is(err, failure) {
+		t.Errorf("expected the first outcome %v, got %v", failure, err)
+	}
+	if err := limiter.WaitForEgress(context.Background(), "restricted"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.WaitForEgress(ctx, "pending"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+}
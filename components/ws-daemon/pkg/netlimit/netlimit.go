@@ -17,6 +17,7 @@ import (
 
 	"github.com/gitpod-io/gitpod/common-go/kubernetes"
 	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/ws-daemon/api"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/dispatch"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/nsinsider"
 	"github.com/google/nftables"
@@ -24,14 +25,71 @@ import (
 	"github.com/vishvananda/netns"
 )
 
+const (
+	connectionDropStats = "ws-connection-drop-stats"
+	egressDeniedStats   = "ws-egress-denied-stats"
+)
+
+var (
+	gitpodTable = &nftables.Table{
+		Name:   "gitpod",
+		Family: nftables.TableFamilyIPv4,
+	}
+	egressTable = &nftables.Table{
+		Name:   "gitpod-egress",
+		Family: nftables.TableFamilyINet,
+	}
+)
+
 type ConnLimiter struct {
 	mu             sync.RWMutex
 	limited        map[string]struct{}
+	workspaces     map[string]*workspaceNetwork
+	egress         map[string]*egressSetup
 	droppedBytes   *prometheus.GaugeVec
 	droppedPackets *prometheus.GaugeVec
+	trafficBytes   *prometheus.GaugeVec
+	deniedPackets  *prometheus.GaugeVec
 	config         Config
 }
 
+// workspaceNetwork is the egress configuration and network traffic of a workspace
+type workspaceNetwork struct {
+	Policy string
+	Rate   int64
+
+	mu            sync.Mutex
+	traffic       Traffic
+	deniedPackets uint64
+}
+
+// egressSetup is the outcome of the first attempt to restrict the egress of a workspace
+type egressSetup struct {
+	once sync.Once
+	done chan struct{}
+	err  error
+}
+
+func (e *egressSetup) finish(err error) {
+	e.once.Do(func() {
+		e.err = err
+		close(e.done)
+	})
+}
+
+func (w *workspaceNetwork) Status() *api.Network {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return &api.Network{
+		RxBytes:              int64(w.traffic.RxBytes),
+		TxBytes:              int64(w.traffic.TxBytes),
+		EgressBandwidthLimit: w.Rate,
+		EgressPolicy:         w.Policy,
+		EgressDeniedPackets:  int64(w.deniedPackets),
+	}
+}
+
 func NewConnLimiter(config Config, prom prometheus.Registerer) *ConnLimiter {
 	s := &ConnLimiter{
 		droppedBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
@@ -43,7 +101,19 @@ func NewConnLimiter(config Config, prom prometheus.Registerer) *ConnLimiter {
 			Name: "netlimit_connections_dropped_packets",
 			Help: "Number of packets dropped due to connection limiting",
 		}, []string{"node", "workspace"}),
-		limited: map[string]struct{}{},
+
+		trafficBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "netlimit_workspace_traffic_bytes",
+			Help: "Number of bytes received and transmitted by a workspace",
+		}, []string{"node", "workspace", "direction"}),
+
+		deniedPackets: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "netlimit_egress_denied_packets",
+			Help: "Number of outbound packets dropped by the egress policy of a workspace",
+		}, []string{"node", "workspace", "policy"}),
+		limited:    map[string]struct{}{},
+		workspaces: map[string]*workspaceNetwork{},
+		egress:     map[string]*egressSetup{},
 	}
 
 	s.config = config
@@ -52,6 +122,8 @@ func NewConnLimiter(config Config, prom prometheus.Registerer) *ConnLimiter {
 		prom.MustRegister(
 			s.droppedBytes,
 			s.droppedPackets,
+			s.trafficBytes,
+			s.deniedPackets,
 		)
 	}
 
@@ -62,6 +134,21 @@ func (c *ConnLimiter) WorkspaceAdded(ctx context.Context, ws *dispatch.Workspace
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	go func() {
+		<-ctx.Done()
+		c.mu.Lock()
+		delete(c.egress, ws.InstanceID)
+		c.mu.Unlock()
+	}()
+
+	if _, ok := c.workspaces[ws.InstanceID]; !ok {
+		err := c.restrictEgress(ctx, ws)
+		c.egressSetupFor(ws.InstanceID).finish(err)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, hasAnnotation := ws.Pod.Annotations[kubernetes.WorkspaceNetConnLimitAnnotation]
 	if !hasAnnotation {
 		return nil
@@ -74,6 +161,14 @@ func (c *ConnLimiter) WorkspaceUpdated(ctx context.Context, ws *dispatch.Workspa
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if _, ok := c.workspaces[ws.InstanceID]; !ok {
+		err := c.restrictEgress(ctx, ws)
+		c.egressSetupFor(ws.InstanceID).finish(err)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, hasAnnotation := ws.Pod.Annotations[kubernetes.WorkspaceNetConnLimitAnnotation]
 	if !hasAnnotation {
 		return nil
@@ -86,7 +181,53 @@ func (c *ConnLimiter) WorkspaceUpdated(ctx context.Context, ws *dispatch.Workspa
 	return c.limitWorkspace(ctx, ws)
 }
 
+// WaitForEgress blocks until the egress of a workspace instance has been restricted, and returns the error
+// of doing so. Workspaces must not get network access before, such that a failure to restrict egress fails the workspace
+// instead of leaving it unrestricted.
+func (c *ConnLimiter) WaitForEgress(ctx context.Context, instanceID string) error {
+	c.mu.Lock()
+	if !c.config.Enabled {
+		c.mu.Unlock()
+		return nil
+	}
+	setup := c.egressSetupFor(instanceID)
+	c.mu.Unlock()
+
+	select {
+	case <-setup.done:
+		return setup.err
+	case <-ctx.Done():
+		return fmt.Errorf("egress of workspace was not restricted in time: %w", ctx.Err())
+	}
+}
+
+// egressSetupFor returns the egress setup of a workspace instance, creating it if needed. Callers must hold c.mu.
+func (c *ConnLimiter) egressSetupFor(instanceID string) *egressSetup {
+	setup, ok := c.egress[instanceID]
+	if !ok {
+		setup = &egressSetup{done: make(chan struct{})}
+		c.egress[instanceID] = setup
+	}
+	return setup
+}
+
+// NetworkStatus returns the network traffic and egress restrictions of a workspace instance, nil if they are unknown
+func (c *ConnLimiter) NetworkStatus(instanceID string) *api.Network {
+	c.mu.RLock()
+	wsn, ok := c.workspaces[instanceID]
+	c.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	return wsn.Status()
+}
+
 func (n *ConnLimiter) GetConnectionDropCounter(pid uint64) (*nftables.CounterObj, error) {
+	return getCounter(pid, gitpodTable, connectionDropStats)
+}
+
+func getCounter(pid uint64, table *nftables.Table, name string) (*nftables.CounterObj, error) {
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
 
@@ -94,32 +235,28 @@ func (n *ConnLimiter) GetConnectionDropCounter(pid uint64) (*nftables.CounterObj
 	if err != nil {
 		return nil, fmt.Errorf("could not get handle for network namespace: %w", err)
 	}
+	defer netns.Close()
 
 	nftconn, err := nftables.New(nftables.WithNetNSFd(int(netns)))
 	if err != nil {
 		return nil, fmt.Errorf("could not establish netlink connection for nft: %w", err)
 	}
 
-	gitpodTable := &nftables.Table{
-		Name:   "gitpod",
-		Family: nftables.TableFamilyIPv4,
-	}
-
 	counterObject, err := nftconn.GetObject(&nftables.CounterObj{
-		Table: gitpodTable,
-		Name:  "ws-connection-drop-stats",
+		Table: table,
+		Name:  name,
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("could not get connection drop stats: %w", err)
+		return nil, fmt.Errorf("could not get %s: %w", name, err)
 	}
 
-	dropCounter, ok := counterObject.(*nftables.CounterObj)
+	counter, ok := counterObject.(*nftables.CounterObj)
 	if !ok {
 		return nil, fmt.Errorf("could not cast counter object")
 	}
 
-	return dropCounter, nil
+	return counter, nil
 }
 
 func (c *ConnLimiter) limitWorkspace(ctx context.Context, ws *dispatch.Workspace) error {
@@ -177,6 +314,99 @@ func (c *ConnLimiter) limitWorkspace(ctx context.Context, ws *dispatch.Workspace
 	return nil
 }
 
+// restrictEgress applies the egress policy and bandwidth of the workspace and starts watching its traffic
+func (c *ConnLimiter) restrictEgress(ctx context.Context, ws *dispatch.Workspace) error {
+	disp := dispatch.GetFromContext(ctx)
+	if disp == nil {
+		return fmt.Errorf("no dispatch available")
+	}
+
+	pid, err := disp.Runtime.ContainerPID(context.Background(), ws.ContainerID)
+	if err != nil {
+		return fmt.Errorf("could not get pid for container %s of workspace %s", ws.ContainerID, ws.WorkspaceID)
+	}
+
+	var (
+		policy = c.config.policyFor(ws.Pod.Labels)
+		wsn    = &workspaceNetwork{Rate: c.config.Bandwidth.rate(ws.Pod.Labels[kubernetes.WorkspaceClassLabel])}
+	)
+	if policy != nil {
+		err = policy.Validate()
+		if err != nil {
+			return err
+		}
+		wsn.Policy = policy.Name
+	}
+
+	if policy != nil || wsn.Rate > 0 {
+		log.WithFields(ws.OWI()).WithField("policy", wsn.Policy).WithField("rate", wsn.Rate).Info("will restrict network egress")
+		err = nsinsider.Nsinsider(ws.InstanceID, int(pid), func(cmd *exec.Cmd) {
+			cmd.Args = append(cmd.Args, "setup-egress-policy", "--rate", strconv.FormatInt(wsn.Rate, 10))
+			if policy != nil {
+				for _, r := range nsinsiderRules(policy.Allow) {
+					cmd.Args = append(cmd.Args, "--allow", r)
+				}
+				for _, r := range nsinsiderRules(policy.Deny) {
+					cmd.Args = append(cmd.Args, "--deny", r)
+				}
+			}
+		}, nsinsider.EnterMountNS(false), nsinsider.EnterNetNS(true))
+		if err != nil {
+			log.WithError(err).WithFields(ws.OWI()).Error("cannot restrict network egress")
+			return err
+		}
+	}
+
+	c.workspaces[ws.InstanceID] = wsn
+
+	go func(*dispatch.Workspace) {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+
+		nodeName := os.Getenv("NODENAME")
+		for {
+			select {
+			case <-ticker.C:
+				traffic, err := readTraffic(pid)
+				if err != nil {
+					log.WithError(err).Errorf("could not get network traffic of %s", ws.WorkspaceID)
+					continue
+				}
+				wsn.mu.Lock()
+				wsn.traffic = *traffic
+				wsn.mu.Unlock()
+				c.trafficBytes.WithLabelValues(nodeName, ws.Pod.Name, "rx").Set(float64(traffic.RxBytes))
+				c.trafficBytes.WithLabelValues(nodeName, ws.Pod.Name, "tx").Set(float64(traffic.TxBytes))
+
+				if policy != nil && (len(policy.Allow) > 0 || len(policy.Deny) > 0) {
+					counter, err := getCounter(pid, egressTable, egressDeniedStats)
+					if err != nil {
+						log.WithError(err).Errorf("could not get egress denied stats for %s", ws.WorkspaceID)
+					} else {
+						wsn.mu.Lock()
+						wsn.deniedPackets = counter.Packets
+						wsn.mu.Unlock()
+						c.deniedPackets.WithLabelValues(nodeName, ws.Pod.Name, policy.Name).Set(float64(counter.Packets))
+					}
+				}
+
+			case <-ctx.Done():
+				c.mu.Lock()
+				delete(c.workspaces, ws.InstanceID)
+				c.mu.Unlock()
+				c.trafficBytes.DeleteLabelValues(nodeName, ws.Pod.Name, "rx")
+				c.trafficBytes.DeleteLabelValues(nodeName, ws.Pod.Name, "tx")
+				if policy != nil {
+					c.deniedPackets.DeleteLabelValues(nodeName, ws.Pod.Name, policy.Name)
+				}
+				return
+			}
+		}
+	}(ws)
+
+	return nil
+}
+
 func (c *ConnLimiter) Update(config Config) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -0,0 +1,101 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package quota
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// Backend enforces disk quota on directories using filesystem project quota
+type Backend interface {
+	// SetQuota sets the quota for a path using a free project ID
+	SetQuota(path string, quota Size, isHard bool) (projectID int, err error)
+
+	// SetQuotaWithPrjId sets the quota for a path using an existing project ID
+	SetQuotaWithPrjId(path string, quota Size, prjID int, isHard bool) (projectID int, err error)
+
+	// RegisterProject tells this backend that a projectID is already in use
+	RegisterProject(prjID int)
+
+	// RemoveQuota removes the limitation for a project/path and frees the projectID
+	RemoveQuota(projectID int) error
+
+	// GetProjectUseCount returns the number of projectIDs in use
+	GetProjectUseCount() int
+
+	// GetUsage returns the current disk usage and limits of a project
+	GetUsage(projectID int) (*Usage, error)
+}
+
+// Usage describes the disk usage of a project. A limit of zero means no limit is set.
+type Usage struct {
+	Used      Size
+	SoftLimit Size
+	HardLimit Size
+}
+
+// Limit returns the effective limit, i.e. the hard limit if set, the soft limit otherwise
+func (u *Usage) Limit() Size {
+	if u.HardLimit != 0 {
+		return u.HardLimit
+	}
+	return u.SoftLimit
+}
+
+// BackendKind selects the quota backend
+type BackendKind string
+
+const (
+	// BackendAuto uses the native backend if the filesystem supports it, and xfs_quota otherwise
+	BackendAuto BackendKind = ""
+
+	// BackendNative uses the quotactl syscall and supports XFS and ext4
+	BackendNative BackendKind = "native"
+
+	// BackendXFSQuota shells out to xfs_quota and supports XFS only
+	BackendXFSQuota BackendKind = "xfs_quota"
+)
+
+// New produces a quota backend for the filesystem at path
+func New(path string, kind BackendKind) (Backend, error) {
+	switch kind {
+	case BackendNative:
+		return NewNative(path)
+	case BackendXFSQuota:
+		return NewXFS(path)
+	case BackendAuto:
+		native, err := NewNative(path)
+		if err == nil {
+			return native, nil
+		}
+		log.WithError(err).WithField("path", path).Info("native project quota is unavailable, falling back to xfs_quota")
+		return NewXFS(path)
+	default:
+		return nil, fmt.Errorf("unknown quota backend %q", kind)
+	}
+}
+
+// allocateProjectID reserves the lowest project ID which is not in use yet
+func allocateProjectID(mu *sync.Mutex, projectIDs map[int]struct{}) (int, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for prjID := prjidLow; prjID < prjidHi; prjID++ {
+		_, exists := projectIDs[prjID]
+		if !exists {
+			projectIDs[prjID] = struct{}{}
+			return prjID, nil
+		}
+	}
+	return 0, fmt.Errorf("no free projectID found")
+}
+
+var (
+	_ Backend = ((*XFS)(nil))
+	_ Backend = ((*Native)(nil))
+)
@@ -0,0 +1,326 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package quota
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// see linux/quota.h and linux/dqblk_xfs.h
+const (
+	prjQuota = 2
+
+	qXGetQuota     = ('X' << 8) + 3
+	qXSetQLim      = ('X' << 8) + 4
+	qXGetNextQuota = ('X' << 8) + 9
+
+	fsDQuotVersion = 1
+	fsProjQuota    = 2
+	fsDQBSoft      = 1 << 2
+	fsDQBHard      = 1 << 3
+
+	// quotactl reports and sets block limits in "basic blocks" of 512 bytes
+	basicBlockSize = 512
+)
+
+// see linux/fs.h
+const (
+	fsIOCFSGetXAttr    = 0x801c581f
+	fsIOCFSSetXAttr    = 0x401c5820
+	fsXFlagProjInherit = 0x00000200
+)
+
+// projectSetMaxDepth matches the depth xfs_quota is invoked with by the XFS backend
+const projectSetMaxDepth = 1
+
+// fsDiskQuota mirrors struct fs_disk_quota
+type fsDiskQuota struct {
+	Version      int8
+	Flags        int8
+	FieldMask    uint16
+	ID           uint32
+	BlkHardLimit uint64
+	BlkSoftLimit uint64
+	InoHardLimit uint64
+	InoSoftLimit uint64
+	BCount       uint64
+	ICount       uint64
+	ITimer       int32
+	BTimer       int32
+	IWarns       uint16
+	BWarns       uint16
+	ITimerHi     int8
+	BTimerHi     int8
+	RtbTimerHi   int8
+	Padding2     int8
+	RtbHardLimit uint64
+	RtbSoftLimit uint64
+	RtbCount     uint64
+	RtbTimer     int32
+	RtbWarns     uint16
+	Padding3     int16
+	Padding4     [8]byte
+}
+
+// fsXAttr mirrors struct fsxattr
+type fsXAttr struct {
+	XFlags     uint32
+	ExtSize    uint32
+	NExtents   uint32
+	ProjID     uint32
+	CowExtSize uint32
+	Pad        [8]byte
+}
+
+type quotactlFunc func(cmd int, id uint32, dq *fsDiskQuota) error
+
+// Native enforces project quota using the quotactl_fd syscall instead of shelling out to xfs_quota.
+// It supports all filesystems which support project quota, i.e. XFS and ext4 (mounted with prjquota).
+type Native struct {
+	Dir string
+
+	quotactl   quotactlFunc
+	setProject func(path string, prjID uint32) error
+
+	projectIDs map[int]struct{}
+	mu         sync.Mutex
+}
+
+func NewNative(path string) (*Native, error) {
+	res := &Native{
+		Dir:        path,
+		quotactl:   defaultQuotactl(path),
+		setProject: setProjectID,
+		projectIDs: make(map[int]struct{}),
+	}
+
+	// Note: if the filesystem or kernel does not support project quota via quotactl_fd,
+	//       getUsedProjectIDs will fail, hence the NewNative call will fail.
+	prjIDs, err := res.getUsedProjectIDs()
+	if err != nil {
+		return nil, err
+	}
+	for _, prjID := range prjIDs {
+		res.projectIDs[prjID] = struct{}{}
+	}
+
+	return res, nil
+}
+
+// defaultQuotactl issues quotactl_fd (Linux 5.14+) against the filesystem dir lives on.
+// Unlike quotactl it does not require access to the block device.
+func defaultQuotactl(dir string) quotactlFunc {
+	return func(cmd int, id uint32, dq *fsDiskQuota) error {
+		f, err := os.Open(dir)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, _, errno := unix.Syscall6(unix.SYS_QUOTACTL_FD, f.Fd(), uintptr(cmd<<8|prjQuota), uintptr(id), uintptr(unsafe.Pointer(dq)), 0, 0)
+		if errno != 0 {
+			return errno
+		}
+		return nil
+	}
+}
+
+// setProjectID sets the project ID of path. Directories inherit their project ID to new children.
+func setProjectID(path string, prjID uint32) error {
+	f, err := os.OpenFile(path, os.O_RDONLY|unix.O_NOFOLLOW, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var attr fsXAttr
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), fsIOCFSGetXAttr, uintptr(unsafe.Pointer(&attr)))
+	if errno != 0 {
+		return fmt.Errorf("cannot get attributes of %s: %w", path, errno)
+	}
+
+	attr.ProjID = prjID
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if stat.IsDir() {
+		attr.XFlags |= fsXFlagProjInherit
+	}
+
+	_, _, errno = unix.Syscall(unix.SYS_IOCTL, f.Fd(), fsIOCFSSetXAttr, uintptr(unsafe.Pointer(&attr)))
+	if errno != 0 {
+		return fmt.Errorf("cannot set project ID of %s: %w", path, errno)
+	}
+	return nil
+}
+
+// getUsedProjectIDs lists all project IDs used on the filesystem
+func (n *Native) getUsedProjectIDs() ([]int, error) {
+	var (
+		res []int
+		id  uint32
+	)
+	for {
+		var dq fsDiskQuota
+		err := n.quotactl(qXGetNextQuota, id, &dq)
+		if errors.Is(err, unix.ENOENT) {
+			return res, nil
+		}
+		if errors.Is(err, unix.ENOSYS) || errors.Is(err, unix.ESRCH) || errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.ENOTTY) {
+			return nil, fmt.Errorf("filesystem does not support project quota via quotactl: %w", err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot list project quota: %w", err)
+		}
+
+		if dq.BCount > 0 {
+			res = append(res, int(dq.ID))
+		}
+		if dq.ID == ^uint32(0) {
+			return res, nil
+		}
+		id = dq.ID + 1
+	}
+}
+
+// SetQuota sets the quota for a path
+func (n *Native) SetQuota(path string, quota Size, isHard bool) (projectID int, err error) {
+	prjID, err := allocateProjectID(&n.mu, n.projectIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			n.mu.Lock()
+			delete(n.projectIDs, prjID)
+			n.mu.Unlock()
+		}
+	}()
+
+	_, err = n.SetQuotaWithPrjId(path, quota, prjID, isHard)
+	if err != nil {
+		return 0, err
+	}
+
+	return prjID, nil
+}
+
+func (n *Native) SetQuotaWithPrjId(path string, quota Size, prjID int, isHard bool) (projectID int, err error) {
+	err = n.setProjectRecursive(path, uint32(prjID), projectSetMaxDepth)
+	if err != nil {
+		return 0, err
+	}
+
+	dq := fsDiskQuota{
+		Version: fsDQuotVersion,
+		Flags:   fsProjQuota,
+		ID:      uint32(prjID),
+	}
+	blocks := uint64(quota) / basicBlockSize
+	if isHard {
+		dq.FieldMask = fsDQBHard
+		dq.BlkHardLimit = blocks
+	} else {
+		dq.FieldMask = fsDQBSoft
+		dq.BlkSoftLimit = blocks
+	}
+
+	err = n.quotactl(qXSetQLim, uint32(prjID), &dq)
+	if err != nil {
+		return 0, fmt.Errorf("cannot set quota limit of project %d: %w", prjID, err)
+	}
+	return prjID, nil
+}
+
+// setProjectRecursive sets the project ID of path and its children up to depth, like `xfs_quota project -s -d`
+func (n *Native) setProjectRecursive(path string, prjID uint32, depth int) error {
+	err := n.setProject(path, prjID)
+	if err != nil {
+		return err
+	}
+	if depth == 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		// only regular files and directories can carry a project ID
+		if !e.Type().IsRegular() && !e.IsDir() {
+			continue
+		}
+
+		err = n.setProjectRecursive(filepath.Join(path, e.Name()), prjID, depth-1)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterProject tells this implementation that a projectID is already in use
+func (n *Native) RegisterProject(prjID int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.projectIDs[prjID] = struct{}{}
+}
+
+// RemoveQuota removes the limitation for a project/path and frees the projectID
+func (n *Native) RemoveQuota(projectID int) error {
+	dq := fsDiskQuota{
+		Version:   fsDQuotVersion,
+		Flags:     fsProjQuota,
+		FieldMask: fsDQBSoft | fsDQBHard,
+		ID:        uint32(projectID),
+	}
+	err := n.quotactl(qXSetQLim, uint32(projectID), &dq)
+	if err != nil {
+		return fmt.Errorf("cannot remove quota limit of project %d: %w", projectID, err)
+	}
+
+	n.mu.Lock()
+	delete(n.projectIDs, projectID)
+	n.mu.Unlock()
+	return nil
+}
+
+// GetProjectUseCount returns the number of projectIDs in use
+func (n *Native) GetProjectUseCount() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return len(n.projectIDs)
+}
+
+// GetUsage returns the current disk usage and limits of a project
+func (n *Native) GetUsage(projectID int) (*Usage, error) {
+	var dq fsDiskQuota
+	err := n.quotactl(qXGetQuota, uint32(projectID), &dq)
+	if errors.Is(err, unix.ENOENT) {
+		// the project neither uses any space nor has limits
+		return &Usage{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot get quota of project %d: %w", projectID, err)
+	}
+
+	return &Usage{
+		Used:      Size(dq.BCount * basicBlockSize),
+		SoftLimit: Size(dq.BlkSoftLimit * basicBlockSize),
+		HardLimit: Size(dq.BlkHardLimit * basicBlockSize),
+	}, nil
+}
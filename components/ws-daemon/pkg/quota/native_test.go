@@ -0,0 +1,284 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package quota
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/sys/unix"
+)
+
+// fakeQuotactl emulates the project quota of a filesystem
+type fakeQuotactl struct {
+	Quota map[uint32]fsDiskQuota
+	Err   error
+}
+
+func (f *fakeQuotactl) quotactl(cmd int, id uint32, dq *fsDiskQuota) error {
+	if f.Err != nil {
+		return f.Err
+	}
+
+	switch cmd {
+	case qXGetQuota:
+		q, ok := f.Quota[id]
+		if !ok {
+			return unix.ENOENT
+		}
+		*dq = q
+	case qXGetNextQuota:
+		var ids []int
+		for prjID := range f.Quota {
+			if prjID >= id {
+				ids = append(ids, int(prjID))
+			}
+		}
+		if len(ids) == 0 {
+			return unix.ENOENT
+		}
+		sort.Ints(ids)
+		*dq = f.Quota[uint32(ids[0])]
+	case qXSetQLim:
+		q := f.Quota[id]
+		q.ID = id
+		if dq.FieldMask&fsDQBSoft != 0 {
+			q.BlkSoftLimit = dq.BlkSoftLimit
+		}
+		if dq.FieldMask&fsDQBHard != 0 {
+			q.BlkHardLimit = dq.BlkHardLimit
+		}
+		f.Quota[id] = q
+	default:
+		return fmt.Errorf("unexpected quotactl command %d", cmd)
+	}
+	return nil
+}
+
+func TestNativeGetUsedProjectIDs(t *testing.T) {
+	type Expectation struct {
+		ProjectIDs []int
+		Error      string
+	}
+	tests := []struct {
+		Name        string
+		Quota       map[uint32]fsDiskQuota
+		Err         error
+		Expectation Expectation
+	}{
+		{
+			Name: "no projects",
+		},
+		{
+			Name: "multiple projects none used",
+			Quota: map[uint32]fsDiskQuota{
+				100: {ID: 100, BlkHardLimit: 10},
+				200: {ID: 200, BlkSoftLimit: 10},
+			},
+		},
+		{
+			Name: "multiple projects in use",
+			Quota: map[uint32]fsDiskQuota{
+				0:   {ID: 0, BCount: 8},
+				100: {ID: 100, BCount: 8},
+				150: {ID: 150},
+				200: {ID: 200, BCount: 1},
+			},
+			Expectation: Expectation{
+				ProjectIDs: []int{0, 100, 200},
+			},
+		},
+		{
+			Name: "unsupported filesystem",
+			Err:  unix.ESRCH,
+			Expectation: Expectation{
+				Error: "filesystem does not support project quota via quotactl: no such process",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			fake := &fakeQuotactl{Quota: test.Quota, Err: test.Err}
+			n := &Native{quotactl: fake.quotactl}
+
+			var (
+				act Expectation
+				err error
+			)
+			act.ProjectIDs, err = n.getUsedProjectIDs()
+			if err != nil {
+				act.Error = err.Error()
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected getUsedProjectIDs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNativeSetQuota(t *testing.T) {
+	type Expectation struct {
+		ProjectID  int
+		ProjectIDs []int
+		Projects   map[string]uint32
+		Quota      map[uint32]fsDiskQuota
+		Error      string
+	}
+	tests := []struct {
+		Name          string
+		Size          Size
+		IsHard        bool
+		SetProjectErr error
+		ProjectIDs    []int
+		Expectation   Expectation
+	}{
+		{
+			Name:   "happy path",
+			Size:   100 * Kilobyte,
+			IsHard: true,
+			Expectation: Expectation{
+				ProjectID:  1000,
+				ProjectIDs: []int{1000},
+				Projects:   map[string]uint32{".": 1000, "file": 1000, "dir": 1000},
+				Quota:      map[uint32]fsDiskQuota{1000: {ID: 1000, BlkHardLimit: 200}},
+			},
+		},
+		{
+			Name:   "with soft limit",
+			Size:   100 * Kilobyte,
+			IsHard: false,
+			Expectation: Expectation{
+				ProjectID:  1000,
+				ProjectIDs: []int{1000},
+				Projects:   map[string]uint32{".": 1000, "file": 1000, "dir": 1000},
+				Quota:      map[uint32]fsDiskQuota{1000: {ID: 1000, BlkSoftLimit: 200}},
+			},
+		},
+		{
+			Name:       "with other prj",
+			Size:       100 * Kilobyte,
+			IsHard:     true,
+			ProjectIDs: []int{1000},
+			Expectation: Expectation{
+				ProjectID:  1001,
+				ProjectIDs: []int{1000, 1001},
+				Projects:   map[string]uint32{".": 1001, "file": 1001, "dir": 1001},
+				Quota:      map[uint32]fsDiskQuota{1001: {ID: 1001, BlkHardLimit: 200}},
+			},
+		},
+		{
+			Name:          "prj creation failure",
+			Size:          100 * Kilobyte,
+			IsHard:        true,
+			SetProjectErr: fmt.Errorf("failed to set project"),
+			Expectation: Expectation{
+				Projects: map[string]uint32{},
+				Quota:    map[uint32]fsDiskQuota{},
+				Error:    "failed to set project",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				act  Expectation
+				err  error
+				fake = &fakeQuotactl{Quota: make(map[uint32]fsDiskQuota)}
+			)
+			// the project ID is set on the location and its direct children only, like xfs_quota -d 1 does
+			location := t.TempDir()
+			err = os.MkdirAll(filepath.Join(location, "dir", "nested"), 0755)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = os.WriteFile(filepath.Join(location, "file"), nil, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = os.Symlink("file", filepath.Join(location, "link"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			act.Projects = make(map[string]uint32)
+			n := &Native{
+				quotactl: fake.quotactl,
+				setProject: func(path string, prjID uint32) error {
+					if test.SetProjectErr != nil {
+						return test.SetProjectErr
+					}
+					rel, err := filepath.Rel(location, path)
+					if err != nil {
+						return err
+					}
+					act.Projects[rel] = prjID
+					return nil
+				},
+				projectIDs: make(map[int]struct{}),
+			}
+			for _, prjid := range test.ProjectIDs {
+				n.projectIDs[prjid] = struct{}{}
+			}
+
+			act.ProjectID, err = n.SetQuota(location, test.Size, test.IsHard)
+			if err != nil {
+				act.Error = err.Error()
+			}
+			for p := range n.projectIDs {
+				act.ProjectIDs = append(act.ProjectIDs, p)
+			}
+			sort.Ints(act.ProjectIDs)
+			act.Quota = fake.Quota
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected SetQuota (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNativeUsage(t *testing.T) {
+	fake := &fakeQuotactl{Quota: map[uint32]fsDiskQuota{
+		1000: {ID: 1000, BCount: 2048, BlkSoftLimit: 4096, BlkHardLimit: 8192},
+	}}
+	n := &Native{
+		quotactl:   fake.quotactl,
+		projectIDs: map[int]struct{}{1000: {}},
+	}
+
+	usage, err := n.GetUsage(1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&Usage{Used: 1 * Megabyte, SoftLimit: 2 * Megabyte, HardLimit: 4 * Megabyte}, usage); diff != "" {
+		t.Errorf("unexpected GetUsage (-want +got):\n%s", diff)
+	}
+
+	usage, err = n.GetUsage(1001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&Usage{}, usage); diff != "" {
+		t.Errorf("unexpected GetUsage for unknown project (-want +got):\n%s", diff)
+	}
+
+	err = n.RemoveQuota(1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(fsDiskQuota{ID: 1000, BCount: 2048}, fake.Quota[1000]); diff != "" {
+		t.Errorf("RemoveQuota did not reset the limits (-want +got):\n%s", diff)
+	}
+	if n.GetProjectUseCount() != 0 {
+		t.Errorf("RemoveQuota did not free the project ID")
+	}
+}
@@ -87,22 +87,9 @@ func (xfs *XFS) getUsedProjectIDs() ([]int, error) {
 
 // SetQuota sets the quota for a path
 func (xfs *XFS) SetQuota(path string, quota Size, isHard bool) (projectID int, err error) {
-	xfs.mu.Lock()
-	var (
-		prjID = prjidLow
-		found bool
-	)
-	for ; prjID < prjidHi; prjID++ {
-		_, exists := xfs.projectIDs[prjID]
-		if !exists {
-			found = true
-			xfs.projectIDs[prjID] = struct{}{}
-			break
-		}
-	}
-	xfs.mu.Unlock()
-	if !found {
-		return 0, fmt.Errorf("no free projectID found")
+	prjID, err := allocateProjectID(&xfs.mu, xfs.projectIDs)
+	if err != nil {
+		return 0, err
 	}
 
 	defer func() {
@@ -166,3 +153,31 @@ func (xfs *XFS) GetProjectUseCount() int {
 
 	return len(xfs.projectIDs)
 }
+
+// GetUsage returns the current disk usage and limits of a project
+func (xfs *XFS) GetUsage(projectID int) (*Usage, error) {
+	out, err := xfs.exec(xfs.Dir, fmt.Sprintf("quota -p -b -N %d", projectID))
+	if err != nil {
+		return nil, err
+	}
+
+	// xfs_quota reports blocks of 1KiB: <filesystem> <used> <soft> <hard> <warn/grace> [<grace period>] <mountpoint>
+	fields := strings.Fields(out)
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("cannot parse xfs_quota output: %s", out)
+	}
+
+	var blocks [3]int64
+	for i := range blocks {
+		blocks[i], err = strconv.ParseInt(fields[i+1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse xfs_quota output: %s", out)
+		}
+	}
+
+	return &Usage{
+		Used:      Size(blocks[0]) * Kilobyte,
+		SoftLimit: Size(blocks[1]) * Kilobyte,
+		HardLimit: Size(blocks[2]) * Kilobyte,
+	}, nil
+}
@@ -191,3 +191,71 @@ func TestSetQuota(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUsage(t *testing.T) {
+	type Expectation struct {
+		Usage *Usage
+		Error string
+	}
+	tests := []struct {
+		Name        string
+		Input       string
+		InputErr    error
+		Expectation Expectation
+	}{
+		{
+			Name:  "with limits",
+			Input: "/dev/sdb          1024       2048       4096   00 [--------] /var/gitpod/workspaces\n",
+			Expectation: Expectation{
+				Usage: &Usage{Used: 1 * Megabyte, SoftLimit: 2 * Megabyte, HardLimit: 4 * Megabyte},
+			},
+		},
+		{
+			Name:  "exceeding soft limit",
+			Input: "/dev/sdb          2052       2048          0   00 [6 days] /var/gitpod/workspaces\n",
+			Expectation: Expectation{
+				Usage: &Usage{Used: 2052 * Kilobyte, SoftLimit: 2 * Megabyte},
+			},
+		},
+		{
+			Name:  "unexpected output",
+			Input: "foo bar",
+			Expectation: Expectation{
+				Error: "cannot parse xfs_quota output: foo bar",
+			},
+		},
+		{
+			Name:     "exec failure",
+			InputErr: fmt.Errorf("exec failed"),
+			Expectation: Expectation{
+				Error: "exec failed",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			xfs := &XFS{
+				exec: func(dir, command string) (output string, err error) {
+					if command != "quota -p -b -N 1000" {
+						return "", fmt.Errorf("unexpected command: %s", command)
+					}
+					return test.Input, test.InputErr
+				},
+			}
+
+			var (
+				act Expectation
+				err error
+			)
+			act.Usage, err = xfs.GetUsage(1000)
+			if err != nil {
+				act.Error = err.Error()
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected GetUsage (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
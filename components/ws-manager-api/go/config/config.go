@@ -53,6 +53,11 @@ type ServiceConfiguration struct {
 			PrivateKey  string `json:"key"`
 		} `json:"tls"`
 		RateLimits map[string]grpc.RateLimit `json:"ratelimits"`
+		// RateLimitStore configures a Redis instance through which rate limits are shared
+		// between replicas. If empty, each replica enforces its limits in memory.
+		RateLimitStore struct {
+			RedisAddr string `json:"redisAddr,omitempty"`
+		} `json:"ratelimitStore"`
 	} `json:"rpcServer"`
 	ImageBuilderProxy struct {
 		TargetAddr string `json:"targetAddr"`
@@ -133,6 +138,20 @@ type Configuration struct {
 	TimeoutMaxConcurrentReconciles int `json:"timeoutMaxConcurrentReconciles,omitempty"`
 	// ExperimentalMode controls if experimental features are enabled
 	ExperimentalMode bool `json:"experimentalMode"`
+	// Prebuilds configures how ws-manager-mk2 schedules and tracks prebuild workspaces
+	Prebuilds *PrebuildConfiguration `json:"prebuilds,omitempty"`
+}
+
+// PrebuildConfiguration configures the prebuild controller of ws-manager-mk2
+type PrebuildConfiguration struct {
+	// MaxConcurrentPerProject is the number of prebuilds of a project which run at the same time. Further prebuilds
+	// are queued until a running one stops. Zero means no limit.
+	MaxConcurrentPerProject int `json:"maxConcurrentPerProject,omitempty"`
+	// CancelSuperseded stops running and queued prebuilds of a branch once a prebuild for a newer commit of
+	// that branch is started, unless the prebuild is annotated to keep outdated prebuilds running.
+	CancelSuperseded bool `json:"cancelSuperseded,omitempty"`
+	// Retention is how long the outcome of a prebuild is kept after its workspace is gone. Defaults to 24 hours.
+	Retention util.Duration `json:"retention,omitempty"`
 }
 
 type WorkspaceClass struct {
@@ -237,6 +256,10 @@ func (c *Configuration) Validate() error {
 		return err
 	}
 
+	if c.Prebuilds != nil && c.Prebuilds.MaxConcurrentPerProject < 0 {
+		return xerrors.Errorf("prebuilds: maxConcurrentPerProject must not be negative")
+	}
+
 	if _, ok := c.WorkspaceClasses[DefaultWorkspaceClass]; !ok {
 		return xerrors.Errorf("missing \"%s\" workspace class", DefaultWorkspaceClass)
 	}
@@ -0,0 +1,116 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PrebuildSpec defines the commit of a project which is prebuilt
+type PrebuildSpec struct {
+	// +kubebuilder:validation:Required
+	ProjectID string `json:"projectID"`
+
+	// +kubebuilder:validation:Required
+	Commit string `json:"commit"`
+}
+
+// +kubebuilder:validation:Enum=queued;running;available;failed;aborted;timeout
+type PrebuildPhase string
+
+const (
+	// PrebuildPhaseQueued means the prebuild waits until its project may run another prebuild
+	PrebuildPhaseQueued PrebuildPhase = "queued"
+	// PrebuildPhaseRunning means the prebuild workspace is starting or running its tasks
+	PrebuildPhaseRunning PrebuildPhase = "running"
+	// PrebuildPhaseAvailable means the prebuild finished successfully
+	PrebuildPhaseAvailable PrebuildPhase = "available"
+	// PrebuildPhaseFailed means a prebuild task or the prebuild workspace failed
+	PrebuildPhaseFailed PrebuildPhase = "failed"
+	// PrebuildPhaseAborted means the prebuild was stopped before it finished, e.g. because it was superseded
+	PrebuildPhaseAborted PrebuildPhase = "aborted"
+	// PrebuildPhaseTimeout means the prebuild took longer than the headless workspace timeout
+	PrebuildPhaseTimeout PrebuildPhase = "timeout"
+)
+
+// Final returns true if a prebuild in this phase does not change anymore
+func (p PrebuildPhase) Final() bool {
+	switch p {
+	case PrebuildPhaseAvailable, PrebuildPhaseFailed, PrebuildPhaseAborted, PrebuildPhaseTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// PrebuildStatus defines the observed state of a prebuild, across all workspaces which built its commit
+type PrebuildStatus struct {
+	// Branch is the branch the most recent attempt builds
+	// +kubebuilder:validation:Optional
+	Branch string `json:"branch,omitempty"`
+
+	// Phase is the phase of the most recent attempt
+	// +kubebuilder:validation:Optional
+	Phase PrebuildPhase `json:"phase,omitempty"`
+
+	// InstanceID is the workspace instance of the most recent attempt
+	// +kubebuilder:validation:Optional
+	InstanceID string `json:"instanceID,omitempty"`
+
+	// InstanceCreated is the time at which the most recent attempt was created
+	// +kubebuilder:validation:Optional
+	InstanceCreated metav1.Time `json:"instanceCreated,omitempty"`
+
+	// Instances are the workspace instances of all attempts, i.e. the first attempt and all retries
+	// +kubebuilder:validation:Optional
+	Instances []string `json:"instances,omitempty"`
+
+	// Error explains why the most recent attempt failed, was aborted or timed out
+	// +kubebuilder:validation:Optional
+	Error string `json:"error,omitempty"`
+
+	// Created is the time at which the first attempt was created
+	// +kubebuilder:validation:Optional
+	Created metav1.Time `json:"created,omitempty"`
+
+	// Finished is the time at which the most recent attempt finished. Unset while it has not finished.
+	// +kubebuilder:validation:Optional
+	Finished *metav1.Time `json:"finished,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=prebuild
+// Custom print columns on the Custom Resource Definition. These are the columns
+// showing up when doing e.g. `kubectl get prebuilds`.
+// Columns with priority > 0 will only show up with `-o wide`.
+//+kubebuilder:printcolumn:name="Project",type="string",JSONPath=".spec.projectID"
+//+kubebuilder:printcolumn:name="Commit",type="string",JSONPath=".spec.commit"
+//+kubebuilder:printcolumn:name="Branch",type="string",JSONPath=".status.branch",priority=10
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Instance",type="string",JSONPath=".status.instanceID",priority=10
+
+// Prebuild records the outcome of the prebuild of a commit of a project. It outlives the workspaces
+// which built the commit, such that the outcome and the number of retries survive restarts of ws-manager.
+type Prebuild struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PrebuildSpec   `json:"spec,omitempty"`
+	Status PrebuildStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PrebuildList contains a list of Prebuilds
+type PrebuildList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Prebuild `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Prebuild{}, &PrebuildList{})
+}
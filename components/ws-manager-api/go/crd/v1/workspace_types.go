@@ -65,6 +65,8 @@ type Ownership struct {
 	// +kubebuilder:validation:Optional
 	Team string `json:"team,omitempty"`
 	// +kubebuilder:validation:Optional
+	Project string `json:"project,omitempty"`
+	// +kubebuilder:validation:Optional
 	Tenant string `json:"tenant,omitempty"`
 }
 
@@ -147,6 +149,10 @@ type WorkspaceStatus struct {
 	// +kubebuilder:validation:Optional
 	GitStatus *GitStatus `json:"git,omitempty"`
 
+	// AdditionalGitStatus contains the Git status of the additional repositories of a multi-repository workspace.
+	// +kubebuilder:validation:Optional
+	AdditionalGitStatus []RepositoryGitStatus `json:"additionalGit,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Runtime *WorkspaceRuntimeStatus `json:"runtime,omitempty"`
 }
@@ -353,6 +359,13 @@ type GitStatus struct {
 	TotalUnpushedCommits int64 `json:"totalUnpushedCommits,omitempty"`
 }
 
+type RepositoryGitStatus struct {
+	// checkout_location is the location of the repository relative to the workspace root
+	CheckoutLocation string `json:"checkoutLocation"`
+
+	GitStatus `json:",inline"`
+}
+
 type WorkspaceRuntimeStatus struct {
 	NodeName string `json:"nodeName,omitempty"`
 	PodName  string `json:"podName,omitempty"`
@@ -0,0 +1,805 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.20.1
+// source: prebuilds.proto
+
+package api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PrebuildPhase is the phase of a prebuild
+type PrebuildPhase int32
+
+const (
+	PrebuildPhase_PREBUILD_PHASE_UNSPECIFIED PrebuildPhase = 0
+	// PREBUILD_PHASE_QUEUED means the prebuild waits until the project has capacity to run it
+	PrebuildPhase_PREBUILD_PHASE_QUEUED PrebuildPhase = 1
+	// PREBUILD_PHASE_RUNNING means the prebuild tasks are running
+	PrebuildPhase_PREBUILD_PHASE_RUNNING PrebuildPhase = 2
+	// PREBUILD_PHASE_AVAILABLE means the prebuild finished successfully and can be used by workspaces
+	PrebuildPhase_PREBUILD_PHASE_AVAILABLE PrebuildPhase = 3
+	// PREBUILD_PHASE_FAILED means a prebuild task or the prebuild workspace failed
+	PrebuildPhase_PREBUILD_PHASE_FAILED PrebuildPhase = 4
+	// PREBUILD_PHASE_ABORTED means the prebuild was cancelled, e.g. because it was superseded by a newer commit
+	PrebuildPhase_PREBUILD_PHASE_ABORTED PrebuildPhase = 5
+	// PREBUILD_PHASE_TIMEOUT means the prebuild took longer than its timeout
+	PrebuildPhase_PREBUILD_PHASE_TIMEOUT PrebuildPhase = 6
+)
+
+// Enum value maps for PrebuildPhase.
+var (
+	PrebuildPhase_name = map[int32]string{
+		0: "PREBUILD_PHASE_UNSPECIFIED",
+		1: "PREBUILD_PHASE_QUEUED",
+		2: "PREBUILD_PHASE_RUNNING",
+		3: "PREBUILD_PHASE_AVAILABLE",
+		4: "PREBUILD_PHASE_FAILED",
+		5: "PREBUILD_PHASE_ABORTED",
+		6: "PREBUILD_PHASE_TIMEOUT",
+	}
+	PrebuildPhase_value = map[string]int32{
+		"PREBUILD_PHASE_UNSPECIFIED": 0,
+		"PREBUILD_PHASE_QUEUED":      1,
+		"PREBUILD_PHASE_RUNNING":     2,
+		"PREBUILD_PHASE_AVAILABLE":   3,
+		"PREBUILD_PHASE_FAILED":      4,
+		"PREBUILD_PHASE_ABORTED":     5,
+		"PREBUILD_PHASE_TIMEOUT":     6,
+	}
+)
+
+func (x PrebuildPhase) Enum() *PrebuildPhase {
+	p := new(PrebuildPhase)
+	*p = x
+	return p
+}
+
+func (x PrebuildPhase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PrebuildPhase) Descriptor() protoreflect.EnumDescriptor {
+	return file_prebuilds_proto_enumTypes[0].Descriptor()
+}
+
+func (PrebuildPhase) Type() protoreflect.EnumType {
+	return &file_prebuilds_proto_enumTypes[0]
+}
+
+func (x PrebuildPhase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PrebuildPhase.Descriptor instead.
+func (PrebuildPhase) EnumDescriptor() ([]byte, []int) {
+	return file_prebuilds_proto_rawDescGZIP(), []int{0}
+}
+
+// Prebuild is the prebuild of a commit of a project, across all attempts to build it
+type Prebuild struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// project_id is the project the prebuild belongs to
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// branch is the branch the commit was pushed to
+	Branch string `protobuf:"bytes,2,opt,name=branch,proto3" json:"branch,omitempty"`
+	// commit is the SHA of the commit which is prebuilt
+	Commit string `protobuf:"bytes,3,opt,name=commit,proto3" json:"commit,omitempty"`
+	// phase is the phase of the most recent attempt
+	Phase PrebuildPhase `protobuf:"varint,4,opt,name=phase,proto3,enum=wsman.PrebuildPhase" json:"phase,omitempty"`
+	// instance_id is the ID of the workspace instance which runs the most recent attempt
+	InstanceId string `protobuf:"bytes,5,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	// attempts is the number of times the commit was prebuilt, i.e. 1 plus the number of retries
+	Attempts int32 `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// error explains why the most recent attempt failed, was aborted or timed out
+	Error string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	// created_at is the time at which the first attempt was created
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// finished_at is the time at which the most recent attempt finished. Unset while it has not finished.
+	FinishedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+}
+
+func (x *Prebuild) Reset() {
+	*x = Prebuild{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prebuilds_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Prebuild) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Prebuild) ProtoMessage() {}
+
+func (x *Prebuild) ProtoReflect() protoreflect.Message {
+	mi := &file_prebuilds_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Prebuild.ProtoReflect.Descriptor instead.
+func (*Prebuild) Descriptor() ([]byte, []int) {
+	return file_prebuilds_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Prebuild) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *Prebuild) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *Prebuild) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *Prebuild) GetPhase() PrebuildPhase {
+	if x != nil {
+		return x.Phase
+	}
+	return PrebuildPhase_PREBUILD_PHASE_UNSPECIFIED
+}
+
+func (x *Prebuild) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *Prebuild) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Prebuild) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Prebuild) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Prebuild) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+// GetPrebuildRequest requests the prebuild of a commit of a project
+type GetPrebuildRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Commit    string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+}
+
+func (x *GetPrebuildRequest) Reset() {
+	*x = GetPrebuildRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prebuilds_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPrebuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPrebuildRequest) ProtoMessage() {}
+
+func (x *GetPrebuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prebuilds_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPrebuildRequest.ProtoReflect.Descriptor instead.
+func (*GetPrebuildRequest) Descriptor() ([]byte, []int) {
+	return file_prebuilds_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPrebuildRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *GetPrebuildRequest) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+type GetPrebuildResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prebuild *Prebuild `protobuf:"bytes,1,opt,name=prebuild,proto3" json:"prebuild,omitempty"`
+}
+
+func (x *GetPrebuildResponse) Reset() {
+	*x = GetPrebuildResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prebuilds_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPrebuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPrebuildResponse) ProtoMessage() {}
+
+func (x *GetPrebuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prebuilds_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPrebuildResponse.ProtoReflect.Descriptor instead.
+func (*GetPrebuildResponse) Descriptor() ([]byte, []int) {
+	return file_prebuilds_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetPrebuildResponse) GetPrebuild() *Prebuild {
+	if x != nil {
+		return x.Prebuild
+	}
+	return nil
+}
+
+// ListPrebuildsRequest requests the prebuilds of a project
+type ListPrebuildsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// branch only lists prebuilds of the given branch, if set
+	Branch string `protobuf:"bytes,2,opt,name=branch,proto3" json:"branch,omitempty"`
+	// phase only lists prebuilds in the given phase, if set
+	Phase PrebuildPhase `protobuf:"varint,3,opt,name=phase,proto3,enum=wsman.PrebuildPhase" json:"phase,omitempty"`
+	// page_size is the number of prebuilds per page. Defaults to 25, at most 100.
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page is the page to return, starting at 1
+	Page int32 `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+}
+
+func (x *ListPrebuildsRequest) Reset() {
+	*x = ListPrebuildsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prebuilds_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPrebuildsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPrebuildsRequest) ProtoMessage() {}
+
+func (x *ListPrebuildsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prebuilds_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPrebuildsRequest.ProtoReflect.Descriptor instead.
+func (*ListPrebuildsRequest) Descriptor() ([]byte, []int) {
+	return file_prebuilds_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPrebuildsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ListPrebuildsRequest) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *ListPrebuildsRequest) GetPhase() PrebuildPhase {
+	if x != nil {
+		return x.Phase
+	}
+	return PrebuildPhase_PREBUILD_PHASE_UNSPECIFIED
+}
+
+func (x *ListPrebuildsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPrebuildsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type ListPrebuildsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prebuilds []*Prebuild `protobuf:"bytes,1,rep,name=prebuilds,proto3" json:"prebuilds,omitempty"`
+	// total_results is the total number of prebuilds which match the request
+	TotalResults int32 `protobuf:"varint,2,opt,name=total_results,json=totalResults,proto3" json:"total_results,omitempty"`
+}
+
+func (x *ListPrebuildsResponse) Reset() {
+	*x = ListPrebuildsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prebuilds_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPrebuildsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPrebuildsResponse) ProtoMessage() {}
+
+func (x *ListPrebuildsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prebuilds_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPrebuildsResponse.ProtoReflect.Descriptor instead.
+func (*ListPrebuildsResponse) Descriptor() ([]byte, []int) {
+	return file_prebuilds_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListPrebuildsResponse) GetPrebuilds() []*Prebuild {
+	if x != nil {
+		return x.Prebuilds
+	}
+	return nil
+}
+
+func (x *ListPrebuildsResponse) GetTotalResults() int32 {
+	if x != nil {
+		return x.TotalResults
+	}
+	return 0
+}
+
+// CancelPrebuildRequest requests to stop all attempts of the prebuild of a commit of a project
+type CancelPrebuildRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Commit    string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+}
+
+func (x *CancelPrebuildRequest) Reset() {
+	*x = CancelPrebuildRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prebuilds_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelPrebuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPrebuildRequest) ProtoMessage() {}
+
+func (x *CancelPrebuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prebuilds_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPrebuildRequest.ProtoReflect.Descriptor instead.
+func (*CancelPrebuildRequest) Descriptor() ([]byte, []int) {
+	return file_prebuilds_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CancelPrebuildRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *CancelPrebuildRequest) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+type CancelPrebuildResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CancelPrebuildResponse) Reset() {
+	*x = CancelPrebuildResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prebuilds_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelPrebuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPrebuildResponse) ProtoMessage() {}
+
+func (x *CancelPrebuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prebuilds_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPrebuildResponse.ProtoReflect.Descriptor instead.
+func (*CancelPrebuildResponse) Descriptor() ([]byte, []int) {
+	return file_prebuilds_proto_rawDescGZIP(), []int{6}
+}
+
+var File_prebuilds_proto protoreflect.FileDescriptor
+
+var file_prebuilds_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x70, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x05, 0x77, 0x73, 0x6d, 0x61, 0x6e, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd0, 0x02, 0x0a, 0x08, 0x50, 0x72,
+	0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x16, 0x0a,
+	0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x77, 0x73, 0x6d, 0x61, 0x6e, 0x2e, 0x50, 0x72, 0x65,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x50, 0x68, 0x61, 0x73, 0x65, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x3b, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x22, 0x4b, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0x42, 0x0a, 0x13, 0x47, 0x65, 0x74,
+	0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2b, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x77, 0x73, 0x6d, 0x61, 0x6e, 0x2e, 0x50, 0x72, 0x65, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x52, 0x08, 0x70, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x22, 0xaa, 0x01,
+	0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2a, 0x0a,
+	0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x77,
+	0x73, 0x6d, 0x61, 0x6e, 0x2e, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x50, 0x68, 0x61,
+	0x73, 0x65, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x22, 0x6b, 0x0a, 0x15, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x70, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x77, 0x73, 0x6d, 0x61, 0x6e, 0x2e, 0x50,
+	0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x09, 0x70, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x4e, 0x0a, 0x15, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0x18, 0x0a, 0x16, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2a, 0xd7, 0x01, 0x0a, 0x0d, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x50, 0x68,
+	0x61, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x1a, 0x50, 0x52, 0x45, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f,
+	0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x52, 0x45, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f,
+	0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x51, 0x55, 0x45, 0x55, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1a,
+	0x0a, 0x16, 0x50, 0x52, 0x45, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x50, 0x48, 0x41, 0x53, 0x45,
+	0x5f, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x52,
+	0x45, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x41, 0x56, 0x41,
+	0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x52, 0x45, 0x42,
+	0x55, 0x49, 0x4c, 0x44, 0x5f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45,
+	0x44, 0x10, 0x04, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x52, 0x45, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f,
+	0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x41, 0x42, 0x4f, 0x52, 0x54, 0x45, 0x44, 0x10, 0x05, 0x12,
+	0x1a, 0x0a, 0x16, 0x50, 0x52, 0x45, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x50, 0x48, 0x41, 0x53,
+	0x45, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x06, 0x32, 0xfa, 0x01, 0x0a, 0x11,
+	0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x72, 0x12, 0x46, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x12, 0x19, 0x2e, 0x77, 0x73, 0x6d, 0x61, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x65, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x77, 0x73,
+	0x6d, 0x61, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0d, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x12, 0x1b, 0x2e, 0x77, 0x73, 0x6d,
+	0x61, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x77, 0x73, 0x6d, 0x61, 0x6e, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x1c, 0x2e, 0x77, 0x73, 0x6d, 0x61,
+	0x6e, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x77, 0x73, 0x6d, 0x61, 0x6e, 0x2e,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x50, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x69, 0x74, 0x70, 0x6f, 0x64, 0x2d, 0x69, 0x6f,
+	0x2f, 0x67, 0x69, 0x74, 0x70, 0x6f, 0x64, 0x2f, 0x77, 0x73, 0x2d, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_prebuilds_proto_rawDescOnce sync.Once
+	file_prebuilds_proto_rawDescData = file_prebuilds_proto_rawDesc
+)
+
+func file_prebuilds_proto_rawDescGZIP() []byte {
+	file_prebuilds_proto_rawDescOnce.Do(func() {
+		file_prebuilds_proto_rawDescData = protoimpl.X.CompressGZIP(file_prebuilds_proto_rawDescData)
+	})
+	return file_prebuilds_proto_rawDescData
+}
+
+var file_prebuilds_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_prebuilds_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_prebuilds_proto_goTypes = []interface{}{
+	(PrebuildPhase)(0),             // 0: wsman.PrebuildPhase
+	(*Prebuild)(nil),               // 1: wsman.Prebuild
+	(*GetPrebuildRequest)(nil),     // 2: wsman.GetPrebuildRequest
+	(*GetPrebuildResponse)(nil),    // 3: wsman.GetPrebuildResponse
+	(*ListPrebuildsRequest)(nil),   // 4: wsman.ListPrebuildsRequest
+	(*ListPrebuildsResponse)(nil),  // 5: wsman.ListPrebuildsResponse
+	(*CancelPrebuildRequest)(nil),  // 6: wsman.CancelPrebuildRequest
+	(*CancelPrebuildResponse)(nil), // 7: wsman.CancelPrebuildResponse
+	(*timestamppb.Timestamp)(nil),  // 8: google.protobuf.Timestamp
+}
+var file_prebuilds_proto_depIdxs = []int32{
+	0, // 0: wsman.Prebuild.phase:type_name -> wsman.PrebuildPhase
+	8, // 1: wsman.Prebuild.created_at:type_name -> google.protobuf.Timestamp
+	8, // 2: wsman.Prebuild.finished_at:type_name -> google.protobuf.Timestamp
+	1, // 3: wsman.GetPrebuildResponse.prebuild:type_name -> wsman.Prebuild
+	0, // 4: wsman.ListPrebuildsRequest.phase:type_name -> wsman.PrebuildPhase
+	1, // 5: wsman.ListPrebuildsResponse.prebuilds:type_name -> wsman.Prebuild
+	2, // 6: wsman.PrebuildScheduler.GetPrebuild:input_type -> wsman.GetPrebuildRequest
+	4, // 7: wsman.PrebuildScheduler.ListPrebuilds:input_type -> wsman.ListPrebuildsRequest
+	6, // 8: wsman.PrebuildScheduler.CancelPrebuild:input_type -> wsman.CancelPrebuildRequest
+	3, // 9: wsman.PrebuildScheduler.GetPrebuild:output_type -> wsman.GetPrebuildResponse
+	5, // 10: wsman.PrebuildScheduler.ListPrebuilds:output_type -> wsman.ListPrebuildsResponse
+	7, // 11: wsman.PrebuildScheduler.CancelPrebuild:output_type -> wsman.CancelPrebuildResponse
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_prebuilds_proto_init() }
+func file_prebuilds_proto_init() {
+	if File_prebuilds_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_prebuilds_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Prebuild); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_prebuilds_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPrebuildRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_prebuilds_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPrebuildResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_prebuilds_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPrebuildsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_prebuilds_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPrebuildsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_prebuilds_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelPrebuildRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_prebuilds_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelPrebuildResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_prebuilds_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_prebuilds_proto_goTypes,
+		DependencyIndexes: file_prebuilds_proto_depIdxs,
+		EnumInfos:         file_prebuilds_proto_enumTypes,
+		MessageInfos:      file_prebuilds_proto_msgTypes,
+	}.Build()
+	File_prebuilds_proto = out.File
+	file_prebuilds_proto_rawDesc = nil
+	file_prebuilds_proto_goTypes = nil
+	file_prebuilds_proto_depIdxs = nil
+}
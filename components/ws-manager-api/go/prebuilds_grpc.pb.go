@@ -0,0 +1,187 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.20.1
+// source: prebuilds.proto
+
+package api
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PrebuildSchedulerClient is the client API for PrebuildScheduler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PrebuildSchedulerClient interface {
+	// getPrebuild returns the prebuild of a commit of a project
+	GetPrebuild(ctx context.Context, in *GetPrebuildRequest, opts ...grpc.CallOption) (*GetPrebuildResponse, error)
+	// listPrebuilds lists the prebuilds of a project, most recent first
+	ListPrebuilds(ctx context.Context, in *ListPrebuildsRequest, opts ...grpc.CallOption) (*ListPrebuildsResponse, error)
+	// cancelPrebuild stops all running and queued attempts of a prebuild
+	CancelPrebuild(ctx context.Context, in *CancelPrebuildRequest, opts ...grpc.CallOption) (*CancelPrebuildResponse, error)
+}
+
+type prebuildSchedulerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPrebuildSchedulerClient(cc grpc.ClientConnInterface) PrebuildSchedulerClient {
+	return &prebuildSchedulerClient{cc}
+}
+
+func (c *prebuildSchedulerClient) GetPrebuild(ctx context.Context, in *GetPrebuildRequest, opts ...grpc.CallOption) (*GetPrebuildResponse, error) {
+	out := new(GetPrebuildResponse)
+	err := c.cc.Invoke(ctx, "/wsman.PrebuildScheduler/GetPrebuild", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prebuildSchedulerClient) ListPrebuilds(ctx context.Context, in *ListPrebuildsRequest, opts ...grpc.CallOption) (*ListPrebuildsResponse, error) {
+	out := new(ListPrebuildsResponse)
+	err := c.cc.Invoke(ctx, "/wsman.PrebuildScheduler/ListPrebuilds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prebuildSchedulerClient) CancelPrebuild(ctx context.Context, in *CancelPrebuildRequest, opts ...grpc.CallOption) (*CancelPrebuildResponse, error) {
+	out := new(CancelPrebuildResponse)
+	err := c.cc.Invoke(ctx, "/wsman.PrebuildScheduler/CancelPrebuild", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PrebuildSchedulerServer is the server API for PrebuildScheduler service.
+// All implementations must embed UnimplementedPrebuildSchedulerServer
+// for forward compatibility
+type PrebuildSchedulerServer interface {
+	// getPrebuild returns the prebuild of a commit of a project
+	GetPrebuild(context.Context, *GetPrebuildRequest) (*GetPrebuildResponse, error)
+	// listPrebuilds lists the prebuilds of a project, most recent first
+	ListPrebuilds(context.Context, *ListPrebuildsRequest) (*ListPrebuildsResponse, error)
+	// cancelPrebuild stops all running and queued attempts of a prebuild
+	CancelPrebuild(context.Context, *CancelPrebuildRequest) (*CancelPrebuildResponse, error)
+	mustEmbedUnimplementedPrebuildSchedulerServer()
+}
+
+// UnimplementedPrebuildSchedulerServer must be embedded to have forward compatible implementations.
+type UnimplementedPrebuildSchedulerServer struct {
+}
+
+func (UnimplementedPrebuildSchedulerServer) GetPrebuild(context.Context, *GetPrebuildRequest) (*GetPrebuildResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPrebuild not implemented")
+}
+func (UnimplementedPrebuildSchedulerServer) ListPrebuilds(context.Context, *ListPrebuildsRequest) (*ListPrebuildsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPrebuilds not implemented")
+}
+func (UnimplementedPrebuildSchedulerServer) CancelPrebuild(context.Context, *CancelPrebuildRequest) (*CancelPrebuildResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelPrebuild not implemented")
+}
+func (UnimplementedPrebuildSchedulerServer) mustEmbedUnimplementedPrebuildSchedulerServer() {}
+
+// UnsafePrebuildSchedulerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PrebuildSchedulerServer will
+// result in compilation errors.
+type UnsafePrebuildSchedulerServer interface {
+	mustEmbedUnimplementedPrebuildSchedulerServer()
+}
+
+func RegisterPrebuildSchedulerServer(s grpc.ServiceRegistrar, srv PrebuildSchedulerServer) {
+	s.RegisterService(&PrebuildScheduler_ServiceDesc, srv)
+}
+
+func _PrebuildScheduler_GetPrebuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPrebuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrebuildSchedulerServer).GetPrebuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wsman.PrebuildScheduler/GetPrebuild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrebuildSchedulerServer).GetPrebuild(ctx, req.(*GetPrebuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrebuildScheduler_ListPrebuilds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPrebuildsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrebuildSchedulerServer).ListPrebuilds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wsman.PrebuildScheduler/ListPrebuilds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrebuildSchedulerServer).ListPrebuilds(ctx, req.(*ListPrebuildsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrebuildScheduler_CancelPrebuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPrebuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrebuildSchedulerServer).CancelPrebuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wsman.PrebuildScheduler/CancelPrebuild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrebuildSchedulerServer).CancelPrebuild(ctx, req.(*CancelPrebuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PrebuildScheduler_ServiceDesc is the grpc.ServiceDesc for PrebuildScheduler service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PrebuildScheduler_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wsman.PrebuildScheduler",
+	HandlerType: (*PrebuildSchedulerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPrebuild",
+			Handler:    _PrebuildScheduler_GetPrebuild_Handler,
+		},
+		{
+			MethodName: "ListPrebuilds",
+			Handler:    _PrebuildScheduler_ListPrebuilds_Handler,
+		},
+		{
+			MethodName: "CancelPrebuild",
+			Handler:    _PrebuildScheduler_CancelPrebuild_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "prebuilds.proto",
+}
@@ -662,14 +662,17 @@ func newStartWorkspaceContext(ctx context.Context, cfg *config.Configuration, ws
 
 	return &startWorkspaceContext{
 		Labels: map[string]string{
-			"app":                  "gitpod",
-			"component":            "workspace",
-			wsk8s.MetaIDLabel:      ws.Spec.Ownership.WorkspaceID,
-			wsk8s.WorkspaceIDLabel: ws.Name,
-			wsk8s.OwnerLabel:       ws.Spec.Ownership.Owner,
-			wsk8s.TypeLabel:        strings.ToLower(string(ws.Spec.Type)),
-			instanceIDLabel:        ws.Name,
-			headlessLabel:          strconv.FormatBool(ws.IsHeadless()),
+			"app":                     "gitpod",
+			"component":               "workspace",
+			wsk8s.MetaIDLabel:         ws.Spec.Ownership.WorkspaceID,
+			wsk8s.WorkspaceIDLabel:    ws.Name,
+			wsk8s.OwnerLabel:          ws.Spec.Ownership.Owner,
+			wsk8s.TeamLabel:           ws.Spec.Ownership.Team,
+			wsk8s.ProjectLabel:        ws.Spec.Ownership.Project,
+			wsk8s.TypeLabel:           strings.ToLower(string(ws.Spec.Type)),
+			wsk8s.WorkspaceClassLabel: ws.Spec.Class,
+			instanceIDLabel:           ws.Name,
+			headlessLabel:             strconv.FormatBool(ws.IsHeadless()),
 		},
 		Config:         cfg,
 		Workspace:      ws,
@@ -0,0 +1,360 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
+	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/prebuild"
+	config "github.com/gitpod-io/gitpod/ws-manager/api/config"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+const (
+	defaultPrebuildRetention = 24 * time.Hour
+
+	// stopPrebuildGracePeriod is the grace period of prebuilds which are stopped because they were superseded or cancelled
+	stopPrebuildGracePeriod = 1 * time.Second
+)
+
+func NewPrebuildReconciler(c client.Client, recorder record.EventRecorder, cfg config.Configuration) (*PrebuildReconciler, error) {
+	if cfg.Prebuilds == nil {
+		return nil, fmt.Errorf("prebuilds are not configured")
+	}
+
+	retention := time.Duration(cfg.Prebuilds.Retention)
+	if retention == 0 {
+		retention = defaultPrebuildRetention
+	}
+
+	return &PrebuildReconciler{
+		Client:   c,
+		Config:   *cfg.Prebuilds,
+		Tracker:  prebuild.NewTracker(c, cfg.Namespace, retention),
+		recorder: recorder,
+	}, nil
+}
+
+// PrebuildReconciler tracks the outcome of prebuild workspaces per project and commit. It admits queued prebuilds
+// as long as their project runs fewer than the configured number of prebuilds, and stops prebuilds of commits
+// which were superseded by a newer commit of the same branch.
+type PrebuildReconciler struct {
+	client.Client
+
+	Config   config.PrebuildConfiguration
+	Tracker  *prebuild.Tracker
+	recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=workspace.gitpod.io,resources=workspaces,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=workspace.gitpod.io,resources=workspaces/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=workspace.gitpod.io,resources=prebuilds,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=workspace.gitpod.io,resources=prebuilds/status,verbs=get;update;patch
+
+// Reconcile records the state of a prebuild workspace and schedules the other prebuilds of its project.
+func (r *PrebuildReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithValues("ws", req.NamespacedName)
+
+	var workspace workspacev1.Workspace
+	if err := r.Get(ctx, req.NamespacedName, &workspace); err != nil {
+		if apierrors.IsNotFound(err) {
+			err = r.Tracker.Gone(ctx, req.Name)
+			if err != nil {
+				log.Error(err, "unable to record that prebuild workspace is gone")
+			}
+			return ctrl.Result{}, err
+		}
+		log.Error(err, "unable to fetch workspace")
+		return ctrl.Result{}, err
+	}
+
+	attempt, ok := prebuildAttempt(&workspace)
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+	err := r.Tracker.Observe(ctx, attempt)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to record prebuild: %w", err)
+	}
+
+	prebuilds, err := r.listPrebuilds(ctx, workspace.Namespace, attempt.ProjectID)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if r.Config.CancelSuperseded {
+		for _, ws := range supersededPrebuilds(prebuilds) {
+			log.Info("stopping superseded prebuild", "superseded", ws.Name, "commit", ws.Annotations[wsk8s.PrebuildCommitAnnotation])
+			err = r.stopPrebuild(ctx, ws, "PrebuildSuperseded", "superseded by a prebuild of a newer commit")
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to stop superseded prebuild %s: %w", ws.Name, err)
+			}
+		}
+	}
+
+	for _, ws := range admittablePrebuilds(prebuilds, r.Config.MaxConcurrentPerProject) {
+		log.Info("admitting queued prebuild", "admitted", ws.Name)
+		err = r.admitPrebuild(ctx, ws)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to admit prebuild %s: %w", ws.Name, err)
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// listPrebuilds returns the prebuild workspaces of a project
+func (r *PrebuildReconciler) listPrebuilds(ctx context.Context, namespace, projectID string) ([]*workspacev1.Workspace, error) {
+	var workspaces workspacev1.WorkspaceList
+	err := r.List(ctx, &workspaces, client.InNamespace(namespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var res []*workspacev1.Workspace
+	for i := range workspaces.Items {
+		ws := &workspaces.Items[i]
+		if ws.Spec.Type != workspacev1.WorkspaceTypePrebuild || ws.Annotations[wsk8s.PrebuildProjectAnnotation] != projectID {
+			continue
+		}
+		res = append(res, ws)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		ti, tj := res[i].CreationTimestamp, res[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res, nil
+}
+
+// Cancel stops all prebuild workspaces of a commit of a project. It returns false if there are none.
+func (r *PrebuildReconciler) Cancel(ctx context.Context, namespace, projectID, commit string) (bool, error) {
+	prebuilds, err := r.listPrebuilds(ctx, namespace, projectID)
+	if err != nil {
+		return false, err
+	}
+
+	var found bool
+	for _, ws := range prebuilds {
+		if ws.Annotations[wsk8s.PrebuildCommitAnnotation] != commit {
+			continue
+		}
+		found = true
+		if !isPrebuildActive(ws) {
+			continue
+		}
+		err = r.stopPrebuild(ctx, ws, "PrebuildCancelled", "cancelled by request")
+		if err != nil {
+			return found, err
+		}
+	}
+	return found, nil
+}
+
+func (r *PrebuildReconciler) stopPrebuild(ctx context.Context, ws *workspacev1.Workspace, reason, message string) error {
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		err := r.Get(ctx, types.NamespacedName{Namespace: ws.Namespace, Name: ws.Name}, ws)
+		if err != nil {
+			return err
+		}
+
+		aborted := workspacev1.NewWorkspaceConditionAborted(reason)
+		aborted.Message = message
+		ws.Status.SetCondition(aborted)
+		ws.Status.SetCondition(workspacev1.NewWorkspaceConditionStoppedByRequest(stopPrebuildGracePeriod.String()))
+		return r.Status().Update(ctx, ws)
+	})
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	r.recorder.Event(ws, corev1.EventTypeNormal, "PrebuildStopped", message)
+	return nil
+}
+
+func (r *PrebuildReconciler) admitPrebuild(ctx context.Context, ws *workspacev1.Workspace) error {
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		err := r.Get(ctx, types.NamespacedName{Namespace: ws.Namespace, Name: ws.Name}, ws)
+		if err != nil {
+			return err
+		}
+
+		delete(ws.Annotations, wsk8s.PrebuildQueuedAnnotation)
+		return r.Update(ctx, ws)
+	})
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	r.recorder.Event(ws, corev1.EventTypeNormal, "PrebuildAdmitted", "")
+	return nil
+}
+
+// prebuildAttempt returns the prebuild attempt a workspace runs. It returns false if the workspace is no prebuild of a project.
+func prebuildAttempt(ws *workspacev1.Workspace) (prebuild.Attempt, bool) {
+	if ws.Spec.Type != workspacev1.WorkspaceTypePrebuild {
+		return prebuild.Attempt{}, false
+	}
+	projectID := ws.Annotations[wsk8s.PrebuildProjectAnnotation]
+	if projectID == "" {
+		return prebuild.Attempt{}, false
+	}
+
+	phase, msg := prebuildPhase(ws)
+	return prebuild.Attempt{
+		InstanceID: ws.Name,
+		ProjectID:  projectID,
+		Branch:     ws.Annotations[wsk8s.PrebuildBranchAnnotation],
+		Commit:     ws.Annotations[wsk8s.PrebuildCommitAnnotation],
+		Phase:      phase,
+		Error:      msg,
+		Created:    ws.CreationTimestamp.Time,
+	}, true
+}
+
+// prebuildPhase derives the phase of a prebuild from the conditions of its workspace
+func prebuildPhase(ws *workspacev1.Workspace) (workspacev1.PrebuildPhase, string) {
+	conditions := ws.Status.Conditions
+	if c := wsk8s.GetCondition(conditions, string(workspacev1.WorkspaceConditionAborted)); c != nil && c.Status == "True" {
+		msg := c.Message
+		if msg == "" {
+			msg = c.Reason
+		}
+		return workspacev1.PrebuildPhaseAborted, msg
+	}
+	if c := wsk8s.GetCondition(conditions, string(workspacev1.WorkspaceConditionTimeout)); c != nil && c.Status == "True" {
+		return workspacev1.PrebuildPhaseTimeout, c.Message
+	}
+	for _, tpe := range []workspacev1.WorkspaceCondition{
+		workspacev1.WorkspaceConditionFailed,
+		workspacev1.WorkspaceConditionsHeadlessTaskFailed,
+		workspacev1.WorkspaceConditionBackupFailure,
+	} {
+		if c := wsk8s.GetCondition(conditions, string(tpe)); c != nil && c.Status == "True" {
+			return workspacev1.PrebuildPhaseFailed, c.Message
+		}
+	}
+	if wsk8s.ConditionPresentAndTrue(conditions, string(workspacev1.WorkspaceConditionStoppedByRequest)) {
+		return workspacev1.PrebuildPhaseAborted, "stopped by request"
+	}
+	if ws.Status.Phase == workspacev1.WorkspacePhaseStopped {
+		return workspacev1.PrebuildPhaseAvailable, ""
+	}
+	if isPrebuildQueued(ws) {
+		return workspacev1.PrebuildPhaseQueued, ""
+	}
+	return workspacev1.PrebuildPhaseRunning, ""
+}
+
+// isPrebuildQueued returns true if the pod of a prebuild workspace must not be created yet
+func isPrebuildQueued(ws *workspacev1.Workspace) bool {
+	_, queued := ws.Annotations[wsk8s.PrebuildQueuedAnnotation]
+	return queued
+}
+
+// isPrebuildActive returns true if a prebuild workspace is queued or running, and nobody asked to stop it yet
+func isPrebuildActive(ws *workspacev1.Workspace) bool {
+	if ws.Status.Phase == workspacev1.WorkspacePhaseStopping || ws.Status.Phase == workspacev1.WorkspacePhaseStopped {
+		return false
+	}
+	if isWorkspaceBeingDeleted(ws) {
+		return false
+	}
+	for _, c := range []workspacev1.WorkspaceCondition{
+		workspacev1.WorkspaceConditionStoppedByRequest,
+		workspacev1.WorkspaceConditionTimeout,
+		workspacev1.WorkspaceConditionFailed,
+	} {
+		if wsk8s.ConditionPresentAndTrue(ws.Status.Conditions, string(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+// supersededPrebuilds returns the active prebuilds of a project for which a newer commit of the same branch is prebuilt.
+// Prebuilds must be ordered by creation time.
+func supersededPrebuilds(prebuilds []*workspacev1.Workspace) []*workspacev1.Workspace {
+	latest := make(map[string]string)
+	for _, ws := range prebuilds {
+		if branch := ws.Annotations[wsk8s.PrebuildBranchAnnotation]; branch != "" {
+			latest[branch] = ws.Annotations[wsk8s.PrebuildCommitAnnotation]
+		}
+	}
+
+	var res []*workspacev1.Workspace
+	for _, ws := range prebuilds {
+		branch := ws.Annotations[wsk8s.PrebuildBranchAnnotation]
+		if branch == "" || ws.Annotations[wsk8s.PrebuildCommitAnnotation] == latest[branch] {
+			continue
+		}
+		if _, keep := ws.Annotations[wsk8s.PrebuildKeepOutdatedAnnotation]; keep {
+			continue
+		}
+		if !isPrebuildActive(ws) {
+			continue
+		}
+		res = append(res, ws)
+	}
+	return res
+}
+
+// admittablePrebuilds returns the queued prebuilds which may start, oldest first, given the number of
+// prebuilds a project may run at the same time. Prebuilds must be ordered by creation time.
+func admittablePrebuilds(prebuilds []*workspacev1.Workspace, maxConcurrent int) []*workspacev1.Workspace {
+	var (
+		running int
+		queued  []*workspacev1.Workspace
+	)
+	for _, ws := range prebuilds {
+		if !isPrebuildActive(ws) {
+			continue
+		}
+		if isPrebuildQueued(ws) {
+			queued = append(queued, ws)
+		} else {
+			running++
+		}
+	}
+
+	if maxConcurrent <= 0 {
+		return queued
+	}
+	capacity := maxConcurrent - running
+	if capacity <= 0 {
+		return nil
+	}
+	if capacity < len(queued) {
+		queued = queued[:capacity]
+	}
+	return queued
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PrebuildReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("prebuild").
+		// A single worker prevents admitting more prebuilds than allowed, because admission decisions
+		// are based on all prebuilds of a project.
+		WithOptions(controller.Options{MaxConcurrentReconciles: 1}).
+		For(&workspacev1.Workspace{}).
+		Complete(r)
+}
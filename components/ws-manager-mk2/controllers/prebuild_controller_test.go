@@ -0,0 +1,124 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package controllers
+
+import (
+	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("PrebuildController", func() {
+	type prebuildSpec struct {
+		name    string
+		branch  string
+		commit  string
+		queued  bool
+		stopped bool
+		keep    bool
+	}
+	newPrebuilds := func(specs []prebuildSpec) []*workspacev1.Workspace {
+		var res []*workspacev1.Workspace
+		for _, s := range specs {
+			ws := newWorkspace(s.name, "default")
+			ws.Spec.Type = workspacev1.WorkspaceTypePrebuild
+			ws.Annotations = map[string]string{
+				wsk8s.PrebuildProjectAnnotation: "project",
+				wsk8s.PrebuildBranchAnnotation:  s.branch,
+				wsk8s.PrebuildCommitAnnotation:  s.commit,
+			}
+			if s.queued {
+				ws.Annotations[wsk8s.PrebuildQueuedAnnotation] = "true"
+			}
+			if s.keep {
+				ws.Annotations[wsk8s.PrebuildKeepOutdatedAnnotation] = "true"
+			}
+			if s.stopped {
+				ws.Status.Phase = workspacev1.WorkspacePhaseStopped
+			}
+			res = append(res, ws)
+		}
+		return res
+	}
+	names := func(wss []*workspacev1.Workspace) []string {
+		res := []string{}
+		for _, ws := range wss {
+			res = append(res, ws.Name)
+		}
+		return res
+	}
+
+	DescribeTable("admitting queued prebuilds",
+		func(maxConcurrent int, specs []prebuildSpec, expectation []string) {
+			Expect(names(admittablePrebuilds(newPrebuilds(specs), maxConcurrent))).To(Equal(expectation))
+		},
+		Entry("admits oldest first", 2, []prebuildSpec{
+			{name: "a", commit: "1", queued: true},
+			{name: "b", commit: "2", queued: true},
+			{name: "c", commit: "3", queued: true},
+		}, []string{"a", "b"}),
+		Entry("counts running prebuilds", 2, []prebuildSpec{
+			{name: "a", commit: "1"},
+			{name: "b", commit: "2", queued: true},
+			{name: "c", commit: "3", queued: true},
+		}, []string{"b"}),
+		Entry("ignores stopped prebuilds", 1, []prebuildSpec{
+			{name: "a", commit: "1", stopped: true},
+			{name: "b", commit: "2", queued: true},
+		}, []string{"b"}),
+		Entry("admits nothing at capacity", 1, []prebuildSpec{
+			{name: "a", commit: "1"},
+			{name: "b", commit: "2", queued: true},
+		}, []string{}),
+		Entry("admits everything without limit", 0, []prebuildSpec{
+			{name: "a", commit: "1"},
+			{name: "b", commit: "2", queued: true},
+			{name: "c", commit: "3", queued: true},
+		}, []string{"b", "c"}),
+	)
+
+	DescribeTable("superseding prebuilds",
+		func(specs []prebuildSpec, expectation []string) {
+			Expect(names(supersededPrebuilds(newPrebuilds(specs)))).To(Equal(expectation))
+		},
+		Entry("supersedes older commits of the same branch", []prebuildSpec{
+			{name: "a", branch: "main", commit: "1"},
+			{name: "b", branch: "main", commit: "2", queued: true},
+			{name: "c", branch: "main", commit: "3", queued: true},
+		}, []string{"a", "b"}),
+		Entry("keeps other branches", []prebuildSpec{
+			{name: "a", branch: "main", commit: "1"},
+			{name: "b", branch: "feature", commit: "2"},
+		}, []string{}),
+		Entry("keeps retries of the latest commit", []prebuildSpec{
+			{name: "a", branch: "main", commit: "1"},
+			{name: "b", branch: "main", commit: "1"},
+		}, []string{}),
+		Entry("keeps stopped prebuilds and prebuilds which opted out", []prebuildSpec{
+			{name: "a", branch: "main", commit: "1", stopped: true},
+			{name: "b", branch: "main", commit: "2", keep: true},
+			{name: "c", branch: "main", commit: "3"},
+		}, []string{}),
+	)
+
+	It("should derive the prebuild phase from the workspace", func() {
+		ws := newPrebuilds([]prebuildSpec{{name: "a", branch: "main", commit: "1", queued: true}})[0]
+		Expect(prebuildPhase(ws)).To(BeEquivalentTo("queued"))
+
+		delete(ws.Annotations, wsk8s.PrebuildQueuedAnnotation)
+		Expect(prebuildPhase(ws)).To(BeEquivalentTo("running"))
+
+		ws.Status.Conditions = append(ws.Status.Conditions, metav1.Condition{
+			Type:    string(workspacev1.WorkspaceConditionsHeadlessTaskFailed),
+			Status:  metav1.ConditionTrue,
+			Message: "task failed",
+		})
+		phase, msg := prebuildPhase(ws)
+		Expect(phase).To(BeEquivalentTo("failed"))
+		Expect(msg).To(Equal("task failed"))
+	})
+})
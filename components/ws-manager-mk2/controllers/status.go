@@ -44,6 +44,13 @@ func (r *WorkspaceReconciler) updateWorkspaceStatus(ctx context.Context, workspa
 		if workspace.Status.Phase == workspacev1.WorkspacePhaseStopping && isDisposalFinished(workspace) {
 			workspace.Status.Phase = workspacev1.WorkspacePhaseStopped
 		}
+
+		if workspace.Status.PodStarts == 0 && isPrebuildQueued(workspace) &&
+			(wsk8s.ConditionPresentAndTrue(workspace.Status.Conditions, string(workspacev1.WorkspaceConditionStoppedByRequest)) ||
+				wsk8s.ConditionPresentAndTrue(workspace.Status.Conditions, string(workspacev1.WorkspaceConditionTimeout))) {
+			// A queued prebuild never had a pod, hence there is nothing to dispose.
+			workspace.Status.Phase = workspacev1.WorkspacePhaseStopped
+		}
 		return nil
 	case 1:
 		// continue below
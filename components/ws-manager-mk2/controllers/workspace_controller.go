@@ -165,7 +165,12 @@ func (r *WorkspaceReconciler) actOnStatus(ctx context.Context, workspace *worksp
 	if len(workspacePods.Items) == 0 {
 		// if there isn't a workspace pod and we're not currently deleting this workspace,// create one.
 		switch {
-		case workspace.Status.PodStarts == 0:
+		case workspace.Status.PodStarts == 0 && isPrebuildQueued(workspace) && workspace.Status.Phase != workspacev1.WorkspacePhaseStopped:
+			// The prebuild controller admits the workspace once its project may run another prebuild.
+			log.Info("prebuild is queued, not creating a workspace pod yet")
+			return ctrl.Result{}, nil
+
+		case workspace.Status.PodStarts == 0 && !isPrebuildQueued(workspace):
 			sctx, err := newStartWorkspaceContext(ctx, r.Config, workspace)
 			if err != nil {
 				log.Error(err, "unable to create startWorkspace context")
@@ -22,6 +22,7 @@ import (
 	"github.com/bombsimon/logrusr/v2"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -154,7 +155,16 @@ func main() {
 		os.Exit(1)
 	}
 
-	wsmanService, err := setupGRPCService(cfg, mgr.GetClient(), activity, maintenanceReconciler)
+	var prebuildReconciler *controllers.PrebuildReconciler
+	if cfg.Manager.Prebuilds != nil {
+		prebuildReconciler, err = controllers.NewPrebuildReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("prebuild"), cfg.Manager)
+		if err != nil {
+			setupLog.Error(err, "unable to create prebuild controller", "controller", "Prebuild")
+			os.Exit(1)
+		}
+	}
+
+	wsmanService, err := setupGRPCService(cfg, mgr.GetClient(), activity, maintenanceReconciler, prebuildReconciler)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager service")
 		os.Exit(1)
@@ -173,6 +183,12 @@ func main() {
 		setupLog.Error(err, "unable to setup maintenance controller with manager", "controller", "Maintenance")
 		os.Exit(1)
 	}
+	if prebuildReconciler != nil {
+		if err = prebuildReconciler.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to setup prebuild controller with manager", "controller", "Prebuild")
+			os.Exit(1)
+		}
+	}
 
 	// if err = (&workspacev1.Workspace{}).SetupWebhookWithManager(mgr); err != nil {
 	// 	setupLog.Error(err, "unable to create webhook", "webhook", "Workspace")
@@ -197,20 +213,25 @@ func main() {
 	}
 }
 
-func setupGRPCService(cfg *config.ServiceConfiguration, k8s client.Client, activity *activity.WorkspaceActivity, maintenance maintenance.Maintenance) (*service.WorkspaceManagerServer, error) {
+func setupGRPCService(cfg *config.ServiceConfiguration, k8s client.Client, activity *activity.WorkspaceActivity, maintenance maintenance.Maintenance, prebuilds *controllers.PrebuildReconciler) (*service.WorkspaceManagerServer, error) {
 	// TODO(cw): remove use of common-go/log
 
 	if len(cfg.RPCServer.RateLimits) > 0 {
 		log.WithField("ratelimits", cfg.RPCServer.RateLimits).Info("imposing rate limits on the gRPC interface")
 	}
-	ratelimits := common_grpc.NewRatelimitingInterceptor(cfg.RPCServer.RateLimits)
+	var ratelimitOpts []common_grpc.RatelimitingOption
+	if addr := cfg.RPCServer.RateLimitStore.RedisAddr; addr != "" {
+		log.WithField("addr", addr).Info("sharing gRPC rate limits through Redis")
+		ratelimitOpts = append(ratelimitOpts, common_grpc.WithRatelimitStore(common_grpc.NewRedisRatelimitStore(redis.NewClient(&redis.Options{Addr: addr}))))
+	}
+	ratelimits := common_grpc.NewRatelimitingInterceptor(cfg.RPCServer.RateLimits, ratelimitOpts...)
 
 	grpcMetrics := grpc_prometheus.NewServerMetrics()
 	grpcMetrics.EnableHandlingTimeHistogram()
 	metrics.Registry.MustRegister(grpcMetrics)
 
 	grpcOpts := common_grpc.ServerOptionsWithInterceptors(
-		[]grpc.StreamServerInterceptor{grpcMetrics.StreamServerInterceptor()},
+		[]grpc.StreamServerInterceptor{grpcMetrics.StreamServerInterceptor(), ratelimits.StreamInterceptor()},
 		[]grpc.UnaryServerInterceptor{grpcMetrics.UnaryServerInterceptor(), ratelimits.UnaryInterceptor()},
 	)
 	if cfg.RPCServer.TLS.CA != "" && cfg.RPCServer.TLS.Certificate != "" && cfg.RPCServer.TLS.PrivateKey != "" {
@@ -261,6 +282,13 @@ func setupGRPCService(cfg *config.ServiceConfiguration, k8s client.Client, activ
 		Client:    k8s,
 		Namespace: cfg.Manager.Namespace,
 	})
+	if prebuilds != nil {
+		wsmanapi.RegisterPrebuildSchedulerServer(grpcServer, &service.PrebuildService{
+			Namespace: cfg.Manager.Namespace,
+			Tracker:   prebuilds.Tracker,
+			Canceler:  prebuilds,
+		})
+	}
 
 	lis, err := net.Listen("tcp", cfg.RPCServer.Addr)
 	if err != nil {
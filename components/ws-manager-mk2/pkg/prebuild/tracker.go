@@ -0,0 +1,282 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package prebuild
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wsk8s "github.com/gitpod-io/gitpod/common-go/kubernetes"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+const (
+	// instanceLabel contains the workspace instance of the most recent attempt of a prebuild
+	instanceLabel = "gitpod.io/prebuildInstance"
+)
+
+// Attempt is a single workspace which builds a commit of a project
+type Attempt struct {
+	InstanceID string
+	ProjectID  string
+	Branch     string
+	Commit     string
+	Phase      workspacev1.PrebuildPhase
+	Error      string
+	Created    time.Time
+}
+
+// Prebuild is the state of the prebuild of a commit of a project, across all its attempts
+type Prebuild struct {
+	ProjectID string
+	Branch    string
+	Commit    string
+	// Phase is the phase of the most recent attempt
+	Phase workspacev1.PrebuildPhase
+	// InstanceID is the workspace instance of the most recent attempt
+	InstanceID string
+	// Attempts counts the workspaces which built this commit, i.e. 1 plus the number of retries
+	Attempts int
+	Error    string
+	// Created is the time at which the first attempt was created
+	Created time.Time
+	// Finished is the time at which the most recent attempt finished. Zero while it has not finished.
+	Finished time.Time
+}
+
+// Tracker keeps the state of prebuilds in Prebuild resources, such that it survives restarts. Prebuilds which
+// are still running are tracked until their workspace is gone, finished prebuilds are kept for the retention period.
+type Tracker struct {
+	Client    client.Client
+	Namespace string
+	Retention time.Duration
+
+	now func() time.Time
+}
+
+// NewTracker creates a new tracker which keeps finished prebuilds for the retention period
+func NewTracker(c client.Client, namespace string, retention time.Duration) *Tracker {
+	return &Tracker{
+		Client:    c,
+		Namespace: namespace,
+		Retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Observe records the state of an attempt
+func (t *Tracker) Observe(ctx context.Context, a Attempt) error {
+	name := prebuildName(a.ProjectID, a.Commit)
+	err := t.Client.Create(ctx, &workspacev1.Prebuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: t.Namespace,
+			Labels: map[string]string{
+				wsk8s.ProjectLabel: a.ProjectID,
+			},
+		},
+		Spec: workspacev1.PrebuildSpec{
+			ProjectID: a.ProjectID,
+			Commit:    a.Commit,
+		},
+	})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("cannot create prebuild %s: %w", name, err)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var pb workspacev1.Prebuild
+		err := t.Client.Get(ctx, types.NamespacedName{Namespace: t.Namespace, Name: name}, &pb)
+		if err != nil {
+			return err
+		}
+
+		status := pb.Status.DeepCopy()
+		t.observe(status, a)
+		if pb.Labels[instanceLabel] != status.InstanceID {
+			// the label lets Gone find the prebuild once the workspace of its most recent attempt is gone
+			if pb.Labels == nil {
+				pb.Labels = make(map[string]string)
+			}
+			pb.Labels[instanceLabel] = status.InstanceID
+			err = t.Client.Update(ctx, &pb)
+			if err != nil {
+				return err
+			}
+		}
+		if equality.Semantic.DeepEqual(&pb.Status, status) {
+			return nil
+		}
+		pb.Status = *status
+		return t.Client.Status().Update(ctx, &pb)
+	})
+}
+
+// observe applies an attempt to the status of its prebuild
+func (t *Tracker) observe(s *workspacev1.PrebuildStatus, a Attempt) {
+	if !containsString(s.Instances, a.InstanceID) {
+		s.Instances = append(s.Instances, a.InstanceID)
+	}
+	if s.Created.IsZero() || a.Created.Before(s.Created.Time) {
+		s.Created = metav1.NewTime(a.Created)
+	}
+
+	// only the most recent attempt determines the state of the prebuild
+	if s.InstanceID != "" && s.InstanceID != a.InstanceID && a.Created.Before(s.InstanceCreated.Time) {
+		return
+	}
+
+	alreadyFinished := s.InstanceID == a.InstanceID && s.Phase.Final()
+	s.InstanceID = a.InstanceID
+	s.InstanceCreated = metav1.NewTime(a.Created)
+	s.Branch = a.Branch
+	s.Phase = a.Phase
+	s.Error = a.Error
+	switch {
+	case !a.Phase.Final():
+		s.Finished = nil
+	case !alreadyFinished:
+		finished := metav1.NewTime(t.now())
+		s.Finished = &finished
+	}
+}
+
+// Gone records that the workspace of an attempt is gone. Attempts which have not finished by then are aborted.
+// Prebuilds which finished longer than the retention period ago are removed.
+func (t *Tracker) Gone(ctx context.Context, instanceID string) error {
+	var prebuilds workspacev1.PrebuildList
+	err := t.Client.List(ctx, &prebuilds, client.InNamespace(t.Namespace), client.MatchingLabels{instanceLabel: instanceID})
+	if err != nil {
+		return fmt.Errorf("cannot list prebuilds: %w", err)
+	}
+	for _, pb := range prebuilds.Items {
+		err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			err := t.Client.Get(ctx, types.NamespacedName{Namespace: pb.Namespace, Name: pb.Name}, &pb)
+			if err != nil {
+				return err
+			}
+			if pb.Status.InstanceID != instanceID || pb.Status.Phase.Final() {
+				return nil
+			}
+
+			finished := metav1.NewTime(t.now())
+			pb.Status.Phase = workspacev1.PrebuildPhaseAborted
+			pb.Status.Error = "prebuild workspace is gone before it finished"
+			pb.Status.Finished = &finished
+			return t.Client.Status().Update(ctx, &pb)
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("cannot abort prebuild %s: %w", pb.Name, err)
+		}
+	}
+
+	return t.collect(ctx)
+}
+
+// Get returns the prebuild of a commit of a project
+func (t *Tracker) Get(ctx context.Context, projectID, commit string) (Prebuild, bool, error) {
+	var pb workspacev1.Prebuild
+	err := t.Client.Get(ctx, types.NamespacedName{Namespace: t.Namespace, Name: prebuildName(projectID, commit)}, &pb)
+	if apierrors.IsNotFound(err) {
+		return Prebuild{}, false, nil
+	}
+	if err != nil {
+		return Prebuild{}, false, fmt.Errorf("cannot get prebuild: %w", err)
+	}
+	if pb.Spec.ProjectID != projectID || pb.Spec.Commit != commit || t.expired(&pb) {
+		return Prebuild{}, false, nil
+	}
+	return fromResource(&pb), true, nil
+}
+
+// List returns the prebuilds of a project, most recent first
+func (t *Tracker) List(ctx context.Context, projectID string) ([]Prebuild, error) {
+	var prebuilds workspacev1.PrebuildList
+	err := t.Client.List(ctx, &prebuilds, client.InNamespace(t.Namespace), client.MatchingLabels{wsk8s.ProjectLabel: projectID})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list prebuilds: %w", err)
+	}
+
+	var res []Prebuild
+	for i := range prebuilds.Items {
+		pb := &prebuilds.Items[i]
+		if pb.Spec.ProjectID != projectID || t.expired(pb) {
+			continue
+		}
+		res = append(res, fromResource(pb))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].Created.Equal(res[j].Created) {
+			return res[i].Created.After(res[j].Created)
+		}
+		return res[i].Commit < res[j].Commit
+	})
+	return res, nil
+}
+
+func (t *Tracker) expired(pb *workspacev1.Prebuild) bool {
+	return pb.Status.Phase.Final() && pb.Status.Finished != nil && t.Retention > 0 && t.now().Sub(pb.Status.Finished.Time) > t.Retention
+}
+
+// collect removes expired prebuilds
+func (t *Tracker) collect(ctx context.Context) error {
+	var prebuilds workspacev1.PrebuildList
+	err := t.Client.List(ctx, &prebuilds, client.InNamespace(t.Namespace))
+	if err != nil {
+		return fmt.Errorf("cannot list prebuilds: %w", err)
+	}
+	for i := range prebuilds.Items {
+		pb := &prebuilds.Items[i]
+		if !t.expired(pb) {
+			continue
+		}
+		err = t.Client.Delete(ctx, pb)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("cannot delete expired prebuild %s: %w", pb.Name, err)
+		}
+	}
+	return nil
+}
+
+// prebuildName returns the name of the Prebuild resource of a commit of a project
+func prebuildName(projectID, commit string) string {
+	return strings.ToLower(fmt.Sprintf("%s-%s", projectID, commit))
+}
+
+func fromResource(pb *workspacev1.Prebuild) Prebuild {
+	res := Prebuild{
+		ProjectID:  pb.Spec.ProjectID,
+		Branch:     pb.Status.Branch,
+		Commit:     pb.Spec.Commit,
+		Phase:      pb.Status.Phase,
+		InstanceID: pb.Status.InstanceID,
+		Attempts:   len(pb.Status.Instances),
+		Error:      pb.Status.Error,
+		Created:    pb.Status.Created.Time,
+	}
+	if pb.Status.Finished != nil {
+		res.Finished = pb.Status.Finished.Time
+	}
+	return res
+}
+
+func containsString(s []string, e string) bool {
+	for _, v := range s {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
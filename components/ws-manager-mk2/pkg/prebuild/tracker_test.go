@@ -0,0 +1,154 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package prebuild
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+func TestTracker(t *testing.T) {
+	t0 := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	type step struct {
+		At      time.Duration
+		Observe *Attempt
+		Gone    string
+		// Restart replaces the tracker by a new one, as if ws-manager restarted
+		Restart bool
+	}
+	tests := []struct {
+		Name        string
+		Steps       []step
+		Expectation []Prebuild
+	}{
+		{
+			Name: "single attempt",
+			Steps: []step{
+				{Observe: &Attempt{InstanceID: "a", ProjectID: "p", Branch: "main", Commit: "1", Phase: workspacev1.PrebuildPhaseQueued, Created: t0}},
+				{At: time.Minute, Observe: &Attempt{InstanceID: "a", ProjectID: "p", Branch: "main", Commit: "1", Phase: workspacev1.PrebuildPhaseRunning, Created: t0}},
+				{At: 2 * time.Minute, Observe: &Attempt{InstanceID: "a", ProjectID: "p", Branch: "main", Commit: "1", Phase: workspacev1.PrebuildPhaseAvailable, Created: t0}},
+				{At: 3 * time.Minute, Observe: &Attempt{InstanceID: "a", ProjectID: "p", Branch: "main", Commit: "1", Phase: workspacev1.PrebuildPhaseAvailable, Created: t0}},
+			},
+			Expectation: []Prebuild{
+				{ProjectID: "p", Branch: "main", Commit: "1", Phase: workspacev1.PrebuildPhaseAvailable, InstanceID: "a", Attempts: 1, Created: t0, Finished: t0.Add(2 * time.Minute)},
+			},
+		},
+		{
+			Name: "retry",
+			Steps: []step{
+				{Observe: &Attempt{InstanceID: "a", ProjectID: "p", Commit: "1", Phase: workspacev1.PrebuildPhaseFailed, Error: "task failed", Created: t0}},
+				{At: time.Minute, Observe: &Attempt{InstanceID: "b", ProjectID: "p", Commit: "1", Phase: workspacev1.PrebuildPhaseRunning, Created: t0.Add(time.Minute)}},
+				// late update of the first attempt must not override the retry
+				{At: 2 * time.Minute, Observe: &Attempt{InstanceID: "a", ProjectID: "p", Commit: "1", Phase: workspacev1.PrebuildPhaseFailed, Error: "task failed", Created: t0}},
+			},
+			Expectation: []Prebuild{
+				{ProjectID: "p", Commit: "1", Phase: workspacev1.PrebuildPhaseRunning, InstanceID: "b", Attempts: 2, Created: t0},
+			},
+		},
+		{
+			Name: "retry after restart",
+			Steps: []step{
+				{Observe: &Attempt{InstanceID: "a", ProjectID: "p", Commit: "1", Phase: workspacev1.PrebuildPhaseFailed, Error: "task failed", Created: t0}},
+				{At: time.Minute, Restart: true},
+				{At: time.Minute, Observe: &Attempt{InstanceID: "b", ProjectID: "p", Commit: "1", Phase: workspacev1.PrebuildPhaseRunning, Created: t0.Add(time.Minute)}},
+			},
+			Expectation: []Prebuild{
+				{ProjectID: "p", Commit: "1", Phase: workspacev1.PrebuildPhaseRunning, InstanceID: "b", Attempts: 2, Created: t0},
+			},
+		},
+		{
+			Name: "gone before finished",
+			Steps: []step{
+				{Observe: &Attempt{InstanceID: "a", ProjectID: "p", Commit: "1", Phase: workspacev1.PrebuildPhaseRunning, Created: t0}},
+				{At: time.Minute, Gone: "a"},
+			},
+			Expectation: []Prebuild{
+				{ProjectID: "p", Commit: "1", Phase: workspacev1.PrebuildPhaseAborted, InstanceID: "a", Attempts: 1, Error: "prebuild workspace is gone before it finished", Created: t0, Finished: t0.Add(time.Minute)},
+			},
+		},
+		{
+			Name: "expired",
+			Steps: []step{
+				{Observe: &Attempt{InstanceID: "a", ProjectID: "p", Commit: "1", Phase: workspacev1.PrebuildPhaseAvailable, Created: t0}},
+				{Observe: &Attempt{InstanceID: "b", ProjectID: "p", Commit: "2", Phase: workspacev1.PrebuildPhaseRunning, Created: t0.Add(time.Minute)}},
+				{At: 2 * time.Hour, Gone: "a"},
+			},
+			Expectation: []Prebuild{
+				{ProjectID: "p", Commit: "2", Phase: workspacev1.PrebuildPhaseRunning, InstanceID: "b", Attempts: 1, Created: t0.Add(time.Minute)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				now time.Time
+				k8s = newFakeClient(t)
+			)
+			newTracker := func() *Tracker {
+				tracker := NewTracker(k8s, "default", time.Hour)
+				tracker.now = func() time.Time { return now }
+				return tracker
+			}
+			tracker := newTracker()
+
+			ctx := context.Background()
+			for _, s := range test.Steps {
+				now = t0.Add(s.At)
+				if s.Restart {
+					tracker = newTracker()
+				}
+				if s.Observe != nil {
+					if err := tracker.Observe(ctx, *s.Observe); err != nil {
+						t.Fatalf("cannot observe attempt: %v", err)
+					}
+				}
+				if s.Gone != "" {
+					if err := tracker.Gone(ctx, s.Gone); err != nil {
+						t.Fatalf("cannot record gone attempt: %v", err)
+					}
+				}
+			}
+
+			act, err := tracker.List(ctx, "p")
+			if err != nil {
+				t.Fatalf("cannot list prebuilds: %v", err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected prebuilds (-want +got):\n%s", diff)
+			}
+			for _, pb := range test.Expectation {
+				act, ok, err := tracker.Get(ctx, pb.ProjectID, pb.Commit)
+				if err != nil {
+					t.Fatalf("cannot get prebuild: %v", err)
+				}
+				if !ok {
+					t.Errorf("prebuild of commit %s not found", pb.Commit)
+					continue
+				}
+				if diff := cmp.Diff(pb, act); diff != "" {
+					t.Errorf("unexpected prebuild (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func newFakeClient(t *testing.T) client.Client {
+	scheme := runtime.NewScheme()
+	if err := workspacev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).Build()
+}
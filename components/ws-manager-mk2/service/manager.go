@@ -206,6 +206,12 @@ func (wsm *WorkspaceManagerServer) StartWorkspace(ctx context.Context, req *wsma
 		}
 	}
 
+	if workspaceType == workspacev1.WorkspaceTypePrebuild && annotations[wsk8s.PrebuildProjectAnnotation] != "" &&
+		wsm.Config.Prebuilds != nil && wsm.Config.Prebuilds.MaxConcurrentPerProject > 0 {
+		// The prebuild controller admits the workspace once its project may run another prebuild.
+		annotations[wsk8s.PrebuildQueuedAnnotation] = util.BooleanTrueString
+	}
+
 	envSecretName := fmt.Sprintf("%s-%s", req.Id, "env")
 	userEnvVars, envData := extractWorkspaceUserEnv(envSecretName, req.Spec.Envvars, req.Spec.SysEnvvars)
 	sysEnvVars := extractWorkspaceSysEnv(req.Spec.SysEnvvars)
@@ -234,6 +240,8 @@ func (wsm *WorkspaceManagerServer) StartWorkspace(ctx context.Context, req *wsma
 			Ownership: workspacev1.Ownership{
 				Owner:       req.Metadata.Owner,
 				WorkspaceID: req.Metadata.MetaId,
+				Team:        req.Metadata.GetTeam(),
+				Project:     req.Metadata.GetProject(),
 			},
 			Type:  workspaceType,
 			Class: classID,
@@ -0,0 +1,153 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package service
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/gitpod-io/gitpod/ws-manager-mk2/pkg/prebuild"
+	wsmanapi "github.com/gitpod-io/gitpod/ws-manager/api"
+	workspacev1 "github.com/gitpod-io/gitpod/ws-manager/api/crd/v1"
+)
+
+const (
+	defaultPrebuildsPageSize = 25
+	maxPrebuildsPageSize     = 100
+)
+
+// PrebuildCanceler stops all prebuild workspaces of a commit of a project
+type PrebuildCanceler interface {
+	Cancel(ctx context.Context, namespace, projectID, commit string) (bool, error)
+}
+
+// PrebuildService exposes the prebuilds the prebuild controller tracks. It does not check whether callers may access
+// a project, hence it must only be served to other components of Gitpod.
+type PrebuildService struct {
+	Namespace string
+	Tracker   *prebuild.Tracker
+	Canceler  PrebuildCanceler
+
+	wsmanapi.UnimplementedPrebuildSchedulerServer
+}
+
+var _ wsmanapi.PrebuildSchedulerServer = (*PrebuildService)(nil)
+
+func (s *PrebuildService) GetPrebuild(ctx context.Context, req *wsmanapi.GetPrebuildRequest) (*wsmanapi.GetPrebuildResponse, error) {
+	if req.ProjectId == "" || req.Commit == "" {
+		return nil, status.Error(codes.InvalidArgument, "project_id and commit are required")
+	}
+
+	pb, ok, err := s.Tracker.Get(ctx, req.ProjectId, req.Commit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get prebuild: %v", err)
+	}
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no prebuild of commit %s of project %s", req.Commit, req.ProjectId)
+	}
+	return &wsmanapi.GetPrebuildResponse{Prebuild: prebuildToAPI(pb)}, nil
+}
+
+func (s *PrebuildService) ListPrebuilds(ctx context.Context, req *wsmanapi.ListPrebuildsRequest) (*wsmanapi.ListPrebuildsResponse, error) {
+	if req.ProjectId == "" {
+		return nil, status.Error(codes.InvalidArgument, "project_id is required")
+	}
+
+	all, err := s.Tracker.List(ctx, req.ProjectId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list prebuilds: %v", err)
+	}
+
+	var prebuilds []*wsmanapi.Prebuild
+	for _, pb := range all {
+		if req.Branch != "" && pb.Branch != req.Branch {
+			continue
+		}
+		res := prebuildToAPI(pb)
+		if req.Phase != wsmanapi.PrebuildPhase_PREBUILD_PHASE_UNSPECIFIED && res.Phase != req.Phase {
+			continue
+		}
+		prebuilds = append(prebuilds, res)
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPrebuildsPageSize
+	}
+	if pageSize > maxPrebuildsPageSize {
+		pageSize = maxPrebuildsPageSize
+	}
+	page := int(req.Page)
+	if page <= 0 {
+		page = 1
+	}
+	start := (page - 1) * pageSize
+	end := start + pageSize
+	if start > len(prebuilds) {
+		start = len(prebuilds)
+	}
+	if end > len(prebuilds) {
+		end = len(prebuilds)
+	}
+
+	return &wsmanapi.ListPrebuildsResponse{
+		Prebuilds:    prebuilds[start:end],
+		TotalResults: int32(len(prebuilds)),
+	}, nil
+}
+
+func (s *PrebuildService) CancelPrebuild(ctx context.Context, req *wsmanapi.CancelPrebuildRequest) (*wsmanapi.CancelPrebuildResponse, error) {
+	if req.ProjectId == "" || req.Commit == "" {
+		return nil, status.Error(codes.InvalidArgument, "project_id and commit are required")
+	}
+
+	found, err := s.Canceler.Cancel(ctx, s.Namespace, req.ProjectId, req.Commit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot cancel prebuild: %v", err)
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "no prebuild workspace of commit %s of project %s", req.Commit, req.ProjectId)
+	}
+	return &wsmanapi.CancelPrebuildResponse{}, nil
+}
+
+func prebuildToAPI(pb prebuild.Prebuild) *wsmanapi.Prebuild {
+	res := &wsmanapi.Prebuild{
+		ProjectId:  pb.ProjectID,
+		Branch:     pb.Branch,
+		Commit:     pb.Commit,
+		Phase:      prebuildPhaseToAPI(pb.Phase),
+		InstanceId: pb.InstanceID,
+		Attempts:   int32(pb.Attempts),
+		Error:      pb.Error,
+		CreatedAt:  timestamppb.New(pb.Created),
+	}
+	if !pb.Finished.IsZero() {
+		res.FinishedAt = timestamppb.New(pb.Finished)
+	}
+	return res
+}
+
+func prebuildPhaseToAPI(p workspacev1.PrebuildPhase) wsmanapi.PrebuildPhase {
+	switch p {
+	case workspacev1.PrebuildPhaseQueued:
+		return wsmanapi.PrebuildPhase_PREBUILD_PHASE_QUEUED
+	case workspacev1.PrebuildPhaseRunning:
+		return wsmanapi.PrebuildPhase_PREBUILD_PHASE_RUNNING
+	case workspacev1.PrebuildPhaseAvailable:
+		return wsmanapi.PrebuildPhase_PREBUILD_PHASE_AVAILABLE
+	case workspacev1.PrebuildPhaseFailed:
+		return wsmanapi.PrebuildPhase_PREBUILD_PHASE_FAILED
+	case workspacev1.PrebuildPhaseAborted:
+		return wsmanapi.PrebuildPhase_PREBUILD_PHASE_ABORTED
+	case workspacev1.PrebuildPhaseTimeout:
+		return wsmanapi.PrebuildPhase_PREBUILD_PHASE_TIMEOUT
+	default:
+		return wsmanapi.PrebuildPhase_PREBUILD_PHASE_UNSPECIFIED
+	}
+}
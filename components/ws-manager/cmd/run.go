@@ -14,6 +14,7 @@ import (
 
 	"github.com/bombsimon/logrusr/v2"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/redis/go-redis/v9"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -137,7 +138,12 @@ var runCmd = &cobra.Command{
 		if len(cfg.RPCServer.RateLimits) > 0 {
 			log.WithField("ratelimits", cfg.RPCServer.RateLimits).Info("imposing rate limits on the gRPC interface")
 		}
-		ratelimits := common_grpc.NewRatelimitingInterceptor(cfg.RPCServer.RateLimits)
+		var ratelimitOpts []common_grpc.RatelimitingOption
+		if addr := cfg.RPCServer.RateLimitStore.RedisAddr; addr != "" {
+			log.WithField("addr", addr).Info("sharing gRPC rate limits through Redis")
+			ratelimitOpts = append(ratelimitOpts, common_grpc.WithRatelimitStore(common_grpc.NewRedisRatelimitStore(redis.NewClient(&redis.Options{Addr: addr}))))
+		}
+		ratelimits := common_grpc.NewRatelimitingInterceptor(cfg.RPCServer.RateLimits, ratelimitOpts...)
 		metrics.Registry.MustRegister(ratelimits)
 
 		grpcMetrics := grpc_prometheus.NewServerMetrics()
@@ -147,7 +153,7 @@ var runCmd = &cobra.Command{
 		metrics.Registry.MustRegister(grpcMetrics)
 
 		grpcOpts := common_grpc.ServerOptionsWithInterceptors(
-			[]grpc.StreamServerInterceptor{grpcMetrics.StreamServerInterceptor()},
+			[]grpc.StreamServerInterceptor{grpcMetrics.StreamServerInterceptor(), ratelimits.StreamInterceptor()},
 			[]grpc.UnaryServerInterceptor{grpcMetrics.UnaryServerInterceptor(), ratelimits.UnaryInterceptor()},
 		)
 		if cfg.RPCServer.TLS.CA != "" && cfg.RPCServer.TLS.Certificate != "" && cfg.RPCServer.TLS.PrivateKey != "" {
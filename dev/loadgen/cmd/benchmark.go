@@ -54,10 +54,25 @@ var benchmarkCommand = &cobra.Command{
 			log.WithError(err).WithField("fn", fn).Fatal("cannot unmarshal scenario file")
 		}
 
-		var load loadgen.LoadGenerator
-		load = loadgen.NewFixedLoadGenerator(800*time.Millisecond, 300*time.Millisecond)
+		load, err := scenario.Load.Generator(800*time.Millisecond, 300*time.Millisecond)
+		if err != nil {
+			log.WithError(err).WithField("fn", fn).Fatal("invalid load profile")
+		}
 		load = loadgen.NewWorkspaceCountLimitingGenerator(load, scenario.Workspaces)
 
+		var workload loadgen.WorkloadRunner
+		if scenario.Workload != nil {
+			workload, err = loadgen.NewSupervisorWorkloadRunner(scenario.Workload)
+			if err != nil {
+				log.WithError(err).WithField("fn", fn).Fatal("invalid workload")
+			}
+		}
+
+		worker := scenario.Workers
+		if worker <= 0 {
+			worker = 5
+		}
+
 		template := &api.StartWorkspaceRequest{
 			Id: "will-be-overriden",
 			Metadata: &api.WorkspaceMetadata{
@@ -141,7 +156,8 @@ var benchmarkCommand = &cobra.Command{
 					Auth:  scenario.RepositoryAuth,
 				},
 			},
-			Worker: 5,
+			Worker:   worker,
+			Workload: workload,
 			Observer: []chan<- *loadgen.SessionEvent{
 				observer.NewLogObserver(true),
 				observer.NewProgressBarObserver(scenario.Workspaces),
@@ -214,6 +230,9 @@ type BenchmarkScenario struct {
 	FeatureFlags     []api.WorkspaceFeatureFlag `json:"featureFlags"`
 	RepositoryAuth   *loadgen.RepositoryAuth    `json:"repoAuth,omitempty"`
 	WorkspaceTimeout string                     `json:"workspaceTimeout,omitempty"`
+	Load             loadgen.LoadProfile        `json:"load"`
+	Workers          int                        `json:"workers,omitempty"`
+	Workload         *loadgen.Workload          `json:"workload,omitempty"`
 }
 
 func handleWorkspaceDeletion(timeout string, resultsDir string, executor loadgen.Executor, canceled bool) error {
@@ -0,0 +1,88 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/gitpod-io/gitpod/loadgen/pkg/observer"
+	"github.com/gitpod-io/gitpod/loadgen/pkg/report"
+)
+
+var compareOpts struct {
+	StartupRegression   float64
+	FailureRateIncrease float64
+	JSON                bool
+}
+
+// compareCommand represents the compare command
+var compareCommand = &cobra.Command{
+	Use:   "compare <baseline> <candidate>",
+	Short: "compares startup times and failure rates of two benchmark results",
+	Long: `Compares startup time percentiles and failure rates of two benchmark results.
+Both arguments are either a results directory of the benchmark command, or the stats.json file in it.
+Exits with status 1 if the candidate regressed beyond the thresholds.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		baseline, err := loadStats(args[0])
+		if err != nil {
+			log.WithError(err).Fatal("cannot load baseline")
+		}
+		candidate, err := loadStats(args[1])
+		if err != nil {
+			log.WithError(err).Fatal("cannot load candidate")
+		}
+
+		comparison := report.Compare(report.Summarize(baseline), report.Summarize(candidate), report.Thresholds{
+			StartupRegression:   compareOpts.StartupRegression,
+			FailureRateIncrease: compareOpts.FailureRateIncrease,
+		})
+
+		if compareOpts.JSON {
+			fc, err := json.MarshalIndent(comparison, "", "  ")
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println(string(fc))
+		} else if err := comparison.Write(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+
+		if len(comparison.Regressions) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compareCommand)
+
+	compareCommand.Flags().Float64Var(&compareOpts.StartupRegression, "max-startup-regression", 0.1, "relative increase of the p50, p95 or p99 startup time which counts as regression")
+	compareCommand.Flags().Float64Var(&compareOpts.FailureRateIncrease, "max-failure-rate-increase", 0.02, "absolute increase of the failure rate which counts as regression")
+	compareCommand.Flags().BoolVar(&compareOpts.JSON, "json", false, "print the comparison as JSON")
+}
+
+func loadStats(fn string) (*observer.Stats, error) {
+	if fi, err := os.Stat(fn); err == nil && fi.IsDir() {
+		fn = filepath.Join(fn, "stats.json")
+	}
+
+	fc, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	var stats observer.Stats
+	err = json.Unmarshal(fc, &stats)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal %s: %w", fn, err)
+	}
+	return &stats, nil
+}
@@ -46,6 +46,10 @@ type WorkspaceUpdate struct {
 
 	Phase  api.WorkspacePhase `json:"phase"`
 	Failed bool               `json:"failed"`
+
+	// URL and OwnerToken give access to the workspace once it is running
+	URL        string `json:"url,omitempty"`
+	OwnerToken string `json:"-"`
 }
 
 // NewFakeExecutor creates a new fake executor
@@ -191,6 +195,8 @@ func (w *WsmanExecutor) Observe() (<-chan WorkspaceUpdate, error) {
 					OwnerID:     status.Metadata.Owner,
 					Failed:      status.Conditions.Failed != "",
 					Phase:       status.Phase,
+					URL:         status.Spec.GetUrl(),
+					OwnerToken:  status.Auth.GetOwnerToken(),
 				}
 			}
 		}
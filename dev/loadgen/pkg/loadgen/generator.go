@@ -5,6 +5,7 @@
 package loadgen
 
 import (
+	"fmt"
 	"math/rand"
 	"net/url"
 	"path"
@@ -134,7 +135,10 @@ func (f *FixedLoadGenerator) Generate() <-chan struct{} {
 		defer close(res)
 
 		for {
-			delay := f.Delay + time.Duration(rand.Int63()%int64(f.Jitter))
+			delay := f.Delay
+			if f.Jitter > 0 {
+				delay += time.Duration(rand.Int63() % int64(f.Jitter))
+			}
 			select {
 			case <-time.After(delay):
 			case <-f.close:
@@ -157,6 +161,188 @@ func (f *FixedLoadGenerator) Close() error {
 	return nil
 }
 
+// openLoopBuffer is the number of workspace starts an open-loop generator queues up while
+// all load workers are busy
+const openLoopBuffer = 1000
+
+// openLoop produces workspace starts on a schedule which does not depend on how quickly
+// they are consumed. interval returns the time until the next start, given the time
+// since the generator started. If arrive is false, no workspace is started after the delay.
+func openLoop(stop <-chan struct{}, interval func(elapsed time.Duration) (delay time.Duration, arrive bool)) <-chan struct{} {
+	res := make(chan struct{}, openLoopBuffer)
+	go func() {
+		defer close(res)
+
+		t0 := time.Now()
+		for {
+			delay, arrive := interval(time.Since(t0))
+			select {
+			case <-time.After(delay):
+			case <-stop:
+				return
+			}
+			if !arrive {
+				continue
+			}
+
+			select {
+			case res <- struct{}{}:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return res
+}
+
+// NewPoissonLoadGenerator produces a new load generator
+func NewPoissonLoadGenerator(rate float64) *PoissonLoadGenerator {
+	return &PoissonLoadGenerator{
+		Rate:  rate,
+		close: make(chan struct{}),
+	}
+}
+
+// PoissonLoadGenerator starts workspaces like independent users would, i.e. with
+// exponentially distributed delays between them
+type PoissonLoadGenerator struct {
+	// Rate is the average number of workspaces started per second
+	Rate float64
+
+	close chan struct{}
+}
+
+// Generate starts a new load generator.
+func (p *PoissonLoadGenerator) Generate() <-chan struct{} {
+	return openLoop(p.close, func(time.Duration) (time.Duration, bool) {
+		return exponentialDelay(p.Rate), true
+	})
+}
+
+// Close stops all generators
+func (p *PoissonLoadGenerator) Close() error {
+	close(p.close)
+	return nil
+}
+
+// rampIdleDelay is how long the ramp generator waits before it checks the rate again while it is zero
+const rampIdleDelay = 100 * time.Millisecond
+
+// NewRampLoadGenerator produces a new load generator
+func NewRampLoadGenerator(from, to float64, duration time.Duration) *RampLoadGenerator {
+	return &RampLoadGenerator{
+		From:     from,
+		To:       to,
+		Duration: duration,
+		close:    make(chan struct{}),
+	}
+}
+
+// RampLoadGenerator starts workspaces at a rate which changes linearly from From to To
+// over Duration, and stays at To afterwards. Like with the PoissonLoadGenerator the
+// delays between workspace starts are exponentially distributed.
+type RampLoadGenerator struct {
+	From, To float64
+	Duration time.Duration
+
+	close chan struct{}
+}
+
+// Generate starts a new load generator.
+func (r *RampLoadGenerator) Generate() <-chan struct{} {
+	return openLoop(r.close, func(elapsed time.Duration) (time.Duration, bool) {
+		rate := r.Rate(elapsed)
+		if rate <= 0 {
+			return rampIdleDelay, false
+		}
+		return exponentialDelay(rate), true
+	})
+}
+
+// Rate returns the number of workspaces started per second after elapsed time
+func (r *RampLoadGenerator) Rate(elapsed time.Duration) float64 {
+	if elapsed >= r.Duration {
+		return r.To
+	}
+	return r.From + (r.To-r.From)*float64(elapsed)/float64(r.Duration)
+}
+
+// Close stops all generators
+func (r *RampLoadGenerator) Close() error {
+	close(r.close)
+	return nil
+}
+
+func exponentialDelay(rate float64) time.Duration {
+	return time.Duration(rand.ExpFloat64() / rate * float64(time.Second))
+}
+
+// LoadProfile configures when workspaces are started
+type LoadProfile struct {
+	// Kind is one of fixed, poisson or ramp. Defaults to fixed.
+	Kind string `json:"kind"`
+	// Delay and Jitter configure the delay between workspace starts of the fixed profile
+	Delay  string `json:"delay,omitempty"`
+	Jitter string `json:"jitter,omitempty"`
+	// Rate is the average number of workspaces started per second of the poisson profile,
+	// and the rate the ramp profile ends up at
+	Rate float64 `json:"rate,omitempty"`
+	// InitialRate is the rate the ramp profile starts with
+	InitialRate float64 `json:"initialRate,omitempty"`
+	// RampUp is how long the ramp profile takes to get from its initial rate to its rate
+	RampUp string `json:"rampUp,omitempty"`
+}
+
+const (
+	LoadProfileFixed   = "fixed"
+	LoadProfilePoisson = "poisson"
+	LoadProfileRamp    = "ramp"
+)
+
+// Generator produces the load generator of this profile. The delay and jitter
+// are used by the fixed profile unless the profile configures them.
+func (p LoadProfile) Generator(delay, jitter time.Duration) (LoadGenerator, error) {
+	parse := func(name, value string, def time.Duration) (time.Duration, error) {
+		if value == "" {
+			return def, nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s of %s load profile: %w", name, p.Kind, err)
+		}
+		return d, nil
+	}
+
+	switch p.Kind {
+	case "", LoadProfileFixed:
+		delay, err := parse("delay", p.Delay, delay)
+		if err != nil {
+			return nil, err
+		}
+		jitter, err := parse("jitter", p.Jitter, jitter)
+		if err != nil {
+			return nil, err
+		}
+		return NewFixedLoadGenerator(delay, jitter), nil
+	case LoadProfilePoisson:
+		if p.Rate <= 0 {
+			return nil, fmt.Errorf("poisson load profile needs a positive rate")
+		}
+		return NewPoissonLoadGenerator(p.Rate), nil
+	case LoadProfileRamp:
+		if p.Rate <= 0 || p.InitialRate < 0 {
+			return nil, fmt.Errorf("ramp load profile needs a positive rate and an initial rate of at least zero")
+		}
+		rampUp, err := parse("rampUp", p.RampUp, 0)
+		if err != nil {
+			return nil, err
+		}
+		return NewRampLoadGenerator(p.InitialRate, p.Rate, rampUp), nil
+	default:
+		return nil, fmt.Errorf("unknown load profile %s", p.Kind)
+	}
+}
+
 type WorkspaceCfg struct {
 	CloneURL       string                     `json:"cloneURL"`
 	WorkspaceImage string                     `json:"workspaceImage"`
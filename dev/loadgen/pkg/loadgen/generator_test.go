@@ -0,0 +1,133 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package loadgen
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRampLoadGeneratorRate(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Generator   *RampLoadGenerator
+		Elapsed     time.Duration
+		Expectation float64
+	}{
+		{Name: "start", Generator: &RampLoadGenerator{From: 1, To: 5, Duration: 10 * time.Second}, Elapsed: 0, Expectation: 1},
+		{Name: "half way", Generator: &RampLoadGenerator{From: 1, To: 5, Duration: 10 * time.Second}, Elapsed: 5 * time.Second, Expectation: 3},
+		{Name: "end", Generator: &RampLoadGenerator{From: 1, To: 5, Duration: 10 * time.Second}, Elapsed: 10 * time.Second, Expectation: 5},
+		{Name: "after end", Generator: &RampLoadGenerator{From: 1, To: 5, Duration: 10 * time.Second}, Elapsed: time.Hour, Expectation: 5},
+		{Name: "ramp down", Generator: &RampLoadGenerator{From: 4, To: 0, Duration: 4 * time.Second}, Elapsed: time.Second, Expectation: 3},
+		{Name: "from zero", Generator: &RampLoadGenerator{From: 0, To: 2, Duration: 4 * time.Second}, Elapsed: time.Second, Expectation: 0.5},
+		{Name: "no ramp up", Generator: &RampLoadGenerator{From: 1, To: 5}, Elapsed: 0, Expectation: 5},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := test.Generator.Rate(test.Elapsed)
+			if act != test.Expectation {
+				t.Errorf("unexpected rate: expected %v, got %v", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestLoadProfileGenerator(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Profile     LoadProfile
+		Expectation LoadGenerator
+		Error       bool
+	}{
+		{
+			Name:        "defaults to fixed with the given delay and jitter",
+			Profile:     LoadProfile{},
+			Expectation: &FixedLoadGenerator{Delay: time.Second, Jitter: 100 * time.Millisecond},
+		},
+		{
+			Name:        "fixed with own delay and jitter",
+			Profile:     LoadProfile{Kind: LoadProfileFixed, Delay: "5s", Jitter: "1s"},
+			Expectation: &FixedLoadGenerator{Delay: 5 * time.Second, Jitter: time.Second},
+		},
+		{
+			Name:    "fixed with invalid delay",
+			Profile: LoadProfile{Kind: LoadProfileFixed, Delay: "5"},
+			Error:   true,
+		},
+		{
+			Name:    "fixed with invalid jitter",
+			Profile: LoadProfile{Kind: LoadProfileFixed, Jitter: "soon"},
+			Error:   true,
+		},
+		{
+			Name:        "poisson",
+			Profile:     LoadProfile{Kind: LoadProfilePoisson, Rate: 0.5},
+			Expectation: &PoissonLoadGenerator{Rate: 0.5},
+		},
+		{
+			Name:    "poisson without rate",
+			Profile: LoadProfile{Kind: LoadProfilePoisson},
+			Error:   true,
+		},
+		{
+			Name:        "ramp",
+			Profile:     LoadProfile{Kind: LoadProfileRamp, InitialRate: 0.1, Rate: 2, RampUp: "10m"},
+			Expectation: &RampLoadGenerator{From: 0.1, To: 2, Duration: 10 * time.Minute},
+		},
+		{
+			Name:        "ramp without ramp up",
+			Profile:     LoadProfile{Kind: LoadProfileRamp, Rate: 2},
+			Expectation: &RampLoadGenerator{To: 2},
+		},
+		{
+			Name:    "ramp with negative initial rate",
+			Profile: LoadProfile{Kind: LoadProfileRamp, InitialRate: -1, Rate: 2},
+			Error:   true,
+		},
+		{
+			Name:    "ramp without rate",
+			Profile: LoadProfile{Kind: LoadProfileRamp, InitialRate: 1},
+			Error:   true,
+		},
+		{
+			Name:    "ramp with invalid ramp up",
+			Profile: LoadProfile{Kind: LoadProfileRamp, Rate: 2, RampUp: "10"},
+			Error:   true,
+		},
+		{
+			Name:    "unknown kind",
+			Profile: LoadProfile{Kind: "burst"},
+			Error:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := test.Profile.Generator(time.Second, 100*time.Millisecond)
+			if test.Error {
+				if err == nil {
+					t.Fatalf("expected an error, got generator %+v", act)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// the generators' close channels are irrelevant to the comparison
+			switch g := act.(type) {
+			case *FixedLoadGenerator:
+				g.close = nil
+			case *PoissonLoadGenerator:
+				g.close = nil
+			case *RampLoadGenerator:
+				g.close = nil
+			}
+			if !reflect.DeepEqual(act, test.Expectation) {
+				t.Errorf("unexpected generator: expected %+v, got %+v", test.Expectation, act)
+			}
+		})
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/gitpod-io/gitpod/ws-manager/api"
 )
 
 // Session is a load testing session
@@ -21,8 +23,15 @@ type Session struct {
 	Observer     []chan<- *SessionEvent
 	PostLoadWait func()
 	Termination  func(executor Executor) error
+	// Workload runs in every workspace once it is running, nil if workspaces should idle
+	Workload WorkloadRunner
 
 	Worker int
+
+	workloadMu      sync.Mutex
+	workloadWG      sync.WaitGroup
+	workloadStarted map[string]struct{}
+	workloadStopped bool
 }
 
 // SessionEvent provides updates whenever something happens in this session
@@ -32,6 +41,7 @@ type SessionEvent struct {
 	Error           error
 	WorkspaceStart  *SessionEventWorkspaceStart
 	WorkspaceUpdate *SessionEventWorkspaceUpdate
+	Workload        *SessionEventWorkload
 }
 
 // SessionEventKind describes the type of session event
@@ -46,8 +56,10 @@ const (
 	SessionWorkspaceStart
 	// SessionWorkspaceUpdate indicates a workspace update. Expect the WorkspaceUpdate field to be non-nil
 	SessionWorkspaceUpdate
-	// SessionDone indicates the session is done. Expect no more updates.
+	// SessionDone indicates the session is done. Expect no more updates, except for SessionWorkload.
 	SessionDone
+	// SessionWorkload indicates the workload was started in a workspace. Expect the Workload field to be non-nil
+	SessionWorkload
 )
 
 // SessionEventWorkspaceStart describes a workspace start event
@@ -63,6 +75,13 @@ type SessionEventWorkspaceUpdate struct {
 	Update WorkspaceUpdate
 }
 
+// SessionEventWorkload describes the start of a workload in a workspace
+type SessionEventWorkload struct {
+	Time       time.Time
+	InstanceID string
+	Error      error
+}
+
 // Run starts the load testing
 func (s *Session) Run(ctx context.Context) error {
 	load := s.Load.Generate()
@@ -83,6 +102,9 @@ func (s *Session) Run(ctx context.Context) error {
 
 		<-start
 		for u := range obs {
+			if s.Workload != nil && u.Phase == api.WorkspacePhase_RUNNING {
+				s.startWorkload(ctx, u, updates)
+			}
 			updates <- &SessionEvent{
 				Kind: SessionWorkspaceUpdate,
 				WorkspaceUpdate: &SessionEventWorkspaceUpdate{
@@ -144,12 +166,52 @@ func (s *Session) Run(ctx context.Context) error {
 			return err
 		}
 	}
+
+	s.workloadMu.Lock()
+	s.workloadStopped = true
+	s.workloadMu.Unlock()
+	s.workloadWG.Wait()
 	close(updates)
 
 	infraWG.Wait()
 	return nil
 }
 
+// startWorkload starts the workload in a workspace unless it was started before
+func (s *Session) startWorkload(ctx context.Context, ws WorkspaceUpdate, updates chan<- *SessionEvent) {
+	s.workloadMu.Lock()
+	defer s.workloadMu.Unlock()
+
+	if s.workloadStopped {
+		return
+	}
+	if s.workloadStarted == nil {
+		s.workloadStarted = make(map[string]struct{})
+	}
+	if _, started := s.workloadStarted[ws.InstanceID]; started {
+		return
+	}
+	s.workloadStarted[ws.InstanceID] = struct{}{}
+
+	s.workloadWG.Add(1)
+	go func() {
+		defer s.workloadWG.Done()
+
+		err := s.Workload.RunWorkload(ctx, ws)
+		if err != nil {
+			log.WithError(err).WithField("instance", ws.InstanceID).Warn("cannot start workload")
+		}
+		updates <- &SessionEvent{
+			Kind: SessionWorkload,
+			Workload: &SessionEventWorkload{
+				Time:       time.Now(),
+				InstanceID: ws.InstanceID,
+				Error:      err,
+			},
+		}
+	}()
+}
+
 func (s *Session) distributeUpdates(wg *sync.WaitGroup, updates <-chan *SessionEvent) {
 	defer wg.Done()
 
@@ -0,0 +1,215 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package loadgen
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// WorkloadKind is the kind of activity a workload step simulates
+type WorkloadKind string
+
+const (
+	// WorkloadCPU keeps CPU cores busy
+	WorkloadCPU WorkloadKind = "cpu"
+	// WorkloadDisk writes a file to the workspace and reads it back
+	WorkloadDisk WorkloadKind = "disk"
+	// WorkloadGit runs common git commands in the repository of the workspace
+	WorkloadGit WorkloadKind = "git"
+	// WorkloadNetwork downloads a file
+	WorkloadNetwork WorkloadKind = "network"
+)
+
+// Workload is scripted activity which simulates a user working in a running workspace
+type Workload struct {
+	// Steps run one after another
+	Steps []WorkloadStep `json:"steps"`
+	// Timeout is how long loadgen tries to start the workload in a workspace. Defaults to 2m.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// WorkloadStep is a single activity of a workload
+type WorkloadStep struct {
+	Kind WorkloadKind `json:"kind"`
+	// Duration is how long a cpu step keeps the CPU busy in whole seconds, e.g. 60s
+	Duration string `json:"duration,omitempty"`
+	// Workers is the number of cores a cpu step keeps busy. Defaults to 1.
+	Workers int `json:"workers,omitempty"`
+	// SizeMiB is the size of the file a disk step writes and reads
+	SizeMiB int `json:"sizeMiB,omitempty"`
+	// URL is the file a network step downloads
+	URL string `json:"url,omitempty"`
+	// Repeat is how often a git or network step repeats its commands. Defaults to 1.
+	Repeat int `json:"repeat,omitempty"`
+}
+
+// workloadFile is the file disk steps write to
+const workloadFile = "/workspace/.loadgen-workload"
+
+// Script renders the workload as a single shell command line
+func (w *Workload) Script() (string, error) {
+	if len(w.Steps) == 0 {
+		return "", fmt.Errorf("workload has no steps")
+	}
+
+	steps := make([]string, 0, len(w.Steps))
+	for i, s := range w.Steps {
+		cmd, err := s.script()
+		if err != nil {
+			return "", fmt.Errorf("workload step %d: %w", i, err)
+		}
+		steps = append(steps, "("+cmd+")")
+	}
+	return strings.Join(steps, "; "), nil
+}
+
+func (s WorkloadStep) script() (string, error) {
+	repeat := s.Repeat
+	if repeat <= 0 {
+		repeat = 1
+	}
+
+	switch s.Kind {
+	case WorkloadCPU:
+		d, err := time.ParseDuration(s.Duration)
+		if err != nil {
+			return "", fmt.Errorf("invalid duration of cpu step: %w", err)
+		}
+		// timeout takes whole seconds here, and never stops the command if the duration is zero
+		if d < time.Second {
+			return "", fmt.Errorf("duration of cpu step must be at least 1s")
+		}
+		workers := s.Workers
+		if workers <= 0 {
+			workers = 1
+		}
+		return fmt.Sprintf("for i in $(seq %d); do timeout %d sha256sum /dev/zero & done; wait", workers, int(d.Seconds())), nil
+	case WorkloadDisk:
+		if s.SizeMiB <= 0 {
+			return "", fmt.Errorf("disk step needs a positive size")
+		}
+		return fmt.Sprintf("dd if=/dev/zero of=%[1]s bs=1M count=%[2]d conv=fsync status=none && cat %[1]s > /dev/null; rm -f %[1]s", workloadFile, s.SizeMiB), nil
+	case WorkloadGit:
+		return fmt.Sprintf(`cd "${GITPOD_REPO_ROOT:-/workspace}" && for i in $(seq %d); do git status > /dev/null; git log --oneline -n 1000 > /dev/null; git fetch --quiet; done`, repeat), nil
+	case WorkloadNetwork:
+		u, err := url.Parse(s.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return "", fmt.Errorf("network step needs an http or https URL")
+		}
+		return fmt.Sprintf("for i in $(seq %d); do curl -sSfLo /dev/null '%s'; done", repeat, strings.ReplaceAll(s.URL, "'", `'\''`)), nil
+	default:
+		return "", fmt.Errorf("unknown kind %s", s.Kind)
+	}
+}
+
+// WorkloadRunner starts a workload in a running workspace
+type WorkloadRunner interface {
+	RunWorkload(ctx context.Context, ws WorkspaceUpdate) error
+}
+
+// NewSupervisorWorkloadRunner produces a workload runner which starts the workload through the supervisor API
+func NewSupervisorWorkloadRunner(workload *Workload) (*SupervisorWorkloadRunner, error) {
+	script, err := workload.Script()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := 2 * time.Minute
+	if workload.Timeout != "" {
+		timeout, err = time.ParseDuration(workload.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workload timeout: %w", err)
+		}
+	}
+
+	return &SupervisorWorkloadRunner{
+		Script:  script,
+		Timeout: timeout,
+		Client:  &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// SupervisorWorkloadRunner writes the workload script into the first terminal of a workspace,
+// i.e. the terminal of its first task, using supervisor's REST API behind ws-proxy
+type SupervisorWorkloadRunner struct {
+	Script  string
+	Timeout time.Duration
+	Client  *http.Client
+}
+
+// RunWorkload starts the workload in the workspace. It does not wait for the workload to finish.
+func (r *SupervisorWorkloadRunner) RunWorkload(ctx context.Context, ws WorkspaceUpdate) error {
+	if ws.URL == "" || ws.OwnerToken == "" {
+		return fmt.Errorf("workspace %s has no URL or owner token", ws.InstanceID)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+
+	api := strings.TrimSuffix(ws.URL, "/") + "/_supervisor/v1"
+	for {
+		alias, err := r.firstTerminal(ctx, api, ws.OwnerToken)
+		if err == nil && alias != "" {
+			stdin := base64.URLEncoding.EncodeToString([]byte(r.Script + "\n"))
+			return r.call(ctx, http.MethodPost, api+"/terminal/write/"+url.PathEscape(alias)+"?stdin="+stdin, ws.OwnerToken, nil)
+		}
+		if err != nil {
+			log.WithError(err).WithField("instance", ws.InstanceID).Debug("cannot list terminals yet, retrying")
+		}
+
+		select {
+		case <-time.After(5 * time.Second):
+		case <-ctx.Done():
+			return fmt.Errorf("no terminal of workspace %s became available: %w", ws.InstanceID, ctx.Err())
+		}
+	}
+}
+
+func (r *SupervisorWorkloadRunner) firstTerminal(ctx context.Context, api, ownerToken string) (string, error) {
+	var resp struct {
+		Terminals []struct {
+			Alias string `json:"alias"`
+		} `json:"terminals"`
+	}
+	err := r.call(ctx, http.MethodGet, api+"/terminal/list", ownerToken, &resp)
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Terminals) == 0 {
+		return "", nil
+	}
+	return resp.Terminals[0].Alias, nil
+}
+
+func (r *SupervisorWorkloadRunner) call(ctx context.Context, method, endpoint, ownerToken string, resp interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-gitpod-owner-token", url.QueryEscape(ownerToken))
+
+	res, err := r.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: unexpected status %s", method, req.URL.Path, res.Status)
+	}
+	if resp == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(resp)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package loadgen
+
+import (
+	"testing"
+)
+
+func TestWorkloadStepScript(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Step        WorkloadStep
+		Expectation string
+		Error       bool
+	}{
+		{
+			Name:        "cpu",
+			Step:        WorkloadStep{Kind: WorkloadCPU, Duration: "1m", Workers: 2},
+			Expectation: "for i in $(seq 2); do timeout 60 sha256sum /dev/zero & done; wait",
+		},
+		{
+			Name:        "cpu with one second",
+			Step:        WorkloadStep{Kind: WorkloadCPU, Duration: "1s"},
+			Expectation: "for i in $(seq 1); do timeout 1 sha256sum /dev/zero & done; wait",
+		},
+		{
+			Name:  "cpu below one second",
+			Step:  WorkloadStep{Kind: WorkloadCPU, Duration: "500ms"},
+			Error: true,
+		},
+		{
+			Name:  "cpu with invalid duration",
+			Step:  WorkloadStep{Kind: WorkloadCPU, Duration: "60"},
+			Error: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := test.Step.script()
+			if test.Error {
+				if err == nil {
+					t.Fatalf("expected an error, got script %q", act)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if act != test.Expectation {
+				t.Errorf("unexpected script:\nexpected %q\ngot      %q", test.Expectation, act)
+			}
+		})
+	}
+}
@@ -31,6 +31,8 @@ func NewLogObserver(errorOnly bool) chan<- *loadgen.SessionEvent {
 				log.WithField("instanceID", up.InstanceID).WithField("failed", up.Failed).WithField("phase", up.Phase).Info("workspace update")
 			case loadgen.SessionDone:
 				log.Info("session done")
+			case loadgen.SessionWorkload:
+				log.WithField("instanceID", evt.Workload.InstanceID).WithError(evt.Workload.Error).Info("workload started")
 			}
 		}
 	}()
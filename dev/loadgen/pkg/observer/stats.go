@@ -25,7 +25,8 @@ type Stats struct {
 
 // StatsSample is a single workspace sample
 type StatsSample struct {
-	InstanceID     string
+	InstanceID string
+	// Start is when the start workspace call returned, Running when the workspace entered the running phase
 	Start, Running time.Time
 	Phase          api.WorkspacePhase
 	Failed         bool
@@ -33,6 +34,18 @@ type StatsSample struct {
 	CloneURL       string
 	WorkspaceImage string
 	WorkspaceName  string
+	// WorkloadStart is when the workload was started in the workspace, zero if it was not
+	WorkloadStart time.Time
+	WorkloadError string
+}
+
+// StartupTime is the time from the start workspace call until the workspace was running,
+// zero if it never was
+func (s StatsSample) StartupTime() time.Duration {
+	if s.Running.IsZero() {
+		return 0
+	}
+	return s.StartDuration + s.Running.Sub(s.Start)
 }
 
 // NewStatsObserver produces a stats collecting observer
@@ -81,7 +94,20 @@ func NewStatsObserver(cb func(*Stats)) chan<- *loadgen.SessionEvent {
 				}
 				ws.Phase = up.Phase
 				ws.Failed = up.Failed
-				ws.Running = evt.WorkspaceUpdate.Time
+				if up.Phase == api.WorkspacePhase_RUNNING && ws.Running.IsZero() {
+					ws.Running = evt.WorkspaceUpdate.Time
+				}
+				publishStats(status)
+			case loadgen.SessionWorkload:
+				ws, ok := status[evt.Workload.InstanceID]
+				if !ok {
+					continue
+				}
+				if evt.Workload.Error != nil {
+					ws.WorkloadError = evt.Workload.Error.Error()
+				} else {
+					ws.WorkloadStart = evt.Workload.Time
+				}
 				publishStats(status)
 			case loadgen.SessionDone:
 				publishStats(status)
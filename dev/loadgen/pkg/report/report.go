@@ -0,0 +1,170 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gitpod-io/gitpod/loadgen/pkg/observer"
+)
+
+// Summary describes the outcome of a benchmark
+type Summary struct {
+	Total int `json:"total"`
+	// Failed counts the workspaces which failed or never entered the running phase
+	Failed      int     `json:"failed"`
+	FailureRate float64 `json:"failureRate"`
+	// WorkloadFailed counts the running workspaces in which the workload could not be started
+	WorkloadFailed int `json:"workloadFailed"`
+	// Startup are the percentiles of the startup time of workspaces which entered the running phase
+	Startup Percentiles `json:"startup"`
+}
+
+// Percentiles of a duration
+type Percentiles struct {
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P95 time.Duration `json:"p95"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// Summarize summarizes the stats of a benchmark
+func Summarize(stats *observer.Stats) Summary {
+	var (
+		res     Summary
+		startup []time.Duration
+	)
+	for _, s := range stats.Samples {
+		res.Total++
+		if s.Failed || s.Running.IsZero() {
+			res.Failed++
+			continue
+		}
+		startup = append(startup, s.StartupTime())
+		if s.WorkloadError != "" {
+			res.WorkloadFailed++
+		}
+	}
+	if res.Total > 0 {
+		res.FailureRate = float64(res.Failed) / float64(res.Total)
+	}
+
+	sort.Slice(startup, func(i, j int) bool { return startup[i] < startup[j] })
+	res.Startup = Percentiles{
+		P50: percentile(startup, 50),
+		P90: percentile(startup, 90),
+		P95: percentile(startup, 95),
+		P99: percentile(startup, 99),
+		Max: percentile(startup, 100),
+	}
+	return res
+}
+
+// percentile returns the nearest-rank percentile of sorted durations, zero if there are none
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Thresholds decide which differences between two benchmarks are regressions
+type Thresholds struct {
+	// StartupRegression is the relative increase of a startup time percentile which is a regression, e.g. 0.1 for 10%
+	StartupRegression float64
+	// FailureRateIncrease is the absolute increase of the failure rate which is a regression, e.g. 0.02 for 2 percentage points
+	FailureRateIncrease float64
+}
+
+// Comparison compares a candidate benchmark to a baseline
+type Comparison struct {
+	Baseline    Summary  `json:"baseline"`
+	Candidate   Summary  `json:"candidate"`
+	Regressions []string `json:"regressions"`
+}
+
+// Compare compares the candidate to the baseline and lists the regressions beyond the thresholds
+func Compare(baseline, candidate Summary, thresholds Thresholds) Comparison {
+	res := Comparison{
+		Baseline:    baseline,
+		Candidate:   candidate,
+		Regressions: []string{},
+	}
+
+	for _, p := range []struct {
+		Name                string
+		Baseline, Candidate time.Duration
+	}{
+		{"p50", baseline.Startup.P50, candidate.Startup.P50},
+		{"p95", baseline.Startup.P95, candidate.Startup.P95},
+		{"p99", baseline.Startup.P99, candidate.Startup.P99},
+	} {
+		if p.Baseline == 0 {
+			continue
+		}
+		change := relativeChange(p.Baseline, p.Candidate)
+		if change > thresholds.StartupRegression {
+			res.Regressions = append(res.Regressions, fmt.Sprintf("%s startup time increased by %.1f%% from %s to %s", p.Name, change*100, p.Baseline.Round(time.Millisecond), p.Candidate.Round(time.Millisecond)))
+		}
+	}
+
+	if candidate.FailureRate-baseline.FailureRate > thresholds.FailureRateIncrease {
+		res.Regressions = append(res.Regressions, fmt.Sprintf("failure rate increased from %.1f%% to %.1f%%", baseline.FailureRate*100, candidate.FailureRate*100))
+	}
+
+	return res
+}
+
+func relativeChange(baseline, candidate time.Duration) float64 {
+	return float64(candidate-baseline) / float64(baseline)
+}
+
+// Write renders the comparison as a table
+func (c Comparison) Write(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "\tbaseline\tcandidate\tchange")
+	fmt.Fprintf(w, "workspaces\t%d\t%d\t\n", c.Baseline.Total, c.Candidate.Total)
+	fmt.Fprintf(w, "failure rate\t%.1f%%\t%.1f%%\t%+.1f pp\n", c.Baseline.FailureRate*100, c.Candidate.FailureRate*100, (c.Candidate.FailureRate-c.Baseline.FailureRate)*100)
+	fmt.Fprintf(w, "workload failures\t%d\t%d\t\n", c.Baseline.WorkloadFailed, c.Candidate.WorkloadFailed)
+	for _, p := range []struct {
+		Name                string
+		Baseline, Candidate time.Duration
+	}{
+		{"startup p50", c.Baseline.Startup.P50, c.Candidate.Startup.P50},
+		{"startup p90", c.Baseline.Startup.P90, c.Candidate.Startup.P90},
+		{"startup p95", c.Baseline.Startup.P95, c.Candidate.Startup.P95},
+		{"startup p99", c.Baseline.Startup.P99, c.Candidate.Startup.P99},
+		{"startup max", c.Baseline.Startup.Max, c.Candidate.Startup.Max},
+	} {
+		change := "n/a"
+		if p.Baseline > 0 {
+			change = fmt.Sprintf("%+.1f%%", relativeChange(p.Baseline, p.Candidate)*100)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.Name, p.Baseline.Round(time.Millisecond), p.Candidate.Round(time.Millisecond), change)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(c.Regressions) == 0 {
+		_, err := fmt.Fprintln(out, "\nno regressions")
+		return err
+	}
+	fmt.Fprintln(out, "\nregressions:")
+	for _, r := range c.Regressions {
+		fmt.Fprintf(out, "  - %s\n", r)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package report
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/loadgen/pkg/observer"
+)
+
+func TestPercentile(t *testing.T) {
+	tenSamples := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	tests := []struct {
+		Name        string
+		Sorted      []time.Duration
+		Percentile  float64
+		Expectation time.Duration
+	}{
+		{Name: "no samples", Sorted: nil, Percentile: 50, Expectation: 0},
+		{Name: "single sample p0", Sorted: []time.Duration{7}, Percentile: 0, Expectation: 7},
+		{Name: "single sample p100", Sorted: []time.Duration{7}, Percentile: 100, Expectation: 7},
+		{Name: "p0 is the minimum", Sorted: tenSamples, Percentile: 0, Expectation: 1},
+		{Name: "p10 is exactly rank 1", Sorted: tenSamples, Percentile: 10, Expectation: 1},
+		{Name: "p11 rounds up to rank 2", Sorted: tenSamples, Percentile: 11, Expectation: 2},
+		{Name: "p50", Sorted: tenSamples, Percentile: 50, Expectation: 5},
+		{Name: "p90", Sorted: tenSamples, Percentile: 90, Expectation: 9},
+		{Name: "p95 rounds up to the maximum", Sorted: tenSamples, Percentile: 95, Expectation: 10},
+		{Name: "p100 is the maximum", Sorted: tenSamples, Percentile: 100, Expectation: 10},
+		{Name: "p50 of two samples", Sorted: []time.Duration{1, 2}, Percentile: 50, Expectation: 1},
+		{Name: "p51 of two samples", Sorted: []time.Duration{1, 2}, Percentile: 51, Expectation: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := percentile(test.Sorted, test.Percentile)
+			if act != test.Expectation {
+				t.Errorf("unexpected percentile: expected %v, got %v", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	start := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	running := func(startup time.Duration) observer.StatsSample {
+		return observer.StatsSample{Start: start, Running: start.Add(startup)}
+	}
+
+	tests := []struct {
+		Name        string
+		Samples     []observer.StatsSample
+		Expectation Summary
+	}{
+		{
+			Name:        "no samples",
+			Expectation: Summary{},
+		},
+		{
+			Name: "startup time includes the start call",
+			Samples: []observer.StatsSample{
+				{Start: start, Running: start.Add(10 * time.Second), StartDuration: 2 * time.Second},
+			},
+			Expectation: Summary{
+				Total:   1,
+				Startup: Percentiles{P50: 12 * time.Second, P90: 12 * time.Second, P95: 12 * time.Second, P99: 12 * time.Second, Max: 12 * time.Second},
+			},
+		},
+		{
+			Name: "failed and never running workspaces are excluded from startup times",
+			Samples: []observer.StatsSample{
+				running(30 * time.Second),
+				running(10 * time.Second),
+				{Start: start, Running: start.Add(time.Hour), Failed: true},
+				{Start: start},
+			},
+			Expectation: Summary{
+				Total:       4,
+				Failed:      2,
+				FailureRate: 0.5,
+				Startup:     Percentiles{P50: 10 * time.Second, P90: 30 * time.Second, P95: 30 * time.Second, P99: 30 * time.Second, Max: 30 * time.Second},
+			},
+		},
+		{
+			Name: "workload failures are counted for running workspaces",
+			Samples: []observer.StatsSample{
+				func() observer.StatsSample {
+					s := running(5 * time.Second)
+					s.WorkloadError = "exit status 1"
+					return s
+				}(),
+				running(5 * time.Second),
+			},
+			Expectation: Summary{
+				Total:          2,
+				WorkloadFailed: 1,
+				Startup:        Percentiles{P50: 5 * time.Second, P90: 5 * time.Second, P95: 5 * time.Second, P99: 5 * time.Second, Max: 5 * time.Second},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := Summarize(&observer.Stats{Samples: test.Samples})
+			if !reflect.DeepEqual(act, test.Expectation) {
+				t.Errorf("unexpected summary:\nexpected %+v\ngot      %+v", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	summary := func(p50, p95, p99 time.Duration, failureRate float64) Summary {
+		return Summary{FailureRate: failureRate, Startup: Percentiles{P50: p50, P95: p95, P99: p99}}
+	}
+	baseline := summary(10*time.Second, 20*time.Second, 30*time.Second, 0.01)
+	thresholds := Thresholds{StartupRegression: 0.1, FailureRateIncrease: 0.02}
+
+	tests := []struct {
+		Name        string
+		Baseline    Summary
+		Candidate   Summary
+		Expectation []string
+	}{
+		{
+			Name:        "no change",
+			Baseline:    baseline,
+			Candidate:   baseline,
+			Expectation: []string{},
+		},
+		{
+			Name:        "improvements are no regressions",
+			Baseline:    baseline,
+			Candidate:   summary(5*time.Second, 10*time.Second, 15*time.Second, 0),
+			Expectation: []string{},
+		},
+		{
+			Name:        "increase at the startup threshold is no regression",
+			Baseline:    baseline,
+			Candidate:   summary(11*time.Second, 22*time.Second, 33*time.Second, 0.01),
+			Expectation: []string{},
+		},
+		{
+			Name:      "increase beyond the startup threshold is a regression",
+			Baseline:  baseline,
+			Candidate: summary(11*time.Second, 23*time.Second, 30*time.Second, 0.01),
+			Expectation: []string{
+				"p95 startup time increased by 15.0% from 20s to 23s",
+			},
+		},
+		{
+			Name:        "increase at the failure rate threshold is no regression",
+			Baseline:    summary(10*time.Second, 20*time.Second, 30*time.Second, 0),
+			Candidate:   summary(10*time.Second, 20*time.Second, 30*time.Second, 0.02),
+			Expectation: []string{},
+		},
+		{
+			Name:      "increase beyond the failure rate threshold is a regression",
+			Baseline:  baseline,
+			Candidate: summary(10*time.Second, 20*time.Second, 30*time.Second, 0.04),
+			Expectation: []string{
+				"failure rate increased from 1.0% to 4.0%",
+			},
+		},
+		{
+			Name:        "percentiles missing from the baseline are not compared",
+			Baseline:    summary(0, 0, 0, 0),
+			Candidate:   summary(10*time.Second, 20*time.Second, 30*time.Second, 0),
+			Expectation: []string{},
+		},
+		{
+			Name:      "all regressions are listed",
+			Baseline:  baseline,
+			Candidate: summary(20*time.Second, 40*time.Second, 60*time.Second, 0.5),
+			Expectation: []string{
+				"p50 startup time increased by 100.0% from 10s to 20s",
+				"p95 startup time increased by 100.0% from 20s to 40s",
+				"p99 startup time increased by 100.0% from 30s to 1m0s",
+				"failure rate increased from 1.0% to 50.0%",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := Compare(test.Baseline, test.Candidate, thresholds)
+			if !reflect.DeepEqual(act.Regressions, test.Expectation) {
+				t.Errorf("unexpected regressions:\nexpected %q\ngot      %q", test.Expectation, act.Regressions)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	"github.com/gitpod-io/gitpod/installer/pkg/config/versions"
+	"github.com/gitpod-io/gitpod/installer/pkg/diff"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// installationConfigMap is the config map which lists all objects of an installation, see common.GenerateInstallationConfigMap
+const installationConfigMap = "gitpod-app"
+
+type diffSourceOpts struct {
+	Previous string
+	Live     bool
+	Kube     kubeConfig
+}
+
+var diffOpts struct {
+	Source diffSourceOpts
+	Output string
+}
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Shows how the Kubernetes manifests change compared to a previous render or a live cluster",
+	Long: `Shows how the Kubernetes manifests change compared to a previous render or a live cluster
+
+Objects are compared semantically, ignoring fields that are generated by Kubernetes
+such as the status, resource version or managed fields. When comparing against a live
+cluster, fields which are defaulted by Kubernetes are ignored as well.`,
+	Example: `  # Compare against a previous render
+  gitpod-installer diff --config config.yaml --previous previous.yaml
+
+  # Compare against the installation in the cluster
+  gitpod-installer diff --config config.yaml --live --namespace gitpod`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		diffs, err := diffFn(context.Background(), diffOpts.Source, nil)
+		if err != nil {
+			return err
+		}
+
+		switch diffOpts.Output {
+		case "json":
+			out, err := common.ToJSONString(diffs)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		case "text":
+			return diff.Format(os.Stdout, diffs)
+		default:
+			return fmt.Errorf("unsupported output format: %s", diffOpts.Output)
+		}
+	},
+}
+
+// diffFn renders the config and compares it against the selected source. If previousVersions is set
+// and no other source is selected, the config is compared against its render with the previous versions.
+func diffFn(ctx context.Context, src diffSourceOpts, previousVersions *versions.Manifest) ([]diff.ObjectDiff, error) {
+	cfgVersion, cfg, err := loadRenderConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := renderKubernetesObjects(cfgVersion, cfg)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := diff.Parse(rendered...)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := diff.CompareOptions{
+		// the installation config map lists all other objects, and hence changes whenever they do
+		Ignore: []diff.ObjectKey{{Kind: "ConfigMap", Namespace: renderOpts.Namespace, Name: installationConfigMap}},
+	}
+
+	var previous []*unstructured.Unstructured
+	switch {
+	case src.Previous != "" && src.Live:
+		return nil, fmt.Errorf("--previous and --live are mutually exclusive")
+	case src.Previous != "":
+		previous, err = diff.Load(src.Previous)
+		if err != nil {
+			return nil, err
+		}
+	case src.Live:
+		previous, err = loadLiveObjects(ctx, src.Kube, renderOpts.Namespace, desired)
+		if err != nil {
+			return nil, err
+		}
+		opts.PruneOld = true
+	case previousVersions != nil:
+		rendered, err := renderKubernetesObjectsWithVersions(cfgVersion, cfg, previousVersions)
+		if err != nil {
+			return nil, err
+		}
+		previous, err = diff.Parse(rendered...)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("either --previous or --live is required")
+	}
+
+	return diff.Compare(previous, desired, opts)
+}
+
+// loadLiveObjects reads the current state of all desired objects and of all objects
+// listed in the installation config map from the cluster. Objects which do not exist are omitted.
+func loadLiveObjects(ctx context.Context, kube kubeConfig, namespace string, desired []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	if err := checkKubeConfig(&kube); err != nil {
+		return nil, err
+	}
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kube.Config},
+		&clientcmd.ConfigOverrides{},
+	).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discovery.NewDiscoveryClient(clientset.RESTClient())))
+
+	candidates := append([]*unstructured.Unstructured{}, desired...)
+	cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, installationConfigMap, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if cm != nil && cm.Data != nil {
+		installed, err := parseInstallationConfigMap(cm)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, installed...)
+	}
+
+	var (
+		res  []*unstructured.Unstructured
+		seen = make(map[diff.ObjectKey]struct{}, len(candidates))
+	)
+	for _, obj := range candidates {
+		key := diff.KeyOf(obj)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		gvk := obj.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if meta.IsNoMatchError(err) {
+			// the API (e.g. a CRD) is not installed in the cluster, hence neither is the object
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var ri dynamic.ResourceInterface = client.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			ns := obj.GetNamespace()
+			if ns == "" {
+				ns = namespace
+			}
+			ri = client.Resource(mapping.Resource).Namespace(ns)
+		}
+
+		live, err := ri.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot get %s: %w", key, err)
+		}
+		res = append(res, live)
+	}
+
+	return res, nil
+}
+
+func parseInstallationConfigMap(cm *corev1.ConfigMap) ([]*unstructured.Unstructured, error) {
+	objs, err := diff.Parse(cm.Data["app.yaml"])
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse installation config map: %w", err)
+	}
+	return objs, nil
+}
+
+func addDiffSourceFlags(cmd *cobra.Command, src *diffSourceOpts) {
+	dir, err := os.Getwd()
+	if err != nil {
+		log.WithError(err).Fatal("Failed to get working directory")
+	}
+
+	cmd.Flags().StringVarP(&renderOpts.ConfigFN, "config", "c", getEnvvar("GITPOD_INSTALLER_CONFIG", filepath.Join(dir, "gitpod.config.yaml")), "path to the config file, use - for stdin")
+	cmd.Flags().StringVarP(&renderOpts.Namespace, "namespace", "n", getEnvvar("NAMESPACE", "default"), "namespace to deploy to")
+	cmd.Flags().BoolVar(&renderOpts.ValidateConfigDisabled, "no-validation", false, "if set, the config will not be validated before running")
+	cmd.Flags().BoolVar(&renderOpts.UseExperimentalConfig, "use-experimental-config", false, "enable the use of experimental config that is prone to be changed")
+	cmd.Flags().StringVar(&src.Previous, "previous", "", "path to a previous render, either a YAML file or a directory produced by --output-split-files")
+	cmd.Flags().BoolVar(&src.Live, "live", false, "compare against the objects in the cluster")
+	cmd.Flags().StringVar(&src.Kube.Config, "kubeconfig", "", "path to the kubeconfig file, used with --live")
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	addDiffSourceFlags(diffCmd, &diffOpts.Source)
+	diffCmd.Flags().StringVarP(&diffOpts.Output, "output", "o", "text", "output format, one of text or json")
+}
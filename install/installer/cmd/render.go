@@ -19,6 +19,7 @@ import (
 	"github.com/gitpod-io/gitpod/installer/pkg/config"
 	configv1 "github.com/gitpod-io/gitpod/installer/pkg/config/v1"
 	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
+	"github.com/gitpod-io/gitpod/installer/pkg/config/versions"
 	"github.com/gitpod-io/gitpod/installer/pkg/postprocess"
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/yaml"
@@ -67,11 +68,21 @@ A config file is required which can be generated with the init command.`,
 }
 
 func renderFn() ([]string, error) {
-	_, cfgVersion, cfg, err := loadConfig(renderOpts.ConfigFN)
+	cfgVersion, cfg, err := loadRenderConfig()
 	if err != nil {
 		return nil, err
 	}
 
+	return renderKubernetesObjects(cfgVersion, cfg)
+}
+
+// loadRenderConfig loads the config file passed to the render flags, removing the experimental section unless it was enabled
+func loadRenderConfig() (cfgVersion string, cfg *configv1.Config, err error) {
+	_, cfgVersion, cfg, err = loadConfig(renderOpts.ConfigFN)
+	if err != nil {
+		return "", nil, err
+	}
+
 	if cfg.Experimental != nil {
 		if renderOpts.UseExperimentalConfig {
 			fmt.Fprintf(os.Stderr, "rendering using experimental config\n")
@@ -81,7 +92,7 @@ func renderFn() ([]string, error) {
 		}
 	}
 
-	return renderKubernetesObjects(cfgVersion, cfg)
+	return cfgVersion, cfg, nil
 }
 
 func saveYamlToFiles(dir string, yaml []string) error {
@@ -141,6 +152,10 @@ func renderKubernetesObjects(cfgVersion string, cfg *configv1.Config) ([]string,
 		return nil, err
 	}
 
+	return renderKubernetesObjectsWithVersions(cfgVersion, cfg, versionMF)
+}
+
+func renderKubernetesObjectsWithVersions(cfgVersion string, cfg *configv1.Config, versionMF *versions.Manifest) ([]string, error) {
 	if !renderOpts.ValidateConfigDisabled {
 		apiVersion, err := config.LoadConfigVersion(cfgVersion)
 		if err != nil {
@@ -0,0 +1,88 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	"github.com/gitpod-io/gitpod/installer/pkg/config/versions"
+	"github.com/gitpod-io/gitpod/installer/pkg/diff"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+var upgradePlanOpts struct {
+	Source           diffSourceOpts
+	PreviousVersions string
+}
+
+// upgradePlanCmd represents the upgrade-plan command
+var upgradePlanCmd = &cobra.Command{
+	Use:   "upgrade-plan",
+	Short: "Lists the changes of an upgrade which need attention, such as destructive changes",
+	Long: `Lists the changes of an upgrade which need attention, such as destructive changes
+
+Destructive changes are changes to immutable fields which require objects to be deleted and
+recreated, deleted persistent volume claims and custom resource definitions, and custom resource
+definition versions which are no longer served. The command exits with a non-zero exit code
+if the upgrade contains destructive changes.`,
+	Example: `  # Plan an upgrade of the installation in the cluster
+  gitpod-installer upgrade-plan --config config.yaml --live --namespace gitpod
+
+  # Plan an upgrade from a previous version using the same config
+  gitpod-installer upgrade-plan --config config.yaml --previous-versions previous-versions.yaml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var previousVersions *versions.Manifest
+		if upgradePlanOpts.PreviousVersions != "" {
+			fc, err := os.ReadFile(upgradePlanOpts.PreviousVersions)
+			if err != nil {
+				return err
+			}
+			err = yaml.Unmarshal(fc, &previousVersions)
+			if err != nil {
+				return fmt.Errorf("cannot parse versions manifest %s: %w", upgradePlanOpts.PreviousVersions, err)
+			}
+		}
+
+		diffs, err := diffFn(context.Background(), upgradePlanOpts.Source, previousVersions)
+		if err != nil {
+			return err
+		}
+
+		plan := diff.NewPlan(diffs)
+		if previousVersions != nil {
+			versionMF, err := getVersionManifest()
+			if err != nil {
+				return err
+			}
+			plan.Versions, err = diff.CompareVersions(previousVersions, versionMF)
+			if err != nil {
+				return err
+			}
+		}
+
+		out, err := common.ToJSONString(plan)
+		if err != nil {
+			return err
+		}
+		if plan.Destructive() {
+			fmt.Fprintln(os.Stderr, string(out))
+			os.Exit(1)
+		}
+
+		fmt.Println(string(out))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(upgradePlanCmd)
+
+	addDiffSourceFlags(upgradePlanCmd, &upgradePlanOpts.Source)
+	upgradePlanCmd.Flags().StringVar(&upgradePlanOpts.PreviousVersions, "previous-versions", "", "path to the versions manifest of the installed version, used to list version changes and as comparison if neither --previous nor --live is set")
+}
@@ -206,6 +206,12 @@ func tracingEnv(context *RenderContext, component string, tracing *experimental.
 		if tracing.SamplerParam != nil {
 			samplerParam = strconv.FormatFloat(*tracing.SamplerParam, 'f', -1, 64)
 		}
+		if tracing.OTLPProtocol != nil {
+			res = append(res,
+				corev1.EnvVar{Name: "OTEL_TRACES_EXPORTER", Value: "otlp"},
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: *tracing.OTLPProtocol},
+			)
+		}
 	}
 
 	res = append(res,
@@ -14,6 +14,7 @@ import (
 
 const (
 	AppName                     = "gitpod"
+	BlobServeComponent          = "blobserve"
 	BlobServeServicePort        = 4000
 	CertManagerCAIssuer         = "gitpod-ca-issuer"
 	DockerRegistryURL           = "docker.io"
@@ -112,6 +112,9 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 				Location: "/mnt/cache/blobserve",
 				MaxSize:  MaxSizeBytes,
 			},
+			Peers: &blobserve_config.Peers{
+				Service: fmt.Sprintf("%s.%s.svc.cluster.local", PeersService, ctx.Namespace),
+			},
 		},
 		AuthCfg:            "/mnt/pull-secret/pull-secret.json",
 		PProfAddr:          common.LocalhostAddressFromPort(baseserver.BuiltinDebugPort),
@@ -9,10 +9,12 @@ import (
 )
 
 const (
-	Component       = "blobserve"
+	Component       = common.BlobServeComponent
 	ContainerPort   = 32224
 	ServicePort     = common.BlobServeServicePort
 	ServicePortName = "service"
 	MaxSizeBytes    = 1024 * 1024 * 1024 // 1 Gibibyte
 	ReadinessPort   = 8086
+	// PeersService is the headless service blobserve replicas use to find each other
+	PeersService = Component + "-peers"
 )
@@ -4,7 +4,11 @@
 
 package blobserve
 
-import "github.com/gitpod-io/gitpod/installer/pkg/common"
+import (
+	"github.com/gitpod-io/gitpod/installer/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+)
 
 var Objects = common.CompositeRenderFunc(
 	configmap,
@@ -18,5 +22,15 @@ var Objects = common.CompositeRenderFunc(
 			ServicePort:   ServicePort,
 		},
 	}),
+	common.GenerateService(Component, []common.ServicePort{
+		{
+			Name:          ServicePortName,
+			ContainerPort: ContainerPort,
+			ServicePort:   ContainerPort,
+		},
+	}, func(service *corev1.Service) {
+		service.Name = PeersService
+		service.Spec.ClusterIP = corev1.ClusterIPNone
+	}),
 	common.DefaultServiceAccount(Component),
 )
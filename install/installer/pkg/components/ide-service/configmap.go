@@ -27,6 +27,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 			},
 		},
 		IDEConfigPath: "/ide-config/config.json",
+		BlobserveURL:  fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", common.BlobServeComponent, ctx.Namespace, common.BlobServeServicePort),
 	}
 
 	fc, err := common.ToJSONString(cfg)
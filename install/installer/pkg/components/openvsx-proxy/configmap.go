@@ -34,6 +34,21 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 		RedisAddr:            "localhost:6379",
 		AllowCacheDomain:     []string{domain.Host},
 	}
+	if proxy := ctx.Config.OpenVSX.Proxy; proxy != nil {
+		imgcfg.BlockedExtensions = proxy.BlockedExtensions
+		if mirror := proxy.Mirror; mirror != nil {
+			imgcfg.Mirror = openvsx.MirrorConfig{
+				Enabled:         true,
+				Extensions:      mirror.Extensions,
+				StoragePath:     mirrorMountPath,
+				RefreshInterval: util.Duration(24 * time.Hour),
+				Offline:         mirror.Offline,
+			}
+			if mirror.RefreshInterval != nil {
+				imgcfg.Mirror.RefreshInterval = *mirror.RefreshInterval
+			}
+		}
+	}
 
 	redisCfg := `
 maxmemory 100mb
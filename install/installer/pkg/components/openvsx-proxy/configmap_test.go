@@ -0,0 +1,64 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package openvsx_proxy
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gitpod-io/gitpod/common-go/util"
+	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	config "github.com/gitpod-io/gitpod/installer/pkg/config/v1"
+	"github.com/gitpod-io/gitpod/installer/pkg/config/versions"
+	openvsx "github.com/gitpod-io/gitpod/openvsx-proxy/pkg"
+)
+
+func TestMirror(t *testing.T) {
+	ctx, err := common.NewRenderContext(config.Config{
+		Repository: "eu.gcr.io/gitpod",
+		OpenVSX: config.OpenVSX{
+			URL: "https://open-vsx.org",
+			Proxy: &config.OpenVSXProxy{
+				Mirror: &config.OpenVSXMirror{
+					Extensions: []string{"redhat.java", "gitpod.gitpod-theme@1.0.0"},
+					Offline:    true,
+				},
+				BlockedExtensions: []string{"ms-python"},
+			},
+		},
+	}, versions.Manifest{
+		Components: versions.Components{
+			OpenVSXProxy: versions.Versioned{
+				Version: "commit-test-latest",
+			},
+		},
+	}, "test-namespace")
+	require.NoError(t, err)
+
+	objects, err := configmap(ctx)
+	require.NoError(t, err)
+	var cfg openvsx.Config
+	require.NoError(t, json.Unmarshal([]byte(objects[0].(*corev1.ConfigMap).Data["config.json"]), &cfg))
+	require.Equal(t, openvsx.MirrorConfig{
+		Enabled:         true,
+		Extensions:      []string{"redhat.java", "gitpod.gitpod-theme@1.0.0"},
+		StoragePath:     mirrorMountPath,
+		RefreshInterval: util.Duration(24 * time.Hour),
+		Offline:         true,
+	}, cfg.Mirror)
+	require.Equal(t, []string{"ms-python"}, cfg.BlockedExtensions)
+
+	objects, err = statefulset(ctx)
+	require.NoError(t, err)
+	sts := objects[0].(*appsv1.StatefulSet)
+	require.Len(t, sts.Spec.VolumeClaimTemplates, 2)
+	require.Equal(t, mirrorVolumeName, sts.Spec.VolumeClaimTemplates[1].Name)
+	require.Contains(t, sts.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: mirrorVolumeName, MountPath: mirrorMountPath})
+}
@@ -9,4 +9,7 @@ const (
 	ContainerPort = 8080
 	ServicePort   = 8080
 	PortName      = "http"
+
+	mirrorVolumeName = "mirror-data"
+	mirrorMountPath  = "/mirror"
 )
@@ -59,9 +59,44 @@ func statefulset(ctx *common.RenderContext) ([]runtime.Object, error) {
 		},
 	}
 
+	volumeMounts := []v1.VolumeMount{{
+		Name:      "config",
+		MountPath: "/config",
+	}}
+
+	if proxy := ctx.Config.OpenVSX.Proxy; proxy != nil && proxy.Mirror != nil {
+		storageSize := resource.MustParse("8Gi")
+		if proxy.Mirror.StorageSize != nil {
+			storageSize = *proxy.Mirror.StorageSize
+		}
+		volumeClaimTemplates = append(volumeClaimTemplates, v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   mirrorVolumeName,
+				Labels: common.DefaultLabels(Component),
+			},
+			Spec: v1.PersistentVolumeClaimSpec{
+				AccessModes: []v1.PersistentVolumeAccessMode{
+					v1.ReadWriteOnce,
+				},
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						"storage": storageSize,
+					},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      mirrorVolumeName,
+			MountPath: mirrorMountPath,
+		})
+	}
+
 	if ctx.Config.OpenVSX.Proxy != nil && ctx.Config.OpenVSX.Proxy.DisablePVC {
 		volumeClaimTemplates = nil
 		volumes = append(volumes, *common.NewEmptyDirVolume("redis-data"))
+		if ctx.Config.OpenVSX.Proxy.Mirror != nil {
+			volumes = append(volumes, *common.NewEmptyDirVolume(mirrorVolumeName))
+		}
 	}
 
 	const redisContainerName = "redis"
@@ -129,10 +164,7 @@ func statefulset(ctx *common.RenderContext) ([]runtime.Object, error) {
 							Name:          baseserver.BuiltinMetricsPortName,
 							ContainerPort: baseserver.BuiltinMetricsPort,
 						}},
-						VolumeMounts: []v1.VolumeMount{{
-							Name:      "config",
-							MountPath: "/config",
-						}},
+						VolumeMounts: volumeMounts,
 						Env: common.CustomizeEnvvar(ctx, Component, common.MergeEnv(
 							common.DefaultEnv(&ctx.Config),
 							common.ConfigcatEnv(ctx),
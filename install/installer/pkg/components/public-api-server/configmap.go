@@ -15,9 +15,11 @@ import (
 
 	"github.com/gitpod-io/gitpod/installer/pkg/common"
 	"github.com/gitpod-io/gitpod/installer/pkg/components/auth"
+	contentservice "github.com/gitpod-io/gitpod/installer/pkg/components/content-service"
 	"github.com/gitpod-io/gitpod/installer/pkg/components/redis"
 	"github.com/gitpod-io/gitpod/installer/pkg/components/server"
 	"github.com/gitpod-io/gitpod/installer/pkg/components/usage"
+	wsmanagermk2 "github.com/gitpod-io/gitpod/installer/pkg/components/ws-manager-mk2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -42,6 +44,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 	})
 
 	_, _, databaseSecretMountPath := common.DatabaseEnvSecret(ctx.Config)
+	_, _, prebuildsCfg, _ := getPrebuildsConfig(ctx)
 
 	_, _, authCfg := auth.GetConfig(ctx)
 	redisCfg := redis.GetConfiguration(ctx)
@@ -53,10 +56,15 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 		PersonalAccessTokenSigningKeyPath: personalAccessTokenSigningKeyPath,
 		BillingServiceAddress:             common.ClusterAddress(usage.Component, ctx.Namespace, usage.GRPCServicePort),
 		SessionServiceAddress:             common.ClusterAddress(common.ServerComponent, ctx.Namespace, common.ServerIAMSessionPort),
+		ServerAddress:                     common.ClusterAddress(common.ServerComponent, ctx.Namespace, common.ServerGRPCAPIPort),
 		DatabaseConfigPath:                databaseSecretMountPath,
 		Redis: config.RedisConfiguration{
 			Address: redisCfg.Address,
 		},
+		WorkspaceTemplates: &config.WorkspaceTemplatesConfiguration{
+			ContentServiceAddress: common.ClusterAddress(contentservice.Component, ctx.Namespace, contentservice.RPCPort),
+		},
+		Prebuilds: prebuildsCfg,
 		Auth: config.AuthConfiguration{
 			PKI: config.AuthPKIConfiguration{
 				Signing: config.KeyPair{
@@ -173,3 +181,45 @@ func getPersonalAccessTokenSigningKey(cfg *experimental.Config) (corev1.Volume,
 
 	return volume, mount, path, true
 }
+
+// getPrebuildsConfig returns the ws-manager-mk2 client certificates and the configuration to reach its prebuild scheduler.
+// Prebuilds are only served when ws-manager-mk2 runs in the same cluster.
+func getPrebuildsConfig(ctx *common.RenderContext) (corev1.Volume, corev1.VolumeMount, *config.PrebuildsConfiguration, bool) {
+	var volume corev1.Volume
+	var mount corev1.VolumeMount
+
+	var useMk2 bool
+	_ = ctx.WithExperimental(func(cfg *experimental.Config) error {
+		useMk2 = cfg.Workspace != nil && cfg.Workspace.UseWsmanagerMk2
+		return nil
+	})
+	if !common.WithLocalWsManager(ctx) || !useMk2 {
+		return volume, mount, nil, false
+	}
+
+	volume = corev1.Volume{
+		Name: "ws-manager-client-tls-certs",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: wsmanagermk2.TLSSecretNameClient,
+			},
+		},
+	}
+
+	mount = corev1.VolumeMount{
+		Name:      "ws-manager-client-tls-certs",
+		MountPath: wsManagerClientTLSMountPath,
+		ReadOnly:  true,
+	}
+
+	cfg := &config.PrebuildsConfiguration{
+		WorkspaceManagerAddress: fmt.Sprintf("%s:%d", wsmanagermk2.Component, wsmanagermk2.RPCPort),
+		TLS: &config.ClientTLSConfiguration{
+			CA:          wsManagerClientTLSMountPath + "/ca.crt",
+			Certificate: wsManagerClientTLSMountPath + "/tls.crt",
+			PrivateKey:  wsManagerClientTLSMountPath + "/tls.key",
+		},
+	}
+
+	return volume, mount, cfg, true
+}
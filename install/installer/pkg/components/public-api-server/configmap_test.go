@@ -5,11 +5,13 @@
 package public_api_server
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/gitpod-io/gitpod/installer/pkg/components/redis"
+	v1 "github.com/gitpod-io/gitpod/installer/pkg/config/v1"
 	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
 	"github.com/google/go-cmp/cmp"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/gitpod-io/gitpod/components/public-api/go/config"
 	"github.com/gitpod-io/gitpod/installer/pkg/common"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -45,12 +48,16 @@ func TestConfigMap(t *testing.T) {
 		GitpodServiceURL:                  fmt.Sprintf("ws://server.%s.svc.cluster.local:3000", ctx.Namespace),
 		BillingServiceAddress:             fmt.Sprintf("usage.%s.svc.cluster.local:9001", ctx.Namespace),
 		SessionServiceAddress:             fmt.Sprintf("server.%s.svc.cluster.local:9876", ctx.Namespace),
+		ServerAddress:                     fmt.Sprintf("server.%s.svc.cluster.local:9877", ctx.Namespace),
 		StripeWebhookSigningSecretPath:    stripeSecretPath,
 		PersonalAccessTokenSigningKeyPath: personalAccessTokenSigningKeyPath,
 		DatabaseConfigPath:                "/secrets/database-config",
 		Redis: config.RedisConfiguration{
 			Address: fmt.Sprintf("%s.%s.svc.cluster.local:%d", redis.Component, ctx.Namespace, redis.Port),
 		},
+		WorkspaceTemplates: &config.WorkspaceTemplatesConfiguration{
+			ContentServiceAddress: fmt.Sprintf("content-service.%s.svc.cluster.local:8080", ctx.Namespace),
+		},
 		Auth: config.AuthConfiguration{
 			PKI: config.AuthPKIConfiguration{
 				Signing: config.KeyPair{
@@ -104,3 +111,45 @@ func TestConfigMap(t *testing.T) {
 		t.Errorf("configMap mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestConfigMap_Prebuilds(t *testing.T) {
+	ctx := renderContextWithPublicAPI(t)
+	ctx.Config.Kind = v1.InstallationFull
+	_ = ctx.WithExperimental(func(ucfg *experimental.Config) error {
+		ucfg.Workspace = &experimental.WorkspaceConfig{UseWsmanagerMk2: true}
+		return nil
+	})
+
+	objs, err := configmap(ctx)
+	require.NoError(t, err)
+
+	var cfg config.Configuration
+	require.NoError(t, json.Unmarshal([]byte(objs[0].(*corev1.ConfigMap).Data["config.json"]), &cfg))
+
+	require.Equal(t, &config.PrebuildsConfiguration{
+		WorkspaceManagerAddress: "ws-manager-mk2:8080",
+		TLS: &config.ClientTLSConfiguration{
+			CA:          "/ws-manager-client-tls-certs/ca.crt",
+			Certificate: "/ws-manager-client-tls-certs/tls.crt",
+			PrivateKey:  "/ws-manager-client-tls-certs/tls.key",
+		},
+	}, cfg.Prebuilds)
+
+	objs, err = deployment(ctx)
+	require.NoError(t, err)
+
+	podSpec := objs[0].(*appsv1.Deployment).Spec.Template.Spec
+	require.Contains(t, podSpec.Volumes, corev1.Volume{
+		Name: "ws-manager-client-tls-certs",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "ws-manager-mk2-client-tls",
+			},
+		},
+	})
+	require.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "ws-manager-client-tls-certs",
+		MountPath: "/ws-manager-client-tls-certs",
+		ReadOnly:  true,
+	})
+}
@@ -17,4 +17,5 @@ const (
 	oidcClientJWTSigningKeyMountPath       = "/secrets/oidc-client-jwt-signing-key"
 	stripeSecretMountPath                  = "/secrets/stripe-webhook-secret"
 	personalAccessTokenSigningKeyMountPath = "/secrets/personal-access-token-signing-key"
+	wsManagerClientTLSMountPath            = "/ws-manager-client-tls-certs"
 )
@@ -81,6 +81,11 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 		return nil
 	})
 
+	if volume, mount, _, ok := getPrebuildsConfig(ctx); ok {
+		volumes = append(volumes, volume)
+		volumeMounts = append(volumeMounts, mount)
+	}
+
 	authVolumes, authMounts, _ := auth.GetConfig(ctx)
 	volumes = append(volumes, authVolumes...)
 	volumeMounts = append(volumeMounts, authMounts...)
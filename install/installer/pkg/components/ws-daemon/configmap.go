@@ -25,6 +25,7 @@ import (
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/netlimit"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -45,7 +46,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 		CGroupBasePath: "/mnt/node-cgroups",
 		ControlPeriod:  util.Duration(15 * time.Second),
 	}
-	var ioLimitConfig daemon.IOLimitConfig
+	var ioLimitConfig cgroup.IOLimitConfig
 
 	var procLimit int64
 	networkLimitConfig := netlimit.Config{
@@ -84,11 +85,32 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 		ioLimitConfig.ReadBWPerSecond = ucfg.Workspace.IOLimits.ReadBWPerSecond
 		ioLimitConfig.WriteIOPS = ucfg.Workspace.IOLimits.WriteIOPS
 		ioLimitConfig.ReadIOPS = ucfg.Workspace.IOLimits.ReadIOPS
+		ioLimitConfig.Saturation = ucfg.Workspace.IOLimits.Saturation
+		for id, class := range ucfg.Workspace.WorkspaceClasses {
+			if class.Resources.Limits.IO == nil {
+				continue
+			}
+			if ioLimitConfig.Classes == nil {
+				ioLimitConfig.Classes = make(map[string]cgroup.IOLimits)
+			}
+			ioLimitConfig.Classes[id] = *class.Resources.Limits.IO
+		}
 
 		networkLimitConfig.Enabled = ucfg.Workspace.NetworkLimits.Enabled
 		networkLimitConfig.Enforce = ucfg.Workspace.NetworkLimits.Enforce
 		networkLimitConfig.ConnectionsPerMinute = ucfg.Workspace.NetworkLimits.ConnectionsPerMinute
 		networkLimitConfig.BucketSize = ucfg.Workspace.NetworkLimits.BucketSize
+		networkLimitConfig.EgressPolicies = ucfg.Workspace.NetworkLimits.EgressPolicies
+		networkLimitConfig.Bandwidth.Default = ucfg.Workspace.NetworkLimits.EgressBandwidth
+		for id, class := range ucfg.Workspace.WorkspaceClasses {
+			if class.Resources.Limits.EgressBandwidth == nil {
+				continue
+			}
+			if networkLimitConfig.Bandwidth.Classes == nil {
+				networkLimitConfig.Bandwidth.Classes = make(map[string]resource.Quantity)
+			}
+			networkLimitConfig.Bandwidth.Classes[id] = *class.Resources.Limits.EgressBandwidth
+		}
 
 		oomScoreAdjConfig.Enabled = ucfg.Workspace.OOMScores.Enabled
 		oomScoreAdjConfig.Tier1 = ucfg.Workspace.OOMScores.Tier1
@@ -164,6 +186,13 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 			ProcLimit: procLimit,
 			NetLimit:  networkLimitConfig,
 			OOMScores: oomScoreAdjConfig,
+			MemoryPressure: cgroup.MemoryPressureConfig{
+				Enabled:           true,
+				UsageThreshold:    0.9,
+				PressureThreshold: 0.2,
+				Interval:          util.Duration(10 * time.Second),
+				WarningCooldown:   util.Duration(5 * time.Minute),
+			},
 			DiskSpaceGuard: diskguard.Config{
 				Enabled:  true,
 				Interval: util.Duration(5 * time.Minute),
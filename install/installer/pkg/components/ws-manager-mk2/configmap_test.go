@@ -7,14 +7,17 @@ package wsmanagermk2
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 
+	"github.com/gitpod-io/gitpod/common-go/util"
 	"github.com/gitpod-io/gitpod/installer/pkg/common"
 	config "github.com/gitpod-io/gitpod/installer/pkg/config/v1"
+	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
 	"github.com/gitpod-io/gitpod/installer/pkg/config/versions"
 	wsmancfg "github.com/gitpod-io/gitpod/ws-manager/api/config"
 )
@@ -210,3 +213,57 @@ func TestWorkspaceURLTemplates(t *testing.T) {
 		})
 	}
 }
+
+func TestPrebuilds(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Experiment  *experimental.WorkspaceConfig
+		Expectation *wsmancfg.PrebuildConfiguration
+	}{
+		{
+			Name:        "Without prebuilds configuration",
+			Expectation: &wsmancfg.PrebuildConfiguration{},
+		},
+		{
+			Name: "With prebuilds configuration",
+			Experiment: &experimental.WorkspaceConfig{
+				Prebuilds: &experimental.PrebuildsConfig{
+					MaxConcurrentPerProject: 5,
+					CancelSuperseded:        true,
+					Retention:               util.Duration(48 * time.Hour),
+				},
+			},
+			Expectation: &wsmancfg.PrebuildConfiguration{
+				MaxConcurrentPerProject: 5,
+				CancelSuperseded:        true,
+				Retention:               util.Duration(48 * time.Hour),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx, err := common.NewRenderContext(config.Config{
+				Domain: "example.com",
+				ObjectStorage: config.ObjectStorage{
+					InCluster: pointer.Bool(true),
+				},
+				Experimental: &experimental.Config{
+					Workspace: test.Experiment,
+				},
+			}, versions.Manifest{}, "test_namespace")
+			require.NoError(t, err)
+
+			objs, err := configmap(ctx)
+			require.NoError(t, err)
+
+			cfgmap, ok := objs[0].(*corev1.ConfigMap)
+			require.Truef(t, ok, "configmap function did not return a configmap")
+
+			serviceConfig := wsmancfg.ServiceConfiguration{}
+			require.NoError(t, json.Unmarshal([]byte(cfgmap.Data["config.json"]), &serviceConfig))
+
+			require.Equal(t, test.Expectation, serviceConfig.Manager.Prebuilds)
+		})
+	}
+}
@@ -58,6 +58,28 @@ var controllerRules = []rbacv1.PolicyRule{
 			"update",
 		},
 	},
+	{
+		APIGroups: []string{"workspace.gitpod.io"},
+		Resources: []string{"prebuilds"},
+		Verbs: []string{
+			"create",
+			"delete",
+			"get",
+			"list",
+			"patch",
+			"update",
+			"watch",
+		},
+	},
+	{
+		APIGroups: []string{"workspace.gitpod.io"},
+		Resources: []string{"prebuilds/status"},
+		Verbs: []string{
+			"get",
+			"patch",
+			"update",
+		},
+	},
 	{
 		APIGroups: []string{"workspace.gitpod.io"},
 		Resources: []string{"snapshots"},
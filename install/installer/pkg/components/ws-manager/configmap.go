@@ -16,6 +16,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/util"
 	storageconfig "github.com/gitpod-io/gitpod/content-service/api/config"
 	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	"github.com/gitpod-io/gitpod/installer/pkg/components/redis"
 	configv1 "github.com/gitpod-io/gitpod/installer/pkg/config/v1"
 	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
 	"github.com/gitpod-io/gitpod/ws-manager/api/config"
@@ -158,6 +159,15 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 		return nil, err
 	}
 
+	var rateLimitStore struct {
+		RedisAddr string `json:"redisAddr,omitempty"`
+	}
+	if ctx.Config.Kind == configv1.InstallationFull {
+		// Redis is only deployed alongside the webapp components, so replicas can only
+		// share their rate limits when both live in the same cluster.
+		rateLimitStore.RedisAddr = redis.GetConfiguration(ctx).Address
+	}
+
 	var imageBuilderTLS struct {
 		CA          string `json:"ca"`
 		Certificate string `json:"crt"`
@@ -230,7 +240,10 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 				Certificate string `json:"crt"`
 				PrivateKey  string `json:"key"`
 			} `json:"tls"`
-			RateLimits map[string]grpc.RateLimit `json:"ratelimits"`
+			RateLimits     map[string]grpc.RateLimit `json:"ratelimits"`
+			RateLimitStore struct {
+				RedisAddr string `json:"redisAddr,omitempty"`
+			} `json:"ratelimitStore"`
 		}{
 			Addr: fmt.Sprintf(":%d", RPCPort),
 			TLS: struct {
@@ -242,7 +255,8 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 				Certificate: "/certs/tls.crt",
 				PrivateKey:  "/certs/tls.key",
 			},
-			RateLimits: rateLimits,
+			RateLimits:     rateLimits,
+			RateLimitStore: rateLimitStore,
 		},
 		ImageBuilderProxy: struct {
 			TargetAddr string "json:\"targetAddr\""
@@ -382,6 +382,22 @@ type OpenVSX struct {
 type OpenVSXProxy struct {
 	DisablePVC bool `json:"disablePVC"`
 	Proxy      `json:",inline"`
+
+	// Mirror keeps an allowlist of extensions, including their VSIX files, in the storage of the proxy
+	Mirror *OpenVSXMirror `json:"mirror,omitempty"`
+	// BlockedExtensions are publishers (e.g. "ms-python") or extensions (e.g. "ms-python.python") which are never served
+	BlockedExtensions []string `json:"blockedExtensions,omitempty"`
+}
+
+type OpenVSXMirror struct {
+	// Extensions are publisher.name for the latest version or publisher.name@version for a pinned version
+	Extensions []string `json:"extensions" validate:"required,dive,required"`
+	// RefreshInterval is how often the mirror is synced with the upstream. Defaults to 24h.
+	RefreshInterval *util.Duration `json:"refreshInterval,omitempty"`
+	// Offline serves mirrored extensions only and never calls the upstream, for air-gapped installations
+	Offline bool `json:"offline"`
+	// StorageSize is the size of the volume the extensions are stored in. Defaults to 8Gi.
+	StorageSize *resource.Quantity `json:"storageSize,omitempty"`
 }
 
 type Proxy struct {
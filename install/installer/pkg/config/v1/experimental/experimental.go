@@ -15,8 +15,11 @@ import (
 
 	agentSmith "github.com/gitpod-io/gitpod/agent-smith/pkg/config"
 	"github.com/gitpod-io/gitpod/common-go/grpc"
+	"github.com/gitpod-io/gitpod/common-go/util"
 	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/cgroup"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/cpulimit"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/netlimit"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -79,12 +82,18 @@ type WorkspaceConfig struct {
 		ReadBWPerSecond  resource.Quantity `json:"readBandwidthPerSecond"`
 		WriteIOPS        int64             `json:"writeIOPS"`
 		ReadIOPS         int64             `json:"readIOPS"`
+		// Saturation reduces the IO limits of all workspaces while a node's disks are saturated
+		Saturation cgroup.IOSaturationConfig `json:"saturation"`
 	} `json:"ioLimits"`
 	NetworkLimits struct {
 		Enabled              bool  `json:"enabled"`
 		Enforce              bool  `json:"enforce"`
 		ConnectionsPerMinute int64 `json:"connectionsPerMinute"`
 		BucketSize           int64 `json:"bucketSize"`
+		// EgressPolicies restrict the destinations workspaces can connect to, the first policy matching a workspace's team or project applies
+		EgressPolicies []netlimit.EgressPolicy `json:"egressPolicies,omitempty"`
+		// EgressBandwidth is the default egress bandwidth of workspaces in bytes per second, zero does not shape it
+		EgressBandwidth resource.Quantity `json:"egressBandwidth"`
 	} `json:"networkLimits"`
 	OOMScores struct {
 		Enabled bool `json:"enabled"`
@@ -135,6 +144,15 @@ type WorkspaceConfig struct {
 	EnableProtectedSecrets *bool `json:"enableProtectedSecrets"`
 	UseWsmanagerMk2        bool  `json:"useWsmanagerMk2,omitempty"`
 	UseMk2ExperimentalMode bool  `json:"useMk2ExperimentalMode,omitempty"`
+
+	Prebuilds *PrebuildsConfig `json:"prebuilds,omitempty"`
+}
+
+// PrebuildsConfig configures how ws-manager-mk2 schedules prebuilds. Without it, prebuilds are tracked but not limited.
+type PrebuildsConfig struct {
+	MaxConcurrentPerProject int           `json:"maxConcurrentPerProject,omitempty"`
+	CancelSuperseded        bool          `json:"cancelSuperseded,omitempty"`
+	Retention               util.Duration `json:"retention,omitempty"`
 }
 
 type PersistentVolumeClaim struct {
@@ -166,6 +184,10 @@ type WorkspaceLimits struct {
 	Memory           string             `json:"memory"`
 	Storage          string             `json:"storage"`
 	EphemeralStorage string             `json:"ephemeral-storage"`
+	// IO overrides the IO limits in workspace.ioLimits for workspaces of this class
+	IO *cgroup.IOLimits `json:"io,omitempty"`
+	// EgressBandwidth overrides workspace.networkLimits.egressBandwidth for workspaces of this class
+	EgressBandwidth *resource.Quantity `json:"egressBandwidth,omitempty"`
 }
 
 type WorkspaceCpuLimits struct {
@@ -359,6 +381,9 @@ type TracingSampleType string
 type Tracing struct {
 	SamplerType  *TracingSampleType `json:"samplerType,omitempty" validate:"omitempty,tracing_sampler_type"`
 	SamplerParam *float64           `json:"samplerParam,omitempty" validate:"required_with=SamplerType"`
+	// OTLPProtocol makes Go components export spans to the tracing endpoint using OpenTelemetry instead of Jaeger.
+	// Either "grpc" or "http/protobuf".
+	OTLPProtocol *string `json:"otlpProtocol,omitempty" validate:"omitempty,oneof=grpc http/protobuf"`
 }
 
 // Values taken from https://github.com/jaegertracing/jaeger-client-go/blob/967f9c36f0fa5a2617c9a0993b03f9a3279fadc8/config/config.go#L71
@@ -0,0 +1,322 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Operation describes what happens to an object
+type Operation string
+
+const (
+	OperationAdd    Operation = "add"
+	OperationRemove Operation = "remove"
+	OperationChange Operation = "change"
+)
+
+// Change is a single field which differs between two versions of an object
+type Change struct {
+	// Path is the path of the field, e.g. spec.template.spec.containers[name=server].image
+	Path string `json:"path"`
+	// Old is the previous value of the field, or nil if the field was added
+	Old interface{} `json:"old,omitempty"`
+	// New is the new value of the field, or nil if the field was removed
+	New interface{} `json:"new,omitempty"`
+}
+
+// ObjectDiff describes how a single object changes
+type ObjectDiff struct {
+	Key       ObjectKey `json:"object"`
+	Operation Operation `json:"operation"`
+	// Changes lists the changed fields of changed objects
+	Changes []Change `json:"changes,omitempty"`
+
+	Old *unstructured.Unstructured `json:"-"`
+	New *unstructured.Unstructured `json:"-"`
+}
+
+// CompareOptions configures the comparison of objects
+type CompareOptions struct {
+	// PruneOld removes fields from old objects which are not set in their new counterpart.
+	// Use this when old objects were read from a cluster.
+	PruneOld bool
+	// Ignore excludes objects from the comparison
+	Ignore []ObjectKey
+}
+
+// Compare produces a semantic diff between two sets of objects, ignoring generated fields.
+// Unchanged objects are not part of the result.
+func Compare(old, new []*unstructured.Unstructured, opts CompareOptions) ([]ObjectDiff, error) {
+	ignored := make(map[ObjectKey]struct{}, len(opts.Ignore))
+	for _, k := range opts.Ignore {
+		ignored[k] = struct{}{}
+	}
+
+	index := func(objs []*unstructured.Unstructured) (map[ObjectKey]*unstructured.Unstructured, error) {
+		res := make(map[ObjectKey]*unstructured.Unstructured, len(objs))
+		for _, obj := range objs {
+			key := KeyOf(obj)
+			if _, ok := ignored[key]; ok {
+				continue
+			}
+			n, err := Normalize(obj)
+			if err != nil {
+				return nil, fmt.Errorf("cannot normalize %s: %w", key, err)
+			}
+			res[key] = n
+		}
+		return res, nil
+	}
+	oldIdx, err := index(old)
+	if err != nil {
+		return nil, err
+	}
+	newIdx, err := index(new)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []ObjectDiff
+	for key, n := range newIdx {
+		o, ok := oldIdx[key]
+		if !ok {
+			res = append(res, ObjectDiff{Key: key, Operation: OperationAdd, New: n})
+			continue
+		}
+		if opts.PruneOld {
+			o = Prune(o, n)
+		}
+
+		// the API version is part of the object, but not of its key
+		changes := compareValues("", o.Object, n.Object, nil)
+		if len(changes) == 0 {
+			continue
+		}
+		res = append(res, ObjectDiff{Key: key, Operation: OperationChange, Changes: changes, Old: o, New: n})
+	}
+	for key, o := range oldIdx {
+		if _, ok := newIdx[key]; ok {
+			continue
+		}
+		res = append(res, ObjectDiff{Key: key, Operation: OperationRemove, Old: o})
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Key.String() < res[j].Key.String() })
+	return res, nil
+}
+
+func compareValues(path string, old, new interface{}, changes []Change) []Change {
+	switch n := new.(type) {
+	case map[string]interface{}:
+		o, ok := old.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(o)+len(n))
+		for k := range o {
+			keys = append(keys, k)
+		}
+		for k := range n {
+			if _, ok := o[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			changes = compareValues(joinPath(path, k), o[k], n[k], changes)
+		}
+		return changes
+	case []interface{}:
+		o, ok := old.([]interface{})
+		if !ok {
+			break
+		}
+		return compareLists(path, o, n, changes)
+	}
+
+	if reflect.DeepEqual(old, new) {
+		return changes
+	}
+	return append(changes, Change{Path: path, Old: old, New: new})
+}
+
+// compareLists compares lists of named elements (e.g. containers, env vars, ports) by name,
+// and all other lists by index.
+func compareLists(path string, old, new []interface{}, changes []Change) []Change {
+	oldNames, oldNamed := listNames(old)
+	newNames, newNamed := listNames(new)
+	if !oldNamed || !newNamed {
+		for i := 0; i < len(old) || i < len(new); i++ {
+			var o, n interface{}
+			if i < len(old) {
+				o = old[i]
+			}
+			if i < len(new) {
+				n = new[i]
+			}
+			changes = compareValues(fmt.Sprintf("%s[%d]", path, i), o, n, changes)
+		}
+		return changes
+	}
+
+	oldByName := make(map[string]interface{}, len(old))
+	for i, name := range oldNames {
+		oldByName[name] = old[i]
+	}
+	newByName := make(map[string]interface{}, len(new))
+	for i, name := range newNames {
+		newByName[name] = new[i]
+		changes = compareValues(fmt.Sprintf("%s[name=%s]", path, name), oldByName[name], new[i], changes)
+	}
+	for i, name := range oldNames {
+		if _, ok := newByName[name]; ok {
+			continue
+		}
+		changes = compareValues(fmt.Sprintf("%s[name=%s]", path, name), old[i], nil, changes)
+	}
+	return changes
+}
+
+// listNames returns the names of all list elements if all of them are maps with a unique name
+func listNames(l []interface{}) (names []string, ok bool) {
+	if len(l) == 0 {
+		return nil, true
+	}
+	names = make([]string, 0, len(l))
+	seen := make(map[string]struct{}, len(l))
+	for _, e := range l {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := m["name"].(string)
+		if !ok {
+			return nil, false
+		}
+		if _, exists := seen[name]; exists {
+			return nil, false
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	return names, true
+}
+
+func joinPath(path, field string) string {
+	if strings.ContainsAny(field, ".[]") {
+		field = strconv.Quote(field)
+	}
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// Format writes a human readable representation of the diff
+func Format(out io.Writer, diffs []ObjectDiff) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err != nil {
+			return
+		}
+		_, err = fmt.Fprintf(out, format, args...)
+	}
+
+	for _, d := range diffs {
+		switch d.Operation {
+		case OperationAdd:
+			printf("+ %s\n", d.Key)
+		case OperationRemove:
+			printf("- %s\n", d.Key)
+		case OperationChange:
+			printf("~ %s\n", d.Key)
+			for _, c := range d.Changes {
+				oldStr, oldIsStr := c.Old.(string)
+				newStr, newIsStr := c.New.(string)
+				if (oldIsStr || c.Old == nil) && (newIsStr || c.New == nil) && (strings.Contains(oldStr, "\n") || strings.Contains(newStr, "\n")) {
+					printf("    %s:\n", c.Path)
+					for _, l := range lineDiff(oldStr, newStr) {
+						printf("      %s\n", l)
+					}
+					continue
+				}
+				printf("    %s: %s -> %s\n", c.Path, formatValue(c.Old), formatValue(c.New))
+			}
+		}
+	}
+	return err
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "<none>"
+	}
+	fc, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(fc)
+}
+
+// lineDiff produces a minimal line-based diff of two strings, prefixing removed lines with "-" and added lines with "+".
+// Unchanged lines are omitted.
+func lineDiff(old, new string) []string {
+	var a, b []string
+	if old != "" {
+		a = strings.Split(old, "\n")
+	}
+	if new != "" {
+		b = strings.Split(new, "\n")
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var res []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			res = append(res, "-"+a[i])
+			i++
+		default:
+			res = append(res, "+"+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		res = append(res, "-"+a[i])
+	}
+	for ; j < len(b); j++ {
+		res = append(res, "+"+b[j])
+	}
+	return res
+}
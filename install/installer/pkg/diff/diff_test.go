@@ -0,0 +1,225 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const previousRender = `---
+# v1/ConfigMap server
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: server
+  namespace: default
+  creationTimestamp: null
+data:
+  config.json: |
+    {
+      "foo": 1,
+      "bar": 2
+    }
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: server
+  namespace: default
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+        - name: server
+          image: server:v1
+          args: ["--foo"]
+        - name: kube-rbac-proxy
+          image: proxy:v1
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: removed
+  namespace: default
+`
+
+const currentRender = `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: server
+  namespace: default
+data:
+  config.json: |
+    {
+      "foo": 1,
+      "bar": 3
+    }
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: server
+  namespace: default
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: kube-rbac-proxy
+          image: proxy:v1
+        - name: server
+          image: server:v2
+          args: ["--foo", "--bar"]
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: added
+  namespace: default
+`
+
+func mustParse(t *testing.T, manifests ...string) []*unstructured.Unstructured {
+	t.Helper()
+
+	objs, err := Parse(manifests...)
+	require.NoError(t, err)
+	return objs
+}
+
+func TestCompare(t *testing.T) {
+	diffs, err := Compare(mustParse(t, previousRender), mustParse(t, currentRender), CompareOptions{})
+	require.NoError(t, err)
+
+	type result struct {
+		Key       string
+		Operation Operation
+		Changes   []Change
+	}
+	var act []result
+	for _, d := range diffs {
+		act = append(act, result{Key: d.Key.String(), Operation: d.Operation, Changes: d.Changes})
+	}
+
+	expectation := []result{
+		{
+			Key:       "ConfigMap default/server",
+			Operation: OperationChange,
+			Changes: []Change{
+				{Path: `data."config.json"`, Old: "{\n  \"foo\": 1,\n  \"bar\": 2\n}\n", New: "{\n  \"foo\": 1,\n  \"bar\": 3\n}\n"},
+			},
+		},
+		{
+			Key:       "Deployment.apps default/server",
+			Operation: OperationChange,
+			Changes: []Change{
+				{Path: "spec.replicas", Old: float64(1), New: float64(2)},
+				{Path: "spec.template.spec.containers[name=server].args[1]", New: "--bar"},
+				{Path: "spec.template.spec.containers[name=server].image", Old: "server:v1", New: "server:v2"},
+			},
+		},
+		{Key: "Service default/added", Operation: OperationAdd},
+		{Key: "Service default/removed", Operation: OperationRemove},
+	}
+	if diff := cmp.Diff(expectation, act); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestCompare_Live(t *testing.T) {
+	live := mustParse(t, `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: server
+  namespace: default
+  uid: 7d2b8c3e
+  resourceVersion: "1234"
+  generation: 3
+  annotations:
+    deployment.kubernetes.io/revision: "3"
+spec:
+  replicas: 1
+  progressDeadlineSeconds: 600
+  template:
+    spec:
+      containers:
+        - name: server
+          image: server:v1
+          imagePullPolicy: IfNotPresent
+status:
+  replicas: 1
+`)
+	desired := mustParse(t, `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: server
+  namespace: default
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+        - name: server
+          image: server:v1
+`)
+
+	diffs, err := Compare(live, desired, CompareOptions{})
+	require.NoError(t, err)
+	require.Len(t, diffs, 1, "defaulted fields differ without pruning")
+
+	diffs, err = Compare(live, desired, CompareOptions{PruneOld: true})
+	require.NoError(t, err)
+	require.Empty(t, diffs, "generated and defaulted fields must be ignored")
+}
+
+func TestCompare_Ignore(t *testing.T) {
+	diffs, err := Compare(mustParse(t, previousRender), mustParse(t, currentRender), CompareOptions{
+		Ignore: []ObjectKey{{Kind: "ConfigMap", Namespace: "default", Name: "server"}},
+	})
+	require.NoError(t, err)
+	for _, d := range diffs {
+		require.NotEqual(t, "ConfigMap", d.Key.Kind)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	diffs, err := Compare(mustParse(t, previousRender), mustParse(t, currentRender), CompareOptions{})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, Format(&out, diffs))
+
+	expectation := `~ ConfigMap default/server
+    data."config.json":
+      -  "bar": 2
+      +  "bar": 3
+~ Deployment.apps default/server
+    spec.replicas: 1 -> 2
+    spec.template.spec.containers[name=server].args[1]: <none> -> "--bar"
+    spec.template.spec.containers[name=server].image: "server:v1" -> "server:v2"
++ Service default/added
+- Service default/removed
+`
+	if diff := cmp.Diff(expectation, out.String()); diff != "" {
+		t.Errorf("unexpected output (-want +got):\n%s", diff)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse("---\napiVersion: v1\nkind: ConfigMap\n")
+	require.Error(t, err)
+
+	objs, err := Parse("---\n# just a comment\n---\n")
+	require.NoError(t, err)
+	require.Empty(t, objs)
+}
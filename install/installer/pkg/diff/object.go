@@ -0,0 +1,214 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// ObjectKey identifies an object independently of its API version, so that objects
+// which move between API versions (e.g. policy/v1beta1 to policy/v1) are still compared.
+type ObjectKey struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (k ObjectKey) String() string {
+	kind := k.Kind
+	if k.Group != "" {
+		kind += "." + k.Group
+	}
+	if k.Namespace == "" {
+		return fmt.Sprintf("%s %s", kind, k.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", kind, k.Namespace, k.Name)
+}
+
+// KeyOf returns the key of an object
+func KeyOf(obj *unstructured.Unstructured) ObjectKey {
+	gvk := obj.GroupVersionKind()
+	return ObjectKey{
+		Group:     gvk.Group,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
+
+// GroupKind returns the group and kind of the object the key identifies
+func (k ObjectKey) GroupKind() schema.GroupKind {
+	return schema.GroupKind{Group: k.Group, Kind: k.Kind}
+}
+
+var documentSeparator = regexp.MustCompile("(?m)^---")
+
+// Parse parses multi-document YAML, e.g. the output of `gitpod-installer render`
+func Parse(manifests ...string) ([]*unstructured.Unstructured, error) {
+	var res []*unstructured.Unstructured
+	for _, mf := range manifests {
+		for _, doc := range documentSeparator.Split(mf, -1) {
+			if strings.TrimSpace(doc) == "" {
+				continue
+			}
+
+			var content map[string]interface{}
+			err := yaml.Unmarshal([]byte(doc), &content)
+			if err != nil {
+				return nil, err
+			}
+			if len(content) == 0 {
+				// documents which consist of comments only
+				continue
+			}
+
+			obj := &unstructured.Unstructured{Object: content}
+			if obj.GetKind() == "" || obj.GetName() == "" {
+				return nil, fmt.Errorf("object without kind or name: %s", strings.TrimSpace(doc))
+			}
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// Load reads objects from a YAML file or a directory of YAML files,
+// e.g. as produced by `gitpod-installer render --output-split-files`.
+func Load(fn string) ([]*unstructured.Unstructured, error) {
+	stat, err := os.Stat(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{fn}
+	if stat.IsDir() {
+		files = nil
+		entries, err := os.ReadDir(fn)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			if ext := filepath.Ext(e.Name()); ext != ".yaml" && ext != ".yml" {
+				continue
+			}
+			files = append(files, filepath.Join(fn, e.Name()))
+		}
+		sort.Strings(files)
+	}
+
+	manifests := make([]string, 0, len(files))
+	for _, f := range files {
+		content, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, string(content))
+	}
+	objs, err := Parse(manifests...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", fn, err)
+	}
+	return objs, nil
+}
+
+// generatedMetadata are metadata fields which are set by the API server
+var generatedMetadata = []string{
+	"creationTimestamp",
+	"deletionGracePeriodSeconds",
+	"deletionTimestamp",
+	"generation",
+	"managedFields",
+	"resourceVersion",
+	"selfLink",
+	"uid",
+}
+
+// generatedAnnotations are annotations which are set by controllers or kubectl
+var generatedAnnotations = []string{
+	"deployment.kubernetes.io/revision",
+	"kubectl.kubernetes.io/last-applied-configuration",
+}
+
+// Normalize returns a copy of the object without fields that are generated by the API server or controllers.
+// Numbers are normalized to float64 so that objects read from a cluster compare equal to rendered objects.
+func Normalize(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	// a JSON round-trip deep-copies the object and normalizes all numbers
+	fc, err := json.Marshal(obj.Object)
+	if err != nil {
+		return nil, err
+	}
+	var content map[string]interface{}
+	err = json.Unmarshal(fc, &content)
+	if err != nil {
+		return nil, err
+	}
+	res := &unstructured.Unstructured{Object: content}
+
+	delete(res.Object, "status")
+	for _, f := range generatedMetadata {
+		unstructured.RemoveNestedField(res.Object, "metadata", f)
+	}
+	for _, a := range generatedAnnotations {
+		unstructured.RemoveNestedField(res.Object, "metadata", "annotations", a)
+	}
+	for _, f := range []string{"annotations", "labels"} {
+		if m, _, _ := unstructured.NestedMap(res.Object, "metadata", f); len(m) == 0 {
+			unstructured.RemoveNestedField(res.Object, "metadata", f)
+		}
+	}
+
+	return res, nil
+}
+
+// Prune removes all fields from live which are not set in desired. This hides fields defaulted by the API
+// server when comparing rendered objects against a cluster. Lists are pruned element-wise if they have the same length.
+func Prune(live, desired *unstructured.Unstructured) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: prune(live.Object, desired.Object).(map[string]interface{})}
+}
+
+func prune(live, desired interface{}) interface{} {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		res := make(map[string]interface{}, len(d))
+		for k, v := range l {
+			dv, ok := d[k]
+			if !ok {
+				continue
+			}
+			res[k] = prune(v, dv)
+		}
+		return res
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			return live
+		}
+		res := make([]interface{}, len(l))
+		for i := range l {
+			res[i] = prune(l[i], d[i])
+		}
+		return res
+	default:
+		return live
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/gitpod-io/gitpod/installer/pkg/config/versions"
+)
+
+// Severity describes how disruptive a change is
+type Severity string
+
+const (
+	// SeverityDestructive changes cannot be applied without losing data, or require objects to be deleted and recreated
+	SeverityDestructive Severity = "destructive"
+	// SeverityWarning changes need attention, but can be applied
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is a change which needs attention during an upgrade
+type Finding struct {
+	Object   ObjectKey `json:"object"`
+	Severity Severity  `json:"severity"`
+	Path     string    `json:"path,omitempty"`
+	Message  string    `json:"message"`
+}
+
+// VersionChange is a component whose version changes during an upgrade
+type VersionChange struct {
+	Component string `json:"component"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+}
+
+// Plan summarises the effect of an upgrade
+type Plan struct {
+	Versions []VersionChange `json:"versions,omitempty"`
+	Added    int             `json:"added"`
+	Removed  int             `json:"removed"`
+	Changed  int             `json:"changed"`
+	Findings []Finding       `json:"findings"`
+}
+
+// Destructive returns true if the plan contains destructive changes
+func (p *Plan) Destructive() bool {
+	for _, f := range p.Findings {
+		if f.Severity == SeverityDestructive {
+			return true
+		}
+	}
+	return false
+}
+
+// NewPlan analyses a diff for changes which need attention during an upgrade
+func NewPlan(diffs []ObjectDiff) *Plan {
+	res := &Plan{Findings: []Finding{}}
+	for _, d := range diffs {
+		switch d.Operation {
+		case OperationAdd:
+			res.Added++
+		case OperationRemove:
+			res.Removed++
+		case OperationChange:
+			res.Changed++
+		}
+
+		for _, check := range planChecks {
+			res.Findings = append(res.Findings, check(d)...)
+		}
+	}
+	return res
+}
+
+type planCheck func(d ObjectDiff) []Finding
+
+var planChecks = []planCheck{
+	checkImmutableFields,
+	checkRemovedObjects,
+	checkCRDSchema,
+}
+
+var (
+	gkNamespace          = schema.GroupKind{Kind: "Namespace"}
+	gkConfigMap          = schema.GroupKind{Kind: "ConfigMap"}
+	gkSecret             = schema.GroupKind{Kind: "Secret"}
+	gkService            = schema.GroupKind{Kind: "Service"}
+	gkPVC                = schema.GroupKind{Kind: "PersistentVolumeClaim"}
+	gkDeployment         = schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	gkDaemonSet          = schema.GroupKind{Group: "apps", Kind: "DaemonSet"}
+	gkStatefulSet        = schema.GroupKind{Group: "apps", Kind: "StatefulSet"}
+	gkJob                = schema.GroupKind{Group: "batch", Kind: "Job"}
+	gkRoleBinding        = schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"}
+	gkClusterRoleBinding = schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}
+	gkStorageClass       = schema.GroupKind{Group: "storage.k8s.io", Kind: "StorageClass"}
+	gkCRD                = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+)
+
+// immutableFields lists the fields the API server refuses to update. Changing them requires
+// the object to be deleted and recreated.
+var immutableFields = map[schema.GroupKind][]string{
+	gkDeployment:         {"spec.selector"},
+	gkDaemonSet:          {"spec.selector"},
+	gkStatefulSet:        {"spec.selector", "spec.serviceName", "spec.podManagementPolicy", "spec.volumeClaimTemplates"},
+	gkJob:                {"spec.selector", "spec.template", "spec.completionMode"},
+	gkService:            {"spec.clusterIP"},
+	gkPVC:                {"spec.storageClassName", "spec.accessModes", "spec.volumeName", "spec.volumeMode", "spec.selector"},
+	gkRoleBinding:        {"roleRef"},
+	gkClusterRoleBinding: {"roleRef"},
+	gkStorageClass:       {"provisioner", "parameters", "reclaimPolicy", "volumeBindingMode"},
+	gkCRD:                {"spec.group", "spec.scope"},
+}
+
+func checkImmutableFields(d ObjectDiff) []Finding {
+	if d.Operation != OperationChange {
+		return nil
+	}
+
+	fields := immutableFields[d.Key.GroupKind()]
+	if gk := d.Key.GroupKind(); gk == gkConfigMap || gk == gkSecret {
+		if immutable, _, _ := unstructured.NestedBool(d.Old.Object, "immutable"); immutable {
+			fields = []string{"data", "binaryData", "stringData"}
+		}
+	}
+
+	severity := SeverityDestructive
+	if d.Key.GroupKind() == gkJob {
+		// the installer's jobs are expected to be replaced on every upgrade
+		severity = SeverityWarning
+	}
+
+	var res []Finding
+	for _, c := range d.Changes {
+		for _, f := range fields {
+			if c.Path != f && !strings.HasPrefix(c.Path, f+".") && !strings.HasPrefix(c.Path, f+"[") {
+				continue
+			}
+			res = append(res, Finding{
+				Object:   d.Key,
+				Severity: severity,
+				Path:     c.Path,
+				Message:  fmt.Sprintf("%s is immutable - the %s must be deleted and recreated", f, d.Key.Kind),
+			})
+			break
+		}
+	}
+	return res
+}
+
+func checkRemovedObjects(d ObjectDiff) []Finding {
+	if d.Operation != OperationRemove {
+		return nil
+	}
+
+	switch d.Key.GroupKind() {
+	case gkPVC:
+		return []Finding{{Object: d.Key, Severity: SeverityDestructive, Message: "the persistent volume claim is deleted - its data may be lost"}}
+	case gkNamespace:
+		return []Finding{{Object: d.Key, Severity: SeverityDestructive, Message: "the namespace is deleted together with all objects in it"}}
+	case gkCRD:
+		return []Finding{{Object: d.Key, Severity: SeverityDestructive, Message: "the custom resource definition is deleted together with all its custom resources"}}
+	case gkStatefulSet:
+		if tpls, _, _ := unstructured.NestedSlice(d.Old.Object, "spec", "volumeClaimTemplates"); len(tpls) > 0 {
+			return []Finding{{Object: d.Key, Severity: SeverityWarning, Message: "the stateful set is deleted - persistent volume claims created from its volumeClaimTemplates are kept and must be cleaned up manually"}}
+		}
+	}
+	return nil
+}
+
+func checkCRDSchema(d ObjectDiff) []Finding {
+	if d.Operation != OperationChange || d.Key.GroupKind() != gkCRD {
+		return nil
+	}
+
+	type crdVersion struct {
+		Served  bool
+		Storage bool
+		Schema  interface{}
+	}
+	crdVersions := func(obj *unstructured.Unstructured) map[string]crdVersion {
+		vs, _, _ := unstructured.NestedSlice(obj.Object, "spec", "versions")
+		res := make(map[string]crdVersion, len(vs))
+		for _, v := range vs {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := m["name"].(string)
+			served, _ := m["served"].(bool)
+			storage, _ := m["storage"].(bool)
+			res[name] = crdVersion{Served: served, Storage: storage, Schema: m["schema"]}
+		}
+		return res
+	}
+	oldVersions, newVersions := crdVersions(d.Old), crdVersions(d.New)
+
+	names := make([]string, 0, len(oldVersions))
+	for name := range oldVersions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var res []Finding
+	for _, name := range names {
+		o := oldVersions[name]
+		n, ok := newVersions[name]
+		path := fmt.Sprintf("spec.versions[name=%s]", name)
+		switch {
+		case !ok && o.Storage:
+			res = append(res, Finding{Object: d.Key, Severity: SeverityDestructive, Path: path, Message: fmt.Sprintf("stored version %s is removed - existing custom resources must be migrated first", name)})
+		case !ok || (o.Served && !n.Served):
+			res = append(res, Finding{Object: d.Key, Severity: SeverityDestructive, Path: path, Message: fmt.Sprintf("version %s is no longer served - clients using it will fail", name)})
+		case len(compareValues("", o.Schema, n.Schema, nil)) > 0:
+			res = append(res, Finding{Object: d.Key, Severity: SeverityWarning, Path: path + ".schema", Message: fmt.Sprintf("the schema of version %s changes - existing custom resources may no longer validate", name)})
+		}
+	}
+	return res
+}
+
+// CompareVersions lists all components whose version differs between two version manifests
+func CompareVersions(old, new *versions.Manifest) ([]VersionChange, error) {
+	flatten := func(mf *versions.Manifest) (map[string]string, error) {
+		res := make(map[string]string)
+		if mf == nil {
+			return res, nil
+		}
+		fc, err := json.Marshal(mf.Components)
+		if err != nil {
+			return nil, err
+		}
+		var content map[string]interface{}
+		err = json.Unmarshal(fc, &content)
+		if err != nil {
+			return nil, err
+		}
+		flattenVersions("", content, res)
+		return res, nil
+	}
+
+	oldVersions, err := flatten(old)
+	if err != nil {
+		return nil, err
+	}
+	newVersions, err := flatten(new)
+	if err != nil {
+		return nil, err
+	}
+
+	components := make(map[string]struct{}, len(newVersions))
+	for c := range oldVersions {
+		components[c] = struct{}{}
+	}
+	for c := range newVersions {
+		components[c] = struct{}{}
+	}
+
+	var res []VersionChange
+	for c := range components {
+		o, n := oldVersions[c], newVersions[c]
+		if o == n {
+			continue
+		}
+		res = append(res, VersionChange{Component: c, Old: o, New: n})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Component < res[j].Component })
+	return res, nil
+}
+
+func flattenVersions(prefix string, content map[string]interface{}, res map[string]string) {
+	for k, v := range content {
+		switch v := v.(type) {
+		case string:
+			if k != "version" || v == "" {
+				continue
+			}
+			res[strings.TrimPrefix(prefix, ".")] = v
+		case map[string]interface{}:
+			flattenVersions(prefix+"."+k, v, res)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitpod-io/gitpod/installer/pkg/config/versions"
+)
+
+func TestNewPlan(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Old         string
+		New         string
+		Expectation []Finding
+	}{
+		{
+			Name:        "no changes",
+			Old:         "apiVersion: v1\nkind: Service\nmetadata:\n  name: foo\n",
+			New:         "apiVersion: v1\nkind: Service\nmetadata:\n  name: foo\n",
+			Expectation: []Finding{},
+		},
+		{
+			Name: "selector change",
+			Old:  "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: foo\nspec:\n  replicas: 1\n  selector:\n    matchLabels:\n      app: foo\n",
+			New:  "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: foo\nspec:\n  replicas: 2\n  selector:\n    matchLabels:\n      app: bar\n",
+			Expectation: []Finding{
+				{
+					Object:   ObjectKey{Group: "apps", Kind: "Deployment", Name: "foo"},
+					Severity: SeverityDestructive,
+					Path:     "spec.selector.matchLabels.app",
+					Message:  "spec.selector is immutable - the Deployment must be deleted and recreated",
+				},
+			},
+		},
+		{
+			Name: "job template change",
+			Old:  "apiVersion: batch/v1\nkind: Job\nmetadata:\n  name: migrations\nspec:\n  template:\n    spec:\n      containers:\n        - name: migrations\n          image: db:v1\n",
+			New:  "apiVersion: batch/v1\nkind: Job\nmetadata:\n  name: migrations\nspec:\n  template:\n    spec:\n      containers:\n        - name: migrations\n          image: db:v2\n",
+			Expectation: []Finding{
+				{
+					Object:   ObjectKey{Group: "batch", Kind: "Job", Name: "migrations"},
+					Severity: SeverityWarning,
+					Path:     "spec.template.spec.containers[name=migrations].image",
+					Message:  "spec.template is immutable - the Job must be deleted and recreated",
+				},
+			},
+		},
+		{
+			Name: "immutable config map",
+			Old:  "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: foo\nimmutable: true\ndata:\n  foo: bar\n",
+			New:  "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: foo\nimmutable: true\ndata:\n  foo: baz\n",
+			Expectation: []Finding{
+				{
+					Object:   ObjectKey{Kind: "ConfigMap", Name: "foo"},
+					Severity: SeverityDestructive,
+					Path:     "data.foo",
+					Message:  "data is immutable - the ConfigMap must be deleted and recreated",
+				},
+			},
+		},
+		{
+			Name: "deleted pvc",
+			Old:  "apiVersion: v1\nkind: PersistentVolumeClaim\nmetadata:\n  name: data\n  namespace: default\n",
+			New:  "apiVersion: v1\nkind: Service\nmetadata:\n  name: foo\n",
+			Expectation: []Finding{
+				{
+					Object:   ObjectKey{Kind: "PersistentVolumeClaim", Namespace: "default", Name: "data"},
+					Severity: SeverityDestructive,
+					Message:  "the persistent volume claim is deleted - its data may be lost",
+				},
+			},
+		},
+		{
+			Name: "crd schema change",
+			Old: `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: workspaces.workspace.gitpod.io
+spec:
+  group: workspace.gitpod.io
+  versions:
+    - name: v1alpha1
+      served: true
+      storage: false
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+`,
+			New: `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: workspaces.workspace.gitpod.io
+spec:
+  group: workspace.gitpod.io
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+          required: ["spec"]
+`,
+			Expectation: []Finding{
+				{
+					Object:   ObjectKey{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition", Name: "workspaces.workspace.gitpod.io"},
+					Severity: SeverityWarning,
+					Path:     "spec.versions[name=v1].schema",
+					Message:  "the schema of version v1 changes - existing custom resources may no longer validate",
+				},
+				{
+					Object:   ObjectKey{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition", Name: "workspaces.workspace.gitpod.io"},
+					Severity: SeverityDestructive,
+					Path:     "spec.versions[name=v1alpha1]",
+					Message:  "version v1alpha1 is no longer served - clients using it will fail",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			diffs, err := Compare(mustParse(t, test.Old), mustParse(t, test.New), CompareOptions{})
+			require.NoError(t, err)
+
+			plan := NewPlan(diffs)
+			if diff := cmp.Diff(test.Expectation, plan.Findings); diff != "" {
+				t.Errorf("unexpected findings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	var old, new versions.Manifest
+	old.Components.Server.Version = "commit-a"
+	old.Components.Dashboard.Version = "commit-a"
+	old.Components.WSDaemon.Version = "commit-a"
+	new.Components.Server.Version = "commit-b"
+	new.Components.Dashboard.Version = "commit-a"
+	new.Components.WSDaemon.Version = "commit-a"
+	new.Components.WSDaemon.UserNamespaces.SeccompProfileInstaller.Version = "commit-b"
+
+	act, err := CompareVersions(&old, &new)
+	require.NoError(t, err)
+
+	expectation := []VersionChange{
+		{Component: "server", Old: "commit-a", New: "commit-b"},
+		{Component: "wsDaemon.userNamespaces.seccompProfileInstaller", New: "commit-b"},
+	}
+	if diff := cmp.Diff(expectation, act); diff != "" {
+		t.Errorf("unexpected version changes (-want +got):\n%s", diff)
+	}
+}